@@ -15,3 +15,12 @@ app.kubernetes.io/name: openservicemesh.io
 app.kubernetes.io/instance: {{ .Values.OpenServiceMesh.meshName }}
 app.kubernetes.io/version: {{ .Chart.AppVersion }}
 {{- end -}}
+
+{{/* Comma separated list of <arch>=<image> pairs from a map of architectures to images */}}
+{{- define "osm.archImages" -}}
+{{- $pairs := list -}}
+{{- range $arch, $image := . -}}
+{{- $pairs = append $pairs (printf "%s=%s" $arch $image) -}}
+{{- end -}}
+{{ join "," $pairs }}
+{{- end -}}
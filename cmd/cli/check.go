@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/diagnostics"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+)
+
+const checkDescription = `
+This command will check the health of the control plane of a mesh, by reporting
+the errors of the control plane summarized in the MeshDiagnostic resource of
+the mesh.
+
+Each error is identified by an error code, and is reported as recurring when it
+occurred repeatedly in the last minutes. The command fails if any error of the
+control plane is recurring.
+
+With --pre-install, the command instead checks that the cluster is ready for the
+installation of a mesh: the version of Kubernetes, the availability of the mesh
+name and OSM namespace, and the versions of the CRDs already installed.
+
+With --post-install, the command instead checks the health of an installed
+mesh: its control plane pods and version, the versions of the installed CRDs,
+the reachability of the sidecar injection webhook and of the xDS server, the
+validity of the certificates and of the mesh configuration, and the recurring
+errors of the control plane. The reachability of the xDS server is checked from
+a short-lived pod created in the OSM namespace.
+
+The post-install checks also validate a sample of the workload certificates
+issued by osm-controller against the active CA of the mesh: their chain, the
+format of their SAN and their expiry. Mismatches typically occur after a
+partial CA rotation or a change of certificate provider. The certificates are
+listed through the debug server of osm-controller, which must be enabled with
+the 'enable_debug_server' setting. The size of the sample can be set with
+--cert-sample-size.
+
+The results of the pre-install and post-install checks can be printed as JSON
+with --output json for use in CI. The command fails if any check fails.
+`
+
+const checkExample = `
+# Check the control plane of the mesh named 'osm'
+osm check
+
+# Check the control plane of the mesh named 'hello-osm'
+osm check --mesh-name hello-osm
+
+# Check that the cluster is ready for the installation of a mesh
+osm check --pre-install
+
+# Check the health of an installed mesh, with machine-readable output
+osm check --post-install --output json
+`
+
+type checkCmd struct {
+	out            io.Writer
+	kubeClient     kubernetes.Interface
+	configClient   configClientset.Interface
+	meshName       string
+	osmNamespace   string
+	preInstall     bool
+	postInstall    bool
+	output         string
+	testPodImage   string
+	timeout        time.Duration
+	certSampleSize int
+}
+
+func newCheckCmd(out io.Writer) *cobra.Command {
+	check := &checkCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "check the health of the control plane",
+		Long:  checkDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := check.validateOptions(); err != nil {
+				return err
+			}
+
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			kubeClient, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			configClient, err := configClientset.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			check.kubeClient = kubeClient
+			check.configClient = configClient
+			check.osmNamespace = settings.Namespace()
+			return check.run()
+		},
+		Example: checkExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&check.meshName, "mesh-name", settings.MeshName(), "Name of the mesh to check")
+	f.BoolVar(&check.preInstall, "pre-install", false, "Check that the cluster is ready for the installation of the mesh")
+	f.BoolVar(&check.postInstall, "post-install", false, "Check the health of the installed mesh")
+	f.StringVarP(&check.output, "output", "o", checkOutputTable, fmt.Sprintf("Output format of the pre-install and post-install checks, one of: %v", checkOutputFormats))
+	f.StringVar(&check.testPodImage, "test-pod-image", defaultTestPodImage, "Image of the pod used to check the reachability of the xDS server, must provide the nc command")
+	f.DurationVar(&check.timeout, "timeout", time.Minute, "Time to wait for the pod used to check the reachability of the xDS server to complete")
+	f.IntVar(&check.certSampleSize, "cert-sample-size", defaultCertSampleSize, "Number of workload certificates validated against the active CA of the mesh")
+
+	return cmd
+}
+
+func (c *checkCmd) validateOptions() error {
+	if c.preInstall && c.postInstall {
+		return errors.New("--pre-install and --post-install cannot be used together")
+	}
+	if c.postInstall && c.certSampleSize <= 0 {
+		return errors.Errorf("Invalid certificate sample size %d, must be greater than 0", c.certSampleSize)
+	}
+	for _, format := range checkOutputFormats {
+		if c.output == format {
+			return nil
+		}
+	}
+	return errors.Errorf("Invalid output format %q, must be one of: %v", c.output, checkOutputFormats)
+}
+
+func (c *checkCmd) run() error {
+	if c.preInstall || c.postInstall {
+		return c.runHealthChecks()
+	}
+
+	diagnostic, err := c.configClient.ConfigV1alpha1().MeshDiagnostics().Get(context.TODO(), c.meshName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return errors.Errorf("No diagnostics found for mesh %s, ensure the mesh is installed and its control plane is running", c.meshName)
+	}
+	if err != nil {
+		return errors.Errorf("Error fetching diagnostics of mesh %s: %s", c.meshName, err)
+	}
+
+	w := newTabWriter(c.out)
+	fmt.Fprintln(w, "CODE\tCONDITION\tRECURRING\tCOUNT\tLAST OCCURRENCE\tMESSAGE")
+	var recurring int
+	for _, condition := range diagnostic.Status.Conditions {
+		if diagnostics.IsRecurring(condition) {
+			recurring++
+		}
+		lastOccurrence := "-"
+		if condition.LastOccurrenceTime != nil {
+			lastOccurrence = condition.LastOccurrenceTime.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", condition.Code, condition.Type, condition.Status, condition.Count, lastOccurrence, condition.Message)
+	}
+	_ = w.Flush()
+
+	if recurring > 0 {
+		return errors.Errorf("Found %d recurring error(s) in the control plane of mesh %s", recurring, c.meshName)
+	}
+	fmt.Fprintf(c.out, "\nNo recurring errors found in the control plane of mesh %s\n", c.meshName)
+	return nil
+}
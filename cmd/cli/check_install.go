@@ -0,0 +1,574 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilversion "k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/diagnostics"
+	"github.com/openservicemesh/osm/pkg/injector"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+const (
+	// minKubernetesVersion is the minimum version of Kubernetes supported by OSM
+	minKubernetesVersion = "1.18.0"
+
+	// certExpiryWarningPeriod is the period before the expiration of a certificate from which the certificate check warns
+	certExpiryWarningPeriod = 30 * 24 * time.Hour
+
+	// defaultTestPodImage is the default image of the pod used to check the reachability of the xDS server
+	defaultTestPodImage = "busybox:1.33"
+
+	checkOutputTable = "table"
+	checkOutputJSON  = "json"
+)
+
+var checkOutputFormats = []string{checkOutputTable, checkOutputJSON}
+
+type checkStatus string
+
+const (
+	checkPassed  checkStatus = "pass"
+	checkWarning checkStatus = "warn"
+	checkFailed  checkStatus = "fail"
+)
+
+// checkResult is the result of a health check
+type checkResult struct {
+	Name    string      `json:"name"`
+	Status  checkStatus `json:"status"`
+	Message string      `json:"message"`
+}
+
+// checkReport is the machine-readable report of the health checks of a mesh
+type checkReport struct {
+	Mesh      string        `json:"mesh"`
+	Namespace string        `json:"namespace"`
+	Phase     string        `json:"phase"`
+	Passed    bool          `json:"passed"`
+	Checks    []checkResult `json:"checks"`
+}
+
+func passed(name, msgFormat string, args ...interface{}) checkResult {
+	return checkResult{Name: name, Status: checkPassed, Message: fmt.Sprintf(msgFormat, args...)}
+}
+
+func warning(name, msgFormat string, args ...interface{}) checkResult {
+	return checkResult{Name: name, Status: checkWarning, Message: fmt.Sprintf(msgFormat, args...)}
+}
+
+func failed(name, msgFormat string, args ...interface{}) checkResult {
+	return checkResult{Name: name, Status: checkFailed, Message: fmt.Sprintf(msgFormat, args...)}
+}
+
+// runHealthChecks runs the pre-install or post-install health checks and reports their results
+func (c *checkCmd) runHealthChecks() error {
+	report := checkReport{
+		Mesh:      c.meshName,
+		Namespace: c.osmNamespace,
+		Passed:    true,
+	}
+
+	var checks []func() checkResult
+	if c.preInstall {
+		report.Phase = "pre-install"
+		checks = []func() checkResult{
+			c.checkKubernetesVersion,
+			c.checkMeshNameAvailable,
+			c.checkNamespaceAvailable,
+			c.checkInstalledCRDVersions,
+		}
+	} else {
+		report.Phase = "post-install"
+		checks = []func() checkResult{
+			c.checkComponentRunning(constants.OSMControllerName),
+			c.checkComponentRunning(constants.OSMInjectorName),
+			c.checkControllerVersion,
+			c.checkControllerCRDVersions,
+			c.checkWebhookReachable,
+			c.checkCertificates,
+			c.checkWorkloadCertificates,
+			c.checkXDSReachable,
+			c.checkMeshConfig,
+			c.checkDiagnostics,
+		}
+	}
+
+	for _, check := range checks {
+		result := check()
+		if result.Status == checkFailed {
+			report.Passed = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	if err := c.printReport(report); err != nil {
+		return err
+	}
+	if !report.Passed {
+		return errors.Errorf("%s checks failed for mesh %s", report.Phase, c.meshName)
+	}
+	return nil
+}
+
+func (c *checkCmd) printReport(report checkReport) error {
+	if c.output == checkOutputJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Errorf("Error marshaling check report: %s", err)
+		}
+		fmt.Fprintln(c.out, string(out))
+		return nil
+	}
+
+	w := newTabWriter(c.out)
+	fmt.Fprintln(w, "CHECK\tSTATUS\tMESSAGE")
+	for _, result := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Name, result.Status, result.Message)
+	}
+	return w.Flush()
+}
+
+func (c *checkCmd) checkKubernetesVersion() checkResult {
+	const name = "Kubernetes version"
+
+	info, err := c.kubeClient.Discovery().ServerVersion()
+	if err != nil {
+		return failed(name, "Error fetching the version of the Kubernetes API server: %s", err)
+	}
+	serverVersion, err := utilversion.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return failed(name, "Error parsing the version %q of the Kubernetes API server: %s", info.GitVersion, err)
+	}
+	if serverVersion.LessThan(utilversion.MustParseGeneric(minKubernetesVersion)) {
+		return failed(name, "Kubernetes %s is not supported, OSM requires Kubernetes %s or greater", info.GitVersion, minKubernetesVersion)
+	}
+	return passed(name, "Kubernetes %s is supported", info.GitVersion)
+}
+
+func (c *checkCmd) checkMeshNameAvailable() checkResult {
+	const name = "Mesh name"
+
+	if err := isValidMeshName(c.meshName); err != nil {
+		return failed(name, "Mesh name %q is invalid", c.meshName)
+	}
+
+	deployments, err := getControllerDeployments(c.kubeClient)
+	if err != nil {
+		return failed(name, "Error listing %s deployments: %s", constants.OSMControllerName, err)
+	}
+	for _, deployment := range deployments.Items {
+		meshName := deployment.Labels["meshName"]
+		if meshName == c.meshName {
+			return failed(name, "Mesh %s already exists in namespace %s", c.meshName, deployment.Namespace)
+		}
+		if deployment.Labels["enforceSingleMesh"] == "true" {
+			return failed(name, "Existing mesh %s enforces a single mesh in the cluster", meshName)
+		}
+	}
+	return passed(name, "Mesh name %s is available", c.meshName)
+}
+
+func (c *checkCmd) checkNamespaceAvailable() checkResult {
+	const name = "OSM namespace"
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{"app": constants.OSMControllerName}).String(),
+	}
+	deployments, err := c.kubeClient.AppsV1().Deployments(c.osmNamespace).List(context.TODO(), listOptions)
+	if err != nil {
+		return failed(name, "Error listing %s deployments in namespace %s: %s", constants.OSMControllerName, c.osmNamespace, err)
+	}
+	if len(deployments.Items) > 0 {
+		return failed(name, "Namespace %s already has an %s", c.osmNamespace, constants.OSMControllerName)
+	}
+	return passed(name, "Namespace %s has no control plane", c.osmNamespace)
+}
+
+// checkInstalledCRDVersions checks that the CRDs already installed in the cluster serve the API versions required
+// by this version of OSM. CRDs that are not installed will be installed along with the control plane.
+func (c *checkCmd) checkInstalledCRDVersions() checkResult {
+	const name = "CRD versions"
+
+	required := map[string]string{
+		"TrafficTarget":          smiAccess.SchemeGroupVersion.String(),
+		"HTTPRouteGroup":         smiSpecs.SchemeGroupVersion.String(),
+		"TCPRoute":               smiSpecs.SchemeGroupVersion.String(),
+		"TrafficSplit":           smiSplit.SchemeGroupVersion.String(),
+		"MeshConfig":             configv1alpha1.SchemeGroupVersion.String(),
+		"Egress":                 policyv1alpha1.SchemeGroupVersion.String(),
+		"UpstreamTrafficSetting": policyv1alpha1.SchemeGroupVersion.String(),
+	}
+	if err := c.checkAPIVersions(required, false); err != nil {
+		return failed(name, "%s", err)
+	}
+	return passed(name, "Installed CRDs serve the API versions required by OSM %s", version.Version)
+}
+
+// checkControllerCRDVersions checks that the CRDs installed in the cluster serve the API versions required by the running osm-controller
+func (c *checkCmd) checkControllerCRDVersions() checkResult {
+	const name = "CRD versions"
+
+	pod, err := getRunningPod(c.kubeClient, c.osmNamespace, constants.OSMControllerName)
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+	resp, err := c.kubeClient.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, strconv.Itoa(constants.OSMHTTPServerPort), constants.HTTPServerSmiVersionPath, nil).DoRaw(context.TODO())
+	if err != nil {
+		return failed(name, "Error fetching the API versions required by %s pod %s: %s", constants.OSMControllerName, pod.Name, err)
+	}
+	var required map[string]string
+	if err := json.Unmarshal(resp, &required); err != nil {
+		return failed(name, "Error decoding the API versions required by %s pod %s: %s", constants.OSMControllerName, pod.Name, err)
+	}
+
+	if err := c.checkAPIVersions(required, true); err != nil {
+		return failed(name, "%s", err)
+	}
+	return passed(name, "Installed CRDs serve the API versions required by %s", constants.OSMControllerName)
+}
+
+// checkAPIVersions returns an error if the given kinds are not served at the given API versions.
+// Kinds whose API group is not served at all are only reported when requireInstalled is true.
+func (c *checkCmd) checkAPIVersions(required map[string]string, requireInstalled bool) error {
+	groups, err := c.kubeClient.Discovery().ServerGroups()
+	if err != nil {
+		return errors.Errorf("Error fetching the API groups served by the Kubernetes API server: %s", err)
+	}
+	servedVersions := make(map[string]map[string]bool)
+	for _, group := range groups.Groups {
+		servedVersions[group.Name] = make(map[string]bool)
+		for _, v := range group.Versions {
+			servedVersions[group.Name][v.Version] = true
+		}
+	}
+
+	var problems []string
+	for kind, groupVersion := range required {
+		gv, err := schema.ParseGroupVersion(groupVersion)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid API version %s", kind, groupVersion))
+			continue
+		}
+		versions, ok := servedVersions[gv.Group]
+		if !ok {
+			if requireInstalled {
+				problems = append(problems, fmt.Sprintf("%s: CRD for API group %s is not installed", kind, gv.Group))
+			}
+			continue
+		}
+		if !versions[gv.Version] {
+			problems = append(problems, fmt.Sprintf("%s: API version %s is not served", kind, groupVersion))
+			continue
+		}
+		if !requireInstalled {
+			continue
+		}
+		resources, err := c.kubeClient.Discovery().ServerResourcesForGroupVersion(groupVersion)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: error fetching resources of API version %s: %s", kind, groupVersion, err))
+			continue
+		}
+		if !hasKind(resources, kind) {
+			problems = append(problems, fmt.Sprintf("%s: CRD is not installed", kind))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.Errorf("Incompatible CRDs: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func hasKind(resources *metav1.APIResourceList, kind string) bool {
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// checkComponentRunning returns a check that a pod of the given control plane component is running
+func (c *checkCmd) checkComponentRunning(component string) func() checkResult {
+	return func() checkResult {
+		name := fmt.Sprintf("%s pod", component)
+		pod, err := getRunningPod(c.kubeClient, c.osmNamespace, component)
+		if err != nil {
+			return failed(name, "%s", err)
+		}
+		return passed(name, "Pod %s is running", pod.Name)
+	}
+}
+
+// checkControllerVersion checks that the version of the control plane matches the version of the CLI
+func (c *checkCmd) checkControllerVersion() checkResult {
+	const name = "Control plane version"
+
+	deployment, err := c.getControllerDeployment()
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+	controllerVersion := deployment.Labels[constants.OSMAppVersionLabelKey]
+	if controllerVersion != version.Version {
+		return warning(name, "Control plane version %s does not match CLI version %s", controllerVersion, version.Version)
+	}
+	return passed(name, "Control plane version %s matches CLI version", controllerVersion)
+}
+
+// checkWebhookReachable checks that the sidecar injection webhook of the mesh is reachable through the Kubernetes API server
+func (c *checkCmd) checkWebhookReachable() checkResult {
+	const name = "Sidecar injector webhook"
+
+	webhook, err := c.getInjectorWebhook()
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+	service := webhook.Webhooks[0].ClientConfig.Service
+	if service == nil {
+		return failed(name, "Webhook %s is not backed by a service", webhook.Name)
+	}
+	port := constants.InjectorWebhookPort
+	if service.Port != nil {
+		port = int(*service.Port)
+	}
+	if _, err := c.kubeClient.CoreV1().Services(service.Namespace).ProxyGet("https", service.Name, strconv.Itoa(port), injector.WebhookHealthPath, nil).DoRaw(context.TODO()); err != nil {
+		return failed(name, "Service %s/%s of webhook %s is not reachable: %s", service.Namespace, service.Name, webhook.Name, err)
+	}
+	return passed(name, "Service %s/%s of webhook %s is reachable", service.Namespace, service.Name, webhook.Name)
+}
+
+// checkCertificates checks the validity of the CA bundle of the mesh and of the CA bundle of the sidecar injection webhook
+func (c *checkCmd) checkCertificates() checkResult {
+	const name = "Certificates"
+
+	deployment, err := c.getControllerDeployment()
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+	secretName := getContainerArg(deployment, constants.OSMControllerName, "--ca-bundle-secret-name")
+	if secretName == "" {
+		return failed(name, "Deployment %s/%s does not specify a CA bundle secret", deployment.Namespace, deployment.Name)
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(c.osmNamespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return failed(name, "Error fetching CA bundle secret %s/%s: %s", c.osmNamespace, secretName, err)
+	}
+
+	webhook, err := c.getInjectorWebhook()
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+
+	certs := []struct {
+		source string
+		pem    []byte
+	}{
+		{source: fmt.Sprintf("CA bundle secret %s/%s", c.osmNamespace, secretName), pem: secret.Data[constants.KubernetesOpaqueSecretCAKey]},
+		{source: fmt.Sprintf("CA bundle of webhook %s", webhook.Name), pem: webhook.Webhooks[0].ClientConfig.CABundle},
+	}
+
+	now := time.Now()
+	var warnings []string
+	for _, cert := range certs {
+		x509Cert, err := certificate.DecodePEMCertificate(cert.pem)
+		if err != nil {
+			return failed(name, "Error decoding the certificate of %s: %s", cert.source, err)
+		}
+		if now.Before(x509Cert.NotBefore) {
+			return failed(name, "Certificate of %s is not valid before %s", cert.source, x509Cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if now.After(x509Cert.NotAfter) {
+			return failed(name, "Certificate of %s expired at %s", cert.source, x509Cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		if x509Cert.NotAfter.Sub(now) < certExpiryWarningPeriod {
+			warnings = append(warnings, fmt.Sprintf("Certificate of %s expires at %s", cert.source, x509Cert.NotAfter.UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(warnings) > 0 {
+		return warning(name, "%s", strings.Join(warnings, "; "))
+	}
+	return passed(name, "Certificates are valid")
+}
+
+// checkXDSReachable checks that the xDS server of osm-controller is reachable from a pod in the cluster
+func (c *checkCmd) checkXDSReachable() checkResult {
+	const name = "xDS server"
+
+	address := fmt.Sprintf("%s.%s.svc", constants.OSMControllerName, c.osmNamespace)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "osm-check-xds-",
+			Namespace:    c.osmNamespace,
+			Labels:       map[string]string{"app": "osm-check"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:    "check",
+					Image:   c.testPodImage,
+					Command: []string{"nc", "-z", "-w", "5", address, strconv.Itoa(constants.OSMControllerPort)},
+				},
+			},
+		},
+	}
+
+	pod, err := c.kubeClient.CoreV1().Pods(c.osmNamespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+	if err != nil {
+		return failed(name, "Error creating test pod in namespace %s: %s", c.osmNamespace, err)
+	}
+	defer func() {
+		_ = c.kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	var phase corev1.PodPhase
+	err = wait.PollImmediate(time.Second, c.timeout, func() (bool, error) {
+		p, err := c.kubeClient.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = p.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return failed(name, "Test pod %s/%s did not complete: %s", pod.Namespace, pod.Name, err)
+	}
+	if phase != corev1.PodSucceeded {
+		return failed(name, "%s:%d is not reachable from test pod %s/%s", address, constants.OSMControllerPort, pod.Namespace, pod.Name)
+	}
+	return passed(name, "%s:%d is reachable from a pod", address, constants.OSMControllerPort)
+}
+
+// checkMeshConfig checks that the configuration of the mesh is valid
+func (c *checkCmd) checkMeshConfig() checkResult {
+	const name = "Mesh configuration"
+
+	configMap, err := c.kubeClient.CoreV1().ConfigMaps(c.osmNamespace).Get(context.TODO(), constants.OSMConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return failed(name, "Error fetching ConfigMap %s/%s: %s", c.osmNamespace, constants.OSMConfigMap, err)
+	}
+	if err := configurator.ValidateConfigMap(*configMap); err != nil {
+		return failed(name, "%s", strings.ReplaceAll(strings.TrimSpace(err.Error()), "\n", " "))
+	}
+	return passed(name, "ConfigMap %s/%s is valid", c.osmNamespace, constants.OSMConfigMap)
+}
+
+// checkDiagnostics checks that the control plane of the mesh reports no recurring errors
+func (c *checkCmd) checkDiagnostics() checkResult {
+	const name = "Control plane errors"
+
+	diagnostic, err := c.configClient.ConfigV1alpha1().MeshDiagnostics().Get(context.TODO(), c.meshName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return warning(name, "No diagnostics reported by the control plane yet")
+	}
+	if err != nil {
+		return failed(name, "Error fetching diagnostics of mesh %s: %s", c.meshName, err)
+	}
+
+	var recurring []string
+	for _, condition := range diagnostic.Status.Conditions {
+		if diagnostics.IsRecurring(condition) {
+			recurring = append(recurring, fmt.Sprintf("%s (%s)", condition.Code, condition.Type))
+		}
+	}
+	if len(recurring) > 0 {
+		return failed(name, "Recurring errors: %s, run 'osm check' for details", strings.Join(recurring, ", "))
+	}
+	return passed(name, "No recurring errors")
+}
+
+// getControllerDeployment returns the osm-controller Deployment of the mesh
+func (c *checkCmd) getControllerDeployment() (*appsv1.Deployment, error) {
+	deployments, err := getControllerDeployments(c.kubeClient)
+	if err != nil {
+		return nil, errors.Errorf("Error listing %s deployments: %s", constants.OSMControllerName, err)
+	}
+	for _, deployment := range deployments.Items {
+		if deployment.Namespace == c.osmNamespace && deployment.Labels["meshName"] == c.meshName {
+			deployment := deployment
+			return &deployment, nil
+		}
+	}
+	return nil, errors.Errorf("No %s deployment found for mesh %s in namespace %s", constants.OSMControllerName, c.meshName, c.osmNamespace)
+}
+
+// getInjectorWebhook returns the MutatingWebhookConfiguration of the sidecar injection webhook of the mesh
+func (c *checkCmd) getInjectorWebhook() (*admissionregv1.MutatingWebhookConfiguration, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"app":                            constants.OSMInjectorName,
+			constants.OSMAppInstanceLabelKey: c.meshName,
+		}).String(),
+	}
+	webhookConfigs, err := c.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(context.TODO(), listOptions)
+	if err != nil {
+		return nil, errors.Errorf("Error listing MutatingWebhookConfigurations: %s", err)
+	}
+	for _, webhookConfig := range webhookConfigs.Items {
+		if len(webhookConfig.Webhooks) > 0 {
+			webhookConfig := webhookConfig
+			return &webhookConfig, nil
+		}
+	}
+	return nil, errors.Errorf("No MutatingWebhookConfiguration found for mesh %s", c.meshName)
+}
+
+// getRunningPod returns a running pod of the given control plane component in the given namespace
+func getRunningPod(clientSet kubernetes.Interface, namespace, component string) (*corev1.Pod, error) {
+	listOptions := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{"app": component}).String(),
+	}
+	pods, err := clientSet.CoreV1().Pods(namespace).List(context.TODO(), listOptions)
+	if err != nil {
+		return nil, errors.Errorf("Error listing %s pods in namespace %s: %s", component, namespace, err)
+	}
+	for _, pod := range pods.Items {
+		pod := pod // prevents aliasing address of loop variable which is the same in each iteration
+		if pod.Status.Phase == corev1.PodRunning {
+			return &pod, nil
+		}
+	}
+	return nil, errors.Errorf("No running %s pod found in namespace %s", component, namespace)
+}
+
+// getContainerArg returns the value of the given argument of the given container of a Deployment
+func getContainerArg(deployment *appsv1.Deployment, container, arg string) string {
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if c.Name != container {
+			continue
+		}
+		for i := 0; i < len(c.Args)-1; i++ {
+			if c.Args[i] == arg {
+				return c.Args[i+1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,396 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sversion "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeKubeClient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/debugger"
+	fakeConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+const testOsmNamespace = "osm-system"
+
+// fakeProxyResponse is a rest.ResponseWrapper returning a fixed body
+type fakeProxyResponse struct {
+	body []byte
+}
+
+func (r fakeProxyResponse) DoRaw(context.Context) ([]byte, error) {
+	return r.body, nil
+}
+
+func (r fakeProxyResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(r.body)), nil
+}
+
+func newTestCheckCmd(objects ...runtime.Object) (*checkCmd, *fakeKubeClient.Clientset) {
+	kubeClient := fakeKubeClient.NewSimpleClientset(objects...)
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &k8sversion.Info{GitVersion: "v1.19.7"}
+	kubeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "access.smi-spec.io/v1alpha3", APIResources: []metav1.APIResource{{Kind: "TrafficTarget"}}},
+		{GroupVersion: "specs.smi-spec.io/v1alpha4", APIResources: []metav1.APIResource{{Kind: "HTTPRouteGroup"}, {Kind: "TCPRoute"}}},
+		{GroupVersion: "split.smi-spec.io/v1alpha2", APIResources: []metav1.APIResource{{Kind: "TrafficSplit"}}},
+	}
+
+	return &checkCmd{
+		out:            new(bytes.Buffer),
+		kubeClient:     kubeClient,
+		configClient:   fakeConfigClient.NewSimpleClientset(),
+		meshName:       defaultMeshName,
+		osmNamespace:   testOsmNamespace,
+		output:         checkOutputJSON,
+		testPodImage:   defaultTestPodImage,
+		timeout:        time.Second,
+		certSampleSize: defaultCertSampleSize,
+	}, kubeClient
+}
+
+func getCheckReport(t *testing.T, cmd *checkCmd) checkReport {
+	var report checkReport
+	trequire.Nil(t, json.Unmarshal(cmd.out.(*bytes.Buffer).Bytes(), &report))
+	return report
+}
+
+func getCheckStatuses(report checkReport) map[string]checkStatus {
+	statuses := make(map[string]checkStatus)
+	for _, result := range report.Checks {
+		statuses[result.Name] = result.Status
+	}
+	return statuses
+}
+
+func TestPreInstallChecks(t *testing.T) {
+	existingController := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.OSMControllerName,
+			Namespace: testOsmNamespace,
+			Labels:    map[string]string{"app": constants.OSMControllerName, "meshName": defaultMeshName},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		objects          []runtime.Object
+		serverVersion    string
+		splitVersion     string
+		expectedStatuses map[string]checkStatus
+	}{
+		{
+			name: "cluster ready for installation",
+			expectedStatuses: map[string]checkStatus{
+				"Kubernetes version": checkPassed,
+				"Mesh name":          checkPassed,
+				"OSM namespace":      checkPassed,
+				"CRD versions":       checkPassed,
+			},
+		},
+		{
+			name:          "unsupported Kubernetes version",
+			serverVersion: "v1.17.4",
+			expectedStatuses: map[string]checkStatus{
+				"Kubernetes version": checkFailed,
+			},
+		},
+		{
+			name:    "mesh already installed",
+			objects: []runtime.Object{existingController},
+			expectedStatuses: map[string]checkStatus{
+				"Mesh name":     checkFailed,
+				"OSM namespace": checkFailed,
+			},
+		},
+		{
+			name:         "installed CRD serves an incompatible version",
+			splitVersion: "split.smi-spec.io/v1alpha1",
+			expectedStatuses: map[string]checkStatus{
+				"CRD versions": checkFailed,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			cmd, kubeClient := newTestCheckCmd(tc.objects...)
+			cmd.preInstall = true
+			discovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+			if tc.serverVersion != "" {
+				discovery.FakedServerVersion = &k8sversion.Info{GitVersion: tc.serverVersion}
+			}
+			if tc.splitVersion != "" {
+				discovery.Resources[2].GroupVersion = tc.splitVersion
+			}
+
+			err := cmd.run()
+			report := getCheckReport(t, cmd)
+			assert.Equal("pre-install", report.Phase)
+			assert.Equal(err == nil, report.Passed)
+
+			statuses := getCheckStatuses(report)
+			for name, status := range tc.expectedStatuses {
+				assert.Equal(status, statuses[name], name)
+			}
+		})
+	}
+}
+
+func TestPostInstallChecks(t *testing.T) {
+	ca, err := tresor.NewCA("osm-ca", 365*24*time.Hour, "US", "Seattle", "OSM")
+	trequire.Nil(t, err)
+
+	newObjects := func() []runtime.Object {
+		return []runtime.Object{
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.OSMControllerName,
+					Namespace: testOsmNamespace,
+					Labels: map[string]string{
+						"app":                           constants.OSMControllerName,
+						"meshName":                      defaultMeshName,
+						constants.OSMAppVersionLabelKey: version.Version,
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name: constants.OSMControllerName,
+									Args: []string{"--mesh-name", defaultMeshName, "--ca-bundle-secret-name", "osm-ca-bundle"},
+								},
+							},
+						},
+					},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "osm-controller-1", Namespace: testOsmNamespace, Labels: map[string]string{"app": constants.OSMControllerName}},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "osm-injector-1", Namespace: testOsmNamespace, Labels: map[string]string{"app": constants.OSMInjectorName}},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "osm-ca-bundle", Namespace: testOsmNamespace},
+				Data:       map[string][]byte{constants.KubernetesOpaqueSecretCAKey: ca.GetCertificateChain()},
+			},
+			&admissionregv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "osm-webhook-osm",
+					Labels: map[string]string{"app": constants.OSMInjectorName, constants.OSMAppInstanceLabelKey: defaultMeshName},
+				},
+				Webhooks: []admissionregv1.MutatingWebhook{
+					{
+						Name: "osm-inject.k8s.io",
+						ClientConfig: admissionregv1.WebhookClientConfig{
+							Service:  &admissionregv1.ServiceReference{Name: constants.OSMInjectorName, Namespace: testOsmNamespace},
+							CABundle: ca.GetCertificateChain(),
+						},
+					},
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: constants.OSMConfigMap, Namespace: testOsmNamespace},
+				Data: map[string]string{
+					"egress":                           "true",
+					"enable_debug_server":              "true",
+					"permissive_traffic_policy_mode":   "false",
+					"prometheus_scraping":              "true",
+					"use_https_ingress":                "false",
+					"envoy_log_level":                  "error",
+					"envoy_image":                      "envoyproxy/envoy-alpine:v1.17.2",
+					"service_cert_validity_duration":   "24h",
+					"tracing_enable":                   "false",
+					"enable_privileged_init_container": "false",
+					"max_data_plane_connections":       "0",
+				},
+			},
+		}
+	}
+
+	now := time.Now()
+	workloadCert := newTestWorkloadCert(t, ca, "bookstore.bookstore.cluster.local", []string{"bookstore.bookstore.cluster.local"}, now.Add(-time.Hour), now.Add(23*time.Hour))
+	previousCA, err := tresor.NewCA("osm-ca", 365*24*time.Hour, "US", "Seattle", "OSM")
+	trequire.Nil(t, err)
+	staleWorkloadCert := newTestWorkloadCert(t, previousCA, "bookbuyer.bookbuyer.cluster.local", []string{"bookbuyer.bookbuyer.cluster.local"}, now.Add(-time.Hour), now.Add(23*time.Hour))
+
+	testCases := []struct {
+		name             string
+		mutate           func(*checkCmd, *fakeKubeClient.Clientset)
+		xdsPodPhase      corev1.PodPhase
+		issuedCerts      []debugger.IssuedCertificate
+		expectedStatuses map[string]checkStatus
+	}{
+		{
+			name:        "healthy mesh",
+			xdsPodPhase: corev1.PodSucceeded,
+			issuedCerts: []debugger.IssuedCertificate{workloadCert},
+			expectedStatuses: map[string]checkStatus{
+				"osm-controller pod":       checkPassed,
+				"osm-injector pod":         checkPassed,
+				"Control plane version":    checkPassed,
+				"CRD versions":             checkPassed,
+				"Sidecar injector webhook": checkPassed,
+				"Certificates":             checkPassed,
+				"Workload certificates":    checkPassed,
+				"xDS server":               checkPassed,
+				"Mesh configuration":       checkPassed,
+				"Control plane errors":     checkWarning,
+			},
+		},
+		{
+			name:        "unreachable xDS server and invalid mesh configuration",
+			xdsPodPhase: corev1.PodFailed,
+			mutate: func(cmd *checkCmd, kubeClient *fakeKubeClient.Clientset) {
+				configMap, _ := kubeClient.CoreV1().ConfigMaps(testOsmNamespace).Get(context.TODO(), constants.OSMConfigMap, metav1.GetOptions{})
+				configMap.Data["envoy_log_level"] = "verbose"
+				_, _ = kubeClient.CoreV1().ConfigMaps(testOsmNamespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+			},
+			expectedStatuses: map[string]checkStatus{
+				"xDS server":         checkFailed,
+				"Mesh configuration": checkFailed,
+			},
+		},
+		{
+			name:        "recurring control plane errors",
+			xdsPodPhase: corev1.PodSucceeded,
+			mutate: func(cmd *checkCmd, kubeClient *fakeKubeClient.Clientset) {
+				_, _ = cmd.configClient.ConfigV1alpha1().MeshDiagnostics().Create(context.TODO(), &configv1alpha1.MeshDiagnostic{
+					ObjectMeta: metav1.ObjectMeta{Name: defaultMeshName},
+					Status: configv1alpha1.MeshDiagnosticStatus{
+						Conditions: []configv1alpha1.DiagnosticCondition{
+							{Type: "CertificateIssuanceFailed", Code: "E1001", Status: metav1.ConditionTrue, Count: 3},
+						},
+					},
+				}, metav1.CreateOptions{})
+			},
+			expectedStatuses: map[string]checkStatus{
+				"Control plane errors": checkFailed,
+			},
+		},
+		{
+			name:        "workload certificate issued by a previous CA",
+			xdsPodPhase: corev1.PodSucceeded,
+			issuedCerts: []debugger.IssuedCertificate{workloadCert, staleWorkloadCert},
+			expectedStatuses: map[string]checkStatus{
+				"Certificates":          checkPassed,
+				"Workload certificates": checkFailed,
+			},
+		},
+		{
+			name:        "controller version mismatch",
+			xdsPodPhase: corev1.PodSucceeded,
+			mutate: func(cmd *checkCmd, kubeClient *fakeKubeClient.Clientset) {
+				deployment, _ := kubeClient.AppsV1().Deployments(testOsmNamespace).Get(context.TODO(), constants.OSMControllerName, metav1.GetOptions{})
+				deployment.Labels[constants.OSMAppVersionLabelKey] = "v0.0.1"
+				_, _ = kubeClient.AppsV1().Deployments(testOsmNamespace).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+			},
+			expectedStatuses: map[string]checkStatus{
+				"Control plane version": checkWarning,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			cmd, kubeClient := newTestCheckCmd(newObjects()...)
+			cmd.postInstall = true
+			if tc.mutate != nil {
+				tc.mutate(cmd, kubeClient)
+			}
+
+			kubeClient.PrependProxyReactor("pods", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+				if action.(k8stesting.ProxyGetAction).GetPath() == debugCertsPath {
+					body, err := json.Marshal(tc.issuedCerts)
+					return true, fakeProxyResponse{body: body}, err
+				}
+				return true, fakeProxyResponse{body: []byte(`{"TrafficTarget":"access.smi-spec.io/v1alpha3","HTTPRouteGroup":"specs.smi-spec.io/v1alpha4","TCPRoute":"specs.smi-spec.io/v1alpha4","TrafficSplit":"split.smi-spec.io/v1alpha2"}`)}, nil
+			})
+			kubeClient.PrependProxyReactor("services", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+				return true, fakeProxyResponse{body: []byte("Health OK")}, nil
+			})
+			// The test pod completes as soon as it is created
+			kubeClient.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+				pod.Name = pod.GenerateName + "test"
+				pod.Status.Phase = tc.xdsPodPhase
+				return false, nil, nil
+			})
+
+			err := cmd.run()
+			report := getCheckReport(t, cmd)
+			assert.Equal("post-install", report.Phase)
+			assert.Equal(err == nil, report.Passed)
+
+			statuses := getCheckStatuses(report)
+			for name, status := range tc.expectedStatuses {
+				assert.Equal(status, statuses[name], name)
+			}
+
+			// The test pod is deleted once the check completes
+			pods, err := kubeClient.CoreV1().Pods(testOsmNamespace).List(context.TODO(), metav1.ListOptions{})
+			assert.Nil(err)
+			assert.Len(pods.Items, 2)
+		})
+	}
+}
+
+func TestCheckValidateOptions(t *testing.T) {
+	assert := tassert.New(t)
+
+	cmd := &checkCmd{output: checkOutputTable}
+	assert.Nil(cmd.validateOptions())
+
+	cmd.output = "yaml"
+	assert.NotNil(cmd.validateOptions())
+
+	cmd = &checkCmd{output: checkOutputJSON, preInstall: true, postInstall: true}
+	assert.NotNil(cmd.validateOptions())
+
+	cmd = &checkCmd{output: checkOutputJSON, postInstall: true, certSampleSize: defaultCertSampleSize}
+	assert.Nil(cmd.validateOptions())
+
+	cmd.certSampleSize = 0
+	assert.NotNil(cmd.validateOptions())
+}
+
+func TestCheckTableOutput(t *testing.T) {
+	assert := tassert.New(t)
+
+	out := new(bytes.Buffer)
+	cmd := &checkCmd{out: out, output: checkOutputTable}
+	err := cmd.printReport(checkReport{
+		Checks: []checkResult{
+			{Name: "Mesh name", Status: checkPassed, Message: "Mesh name osm is available"},
+		},
+	})
+	assert.Nil(err)
+	assert.Equal("CHECK       STATUS   MESSAGE\n"+
+		"Mesh name   pass     Mesh name osm is available\n", out.String())
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	fakeConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+func TestCheckCmd(t *testing.T) {
+	lastOccurrence := metav1.NewTime(time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name           string
+		diagnostic     *configv1alpha1.MeshDiagnostic
+		expectErr      bool
+		expectedOutput string
+	}{
+		{
+			name:      "mesh without diagnostics",
+			expectErr: true,
+		},
+		{
+			name: "no recurring errors",
+			diagnostic: &configv1alpha1.MeshDiagnostic{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultMeshName},
+				Status: configv1alpha1.MeshDiagnosticStatus{
+					Conditions: []configv1alpha1.DiagnosticCondition{
+						{Type: "CertificateIssuanceFailed", Code: "E1001", Status: metav1.ConditionFalse},
+					},
+				},
+			},
+			expectedOutput: "CODE    CONDITION                   RECURRING   COUNT   LAST OCCURRENCE   MESSAGE\n" +
+				"E1001   CertificateIssuanceFailed   False       0       -                 \n" +
+				"\nNo recurring errors found in the control plane of mesh osm\n",
+		},
+		{
+			name: "recurring errors",
+			diagnostic: &configv1alpha1.MeshDiagnostic{
+				ObjectMeta: metav1.ObjectMeta{Name: defaultMeshName},
+				Status: configv1alpha1.MeshDiagnosticStatus{
+					Conditions: []configv1alpha1.DiagnosticCondition{
+						{Type: "CertificateIssuanceFailed", Code: "E1001", Status: metav1.ConditionTrue, Count: 3, Message: "vault unreachable", LastOccurrenceTime: &lastOccurrence},
+					},
+				},
+			},
+			expectErr: true,
+			expectedOutput: "CODE    CONDITION                   RECURRING   COUNT   LAST OCCURRENCE        MESSAGE\n" +
+				"E1001   CertificateIssuanceFailed   True        3       2021-05-01T12:00:00Z   vault unreachable\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			configClient := fakeConfigClient.NewSimpleClientset()
+			if tc.diagnostic != nil {
+				_, err := configClient.ConfigV1alpha1().MeshDiagnostics().Create(context.TODO(), tc.diagnostic, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			out := new(bytes.Buffer)
+			cmd := &checkCmd{
+				out:          out,
+				configClient: configClient,
+				meshName:     defaultMeshName,
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectErr, err != nil)
+			if tc.expectedOutput != "" {
+				assert.Equal(tc.expectedOutput, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/debugger"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	// defaultCertSampleSize is the default number of workload certificates validated by the workload certificates check
+	defaultCertSampleSize = 10
+
+	// debugCertsPath is the path of the debug server of osm-controller listing the issued certificates
+	debugCertsPath = "/debug/certs"
+)
+
+// certValidation is the result of the validation of a workload certificate against the active CA
+type certValidation struct {
+	commonName string
+	errors     []string
+	warnings   []string
+}
+
+// checkWorkloadCertificates checks that a sample of the workload certificates issued by osm-controller chain to the
+// active CA of the mesh, have a well-formed SAN, and are within their validity period. Mismatches typically occur
+// after a partial CA rotation or a change of certificate provider.
+func (c *checkCmd) checkWorkloadCertificates() checkResult {
+	const name = "Workload certificates"
+
+	deployment, err := c.getControllerDeployment()
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+	secretName := getContainerArg(deployment, constants.OSMControllerName, "--ca-bundle-secret-name")
+	if secretName == "" {
+		return failed(name, "Deployment %s/%s does not specify a CA bundle secret", deployment.Namespace, deployment.Name)
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(c.osmNamespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return failed(name, "Error fetching CA bundle secret %s/%s: %s", c.osmNamespace, secretName, err)
+	}
+	caCert, err := certificate.DecodePEMCertificate(secret.Data[constants.KubernetesOpaqueSecretCAKey])
+	if err != nil {
+		return failed(name, "Error decoding the certificate of CA bundle secret %s/%s: %s", c.osmNamespace, secretName, err)
+	}
+
+	pod, err := getRunningPod(c.kubeClient, c.osmNamespace, constants.OSMControllerName)
+	if err != nil {
+		return failed(name, "%s", err)
+	}
+	resp, err := c.kubeClient.CoreV1().Pods(pod.Namespace).ProxyGet("http", pod.Name, strconv.Itoa(constants.DebugPort), debugCertsPath, map[string]string{"format": "json"}).DoRaw(context.TODO())
+	if err != nil {
+		return warning(name, "Could not list the certificates issued by osm-controller pod %s/%s, ensure the 'enable_debug_server' setting is enabled: %s", pod.Namespace, pod.Name, err)
+	}
+	var issued []debugger.IssuedCertificate
+	if err := json.Unmarshal(resp, &issued); err != nil {
+		return failed(name, "Error parsing the certificates issued by osm-controller pod %s/%s: %s", pod.Namespace, pod.Name, err)
+	}
+
+	sample := sampleWorkloadCertificates(issued, c.certSampleSize)
+	if len(sample) == 0 {
+		return passed(name, "No workload certificates issued")
+	}
+
+	var errs, warnings []string
+	mismatched := 0
+	for _, validation := range validateWorkloadCertificates(sample, caCert, time.Now()) {
+		if len(validation.errors) > 0 {
+			mismatched++
+		}
+		for _, err := range validation.errors {
+			errs = append(errs, fmt.Sprintf("%s: %s", validation.commonName, err))
+		}
+		for _, warning := range validation.warnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", validation.commonName, warning))
+		}
+	}
+	if len(errs) > 0 {
+		return failed(name, "%d of %d sampled certificates do not match the active CA: %s", mismatched, len(sample), strings.Join(errs, "; "))
+	}
+	if len(warnings) > 0 {
+		return warning(name, "%s", strings.Join(warnings, "; "))
+	}
+	return passed(name, "%d sampled certificates match the active CA", len(sample))
+}
+
+// sampleWorkloadCertificates returns up to size workload certificates from the given issued certificates, sorted by
+// common name. Workload certificates are the certificates issued to service identities of the local trust domain.
+func sampleWorkloadCertificates(issued []debugger.IssuedCertificate, size int) []debugger.IssuedCertificate {
+	var sample []debugger.IssuedCertificate
+	for _, cert := range issued {
+		if strings.HasSuffix(cert.CommonName, "."+identity.ClusterLocalTrustDomain) {
+			sample = append(sample, cert)
+		}
+	}
+	sort.Slice(sample, func(i, j int) bool {
+		return sample[i].CommonName < sample[j].CommonName
+	})
+	if len(sample) > size {
+		sample = sample[:size]
+	}
+	return sample
+}
+
+// validateWorkloadCertificates validates each of the given workload certificates against the active CA at the given time
+func validateWorkloadCertificates(certs []debugger.IssuedCertificate, caCert *x509.Certificate, now time.Time) []certValidation {
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	var validations []certValidation
+	for _, cert := range certs {
+		validations = append(validations, validateWorkloadCertificate(cert, caCert, roots, now))
+	}
+	return validations
+}
+
+func validateWorkloadCertificate(cert debugger.IssuedCertificate, caCert *x509.Certificate, roots *x509.CertPool, now time.Time) certValidation {
+	v := certValidation{commonName: cert.CommonName}
+
+	leaf, err := certificate.DecodePEMCertificate([]byte(cert.CertChain))
+	if err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("error decoding the certificate chain: %s", err))
+		return v
+	}
+
+	// Any certificates following the leaf in the chain are intermediates
+	intermediates := x509.NewCertPool()
+	intermediates.AppendCertsFromPEM([]byte(cert.CertChain))
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("chain does not verify against the active CA: %s", err))
+	}
+
+	if issuingCA, err := certificate.DecodePEMCertificate([]byte(cert.IssuingCA)); err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("error decoding the issuing CA: %s", err))
+	} else if !issuingCA.Equal(caCert) {
+		v.errors = append(v.errors, fmt.Sprintf("issued by CA %q (serial %x) instead of the active CA %q (serial %x)", issuingCA.Subject.CommonName, issuingCA.SerialNumber, caCert.Subject.CommonName, caCert.SerialNumber))
+	}
+
+	if leaf.Subject.CommonName != cert.CommonName {
+		v.errors = append(v.errors, fmt.Sprintf("subject common name %q does not match the common name of the issued certificate", leaf.Subject.CommonName))
+	}
+	hasSAN := false
+	for _, dnsName := range leaf.DNSNames {
+		if dnsName == cert.CommonName {
+			hasSAN = true
+			break
+		}
+	}
+	if !hasSAN {
+		v.errors = append(v.errors, fmt.Sprintf("SAN %v does not contain the common name", leaf.DNSNames))
+	}
+
+	if now.Before(leaf.NotBefore) {
+		v.errors = append(v.errors, fmt.Sprintf("not valid before %s", leaf.NotBefore.UTC().Format(time.RFC3339)))
+	}
+	if now.After(leaf.NotAfter) {
+		v.errors = append(v.errors, fmt.Sprintf("expired at %s", leaf.NotAfter.UTC().Format(time.RFC3339)))
+	} else if leaf.NotAfter.After(caCert.NotAfter) {
+		v.warnings = append(v.warnings, fmt.Sprintf("expires at %s, after the active CA expires at %s", leaf.NotAfter.UTC().Format(time.RFC3339), caCert.NotAfter.UTC().Format(time.RFC3339)))
+	}
+
+	return v
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/debugger"
+)
+
+// newTestWorkloadCert returns a certificate with the given common name, SAN and validity period, issued by the given CA
+func newTestWorkloadCert(t *testing.T, ca certificate.Certificater, cn string, dnsNames []string, notBefore, notAfter time.Time) debugger.IssuedCertificate {
+	caCert, err := certificate.DecodePEMCertificate(ca.GetCertificateChain())
+	trequire.Nil(t, err)
+	caKey, err := certificate.DecodePEMPrivateKey(ca.GetPrivateKey())
+	trequire.Nil(t, err)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	trequire.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     dnsNames,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	trequire.Nil(t, err)
+	certPEM, err := certificate.EncodeCertDERtoPEM(der)
+	trequire.Nil(t, err)
+
+	return debugger.IssuedCertificate{
+		CommonName:   cn,
+		SerialNumber: fmt.Sprintf("%x", template.SerialNumber),
+		Expiration:   notAfter,
+		CertChain:    string(certPEM),
+		IssuingCA:    string(ca.GetCertificateChain()),
+	}
+}
+
+func TestSampleWorkloadCertificates(t *testing.T) {
+	assert := tassert.New(t)
+
+	issued := []debugger.IssuedCertificate{
+		{CommonName: "osm-validator.osm-system.svc"},
+		{CommonName: "bookstore.bookstore.cluster.local"},
+		{CommonName: "bookbuyer.bookbuyer.cluster.local"},
+		{CommonName: "bookthief.bookthief.cluster.local"},
+	}
+
+	sample := sampleWorkloadCertificates(issued, 2)
+	assert.Len(sample, 2)
+	assert.Equal("bookbuyer.bookbuyer.cluster.local", sample[0].CommonName)
+	assert.Equal("bookstore.bookstore.cluster.local", sample[1].CommonName)
+
+	assert.Len(sampleWorkloadCertificates(issued, defaultCertSampleSize), 3)
+	assert.Empty(sampleWorkloadCertificates(issued[:1], defaultCertSampleSize))
+}
+
+func TestValidateWorkloadCertificates(t *testing.T) {
+	const cn = "bookstore.bookstore.cluster.local"
+
+	now := time.Now()
+	ca, err := tresor.NewCA("osm-ca", 365*24*time.Hour, "US", "Seattle", "OSM")
+	trequire.Nil(t, err)
+	previousCA, err := tresor.NewCA("osm-ca", 365*24*time.Hour, "US", "Seattle", "OSM")
+	trequire.Nil(t, err)
+	caCert, err := certificate.DecodePEMCertificate(ca.GetCertificateChain())
+	trequire.Nil(t, err)
+
+	testCases := []struct {
+		name             string
+		cert             debugger.IssuedCertificate
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{
+			name: "valid certificate",
+			cert: newTestWorkloadCert(t, ca, cn, []string{cn}, now.Add(-time.Hour), now.Add(23*time.Hour)),
+		},
+		{
+			name:           "certificate issued by a previous CA",
+			cert:           newTestWorkloadCert(t, previousCA, cn, []string{cn}, now.Add(-time.Hour), now.Add(23*time.Hour)),
+			expectedErrors: 2,
+		},
+		{
+			name: "certificate issued by the active CA but reported with a previous CA",
+			cert: func() debugger.IssuedCertificate {
+				cert := newTestWorkloadCert(t, ca, cn, []string{cn}, now.Add(-time.Hour), now.Add(23*time.Hour))
+				cert.IssuingCA = string(previousCA.GetCertificateChain())
+				return cert
+			}(),
+			expectedErrors: 1,
+		},
+		{
+			name:           "SAN without the common name",
+			cert:           newTestWorkloadCert(t, ca, cn, []string{"bookstore.bookstore.svc"}, now.Add(-time.Hour), now.Add(23*time.Hour)),
+			expectedErrors: 1,
+		},
+		{
+			name:           "expired certificate",
+			cert:           newTestWorkloadCert(t, ca, cn, []string{cn}, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+			expectedErrors: 2,
+		},
+		{
+			name:           "certificate not yet valid",
+			cert:           newTestWorkloadCert(t, ca, cn, []string{cn}, now.Add(time.Hour), now.Add(2*time.Hour)),
+			expectedErrors: 2,
+		},
+		{
+			name:             "certificate outliving the active CA",
+			cert:             newTestWorkloadCert(t, ca, cn, []string{cn}, now.Add(-time.Hour), caCert.NotAfter.Add(time.Hour)),
+			expectedWarnings: 1,
+		},
+		{
+			name:           "invalid certificate chain",
+			cert:           debugger.IssuedCertificate{CommonName: cn, CertChain: "invalid"},
+			expectedErrors: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			validations := validateWorkloadCertificates([]debugger.IssuedCertificate{tc.cert}, caCert, now)
+			assert.Len(validations, 1)
+			assert.Equal(cn, validations[0].commonName)
+			assert.Len(validations[0].errors, tc.expectedErrors, "%v", validations[0].errors)
+			assert.Len(validations[0].warnings, tc.expectedWarnings, "%v", validations[0].warnings)
+		})
+	}
+}
@@ -51,6 +51,11 @@ Example:
 The mesh name is used in various ways like for naming Kubernetes resources as
 well as for adding a Kubernetes Namespace to the list of Namespaces a control
 plane should watch for sidecar injection of Envoy proxies.
+
+When a mesh context is set with 'osm mesh use' for the current kubeconfig
+context, the mesh name and namespace both default to the ones of the mesh
+context, like for the other commands. Specify both the --mesh-name and
+--osm-namespace flags to install another control plane.
 `
 const (
 	defaultChartPath         = ""
@@ -101,7 +106,8 @@ func newInstallCmd(config *helm.Configuration, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.StringVar(&inst.chartPath, "osm-chart-path", defaultChartPath, "path to osm chart to override default chart")
-	f.StringVar(&inst.meshName, "mesh-name", defaultMeshName, "name for the new control plane instance")
+	// The mesh name defaults to the mesh context, like the namespace given by the global --osm-namespace flag
+	f.StringVar(&inst.meshName, "mesh-name", settings.MeshName(), "name for the new control plane instance")
 	f.BoolVar(&inst.enforceSingleMesh, "enforce-single-mesh", defaultEnforceSingleMesh, "Enforce only deploying one mesh in the cluster")
 	f.DurationVar(&inst.timeout, "timeout", 5*time.Minute, "Time to wait for installation and resources in a ready state, zero means no timeout")
 	f.StringArrayVar(&inst.setOptions, "set", nil, "Set arbitrary chart values (can specify multiple or separate values with commas: key1=val1,key2=val2)")
@@ -22,7 +22,9 @@ func newMeshCmd(config *action.Configuration, in io.Reader, out io.Writer) *cobr
 		Args:  cobra.NoArgs,
 	}
 	cmd.AddCommand(newMeshList(out))
+	cmd.AddCommand(newMeshUse(out))
 	cmd.AddCommand(newMeshUpgradeCmd(config, out))
+	cmd.AddCommand(newMeshTopology(out))
 
 	return cmd
 }
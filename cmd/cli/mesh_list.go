@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
@@ -22,13 +23,20 @@ import (
 )
 
 const meshListDescription = `
-This command will list all the osm control planes running in a Kubernetes cluster and controller pods.`
+This command will list all the osm control planes running in a Kubernetes cluster
+across namespaces, with their versions, controller pods and the number of
+namespaces that are members of each mesh.
+
+The mesh targeted by other osm commands when the --mesh-name and --osm-namespace
+flags are not specified is marked as current. It is set with 'osm mesh use'.`
 
 type meshListCmd struct {
-	out       io.Writer
-	config    *rest.Config
-	clientSet kubernetes.Interface
-	localPort uint16
+	out              io.Writer
+	config           *rest.Config
+	clientSet        kubernetes.Interface
+	localPort        uint16
+	currentMeshName  string
+	currentNamespace string
 }
 
 func newMeshList(out io.Writer) *cobra.Command {
@@ -52,6 +60,8 @@ func newMeshList(out io.Writer) *cobra.Command {
 				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
 			}
 			listCmd.clientSet = clientset
+			listCmd.currentMeshName = settings.MeshName()
+			listCmd.currentNamespace = settings.Namespace()
 			return listCmd.run()
 		},
 	}
@@ -72,9 +82,23 @@ func (l *meshListCmd) run() error {
 		return nil
 	}
 
+	memberCounts, err := getMeshMemberNamespaceCounts(l.clientSet)
+	if err != nil {
+		return errors.Errorf("Could not list namespaces %v", err)
+	}
+
+	// Sort the control planes for a constant output across calls
+	sort.Slice(list.Items, func(i, j int) bool {
+		mi, mj := list.Items[i].ObjectMeta.Labels["meshName"], list.Items[j].ObjectMeta.Labels["meshName"]
+		if mi != mj {
+			return mi < mj
+		}
+		return list.Items[i].Namespace < list.Items[j].Namespace
+	})
+
 	w := newTabWriter(l.out)
 
-	fmt.Fprintln(w, "\nMESH NAME\tNAMESPACE\tCONTROLLER PODS\tVERSION\tSMI SUPPORTED")
+	fmt.Fprintln(w, "\nCURRENT\tMESH NAME\tNAMESPACE\tCONTROLLER PODS\tVERSION\tMEMBER NAMESPACES\tSMI SUPPORTED")
 
 	for _, elem := range list.Items {
 		m := elem.ObjectMeta.Labels["meshName"]
@@ -82,6 +106,11 @@ func (l *meshListCmd) run() error {
 		x := getNamespacePods(l.clientSet, m, ns)
 		v := elem.ObjectMeta.Labels[constants.OSMAppVersionLabelKey]
 
+		current := ""
+		if m == l.currentMeshName && ns == l.currentNamespace {
+			current = "*"
+		}
+
 		smiList := []string{"Unknown"}
 		if pods, ok := x["Pods"]; ok && len(pods) > 0 {
 			smiMap, err := getSupportedSmiForControllerPod(x["Pods"][0], ns, l)
@@ -93,7 +122,7 @@ func (l *meshListCmd) run() error {
 			}
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", m, ns, strings.Join(x["Pods"], ","), v, strings.Join(smiList, ","))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", current, m, ns, strings.Join(x["Pods"], ","), v, memberCounts[m], strings.Join(smiList, ","))
 	}
 	_ = w.Flush()
 
@@ -127,6 +156,20 @@ func getControllerDeployments(clientSet kubernetes.Interface) (*v1.DeploymentLis
 	return deploymentsClient.List(context.TODO(), listOptions)
 }
 
+// getMeshMemberNamespaceCounts returns the number of namespaces that are members of each mesh, keyed by mesh name
+func getMeshMemberNamespaceCounts(clientSet kubernetes.Interface) (map[string]int, error) {
+	namespaces, err := clientSet.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: constants.OSMKubeResourceMonitorAnnotation})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, ns := range namespaces.Items {
+		counts[ns.Labels[constants.OSMKubeResourceMonitorAnnotation]]++
+	}
+	return counts, nil
+}
+
 // getMeshNames returns a set of mesh names corresponding to meshes within the cluster
 func getMeshNames(clientSet kubernetes.Interface) mapset.Set {
 	meshList := mapset.NewSet()
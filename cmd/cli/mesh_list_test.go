@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -77,6 +78,29 @@ var _ = Describe("Running the mesh list command", func() {
 			}))
 		})
 
+		It("should list the control planes with their member namespaces and the current mesh", func() {
+			for _, ns := range []*corev1.Namespace{
+				{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "testMesh1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "bookbuyer", Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "testMesh1"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "bookthief", Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "testMesh2"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			} {
+				_, err := fakeClientSet.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			listCmd.currentMeshName = "testMesh2"
+			listCmd.currentNamespace = "testNs2"
+
+			err = listCmd.run()
+			Expect(err).NotTo(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(strings.Fields(lines[0])).To(Equal([]string{"CURRENT", "MESH", "NAME", "NAMESPACE", "CONTROLLER", "PODS", "VERSION", "MEMBER", "NAMESPACES", "SMI", "SUPPORTED"}))
+			Expect(strings.Fields(lines[1])).To(Equal([]string{"testMesh1", "testNs1", "testVersion0.1.2", "2", "Unknown"}))
+			Expect(strings.Fields(lines[2])).To(Equal([]string{"*", "testMesh2", "testNs2", "testVersion0.1.3", "1", "Unknown"}))
+		})
+
 		It("Should return map with pods and joined namespaces", func() {
 			fakeClientSet := fake.NewSimpleClientset(&corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
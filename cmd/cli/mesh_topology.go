@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+const meshTopologyDescription = `
+This command will export the communication graph of the mesh, composed of the
+services in the mesh and the connections allowed by the traffic policies from
+the service identities in the mesh to these services.
+
+The graph is retrieved from the debug server of the OSM controller in the OSM
+namespace, which must be enabled with the 'enable_debug_server' setting in the
+osm-config ConfigMap. It is output in JSON, or as a GraphViz DOT graph that can
+be rendered with the 'dot' tool.
+`
+
+const meshTopologyExample = `
+# Export the topology of the mesh controlled by the OSM controller in the 'osm-system' namespace as JSON
+osm mesh topology
+
+# Render the topology of the mesh controlled by the OSM controller in the 'osm' namespace as an SVG image
+osm mesh topology --osm-namespace osm -o dot | dot -Tsvg > topology.svg
+`
+
+const (
+	meshTopologyPath = "/debug/topology"
+)
+
+var meshTopologyFormats = []string{"json", "dot"}
+
+type meshTopologyCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	format    string
+	localPort uint16
+	outFile   string
+}
+
+func newMeshTopology(out io.Writer) *cobra.Command {
+	topologyCmd := &meshTopologyCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "topology",
+		Short: "export the communication graph of the mesh",
+		Long:  meshTopologyDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := topologyCmd.validateFormat(); err != nil {
+				return err
+			}
+
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+			topologyCmd.config = config
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			topologyCmd.clientSet = clientset
+			return topologyCmd.run()
+		},
+		Example: meshTopologyExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&topologyCmd.format, "output", "o", "json", fmt.Sprintf("Output format, one of: %v", meshTopologyFormats))
+	f.StringVarP(&topologyCmd.outFile, "file", "f", "", "File to write output to")
+	f.Uint16VarP(&topologyCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (t *meshTopologyCmd) validateFormat() error {
+	for _, format := range meshTopologyFormats {
+		if t.format == format {
+			return nil
+		}
+	}
+	return errors.Errorf("Invalid output format %q, must be one of: %v", t.format, meshTopologyFormats)
+}
+
+func (t *meshTopologyCmd) run() error {
+	controllerPod, err := getRunningControllerPod(t.clientSet, settings.Namespace())
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("%s", err)
+	}
+
+	out := t.out // By default, output is written to stdout
+	if t.outFile != "" {
+		fd, err := os.Create(t.outFile)
+		if err != nil {
+			return errors.Errorf("Error opening file %s: %s", t.outFile, err)
+		}
+		defer fd.Close() //nolint: errcheck, gosec
+		out = fd         // write output to file
+	}
+
+	dialer, err := k8s.DialerToPod(t.config, t.clientSet, controllerPod.Name, controllerPod.Namespace)
+	if err != nil {
+		return err
+	}
+
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", t.localPort, constants.DebugPort))
+	if err != nil {
+		return errors.Errorf("Error setting up port forwarding: %s", err)
+	}
+
+	return portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		defer pf.Stop()
+		url := fmt.Sprintf("http://localhost:%d%s?format=%s", t.localPort, meshTopologyPath, t.format)
+
+		// #nosec G107: Potential HTTP request made with variable url
+		resp, err := http.Get(url)
+		if err != nil {
+			return errors.Errorf("Error fetching url %s, ensure the debug server of the OSM controller is enabled: %s", url, err)
+		}
+		defer resp.Body.Close() //nolint: errcheck
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("Error fetching url %s: %s", url, resp.Status)
+		}
+
+		if _, err := io.Copy(out, resp.Body); err != nil {
+			return errors.Errorf("Error rendering HTTP response: %s", err)
+		}
+		return nil
+	})
+}
+
+// getRunningControllerPod returns a running osm-controller pod in the given namespace
+func getRunningControllerPod(clientSet kubernetes.Interface, namespace string) (*corev1.Pod, error) {
+	return getRunningPod(clientSet, namespace, constants.OSMControllerName)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Running the mesh topology command", func() {
+	Context("when validating the output format", func() {
+		It("should accept the supported formats", func() {
+			for _, format := range []string{"json", "dot"} {
+				cmd := &meshTopologyCmd{format: format}
+				Expect(cmd.validateFormat()).To(Succeed())
+			}
+		})
+
+		It("should reject an unsupported format", func() {
+			cmd := &meshTopologyCmd{format: "yaml"}
+			Expect(cmd.validateFormat()).NotTo(Succeed())
+		})
+	})
+
+	Context("when looking up the OSM controller pod", func() {
+		addPod := func(clientSet *fake.Clientset, name string, phase corev1.PodPhase) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "osm-system",
+					Labels:    map[string]string{"app": constants.OSMControllerName},
+				},
+				Status: corev1.PodStatus{Phase: phase},
+			}
+			_, err := clientSet.CoreV1().Pods("osm-system").Create(context.TODO(), pod, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should return a running pod", func() {
+			clientSet := fake.NewSimpleClientset()
+			addPod(clientSet, "osm-controller-pending", corev1.PodPending)
+			addPod(clientSet, "osm-controller-running", corev1.PodRunning)
+
+			pod, err := getRunningControllerPod(clientSet, "osm-system")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pod.Name).To(Equal("osm-controller-running"))
+		})
+
+		It("should error when no pod is running", func() {
+			clientSet := fake.NewSimpleClientset()
+			addPod(clientSet, "osm-controller-pending", corev1.PodPending)
+
+			_, err := getRunningControllerPod(clientSet, "osm-system")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
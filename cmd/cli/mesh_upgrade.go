@@ -141,7 +141,7 @@ func newMeshUpgradeCmd(config *helm.Configuration, out io.Writer) *cobra.Command
 
 	f := cmd.Flags()
 
-	f.StringVar(&upg.meshName, "mesh-name", defaultMeshName, "Name of the mesh to upgrade")
+	f.StringVar(&upg.meshName, "mesh-name", settings.MeshName(), "Name of the mesh to upgrade")
 	f.StringVar(&chartPath, "osm-chart-path", "", "path to osm chart to override default chart")
 	f.StringVar(&upg.containerRegistry, "container-registry", defaultContainerRegistry, "container registry that hosts control plane component images")
 	f.StringVar(&upg.osmImageTag, "osm-image-tag", defaultOsmImageTag, "osm image tag")
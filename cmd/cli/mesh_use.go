@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+)
+
+const meshUseDescription = `
+This command sets the mesh context, the mesh targeted by the osm commands run
+afterwards when the --mesh-name and --osm-namespace flags are not specified.
+It is useful in clusters running several meshes.
+
+The mesh context is set for the current kubeconfig context, so that the
+commands run against another cluster do not target the mesh of this one. It is
+persisted in the config file given by the OSM_CONFIG environment variable,
+which defaults to 'osm/config.yaml' under the user's config directory. The
+OSM_MESH_NAME and OSM_NAMESPACE environment variables take precedence over the
+mesh context.
+
+If control planes of the mesh run in several namespaces, the namespace of the
+control plane to target must be given with the --osm-namespace flag.
+`
+
+const meshUseExample = `
+# Target the mesh 'osm-edge' with the commands run afterwards
+osm mesh use osm-edge
+
+# Target the control plane of the mesh 'osm-edge' in namespace 'edge-system'
+osm mesh use osm-edge --osm-namespace edge-system
+`
+
+type meshUseCmd struct {
+	out         io.Writer
+	meshName    string
+	namespace   string
+	configPath  string
+	kubeContext string
+	clientSet   kubernetes.Interface
+}
+
+func newMeshUse(out io.Writer) *cobra.Command {
+	useCmd := &meshUseCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "use MESH_NAME",
+		Short: "set the mesh targeted by osm commands",
+		Long:  meshUseDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			useCmd.meshName = args[0]
+			if cmd.Flags().Changed("osm-namespace") {
+				useCmd.namespace = settings.Namespace()
+			}
+			useCmd.configPath = settings.ConfigPath()
+			useCmd.kubeContext = settings.KubeContext()
+
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			useCmd.clientSet = clientset
+			return useCmd.run()
+		},
+		Example: meshUseExample,
+	}
+
+	return cmd
+}
+
+func (u *meshUseCmd) run() error {
+	list, err := getControllerDeployments(u.clientSet)
+	if err != nil {
+		return errors.Errorf("Could not list deployments %v", err)
+	}
+
+	var namespaces []string
+	for _, elem := range list.Items {
+		if elem.ObjectMeta.Labels["meshName"] != u.meshName {
+			continue
+		}
+		if u.namespace == "" || elem.Namespace == u.namespace {
+			namespaces = append(namespaces, elem.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	switch {
+	case len(namespaces) == 0 && u.namespace != "":
+		return errors.Errorf("No control plane of mesh %s found in namespace %s, list the control planes with 'osm mesh list'", u.meshName, u.namespace)
+	case len(namespaces) == 0:
+		return errors.Errorf("No control plane of mesh %s found, list the control planes with 'osm mesh list'", u.meshName)
+	case len(namespaces) > 1:
+		return errors.Errorf("Control planes of mesh %s found in namespaces %s, specify the namespace to target with --osm-namespace", u.meshName, strings.Join(namespaces, ", "))
+	}
+
+	config, err := cli.LoadConfig(u.configPath)
+	if err != nil {
+		// The config file is overwritten with the new mesh context
+		config = &cli.Config{}
+	}
+	config.SetMeshContext(u.kubeContext, cli.MeshContext{MeshName: u.meshName, OSMNamespace: namespaces[0]})
+	if err := config.Save(u.configPath); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(u.out, "Switched to mesh [%s] in namespace [%s] for kubeconfig context [%s]\n", u.meshName, namespaces[0], u.kubeContext)
+	return nil
+}
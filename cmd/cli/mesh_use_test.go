@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+)
+
+func TestMeshUse(t *testing.T) {
+	testCases := []struct {
+		name              string
+		meshName          string
+		namespace         string
+		persisted         *cli.Config
+		expectedErr       bool
+		expectedNamespace string
+	}{
+		{
+			name:              "mesh with a single control plane",
+			meshName:          "testMesh1",
+			expectedNamespace: "testNs1",
+		},
+		{
+			name:              "mesh context replaced",
+			meshName:          "testMesh1",
+			persisted:         &cli.Config{MeshContexts: map[string]cli.MeshContext{"test-context": {MeshName: "testMesh2", OSMNamespace: "testNs2"}}},
+			expectedNamespace: "testNs1",
+		},
+		{
+			name:              "mesh context of another kubeconfig context kept",
+			meshName:          "testMesh1",
+			persisted:         &cli.Config{MeshContexts: map[string]cli.MeshContext{"other-context": {MeshName: "testMesh2", OSMNamespace: "testNs2"}}},
+			expectedNamespace: "testNs1",
+		},
+		{
+			name:        "mesh with control planes in several namespaces",
+			meshName:    "testMesh2",
+			expectedErr: true,
+		},
+		{
+			name:              "mesh with control planes in several namespaces and namespace given",
+			meshName:          "testMesh2",
+			namespace:         "testNs3",
+			expectedNamespace: "testNs3",
+		},
+		{
+			name:        "mesh without a control plane in the namespace given",
+			meshName:    "testMesh1",
+			namespace:   "testNs2",
+			expectedErr: true,
+		},
+		{
+			name:        "mesh not found",
+			meshName:    "testMesh3",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakeClientSet := fake.NewSimpleClientset()
+			for ns, meshName := range map[string]string{"testNs1": "testMesh1", "testNs2": "testMesh2", "testNs3": "testMesh2"} {
+				_, err := fakeClientSet.AppsV1().Deployments(ns).Create(context.TODO(), createDeployment("osm-controller", meshName, "testVersion0.1.2", true), metav1.CreateOptions{})
+				trequire.Nil(t, err)
+			}
+
+			dir, err := ioutil.TempDir("", "osm-cli")
+			trequire.Nil(t, err)
+			defer os.RemoveAll(dir) //nolint: errcheck,gosec
+			configPath := filepath.Join(dir, "config.yaml")
+			if tc.persisted != nil {
+				trequire.Nil(t, tc.persisted.Save(configPath))
+			}
+
+			out := new(bytes.Buffer)
+			useCmd := &meshUseCmd{
+				out:         out,
+				meshName:    tc.meshName,
+				namespace:   tc.namespace,
+				configPath:  configPath,
+				kubeContext: "test-context",
+				clientSet:   fakeClientSet,
+			}
+
+			err = useCmd.run()
+			assert.Equal(tc.expectedErr, err != nil, "%v", err)
+
+			config, err := cli.LoadConfig(configPath)
+			trequire.Nil(t, err)
+			if tc.expectedErr {
+				if tc.persisted == nil {
+					tc.persisted = &cli.Config{}
+				}
+				assert.Equal(tc.persisted, config)
+				return
+			}
+			expected := tc.persisted
+			if expected == nil {
+				expected = &cli.Config{}
+			}
+			expected.SetMeshContext("test-context", cli.MeshContext{MeshName: tc.meshName, OSMNamespace: tc.expectedNamespace})
+			assert.Equal(expected, config)
+			assert.Contains(out.String(), tc.expectedNamespace)
+		})
+	}
+}
@@ -21,6 +21,16 @@ control plane with the given mesh name can observe resources within that namespa
 or set of namespaces. It also enables automatic sidecar injection for all pods
 created within the given namespace. Automatic sidecar injection can be disabled
 via the --disable-sidecar-injection flag.
+
+The namespaces matching the label selector given with the --selector flag are
+added in addition to the namespaces given as arguments. The matching namespaces
+that are already monitored by another mesh or that are ignored are skipped,
+unless the --force flag is set.
+
+With the --report flag, the namespaces are not modified. Instead, the command
+lists, per namespace, how many pods run with a sidecar, how many pods run
+without a sidecar and will be injected once restarted, and how many workloads
+cannot create pods because the sidecar injection fails.
 `
 const namespaceAddExample = `
 # Add namespace 'test' to the mesh with automatic sidecar injection enabled.
@@ -36,6 +46,16 @@ osm namespace add test foo bar baz
 # Specify which mesh (osm control plane) to add the namespace if multiple control planes
 are present or mesh name was overridden at install time
 osm namespace add test --mesh-name=<my-mesh-name>
+
+# Add all the namespaces labeled 'team=bookstore' to the mesh.
+osm namespace add --selector team=bookstore
+
+# Add all the namespaces labeled 'team=bookstore' to the mesh, including the ones
+# monitored by another mesh or ignored.
+osm namespace add --selector team=bookstore --force
+
+# Report the onboarding progress of the namespaces labeled 'team=bookstore'.
+osm namespace add --selector team=bookstore --report
 `
 
 type namespaceAddCmd struct {
@@ -43,6 +63,9 @@ type namespaceAddCmd struct {
 	namespaces              []string
 	meshName                string
 	disableSidecarInjection bool
+	selector                string
+	report                  bool
+	force                   bool
 	clientSet               kubernetes.Interface
 }
 
@@ -55,7 +78,12 @@ func newNamespaceAdd(out io.Writer) *cobra.Command {
 		Use:   "add NAMESPACE ...",
 		Short: "add namespace to mesh",
 		Long:  namespaceAddDescription,
-		Args:  cobra.MinimumNArgs(1),
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 && namespaceAdd.selector == "" {
+				return errors.New("requires at least 1 namespace or a --selector")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			namespaceAdd.namespaces = args
 			config, err := settings.RESTClientGetter().ToRESTConfig()
@@ -75,19 +103,53 @@ func newNamespaceAdd(out io.Writer) *cobra.Command {
 
 	//add mesh name flag
 	f := cmd.Flags()
-	f.StringVar(&namespaceAdd.meshName, "mesh-name", "osm", "Name of the service mesh")
+	f.StringVar(&namespaceAdd.meshName, "mesh-name", settings.MeshName(), "Name of the service mesh")
 
 	//add sidecar injection flag
 	f.BoolVar(&namespaceAdd.disableSidecarInjection, "disable-sidecar-injection", false, "Disable automatic sidecar injection")
 
+	f.StringVarP(&namespaceAdd.selector, "selector", "l", "", "Label selector of the namespaces to add, in addition to the namespaces given as arguments")
+	f.BoolVar(&namespaceAdd.report, "report", false, "Report the readiness of the pods of the namespaces instead of adding them")
+	f.BoolVar(&namespaceAdd.force, "force", false, "Add the namespaces matching the selector even if they are monitored by another mesh or ignored")
+
 	return cmd
 }
 
 func (a *namespaceAddCmd) run() error {
+	namespaces, err := getTargetNamespaces(a.clientSet, a.namespaces, a.selector)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) == 0 {
+		fmt.Fprintf(a.out, "No namespaces matching selector [%s]\n", a.selector)
+		return nil
+	}
+
+	if a.report {
+		return printNamespaceReadinessReport(a.out, a.clientSet, namespaces)
+	}
+
+	explicit := make(map[string]bool)
 	for _, ns := range a.namespaces {
+		explicit[ns] = true
+	}
+
+	for _, ns := range namespaces {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
+		// namespaces matching the selector are only added if they are not claimed by another mesh or ignored,
+		// the namespaces given as arguments are always added
+		if !explicit[ns] && !a.force {
+			skip, err := a.skipSelectedNamespace(ctx, ns)
+			if err != nil {
+				return err
+			}
+			if skip {
+				continue
+			}
+		}
+
 		deploymentsClient := a.clientSet.AppsV1().Deployments(ns)
 		labelSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": constants.OSMControllerName}}
 
@@ -142,3 +204,22 @@ func (a *namespaceAddCmd) run() error {
 
 	return nil
 }
+
+// skipSelectedNamespace returns whether the given namespace matching the selector must not be added to the mesh
+// because it is monitored by another mesh or is ignored, and prints the reason if so
+func (a *namespaceAddCmd) skipSelectedNamespace(ctx context.Context, name string) (bool, error) {
+	ns, err := a.clientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, errors.Errorf("Could not get namespace [%s]: %v", name, err)
+	}
+
+	if meshName, ok := ns.Labels[constants.OSMKubeResourceMonitorAnnotation]; ok && meshName != a.meshName {
+		_, _ = fmt.Fprintf(a.out, "Namespace [%s] is already monitored by mesh [%s] and was not added to mesh [%s], use --force to add it\n", name, meshName, a.meshName)
+		return true, nil
+	}
+	if ns.Labels[ignoreLabel] == "true" {
+		_, _ = fmt.Fprintf(a.out, "Namespace [%s] is ignored and was not added to mesh [%s], use --force to add it\n", name, a.meshName)
+		return true, nil
+	}
+	return false, nil
+}
@@ -15,15 +15,34 @@ import (
 )
 
 const namespaceRemoveDescription = `
-This command will remove a namespace from the mesh. All
-services in this namespace will be removed from the mesh.
+This command will remove a namespace or set of namespaces from the mesh. All
+services in these namespaces will be removed from the mesh.
+
+The namespaces of the mesh matching the label selector given with the --selector
+flag are removed in addition to the namespaces given as arguments.
+
+With the --report flag, the namespaces are not modified. Instead, the command
+lists, per namespace, how many pods still run with a sidecar.
+`
+
+const namespaceRemoveExample = `
+# Remove namespace 'test' from the mesh.
+osm namespace remove test
+
+# Remove all the namespaces of the mesh labeled 'team=bookstore'.
+osm namespace remove --selector team=bookstore
+
+# Report the pods still running with a sidecar in the namespaces labeled 'team=bookstore'.
+osm namespace remove --selector team=bookstore --report
 `
 
 type namespaceRemoveCmd struct {
-	out       io.Writer
-	namespace string
-	meshName  string
-	clientSet kubernetes.Interface
+	out        io.Writer
+	namespaces []string
+	meshName   string
+	selector   string
+	report     bool
+	clientSet  kubernetes.Interface
 }
 
 func newNamespaceRemove(out io.Writer) *cobra.Command {
@@ -32,12 +51,17 @@ func newNamespaceRemove(out io.Writer) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "remove <NAMESPACE>",
+		Use:   "remove NAMESPACE ...",
 		Short: "remove namespace from mesh",
 		Long:  namespaceRemoveDescription,
-		Args:  cobra.ExactArgs(1),
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) == 0 && namespaceRemove.selector == "" {
+				return errors.New("requires at least 1 namespace or a --selector")
+			}
+			return nil
+		},
 		RunE: func(_ *cobra.Command, args []string) error {
-			namespaceRemove.namespace = args[0]
+			namespaceRemove.namespaces = args
 			config, err := settings.RESTClientGetter().ToRESTConfig()
 			if err != nil {
 				return errors.Errorf("Error fetching kubeconfig: %s", err)
@@ -50,23 +74,54 @@ func newNamespaceRemove(out io.Writer) *cobra.Command {
 			namespaceRemove.clientSet = clientset
 			return namespaceRemove.run()
 		},
+		Example: namespaceRemoveExample,
 	}
 
 	//add mesh name flag
 	f := cmd.Flags()
-	f.StringVar(&namespaceRemove.meshName, "mesh-name", "osm", "Name of the service mesh")
+	f.StringVar(&namespaceRemove.meshName, "mesh-name", settings.MeshName(), "Name of the service mesh")
+
+	f.StringVarP(&namespaceRemove.selector, "selector", "l", "", "Label selector of the namespaces of the mesh to remove, in addition to the namespaces given as arguments")
+	f.BoolVar(&namespaceRemove.report, "report", false, "Report the readiness of the pods of the namespaces instead of removing them")
 
 	return cmd
 }
 
 func (r *namespaceRemoveCmd) run() error {
+	// Only the namespaces of the mesh are selected, namespaces of other meshes are not removed in bulk
+	var selector string
+	if r.selector != "" {
+		selector = fmt.Sprintf("%s=%s,%s", constants.OSMKubeResourceMonitorAnnotation, r.meshName, r.selector)
+	}
+	namespaces, err := getTargetNamespaces(r.clientSet, r.namespaces, selector)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) == 0 {
+		fmt.Fprintf(r.out, "No namespaces of mesh [%s] matching selector [%s]\n", r.meshName, r.selector)
+		return nil
+	}
+
+	if r.report {
+		return printNamespaceReadinessReport(r.out, r.clientSet, namespaces)
+	}
+
+	for _, ns := range namespaces {
+		if err := r.removeNamespace(ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *namespaceRemoveCmd) removeNamespace(name string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	namespace, err := r.clientSet.CoreV1().Namespaces().Get(ctx, r.namespace, metav1.GetOptions{})
+	namespace, err := r.clientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 
 	if err != nil {
-		return errors.Errorf("Could not get namespace [%s]: %v", r.namespace, err)
+		return errors.Errorf("Could not get namespace [%s]: %v", name, err)
 	}
 
 	val, exists := namespace.ObjectMeta.Labels[constants.OSMKubeResourceMonitorAnnotation]
@@ -87,18 +142,18 @@ func (r *namespaceRemoveCmd) run() error {
 	}
 }`, constants.OSMKubeResourceMonitorAnnotation, constants.SidecarInjectionAnnotation)
 
-			_, err = r.clientSet.CoreV1().Namespaces().Patch(ctx, r.namespace, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}, "")
+			_, err = r.clientSet.CoreV1().Namespaces().Patch(ctx, name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{}, "")
 
 			if err != nil {
-				return errors.Errorf("Could not remove namespace [%s] from mesh [%s]: %v", r.namespace, r.meshName, err)
+				return errors.Errorf("Could not remove namespace [%s] from mesh [%s]: %v", name, r.meshName, err)
 			}
 
-			fmt.Fprintf(r.out, "Namespace [%s] successfully removed from mesh [%s]\n", r.namespace, r.meshName)
+			fmt.Fprintf(r.out, "Namespace [%s] successfully removed from mesh [%s]\n", name, r.meshName)
 		} else {
 			return errors.Errorf("Namespace belongs to mesh [%s], not mesh [%s]. Please specify the correct mesh", val, r.meshName)
 		}
 	} else {
-		fmt.Fprintf(r.out, "Namespace [%s] already does not belong to any mesh\n", r.namespace)
+		fmt.Fprintf(r.out, "Namespace [%s] already does not belong to any mesh\n", name)
 		return nil
 	}
 
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/injector"
+)
+
+// failedCreateEventReason is the reason of the events emitted by the controllers of the pods whose creation failed
+const failedCreateEventReason = "FailedCreate"
+
+// namespaceReadiness is the readiness of the pods of a namespace onboarded to a mesh
+type namespaceReadiness struct {
+	// injected is the number of pods running with a sidecar
+	injected int
+
+	// pendingRestart is the number of pods running without a sidecar that will be injected when they are restarted
+	pendingRestart int
+
+	// failingInjection is the number of workloads whose pods cannot be created because the sidecar injection failed
+	failingInjection int
+}
+
+// getTargetNamespaces returns the given namespaces followed by the namespaces matching the given label selector, if any
+func getTargetNamespaces(clientSet kubernetes.Interface, namespaces []string, selector string) ([]string, error) {
+	if selector == "" {
+		return namespaces, nil
+	}
+
+	list, err := clientSet.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Errorf("Could not list namespaces matching selector [%s]: %v", selector, err)
+	}
+
+	targets := append([]string{}, namespaces...)
+	seen := make(map[string]bool)
+	for _, ns := range namespaces {
+		seen[ns] = true
+	}
+	var selected []string
+	for _, ns := range list.Items {
+		if !seen[ns.Name] {
+			selected = append(selected, ns.Name)
+			seen[ns.Name] = true
+		}
+	}
+	sort.Strings(selected)
+
+	return append(targets, selected...), nil
+}
+
+// printNamespaceReadinessReport prints the readiness of the pods of the given namespaces
+func printNamespaceReadinessReport(out io.Writer, clientSet kubernetes.Interface, namespaces []string) error {
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "NAMESPACE\tMESH\tSIDECAR-INJECTION\tINJECTED\tPENDING-RESTART\tFAILING-INJECTION")
+	for _, name := range namespaces {
+		ns, err := clientSet.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Errorf("Could not get namespace [%s]: %v", name, err)
+		}
+
+		readiness, err := getNamespaceReadiness(clientSet, ns)
+		if err != nil {
+			return err
+		}
+
+		meshName, ok := ns.Labels[constants.OSMKubeResourceMonitorAnnotation]
+		if !ok {
+			meshName = "-" // not in a mesh
+		}
+		sidecarInjection, ok := ns.Annotations[constants.SidecarInjectionAnnotation]
+		if !ok {
+			sidecarInjection = "-" // not set
+		}
+		if _, ignored := ns.Labels[ignoreLabel]; ignored {
+			sidecarInjection = "disabled (ignored)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n", ns.Name, meshName, sidecarInjection,
+			readiness.injected, readiness.pendingRestart, readiness.failingInjection)
+	}
+	return w.Flush()
+}
+
+// getNamespaceReadiness returns the readiness of the pods of the given namespace
+func getNamespaceReadiness(clientSet kubernetes.Interface, ns *corev1.Namespace) (namespaceReadiness, error) {
+	var readiness namespaceReadiness
+
+	pods, err := clientSet.CoreV1().Pods(ns.Name).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return readiness, errors.Errorf("Could not list pods in namespace [%s]: %v", ns.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if isMeshedPod(pod) {
+			readiness.injected++
+		} else if isInjectionExpected(ns, &pod) {
+			readiness.pendingRestart++
+		}
+	}
+
+	// The pods failing injection are never created, their failures are reported by the events of their controllers
+	events, err := clientSet.CoreV1().Events(ns.Name).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return readiness, errors.Errorf("Could not list events in namespace [%s]: %v", ns.Name, err)
+	}
+	failingWorkloads := make(map[corev1.ObjectReference]bool)
+	for _, event := range events.Items {
+		if event.Reason != failedCreateEventReason || !strings.Contains(event.Message, injector.MutatingWebhookName) {
+			continue
+		}
+		workload := corev1.ObjectReference{Kind: event.InvolvedObject.Kind, Name: event.InvolvedObject.Name}
+		failingWorkloads[workload] = true
+	}
+	readiness.failingInjection = len(failingWorkloads)
+
+	return readiness, nil
+}
+
+// isInjectionExpected returns whether a sidecar is injected in the given pod of the given namespace when it is created
+func isInjectionExpected(ns *corev1.Namespace, pod *corev1.Pod) bool {
+	if _, monitored := ns.Labels[constants.OSMKubeResourceMonitorAnnotation]; !monitored {
+		return false
+	}
+	if _, ignored := ns.Labels[ignoreLabel]; ignored {
+		return false
+	}
+
+	if podInject, ok := pod.Annotations[constants.SidecarInjectionAnnotation]; ok {
+		return isInjectionEnabled(podInject)
+	}
+	return isInjectionEnabled(ns.Annotations[constants.SidecarInjectionAnnotation])
+}
+
+// isInjectionEnabled returns whether the given value of the sidecar injection annotation enables the injection
+func isInjectionEnabled(annotation string) bool {
+	switch strings.ToLower(annotation) {
+	case "enabled", "yes", "true":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/injector"
+)
+
+func TestGetTargetNamespaces(t *testing.T) {
+	assert := tassert.New(t)
+
+	fakeClientSet := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-2", Labels: map[string]string{"team": "bookstore"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-1", Labels: map[string]string{"team": "bookstore"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns-3"}},
+	)
+
+	namespaces, err := getTargetNamespaces(fakeClientSet, []string{"ns-3"}, "")
+	assert.Nil(err)
+	assert.Equal([]string{"ns-3"}, namespaces)
+
+	// Namespaces given as arguments come first and are not repeated
+	namespaces, err = getTargetNamespaces(fakeClientSet, []string{"ns-3", "ns-2"}, "team=bookstore")
+	assert.Nil(err)
+	assert.Equal([]string{"ns-3", "ns-2", "ns-1"}, namespaces)
+}
+
+func TestGetNamespaceReadiness(t *testing.T) {
+	assert := tassert.New(t)
+
+	ns := createNamespaceSpec(testNamespace, testMeshName, true)
+	newPod := func(name string, labels, annotations map[string]string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: labels, Annotations: annotations},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+	newEvent := func(name, reason, message, workload string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Reason:         reason,
+			Message:        message,
+			InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: workload},
+		}
+	}
+	injectedLabels := map[string]string{constants.EnvoyUniqueIDLabelName: "abc"}
+	injectionError := `Error creating: Internal error occurred: failed calling webhook "` + injector.MutatingWebhookName + `"`
+
+	fakeClientSet := fake.NewSimpleClientset(ns,
+		newPod("injected", injectedLabels, nil, corev1.PodRunning),
+		newPod("pending", nil, nil, corev1.PodRunning),
+		newPod("opted-out", nil, map[string]string{constants.SidecarInjectionAnnotation: "disabled"}, corev1.PodRunning),
+		newPod("completed", nil, nil, corev1.PodSucceeded),
+		newEvent("failing-1", failedCreateEventReason, injectionError, "bookstore-v1"),
+		newEvent("failing-2", failedCreateEventReason, injectionError, "bookstore-v1"),
+		newEvent("failing-3", failedCreateEventReason, injectionError, "bookstore-v2"),
+		newEvent("quota", failedCreateEventReason, "Error creating: exceeded quota", "bookstore-v3"),
+	)
+
+	readiness, err := getNamespaceReadiness(fakeClientSet, ns)
+	assert.Nil(err)
+	assert.Equal(namespaceReadiness{injected: 1, pendingRestart: 1, failingInjection: 2}, readiness)
+
+	// Pods of a namespace that is not monitored are not expected to be injected
+	unmonitored := createNamespaceSpec(testNamespace, "", true)
+	readiness, err = getNamespaceReadiness(fakeClientSet, unmonitored)
+	assert.Nil(err)
+	assert.Equal(0, readiness.pendingRestart)
+}
+
+func TestPrintNamespaceReadinessReport(t *testing.T) {
+	assert := tassert.New(t)
+
+	fakeClientSet := fake.NewSimpleClientset(createNamespaceSpec(testNamespace, testMeshName, true))
+	out := new(bytes.Buffer)
+
+	err := printNamespaceReadinessReport(out, fakeClientSet, []string{testNamespace})
+	assert.Nil(err)
+	assert.Contains(out.String(), "NAMESPACE")
+	assert.Contains(out.String(), testMeshName)
+	assert.Contains(out.String(), "enabled")
+
+	err = printNamespaceReadinessReport(out, fakeClientSet, []string{"missing"})
+	assert.NotNil(err)
+}
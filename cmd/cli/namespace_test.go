@@ -204,6 +204,111 @@ var _ = Describe("Running the namespace add command", func() {
 			})
 		})
 
+		Context("given a label selector", func() {
+
+			BeforeEach(func() {
+				out = new(bytes.Buffer)
+				fakeClientSet = fake.NewSimpleClientset()
+
+				for _, name := range []string{"team-b", "team-a", "other"} {
+					nsSpec := createNamespaceSpec(name, "", false)
+					if name != "other" {
+						nsSpec.Labels["team"] = "bookstore"
+					}
+					_, err = fakeClientSet.CoreV1().Namespaces().Create(context.TODO(), nsSpec, metav1.CreateOptions{})
+					Expect(err).To(BeNil())
+				}
+
+				namespaceAddCmd := &namespaceAddCmd{
+					out:       out,
+					meshName:  testMeshName,
+					selector:  "team=bookstore",
+					clientSet: fakeClientSet,
+				}
+
+				err = namespaceAddCmd.run()
+			})
+
+			It("should not error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should add the matching namespaces in order", func() {
+				Expect(out.String()).To(Equal(fmt.Sprintf("Namespace [team-a] successfully added to mesh [%s]\nNamespace [team-b] successfully added to mesh [%s]\n", testMeshName, testMeshName)))
+			})
+
+			It("should not add the namespaces not matching the selector", func() {
+				ns, err := fakeClientSet.CoreV1().Namespaces().Get(context.TODO(), "other", metav1.GetOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(ns.Labels).ToNot(HaveKey(constants.OSMKubeResourceMonitorAnnotation))
+			})
+		})
+
+		Context("given a label selector matching namespaces monitored by another mesh or ignored", func() {
+			var force bool
+
+			JustBeforeEach(func() {
+				out = new(bytes.Buffer)
+				fakeClientSet = fake.NewSimpleClientset()
+
+				for name, meshName := range map[string]string{"team-a": "", "team-b": incorrectMeshName, "team-c": ""} {
+					nsSpec := createNamespaceSpec(name, meshName, false)
+					nsSpec.Labels["team"] = "bookstore"
+					if name == "team-c" {
+						nsSpec.Labels[ignoreLabel] = "true"
+					}
+					_, err = fakeClientSet.CoreV1().Namespaces().Create(context.TODO(), nsSpec, metav1.CreateOptions{})
+					Expect(err).To(BeNil())
+				}
+
+				namespaceAddCmd := &namespaceAddCmd{
+					out:       out,
+					meshName:  testMeshName,
+					selector:  "team=bookstore",
+					force:     force,
+					clientSet: fakeClientSet,
+				}
+
+				err = namespaceAddCmd.run()
+			})
+
+			Context("without --force", func() {
+				BeforeEach(func() {
+					force = false
+				})
+
+				It("should skip the namespaces monitored by another mesh or ignored", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(out.String()).To(Equal(fmt.Sprintf("Namespace [team-a] successfully added to mesh [%s]\n", testMeshName) +
+						fmt.Sprintf("Namespace [team-b] is already monitored by mesh [%s] and was not added to mesh [%s], use --force to add it\n", incorrectMeshName, testMeshName) +
+						fmt.Sprintf("Namespace [team-c] is ignored and was not added to mesh [%s], use --force to add it\n", testMeshName)))
+
+					ns, err := fakeClientSet.CoreV1().Namespaces().Get(context.TODO(), "team-b", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(ns.Labels[constants.OSMKubeResourceMonitorAnnotation]).To(Equal(incorrectMeshName))
+
+					ns, err = fakeClientSet.CoreV1().Namespaces().Get(context.TODO(), "team-c", metav1.GetOptions{})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(ns.Labels).ToNot(HaveKey(constants.OSMKubeResourceMonitorAnnotation))
+				})
+			})
+
+			Context("with --force", func() {
+				BeforeEach(func() {
+					force = true
+				})
+
+				It("should add all the matching namespaces", func() {
+					Expect(err).NotTo(HaveOccurred())
+					for _, name := range []string{"team-a", "team-b", "team-c"} {
+						ns, err := fakeClientSet.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(ns.Labels[constants.OSMKubeResourceMonitorAnnotation]).To(Equal(testMeshName))
+					}
+				})
+			})
+		})
+
 		Context("given one namespace with osm-controller installed in it as an arg", func() {
 			BeforeEach(func() {
 				out = new(bytes.Buffer)
@@ -282,10 +387,10 @@ var _ = Describe("Running the namespace remove command", func() {
 			Expect(err).To(BeNil())
 
 			namespaceRemoveCmd := &namespaceRemoveCmd{
-				out:       out,
-				meshName:  testMeshName,
-				namespace: testNamespace,
-				clientSet: fakeClientSet,
+				out:        out,
+				meshName:   testMeshName,
+				namespaces: []string{testNamespace},
+				clientSet:  fakeClientSet,
 			}
 
 			err = namespaceRemoveCmd.run()
@@ -322,10 +427,10 @@ var _ = Describe("Running the namespace remove command", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 			namespaceRemoveCmd := &namespaceRemoveCmd{
-				out:       out,
-				meshName:  testMeshName,
-				namespace: testNamespace,
-				clientSet: fakeClientSet,
+				out:        out,
+				meshName:   testMeshName,
+				namespaces: []string{testNamespace},
+				clientSet:  fakeClientSet,
 			}
 
 			err = namespaceRemoveCmd.run()
@@ -368,10 +473,10 @@ var _ = Describe("Running the namespace remove command", func() {
 			Expect(err).To(BeNil())
 
 			namespaceRemoveCmd := &namespaceRemoveCmd{
-				out:       out,
-				meshName:  incorrectMeshName,
-				namespace: testNamespace,
-				clientSet: fakeClientSet,
+				out:        out,
+				meshName:   incorrectMeshName,
+				namespaces: []string{testNamespace},
+				clientSet:  fakeClientSet,
 			}
 
 			err = namespaceRemoveCmd.run()
@@ -400,10 +505,10 @@ var _ = Describe("Running the namespace remove command", func() {
 			Expect(err).To(BeNil())
 
 			namespaceRemoveCmd := &namespaceRemoveCmd{
-				out:       out,
-				meshName:  testMeshName,
-				namespace: testNamespace,
-				clientSet: fakeClientSet,
+				out:        out,
+				meshName:   testMeshName,
+				namespaces: []string{testNamespace},
+				clientSet:  fakeClientSet,
 			}
 
 			err = namespaceRemoveCmd.run()
@@ -418,7 +523,7 @@ var _ = Describe("Running the namespace remove command", func() {
 		})
 	})
 
-	Describe("with non-existent namespace", func() {
+	Describe("with a label selector", func() {
 		var (
 			out           *bytes.Buffer
 			fakeClientSet kubernetes.Interface
@@ -429,16 +534,57 @@ var _ = Describe("Running the namespace remove command", func() {
 			out = new(bytes.Buffer)
 			fakeClientSet = fake.NewSimpleClientset()
 
+			for name, meshName := range map[string]string{"team-a": testMeshName, "team-b": incorrectMeshName} {
+				nsSpec := createNamespaceSpec(name, meshName, false)
+				nsSpec.Labels["team"] = "bookstore"
+				_, err = fakeClientSet.CoreV1().Namespaces().Create(context.TODO(), nsSpec, metav1.CreateOptions{})
+				Expect(err).To(BeNil())
+			}
+
 			namespaceRemoveCmd := &namespaceRemoveCmd{
 				out:       out,
 				meshName:  testMeshName,
-				namespace: testNamespace,
+				selector:  "team=bookstore",
 				clientSet: fakeClientSet,
 			}
 
 			err = namespaceRemoveCmd.run()
 		})
 
+		It("should not error", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should only remove the matching namespaces of the mesh", func() {
+			Expect(out.String()).To(Equal(fmt.Sprintf("Namespace [team-a] successfully removed from mesh [%s]\n", testMeshName)))
+
+			ns, err := fakeClientSet.CoreV1().Namespaces().Get(context.TODO(), "team-b", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ns.Labels[constants.OSMKubeResourceMonitorAnnotation]).To(Equal(incorrectMeshName))
+		})
+	})
+
+	Describe("with non-existent namespace", func() {
+		var (
+			out           *bytes.Buffer
+			fakeClientSet kubernetes.Interface
+			err           error
+		)
+
+		BeforeEach(func() {
+			out = new(bytes.Buffer)
+			fakeClientSet = fake.NewSimpleClientset()
+
+			namespaceRemoveCmd := &namespaceRemoveCmd{
+				out:        out,
+				meshName:   testMeshName,
+				namespaces: []string{testNamespace},
+				clientSet:  fakeClientSet,
+			}
+
+			err = namespaceRemoveCmd.run()
+		})
+
 		It("should error", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal(fmt.Sprintf("Could not get namespace [%s]: namespaces \"%s\" not found", testNamespace, testNamespace)))
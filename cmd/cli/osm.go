@@ -37,6 +37,7 @@ func newRootCmd(config *action.Configuration, in io.Reader, out io.Writer, args
 	// Add subcommands here
 	cmd.AddCommand(
 		newMeshCmd(config, in, out),
+		newCheckCmd(out),
 		newEnvCmd(out),
 		newInstallCmd(config, out),
 		newDashboardCmd(config, out),
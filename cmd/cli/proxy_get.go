@@ -98,19 +98,51 @@ func (cmd *proxyGetCmd) run() error {
 		return annotateErrMsgWithPodNamespaceMsg("Pod %s in namespace %s is not running", cmd.pod, cmd.namespace)
 	}
 
-	dialer, err := k8s.DialerToPod(cmd.config, cmd.clientSet, cmd.pod, cmd.namespace)
+	out := cmd.out // By default, output is written to stdout
+	if cmd.outFile != "" {
+		fd, err := os.Create(cmd.outFile)
+		if err != nil {
+			return errors.Errorf("Error opening file %s: %s", cmd.outFile, err)
+		}
+		defer fd.Close() //nolint: errcheck, gosec
+		out = fd         // write output to file
+	}
+
+	if err := queryProxyAdmin(cmd.config, cmd.clientSet, pod, cmd.query, cmd.localPort, out); err != nil {
+		return annotateErrMsgWithPodNamespaceMsg("Error retrieving proxy config for pod %s in namespace %s: %s", cmd.pod, cmd.namespace, err)
+	}
+
+	return nil
+}
+
+// queryProxyAdmin sends the given query to the admin interface of the Envoy sidecar of the given pod, and writes the
+// response to the given writer
+func queryProxyAdmin(config *rest.Config, clientSet kubernetes.Interface, pod *corev1.Pod, query string, localPort uint16, out io.Writer) error {
+	if k8s.IsEnvoyAdminPortExposed(pod) {
+		return getWithPortForward(config, clientSet, pod, query, localPort, out)
+	}
+
+	// The admin interface of the Envoy sidecar is bound to a Unix domain socket, reachable with osm-healthcheck
+	command := []string{constants.OSMHealthcheckPath, "admin", http.MethodGet, query}
+	return k8s.ExecInPod(config, clientSet, pod.Name, pod.Namespace, constants.EnvoyContainerName, command, out, os.Stderr)
+}
+
+// getWithPortForward sends the query to the admin port of the Envoy sidecar by port forwarding to the pod,
+// and writes the response to the given writer
+func getWithPortForward(config *rest.Config, clientSet kubernetes.Interface, pod *corev1.Pod, query string, localPort uint16, out io.Writer) error {
+	dialer, err := k8s.DialerToPod(config, clientSet, pod.Name, pod.Namespace)
 	if err != nil {
 		return err
 	}
 
-	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", cmd.localPort, constants.EnvoyAdminPort))
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, constants.EnvoyAdminPort))
 	if err != nil {
 		return errors.Errorf("Error setting up port forwarding: %s", err)
 	}
 
-	err = portForwarder.Start(func(pf *k8s.PortForwarder) error {
+	return portForwarder.Start(func(pf *k8s.PortForwarder) error {
 		defer pf.Stop()
-		url := fmt.Sprintf("http://localhost:%d/%s", cmd.localPort, cmd.query)
+		url := fmt.Sprintf("http://localhost:%d/%s", localPort, query)
 
 		// #nosec G107: Potential HTTP request made with variable url
 		resp, err := http.Get(url)
@@ -118,26 +150,11 @@ func (cmd *proxyGetCmd) run() error {
 			return errors.Errorf("Error fetching url %s: %s", url, err)
 		}
 
-		out := cmd.out // By default, output is written to stdout
-		if cmd.outFile != "" {
-			fd, err := os.Create(cmd.outFile)
-			if err != nil {
-				return errors.Errorf("Error opening file %s: %s", cmd.outFile, err)
-			}
-			defer fd.Close() //nolint: errcheck, gosec
-			out = fd         // write output to file
-		}
-
 		if _, err := io.Copy(out, resp.Body); err != nil {
 			return errors.Errorf("Error rendering HTTP response: %s", err)
 		}
 		return nil
 	})
-	if err != nil {
-		return annotateErrMsgWithPodNamespaceMsg("Error retrieving proxy config for pod %s in namespace %s: %s", cmd.pod, cmd.namespace, err)
-	}
-
-	return nil
 }
 
 // isMeshedPod returns a boolean indicating if the pod is part of a mesh
@@ -19,6 +19,8 @@ func newTrafficPolicyCmd(out io.Writer) *cobra.Command {
 		Args:  cobra.NoArgs,
 	}
 	cmd.AddCommand(newTrafficPolicyCheck(out))
+	cmd.AddCommand(newTrafficPolicyDeniedEgress(out))
+	cmd.AddCommand(newTrafficPolicyImport(out))
 
 	return cmd
 }
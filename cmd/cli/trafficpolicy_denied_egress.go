@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const trafficPolicyDeniedEgressDescription = `
+This command lists the egress connections and HTTP requests denied by the Envoy
+proxy sidecars of the pods in the mesh, grouped by source identity and
+destination. It helps identify the Egress policies missing after disabling
+egress mesh-wide.
+
+Connections and HTTP requests denied by an Egress policy with the 'Deny' action
+are reported with the host and port of the policy. The HTTP requests are counted
+individually, the connections are counted for the other protocols. Connections
+that do not match any policy are
+reported with the destination '*', as their host is unknown to the sidecar.
+Plain HTTP requests to hosts without an Egress policy on ports used by other
+Egress policies are rejected with a 404 response and are not counted.
+
+The counts are retrieved from the statistics of the running sidecars, and are
+reset when a pod restarts. They are also exported to Prometheus as the
+'envoy_egress_deny_rbac_denied' counter for connections, and as the
+'envoy_egress_deny_http_local_rate_limit_enabled' counter for HTTP requests.
+`
+
+const trafficPolicyDeniedEgressExample = `
+# List the egress connections and HTTP requests denied for the pods in all the namespaces
+osm policy denied-egress
+
+# List the egress connections and HTTP requests denied for the pods in the 'curl' namespace
+osm policy denied-egress -n curl
+`
+
+// egressDeniedStatsQuery is the admin query returning the statistics of the egress connections and HTTP requests
+// denied by a sidecar
+var egressDeniedStatsQuery = fmt.Sprintf("stats?filter=^%s", envoy.EgressDenyStatPrefix)
+
+type deniedEgressDestination struct {
+	source identity.K8sServiceAccount
+	host   string
+	port   string
+}
+
+type trafficPolicyDeniedEgressCmd struct {
+	out       io.Writer
+	clientSet kubernetes.Interface
+	namespace string
+	localPort uint16
+
+	// queryProxy sends the given query to the admin interface of the sidecar of the given pod
+	queryProxy func(pod *corev1.Pod, query string, out io.Writer) error
+}
+
+func newTrafficPolicyDeniedEgress(out io.Writer) *cobra.Command {
+	deniedEgressCmd := &trafficPolicyDeniedEgressCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "denied-egress",
+		Short: "list the egress connections and HTTP requests denied by the sidecars",
+		Long:  trafficPolicyDeniedEgressDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return errors.Errorf("Error fetching kubeconfig: %s", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return errors.Errorf("Could not access Kubernetes cluster, check kubeconfig: %s", err)
+			}
+			deniedEgressCmd.clientSet = clientset
+			deniedEgressCmd.queryProxy = func(pod *corev1.Pod, query string, out io.Writer) error {
+				return queryProxyAdmin(config, clientset, pod, query, deniedEgressCmd.localPort, out)
+			}
+
+			return deniedEgressCmd.run()
+		},
+		Example: trafficPolicyDeniedEgressExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&deniedEgressCmd.namespace, "namespace", "n", metav1.NamespaceAll, "Namespace of the pods, all namespaces if not specified")
+	f.Uint16VarP(&deniedEgressCmd.localPort, "local-port", "p", constants.EnvoyAdminPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *trafficPolicyDeniedEgressCmd) run() error {
+	pods, err := cmd.clientSet.CoreV1().Pods(cmd.namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: constants.EnvoyUniqueIDLabelName,
+	})
+	if err != nil {
+		return errors.Errorf("Could not list pods: %s", err)
+	}
+
+	denied := make(map[deniedEgressDestination]uint64)
+	var failedPods []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isMeshedPod(*pod) || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		var stats bytes.Buffer
+		if err := cmd.queryProxy(pod, egressDeniedStatsQuery, &stats); err != nil {
+			failedPods = append(failedPods, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			continue
+		}
+		addDeniedEgressStats(denied, &stats)
+	}
+
+	if len(denied) == 0 {
+		fmt.Fprintf(cmd.out, "No denied egress connections or HTTP requests found\n")
+	} else {
+		printDeniedEgress(cmd.out, denied)
+	}
+
+	if len(failedPods) > 0 {
+		fmt.Fprintf(cmd.out, "\nCould not retrieve the statistics of the sidecars of pods: %s\n", strings.Join(failedPods, ", "))
+	}
+
+	return nil
+}
+
+// addDeniedEgressStats adds the counts of the denied egress connections and HTTP requests in the given statistics output by the admin
+// interface of a sidecar, of the form '<name>: <value>', to the given counts
+func addDeniedEgressStats(denied map[deniedEgressDestination]uint64, stats io.Reader) {
+	scanner := bufio.NewScanner(stats)
+	for scanner.Scan() {
+		chunks := strings.SplitN(scanner.Text(), ": ", 2)
+		if len(chunks) != 2 {
+			continue
+		}
+		source, host, port, ok := envoy.ParseEgressDeniedStat(chunks[0])
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseUint(strings.TrimSpace(chunks[1]), 10, 64)
+		if err != nil || count == 0 {
+			continue
+		}
+		denied[deniedEgressDestination{source: source, host: host, port: port}] += count
+	}
+}
+
+// printDeniedEgress prints the given counts of denied egress connections and HTTP requests, from the highest count to the lowest
+func printDeniedEgress(out io.Writer, denied map[deniedEgressDestination]uint64) {
+	var destinations []deniedEgressDestination
+	for destination := range denied {
+		destinations = append(destinations, destination)
+	}
+	sort.Slice(destinations, func(i, j int) bool {
+		a, b := destinations[i], destinations[j]
+		if denied[a] != denied[b] {
+			return denied[a] > denied[b]
+		}
+		if a.source != b.source {
+			return a.source.String() < b.source.String()
+		}
+		if a.host != b.host {
+			return a.host < b.host
+		}
+		return a.port < b.port
+	})
+
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "SOURCE IDENTITY\tDESTINATION HOST\tDESTINATION PORT\tDENIED")
+	for _, destination := range destinations {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", destination.source, destination.host, destination.port, denied[destination])
+	}
+	_ = w.Flush()
+}
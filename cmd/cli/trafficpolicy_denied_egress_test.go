@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestTrafficPolicyDeniedEgress(t *testing.T) {
+	newPod := func(namespace, name string, meshed bool, phase corev1.PodPhase) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if meshed {
+			pod.Labels[constants.EnvoyUniqueIDLabelName] = "uid"
+		}
+		return pod
+	}
+
+	stats := map[string]string{
+		"curl/curl-1": `egress-deny|curl/curl|*|*.rbac.allowed: 0
+egress-deny|curl/curl|*|*.rbac.denied: 3
+egress-deny|curl/curl|*|*.rbac.shadow_allowed: 0
+egress-deny|curl/curl|pastebin.com|443.rbac.denied: 1
+`,
+		"curl/curl-2": `egress-deny|curl/curl|*|*.rbac.denied: 2
+egress-deny|curl/curl|example.com|443.rbac.denied: 0
+egress-deny|curl/curl|httpbin.org|80.http_local_rate_limit.enabled: 2
+egress-deny|curl/curl|httpbin.org|80.http_local_rate_limit.ok: 2
+egress-deny|curl/curl|httpbin.org|80.http_local_rate_limit.rate_limited: 0
+`,
+		"bookstore/bookstore-1": `egress-deny|bookstore/bookstore|pastebin.com|443.rbac.denied: 4
+`,
+		"bookbuyer/bookbuyer-1": `egress-deny|bookbuyer/bookbuyer|*|*.rbac.denied: 100
+`,
+	}
+
+	testCases := []struct {
+		name           string
+		namespace      string
+		pods           []*corev1.Pod
+		expectedOutput string
+	}{
+		{
+			name:      "all namespaces",
+			namespace: metav1.NamespaceAll,
+			pods: []*corev1.Pod{
+				newPod("curl", "curl-1", true, corev1.PodRunning),
+				newPod("curl", "curl-2", true, corev1.PodRunning),
+				newPod("curl", "curl-3", true, corev1.PodRunning),
+				newPod("bookstore", "bookstore-1", true, corev1.PodRunning),
+				newPod("bookbuyer", "bookbuyer-1", true, corev1.PodPending),
+				newPod("bookbuyer", "bookbuyer-2", false, corev1.PodRunning),
+			},
+			expectedOutput: `SOURCE IDENTITY       DESTINATION HOST   DESTINATION PORT   DENIED
+curl/curl             *                  *                  5
+bookstore/bookstore   pastebin.com       443                4
+curl/curl             httpbin.org        80                 2
+curl/curl             pastebin.com       443                1
+
+Could not retrieve the statistics of the sidecars of pods: curl/curl-3
+`,
+		},
+		{
+			name:      "no denied connections in namespace",
+			namespace: "bookbuyer",
+			pods: []*corev1.Pod{
+				newPod("curl", "curl-1", true, corev1.PodRunning),
+				newPod("bookbuyer", "bookbuyer-1", true, corev1.PodPending),
+			},
+			expectedOutput: "No denied egress connections or HTTP requests found\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			fakeClientSet := fake.NewSimpleClientset()
+			for _, pod := range tc.pods {
+				_, err := fakeClientSet.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+				assert.Nil(err)
+			}
+
+			out := new(bytes.Buffer)
+			cmd := &trafficPolicyDeniedEgressCmd{
+				out:       out,
+				clientSet: fakeClientSet,
+				namespace: tc.namespace,
+				queryProxy: func(pod *corev1.Pod, query string, out io.Writer) error {
+					assert.Equal("stats?filter=^egress-deny", query)
+					podStats, ok := stats[pod.Namespace+"/"+pod.Name]
+					if !ok {
+						return errors.New("connection refused")
+					}
+					_, err := out.Write([]byte(podStats))
+					return err
+				},
+			}
+
+			assert.Nil(cmd.run())
+			assert.Equal(tc.expectedOutput, out.String())
+		})
+	}
+}
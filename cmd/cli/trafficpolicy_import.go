@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	"github.com/openservicemesh/osm/pkg/policyimport"
+)
+
+const trafficPolicyImportDescription = `
+This command converts Istio and Kubernetes policies into equivalent SMI and OSM
+policies, to ease the migration of applications onto OSM. It does not require
+access to a cluster. The following resources are converted:
+
+- Istio ServiceEntries describing external hosts are converted into Egress
+  policies from the pods of their namespace.
+- Istio VirtualServices splitting the requests to a service between several
+  services are converted into SMI TrafficSplits.
+- Istio ALLOW AuthorizationPolicies are converted into SMI TrafficTargets and
+  HTTPRouteGroups.
+- The ingress rules of Kubernetes NetworkPolicies from pods are converted into
+  SMI TrafficTargets and TCPRoutes, and their egress rules to IP blocks are
+  converted into Egress policies.
+
+The service accounts of the pods selected by AuthorizationPolicies and
+NetworkPolicies are resolved from the Pods, Deployments, StatefulSets,
+DaemonSets, ReplicaSets and Jobs of the given manifests, which must include
+the workloads the policies apply to.
+
+Rules using constructs without an equivalent, such as IP blocks or request
+conditions, are not converted rather than partially converted, so that the
+converted policies never allow more traffic than the original policies. They
+are reported as comments at the top of the output, which must be reviewed
+before applying the converted policies.
+`
+
+const trafficPolicyImportExample = `
+# Convert the Istio policies and workloads of a directory of manifests
+cat manifests/*.yaml | osm policy import -f -
+
+# Convert the NetworkPolicies and Deployments of the 'bookstore' namespace and apply the converted policies
+kubectl get networkpolicies,deployments -n bookstore -o yaml > bookstore.yaml
+osm policy import -f bookstore.yaml | kubectl apply -f -
+`
+
+type trafficPolicyImportCmd struct {
+	out       io.Writer
+	in        io.Reader
+	filenames []string
+}
+
+func newTrafficPolicyImport(out io.Writer) *cobra.Command {
+	importCmd := &trafficPolicyImportCmd{
+		out: out,
+		in:  os.Stdin,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "convert Istio and Kubernetes policies into SMI and OSM policies",
+		Long:  trafficPolicyImportDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if len(importCmd.filenames) == 0 {
+				return errors.New("At least one manifest file must be specified with --filename")
+			}
+			return importCmd.run()
+		},
+		Example: trafficPolicyImportExample,
+	}
+
+	f := cmd.Flags()
+	f.StringArrayVarP(&importCmd.filenames, "filename", "f", nil, "Manifest file of the resources to convert, '-' to read from standard input. Can be specified multiple times")
+
+	return cmd
+}
+
+func (cmd *trafficPolicyImportCmd) run() error {
+	var manifests bytes.Buffer
+	for _, filename := range cmd.filenames {
+		var content []byte
+		var err error
+		if filename == "-" {
+			content, err = ioutil.ReadAll(cmd.in)
+		} else {
+			content, err = ioutil.ReadFile(filename) // #nosec G304: file inclusion via variable
+		}
+		if err != nil {
+			return errors.Errorf("Error reading %s: %s", filename, err)
+		}
+		// Separate the documents of the files
+		fmt.Fprintf(&manifests, "\n---\n%s\n", content)
+	}
+
+	result, err := policyimport.Import(&manifests)
+	if err != nil {
+		return errors.Errorf("Error converting the policies: %s", err)
+	}
+
+	if len(result.Issues) > 0 {
+		fmt.Fprintf(cmd.out, "# The following constructs could not be converted:\n")
+		for _, issue := range result.Issues {
+			fmt.Fprintf(cmd.out, "# - %s\n", issue)
+		}
+	}
+	if len(result.Objects) == 0 {
+		fmt.Fprintf(cmd.out, "# No policy was converted\n")
+		return nil
+	}
+
+	printer := &printers.YAMLPrinter{}
+	for _, obj := range result.Objects {
+		if err := printer.PrintObj(obj, cmd.out); err != nil {
+			return errors.Errorf("Error printing the converted policies: %s", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestTrafficPolicyImport(t *testing.T) {
+	assert := tassert.New(t)
+
+	dir, err := ioutil.TempDir("", "osm-policy-import")
+	assert.NoError(err)
+	defer os.RemoveAll(dir) //nolint: errcheck
+
+	serviceEntry := filepath.Join(dir, "service-entry.yaml")
+	assert.NoError(ioutil.WriteFile(serviceEntry, []byte(`
+apiVersion: networking.istio.io/v1beta1
+kind: ServiceEntry
+metadata:
+  name: httpbin
+  namespace: curl
+spec:
+  hosts:
+  - httpbin.org
+  exportTo:
+  - "."
+  ports:
+  - number: 443
+    protocol: TLS
+`), 0600))
+
+	stdin := `
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: deny
+  namespace: curl
+spec:
+  action: DENY
+`
+
+	out := new(bytes.Buffer)
+	cmd := &trafficPolicyImportCmd{
+		out:       out,
+		in:        strings.NewReader(stdin),
+		filenames: []string{serviceEntry, "-"},
+	}
+	assert.NoError(cmd.run())
+	assert.Equal(`# The following constructs could not be converted:
+# - AuthorizationPolicy curl/deny: action DENY has no equivalent, only ALLOW policies can be converted
+apiVersion: policy.openservicemesh.io/v1alpha1
+kind: Egress
+metadata:
+  creationTimestamp: null
+  name: httpbin
+  namespace: curl
+spec:
+  hosts:
+  - httpbin.org
+  ports:
+  - number: 443
+    protocol: https
+  sources:
+  - kind: Pod
+    namespace: curl
+    selector: {}
+status: {}
+`, out.String())
+
+	out.Reset()
+	cmd.in = strings.NewReader(stdin)
+	cmd.filenames = []string{"-"}
+	assert.NoError(cmd.run())
+	assert.Contains(out.String(), "# No policy was converted\n")
+
+	cmd.filenames = []string{filepath.Join(dir, "missing.yaml")}
+	assert.Error(cmd.run())
+}
@@ -61,7 +61,7 @@ func newUninstallCmd(config *action.Configuration, in io.Reader, out io.Writer)
 
 	f := cmd.Flags()
 	//add mesh name flag
-	f.StringVar(&uninstall.meshName, "mesh-name", defaultMeshName, "Name of the service mesh")
+	f.StringVar(&uninstall.meshName, "mesh-name", settings.MeshName(), "Name of the service mesh")
 	//add force uninstall flag
 	f.BoolVarP(&uninstall.force, "force", "f", false, "Attempt to uninstall the osm control plane instance without prompting for confirmation.  If the control plane with specified mesh name does not exist, do not display a diagnostic message or modify the exit status to reflect an error.")
 	//add uninstall namespace flag
@@ -12,8 +12,10 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	smiTrafficSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 	"github.com/spf13/pflag"
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,26 +32,50 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/debugger"
+	"github.com/openservicemesh/osm/pkg/diagnostics"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
 	"github.com/openservicemesh/osm/pkg/envoy/ads"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/featureflags"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	policyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/httpserver"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/injector"
+	"github.com/openservicemesh/osm/pkg/job"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/leader"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
 	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/rollout"
 	"github.com/openservicemesh/osm/pkg/signals"
+	"github.com/openservicemesh/osm/pkg/sizing"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/version"
 )
 
 const (
 	xdsServerCertificateCommonName = "ads"
+
+	// rolloutCheckInterval is the interval at which the rollouts of TrafficSplits are analyzed
+	rolloutCheckInterval = 10 * time.Second
+
+	// sidecarSizingCheckInterval is the interval at which the resource usage of the Envoy sidecars is observed
+	sidecarSizingCheckInterval = 1 * time.Minute
+
+	// diagnosticsReportInterval is the interval at which the recurring errors of osm-controller are reported
+	diagnosticsReportInterval = 30 * time.Second
+
+	// egressConflictReportInterval is the interval at which the conflicts between Egress policies are reported
+	egressConflictReportInterval = 30 * time.Second
+
+	// grpcBootstrapCertCheckInterval is the interval at which the workload certificates of proxyless gRPC pods are checked for rotation
+	grpcBootstrapCertCheckInterval = 5 * time.Minute
 )
 
 var (
@@ -70,6 +96,20 @@ var (
 	// feature flag options
 	optionalFeatures featureflags.OptionalFeatures
 
+	// Address of the Prometheus server used to analyze rollouts
+	rolloutPrometheusAddress string
+
+	// Bounds of the resource requests recommended for Envoy sidecars, and whether they are applied
+	sidecarSizingAutoApply bool
+	sidecarMinCPU          string
+	sidecarMaxCPU          string
+	sidecarMinMemory       string
+	sidecarMaxMemory       string
+
+	// External message broker relaying announcements between the replicas of osm-controller
+	messageBroker    string
+	messageBrokerURL string
+
 	scheme = runtime.NewScheme()
 )
 
@@ -106,6 +146,24 @@ func init() {
 	// feature flags
 	flags.BoolVar(&optionalFeatures.WASMStats, "stats-wasm-experimental", false, "Enable a WebAssembly module that generates additional Envoy statistics")
 	flags.BoolVar(&optionalFeatures.EgressPolicy, "enable-egress-policy", false, "Enable OSM's Egress policy API")
+	flags.BoolVar(&optionalFeatures.ProxylessGRPC, "enable-proxyless-grpc", false, "Enable gRPC applications using the xDS client to connect to OSM without a sidecar")
+	flags.BoolVar(&optionalFeatures.ProgressiveDelivery, "enable-progressive-delivery", false, "Enable progressive delivery for TrafficSplits annotated for it")
+	flags.BoolVar(&optionalFeatures.IngressGateway, "enable-ingress-gateway", false, "Enable the OSM managed ingress gateway for ingress resources using the osm ingress class")
+	flags.BoolVar(&optionalFeatures.SidecarSizing, "enable-sidecar-sizing", false, "Enable recommending the resource requests of the Envoy sidecars of each namespace based on their observed usage")
+
+	// Progressive delivery options
+	flags.StringVar(&rolloutPrometheusAddress, "rollout-prometheus-address", "", "Address of the Prometheus server used to analyze the rollouts of TrafficSplits")
+
+	// Sidecar sizing options
+	flags.BoolVar(&sidecarSizingAutoApply, "sidecar-sizing-auto-apply", false, "Apply the recommended resource requests to the Envoy sidecars injected into new pods")
+	flags.StringVar(&sidecarMinCPU, "sidecar-sizing-min-cpu", "10m", "Minimum CPU request recommended for Envoy sidecars")
+	flags.StringVar(&sidecarMaxCPU, "sidecar-sizing-max-cpu", "1", "Maximum CPU request recommended for Envoy sidecars")
+	flags.StringVar(&sidecarMinMemory, "sidecar-sizing-min-memory", "32Mi", "Minimum memory request recommended for Envoy sidecars")
+	flags.StringVar(&sidecarMaxMemory, "sidecar-sizing-max-memory", "1Gi", "Maximum memory request recommended for Envoy sidecars")
+
+	// Message broker options
+	flags.StringVar(&messageBroker, "message-broker", "", fmt.Sprintf("External message broker relaying announcements between the replicas of osm-controller, one of [%v]; announcements are not relayed if empty", events.RelayKinds))
+	flags.StringVar(&messageBrokerURL, "message-broker-url", "", "URL of the external message broker")
 
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = admissionv1.AddToScheme(scheme)
@@ -156,9 +214,23 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Elect the replica of osm-controller running the components that must act on a single replica at a time
+	elector := leader.NewElector(kubeClient, osmNamespace, controllerPod.Name)
+	elector.Start(stop)
+
 	// Start the default metrics store
 	startMetricsStore()
 
+	if messageBroker == events.NATSRelayKind {
+		relay, err := events.NewNATSRelay(messageBrokerURL, meshName, controllerPod.Name)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error connecting to the message broker")
+		}
+		if err := events.SetRelay(relay, stop); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error relaying announcements through the message broker")
+		}
+	}
+
 	// This component will be watching the OSM ConfigMap and will make it
 	// to the rest of the components.
 	cfg := configurator.NewConfigurator(kubernetes.NewForConfigOrDie(kubeConfig), stop, osmNamespace, osmConfigMapName)
@@ -193,6 +265,9 @@ func main() {
 
 	endpointsProviders := []endpoint.Provider{kubeProvider}
 
+	// SMI TrafficTarget sources may reference SPIFFE IDs in addition to Kubernetes service accounts
+	identityProviders := []identity.Provider{identity.NewSPIFFEProvider()}
+
 	ingressClient, err := ingress.NewIngressClient(kubeClient, kubernetesClient, stop, cfg)
 	if err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Ingress monitor client")
@@ -203,6 +278,11 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating controller for policy.openservicemesh.io")
 	}
 
+	// Report the conflicts between Egress policies in their status
+	if featureflags.IsEgressPolicyEnabled() {
+		policy.NewEgressConflictReporter(policyController, kubernetesClient, policyClientset.NewForConfigOrDie(kubeConfig), elector).Start(egressConflictReportInterval, stop)
+	}
+
 	meshCatalog := catalog.NewMeshCatalog(
 		kubernetesClient,
 		kubeClient,
@@ -210,13 +290,37 @@ func main() {
 		certManager,
 		ingressClient,
 		policyController,
+		identityProviders,
 		stop,
 		cfg,
 		endpointsProviders...)
 
+	if featureflags.IsProgressiveDeliveryEnabled() {
+		metricsProvider, err := rollout.NewPrometheusMetricsProvider(rolloutPrometheusAddress)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating Prometheus metrics provider for progressive delivery")
+		}
+		rollout.NewController(meshSpec, smiTrafficSplitClient.NewForConfigOrDie(kubeConfig), metricsProvider, elector).Start(rolloutCheckInterval, stop)
+	}
+
+	if featureflags.IsSidecarSizingEnabled() {
+		bounds, err := sizing.ParseBounds(sidecarMinCPU, sidecarMaxCPU, sidecarMinMemory, sidecarMaxMemory)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error parsing the bounds of the resource requests recommended for sidecars")
+		}
+		metricsProvider := sizing.NewMetricsAPIProvider(kubeClient.CoreV1().RESTClient())
+		sizing.NewController(kubeClient, kubernetesClient, metricsProvider, bounds, sidecarSizingAutoApply).Start(sidecarSizingCheckInterval, stop)
+	}
+
 	proxyRegistry := registry.NewProxyRegistry()
 	proxyRegistry.ReleaseCertificateHandler(certManager)
 
+	// Request the Envoy sidecars of Job pods to exit once the containers of the Job exit
+	job.NewProxyTerminator(kubeClient, kubeConfig, elector).Start(stop)
+
+	// Report the recurring certificate issuance and proxy configuration errors in the MeshDiagnostic resource of the mesh
+	diagnostics.NewReporter(configClientset.NewForConfigOrDie(kubeConfig), meshName, diagnostics.ErrCertificateIssuance, diagnostics.ErrPolicyBuild).Start(diagnosticsReportInterval, stop)
+
 	// Create the configMap validating webhook
 	if err := configurator.NewValidatingWebhook(kubeClient, certManager, osmNamespace, webhookConfigName, stop); err != nil {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating osm-config validating webhook")
@@ -227,6 +331,18 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.CertificateIssuanceFailure, "Error issuing XDS certificate to ADS server")
 	}
 
+	// Create the bootstrap config of the OSM managed ingress gateway, which connects to the ADS server as any other Envoy proxy
+	if featureflags.IsIngressGatewayEnabled() {
+		if err := injector.CreateIngressGatewayBootstrapConfig(kubeClient, certManager, cfg, meshName, osmNamespace); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating the ingress gateway bootstrap config")
+		}
+	}
+
+	// Reissue the workload certificates written by osm-injector in the gRPC xDS bootstraps of proxyless gRPC pods before they expire
+	if featureflags.IsProxylessGRPCEnabled() {
+		injector.StartGRPCBootstrapCertRotation(kubeClient, certManager, cfg, meshName, grpcBootstrapCertCheckInterval, stop)
+	}
+
 	// Create and start the ADS gRPC service
 	xdsServer := ads.NewADSServer(meshCatalog, proxyRegistry, cfg.IsDebugServerEnabled(), osmNamespace, cfg, certManager)
 	if err := xdsServer.Start(ctx, cancel, *port, adsCert); err != nil {
@@ -273,8 +389,17 @@ func startMetricsStore() {
 		metricsstore.DefaultMetricsStore.K8sMeshPodCount,
 		metricsstore.DefaultMetricsStore.ProxyConnectCount,
 		metricsstore.DefaultMetricsStore.ProxyConfigUpdateTime,
+		metricsstore.DefaultMetricsStore.ProxyPendingPushCount,
+		metricsstore.DefaultMetricsStore.ProxyResponseNACKCount,
+		metricsstore.DefaultMetricsStore.ProxyConfigRollbackCount,
+		metricsstore.DefaultMetricsStore.ProxyVersionCount,
+		metricsstore.DefaultMetricsStore.ProxyConfigClassCount,
+		metricsstore.DefaultMetricsStore.ProxyUnsupportedVersionWithheldFeatureCount,
 		metricsstore.DefaultMetricsStore.CertIssuedCount,
 		metricsstore.DefaultMetricsStore.CertIssuedTime,
+		metricsstore.DefaultMetricsStore.BrokerQueueLength,
+		metricsstore.DefaultMetricsStore.BrokerRelayedCount,
+		metricsstore.DefaultMetricsStore.BrokerDroppedCount,
 	)
 }
 
@@ -4,6 +4,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
 
 // validateCLIParams contains all checks necessary that various permutations of the CLI flags are consistent
@@ -32,9 +33,34 @@ func validateCLIParams() error {
 		return errors.Errorf("Please specify the CA bundle secret name using --ca-bundle-secret-name containing the cert-manager CA at 'ca.crt'")
 	}
 
+	if optionalFeatures.ProgressiveDelivery && rolloutPrometheusAddress == "" {
+		return errors.Errorf("Please specify the Prometheus server address using --rollout-prometheus-address when progressive delivery is enabled")
+	}
+
+	if err := validateMessageBrokerOptions(); err != nil {
+		return errors.Errorf("Error validating message broker options: %s", err)
+	}
+
 	return nil
 }
 
+func validateMessageBrokerOptions() error {
+	switch messageBroker {
+	case "":
+		return nil
+
+	case events.NATSRelayKind:
+		if messageBrokerURL == "" {
+			return errors.Errorf("Please specify the URL of the message broker using --message-broker-url")
+		}
+		return nil
+
+	default:
+		return errors.Errorf("Invalid message broker %s. Please specify a valid message broker, one of: [%v]",
+			messageBroker, events.RelayKinds)
+	}
+}
+
 func validateCertificateManagerOptions() error {
 	switch providers.Kind(certProviderKind) {
 	case providers.TresorKind:
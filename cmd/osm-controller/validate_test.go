@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
 
 var _ = Describe("Test validateCertificateManagerOptions", func() {
@@ -145,3 +146,44 @@ var _ = Describe("Test validateCLIParams", func() {
 		})
 	})
 })
+
+var _ = Describe("Test validateMessageBrokerOptions", func() {
+	Context("no message broker is passed in", func() {
+		messageBroker = ""
+
+		err := validateMessageBrokerOptions()
+
+		It("should not error", func() {
+			Expect(err).To(BeNil())
+		})
+	})
+	Context("nats message broker is passed in with a URL", func() {
+		messageBroker = events.NATSRelayKind
+		messageBrokerURL = "nats://nats.osm-system.svc.cluster.local:4222"
+
+		err := validateMessageBrokerOptions()
+
+		It("should not error", func() {
+			Expect(err).To(BeNil())
+		})
+	})
+	Context("nats message broker is passed in without a URL", func() {
+		messageBroker = events.NATSRelayKind
+		messageBrokerURL = ""
+
+		err := validateMessageBrokerOptions()
+
+		It("should error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("an invalid message broker is passed in", func() {
+		messageBroker = "kafka"
+
+		err := validateMessageBrokerOptions()
+
+		It("should error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,136 @@
+// Package main implements osm-healthcheck, a shim to reach the admin interface of an Envoy sidecar bound to a Unix domain socket.
+// osm-healthcheck is copied into the pod by the init container, and is executed in the Envoy sidecar container by the
+// readiness probe of the sidecar and by OSM components that query the admin interface.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// readyCommand checks if the Envoy sidecar is ready
+	readyCommand = "ready"
+
+	// adminCommand sends a request to the admin interface of the Envoy sidecar, and writes the response to stdout
+	adminCommand = "admin"
+
+	// envoyReadyPath is the path of the Envoy admin endpoint returning the readiness of the server
+	envoyReadyPath = "/ready"
+
+	// adminHost is the host used in requests to the admin interface, the request is sent on the Unix domain socket
+	adminHost = "envoy-admin"
+)
+
+var (
+	flags = pflag.NewFlagSet("osm-healthcheck", pflag.ExitOnError)
+
+	adminSocketPath string
+	timeout         time.Duration
+)
+
+func init() {
+	flags.StringVar(&adminSocketPath, "admin-socket", constants.EnvoyAdminSocketPath, "Path to the Unix domain socket of the Envoy admin interface")
+	flags.DurationVar(&timeout, "timeout", 5*time.Second, "Timeout of the request to the Envoy admin interface")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n  osm-healthcheck [flags] %s\n  osm-healthcheck [flags] %s <method> <path>\n\nFlags:\n%s", readyCommand, adminCommand, flags.FlagUsages())
+	}
+}
+
+func main() {
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	_ = flag.CommandLine.Parse([]string{})
+
+	if err := run(flags.Args(), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.Errorf("Missing command, must be one of [%s, %s]", readyCommand, adminCommand)
+	}
+
+	client := newAdminClient(adminSocketPath, timeout)
+
+	switch args[0] {
+	case readyCommand:
+		return checkReady(client)
+
+	case adminCommand:
+		if len(args) != 3 {
+			return errors.Errorf("Command %s requires a method and a path", adminCommand)
+		}
+		return sendAdminRequest(client, args[1], args[2], out)
+
+	default:
+		return errors.Errorf("Unknown command %s, must be one of [%s, %s]", args[0], readyCommand, adminCommand)
+	}
+}
+
+// newAdminClient returns an HTTP client sending requests on the given Unix domain socket
+func newAdminClient(socketPath string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+}
+
+// checkReady returns an error if the Envoy sidecar is not ready
+func checkReady(client *http.Client) error {
+	resp, err := client.Get(fmt.Sprintf("http://%s%s", adminHost, envoyReadyPath))
+	if err != nil {
+		return errors.Errorf("Error checking Envoy readiness: %s", err)
+	}
+	defer resp.Body.Close() //nolint: errcheck,gosec
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Envoy is not ready, status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendAdminRequest sends a request to the Envoy admin interface and writes the response body to the given writer
+func sendAdminRequest(client *http.Client, method, path string, out io.Writer) error {
+	url := fmt.Sprintf("http://%s/%s", adminHost, strings.TrimPrefix(path, "/"))
+	req, err := http.NewRequest(strings.ToUpper(method), url, nil)
+	if err != nil {
+		return errors.Errorf("Error creating request %s %s: %s", method, path, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Errorf("Error sending request %s %s: %s", method, path, err)
+	}
+	defer resp.Body.Close() //nolint: errcheck,gosec
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return errors.Errorf("Error writing response: %s", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("Request %s %s failed with status code %d", method, path, resp.StatusCode)
+	}
+	return nil
+}
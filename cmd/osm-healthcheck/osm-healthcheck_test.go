@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	require := trequire.New(t)
+
+	dir, err := ioutil.TempDir("", "osm-healthcheck")
+	require.Nil(err)
+	defer os.RemoveAll(dir) //nolint: errcheck
+
+	socketPath := filepath.Join(dir, "admin.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.Nil(err)
+
+	var notReady int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ready" && atomic.LoadInt32(&notReady) == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/ready", r.URL.Path == "/config_dump" && r.Method == http.MethodGet, r.URL.Path == "/quitquitquit" && r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(r.Method + " " + r.URL.Path))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	adminSocketPath = socketPath
+	timeout = time.Second
+
+	testCases := []struct {
+		name           string
+		args           []string
+		notReady       bool
+		expectedOutput string
+		expectError    bool
+	}{
+		{
+			name: "ready",
+			args: []string{readyCommand},
+		},
+		{
+			name:        "not ready",
+			args:        []string{readyCommand},
+			notReady:    true,
+			expectError: true,
+		},
+		{
+			name:           "admin GET request",
+			args:           []string{adminCommand, "get", "config_dump"},
+			expectedOutput: "GET /config_dump",
+		},
+		{
+			name:           "admin POST request",
+			args:           []string{adminCommand, "POST", "/quitquitquit"},
+			expectedOutput: "POST /quitquitquit",
+		},
+		{
+			name:        "admin request to unknown path",
+			args:        []string{adminCommand, "GET", "/unknown"},
+			expectError: true,
+		},
+		{
+			name:        "admin request without path",
+			args:        []string{adminCommand, "GET"},
+			expectError: true,
+		},
+		{
+			name:        "missing command",
+			args:        nil,
+			expectError: true,
+		},
+		{
+			name:        "unknown command",
+			args:        []string{"live"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			if tc.notReady {
+				atomic.StoreInt32(&notReady, 1)
+			} else {
+				atomic.StoreInt32(&notReady, 0)
+			}
+			out := new(bytes.Buffer)
+
+			err := run(tc.args, out)
+			assert.Equal(tc.expectError, err != nil)
+			if tc.expectedOutput != "" {
+				assert.Equal(tc.expectedOutput, out.String())
+			}
+		})
+	}
+}
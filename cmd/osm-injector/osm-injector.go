@@ -8,6 +8,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
@@ -23,6 +24,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/diagnostics"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/httpserver"
 	"github.com/openservicemesh/osm/pkg/injector"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
@@ -33,6 +37,11 @@ import (
 	"github.com/openservicemesh/osm/pkg/version"
 )
 
+const (
+	// diagnosticsReportInterval is the interval at which the recurring errors of osm-injector are reported
+	diagnosticsReportInterval = 30 * time.Second
+)
+
 var (
 	verbosity          string
 	meshName           string // An ID that uniquely identifies an OSM instance
@@ -44,6 +53,8 @@ var (
 
 	injectorConfig injector.Config
 
+	optionalFeatures featureflags.OptionalFeatures
+
 	certProviderKind string
 
 	tresorOptions      providers.TresorOptions
@@ -69,6 +80,10 @@ func init() {
 	// sidecar injector options
 	flags.IntVar(&injectorConfig.ListenPort, "webhook-port", constants.InjectorWebhookPort, "Webhook port for sidecar-injector")
 
+	// feature flags affecting sidecar injection
+	flags.BoolVar(&optionalFeatures.ProxylessGRPC, "enable-proxyless-grpc", false, "Enable gRPC applications using the xDS client to connect to OSM without a sidecar")
+	flags.BoolVar(&optionalFeatures.EnvoyAdminUDS, "enable-envoy-admin-uds", false, "Enable binding the admin interface of Envoy sidecars to a Unix domain socket")
+
 	// Generic certificate manager/provider options
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
 	flags.StringVar(&caBundleSecretName, "ca-bundle-secret-name", "", "Name of the Kubernetes Secret for the OSM CA bundle")
@@ -98,6 +113,8 @@ func main() {
 		log.Fatal().Err(err).Msg("Error setting log level")
 	}
 
+	featureflags.Initialize(optionalFeatures)
+
 	// Initialize kube config and client
 	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeConfigFile)
 	if err != nil {
@@ -180,6 +197,9 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating controller manager to reconcile sidecar injector webhook config")
 	}
 
+	// Report the recurring reconcile errors in the MeshDiagnostic resource of the mesh
+	diagnostics.NewReporter(configClientset.NewForConfigOrDie(kubeConfig), meshName, diagnostics.ErrResourceReconcile).Start(diagnosticsReportInterval, stop)
+
 	<-stop
 	log.Info().Msgf("Stopping osm-injector %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
 }
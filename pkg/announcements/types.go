@@ -77,6 +77,17 @@ const (
 
 	// ---
 
+	// SecretAdded is the type of announcement emitted when we observe an addition of a Kubernetes TLS Secret
+	SecretAdded AnnouncementType = "secret-added"
+
+	// SecretDeleted the type of announcement emitted when we observe the deletion of a Kubernetes TLS Secret
+	SecretDeleted AnnouncementType = "secret-deleted"
+
+	// SecretUpdated is the type of announcement emitted when we observe an update to a Kubernetes TLS Secret
+	SecretUpdated AnnouncementType = "secret-updated"
+
+	// ---
+
 	// TrafficSplitAdded is the type of announcement emitted when we observe an addition of a Kubernetes TrafficSplit
 	TrafficSplitAdded AnnouncementType = "trafficsplit-added"
 
@@ -167,6 +178,17 @@ const (
 
 	// EgressUpdated is the type of announcement emitted when we observe an update to egress.policy.openservicemesh.io
 	EgressUpdated AnnouncementType = "egress-updated"
+
+	// ---
+
+	// UpstreamTrafficSettingAdded is the type of announcement emitted when we observe an addition of upstreamtrafficsettings.policy.openservicemesh.io
+	UpstreamTrafficSettingAdded AnnouncementType = "upstreamtrafficsetting-added"
+
+	// UpstreamTrafficSettingDeleted the type of announcement emitted when we observe a deletion of upstreamtrafficsettings.policy.openservicemesh.io
+	UpstreamTrafficSettingDeleted AnnouncementType = "upstreamtrafficsetting-deleted"
+
+	// UpstreamTrafficSettingUpdated is the type of announcement emitted when we observe an update to upstreamtrafficsettings.policy.openservicemesh.io
+	UpstreamTrafficSettingUpdated AnnouncementType = "upstreamtrafficsetting-updated"
 )
 
 // Announcement is a struct for messages between various components of OSM signaling a need for a change in Envoy proxy configuration
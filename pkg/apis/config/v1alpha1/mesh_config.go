@@ -1,6 +1,9 @@
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // MeshConfig is the configuration for the service mesh overall
 // +genclient
@@ -29,6 +32,33 @@ type SidecarSpec struct {
 	InitContainerImage            string `json:"initContainerImage,omitempty" yaml:"initContainerImage,omitempty"`
 	MaxDataPlaneConnections       int    `json:"maxMaxPlaneConnections,omitempty" yaml:"max_data_plane_connections,omitempty"`
 	ConfigResyncInterval          string `json:"configResyncInterval,omitempty" yaml:"config_resync_interval,omitempty"`
+
+	// EnvoyArchImages maps node architectures to the Envoy image injected into pods scheduled on nodes of that architecture
+	EnvoyArchImages map[string]string `json:"envoyArchImages,omitempty" yaml:"envoyArchImages,omitempty"`
+
+	// InitContainerArchImages maps node architectures to the init container image injected into pods scheduled on nodes of that architecture
+	InitContainerArchImages map[string]string `json:"initContainerArchImages,omitempty" yaml:"initContainerArchImages,omitempty"`
+
+	// ImageRegistryOverride is the registry replacing the registry of the Envoy and init container images injected into pods
+	ImageRegistryOverride string `json:"imageRegistryOverride,omitempty" yaml:"imageRegistryOverride,omitempty"`
+
+	// EnvoyMinSupportedVersion is the minimum Envoy version of the sidecars receiving the configuration features that rely on newer Envoy versions
+	EnvoyMinSupportedVersion string `json:"envoyMinSupportedVersion,omitempty" yaml:"envoyMinSupportedVersion,omitempty"`
+
+	// ProxyConfigClasses are the named sets of settings of the sidecars injected into the pods referencing them
+	ProxyConfigClasses []ProxyConfigClassSpec `json:"proxyConfigClasses,omitempty" yaml:"proxyConfigClasses,omitempty"`
+}
+
+// ProxyConfigClassSpec is the spec for a named set of settings of the sidecars injected into the pods referencing it
+// with the openservicemesh.io/proxy-config-class annotation, overriding the mesh-wide settings
+type ProxyConfigClassSpec struct {
+	// Name is the name of the class
+	Name string `json:"name" yaml:"name"`
+
+	LogLevel    string                      `json:"logLevel,omitempty" yaml:"logLevel,omitempty"`
+	Concurrency int                         `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	EnvoyImage  string                      `json:"envoyImage,omitempty" yaml:"envoyImage,omitempty"`
+	Resources   corev1.ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
 }
 
 // TrafficSpec is the spec for OSM's traffic management configuration
@@ -36,8 +66,48 @@ type TrafficSpec struct {
 	EnableEgress                      bool     `json:"enableEgress,omitempty" yaml:"enableEgress,omitempty"`
 	OutboundIPRangeExclusionList      []string `json:"outboundIPRangeExclusionList,omitempty" yaml:"outboundIPRangeExclusionList,omitempty"`
 	OutboundPortExclusionList         []string `json:"outboundPortExclusionList,omitempty" yaml:"outboundPortExclusionList,omitempty"`
+	OutboundWellKnownExclusionList    []string `json:"outboundWellKnownExclusionList,omitempty" yaml:"outboundWellKnownExclusionList,omitempty"`
+	NodeLocalDNSIP                    string   `json:"nodeLocalDNSIP,omitempty" yaml:"nodeLocalDNSIP,omitempty"`
 	UseHTTPSIngress                   bool     `json:"useHTTPSIngress,omitempty" yaml:"useHTTPSIngress,omitempty"`
 	EnablePermissiveTrafficPolicyMode bool     `json:"enablePermissiveTrafficPolicyMode,omitempty" yaml:"enablePermissiveTrafficPolicyMode,omitempty"`
+	TLSMinProtocolVersion             string   `json:"tlsMinProtocolVersion,omitempty" yaml:"tlsMinProtocolVersion,omitempty"`
+	TLSMaxProtocolVersion             string   `json:"tlsMaxProtocolVersion,omitempty" yaml:"tlsMaxProtocolVersion,omitempty"`
+	TLSCipherSuites                   []string `json:"tlsCipherSuites,omitempty" yaml:"tlsCipherSuites,omitempty"`
+	TLSALPNProtocols                  []string `json:"tlsALPNProtocols,omitempty" yaml:"tlsALPNProtocols,omitempty"`
+
+	// HTTPProtocolOptions are the options of the HTTP connections of services
+	HTTPProtocolOptions HTTPProtocolOptionsSpec `json:"httpProtocolOptions,omitempty" yaml:"httpProtocolOptions,omitempty"`
+
+	// InboundHardening are the options hardening the inbound and ingress listeners of sidecars against path-confusion attacks
+	InboundHardening InboundHardeningSpec `json:"inboundHardening,omitempty" yaml:"inboundHardening,omitempty"`
+}
+
+// HTTPProtocolOptionsSpec is the spec for the mesh-wide options of the HTTP connections of services, and their overrides for specific services
+type HTTPProtocolOptionsSpec struct {
+	AcceptHTTP10         bool `json:"acceptHTTP10,omitempty" yaml:"acceptHTTP10,omitempty"`
+	EnableTrailers       bool `json:"enableTrailers,omitempty" yaml:"enableTrailers,omitempty"`
+	NormalizePath        bool `json:"normalizePath,omitempty" yaml:"normalizePath,omitempty"`
+	MergeSlashes         bool `json:"mergeSlashes,omitempty" yaml:"mergeSlashes,omitempty"`
+	RejectEscapedSlashes bool `json:"rejectEscapedSlashes,omitempty" yaml:"rejectEscapedSlashes,omitempty"`
+
+	// Overrides are the options overridden for specific services
+	Overrides []HTTPProtocolOptionsOverrideSpec `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+}
+
+// HTTPProtocolOptionsOverrideSpec is the spec for the options of the HTTP connections of a service overriding the mesh-wide options
+type HTTPProtocolOptionsOverrideSpec struct {
+	// Service is the <namespace>/<name> of the service
+	Service string `json:"service" yaml:"service"`
+
+	// Options maps the overridden options, one of accept_http_10, enable_trailers, normalize_path, merge_slashes, reject_escaped_slashes, to their values
+	Options map[string]bool `json:"options" yaml:"options"`
+}
+
+// InboundHardeningSpec is the spec for the mesh-wide options hardening the handling of the requests received by the
+// inbound and ingress listeners of sidecars and by the ingress gateway
+type InboundHardeningSpec struct {
+	CaseInsensitivePaths bool `json:"caseInsensitivePaths,omitempty" yaml:"caseInsensitivePaths,omitempty"`
+	StripEnvoyHeaders    bool `json:"stripEnvoyHeaders,omitempty" yaml:"stripEnvoyHeaders,omitempty"`
 }
 
 // ObservabilitySpec is the spec for OSM's observability related configuration
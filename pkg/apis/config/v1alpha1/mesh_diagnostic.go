@@ -0,0 +1,55 @@
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// MeshDiagnostic summarizes the recurring errors of the control plane of a mesh, so that they can be inspected
+// without reading the logs of the control plane. It is named after the mesh it describes.
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MeshDiagnostic struct {
+	metav1.TypeMeta   `json:",inline" yaml:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Status MeshDiagnosticStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// MeshDiagnosticStatus is the status of the control plane of a mesh
+type MeshDiagnosticStatus struct {
+	// Conditions are the conditions of the control plane, one per error code
+	Conditions []DiagnosticCondition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// DiagnosticCondition is a condition of the control plane, reporting whether an error identified by an error code is recurring
+type DiagnosticCondition struct {
+	// Type is the type of the condition, describing the error
+	Type string `json:"type" yaml:"type"`
+
+	// Code is the error code of the error
+	Code string `json:"code" yaml:"code"`
+
+	// Status is True if the error is recurring, False otherwise
+	Status metav1.ConditionStatus `json:"status" yaml:"status"`
+
+	// Count is the number of occurrences of the error within the observation window of the control plane
+	Count int `json:"count" yaml:"count"`
+
+	// Message is the message of the last occurrence of the error
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// LastOccurrenceTime is the time of the last occurrence of the error
+	LastOccurrenceTime *metav1.Time `json:"lastOccurrenceTime,omitempty" yaml:"lastOccurrenceTime,omitempty"`
+
+	// LastTransitionTime is the time the status of the condition last changed
+	LastTransitionTime metav1.Time `json:"lastTransitionTime" yaml:"lastTransitionTime"`
+}
+
+// MeshDiagnosticList lists the MeshDiagnostic objects
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type MeshDiagnosticList struct {
+	metav1.TypeMeta `json:",inline" yaml:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+
+	Items []MeshDiagnostic `json:"items" yaml:"items"`
+}
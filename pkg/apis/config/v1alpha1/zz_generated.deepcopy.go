@@ -37,6 +37,89 @@ func (in *CertificateSpec) DeepCopy() *CertificateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiagnosticCondition) DeepCopyInto(out *DiagnosticCondition) {
+	*out = *in
+	if in.LastOccurrenceTime != nil {
+		in, out := &in.LastOccurrenceTime, &out.LastOccurrenceTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticCondition.
+func (in *DiagnosticCondition) DeepCopy() *DiagnosticCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(DiagnosticCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProtocolOptionsOverrideSpec) DeepCopyInto(out *HTTPProtocolOptionsOverrideSpec) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProtocolOptionsOverrideSpec.
+func (in *HTTPProtocolOptionsOverrideSpec) DeepCopy() *HTTPProtocolOptionsOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProtocolOptionsOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPProtocolOptionsSpec) DeepCopyInto(out *HTTPProtocolOptionsSpec) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]HTTPProtocolOptionsOverrideSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPProtocolOptionsSpec.
+func (in *HTTPProtocolOptionsSpec) DeepCopy() *HTTPProtocolOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPProtocolOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InboundHardeningSpec) DeepCopyInto(out *InboundHardeningSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InboundHardeningSpec.
+func (in *InboundHardeningSpec) DeepCopy() *InboundHardeningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InboundHardeningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MeshConfig) DeepCopyInto(out *MeshConfig) {
 	*out = *in
@@ -100,7 +183,7 @@ func (in *MeshConfigList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MeshConfigSpec) DeepCopyInto(out *MeshConfigSpec) {
 	*out = *in
-	out.Sidecar = in.Sidecar
+	in.Sidecar.DeepCopyInto(&out.Sidecar)
 	in.Traffic.DeepCopyInto(&out.Traffic)
 	out.Observability = in.Observability
 	out.Certificate = in.Certificate
@@ -117,6 +200,89 @@ func (in *MeshConfigSpec) DeepCopy() *MeshConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshDiagnostic) DeepCopyInto(out *MeshDiagnostic) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshDiagnostic.
+func (in *MeshDiagnostic) DeepCopy() *MeshDiagnostic {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshDiagnostic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeshDiagnostic) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshDiagnosticList) DeepCopyInto(out *MeshDiagnosticList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MeshDiagnostic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshDiagnosticList.
+func (in *MeshDiagnosticList) DeepCopy() *MeshDiagnosticList {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshDiagnosticList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MeshDiagnosticList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshDiagnosticStatus) DeepCopyInto(out *MeshDiagnosticStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DiagnosticCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshDiagnosticStatus.
+func (in *MeshDiagnosticStatus) DeepCopy() *MeshDiagnosticStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshDiagnosticStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
 	*out = *in
@@ -134,9 +300,47 @@ func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfigClassSpec) DeepCopyInto(out *ProxyConfigClassSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigClassSpec.
+func (in *ProxyConfigClassSpec) DeepCopy() *ProxyConfigClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfigClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarSpec) DeepCopyInto(out *SidecarSpec) {
 	*out = *in
+	if in.EnvoyArchImages != nil {
+		in, out := &in.EnvoyArchImages, &out.EnvoyArchImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InitContainerArchImages != nil {
+		in, out := &in.InitContainerArchImages, &out.InitContainerArchImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProxyConfigClasses != nil {
+		in, out := &in.ProxyConfigClasses, &out.ProxyConfigClasses
+		*out = make([]ProxyConfigClassSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -179,6 +383,23 @@ func (in *TrafficSpec) DeepCopyInto(out *TrafficSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OutboundWellKnownExclusionList != nil {
+		in, out := &in.OutboundWellKnownExclusionList, &out.OutboundWellKnownExclusionList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSCipherSuites != nil {
+		in, out := &in.TLSCipherSuites, &out.TLSCipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLSALPNProtocols != nil {
+		in, out := &in.TLSALPNProtocols, &out.TLSALPNProtocols
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.HTTPProtocolOptions.DeepCopyInto(&out.HTTPProtocolOptions)
+	out.InboundHardening = in.InboundHardening
 	return
 }
 
@@ -10,7 +10,6 @@ import (
 // external to the service mesh or cluster based on the specified
 // rules in the policy.
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type Egress struct {
 	// Object's type metadata
@@ -23,6 +22,10 @@ type Egress struct {
 	// Spec is the Egress policy specification
 	// +optional
 	Spec EgressSpec `json:"spec,omitempty"`
+
+	// Status is the status of the Egress policy, as observed by the control plane
+	// +optional
+	Status EgressStatus `json:"status,omitempty"`
 }
 
 // EgressSpec is the type used to represent the Egress policy specification
@@ -44,18 +47,103 @@ type EgressSpec struct {
 	// Matches defines the list of routes the Egress policy should match on
 	// +optional
 	Matches []corev1.TypedLocalObjectReference `json:"matches,omitempty"`
+
+	// HostRewrite defines the rewrite of the Host/authority header of HTTP requests routed to the external hosts
+	// +optional
+	HostRewrite *HostRewriteSpec `json:"hostRewrite,omitempty"`
+
+	// Action defines whether the traffic to the hosts specified is allowed or denied, defaults to Allow.
+	// A Deny action applies to the hosts on HTTP and HTTPS ports only.
+	// +optional
+	Action EgressAction `json:"action,omitempty"`
+
+	// Validity defines the time during which the Egress policy is enforced. The policy is always enforced when unspecified.
+	// +optional
+	Validity *ValiditySpec `json:"validity,omitempty"`
+}
+
+// ValiditySpec is the type used to represent the time during which a policy is enforced
+type ValiditySpec struct {
+	// NotBefore defines the time from which the policy is enforced
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter defines the time from which the policy is no longer enforced
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// Schedule defines the cron schedule, evaluated in UTC, starting the periods during which the policy is enforced.
+	// When specified, the policy is only enforced for Duration after each time matching the schedule.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Duration defines the duration of the periods started by Schedule, up to 24h
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// EgressAction is the type used to represent the action of an Egress policy on the traffic it matches
+type EgressAction string
+
+const (
+	// EgressActionAllow allows the traffic matched by an Egress policy
+	EgressActionAllow EgressAction = "Allow"
+
+	// EgressActionDeny denies the traffic matched by an Egress policy
+	EgressActionDeny EgressAction = "Deny"
+)
+
+// EgressStatus is the type used to represent the status of an Egress policy
+type EgressStatus struct {
+	// Conflicts defines the list of destinations of the Egress policy for which another Egress policy
+	// takes precedence, for at least one of the sources of the Egress policy
+	// +optional
+	Conflicts []EgressConflict `json:"conflicts,omitempty"`
+}
+
+// EgressConflict is the type used to represent a destination of an Egress policy for which another Egress policy
+// takes precedence
+type EgressConflict struct {
+	// Host defines the host of the destination
+	Host string `json:"host"`
+
+	// Port defines the port number of the destination
+	Port int `json:"port"`
+
+	// OverriddenBy defines the Egress policy applied to the destination, of the form <namespace>/<name>
+	OverriddenBy string `json:"overriddenBy"`
+
+	// Reason defines the precedence rule that applied, one of: MoreSpecificHost, DenyOverride, NamespacePriority, OlderPolicy
+	Reason string `json:"reason"`
+}
+
+// HostRewriteSpec is the type used to represent the rewrite of the Host/authority header of HTTP requests
+// routed to the external hosts specified in an Egress policy specification. When specified, the Host/authority
+// header of HTTP requests is rewritten to the external host the requests are routed to.
+type HostRewriteSpec struct {
+	// Aliases defines the list of IP addresses and internal hostnames applications use to address the external host.
+	// HTTP requests whose Host/authority header matches an alias are routed to the external host.
+	// Aliases are only applicable to Egress policies specifying a single host.
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
 }
 
 // SourceSpec is the type used to represent the Source in the list of Sources specified in an Egress policy specification
 type SourceSpec struct {
-	// Kind defines the kind for the source in the Egress policy, ex. ServiceAccount
+	// Kind defines the kind for the source in the Egress policy, ex. ServiceAccount, Pod
 	Kind string `json:"kind"`
 
-	// Name defines the name of the source for the given Kind
-	Name string `json:"name"`
+	// Name defines the name of the source for the given Kind, applicable to the ServiceAccount kind
+	// +optional
+	Name string `json:"name,omitempty"`
 
 	// Namespace defines the namespace for the given source
 	Namespace string `json:"namespace"`
+
+	// Selector defines the label selector matching the pods in Namespace the Egress policy is applicable to,
+	// applicable to the Pod kind. An empty selector matches all the pods in Namespace.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // PortSpec is the type used to represent the Port in the list of Ports specified in an Egress policy specification
@@ -63,6 +151,11 @@ type PortSpec struct {
 	// Number defines the port number
 	Number int `json:"number"`
 
+	// EndNumber defines the last port number of a port range starting at Number.
+	// When specified, the PortSpec applies to all ports in the inclusive range [Number, EndNumber].
+	// +optional
+	EndNumber int `json:"endNumber,omitempty"`
+
 	// Protocol defines the protocol served by the port
 	Protocol string `json:"protocol"`
 }
@@ -0,0 +1,121 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	policyV1alpha1Client "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	"github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	policyV1alpha1Informers "github.com/openservicemesh/osm/pkg/gen/client/policy/informers/externalversions"
+)
+
+// newPolicyClient returns the clientset used by the examples. Controllers running in a cluster create it from their
+// kubeconfig instead, with policyV1alpha1Client.NewForConfig(kubeConfig).
+func newPolicyClient() policyV1alpha1Client.Interface {
+	return fake.NewSimpleClientset()
+}
+
+// This example creates an Egress policy allowing the pods of the bookbuyer service account to reach httpbin.org over HTTPS.
+func ExampleEgress() {
+	policyClient := newPolicyClient()
+
+	egress := &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "httpbin-https",
+			Namespace: "bookbuyer",
+		},
+		Spec: policyV1alpha1.EgressSpec{
+			Sources: []policyV1alpha1.SourceSpec{
+				{
+					Kind:      "ServiceAccount",
+					Name:      "bookbuyer",
+					Namespace: "bookbuyer",
+				},
+			},
+			Hosts: []string{"httpbin.org"},
+			Ports: []policyV1alpha1.PortSpec{
+				{
+					Number:   443,
+					Protocol: "https",
+				},
+			},
+		},
+	}
+
+	created, err := policyClient.PolicyV1alpha1().Egresses(egress.Namespace).Create(context.Background(), egress, metav1.CreateOptions{})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("created Egress %s/%s for hosts %v\n", created.Namespace, created.Name, created.Spec.Hosts)
+
+	// Output:
+	// created Egress bookbuyer/httpbin-https for hosts [httpbin.org]
+}
+
+// This example creates an UpstreamTrafficSetting policy limiting the connections to the bookstore service, watches the
+// UpstreamTrafficSetting policies with a shared informer, and lists them from the cache of the informer with a lister.
+func ExampleUpstreamTrafficSetting() {
+	policyClient := newPolicyClient()
+	stop := make(chan struct{})
+	defer close(stop)
+
+	informerFactory := policyV1alpha1Informers.NewSharedInformerFactory(policyClient, 30*time.Second)
+	informer := informerFactory.Policy().V1alpha1().UpstreamTrafficSettings().Informer()
+	lister := informerFactory.Policy().V1alpha1().UpstreamTrafficSettings().Lister()
+
+	added := make(chan string)
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			setting := obj.(*policyV1alpha1.UpstreamTrafficSetting)
+			added <- setting.Namespace + "/" + setting.Name
+		},
+	})
+
+	maxConnections := uint32(100)
+	setting := &policyV1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore",
+			Namespace: "bookstore",
+		},
+		Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+			Host: "bookstore.bookstore.svc.cluster.local",
+			ConnectionSettings: &policyV1alpha1.ConnectionSettingsSpec{
+				TCP: &policyV1alpha1.TCPConnectionSettings{
+					MaxConnections: &maxConnections,
+				},
+			},
+		},
+	}
+	if _, err := policyClient.PolicyV1alpha1().UpstreamTrafficSettings(setting.Namespace).Create(context.Background(), setting, metav1.CreateOptions{}); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	informerFactory.Start(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		fmt.Println("error syncing the informer cache")
+		return
+	}
+
+	fmt.Println("observed UpstreamTrafficSetting", <-added)
+
+	settings, err := lister.UpstreamTrafficSettings("bookstore").List(labels.Everything())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range settings {
+		fmt.Printf("UpstreamTrafficSetting %s/%s applies to host %s\n", s.Namespace, s.Name, s.Spec.Host)
+	}
+
+	// Output:
+	// observed UpstreamTrafficSetting bookstore/bookstore
+	// UpstreamTrafficSetting bookstore/bookstore applies to host bookstore.bookstore.svc.cluster.local
+}
@@ -39,6 +39,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Egress{},
 		&EgressList{},
+		&UpstreamTrafficSetting{},
+		&UpstreamTrafficSettingList{},
 	)
 
 	metav1.AddToGroupVersion(
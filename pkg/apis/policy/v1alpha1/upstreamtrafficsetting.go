@@ -0,0 +1,354 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpstreamTrafficSetting is the type used to represent the settings applied to the traffic
+// directed to an upstream host.
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type UpstreamTrafficSetting struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the UpstreamTrafficSetting policy specification
+	// +optional
+	Spec UpstreamTrafficSettingSpec `json:"spec,omitempty"`
+}
+
+// UpstreamTrafficSettingSpec is the type used to represent the UpstreamTrafficSetting policy specification
+type UpstreamTrafficSettingSpec struct {
+	// Host the upstream traffic is directed to.
+	// Must be either the fully qualified domain name of a service, of the form <service>.<namespace>.svc.cluster.local,
+	// in the same namespace as the UpstreamTrafficSetting, or an external host specified in an Egress policy
+	// in the same namespace as the UpstreamTrafficSetting.
+	Host string `json:"host"`
+
+	// ConnectionSettings defines the connection pool and circuit breaking settings applied by clients
+	// to the traffic directed to the upstream host.
+	// Connection settings are currently only applied to the traffic directed to external hosts specified in Egress policies.
+	// +optional
+	ConnectionSettings *ConnectionSettingsSpec `json:"connectionSettings,omitempty"`
+
+	// RateLimit defines the rate limiting settings applied to the traffic directed to the upstream host.
+	// TCP rate limits are currently only applied by clients to the TLS traffic directed to external hosts specified
+	// in Egress policies. HTTP rate limits are applied by the upstream host to the requests it receives from clients
+	// within the mesh.
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+
+	// AdmissionControl defines the settings used to probabilistically reject requests to the upstream host
+	// when its success rate drops below a threshold.
+	// +optional
+	AdmissionControl *AdmissionControlSpec `json:"admissionControl,omitempty"`
+
+	// AdaptiveConcurrency defines the settings used to dynamically limit the number of concurrent requests
+	// to the upstream host based on its observed latency.
+	// +optional
+	AdaptiveConcurrency *AdaptiveConcurrencySpec `json:"adaptiveConcurrency,omitempty"`
+
+	// Inbound defines the limits applied by the upstream host to the connections it accepts from clients
+	// within the mesh, and the ports on which it also accepts plaintext connections.
+	// +optional
+	Inbound *InboundConnectionSettingsSpec `json:"inbound,omitempty"`
+
+	// GRPCRoutes defines the routes of the gRPC services and methods served by the upstream host, along with
+	// the policies applied by clients to the requests matching them.
+	// +optional
+	GRPCRoutes []GRPCRouteSpec `json:"grpcRoutes,omitempty"`
+
+	// JWTAuthentication defines the settings used by the upstream host to verify the JSON Web Tokens (JWT)
+	// of the HTTP requests directed to it, and to authorize these requests based on the identity claimed by the token.
+	// +optional
+	JWTAuthentication *JWTAuthenticationSpec `json:"jwtAuthentication,omitempty"`
+
+	// Maintenance puts the upstream host in maintenance mode, in which its sidecars respond to the HTTP requests
+	// directed to it instead of forwarding them, while the host remains discoverable by clients.
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+}
+
+// ConnectionSettingsSpec is the type used to represent the connection pool and circuit breaking settings
+// applied to the traffic directed to an upstream host
+type ConnectionSettingsSpec struct {
+	// TCP defines the settings applied to the TCP connections to the upstream host.
+	// +optional
+	TCP *TCPConnectionSettings `json:"tcp,omitempty"`
+
+	// HTTP defines the settings applied to the HTTP requests to the upstream host.
+	// +optional
+	HTTP *HTTPConnectionSettings `json:"http,omitempty"`
+}
+
+// TCPConnectionSettings is the type used to represent the settings applied to the TCP connections to an upstream host
+type TCPConnectionSettings struct {
+	// MaxConnections defines the maximum number of connections a client establishes to the upstream host.
+	// Defaults to 1024.
+	// +optional
+	MaxConnections *uint32 `json:"maxConnections,omitempty"`
+
+	// ConnectTimeout defines the timeout of the connections to the upstream host.
+	// Defaults to 1s.
+	// +optional
+	ConnectTimeout *metav1.Duration `json:"connectTimeout,omitempty"`
+}
+
+// HTTPConnectionSettings is the type used to represent the settings applied to the HTTP requests to an upstream host
+type HTTPConnectionSettings struct {
+	// MaxRequestsPerConnection defines the maximum number of requests sent over a single connection
+	// to the upstream host. Defaults to unlimited.
+	// +optional
+	MaxRequestsPerConnection *uint32 `json:"maxRequestsPerConnection,omitempty"`
+
+	// MaxPendingRequests defines the maximum number of requests queued by a client while waiting for
+	// a connection to the upstream host. Defaults to 1024.
+	// +optional
+	MaxPendingRequests *uint32 `json:"maxPendingRequests,omitempty"`
+
+	// MaxRequests defines the maximum number of parallel requests a client sends to the upstream host.
+	// Defaults to 1024.
+	// +optional
+	MaxRequests *uint32 `json:"maxRequests,omitempty"`
+
+	// MaxRetries defines the maximum number of parallel retries a client sends to the upstream host.
+	// Defaults to 3.
+	// +optional
+	MaxRetries *uint32 `json:"maxRetries,omitempty"`
+}
+
+// RateLimitSpec is the type used to represent the rate limiting settings applied to the traffic directed to an upstream host
+type RateLimitSpec struct {
+	// Local defines the rate limit enforced independently by each client.
+	// +optional
+	Local *LocalRateLimitSpec `json:"local,omitempty"`
+}
+
+// LocalRateLimitSpec is the type used to represent the rate limit enforced independently by each client
+type LocalRateLimitSpec struct {
+	// TCP defines the rate limit applied to the TCP connections to the upstream host.
+	// +optional
+	TCP *TCPLocalRateLimitSpec `json:"tcp,omitempty"`
+
+	// HTTP defines the rate limit applied by each sidecar of the upstream host to the HTTP requests it receives.
+	// +optional
+	HTTP *HTTPLocalRateLimitSpec `json:"http,omitempty"`
+}
+
+// TCPLocalRateLimitSpec is the type used to represent the rate limit applied to the TCP connections to an upstream host
+type TCPLocalRateLimitSpec struct {
+	// Connections defines the number of connections allowed per unit of time.
+	Connections uint32 `json:"connections"`
+
+	// Unit defines the period of time over which the connections are allowed, one of: second, minute, hour.
+	Unit string `json:"unit"`
+
+	// Burst defines the number of connections allowed in addition to Connections at a given point in time,
+	// to accommodate bursts of connections. Defaults to 0.
+	// +optional
+	Burst uint32 `json:"burst,omitempty"`
+}
+
+// HTTPLocalRateLimitSpec is the type used to represent the rate limit applied by an upstream host to the HTTP requests
+// it receives, which can depend on the identity of the clients sending them
+type HTTPLocalRateLimitSpec struct {
+	// Requests defines the number of requests allowed per unit of time from the clients not listed in Sources.
+	// The requests from these clients are not rate limited when 0.
+	// +optional
+	Requests uint32 `json:"requests,omitempty"`
+
+	// Unit defines the period of time over which the requests are allowed, one of: second, minute, hour.
+	Unit string `json:"unit"`
+
+	// Burst defines the number of requests allowed in addition to Requests at a given point in time,
+	// to accommodate bursts of requests. Defaults to 0.
+	// +optional
+	Burst uint32 `json:"burst,omitempty"`
+
+	// Sources defines the rate limits applied to the requests from specific clients, overriding the rate limit
+	// applied to the other clients.
+	// +optional
+	Sources []HTTPSourceLocalRateLimitSpec `json:"sources,omitempty"`
+}
+
+// HTTPSourceLocalRateLimitSpec is the type used to represent the rate limit applied by an upstream host to the HTTP
+// requests it receives from a client identified by its service account
+type HTTPSourceLocalRateLimitSpec struct {
+	// Name defines the name of the service account of the client, which is authenticated by the identity of the
+	// client's mTLS certificate.
+	Name string `json:"name"`
+
+	// Namespace defines the namespace of the service account of the client.
+	Namespace string `json:"namespace"`
+
+	// Requests defines the number of requests allowed from the client per unit of time.
+	Requests uint32 `json:"requests"`
+
+	// Burst defines the number of requests allowed from the client in addition to Requests at a given point in time.
+	// Defaults to 0.
+	// +optional
+	Burst uint32 `json:"burst,omitempty"`
+}
+
+// AdmissionControlSpec is the type used to represent the admission control settings applied to the traffic
+// directed to an upstream host
+type AdmissionControlSpec struct {
+	// SamplingWindow defines the time window over which the success rate of requests is computed.
+	// Defaults to 60s.
+	// +optional
+	SamplingWindow *metav1.Duration `json:"samplingWindow,omitempty"`
+
+	// SuccessRateThreshold defines the success rate percentage below which requests start being rejected.
+	// Defaults to 95.
+	// +optional
+	SuccessRateThreshold *int `json:"successRateThreshold,omitempty"`
+
+	// Aggression defines how aggressively requests are rejected as the success rate drops below the threshold.
+	// A value of 1.0 rejects requests with a probability that scales linearly with the success rate.
+	// Defaults to 1.0.
+	// +optional
+	Aggression *float64 `json:"aggression,omitempty"`
+}
+
+// AdaptiveConcurrencySpec is the type used to represent the adaptive concurrency settings applied to the traffic
+// directed to an upstream host
+type AdaptiveConcurrencySpec struct {
+	// SampleAggregatePercentile defines the percentile of the sampled request latencies used to compute
+	// the concurrency limit. Defaults to 50.
+	// +optional
+	SampleAggregatePercentile *int `json:"sampleAggregatePercentile,omitempty"`
+
+	// ConcurrencyLimitUpdateInterval defines the interval at which the concurrency limit is recomputed.
+	// Defaults to 100ms.
+	// +optional
+	ConcurrencyLimitUpdateInterval *metav1.Duration `json:"concurrencyLimitUpdateInterval,omitempty"`
+
+	// MaxConcurrencyLimit defines the maximum concurrency limit. Defaults to 1000.
+	// +optional
+	MaxConcurrencyLimit *uint32 `json:"maxConcurrencyLimit,omitempty"`
+
+	// MinRTTCalcInterval defines the interval at which the ideal round-trip time of requests is measured.
+	// Defaults to 60s.
+	// +optional
+	MinRTTCalcInterval *metav1.Duration `json:"minRTTCalcInterval,omitempty"`
+
+	// MinRTTRequestCount defines the number of requests sampled to measure the ideal round-trip time.
+	// Defaults to 50.
+	// +optional
+	MinRTTRequestCount *uint32 `json:"minRTTRequestCount,omitempty"`
+
+	// MinConcurrency defines the concurrency limit enforced while the ideal round-trip time is measured.
+	// Defaults to 3.
+	// +optional
+	MinConcurrency *uint32 `json:"minConcurrency,omitempty"`
+}
+
+// InboundConnectionSettingsSpec is the type used to represent the limits applied by an upstream host to the
+// connections it accepts from clients within the mesh, and the ports on which it also accepts plaintext connections
+type InboundConnectionSettingsSpec struct {
+	// MaxConnections defines the maximum number of concurrent connections accepted on each port of the
+	// upstream host. Connections exceeding the limit are closed immediately. Defaults to unlimited.
+	// +optional
+	MaxConnections *uint32 `json:"maxConnections,omitempty"`
+
+	// PerConnectionBufferLimitBytes defines the soft limit on the size of the read and write buffers
+	// of each connection accepted by the upstream host. Defaults to 1MiB.
+	// +optional
+	PerConnectionBufferLimitBytes *uint32 `json:"perConnectionBufferLimitBytes,omitempty"`
+
+	// PermissiveTLSPorts defines the target ports of the upstream host that accept plaintext connections,
+	// ex. from a Prometheus server outside the mesh, in addition to mTLS connections. Plaintext connections
+	// carry no client identity, so they are not authorized by access control policies.
+	// Ports that are also backends of an ingress only accept the ingress traffic.
+	// +optional
+	PermissiveTLSPorts []uint32 `json:"permissiveTLSPorts,omitempty"`
+}
+
+// GRPCRouteSpec is the type used to represent a route matching the requests to a gRPC service, or to one
+// of its methods, served by an upstream host
+type GRPCRouteSpec struct {
+	// Service defines the fully qualified name of the gRPC service, of the form <package>.<service>.
+	Service string `json:"service"`
+
+	// Method defines the name of the gRPC method. Defaults to matching all the methods of the service.
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Retry defines the retry policy applied by clients to the requests matching the route.
+	// +optional
+	Retry *GRPCRetryPolicySpec `json:"retry,omitempty"`
+}
+
+// GRPCRetryPolicySpec is the type used to represent the retry policy applied to the requests matching a gRPC route
+type GRPCRetryPolicySpec struct {
+	// RetryOn defines the gRPC status codes of the responses on which requests are retried, one or more of:
+	// cancelled, deadline-exceeded, internal, resource-exhausted, unavailable.
+	RetryOn []string `json:"retryOn"`
+
+	// NumRetries defines the maximum number of retries of a request. Defaults to 1.
+	// +optional
+	NumRetries *uint32 `json:"numRetries,omitempty"`
+
+	// PerTryTimeout defines the timeout of each attempt of a request, including the first one.
+	// Defaults to the timeout of the request.
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+}
+
+// JWTAuthenticationSpec is the type used to represent the settings used to verify the JSON Web Tokens (JWT)
+// of the HTTP requests directed to an upstream host and to authorize these requests based on the identity
+// claimed by the token
+type JWTAuthenticationSpec struct {
+	// Issuer defines the principal that issued the JWTs, matched against the 'iss' claim of the tokens.
+	Issuer string `json:"issuer"`
+
+	// Audiences defines the audiences allowed to access the upstream host, matched against the 'aud' claim
+	// of the tokens. Defaults to allowing any audience.
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JWKS defines the JSON Web Key Set, in JSON format, used to verify the signature of the tokens.
+	JWKS string `json:"jwks"`
+
+	// IdentityClaim defines the claim of the tokens holding the identity of the client, of the form
+	// <namespace>/<service account>. Requests are authorized if this identity is allowed to access the
+	// upstream host by the traffic policies. Defaults to 'sub'.
+	// +optional
+	IdentityClaim string `json:"identityClaim,omitempty"`
+
+	// Required defines whether requests without a token are rejected. When false, requests without a token
+	// are authorized based on the identity of the client's mTLS certificate only. Defaults to false.
+	// +optional
+	Required bool `json:"required,omitempty"`
+}
+
+// MaintenanceSpec is the type used to represent the response returned to the HTTP requests directed to an upstream
+// host in maintenance mode
+type MaintenanceSpec struct {
+	// StatusCode defines the HTTP status code of the response, between 200 and 599. Defaults to 503.
+	// +optional
+	StatusCode *uint32 `json:"statusCode,omitempty"`
+
+	// RetryAfter defines the delay after which clients are advised to retry their requests, returned in seconds
+	// in the Retry-After header of the response. The header is omitted if not specified.
+	// +optional
+	RetryAfter *metav1.Duration `json:"retryAfter,omitempty"`
+
+	// Body defines the body of the response. Defaults to an empty body.
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+// UpstreamTrafficSettingList defines the list of UpstreamTrafficSetting objects
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type UpstreamTrafficSettingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []UpstreamTrafficSetting `json:"items"`
+}
@@ -1,38 +1,65 @@
 package catalog
 
 import (
+	"time"
+
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/ticker"
+	"github.com/openservicemesh/osm/pkg/validity"
 )
 
 // NewMeshCatalog creates a new service catalog
-func NewMeshCatalog(kubeController k8s.Controller, kubeClient kubernetes.Interface, meshSpec smi.MeshSpec, certManager certificate.Manager, ingressMonitor ingress.Monitor, policyController policy.Controller, stop <-chan struct{}, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
+func NewMeshCatalog(kubeController k8s.Controller, kubeClient kubernetes.Interface, meshSpec smi.MeshSpec, certManager certificate.Manager, ingressMonitor ingress.Monitor, policyController policy.Controller, identityProviders []identity.Provider, stop <-chan struct{}, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
 	log.Info().Msg("Create a new Service MeshCatalog.")
-	mc := MeshCatalog{
+	mc := newMeshCatalog(kubeController, meshSpec, ingressMonitor, policyController, identityProviders, cfg, endpointsProviders...)
+
+	// Kubernetes needed to determine what Services a pod that connects to XDS belongs to.
+	// In multicluster scenarios this would be a map of cluster ID to Kubernetes client.
+	// The certificate itself would contain the cluster ID making it easy to lookup the client in this map.
+	mc.kubeClient = kubeClient
+	mc.certManager = certManager
+
+	go mc.dispatcher()
+	go mc.watchPolicyValidity(stop)
+	ticker.InitTicker(cfg)
+
+	return mc
+}
+
+// NewStaticMeshCatalog creates a service catalog computing the traffic policies of the mesh from the given sources,
+// without a Kubernetes client and without subscribing to changes. It is meant to compute policies from declarative
+// inputs, such as the sources returned by k8s.NewStaticController, smi.NewStaticMeshSpec and policy.NewStaticController.
+// Ingress policies are not computed by a static catalog. The policies of a static catalog do not depend on the state of
+// the process either: the given feature flags are used instead of the ones of the process, and the validity windows of
+// policies are evaluated at the given time.
+func NewStaticMeshCatalog(kubeController k8s.Controller, meshSpec smi.MeshSpec, policyController policy.Controller, identityProviders []identity.Provider, features featureflags.OptionalFeatures, now time.Time, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
+	mc := newMeshCatalog(kubeController, meshSpec, nil, policyController, identityProviders, cfg, endpointsProviders...)
+	mc.features = &features
+	mc.clock = func() time.Time {
+		return now
+	}
+	return mc
+}
+
+func newMeshCatalog(kubeController k8s.Controller, meshSpec smi.MeshSpec, ingressMonitor ingress.Monitor, policyController policy.Controller, identityProviders []identity.Provider, cfg configurator.Configurator, endpointsProviders ...endpoint.Provider) *MeshCatalog {
+	return &MeshCatalog{
 		endpointsProviders: endpointsProviders,
 		meshSpec:           meshSpec,
-		certManager:        certManager,
 		ingressMonitor:     ingressMonitor,
 		policyController:   policyController,
 		configurator:       cfg,
-
-		// Kubernetes needed to determine what Services a pod that connects to XDS belongs to.
-		// In multicluster scenarios this would be a map of cluster ID to Kubernetes client.
-		// The certificate itself would contain the cluster ID making it easy to lookup the client in this map.
-		kubeClient:     kubeClient,
-		kubeController: kubeController,
+		identityResolver:   identity.NewResolver(identityProviders...),
+		kubeController:     kubeController,
+		validityWindows:    validity.NewCache(),
 	}
-
-	go mc.dispatcher()
-	ticker.InitTicker(cfg)
-
-	return &mc
 }
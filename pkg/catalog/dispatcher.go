@@ -36,11 +36,13 @@ func (mc *MeshCatalog) dispatcher() {
 		a.RouteGroupAdded, a.RouteGroupDeleted, a.RouteGroupUpdated, // routegroup
 		a.ServiceAdded, a.ServiceDeleted, a.ServiceUpdated, // service
 		a.ServiceAccountAdded, a.ServiceAccountDeleted, a.ServiceAccountUpdated, // serviceaccount
+		a.SecretAdded, a.SecretDeleted, a.SecretUpdated, // secret
 		a.TrafficSplitAdded, a.TrafficSplitDeleted, a.TrafficSplitUpdated, // traffic split
 		a.TrafficTargetAdded, a.TrafficTargetDeleted, a.TrafficTargetUpdated, // traffic target
 		a.IngressAdded, a.IngressDeleted, a.IngressUpdated, // Ingress
 		a.TCPRouteAdded, a.TCPRouteDeleted, a.TCPRouteUpdated, // TCProute
 		a.EgressAdded, a.EgressDeleted, a.EgressUpdated, // Egress
+		a.UpstreamTrafficSettingAdded, a.UpstreamTrafficSettingDeleted, a.UpstreamTrafficSettingUpdated, // UpstreamTrafficSetting
 	)
 
 	// State and channels for event-coalescing
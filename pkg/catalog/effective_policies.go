@@ -0,0 +1,276 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	mapset "github.com/deckarep/golang-set"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	// wildcardServiceAccountName is the name of the service account representing any client, used by policies not
+	// enforcing service account based RBAC such as ingress policies
+	wildcardServiceAccountName = "*"
+)
+
+// GetEffectivePolicies returns the inbound, outbound, egress and ingress policies applied to the traffic of the given service identity
+func (mc *MeshCatalog) GetEffectivePolicies(serviceIdentity identity.ServiceIdentity) (*trafficpolicy.EffectivePolicies, error) {
+	services := mc.ListMeshServicesForIdentity(serviceIdentity)
+
+	effectivePolicies := &trafficpolicy.EffectivePolicies{
+		Identity: serviceIdentity.String(),
+		Inbound:  toEffectiveInboundPolicies(mc.ListInboundTrafficPolicies(serviceIdentity, services)),
+		Outbound: toEffectiveOutboundPolicies(mc.ListOutboundTrafficPolicies(serviceIdentity)),
+	}
+
+	trafficTargets, err := mc.ListInboundTrafficTargetsWithRoutes(serviceIdentity)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing inbound traffic targets for identity %s", serviceIdentity)
+		return nil, err
+	}
+	effectivePolicies.InboundTCP = toEffectiveTCPTrafficTargets(trafficTargets)
+
+	egressPolicy, err := mc.GetEgressTrafficPolicy(serviceIdentity)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting egress traffic policy for identity %s", serviceIdentity)
+		return nil, err
+	}
+	effectivePolicies.Egress = toEffectiveEgressPolicies(egressPolicy)
+
+	var ingressPolicies []*trafficpolicy.InboundTrafficPolicy
+	for _, svc := range services {
+		policies, err := mc.GetIngressPoliciesForService(svc)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting ingress policies for service %s", svc)
+			return nil, err
+		}
+		ingressPolicies = append(ingressPolicies, policies...)
+	}
+	effectivePolicies.Ingress = toEffectiveInboundPolicies(ingressPolicies)
+
+	return effectivePolicies, nil
+}
+
+// toEffectiveInboundPolicies converts the given inbound traffic policies to effective policies sorted by name
+func toEffectiveInboundPolicies(policies []*trafficpolicy.InboundTrafficPolicy) []trafficpolicy.EffectiveTrafficPolicy {
+	effectivePolicies := []trafficpolicy.EffectiveTrafficPolicy{}
+	for _, policy := range policies {
+		effectivePolicy := trafficpolicy.EffectiveTrafficPolicy{
+			Name:      policy.Name,
+			Hostnames: sortedStrings(policy.Hostnames),
+			Routes:    []trafficpolicy.EffectiveRoute{},
+		}
+		for _, rule := range policy.Rules {
+			route := toEffectiveRoute(rule.Route)
+			route.AllowedServiceAccounts = toServiceAccountNames(rule.AllowedServiceAccounts)
+			effectivePolicy.Routes = append(effectivePolicy.Routes, route)
+		}
+		sortEffectiveRoutes(effectivePolicy.Routes)
+		effectivePolicies = append(effectivePolicies, effectivePolicy)
+	}
+	sortEffectiveTrafficPolicies(effectivePolicies)
+	return effectivePolicies
+}
+
+// toEffectiveOutboundPolicies converts the given outbound traffic policies to effective policies sorted by name
+func toEffectiveOutboundPolicies(policies []*trafficpolicy.OutboundTrafficPolicy) []trafficpolicy.EffectiveTrafficPolicy {
+	effectivePolicies := []trafficpolicy.EffectiveTrafficPolicy{}
+	for _, policy := range policies {
+		effectivePolicy := trafficpolicy.EffectiveTrafficPolicy{
+			Name:      policy.Name,
+			Hostnames: sortedStrings(policy.Hostnames),
+			Routes:    []trafficpolicy.EffectiveRoute{},
+		}
+		for _, route := range policy.Routes {
+			effectivePolicy.Routes = append(effectivePolicy.Routes, toEffectiveRoute(*route))
+		}
+		sortEffectiveRoutes(effectivePolicy.Routes)
+		effectivePolicies = append(effectivePolicies, effectivePolicy)
+	}
+	sortEffectiveTrafficPolicies(effectivePolicies)
+	return effectivePolicies
+}
+
+// toEffectiveTCPTrafficTargets converts the given traffic targets with TCP routes to effective TCP traffic targets
+// sorted by name. Traffic targets without TCP routes only allow HTTP traffic, which is part of the inbound policies.
+func toEffectiveTCPTrafficTargets(trafficTargets []trafficpolicy.TrafficTargetWithRoutes) []trafficpolicy.EffectiveTCPTrafficTarget {
+	effectiveTrafficTargets := []trafficpolicy.EffectiveTCPTrafficTarget{}
+	for _, trafficTarget := range trafficTargets {
+		if len(trafficTarget.TCPRouteMatches) == 0 {
+			continue
+		}
+
+		effectiveTrafficTarget := trafficpolicy.EffectiveTCPTrafficTarget{
+			Name:              trafficTarget.Name,
+			AllowedIdentities: []string{},
+		}
+		for _, source := range trafficTarget.Sources {
+			effectiveTrafficTarget.AllowedIdentities = append(effectiveTrafficTarget.AllowedIdentities, source.String())
+		}
+		sort.Strings(effectiveTrafficTarget.AllowedIdentities)
+
+		// A TCP route without ports allows all ports
+		var ports []int
+		for _, tcpRouteMatch := range trafficTarget.TCPRouteMatches {
+			if len(tcpRouteMatch.Ports) == 0 {
+				ports = nil
+				break
+			}
+			ports = append(ports, tcpRouteMatch.Ports...)
+		}
+		effectiveTrafficTarget.Ports = dedupAndSortPorts(ports)
+
+		effectiveTrafficTargets = append(effectiveTrafficTargets, effectiveTrafficTarget)
+	}
+
+	sort.SliceStable(effectiveTrafficTargets, func(i, j int) bool {
+		return effectiveTrafficTargets[i].Name < effectiveTrafficTargets[j].Name
+	})
+	return effectiveTrafficTargets
+}
+
+// toEffectiveEgressPolicies converts the given egress traffic policy to effective policies sorted by port
+func toEffectiveEgressPolicies(policy *trafficpolicy.EgressTrafficPolicy) []trafficpolicy.EffectiveEgressPolicy {
+	effectivePolicies := []trafficpolicy.EffectiveEgressPolicy{}
+	if policy == nil {
+		return effectivePolicies
+	}
+
+	for _, trafficMatch := range policy.TrafficMatches {
+		port := trafficMatch.DestinationPort.Number
+		effectivePolicy := trafficpolicy.EffectiveEgressPolicy{
+			Port:        port,
+			Protocol:    trafficMatch.DestinationPort.Protocol,
+			ServerNames: sortedStrings(trafficMatch.ServerNames),
+			IPRanges:    sortedStrings(trafficMatch.DestinationIPRanges),
+			Hosts:       sortedStrings(trafficMatch.DestinationHosts),
+		}
+		for _, routeConfig := range policy.HTTPRouteConfigsPerPort[port] {
+			httpRoute := trafficpolicy.EffectiveTrafficPolicy{
+				Name:      routeConfig.Name,
+				Hostnames: sortedStrings(routeConfig.Hostnames),
+				Routes:    []trafficpolicy.EffectiveRoute{},
+			}
+			for _, rule := range routeConfig.RoutingRules {
+				route := toEffectiveRoute(rule.Route)
+				route.AllowedDestinationIPRanges = sortedStrings(rule.AllowedDestinationIPRanges)
+				httpRoute.Routes = append(httpRoute.Routes, route)
+			}
+			sortEffectiveRoutes(httpRoute.Routes)
+			effectivePolicy.HTTPRoutes = append(effectivePolicy.HTTPRoutes, httpRoute)
+		}
+		sortEffectiveTrafficPolicies(effectivePolicy.HTTPRoutes)
+		effectivePolicies = append(effectivePolicies, effectivePolicy)
+	}
+
+	sort.SliceStable(effectivePolicies, func(i, j int) bool {
+		if effectivePolicies[i].Port != effectivePolicies[j].Port {
+			return effectivePolicies[i].Port < effectivePolicies[j].Port
+		}
+		if effectivePolicies[i].Protocol != effectivePolicies[j].Protocol {
+			return effectivePolicies[i].Protocol < effectivePolicies[j].Protocol
+		}
+		return fmt.Sprint(effectivePolicies[i].ServerNames) < fmt.Sprint(effectivePolicies[j].ServerNames)
+	})
+	return effectivePolicies
+}
+
+// toEffectiveRoute converts the given route and its weighted clusters to an effective route
+func toEffectiveRoute(route trafficpolicy.RouteWeightedClusters) trafficpolicy.EffectiveRoute {
+	effectiveRoute := trafficpolicy.EffectiveRoute{
+		Path:          route.HTTPRouteMatch.Path,
+		PathMatchType: getPathMatchTypeName(route.HTTPRouteMatch.PathMatchType),
+		Methods:       sortedStrings(route.HTTPRouteMatch.Methods),
+		Headers:       route.HTTPRouteMatch.Headers,
+	}
+
+	if route.WeightedClusters != nil {
+		for wc := range route.WeightedClusters.Iter() {
+			weightedCluster, ok := wc.(service.WeightedCluster)
+			if !ok {
+				continue
+			}
+			effectiveRoute.WeightedClusters = append(effectiveRoute.WeightedClusters, trafficpolicy.EffectiveWeightedCluster{
+				Cluster: weightedCluster.ClusterName.String(),
+				Weight:  weightedCluster.Weight,
+			})
+		}
+	}
+	sort.Slice(effectiveRoute.WeightedClusters, func(i, j int) bool {
+		return effectiveRoute.WeightedClusters[i].Cluster < effectiveRoute.WeightedClusters[j].Cluster
+	})
+
+	return effectiveRoute
+}
+
+// toServiceAccountNames returns the sorted names of the service identities in the given set, the service accounts
+// of those in the cluster local trust domain being named <namespace>/<name>
+func toServiceAccountNames(serviceIdentities mapset.Set) []string {
+	if serviceIdentities == nil {
+		return nil
+	}
+
+	var names []string
+	for si := range serviceIdentities.Iter() {
+		serviceIdentity := si.(identity.ServiceIdentity)
+		switch {
+		case serviceIdentity == wildcardServiceIdentity:
+			names = append(names, wildcardServiceAccountName)
+		case serviceIdentity.IsK8sServiceAccount():
+			names = append(names, serviceIdentity.ToK8sServiceAccount().String())
+		default:
+			names = append(names, serviceIdentity.String())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getPathMatchTypeName returns the name of the given path match type
+func getPathMatchTypeName(pathMatchType trafficpolicy.PathMatchType) string {
+	switch pathMatchType {
+	case trafficpolicy.PathMatchRegex:
+		return "regex"
+	case trafficpolicy.PathMatchExact:
+		return "exact"
+	case trafficpolicy.PathMatchPrefix:
+		return "prefix"
+	default:
+		return ""
+	}
+}
+
+// sortEffectiveTrafficPolicies sorts the given policies by name
+func sortEffectiveTrafficPolicies(policies []trafficpolicy.EffectiveTrafficPolicy) {
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+}
+
+// sortEffectiveRoutes sorts the given routes by path, path match type and methods
+func sortEffectiveRoutes(routes []trafficpolicy.EffectiveRoute) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		if routes[i].PathMatchType != routes[j].PathMatchType {
+			return routes[i].PathMatchType < routes[j].PathMatchType
+		}
+		return fmt.Sprint(routes[i].Methods) < fmt.Sprint(routes[j].Methods)
+	})
+}
+
+// sortedStrings returns a sorted copy of the given strings
+func sortedStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	sorted := make([]string, len(s))
+	copy(sorted, s)
+	sort.Strings(sorted)
+	return sorted
+}
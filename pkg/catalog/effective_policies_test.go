@@ -0,0 +1,241 @@
+package catalog
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	tassert "github.com/stretchr/testify/assert"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestToEffectiveInboundPolicies(t *testing.T) {
+	assert := tassert.New(t)
+
+	policies := []*trafficpolicy.InboundTrafficPolicy{
+		{
+			Name:      "bookstore-v1.default",
+			Hostnames: []string{"bookstore-v1.default", "bookstore-v1"},
+			Rules: []*trafficpolicy.Rule{
+				{
+					Route: trafficpolicy.RouteWeightedClusters{
+						HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+							Path:          "/sell",
+							PathMatchType: trafficpolicy.PathMatchRegex,
+							Methods:       []string{"POST", "GET"},
+						},
+						WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-v1-local", Weight: 100}),
+					},
+					AllowedServiceAccounts: mapset.NewSet(tests.BookbuyerServiceIdentity, identity.K8sServiceAccount{Name: "foo", Namespace: "bar"}.ToServiceIdentity()),
+				},
+				{
+					Route: trafficpolicy.RouteWeightedClusters{
+						HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+							Path:          "/buy",
+							PathMatchType: trafficpolicy.PathMatchExact,
+							Headers:       map[string]string{"user-agent": "test"},
+						},
+						WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-v1-local", Weight: 100}),
+					},
+					AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
+				},
+			},
+		},
+		{
+			Name:      "a-first.default",
+			Hostnames: []string{"a-first.default"},
+		},
+	}
+
+	expected := []trafficpolicy.EffectiveTrafficPolicy{
+		{
+			Name:      "a-first.default",
+			Hostnames: []string{"a-first.default"},
+			Routes:    []trafficpolicy.EffectiveRoute{},
+		},
+		{
+			Name:      "bookstore-v1.default",
+			Hostnames: []string{"bookstore-v1", "bookstore-v1.default"},
+			Routes: []trafficpolicy.EffectiveRoute{
+				{
+					Path:                   "/buy",
+					PathMatchType:          "exact",
+					Headers:                map[string]string{"user-agent": "test"},
+					WeightedClusters:       []trafficpolicy.EffectiveWeightedCluster{{Cluster: "default/bookstore-v1-local", Weight: 100}},
+					AllowedServiceAccounts: []string{"*"},
+				},
+				{
+					Path:                   "/sell",
+					PathMatchType:          "regex",
+					Methods:                []string{"GET", "POST"},
+					WeightedClusters:       []trafficpolicy.EffectiveWeightedCluster{{Cluster: "default/bookstore-v1-local", Weight: 100}},
+					AllowedServiceAccounts: []string{"bar/foo", "default/bookbuyer"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(expected, toEffectiveInboundPolicies(policies))
+	assert.Equal([]trafficpolicy.EffectiveTrafficPolicy{}, toEffectiveInboundPolicies(nil))
+}
+
+func TestToEffectiveOutboundPolicies(t *testing.T) {
+	assert := tassert.New(t)
+
+	policies := []*trafficpolicy.OutboundTrafficPolicy{
+		{
+			Name:      "bookstore.default",
+			Hostnames: []string{"bookstore.default"},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch: tests.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(
+						service.WeightedCluster{ClusterName: "default/bookstore-v2", Weight: 50},
+						service.WeightedCluster{ClusterName: "default/bookstore-v1", Weight: 50},
+					),
+				},
+			},
+		},
+	}
+
+	expected := []trafficpolicy.EffectiveTrafficPolicy{
+		{
+			Name:      "bookstore.default",
+			Hostnames: []string{"bookstore.default"},
+			Routes: []trafficpolicy.EffectiveRoute{
+				{
+					Path:          ".*",
+					PathMatchType: "regex",
+					Methods:       []string{"*"},
+					WeightedClusters: []trafficpolicy.EffectiveWeightedCluster{
+						{Cluster: "default/bookstore-v1", Weight: 50},
+						{Cluster: "default/bookstore-v2", Weight: 50},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(expected, toEffectiveOutboundPolicies(policies))
+}
+
+func TestToEffectiveTCPTrafficTargets(t *testing.T) {
+	assert := tassert.New(t)
+
+	trafficTargets := []trafficpolicy.TrafficTargetWithRoutes{
+		{
+			Name:        "default/mysql-all-ports",
+			Destination: "mysql.default.cluster.local",
+			Sources:     []identity.ServiceIdentity{"spiffe://example.org/vm/billing"},
+			TCPRouteMatches: []trafficpolicy.TCPRouteMatch{
+				{Ports: []int{3306}},
+				{Ports: nil},
+			},
+		},
+		{
+			// HTTP traffic targets are part of the inbound policies
+			Name:        "default/mysql-http",
+			Destination: "mysql.default.cluster.local",
+			Sources:     []identity.ServiceIdentity{"bookstore.default.cluster.local"},
+		},
+		{
+			Name:        "default/mysql",
+			Destination: "mysql.default.cluster.local",
+			Sources:     []identity.ServiceIdentity{"bookwarehouse.default.cluster.local", "bookstore.default.cluster.local"},
+			TCPRouteMatches: []trafficpolicy.TCPRouteMatch{
+				{Ports: []int{33060, 3306}},
+				{Ports: []int{3306}},
+			},
+		},
+	}
+
+	expected := []trafficpolicy.EffectiveTCPTrafficTarget{
+		{
+			Name:              "default/mysql",
+			AllowedIdentities: []string{"bookstore.default.cluster.local", "bookwarehouse.default.cluster.local"},
+			Ports:             []int{3306, 33060},
+		},
+		{
+			Name:              "default/mysql-all-ports",
+			AllowedIdentities: []string{"spiffe://example.org/vm/billing"},
+		},
+	}
+
+	assert.Equal(expected, toEffectiveTCPTrafficTargets(trafficTargets))
+	assert.Equal([]trafficpolicy.EffectiveTCPTrafficTarget{}, toEffectiveTCPTrafficTargets(nil))
+}
+
+func TestToEffectiveEgressPolicies(t *testing.T) {
+	assert := tassert.New(t)
+
+	policy := &trafficpolicy.EgressTrafficPolicy{
+		TrafficMatches: []*trafficpolicy.TrafficMatch{
+			{DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"}, ServerNames: []string{"foo.com"}, Cluster: "foo.com:443"},
+			{DestinationPort: policyV1alpha1.PortSpec{Number: 80, Protocol: "http"}},
+			{DestinationPort: policyV1alpha1.PortSpec{Number: 3306, Protocol: "tcp"}, DestinationIPRanges: []string{"10.1.0.0/16", "10.0.0.0/24"}, DestinationHosts: []string{"mysql.example.com"}},
+			{DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"}, ServerNames: []string{"bar.com"}, Cluster: "bar.com:443"},
+		},
+		HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{
+			80: {
+				{
+					Name:      "foo.com",
+					Hostnames: []string{"foo.com", "foo.com:80"},
+					RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+						{
+							Route: trafficpolicy.RouteWeightedClusters{
+								HTTPRouteMatch:   tests.WildCardRouteMatch,
+								WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "foo.com:80", Weight: 100}),
+							},
+							AllowedDestinationIPRanges: []string{"1.1.1.1/32", "0.0.0.0/0"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []trafficpolicy.EffectiveEgressPolicy{
+		{
+			Port:     80,
+			Protocol: "http",
+			HTTPRoutes: []trafficpolicy.EffectiveTrafficPolicy{
+				{
+					Name:      "foo.com",
+					Hostnames: []string{"foo.com", "foo.com:80"},
+					Routes: []trafficpolicy.EffectiveRoute{
+						{
+							Path:                       ".*",
+							PathMatchType:              "regex",
+							Methods:                    []string{"*"},
+							WeightedClusters:           []trafficpolicy.EffectiveWeightedCluster{{Cluster: "foo.com:80", Weight: 100}},
+							AllowedDestinationIPRanges: []string{"0.0.0.0/0", "1.1.1.1/32"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Port:        443,
+			Protocol:    "https",
+			ServerNames: []string{"bar.com"},
+		},
+		{
+			Port:        443,
+			Protocol:    "https",
+			ServerNames: []string{"foo.com"},
+		},
+		{
+			Port:     3306,
+			Protocol: "tcp",
+			IPRanges: []string{"10.0.0.0/24", "10.1.0.0/16"},
+			Hosts:    []string{"mysql.example.com"},
+		},
+	}
+
+	assert.Equal(expected, toEffectiveEgressPolicies(policy))
+	assert.Equal([]trafficpolicy.EffectiveEgressPolicy{}, toEffectiveEgressPolicies(nil))
+}
@@ -13,29 +13,60 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // GetEgressTrafficPolicy returns the Egress traffic policy associated with the given service identity
 func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
-	if !featureflags.IsEgressPolicyEnabled() {
+	if !mc.isEgressPolicyEnabled() {
 		return nil, nil
 	}
 
 	var trafficMatches []*trafficpolicy.TrafficMatch
 	var clusterConfigs []*trafficpolicy.EgressClusterConfig
-	allowedDestinationPorts := mapset.NewSet()
+	tcpTrafficMatches := make(map[policyV1alpha1.PortSpec]*trafficpolicy.TrafficMatch)
+	allowedTLSDestinations := mapset.NewSet()
 	portToRouteConfigMap := make(map[int][]*trafficpolicy.EgressHTTPRouteConfig)
 
-	egressResources := mc.policyController.ListEgressPoliciesForSourceIdentity(serviceIdentity.ToK8sServiceAccount())
+	source := serviceIdentity.ToK8sServiceAccount()
+	var egressResources []*policyV1alpha1.Egress
+	now := mc.now()
+	for _, egress := range mc.policyController.ListEgressPoliciesForSourceIdentity(source) {
+		if mc.isEgressActive(egress, now) {
+			egressResources = append(egressResources, egress)
+		}
+	}
+
+	// Multiple Egress policies can specify the same host and port, in which case a single policy is applied to them
+	precedence := policy.ResolveEgressPrecedence(source.Namespace, egressResources)
 
 	for _, egress := range egressResources {
-		for _, portSpec := range egress.Spec.Ports {
+		denied := policy.IsEgressDenied(egress)
+		for _, portSpec := range getEgressPortSpecs(egress) {
+			isHTTP := strings.EqualFold(portSpec.Protocol, constants.ProtocolHTTP)
+			isHTTPS := strings.EqualFold(portSpec.Protocol, constants.ProtocolHTTPS)
+
+			// A Deny action only applies to the hosts on HTTP and HTTPS ports
+			if denied && !isHTTP && !isHTTPS {
+				log.Warn().Msgf("Deny action is not applicable to port %d with protocol %s in egress policy %s/%s; will be skipped",
+					portSpec.Number, portSpec.Protocol, egress.Namespace, egress.Name)
+				continue
+			}
+
 			// ---
 			// Build the HTTP route configs for the given Egress policy
-			if strings.EqualFold(portSpec.Protocol, constants.ProtocolHTTP) {
-				httpRouteConfigs, httpClusterConfigs := mc.buildHTTPRouteConfigs(egress, portSpec.Number)
+			if isHTTP && denied {
+				denyRouteConfigs := buildDenyHTTPRouteConfigs(egress, portSpec.Number, precedence)
+				portToRouteConfigMap[portSpec.Number] = append(portToRouteConfigMap[portSpec.Number], denyRouteConfigs...)
+			} else if isHTTP {
+				httpRouteConfigs, httpClusterConfigs := mc.buildHTTPRouteConfigs(egress, portSpec.Number, precedence)
+				for _, clusterConfig := range httpClusterConfigs {
+					if setting := mc.policyController.GetUpstreamTrafficSettingForEgressHost(egress.Namespace, clusterConfig.Host); setting != nil {
+						clusterConfig.ConnectionSettings = setting.Spec.ConnectionSettings
+					}
+				}
 				portToRouteConfigMap[portSpec.Number] = append(portToRouteConfigMap[portSpec.Number], httpRouteConfigs...)
 				clusterConfigs = append(clusterConfigs, httpClusterConfigs...)
 			}
@@ -43,15 +74,38 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 			// ---
 			// TODO(#3045): Build the TCP route configs for the given Egress policy
 
+			// ---
+			// Build the TLS traffic matches for the given Egress policy.
+			// TLS traffic is matched using the SNI of the connection and forwarded to the host without
+			// being terminated, so that the hosts specified are enforced for TLS traffic.
+			if isHTTPS && denied {
+				trafficMatches = append(trafficMatches, buildDenyTLSTrafficMatches(egress, portSpec, precedence, allowedTLSDestinations)...)
+				continue
+			}
+			if isHTTPS {
+				tlsTrafficMatches, tlsClusterConfigs := buildTLSTrafficMatches(egress, portSpec, precedence, allowedTLSDestinations)
+				mc.applyEgressUpstreamTrafficSettings(egress.Namespace, tlsTrafficMatches, tlsClusterConfigs)
+				trafficMatches = append(trafficMatches, tlsTrafficMatches...)
+				clusterConfigs = append(clusterConfigs, tlsClusterConfigs...)
+				continue
+			}
+
 			// ---
 			// Build traffic matches for the given Egress policy.
 			// Traffic matches are used to match outbound traffic as egress traffic using the port numbers
 			// specified in Egress policies.
-			newlyAdded := allowedDestinationPorts.Add(portSpec)
-			if newlyAdded {
-				trafficMatches = append(trafficMatches, &trafficpolicy.TrafficMatch{
+			trafficMatch, ok := tcpTrafficMatches[portSpec]
+			if !ok {
+				trafficMatch = &trafficpolicy.TrafficMatch{
 					DestinationPort: portSpec,
-				})
+				}
+				tcpTrafficMatches[portSpec] = trafficMatch
+				trafficMatches = append(trafficMatches, trafficMatch)
+			}
+			// The destinations of HTTP traffic are matched by the HTTP route configs
+			if !isHTTP {
+				trafficMatch.DestinationIPRanges = appendNewStrings(trafficMatch.DestinationIPRanges, getEgressIPRanges(egress)...)
+				trafficMatch.DestinationHosts = appendNewStrings(trafficMatch.DestinationHosts, egress.Spec.Hosts...)
 			}
 		}
 	}
@@ -63,7 +117,133 @@ func (mc *MeshCatalog) GetEgressTrafficPolicy(serviceIdentity identity.ServiceId
 	}, nil
 }
 
-func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress, port int) ([]*trafficpolicy.EgressHTTPRouteConfig, []*trafficpolicy.EgressClusterConfig) {
+// buildTLSTrafficMatches returns the traffic matches and cluster configs for the TLS traffic to the hosts specified
+// in the given Egress policy on the given port. A traffic match is built per host so that the TLS traffic matching
+// the host's SNI is forwarded to the host. Hosts for which another Egress policy takes precedence and hosts already
+// present in the given set of allowed destinations are skipped.
+// Wildcard hosts are skipped as well: the TLS traffic is forwarded to a cluster resolving the host using DNS, which
+// cannot resolve a wildcard host. Wildcard hosts can only be used to deny TLS traffic.
+func buildTLSTrafficMatches(egressPolicy *policyV1alpha1.Egress, portSpec policyV1alpha1.PortSpec, precedence *policy.EgressPrecedence, allowedTLSDestinations mapset.Set) ([]*trafficpolicy.TrafficMatch, []*trafficpolicy.EgressClusterConfig) {
+	var trafficMatches []*trafficpolicy.TrafficMatch
+	var clusterConfigs []*trafficpolicy.EgressClusterConfig
+
+	for _, host := range egressPolicy.Spec.Hosts {
+		if strings.HasPrefix(host, "*") {
+			log.Error().Msgf("Wildcard host %s cannot be allowed on port %d with protocol %s in egress policy %s/%s; will be skipped",
+				host, portSpec.Number, portSpec.Protocol, egressPolicy.Namespace, egressPolicy.Name)
+			continue
+		}
+		if !precedence.IsApplied(egressPolicy, host, portSpec.Number) {
+			continue
+		}
+		clusterName := fmt.Sprintf("%s:%d", host, portSpec.Number)
+		if newlyAdded := allowedTLSDestinations.Add(clusterName); !newlyAdded {
+			continue
+		}
+
+		trafficMatches = append(trafficMatches, &trafficpolicy.TrafficMatch{
+			DestinationPort: portSpec,
+			ServerNames:     []string{host},
+			Cluster:         clusterName,
+		})
+		clusterConfigs = append(clusterConfigs, &trafficpolicy.EgressClusterConfig{
+			Name:       clusterName,
+			Host:       host,
+			Port:       portSpec.Number,
+			PolicyName: fmt.Sprintf("%s/%s", egressPolicy.Namespace, egressPolicy.Name),
+		})
+	}
+
+	return trafficMatches, clusterConfigs
+}
+
+// buildDenyTLSTrafficMatches returns the traffic matches denying the TLS traffic to the hosts specified in the given
+// Egress policy on the given port, skipping the hosts for which another Egress policy takes precedence
+func buildDenyTLSTrafficMatches(egressPolicy *policyV1alpha1.Egress, portSpec policyV1alpha1.PortSpec, precedence *policy.EgressPrecedence, allowedTLSDestinations mapset.Set) []*trafficpolicy.TrafficMatch {
+	var trafficMatches []*trafficpolicy.TrafficMatch
+
+	for _, host := range egressPolicy.Spec.Hosts {
+		if !precedence.IsApplied(egressPolicy, host, portSpec.Number) {
+			continue
+		}
+		clusterName := fmt.Sprintf("%s:%d", host, portSpec.Number)
+		if newlyAdded := allowedTLSDestinations.Add(clusterName); !newlyAdded {
+			continue
+		}
+
+		trafficMatches = append(trafficMatches, &trafficpolicy.TrafficMatch{
+			DestinationPort: portSpec,
+			ServerNames:     []string{host},
+			Cluster:         clusterName,
+			Deny:            true,
+		})
+	}
+
+	return trafficMatches
+}
+
+// buildDenyHTTPRouteConfigs returns the HTTP route configs denying the requests to the hosts specified in the given
+// Egress policy on the given port, skipping the hosts for which another Egress policy takes precedence
+func buildDenyHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress, port int, precedence *policy.EgressPrecedence) []*trafficpolicy.EgressHTTPRouteConfig {
+	var routeConfigs []*trafficpolicy.EgressHTTPRouteConfig
+
+	for _, host := range egressPolicy.Spec.Hosts {
+		if !precedence.IsApplied(egressPolicy, host, port) {
+			continue
+		}
+		routeConfigs = append(routeConfigs, &trafficpolicy.EgressHTTPRouteConfig{
+			Name:      host,
+			Hostnames: []string{host, fmt.Sprintf("%s:%d", host, port)},
+			Deny:      true,
+		})
+	}
+
+	return routeConfigs
+}
+
+// applyEgressUpstreamTrafficSettings applies the UpstreamTrafficSettings in the given namespace targeting the hosts of the
+// given TLS traffic matches and cluster configs, which are built together by buildTLSTrafficMatches.
+func (mc *MeshCatalog) applyEgressUpstreamTrafficSettings(namespace string, trafficMatches []*trafficpolicy.TrafficMatch, clusterConfigs []*trafficpolicy.EgressClusterConfig) {
+	for i, clusterConfig := range clusterConfigs {
+		setting := mc.policyController.GetUpstreamTrafficSettingForEgressHost(namespace, clusterConfig.Host)
+		if setting == nil {
+			continue
+		}
+		clusterConfig.ConnectionSettings = setting.Spec.ConnectionSettings
+		trafficMatches[i].RateLimit = setting.Spec.RateLimit
+	}
+}
+
+// getEgressPortSpecs returns the port specs of the given Egress policy, where port ranges are expanded to
+// a port spec per port in the range. Invalid port ranges are skipped.
+func getEgressPortSpecs(egressPolicy *policyV1alpha1.Egress) []policyV1alpha1.PortSpec {
+	var portSpecs []policyV1alpha1.PortSpec
+
+	for _, portSpec := range egressPolicy.Spec.Ports {
+		if portSpec.EndNumber == 0 {
+			portSpecs = append(portSpecs, portSpec)
+			continue
+		}
+
+		ports, err := policy.ExpandPortRange(portSpec.Number, portSpec.EndNumber)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid port range specified in egress policy %s/%s; will be skipped", egressPolicy.Namespace, egressPolicy.Name)
+			continue
+		}
+		for _, port := range ports {
+			portSpecs = append(portSpecs, policyV1alpha1.PortSpec{
+				Number:   port,
+				Protocol: portSpec.Protocol,
+			})
+		}
+	}
+
+	return portSpecs
+}
+
+// buildHTTPRouteConfigs returns the HTTP route configs and cluster configs for the hosts specified in the given Egress
+// policy on the given port, skipping the hosts for which another Egress policy takes precedence
+func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress, port int, precedence *policy.EgressPrecedence) ([]*trafficpolicy.EgressHTTPRouteConfig, []*trafficpolicy.EgressClusterConfig) {
 	if egressPolicy == nil {
 		return nil, nil
 	}
@@ -73,18 +253,7 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress
 
 	// Before building the route configs, pre-compute the allowed IP ranges since they
 	// will be the same for every HTTP route config derived from the given Egress policy.
-	var allowedDestinationIPRanges []string
-	destIPSet := mapset.NewSet()
-	for _, ipRange := range egressPolicy.Spec.IPAddresses {
-		if _, _, err := net.ParseCIDR(ipRange); err != nil {
-			log.Error().Err(err).Msgf("Invalid IP range [%s] specified in egress policy %s/%s; will be skipped", ipRange, egressPolicy.Namespace, egressPolicy.Name)
-			continue
-		}
-		newlyAdded := destIPSet.Add(ipRange)
-		if newlyAdded {
-			allowedDestinationIPRanges = append(allowedDestinationIPRanges, ipRange)
-		}
-	}
+	allowedDestinationIPRanges := getEgressIPRanges(egressPolicy)
 
 	// Check if there are object references to HTTP routes specified
 	// in the Egress policy's 'matches' attribute. If there are HTTP route
@@ -114,20 +283,39 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress
 		httpRouteMatches = append(httpRouteMatches, trafficpolicy.WildCardRouteMatch)
 	}
 
+	// Aliases can only be resolved to an external host when a single host is specified
+	var aliases []string
+	if hostRewrite := egressPolicy.Spec.HostRewrite; hostRewrite != nil && len(hostRewrite.Aliases) > 0 {
+		if len(egressPolicy.Spec.Hosts) == 1 {
+			aliases = hostRewrite.Aliases
+		} else {
+			log.Error().Msgf("Host aliases specified in egress policy %s/%s with %d hosts; will be skipped", egressPolicy.Namespace, egressPolicy.Name, len(egressPolicy.Spec.Hosts))
+		}
+	}
+
 	// Parse the hosts specified and build routing rules for the specified hosts
 	for _, host := range egressPolicy.Spec.Hosts {
+		if !precedence.IsApplied(egressPolicy, host, port) {
+			continue
+		}
+
 		// A route matching an HTTP host will include host header matching for the following:
 		// 1. host (ex. foo.com)
 		// 2. host:port (ex. foo.com:80)
+		// 3. alias and alias:port for each alias of the host (ex. 1.2.3.4, 1.2.3.4:80)
 		hostnameWithPort := fmt.Sprintf("%s:%d", host, port)
 		hostnames := []string{host, hostnameWithPort}
+		for _, alias := range aliases {
+			hostnames = append(hostnames, alias, fmt.Sprintf("%s:%d", alias, port))
+		}
 
 		// Create cluster config for this host and port combination
 		clusterName := hostnameWithPort
 		clusterConfig := &trafficpolicy.EgressClusterConfig{
-			Name: clusterName,
-			Host: host,
-			Port: port,
+			Name:       clusterName,
+			Host:       host,
+			Port:       port,
+			PolicyName: fmt.Sprintf("%s/%s", egressPolicy.Namespace, egressPolicy.Name),
 		}
 		clusterConfigs = append(clusterConfigs, clusterConfig)
 
@@ -153,6 +341,9 @@ func (mc *MeshCatalog) buildHTTPRouteConfigs(egressPolicy *policyV1alpha1.Egress
 			Hostnames:    hostnames,
 			RoutingRules: httpRoutingRules,
 		}
+		if egressPolicy.Spec.HostRewrite != nil {
+			hostSpecificRouteConfig.HostRewrite = host
+		}
 
 		routeConfigs = append(routeConfigs, hostSpecificRouteConfig)
 	}
@@ -187,3 +378,41 @@ func getHTTPRouteMatchesFromHTTPRouteGroup(httpRouteGroup *smiSpecs.HTTPRouteGro
 
 	return matches
 }
+
+// getEgressIPRanges returns the valid IP ranges specified in the given Egress policy, without duplicates
+func getEgressIPRanges(egressPolicy *policyV1alpha1.Egress) []string {
+	var ipRanges []string
+	for _, ipRange := range egressPolicy.Spec.IPAddresses {
+		if _, _, err := net.ParseCIDR(ipRange); err != nil {
+			log.Error().Err(err).Msgf("Invalid IP range [%s] specified in egress policy %s/%s; will be skipped", ipRange, egressPolicy.Namespace, egressPolicy.Name)
+			continue
+		}
+		ipRanges = appendNewStrings(ipRanges, ipRange)
+	}
+	return ipRanges
+}
+
+// appendNewStrings appends the given strings that are not already present to the given list
+func appendNewStrings(list []string, s ...string) []string {
+	for _, str := range s {
+		found := false
+		for _, existing := range list {
+			if existing == str {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, str)
+		}
+	}
+	return list
+}
+
+// isEgressPolicyEnabled returns a boolean indicating if the Egress policy API is enabled for the catalog
+func (mc *MeshCatalog) isEgressPolicyEnabled() bool {
+	if mc.features == nil {
+		return featureflags.IsEgressPolicyEnabled()
+	}
+	return mc.features.EgressPolicy
+}
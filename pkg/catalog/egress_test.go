@@ -35,11 +35,14 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
+	maxConnections := uint32(10)
+
 	testCases := []struct {
 		name                 string
 		egressPolicies       []*policyV1alpha1.Egress
 		egressPort           int
 		httpRouteGroups      []*specs.HTTPRouteGroup
+		upstreamSettings     []*policyV1alpha1.UpstreamTrafficSetting
 		expectedEgressPolicy *trafficpolicy.EgressTrafficPolicy
 		expectError          bool
 	}{
@@ -47,6 +50,10 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 			name: "multiple egress policies for HTTP ports",
 			egressPolicies: []*policyV1alpha1.Egress{
 				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-1",
+						Namespace: "bar",
+					},
 					Spec: policyV1alpha1.EgressSpec{
 						Hosts: []string{
 							"foo.com",
@@ -60,6 +67,10 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-2",
+						Namespace: "bar",
+					},
 					Spec: policyV1alpha1.EgressSpec{
 						Hosts: []string{
 							"bar.com",
@@ -73,6 +84,10 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-3",
+						Namespace: "bar",
+					},
 					Spec: policyV1alpha1.EgressSpec{
 						Hosts: []string{
 							"baz.com",
@@ -164,19 +179,22 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 				},
 				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
 					{
-						Name: "foo.com:80",
-						Host: "foo.com",
-						Port: 80,
+						Name:       "foo.com:80",
+						Host:       "foo.com",
+						Port:       80,
+						PolicyName: "bar/egress-1",
 					},
 					{
-						Name: "bar.com:80",
-						Host: "bar.com",
-						Port: 80,
+						Name:       "bar.com:80",
+						Host:       "bar.com",
+						Port:       80,
+						PolicyName: "bar/egress-2",
 					},
 					{
-						Name: "baz.com:90",
-						Host: "baz.com",
-						Port: 90,
+						Name:       "baz.com:90",
+						Host:       "baz.com",
+						Port:       90,
+						PolicyName: "bar/egress-3",
 					},
 				},
 			},
@@ -186,6 +204,10 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 			name: "multiple egress policies for HTTP ports",
 			egressPolicies: []*policyV1alpha1.Egress{
 				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-1",
+						Namespace: "bar",
+					},
 					Spec: policyV1alpha1.EgressSpec{
 						Hosts: []string{
 							"foo.com",
@@ -203,6 +225,10 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-2",
+						Namespace: "bar",
+					},
 					Spec: policyV1alpha1.EgressSpec{
 						Hosts: []string{
 							"bar.com",
@@ -230,6 +256,7 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 							Number:   100, // Used by foo.com
 							Protocol: "tcp",
 						},
+						DestinationHosts: []string{"foo.com"},
 					},
 				},
 				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{
@@ -274,14 +301,410 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 				},
 				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
 					{
-						Name: "foo.com:80",
+						Name:       "foo.com:80",
+						Host:       "foo.com",
+						Port:       80,
+						PolicyName: "bar/egress-1",
+					},
+					{
+						Name:       "bar.com:80",
+						Host:       "bar.com",
+						Port:       80,
+						PolicyName: "bar/egress-2",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "multiple egress policies for TCP ports",
+			egressPolicies: []*policyV1alpha1.Egress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-1",
+						Namespace: "bar",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						IPAddresses: []string{"10.0.0.0/24", "not-an-ip-range"},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   3306,
+								Protocol: "tcp",
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-2",
+						Namespace: "bar",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Hosts:       []string{"mysql.example.com"},
+						IPAddresses: []string{"10.0.0.0/24", "10.1.0.0/16"},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   3306,
+								Protocol: "tcp",
+							},
+						},
+					},
+				},
+			},
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   3306, // Used by both policies
+							Protocol: "tcp",
+						},
+						DestinationIPRanges: []string{"10.0.0.0/24", "10.1.0.0/16"},
+						DestinationHosts:    []string{"mysql.example.com"},
+					},
+				},
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{},
+			},
+			expectError: false,
+		},
+		{
+			name: "wildcard hosts can only be denied for HTTPS ports",
+			egressPolicies: []*policyV1alpha1.Egress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "allow-wildcard",
+						Namespace: "bar",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Hosts: []string{"*.foo.com", "bar.com"},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deny-wildcard",
+						Namespace: "bar",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Action: policyV1alpha1.EgressActionDeny,
+						Hosts:  []string{"*.baz.com"},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+						},
+					},
+				},
+			},
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"bar.com"},
+						Cluster:     "bar.com:443",
+					},
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"*.baz.com"},
+						Cluster:     "*.baz.com:443",
+						Deny:        true,
+					},
+				},
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{},
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+					{
+						Name:       "bar.com:443",
+						Host:       "bar.com",
+						Port:       443,
+						PolicyName: "bar/allow-wildcard",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "multiple egress policies for HTTPS ports",
+			egressPolicies: []*policyV1alpha1.Egress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-1",
+						Namespace: "bar",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Hosts: []string{
+							"foo.com",
+							"bar.com",
+						},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress-2",
+						Namespace: "bar",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Hosts: []string{
+							"foo.com", // Duplicate host and port should be ignored
+						},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+						},
+					},
+				},
+			},
+			httpRouteGroups: nil, // no SMI HTTP route matches
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"foo.com"},
+						Cluster:     "foo.com:443",
+					},
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"bar.com"},
+						Cluster:     "bar.com:443",
+					},
+				},
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{},
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+					{
+						Name:       "foo.com:443",
+						Host:       "foo.com",
+						Port:       443,
+						PolicyName: "bar/egress-1",
+					},
+					{
+						Name:       "bar.com:443",
+						Host:       "bar.com",
+						Port:       443,
+						PolicyName: "bar/egress-1",
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "egress policy with upstream traffic setting for an HTTPS host",
+			egressPolicies: []*policyV1alpha1.Egress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "egress",
+						Namespace: "test",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Hosts: []string{
+							"foo.com",
+						},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+						},
+					},
+				},
+			},
+			upstreamSettings: []*policyV1alpha1.UpstreamTrafficSetting{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "test",
+					},
+					Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
 						Host: "foo.com",
-						Port: 80,
+						ConnectionSettings: &policyV1alpha1.ConnectionSettingsSpec{
+							TCP: &policyV1alpha1.TCPConnectionSettings{
+								MaxConnections: &maxConnections,
+							},
+						},
+						RateLimit: &policyV1alpha1.RateLimitSpec{
+							Local: &policyV1alpha1.LocalRateLimitSpec{
+								TCP: &policyV1alpha1.TCPLocalRateLimitSpec{
+									Connections: 100,
+									Unit:        "minute",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"foo.com"},
+						Cluster:     "foo.com:443",
+						RateLimit: &policyV1alpha1.RateLimitSpec{
+							Local: &policyV1alpha1.LocalRateLimitSpec{
+								TCP: &policyV1alpha1.TCPLocalRateLimitSpec{
+									Connections: 100,
+									Unit:        "minute",
+								},
+							},
+						},
 					},
+				},
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{},
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
 					{
-						Name: "bar.com:80",
-						Host: "bar.com",
-						Port: 80,
+						Name:       "foo.com:443",
+						Host:       "foo.com",
+						Port:       443,
+						PolicyName: "test/egress",
+						ConnectionSettings: &policyV1alpha1.ConnectionSettingsSpec{
+							TCP: &policyV1alpha1.TCPConnectionSettings{
+								MaxConnections: &maxConnections,
+							},
+						},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "conflicting egress policies for the same hosts and ports",
+			egressPolicies: []*policyV1alpha1.Egress{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "allow-foo",
+						Namespace: "shared",
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Hosts: []string{"foo.com", "api.foo.com"},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   80,
+								Protocol: "http",
+							},
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deny-foo",
+						Namespace: "bar", // namespace of the source
+					},
+					Spec: policyV1alpha1.EgressSpec{
+						Action: policyV1alpha1.EgressActionDeny,
+						Hosts:  []string{"foo.com"},
+						Ports: []policyV1alpha1.PortSpec{
+							{
+								Number:   80,
+								Protocol: "http",
+							},
+							{
+								Number:   443,
+								Protocol: "https",
+							},
+							{
+								Number:   3306,
+								Protocol: "tcp",
+							},
+						},
+					},
+				},
+			},
+			expectedEgressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   80,
+							Protocol: "http",
+						},
+					},
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"api.foo.com"},
+						Cluster:     "api.foo.com:443",
+					},
+					{
+						DestinationPort: policyV1alpha1.PortSpec{
+							Number:   443,
+							Protocol: "https",
+						},
+						ServerNames: []string{"foo.com"},
+						Cluster:     "foo.com:443",
+						Deny:        true,
+					},
+				},
+				HTTPRouteConfigsPerPort: map[int][]*trafficpolicy.EgressHTTPRouteConfig{
+					80: {
+						{
+							Name: "api.foo.com",
+							Hostnames: []string{
+								"api.foo.com",
+								"api.foo.com:80",
+							},
+							RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+								{
+									Route: trafficpolicy.RouteWeightedClusters{
+										HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+										WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+											service.WeightedCluster{ClusterName: service.ClusterName("api.foo.com:80"), Weight: 100},
+										}),
+									},
+								},
+							},
+						},
+						{
+							Name: "foo.com",
+							Hostnames: []string{
+								"foo.com",
+								"foo.com:80",
+							},
+							Deny: true,
+						},
+					},
+				},
+				ClustersConfigs: []*trafficpolicy.EgressClusterConfig{
+					{
+						Name:       "api.foo.com:80",
+						Host:       "api.foo.com",
+						Port:       80,
+						PolicyName: "shared/allow-foo",
+					},
+					{
+						Name:       "api.foo.com:443",
+						Host:       "api.foo.com",
+						Port:       443,
+						PolicyName: "shared/allow-foo",
 					},
 				},
 			},
@@ -300,6 +723,15 @@ func TestGetEgressTrafficPolicy(t *testing.T) {
 				mockMeshSpec.EXPECT().GetHTTPRouteGroup(fmt.Sprintf("%s/%s", rg.Namespace, rg.Name)).Return(rg).AnyTimes()
 			}
 			mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(gomock.Any()).Return(tc.egressPolicies).Times(1)
+			mockPolicyController.EXPECT().GetUpstreamTrafficSettingForEgressHost(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(namespace string, host string) *policyV1alpha1.UpstreamTrafficSetting {
+					for _, setting := range tc.upstreamSettings {
+						if setting.Namespace == namespace && setting.Spec.Host == host {
+							return setting
+						}
+					}
+					return nil
+				}).AnyTimes()
 
 			mc := &MeshCatalog{
 				meshSpec:         mockMeshSpec,
@@ -329,6 +761,10 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 		{
 			name: "egress policy with no SMI HTTP route matches specified",
 			egressPolicy: &policyV1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
 				Spec: policyV1alpha1.EgressSpec{
 					Hosts: []string{
 						"foo.com",
@@ -384,14 +820,154 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 			},
 			expectedClusterConfigs: []*trafficpolicy.EgressClusterConfig{
 				{
-					Name: "foo.com:80",
-					Host: "foo.com",
-					Port: 80,
+					Name:       "foo.com:80",
+					Host:       "foo.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
+				},
+				{
+					Name:       "bar.com:80",
+					Host:       "bar.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
+				},
+			},
+		},
+		{
+			name: "egress policy with host rewrite and aliases specified",
+			egressPolicy: &policyV1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+				Spec: policyV1alpha1.EgressSpec{
+					Hosts: []string{
+						"foo.com",
+					},
+					Ports: []policyV1alpha1.PortSpec{
+						{
+							Number:   80,
+							Protocol: "http",
+						},
+					},
+					HostRewrite: &policyV1alpha1.HostRewriteSpec{
+						Aliases: []string{"1.2.3.4", "foo.internal"},
+					},
+				},
+			},
+			egressPort:      80,
+			httpRouteGroups: nil,
+			expectedRouteConfigs: []*trafficpolicy.EgressHTTPRouteConfig{
+				{
+					Name: "foo.com",
+					Hostnames: []string{
+						"foo.com",
+						"foo.com:80",
+						"1.2.3.4",
+						"1.2.3.4:80",
+						"foo.internal",
+						"foo.internal:80",
+					},
+					RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+						{
+							Route: trafficpolicy.RouteWeightedClusters{
+								HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+								WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+									service.WeightedCluster{ClusterName: service.ClusterName("foo.com:80"), Weight: 100},
+								}),
+							},
+							AllowedDestinationIPRanges: nil,
+						},
+					},
+					HostRewrite: "foo.com",
+				},
+			},
+			expectedClusterConfigs: []*trafficpolicy.EgressClusterConfig{
+				{
+					Name:       "foo.com:80",
+					Host:       "foo.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
+				},
+			},
+		},
+		{
+			name: "egress policy with aliases specified for multiple hosts",
+			egressPolicy: &policyV1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "egress-1",
+					Namespace: "test",
+				},
+				Spec: policyV1alpha1.EgressSpec{
+					Hosts: []string{
+						"foo.com",
+						"bar.com",
+					},
+					Ports: []policyV1alpha1.PortSpec{
+						{
+							Number:   80,
+							Protocol: "http",
+						},
+					},
+					HostRewrite: &policyV1alpha1.HostRewriteSpec{
+						Aliases: []string{"1.2.3.4"},
+					},
+				},
+			},
+			egressPort:      80,
+			httpRouteGroups: nil,
+			expectedRouteConfigs: []*trafficpolicy.EgressHTTPRouteConfig{
+				{
+					Name: "foo.com",
+					Hostnames: []string{
+						"foo.com",
+						"foo.com:80",
+					},
+					RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+						{
+							Route: trafficpolicy.RouteWeightedClusters{
+								HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+								WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+									service.WeightedCluster{ClusterName: service.ClusterName("foo.com:80"), Weight: 100},
+								}),
+							},
+							AllowedDestinationIPRanges: nil,
+						},
+					},
+					HostRewrite: "foo.com",
+				},
+				{
+					Name: "bar.com",
+					Hostnames: []string{
+						"bar.com",
+						"bar.com:80",
+					},
+					RoutingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+						{
+							Route: trafficpolicy.RouteWeightedClusters{
+								HTTPRouteMatch: trafficpolicy.WildCardRouteMatch,
+								WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+									service.WeightedCluster{ClusterName: service.ClusterName("bar.com:80"), Weight: 100},
+								}),
+							},
+							AllowedDestinationIPRanges: nil,
+						},
+					},
+					HostRewrite: "bar.com",
+				},
+			},
+			expectedClusterConfigs: []*trafficpolicy.EgressClusterConfig{
+				{
+					Name:       "foo.com:80",
+					Host:       "foo.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
 				},
 				{
-					Name: "bar.com:80",
-					Host: "bar.com",
-					Port: 80,
+					Name:       "bar.com:80",
+					Host:       "bar.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
 				},
 			},
 		},
@@ -506,9 +1082,10 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 			},
 			expectedClusterConfigs: []*trafficpolicy.EgressClusterConfig{
 				{
-					Name: "foo.com:80",
-					Host: "foo.com",
-					Port: 80,
+					Name:       "foo.com:80",
+					Host:       "foo.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
 				},
 			},
 		},
@@ -590,9 +1167,10 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 			},
 			expectedClusterConfigs: []*trafficpolicy.EgressClusterConfig{
 				{
-					Name: "foo.com:80",
-					Host: "foo.com",
-					Port: 80,
+					Name:       "foo.com:80",
+					Host:       "foo.com",
+					Port:       80,
+					PolicyName: "test/egress-1",
 				},
 			},
 		},
@@ -610,7 +1188,7 @@ func TestBuildHTTPRouteConfigs(t *testing.T) {
 				meshSpec: mockMeshSpec,
 			}
 
-			routeConfigs, clusterConfigs := mc.buildHTTPRouteConfigs(tc.egressPolicy, tc.egressPort)
+			routeConfigs, clusterConfigs := mc.buildHTTPRouteConfigs(tc.egressPolicy, tc.egressPort, nil)
 			assert.ElementsMatch(tc.expectedRouteConfigs, routeConfigs)
 			assert.ElementsMatch(tc.expectedClusterConfigs, clusterConfigs)
 		})
@@ -24,6 +24,12 @@ var (
 	// ErrNoTrafficSpecFoundForTrafficPolicy is an error for when OSM cannot find a traffic spec for the given traffic policy.
 	ErrNoTrafficSpecFoundForTrafficPolicy = errors.New("no traffic spec found for the traffic policy")
 
+	// ErrNoPortForTCPRoutes is an error for when none of the TCP routes of a traffic policy resolves to a port.
+	ErrNoPortForTCPRoutes = errors.New("no port resolved for the TCP routes of the traffic policy")
+
 	// ErrServiceNotFound is an error for when OSM cannot find a service.
 	ErrServiceNotFound = errors.New("service not found")
+
+	// ErrInvalidIngressPathType is an error for when the path type of an ingress path is not supported.
+	ErrInvalidIngressPathType = errors.New("invalid ingress path type")
 )
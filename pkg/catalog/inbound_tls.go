@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"strconv"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// GetPermissiveTLSInboundPortsForProxy returns the ports on which the given proxy accepts plaintext traffic in addition to mTLS traffic.
+// The ports are specified using the 'openservicemesh.io/inbound-permissive-tls-ports' annotation on the pod of the proxy,
+// and by the UpstreamTrafficSettings of the services of the pod. All other ports only accept mTLS traffic.
+func (mc *MeshCatalog) GetPermissiveTLSInboundPortsForProxy(proxy *envoy.Proxy) (mapset.Set, error) {
+	pod, err := GetPodFromCertificate(proxy.GetCertificateCommonName(), mc.kubeController)
+	if err != nil {
+		return nil, err
+	}
+
+	ports, err := getPermissiveTLSInboundPorts(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := listServicesForPod(pod, mc.kubeController)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range kubernetesServicesToMeshServices(services) {
+		ports = ports.Union(getUpstreamTrafficSettingPermissiveTLSPorts(mc.policyController.GetUpstreamTrafficSetting(svc)))
+	}
+
+	return ports, nil
+}
+
+// getUpstreamTrafficSettingPermissiveTLSPorts returns the set of ports specified by the given UpstreamTrafficSetting
+// as accepting plaintext traffic
+func getUpstreamTrafficSettingPermissiveTLSPorts(upstreamTrafficSetting *policyV1alpha1.UpstreamTrafficSetting) mapset.Set {
+	ports := mapset.NewSet()
+	if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.Inbound == nil {
+		return ports
+	}
+
+	for _, port := range upstreamTrafficSetting.Spec.Inbound.PermissiveTLSPorts {
+		ports.Add(port)
+	}
+	return ports
+}
+
+// getPermissiveTLSInboundPorts returns the set of ports specified by the 'openservicemesh.io/inbound-permissive-tls-ports'
+// annotation on the given pod, as a comma separated list of ports.
+func getPermissiveTLSInboundPorts(pod *v1.Pod) (mapset.Set, error) {
+	ports := mapset.NewSet()
+
+	portsStr, ok := pod.Annotations[constants.InboundPermissiveTLSPortsAnnotation]
+	if !ok || strings.TrimSpace(portsStr) == "" {
+		return ports, nil
+	}
+
+	for _, portStr := range strings.Split(portsStr, ",") {
+		port, err := strconv.ParseUint(strings.TrimSpace(portStr), 10, 16)
+		if err != nil || port == 0 {
+			return nil, errors.Errorf("Invalid port %q specified by annotation %s on pod %s/%s", portStr, constants.InboundPermissiveTLSPortsAnnotation, pod.Namespace, pod.Name)
+		}
+		ports.Add(uint32(port))
+	}
+
+	return ports, nil
+}
@@ -0,0 +1,112 @@
+package catalog
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	tassert "github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetPermissiveTLSInboundPorts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		annotations   map[string]string
+		expectedPorts mapset.Set
+		expectError   bool
+	}{
+		{
+			name:          "no annotation",
+			annotations:   nil,
+			expectedPorts: mapset.NewSet(),
+			expectError:   false,
+		},
+		{
+			name:          "single port",
+			annotations:   map[string]string{constants.InboundPermissiveTLSPortsAnnotation: "9090"},
+			expectedPorts: mapset.NewSet(uint32(9090)),
+			expectError:   false,
+		},
+		{
+			name:          "multiple ports with whitespace",
+			annotations:   map[string]string{constants.InboundPermissiveTLSPortsAnnotation: "9090, 8081"},
+			expectedPorts: mapset.NewSet(uint32(9090), uint32(8081)),
+			expectError:   false,
+		},
+		{
+			name:          "invalid port",
+			annotations:   map[string]string{constants.InboundPermissiveTLSPortsAnnotation: "9090,metrics"},
+			expectedPorts: nil,
+			expectError:   true,
+		},
+		{
+			name:          "out of range port",
+			annotations:   map[string]string{constants.InboundPermissiveTLSPortsAnnotation: "70000"},
+			expectedPorts: nil,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod",
+					Namespace:   "ns",
+					Annotations: tc.annotations,
+				},
+			}
+
+			actual, err := getPermissiveTLSInboundPorts(pod)
+			assert.Equal(tc.expectError, err != nil)
+			if !tc.expectError {
+				assert.True(tc.expectedPorts.Equal(actual))
+			}
+		})
+	}
+}
+
+func TestGetUpstreamTrafficSettingPermissiveTLSPorts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setting       *policyV1alpha1.UpstreamTrafficSetting
+		expectedPorts mapset.Set
+	}{
+		{
+			name:          "no UpstreamTrafficSetting",
+			setting:       nil,
+			expectedPorts: mapset.NewSet(),
+		},
+		{
+			name:          "no inbound settings",
+			setting:       &policyV1alpha1.UpstreamTrafficSetting{},
+			expectedPorts: mapset.NewSet(),
+		},
+		{
+			name: "permissive ports",
+			setting: &policyV1alpha1.UpstreamTrafficSetting{
+				Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+					Inbound: &policyV1alpha1.InboundConnectionSettingsSpec{
+						PermissiveTLSPorts: []uint32{9090, 8081},
+					},
+				},
+			},
+			expectedPorts: mapset.NewSet(uint32(9090), uint32(8081)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := getUpstreamTrafficSettingPermissiveTLSPorts(tc.setting)
+			assert.True(tc.expectedPorts.Equal(actual))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -46,7 +47,7 @@ func (mc *MeshCatalog) listInboundPoliciesFromTrafficTargets(upstreamIdentity id
 	upstreamServiceAccount := upstreamIdentity.ToK8sServiceAccount()
 	var inboundPolicies []*trafficpolicy.InboundTrafficPolicy
 
-	for _, t := range mc.meshSpec.ListTrafficTargets() { // loop through all traffic targets
+	for _, t := range mc.listActiveTrafficTargets() { // loop through all traffic targets
 		if !isValidTrafficTarget(t) {
 			continue
 		}
@@ -72,7 +73,7 @@ func (mc *MeshCatalog) listInboundPoliciesForTrafficSplits(upstreamIdentity iden
 	upstreamServiceAccount := upstreamIdentity.ToK8sServiceAccount()
 	var inboundPolicies []*trafficpolicy.InboundTrafficPolicy
 
-	for _, t := range mc.meshSpec.ListTrafficTargets() { // loop through all traffic targets
+	for _, t := range mc.listActiveTrafficTargets() { // loop through all traffic targets
 		if !isValidTrafficTarget(t) {
 			continue
 		}
@@ -83,7 +84,7 @@ func (mc *MeshCatalog) listInboundPoliciesForTrafficSplits(upstreamIdentity iden
 		}
 
 		// fetch all routes referenced in traffic target
-		routeMatches, err := mc.routesFromRules(t.Spec.Rules, t.Namespace)
+		routeMatches, err := mc.routesFromRules(t.Spec.Rules, t.Namespace, trafficTargetIdentityToSvcAccount(t.Spec.Destination))
 		if err != nil {
 			log.Error().Err(err).Msgf("Error finding route matches from TrafficTarget %s in namespace %s", t.Name, t.Namespace)
 			continue
@@ -106,16 +107,16 @@ func (mc *MeshCatalog) listInboundPoliciesForTrafficSplits(upstreamIdentity iden
 				servicePolicy := trafficpolicy.NewInboundTrafficPolicy(buildPolicyName(apexService, apexService.Namespace == upstreamServiceAccount.Namespace), hostnames)
 				weightedCluster := getDefaultWeightedClusterForService(upstreamSvc)
 
-				for _, sourceServiceAccount := range trafficTargetIdentitiesToSvcAccounts(t.Spec.Sources) {
+				for _, sourcePrincipal := range mc.listTrafficTargetSourcePrincipals(t) {
 					for _, routeMatch := range routeMatches {
 						// If the traffic target has a route with host headers
 						// we need to create a new inbound traffic policy with the host header as the required hostnames
 						// else the hosnames will be hostnames corresponding to the service
 						if _, ok := routeMatch.Headers[hostHeaderKey]; !ok {
-							servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount)
+							servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourcePrincipal)
 						} else {
 							servicePolicyWithHostHeader := trafficpolicy.NewInboundTrafficPolicy(routeMatch.Headers[hostHeaderKey], []string{routeMatch.Headers[hostHeaderKey]})
-							servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount)
+							servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourcePrincipal)
 							inboundPolicies = trafficpolicy.MergeInboundPolicies(AllowPartialHostnamesMatch, inboundPolicies, servicePolicyWithHostHeader)
 						}
 					}
@@ -131,7 +132,7 @@ func (mc *MeshCatalog) buildInboundPolicies(t *access.TrafficTarget, svc service
 	var inboundPolicies []*trafficpolicy.InboundTrafficPolicy
 
 	// fetch all routes referenced in traffic target
-	routeMatches, err := mc.routesFromRules(t.Spec.Rules, t.Namespace)
+	routeMatches, err := mc.routesFromRules(t.Spec.Rules, t.Namespace, trafficTargetIdentityToSvcAccount(t.Spec.Destination))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error finding route matches from TrafficTarget %s in namespace %s", t.Name, t.Namespace)
 		return inboundPolicies
@@ -143,19 +144,21 @@ func (mc *MeshCatalog) buildInboundPolicies(t *access.TrafficTarget, svc service
 		return inboundPolicies
 	}
 
+	routeMatches = mc.filterRouteMatchesForServicePorts(routeMatches, svc)
+
 	servicePolicy := trafficpolicy.NewInboundTrafficPolicy(buildPolicyName(svc, false), hostnames)
 	weightedCluster := getDefaultWeightedClusterForService(svc)
 
-	for _, sourceServiceAccount := range trafficTargetIdentitiesToSvcAccounts(t.Spec.Sources) {
+	for _, sourcePrincipal := range mc.listTrafficTargetSourcePrincipals(t) {
 		for _, routeMatch := range routeMatches {
 			// If the traffic target has a route with host headers
 			// we need to create a new inbound traffic policy with the host header as the required hostnames
 			// else the hosnames will be hostnames corresponding to the service
 			if _, ok := routeMatch.Headers[hostHeaderKey]; !ok {
-				servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount)
+				servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourcePrincipal)
 			} else {
 				servicePolicyWithHostHeader := trafficpolicy.NewInboundTrafficPolicy(routeMatch.Headers[hostHeaderKey], []string{routeMatch.Headers[hostHeaderKey]})
-				servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourceServiceAccount)
+				servicePolicyWithHostHeader.AddRule(*trafficpolicy.NewRouteWeightedCluster(routeMatch, []service.WeightedCluster{weightedCluster}), sourcePrincipal)
 				inboundPolicies = trafficpolicy.MergeInboundPolicies(AllowPartialHostnamesMatch, inboundPolicies, servicePolicyWithHostHeader)
 			}
 		}
@@ -180,15 +183,45 @@ func (mc *MeshCatalog) buildInboundPermissiveModePolicies(svc service.MeshServic
 
 	// Add a wildcard route to accept traffic from any service account (wildcard service account)
 	// A wildcard service account will program an RBAC policy for this rule that allows ANY downstream service account
-	servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{weightedCluster}), wildcardServiceAccount)
+	servicePolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{weightedCluster}), wildcardServiceIdentity)
 	inboundPolicies = append(inboundPolicies, servicePolicy)
 
 	return inboundPolicies
 }
 
+// filterRouteMatchesForServicePorts returns the given route matches that are not restricted to ports, or are restricted to
+// some of the target ports of the given service
+func (mc *MeshCatalog) filterRouteMatchesForServicePorts(routeMatches []trafficpolicy.HTTPRouteMatch, svc service.MeshService) []trafficpolicy.HTTPRouteMatch {
+	var targetPorts map[uint32]string
+	var filtered []trafficpolicy.HTTPRouteMatch
+	for _, routeMatch := range routeMatches {
+		if len(routeMatch.Ports) == 0 {
+			filtered = append(filtered, routeMatch)
+			continue
+		}
+
+		if targetPorts == nil {
+			var err error
+			if targetPorts, err = mc.GetTargetPortToProtocolMappingForService(svc); err != nil {
+				log.Error().Err(err).Msgf("Error getting the target ports of service %s, ignoring the routes restricted to ports", svc)
+				targetPorts = map[uint32]string{}
+			}
+		}
+		for _, port := range routeMatch.Ports {
+			if _, ok := targetPorts[uint32(port)]; ok {
+				filtered = append(filtered, routeMatch)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 // routesFromRules takes a set of traffic target rules and the namespace of the traffic target and returns a list of
 //	http route matches (trafficpolicy.HTTPRouteMatch)
-func (mc *MeshCatalog) routesFromRules(rules []access.TrafficTargetRule, trafficTargetNamespace string) ([]trafficpolicy.HTTPRouteMatch, error) {
+// The route matches of an HTTPRouteGroup restricted to some ports by its annotations only match the requests received on
+// these ports of the services for the given destination service account.
+func (mc *MeshCatalog) routesFromRules(rules []access.TrafficTargetRule, trafficTargetNamespace string, destination identity.K8sServiceAccount) ([]trafficpolicy.HTTPRouteMatch, error) {
 	var routes []trafficpolicy.HTTPRouteMatch
 
 	specMatchRoute, err := mc.getHTTPPathsPerRoute() // returns map[traffic_spec_name]map[match_name]trafficpolicy.HTTPRoute
@@ -201,11 +234,29 @@ func (mc *MeshCatalog) routesFromRules(rules []access.TrafficTargetRule, traffic
 		return routes, nil
 	}
 
+	routeGroups := make(map[trafficpolicy.TrafficSpecName]*smiSpecs.HTTPRouteGroup)
+	for _, routeGroup := range mc.meshSpec.ListHTTPTrafficSpecs() {
+		routeGroups[mc.getTrafficSpecName(httpRouteGroupKind, routeGroup.Namespace, routeGroup.Name)] = routeGroup
+	}
+
 	for _, rule := range rules {
 		trafficSpecName := mc.getTrafficSpecName("HTTPRouteGroup", trafficTargetNamespace, rule.Name)
+
+		var ports []int
+		if routeGroup, ok := routeGroups[trafficSpecName]; ok {
+			var restricted bool
+			ports, restricted = mc.getAnnotatedPorts(routeGroup.Annotations, constants.HTTPRouteGroupPortRangesAnnotation, constants.HTTPRouteGroupNamedPortsAnnotation,
+				fmt.Sprintf("HTTPRouteGroup %s/%s", routeGroup.Namespace, routeGroup.Name), destination)
+			if restricted && len(ports) == 0 {
+				log.Warn().Msgf("HTTPRouteGroup %s/%s is restricted to ports that do not exist on the services for service account %s, ignoring it", routeGroup.Namespace, routeGroup.Name, destination)
+				continue
+			}
+		}
+
 		for _, match := range rule.Matches {
 			matchedRoute, found := specMatchRoute[trafficSpecName][trafficpolicy.TrafficSpecMatchName(match)]
 			if found {
+				matchedRoute.Ports = ports
 				routes = append(routes, matchedRoute)
 			} else {
 				log.Debug().Msgf("No matching trafficpolicy.HTTPRoute found for match name %s in Traffic Spec %s (in namespace %s)", match, trafficSpecName, trafficTargetNamespace)
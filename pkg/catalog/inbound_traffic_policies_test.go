@@ -15,6 +15,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/identity"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
@@ -111,7 +112,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -124,7 +125,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -223,7 +224,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -236,7 +237,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -266,7 +267,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -279,7 +280,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -379,7 +380,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -409,7 +410,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -428,7 +429,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -465,7 +466,7 @@ func TestListInboundTrafficPolicies(t *testing.T) {
 								HTTPRouteMatch:   tests.WildCardRouteMatch,
 								WeightedClusters: mapset.NewSet(tests.BookbuyerDefaultWeightedCluster),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -684,7 +685,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -697,7 +698,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -800,7 +801,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -813,7 +814,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -917,7 +918,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -936,7 +937,7 @@ func TestListInboundPoliciesForTrafficSplits(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1059,7 +1060,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "bookbuyer-ns",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1072,7 +1073,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "bookbuyer-ns",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1150,7 +1151,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1163,7 +1164,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1222,7 +1223,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1241,7 +1242,7 @@ func TestBuildInboundPolicies(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1313,7 +1314,7 @@ func TestBuildInboundPermissiveModePolicies(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1437,7 +1438,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1450,7 +1451,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1529,7 +1530,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1556,7 +1557,7 @@ func TestListInboundPoliciesFromTrafficTargets(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      "bookbuyer",
 								Namespace: "default",
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -1634,13 +1635,93 @@ func TestRoutesFromRules(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing routesFromRules where %s", tc.name), func(t *testing.T) {
-			routes, err := mc.routesFromRules(tc.rules, tc.namespace)
+			routes, err := mc.routesFromRules(tc.rules, tc.namespace, tests.BookstoreServiceAccount)
 			assert.Nil(err)
 			assert.EqualValues(tc.expectedRoutes, routes)
 		})
 	}
 }
 
+func TestRoutesFromRulesRestrictedToPorts(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mc := MeshCatalog{meshSpec: mockMeshSpec}
+
+	routeGroup := func(name string, annotations map[string]string) *spec.HTTPRouteGroup {
+		return &spec.HTTPRouteGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace:   tests.Namespace,
+				Name:        name,
+				Annotations: annotations,
+			},
+			Spec: spec.HTTPRouteGroupSpec{
+				Matches: []spec.HTTPMatch{{Name: tests.BuyBooksMatchName, PathRegex: tests.BookstoreBuyPath, Methods: []string{"GET"}}},
+			},
+		}
+	}
+	mockMeshSpec.EXPECT().ListHTTPTrafficSpecs().Return([]*spec.HTTPRouteGroup{
+		routeGroup("all-ports", nil),
+		routeGroup("http-ports", map[string]string{constants.HTTPRouteGroupPortRangesAnnotation: "8080,9000-9001"}),
+		routeGroup("invalid-ports", map[string]string{constants.HTTPRouteGroupPortRangesAnnotation: "9001-9000"}),
+	}).AnyTimes()
+
+	testCases := []struct {
+		name          string
+		routeGroup    string
+		expectedPorts [][]int
+	}{
+		{
+			name:          "route group without port annotations matches all ports",
+			routeGroup:    "all-ports",
+			expectedPorts: [][]int{nil},
+		},
+		{
+			name:          "route group with port annotations matches the annotated ports",
+			routeGroup:    "http-ports",
+			expectedPorts: [][]int{{8080, 9000, 9001}},
+		},
+		{
+			name:          "route group restricted to no valid ports is ignored",
+			routeGroup:    "invalid-ports",
+			expectedPorts: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := []access.TrafficTargetRule{{Kind: "HTTPRouteGroup", Name: tc.routeGroup, Matches: []string{tests.BuyBooksMatchName}}}
+			routes, err := mc.routesFromRules(rules, tests.Namespace, tests.BookstoreServiceAccount)
+			assert.Nil(err)
+
+			var ports [][]int
+			for _, route := range routes {
+				ports = append(ports, route.Ports)
+			}
+			assert.Equal(tc.expectedPorts, ports)
+		})
+	}
+}
+
+func TestFilterRouteMatchesForServicePorts(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+	mc := MeshCatalog{endpointsProviders: []endpoint.Provider{mockEndpointProvider}}
+	mockEndpointProvider.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{8080: "http", 9090: "grpc"}, nil).Times(1)
+
+	allPorts := trafficpolicy.HTTPRouteMatch{Path: "/all", PathMatchType: trafficpolicy.PathMatchRegex}
+	grpcPort := trafficpolicy.HTTPRouteMatch{Path: "/grpc", PathMatchType: trafficpolicy.PathMatchRegex, Ports: []int{9090}}
+	otherPort := trafficpolicy.HTTPRouteMatch{Path: "/other", PathMatchType: trafficpolicy.PathMatchRegex, Ports: []int{7070}}
+
+	filtered := mc.filterRouteMatchesForServicePorts([]trafficpolicy.HTTPRouteMatch{allPorts, grpcPort, otherPort}, tests.BookstoreV1Service)
+	assert.Equal([]trafficpolicy.HTTPRouteMatch{allPorts, grpcPort}, filtered)
+}
+
 func TestGetHTTPPathsPerRoute(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -32,9 +32,9 @@ const (
 // Ensure the regex pattern for prefix matching for path elements compiles
 var _ = regexp.MustCompile(prefixMatchPathElementsRegex)
 
-// Ingress does not depend on k8s service accounts, program a wildcard (empty struct) to indicate
+// Ingress does not depend on k8s service accounts, program a wildcard (empty identity) to indicate
 // to RDS that an inbound traffic policy for ingress should not enforce service account based RBAC policies.
-var wildcardServiceAccount = identity.K8sServiceAccount{}
+var wildcardServiceIdentity = identity.ServiceIdentity("")
 
 // GetIngressPoliciesForService returns a list of inbound traffic policies for a service as defined in observed ingress k8s resources.
 func (mc *MeshCatalog) GetIngressPoliciesForService(svc service.MeshService) ([]*trafficpolicy.InboundTrafficPolicy, error) {
@@ -81,7 +81,7 @@ func (mc *MeshCatalog) getIngressPoliciesNetworkingV1beta1(svc service.MeshServi
 	for _, ingress := range ingresses {
 		if ingress.Spec.Backend != nil && ingress.Spec.Backend.ServiceName == svc.Name {
 			wildcardIngressPolicy := trafficpolicy.NewInboundTrafficPolicy(buildIngressPolicyName(ingress.ObjectMeta.Name, ingress.ObjectMeta.Namespace, constants.WildcardHTTPMethod), []string{constants.WildcardHTTPMethod})
-			wildcardIngressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceAccount)
+			wildcardIngressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceIdentity)
 			inboundIngressPolicies = trafficpolicy.MergeInboundPolicies(DisallowPartialHostnamesMatch, inboundIngressPolicies, wildcardIngressPolicy)
 		}
 
@@ -97,64 +97,19 @@ func (mc *MeshCatalog) getIngressPoliciesNetworkingV1beta1(svc service.MeshServi
 					continue
 				}
 
-				httpRouteMatch := trafficpolicy.HTTPRouteMatch{
-					Methods: []string{constants.WildcardHTTPMethod},
-				}
-
 				// Default ingress path type to PathTypeImplementationSpecific if unspecified
 				pathType := networkingV1beta1.PathTypeImplementationSpecific
 				if ingressPath.PathType != nil {
 					pathType = *ingressPath.PathType
 				}
 
-				switch pathType {
-				case networkingV1beta1.PathTypeExact:
-					// Exact match
-					// Request /foo matches path /foo, not /foobar or /foo/bar
-					httpRouteMatch.Path = ingressPath.Path
-					httpRouteMatch.PathMatchType = trafficpolicy.PathMatchExact
-
-				case networkingV1beta1.PathTypePrefix:
-					// Element wise prefix match
-					// Request /foo matches path /foo and /foo/bar, not /foobar
-					if ingressPath.Path == "/" {
-						// A wildcard path '/' for Prefix pathType must be matched
-						// as a string based prefix match, ie. path '/' should
-						// match any path in the request.
-						httpRouteMatch.Path = ingressPath.Path
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
-					} else {
-						// Non-wildcard path of the form '/path' must be matched as a
-						// regex match to meet k8s Ingress API requirement of element-wise
-						// prefix matching.
-						// There is also the requirement for prefix /foo/ to match /foo
-						// based on k8s API interpretation of element-wise matching, so
-						// account for this case by trimming trailing '/'.
-						path := strings.TrimRight(ingressPath.Path, "/")
-						httpRouteMatch.Path = path + prefixMatchPathElementsRegex
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
-					}
-
-				case networkingV1beta1.PathTypeImplementationSpecific:
-					httpRouteMatch.Path = ingressPath.Path
-					// If the path looks like a regex, use regex matching.
-					// Else use string based prefix matching.
-					if strings.ContainsAny(ingressPath.Path, commonRegexChars) {
-						// Path contains regex characters, use regex matching for the path
-						// Request /foo/bar matches path /foo.*
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
-					} else {
-						// String based prefix path matching
-						// Request /foo matches /foo/bar and /foobar
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
-					}
-
-				default:
-					log.Error().Msgf("Invalid pathType=%s unspecified for path %s in ingress resource %s/%s, ignoring this path", *ingressPath.PathType, ingressPath.Path, ingress.Namespace, ingress.Name)
+				httpRouteMatch, err := getIngressHTTPRouteMatch(ingressPath.Path, string(pathType))
+				if err != nil {
+					log.Error().Err(err).Msgf("Invalid pathType=%s unspecified for path %s in ingress resource %s/%s, ignoring this path", pathType, ingressPath.Path, ingress.Namespace, ingress.Name)
 					continue
 				}
 
-				ingressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(httpRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceAccount)
+				ingressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(httpRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceIdentity)
 			}
 
 			// Only create an ingress policy if the ingress policy resulted in valid rules
@@ -185,7 +140,7 @@ func (mc *MeshCatalog) getIngressPoliciesNetworkingV1(svc service.MeshService) (
 	for _, ingress := range ingresses {
 		if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service.Name == svc.Name {
 			wildcardIngressPolicy := trafficpolicy.NewInboundTrafficPolicy(buildIngressPolicyName(ingress.ObjectMeta.Name, ingress.ObjectMeta.Namespace, constants.WildcardHTTPMethod), []string{constants.WildcardHTTPMethod})
-			wildcardIngressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceAccount)
+			wildcardIngressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceIdentity)
 			inboundIngressPolicies = trafficpolicy.MergeInboundPolicies(DisallowPartialHostnamesMatch, inboundIngressPolicies, wildcardIngressPolicy)
 		}
 
@@ -201,64 +156,19 @@ func (mc *MeshCatalog) getIngressPoliciesNetworkingV1(svc service.MeshService) (
 					continue
 				}
 
-				httpRouteMatch := trafficpolicy.HTTPRouteMatch{
-					Methods: []string{constants.WildcardHTTPMethod},
-				}
-
 				// Default ingress path type to PathTypeImplementationSpecific if unspecified
 				pathType := networkingV1.PathTypeImplementationSpecific
 				if ingressPath.PathType != nil {
 					pathType = *ingressPath.PathType
 				}
 
-				switch pathType {
-				case networkingV1.PathTypeExact:
-					// Exact match
-					// Request /foo matches path /foo, not /foobar or /foo/bar
-					httpRouteMatch.Path = ingressPath.Path
-					httpRouteMatch.PathMatchType = trafficpolicy.PathMatchExact
-
-				case networkingV1.PathTypePrefix:
-					// Element wise prefix match
-					// Request /foo matches path /foo and /foo/bar, not /foobar
-					if ingressPath.Path == "/" {
-						// A wildcard path '/' for Prefix pathType must be matched
-						// as a string based prefix match, ie. path '/' should
-						// match any path in the request.
-						httpRouteMatch.Path = ingressPath.Path
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
-					} else {
-						// Non-wildcard path of the form '/path' must be matched as a
-						// regex match to meet k8s Ingress API requirement of element-wise
-						// prefix matching.
-						// There is also the requirement for prefix /foo/ to match /foo
-						// based on k8s API interpretation of element-wise matching, so
-						// account for this case by trimming trailing '/'.
-						path := strings.TrimRight(ingressPath.Path, "/")
-						httpRouteMatch.Path = path + prefixMatchPathElementsRegex
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
-					}
-
-				case networkingV1.PathTypeImplementationSpecific:
-					httpRouteMatch.Path = ingressPath.Path
-					// If the path looks like a regex, use regex matching.
-					// Else use string based prefix matching.
-					if strings.ContainsAny(ingressPath.Path, commonRegexChars) {
-						// Path contains regex characters, use regex matching for the path
-						// Request /foo/bar matches path /foo.*
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
-					} else {
-						// String based prefix path matching
-						// Request /foo matches /foo/bar and /foobar
-						httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
-					}
-
-				default:
-					log.Error().Msgf("Invalid pathType=%s unspecified for path %s in ingress resource %s/%s, ignoring this path", *ingressPath.PathType, ingressPath.Path, ingress.Namespace, ingress.Name)
+				httpRouteMatch, err := getIngressHTTPRouteMatch(ingressPath.Path, string(pathType))
+				if err != nil {
+					log.Error().Err(err).Msgf("Invalid pathType=%s unspecified for path %s in ingress resource %s/%s, ignoring this path", pathType, ingressPath.Path, ingress.Namespace, ingress.Name)
 					continue
 				}
 
-				ingressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(httpRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceAccount)
+				ingressPolicy.AddRule(*trafficpolicy.NewRouteWeightedCluster(httpRouteMatch, []service.WeightedCluster{ingressWeightedCluster}), wildcardServiceIdentity)
 			}
 
 			// Only create an ingress policy if the ingress policy resulted in valid rules
@@ -269,3 +179,58 @@ func (mc *MeshCatalog) getIngressPoliciesNetworkingV1(svc service.MeshService) (
 	}
 	return inboundIngressPolicies, nil
 }
+
+// getIngressHTTPRouteMatch returns the HTTP route match for the given ingress path and path type.
+// The path types of the networking.k8s.io/v1 and networking.k8s.io/v1beta1 ingress APIs share the same values.
+func getIngressHTTPRouteMatch(path string, pathType string) (trafficpolicy.HTTPRouteMatch, error) {
+	httpRouteMatch := trafficpolicy.HTTPRouteMatch{
+		Methods: []string{constants.WildcardHTTPMethod},
+	}
+
+	switch networkingV1.PathType(pathType) {
+	case networkingV1.PathTypeExact:
+		// Exact match
+		// Request /foo matches path /foo, not /foobar or /foo/bar
+		httpRouteMatch.Path = path
+		httpRouteMatch.PathMatchType = trafficpolicy.PathMatchExact
+
+	case networkingV1.PathTypePrefix:
+		// Element wise prefix match
+		// Request /foo matches path /foo and /foo/bar, not /foobar
+		if path == "/" {
+			// A wildcard path '/' for Prefix pathType must be matched
+			// as a string based prefix match, ie. path '/' should
+			// match any path in the request.
+			httpRouteMatch.Path = path
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+		} else {
+			// Non-wildcard path of the form '/path' must be matched as a
+			// regex match to meet k8s Ingress API requirement of element-wise
+			// prefix matching.
+			// There is also the requirement for prefix /foo/ to match /foo
+			// based on k8s API interpretation of element-wise matching, so
+			// account for this case by trimming trailing '/'.
+			httpRouteMatch.Path = strings.TrimRight(path, "/") + prefixMatchPathElementsRegex
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
+		}
+
+	case networkingV1.PathTypeImplementationSpecific:
+		httpRouteMatch.Path = path
+		// If the path looks like a regex, use regex matching.
+		// Else use string based prefix matching.
+		if strings.ContainsAny(path, commonRegexChars) {
+			// Path contains regex characters, use regex matching for the path
+			// Request /foo/bar matches path /foo.*
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchRegex
+		} else {
+			// String based prefix path matching
+			// Request /foo matches /foo/bar and /foobar
+			httpRouteMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+		}
+
+	default:
+		return httpRouteMatch, ErrInvalidIngressPathType
+	}
+
+	return httpRouteMatch, nil
+}
@@ -0,0 +1,157 @@
+package catalog
+
+import (
+	networkingV1 "k8s.io/api/networking/v1"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetIngressGatewayPolicies returns the outbound traffic policies programmed on the OSM managed ingress gateway,
+// as defined in the observed ingress k8s resources served by the ingress gateway.
+func (mc *MeshCatalog) GetIngressGatewayPolicies() ([]*trafficpolicy.OutboundTrafficPolicy, error) {
+	var outboundTrafficPolicies []*trafficpolicy.OutboundTrafficPolicy
+
+	// Build policies for ingress v1
+	if v1Policies, err := mc.getIngressGatewayPoliciesNetworkingV1(); err != nil {
+		log.Error().Err(err).Msg("Error building ingress gateway policies for ingress v1")
+	} else {
+		outboundTrafficPolicies = trafficpolicy.MergeOutboundPolicies(DisallowPartialHostnamesMatch, outboundTrafficPolicies, v1Policies...)
+	}
+
+	// Build policies for ingress v1beta1
+	if v1beta1Policies, err := mc.getIngressGatewayPoliciesNetworkingV1beta1(); err != nil {
+		log.Error().Err(err).Msg("Error building ingress gateway policies for ingress v1beta1")
+	} else {
+		outboundTrafficPolicies = trafficpolicy.MergeOutboundPolicies(DisallowPartialHostnamesMatch, outboundTrafficPolicies, v1beta1Policies...)
+	}
+
+	return outboundTrafficPolicies, nil
+}
+
+// getIngressGatewayPoliciesNetworkingV1beta1 returns the ingress gateway policies associated with networking.k8s.io/v1beta1 ingress resources
+func (mc *MeshCatalog) getIngressGatewayPoliciesNetworkingV1beta1() ([]*trafficpolicy.OutboundTrafficPolicy, error) {
+	var gatewayPolicies []*trafficpolicy.OutboundTrafficPolicy
+
+	ingresses, err := mc.ingressMonitor.GetGatewayIngressNetworkingV1beta1()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get ingress resources served by the ingress gateway")
+		return nil, err
+	}
+
+	for _, ingress := range ingresses {
+		if backend := ingress.Spec.Backend; backend != nil {
+			backendSvc := service.MeshService{Name: backend.ServiceName, Namespace: ingress.Namespace}
+			wildcardPolicy := trafficpolicy.NewOutboundTrafficPolicy(buildIngressPolicyName(ingress.Name, ingress.Namespace, constants.WildcardHTTPMethod), []string{constants.WildcardHTTPMethod})
+			if err := wildcardPolicy.AddRoute(trafficpolicy.WildCardRouteMatch, getDefaultWeightedClusterForService(backendSvc)); err != nil {
+				log.Error().Err(err).Msgf("Error adding default backend route for ingress resource %s/%s", ingress.Namespace, ingress.Name)
+			} else {
+				gatewayPolicies = trafficpolicy.MergeOutboundPolicies(DisallowPartialHostnamesMatch, gatewayPolicies, wildcardPolicy)
+			}
+		}
+
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			domain := rule.Host
+			if domain == "" {
+				domain = constants.WildcardHTTPMethod
+			}
+			gatewayPolicy := trafficpolicy.NewOutboundTrafficPolicy(buildIngressPolicyName(ingress.Name, ingress.Namespace, domain), []string{domain})
+
+			for _, ingressPath := range rule.HTTP.Paths {
+				// Default ingress path type to PathTypeImplementationSpecific if unspecified
+				pathType := networkingV1beta1.PathTypeImplementationSpecific
+				if ingressPath.PathType != nil {
+					pathType = *ingressPath.PathType
+				}
+
+				httpRouteMatch, err := getIngressHTTPRouteMatch(ingressPath.Path, string(pathType))
+				if err != nil {
+					log.Error().Err(err).Msgf("Invalid pathType=%s unspecified for path %s in ingress resource %s/%s, ignoring this path", pathType, ingressPath.Path, ingress.Namespace, ingress.Name)
+					continue
+				}
+
+				backendSvc := service.MeshService{Name: ingressPath.Backend.ServiceName, Namespace: ingress.Namespace}
+				if err := gatewayPolicy.AddRoute(httpRouteMatch, getDefaultWeightedClusterForService(backendSvc)); err != nil {
+					log.Error().Err(err).Msgf("Error adding route for path %s in ingress resource %s/%s, ignoring this path", ingressPath.Path, ingress.Namespace, ingress.Name)
+				}
+			}
+
+			// Only create a gateway policy if the ingress rule resulted in valid routes
+			if len(gatewayPolicy.Routes) > 0 {
+				gatewayPolicies = trafficpolicy.MergeOutboundPolicies(DisallowPartialHostnamesMatch, gatewayPolicies, gatewayPolicy)
+			}
+		}
+	}
+	return gatewayPolicies, nil
+}
+
+// getIngressGatewayPoliciesNetworkingV1 returns the ingress gateway policies associated with networking.k8s.io/v1 ingress resources
+func (mc *MeshCatalog) getIngressGatewayPoliciesNetworkingV1() ([]*trafficpolicy.OutboundTrafficPolicy, error) {
+	var gatewayPolicies []*trafficpolicy.OutboundTrafficPolicy
+
+	ingresses, err := mc.ingressMonitor.GetGatewayIngressNetworkingV1()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get ingress resources served by the ingress gateway")
+		return nil, err
+	}
+
+	for _, ingress := range ingresses {
+		if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+			backendSvc := service.MeshService{Name: backend.Service.Name, Namespace: ingress.Namespace}
+			wildcardPolicy := trafficpolicy.NewOutboundTrafficPolicy(buildIngressPolicyName(ingress.Name, ingress.Namespace, constants.WildcardHTTPMethod), []string{constants.WildcardHTTPMethod})
+			if err := wildcardPolicy.AddRoute(trafficpolicy.WildCardRouteMatch, getDefaultWeightedClusterForService(backendSvc)); err != nil {
+				log.Error().Err(err).Msgf("Error adding default backend route for ingress resource %s/%s", ingress.Namespace, ingress.Name)
+			} else {
+				gatewayPolicies = trafficpolicy.MergeOutboundPolicies(DisallowPartialHostnamesMatch, gatewayPolicies, wildcardPolicy)
+			}
+		}
+
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+
+			domain := rule.Host
+			if domain == "" {
+				domain = constants.WildcardHTTPMethod
+			}
+			gatewayPolicy := trafficpolicy.NewOutboundTrafficPolicy(buildIngressPolicyName(ingress.Name, ingress.Namespace, domain), []string{domain})
+
+			for _, ingressPath := range rule.HTTP.Paths {
+				if ingressPath.Backend.Service == nil {
+					// Resource backends are not supported by the ingress gateway
+					continue
+				}
+
+				// Default ingress path type to PathTypeImplementationSpecific if unspecified
+				pathType := networkingV1.PathTypeImplementationSpecific
+				if ingressPath.PathType != nil {
+					pathType = *ingressPath.PathType
+				}
+
+				httpRouteMatch, err := getIngressHTTPRouteMatch(ingressPath.Path, string(pathType))
+				if err != nil {
+					log.Error().Err(err).Msgf("Invalid pathType=%s unspecified for path %s in ingress resource %s/%s, ignoring this path", pathType, ingressPath.Path, ingress.Namespace, ingress.Name)
+					continue
+				}
+
+				backendSvc := service.MeshService{Name: ingressPath.Backend.Service.Name, Namespace: ingress.Namespace}
+				if err := gatewayPolicy.AddRoute(httpRouteMatch, getDefaultWeightedClusterForService(backendSvc)); err != nil {
+					log.Error().Err(err).Msgf("Error adding route for path %s in ingress resource %s/%s, ignoring this path", ingressPath.Path, ingress.Namespace, ingress.Name)
+				}
+			}
+
+			// Only create a gateway policy if the ingress rule resulted in valid routes
+			if len(gatewayPolicy.Routes) > 0 {
+				gatewayPolicies = trafficpolicy.MergeOutboundPolicies(DisallowPartialHostnamesMatch, gatewayPolicies, gatewayPolicy)
+			}
+		}
+	}
+	return gatewayPolicies, nil
+}
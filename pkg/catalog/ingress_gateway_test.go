@@ -0,0 +1,230 @@
+package catalog
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	networkingV1 "k8s.io/api/networking/v1"
+	networkingV1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/ingress"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetIngressGatewayPolicies(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockIngressMonitor := ingress.NewMockMonitor(mockCtrl)
+	meshCatalog := &MeshCatalog{
+		ingressMonitor: mockIngressMonitor,
+	}
+
+	pathTypePrefix := networkingV1.PathTypePrefix
+	pathTypeExact := networkingV1beta1.PathTypeExact
+
+	v1Ingresses := []*networkingV1.Ingress{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ingress-1",
+				Namespace: "testns",
+			},
+			Spec: networkingV1.IngressSpec{
+				DefaultBackend: &networkingV1.IngressBackend{
+					Service: &networkingV1.IngressServiceBackend{
+						Name: "default",
+						Port: networkingV1.ServiceBackendPort{Number: fakeIngressPort},
+					},
+				},
+				Rules: []networkingV1.IngressRule{
+					{
+						Host: "fake1.com",
+						IngressRuleValue: networkingV1.IngressRuleValue{
+							HTTP: &networkingV1.HTTPIngressRuleValue{
+								Paths: []networkingV1.HTTPIngressPath{
+									{
+										Path:     "/foo/",
+										PathType: &pathTypePrefix,
+										Backend: networkingV1.IngressBackend{
+											Service: &networkingV1.IngressServiceBackend{
+												Name: "foo",
+												Port: networkingV1.ServiceBackendPort{Number: fakeIngressPort},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v1beta1Ingresses := []*networkingV1beta1.Ingress{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "ingress-2",
+				Namespace: "testns",
+			},
+			Spec: networkingV1beta1.IngressSpec{
+				Rules: []networkingV1beta1.IngressRule{
+					{
+						Host: "fake2.com",
+						IngressRuleValue: networkingV1beta1.IngressRuleValue{
+							HTTP: &networkingV1beta1.HTTPIngressRuleValue{
+								Paths: []networkingV1beta1.HTTPIngressPath{
+									{
+										Path:     "/bar",
+										PathType: &pathTypeExact,
+										Backend: networkingV1beta1.IngressBackend{
+											ServiceName: "bar",
+											ServicePort: intstr.IntOrString{
+												Type:   intstr.Int,
+												IntVal: fakeIngressPort,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	mockIngressMonitor.EXPECT().GetGatewayIngressNetworkingV1().Return(v1Ingresses, nil).Times(1)
+	mockIngressMonitor.EXPECT().GetGatewayIngressNetworkingV1beta1().Return(v1beta1Ingresses, nil).Times(1)
+
+	expectedPolicies := []*trafficpolicy.OutboundTrafficPolicy{
+		{
+			Name:      "ingress-1.testns|*",
+			Hostnames: []string{constants.WildcardHTTPMethod},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "testns/default", Weight: 100}),
+				},
+			},
+		},
+		{
+			Name:      "ingress-1.testns|fake1.com",
+			Hostnames: []string{"fake1.com"},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+						Path:          "/foo" + prefixMatchPathElementsRegex,
+						PathMatchType: trafficpolicy.PathMatchRegex,
+						Methods:       []string{constants.WildcardHTTPMethod},
+					},
+					WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "testns/foo", Weight: 100}),
+				},
+			},
+		},
+		{
+			Name:      "ingress-2.testns|fake2.com",
+			Hostnames: []string{"fake2.com"},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+						Path:          "/bar",
+						PathMatchType: trafficpolicy.PathMatchExact,
+						Methods:       []string{constants.WildcardHTTPMethod},
+					},
+					WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "testns/bar", Weight: 100}),
+				},
+			},
+		},
+	}
+
+	actual, err := meshCatalog.GetIngressGatewayPolicies()
+	assert.Nil(err)
+	assert.ElementsMatch(expectedPolicies, actual)
+}
+
+func TestGetIngressHTTPRouteMatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		pathType      string
+		expected      trafficpolicy.HTTPRouteMatch
+		expectedError error
+	}{
+		{
+			name:     "exact path",
+			path:     "/foo",
+			pathType: string(networkingV1.PathTypeExact),
+			expected: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo",
+				PathMatchType: trafficpolicy.PathMatchExact,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name:     "wildcard prefix path",
+			path:     "/",
+			pathType: string(networkingV1.PathTypePrefix),
+			expected: trafficpolicy.HTTPRouteMatch{
+				Path:          "/",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name:     "element wise prefix path",
+			path:     "/foo/",
+			pathType: string(networkingV1.PathTypePrefix),
+			expected: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo" + prefixMatchPathElementsRegex,
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name:     "implementation specific regex path",
+			path:     "/foo.*",
+			pathType: string(networkingV1.PathTypeImplementationSpecific),
+			expected: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo.*",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name:     "implementation specific string path",
+			path:     "/foo",
+			pathType: string(networkingV1.PathTypeImplementationSpecific),
+			expected: trafficpolicy.HTTPRouteMatch{
+				Path:          "/foo",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+			},
+		},
+		{
+			name:          "invalid path type",
+			path:          "/foo",
+			pathType:      "invalid",
+			expectedError: ErrInvalidIngressPathType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual, err := getIngressHTTPRouteMatch(tc.path, tc.pathType)
+			assert.Equal(tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(tc.expected, actual)
+			}
+		})
+	}
+}
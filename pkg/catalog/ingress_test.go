@@ -119,7 +119,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -141,7 +141,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -228,7 +228,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -250,7 +250,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -272,7 +272,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -369,7 +369,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -391,7 +391,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -488,7 +488,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -502,7 +502,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -586,7 +586,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -608,7 +608,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -693,7 +693,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -757,7 +757,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -821,7 +821,7 @@ func TestGetIngressPoliciesNetworkingV1beta1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -940,7 +940,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -962,7 +962,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1052,7 +1052,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1074,7 +1074,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1096,7 +1096,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1195,7 +1195,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1217,7 +1217,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1316,7 +1316,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -1330,7 +1330,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1416,7 +1416,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1438,7 +1438,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1525,7 +1525,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1590,7 +1590,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1655,7 +1655,7 @@ func TestGetIngressPoliciesNetworkingV1(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1775,7 +1775,7 @@ func TestGetIngressPoliciesForService(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1797,7 +1797,7 @@ func TestGetIngressPoliciesForService(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1881,7 +1881,7 @@ func TestGetIngressPoliciesForService(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -1903,7 +1903,7 @@ func TestGetIngressPoliciesForService(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -2003,7 +2003,7 @@ func TestGetIngressPoliciesForService(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
@@ -2025,7 +2025,7 @@ func TestGetIngressPoliciesForService(t *testing.T) {
 									Weight:      100,
 								}),
 							},
-							AllowedServiceAccounts: mapset.NewSet(wildcardServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(wildcardServiceIdentity),
 						},
 					},
 				},
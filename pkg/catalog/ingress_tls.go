@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// GetIngressBackendTLSSecret returns the TLS Secret served by the sidecars of the given service to HTTPS ingress clients,
+// or nil if the service does not reference a TLS Secret with the ingress backend TLS Secret annotation.
+// The Secret must be in the same namespace as the service.
+func (mc *MeshCatalog) GetIngressBackendTLSSecret(svc service.MeshService) *corev1.Secret {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil
+	}
+
+	secretName, ok := k8sSvc.Annotations[constants.IngressBackendTLSSecretAnnotation]
+	if !ok || secretName == "" {
+		return nil
+	}
+
+	secret := mc.kubeController.GetSecret(svc.Namespace, secretName)
+	if secret == nil {
+		log.Error().Msgf("TLS Secret %s/%s referenced by service %s for ingress does not exist", svc.Namespace, secretName, svc)
+		return nil
+	}
+
+	return secret
+}
+
+// GetIngressBackendXFFSettings returns the handling of the x-forwarded-for header of the requests received by the sidecars
+// of the given service from ingress. The mesh-wide settings are overridden by the ingress backend XFF annotations of the
+// service, and invalid annotations are ignored.
+func (mc *MeshCatalog) GetIngressBackendXFFSettings(svc service.MeshService) configurator.XFFSettings {
+	settings := mc.configurator.GetXFFSettings()
+
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return settings
+	}
+
+	if mode, ok := k8sSvc.Annotations[constants.IngressBackendXFFHeaderModeAnnotation]; ok {
+		if configurator.IsValidXFFHeaderMode(mode) {
+			settings.HeaderMode = mode
+		} else {
+			log.Error().Msgf("Invalid value %q for annotation %s on service %s, must be one of append, overwrite, preserve",
+				mode, constants.IngressBackendXFFHeaderModeAnnotation, svc)
+		}
+	}
+
+	if hops, ok := k8sSvc.Annotations[constants.IngressBackendXFFNumTrustedHopsAnnotation]; ok {
+		if numTrustedHops, err := strconv.ParseUint(hops, 10, 32); err == nil {
+			settings.NumTrustedHops = uint32(numTrustedHops)
+		} else {
+			log.Error().Err(err).Msgf("Invalid value %q for annotation %s on service %s, must be a positive integer",
+				hops, constants.IngressBackendXFFNumTrustedHopsAnnotation, svc)
+		}
+	}
+
+	return settings.WithDefaults()
+}
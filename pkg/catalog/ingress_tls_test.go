@@ -0,0 +1,128 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestGetIngressBackendTLSSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore-tls",
+			Namespace: tests.BookstoreV1Service.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		secretExists   bool
+		expectedSecret *corev1.Secret
+	}{
+		{
+			name:           "service without annotation",
+			annotations:    nil,
+			expectedSecret: nil,
+		},
+		{
+			name:           "service referencing an existing secret",
+			annotations:    map[string]string{constants.IngressBackendTLSSecretAnnotation: "bookstore-tls"},
+			secretExists:   true,
+			expectedSecret: secret,
+		},
+		{
+			name:           "service referencing a missing secret",
+			annotations:    map[string]string{constants.IngressBackendTLSSecretAnnotation: "bookstore-tls"},
+			secretExists:   false,
+			expectedSecret: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mc := MeshCatalog{
+				kubeController: mockKubeController,
+			}
+
+			svc := tests.NewServiceFixture(tests.BookstoreV1Service.Name, tests.BookstoreV1Service.Namespace, nil)
+			svc.Annotations = tc.annotations
+			mockKubeController.EXPECT().GetService(tests.BookstoreV1Service).Return(svc).Times(1)
+			if tc.secretExists {
+				mockKubeController.EXPECT().GetSecret(secret.Namespace, secret.Name).Return(secret).Times(1)
+			} else {
+				mockKubeController.EXPECT().GetSecret(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			}
+
+			assert.Equal(tc.expectedSecret, mc.GetIngressBackendTLSSecret(tests.BookstoreV1Service))
+		})
+	}
+}
+
+func TestGetIngressBackendXFFSettings(t *testing.T) {
+	meshSettings := configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModePreserve, UnixSocketsInternal: true}
+
+	testCases := []struct {
+		name             string
+		annotations      map[string]string
+		expectedSettings configurator.XFFSettings
+	}{
+		{
+			name:             "service without annotations",
+			annotations:      nil,
+			expectedSettings: meshSettings,
+		},
+		{
+			name: "service overriding the mesh-wide settings",
+			annotations: map[string]string{
+				constants.IngressBackendXFFHeaderModeAnnotation:     "overwrite",
+				constants.IngressBackendXFFNumTrustedHopsAnnotation: "1",
+			},
+			expectedSettings: configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModeOverwrite, NumTrustedHops: 1, UnixSocketsInternal: true},
+		},
+		{
+			name: "service with invalid annotations",
+			annotations: map[string]string{
+				constants.IngressBackendXFFHeaderModeAnnotation:     "replace",
+				constants.IngressBackendXFFNumTrustedHopsAnnotation: "-1",
+			},
+			expectedSettings: meshSettings,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mc := MeshCatalog{
+				kubeController: mockKubeController,
+				configurator:   mockConfigurator,
+			}
+
+			svc := tests.NewServiceFixture(tests.BookstoreV1Service.Name, tests.BookstoreV1Service.Namespace, nil)
+			svc.Annotations = tc.annotations
+			mockKubeController.EXPECT().GetService(tests.BookstoreV1Service).Return(svc).Times(1)
+			mockConfigurator.EXPECT().GetXFFSettings().Return(meshSettings).Times(1)
+
+			assert.Equal(tc.expectedSettings, mc.GetIngressBackendXFFSettings(tests.BookstoreV1Service))
+		})
+	}
+}
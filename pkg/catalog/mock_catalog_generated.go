@@ -7,12 +7,16 @@ package catalog
 import (
 	reflect "reflect"
 
+	golang_set "github.com/deckarep/golang-set"
 	gomock "github.com/golang/mock/gomock"
+	v1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	configurator "github.com/openservicemesh/osm/pkg/configurator"
 	endpoint "github.com/openservicemesh/osm/pkg/endpoint"
 	envoy "github.com/openservicemesh/osm/pkg/envoy"
 	identity "github.com/openservicemesh/osm/pkg/identity"
 	service "github.com/openservicemesh/osm/pkg/service"
 	trafficpolicy "github.com/openservicemesh/osm/pkg/trafficpolicy"
+	v1 "k8s.io/api/core/v1"
 )
 
 // MockMeshCataloger is a mock of MeshCataloger interface
@@ -38,6 +42,21 @@ func (m *MockMeshCataloger) EXPECT() *MockMeshCatalogerMockRecorder {
 	return m.recorder
 }
 
+// GetEffectivePolicies mocks base method
+func (m *MockMeshCataloger) GetEffectivePolicies(arg0 identity.ServiceIdentity) (*trafficpolicy.EffectivePolicies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePolicies", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.EffectivePolicies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectivePolicies indicates an expected call of GetEffectivePolicies
+func (mr *MockMeshCatalogerMockRecorder) GetEffectivePolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePolicies", reflect.TypeOf((*MockMeshCataloger)(nil).GetEffectivePolicies), arg0)
+}
+
 // GetEgressTrafficPolicy mocks base method
 func (m *MockMeshCataloger) GetEgressTrafficPolicy(arg0 identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +72,49 @@ func (mr *MockMeshCatalogerMockRecorder) GetEgressTrafficPolicy(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetEgressTrafficPolicy), arg0)
 }
 
+// GetIngressBackendTLSSecret mocks base method
+func (m *MockMeshCataloger) GetIngressBackendTLSSecret(arg0 service.MeshService) *v1.Secret {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngressBackendTLSSecret", arg0)
+	ret0, _ := ret[0].(*v1.Secret)
+	return ret0
+}
+
+// GetIngressBackendTLSSecret indicates an expected call of GetIngressBackendTLSSecret
+func (mr *MockMeshCatalogerMockRecorder) GetIngressBackendTLSSecret(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressBackendTLSSecret", reflect.TypeOf((*MockMeshCataloger)(nil).GetIngressBackendTLSSecret), arg0)
+}
+
+// GetIngressBackendXFFSettings mocks base method
+func (m *MockMeshCataloger) GetIngressBackendXFFSettings(arg0 service.MeshService) configurator.XFFSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngressBackendXFFSettings", arg0)
+	ret0, _ := ret[0].(configurator.XFFSettings)
+	return ret0
+}
+
+// GetIngressBackendXFFSettings indicates an expected call of GetIngressBackendXFFSettings
+func (mr *MockMeshCatalogerMockRecorder) GetIngressBackendXFFSettings(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressBackendXFFSettings", reflect.TypeOf((*MockMeshCataloger)(nil).GetIngressBackendXFFSettings), arg0)
+}
+
+// GetIngressGatewayPolicies mocks base method
+func (m *MockMeshCataloger) GetIngressGatewayPolicies() ([]*trafficpolicy.OutboundTrafficPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngressGatewayPolicies")
+	ret0, _ := ret[0].([]*trafficpolicy.OutboundTrafficPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngressGatewayPolicies indicates an expected call of GetIngressGatewayPolicies
+func (mr *MockMeshCatalogerMockRecorder) GetIngressGatewayPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressGatewayPolicies", reflect.TypeOf((*MockMeshCataloger)(nil).GetIngressGatewayPolicies))
+}
+
 // GetIngressPoliciesForService mocks base method
 func (m *MockMeshCataloger) GetIngressPoliciesForService(arg0 service.MeshService) ([]*trafficpolicy.InboundTrafficPolicy, error) {
 	m.ctrl.T.Helper()
@@ -68,6 +130,35 @@ func (mr *MockMeshCatalogerMockRecorder) GetIngressPoliciesForService(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngressPoliciesForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetIngressPoliciesForService), arg0)
 }
 
+// GetMeshTopology mocks base method
+func (m *MockMeshCataloger) GetMeshTopology() *trafficpolicy.MeshTopology {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshTopology")
+	ret0, _ := ret[0].(*trafficpolicy.MeshTopology)
+	return ret0
+}
+
+// GetMeshTopology indicates an expected call of GetMeshTopology
+func (mr *MockMeshCatalogerMockRecorder) GetMeshTopology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshTopology", reflect.TypeOf((*MockMeshCataloger)(nil).GetMeshTopology))
+}
+
+// GetPermissiveTLSInboundPortsForProxy mocks base method
+func (m *MockMeshCataloger) GetPermissiveTLSInboundPortsForProxy(arg0 *envoy.Proxy) (golang_set.Set, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissiveTLSInboundPortsForProxy", arg0)
+	ret0, _ := ret[0].(golang_set.Set)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissiveTLSInboundPortsForProxy indicates an expected call of GetPermissiveTLSInboundPortsForProxy
+func (mr *MockMeshCatalogerMockRecorder) GetPermissiveTLSInboundPortsForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissiveTLSInboundPortsForProxy", reflect.TypeOf((*MockMeshCataloger)(nil).GetPermissiveTLSInboundPortsForProxy), arg0)
+}
+
 // GetPortToProtocolMappingForService mocks base method
 func (m *MockMeshCataloger) GetPortToProtocolMappingForService(arg0 service.MeshService) (map[uint32]string, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +189,21 @@ func (mr *MockMeshCatalogerMockRecorder) GetResolvableServiceEndpoints(arg0 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResolvableServiceEndpoints", reflect.TypeOf((*MockMeshCataloger)(nil).GetResolvableServiceEndpoints), arg0)
 }
 
+// GetServicePortToTargetPortMappingForService mocks base method
+func (m *MockMeshCataloger) GetServicePortToTargetPortMappingForService(arg0 service.MeshService) (map[uint32]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServicePortToTargetPortMappingForService", arg0)
+	ret0, _ := ret[0].(map[uint32]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServicePortToTargetPortMappingForService indicates an expected call of GetServicePortToTargetPortMappingForService
+func (mr *MockMeshCatalogerMockRecorder) GetServicePortToTargetPortMappingForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServicePortToTargetPortMappingForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetServicePortToTargetPortMappingForService), arg0)
+}
+
 // GetServicesForProxy mocks base method
 func (m *MockMeshCataloger) GetServicesForProxy(arg0 *envoy.Proxy) ([]service.MeshService, error) {
 	m.ctrl.T.Helper()
@@ -128,6 +234,20 @@ func (mr *MockMeshCatalogerMockRecorder) GetTargetPortToProtocolMappingForServic
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetPortToProtocolMappingForService", reflect.TypeOf((*MockMeshCataloger)(nil).GetTargetPortToProtocolMappingForService), arg0)
 }
 
+// GetUpstreamTrafficSetting mocks base method
+func (m *MockMeshCataloger) GetUpstreamTrafficSetting(arg0 service.MeshService) *v1alpha1.UpstreamTrafficSetting {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpstreamTrafficSetting", arg0)
+	ret0, _ := ret[0].(*v1alpha1.UpstreamTrafficSetting)
+	return ret0
+}
+
+// GetUpstreamTrafficSetting indicates an expected call of GetUpstreamTrafficSetting
+func (mr *MockMeshCatalogerMockRecorder) GetUpstreamTrafficSetting(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamTrafficSetting", reflect.TypeOf((*MockMeshCataloger)(nil).GetUpstreamTrafficSetting), arg0)
+}
+
 // GetWeightedClustersForUpstream mocks base method
 func (m *MockMeshCataloger) GetWeightedClustersForUpstream(arg0 service.MeshService) []service.WeightedCluster {
 	m.ctrl.T.Helper()
@@ -39,7 +39,7 @@ func (mc *MeshCatalog) listOutboundPoliciesForTrafficTargets(downstreamIdentity
 	downstreamServiceAccount := downstreamIdentity.ToK8sServiceAccount()
 	var outboundPolicies []*trafficpolicy.OutboundTrafficPolicy
 
-	for _, t := range mc.meshSpec.ListTrafficTargets() { // loop through all traffic targets
+	for _, t := range mc.listActiveTrafficTargets() { // loop through all traffic targets
 		if !isValidTrafficTarget(t) {
 			continue
 		}
@@ -84,7 +84,7 @@ func (mc *MeshCatalog) listOutboundTrafficPoliciesForTrafficSplits(sourceNamespa
 		}
 
 		rwc := trafficpolicy.NewRouteWeightedCluster(trafficpolicy.WildCardRouteMatch, weightedClusters)
-		policy.Routes = []*trafficpolicy.RouteWeightedClusters{rwc}
+		policy.Routes = append([]*trafficpolicy.RouteWeightedClusters{rwc}, mc.getGRPCRoutes(svc, weightedClusters...)...)
 
 		if apexServices.Contains(svc) {
 			log.Error().Msgf("Skipping Traffic Split policy %s in namespaces %s as there is already a traffic split policy for apex service %v", split.Name, split.Namespace, svc)
@@ -105,7 +105,7 @@ func (mc *MeshCatalog) ListAllowedOutboundServicesForIdentity(serviceIdentity id
 	}
 
 	serviceSet := mapset.NewSet()
-	for _, t := range mc.meshSpec.ListTrafficTargets() { // loop through all traffic targets
+	for _, t := range mc.listActiveTrafficTargets() { // loop through all traffic targets
 		for _, source := range t.Spec.Sources {
 			if source.Name == ident.Name && source.Namespace == ident.Namespace { // found outbound
 				destServices, err := mc.getServicesForServiceAccount(identity.K8sServiceAccount{
@@ -153,6 +153,7 @@ func (mc *MeshCatalog) buildOutboundPermissiveModePolicies() []*trafficpolicy.Ou
 			log.Error().Err(err).Msgf("Error adding route to outbound policy in permissive mode for destination %s(%s)", destService.Name, destService.Namespace)
 			continue
 		}
+		policy.Routes = append(policy.Routes, mc.getGRPCRoutes(destService, weightedCluster)...)
 		outPolicies = append(outPolicies, policy)
 	}
 	return outPolicies
@@ -171,7 +172,7 @@ func (mc *MeshCatalog) buildOutboundPolicies(sourceServiceIdentity identity.Serv
 	}
 
 	// fetch all routes referenced in traffic target
-	routeMatches, err := mc.routesFromRules(t.Spec.Rules, t.Namespace)
+	routeMatches, err := mc.routesFromRules(t.Spec.Rules, t.Namespace, trafficTargetIdentityToSvcAccount(t.Spec.Destination))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error finding route matches from TrafficTarget %s in namespace %s", t.Name, t.Namespace)
 		return outboundPolicies
@@ -211,6 +212,7 @@ func (mc *MeshCatalog) buildOutboundPolicies(sourceServiceIdentity identity.Serv
 					log.Error().Err(err).Msgf("Error adding Route to outbound policy for source %s(%s) and destination %s (%s)", source.Name, source.Namespace, destService.Name, destService.Namespace)
 					continue
 				}
+				policy.Routes = append(policy.Routes, mc.getGRPCRoutes(destService, weightedCluster)...)
 			}
 
 			outboundPolicies = trafficpolicy.MergeOutboundPolicies(AllowPartialHostnamesMatch, outboundPolicies, policy)
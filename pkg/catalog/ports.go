@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// parsePortRanges parses a comma separated list of ports and port ranges, ex. '8000-8010,9000', and returns the
+// ports in the list.
+func parsePortRanges(portRanges string) ([]int, error) {
+	var ports []int
+
+	for _, portRange := range strings.Split(portRanges, ",") {
+		portRange = strings.TrimSpace(portRange)
+		if portRange == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(portRange, "-", 2)
+		start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, errors.Errorf("Invalid port range %q", portRange)
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+				return nil, errors.Errorf("Invalid port range %q", portRange)
+			}
+		}
+
+		rangePorts, err := policy.ExpandPortRange(start, end)
+		if err != nil {
+			return nil, err
+		}
+		ports = append(ports, rangePorts...)
+	}
+
+	return ports, nil
+}
+
+// resolveNamedPorts returns the target ports of the ports with the given names on the services of the given service account.
+// Names that do not match a port on any of the services are ignored.
+func (mc *MeshCatalog) resolveNamedPorts(names []string, sa identity.K8sServiceAccount) []int {
+	services, err := mc.getServicesForServiceAccount(sa)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error resolving named ports %v for service account %s", names, sa)
+		return nil
+	}
+
+	var ports []int
+	for _, name := range names {
+		resolved := false
+		for _, svc := range services {
+			if port, ok := mc.getTargetPortForPortName(svc, name); ok {
+				ports = append(ports, port)
+				resolved = true
+			}
+		}
+		if !resolved {
+			log.Warn().Msgf("Port name %s does not match a port on any of the services for service account %s, ignoring it", name, sa)
+		}
+	}
+
+	return ports
+}
+
+// getTargetPortForPortName returns the target port of the port with the given name on the given service.
+// A target port referring to a named container port is resolved using the endpoints of the service.
+func (mc *MeshCatalog) getTargetPortForPortName(svc service.MeshService, name string) (int, bool) {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return 0, false
+	}
+
+	for _, port := range k8sSvc.Spec.Ports {
+		if port.Name != name {
+			continue
+		}
+
+		switch {
+		case port.TargetPort.Type == intstr.String:
+			return mc.getEndpointPortForPortName(svc, name)
+		case port.TargetPort.IntValue() != 0:
+			return port.TargetPort.IntValue(), true
+		default:
+			// The target port defaults to the service port when unspecified
+			return int(port.Port), true
+		}
+	}
+
+	return 0, false
+}
+
+// GetServicePortToTargetPortMappingForService returns a mapping of the service's ports to the ports on which the application
+// exposes them. Ports whose named target port is not exposed by any endpoint of the service are omitted.
+func (mc *MeshCatalog) GetServicePortToTargetPortMappingForService(svc service.MeshService) (map[uint32]uint32, error) {
+	k8sSvc := mc.kubeController.GetService(svc)
+	if k8sSvc == nil {
+		return nil, errors.Wrapf(ErrServiceNotFound, "Error retrieving k8s service %s", svc)
+	}
+
+	portToTargetPortMap := make(map[uint32]uint32)
+	for _, port := range k8sSvc.Spec.Ports {
+		switch {
+		case port.TargetPort.Type == intstr.String:
+			if targetPort, ok := mc.getEndpointPortForPortName(svc, port.Name); ok {
+				portToTargetPortMap[uint32(port.Port)] = uint32(targetPort)
+			}
+		case port.TargetPort.IntValue() != 0:
+			portToTargetPortMap[uint32(port.Port)] = uint32(port.TargetPort.IntValue())
+		default:
+			portToTargetPortMap[uint32(port.Port)] = uint32(port.Port)
+		}
+	}
+
+	return portToTargetPortMap, nil
+}
+
+// getEndpointPortForPortName returns the port with the given name from the endpoints of the given service
+func (mc *MeshCatalog) getEndpointPortForPortName(svc service.MeshService, name string) (int, bool) {
+	endpointSlices, err := mc.kubeController.ListEndpointSlicesForService(svc)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, endpointSlice := range endpointSlices {
+		for _, port := range endpointSlice.Ports {
+			if port.Name == nil || port.Port == nil || *port.Name != name {
+				continue
+			}
+			if port.Protocol != nil && *port.Protocol == corev1.ProtocolUDP {
+				continue
+			}
+			return int(*port.Port), true
+		}
+	}
+
+	return 0, false
+}
+
+// dedupAndSortPorts returns the unique ports in the given list in ascending order
+func dedupAndSortPorts(ports []int) []int {
+	seen := make(map[int]bool, len(ports))
+	var deduped []int
+	for _, port := range ports {
+		if !seen[port] {
+			seen[port] = true
+			deduped = append(deduped, port)
+		}
+	}
+	sort.Ints(deduped)
+	return deduped
+}
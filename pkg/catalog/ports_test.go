@@ -0,0 +1,237 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestParsePortRanges(t *testing.T) {
+	testCases := []struct {
+		name          string
+		portRanges    string
+		expectedPorts []int
+		expectError   bool
+	}{
+		{
+			name:          "single ports and ranges",
+			portRanges:    "8000-8003, 9000,9100-9100",
+			expectedPorts: []int{8000, 8001, 8002, 8003, 9000, 9100},
+		},
+		{
+			name:          "empty entries are ignored",
+			portRanges:    "80,,",
+			expectedPorts: []int{80},
+		},
+		{
+			name:        "end port smaller than start port",
+			portRanges:  "9000-8000",
+			expectError: true,
+		},
+		{
+			name:        "port out of range",
+			portRanges:  "65535-65536",
+			expectError: true,
+		},
+		{
+			name:        "range exceeds the maximum size",
+			portRanges:  "1000-3000",
+			expectError: true,
+		},
+		{
+			name:        "not a number",
+			portRanges:  "80-http",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			ports, err := parsePortRanges(tc.portRanges)
+			assert.Equal(tc.expectError, err != nil)
+			assert.Equal(tc.expectedPorts, ports)
+		})
+	}
+}
+
+func TestGetTCPRoutePorts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockEndpointProvider := endpoint.NewMockProvider(mockCtrl)
+
+	mc := MeshCatalog{
+		kubeController:     mockKubeController,
+		endpointsProviders: []endpoint.Provider{mockEndpointProvider},
+	}
+
+	mockEndpointProvider.EXPECT().GetServicesForServiceAccount(tests.BookstoreServiceAccount).Return([]service.MeshService{tests.BookstoreV1Service}, nil).AnyTimes()
+	mockEndpointProvider.EXPECT().GetID().Return("fake").AnyTimes()
+	mockKubeController.EXPECT().GetService(tests.BookstoreV1Service).Return(&corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "mysql", Port: 3306, TargetPort: intstr.FromInt(13306)},
+				{Name: "admin", Port: 9090},
+				{Name: "metrics", Port: 9091, TargetPort: intstr.FromString("metrics")},
+			},
+		},
+	}).AnyTimes()
+	protocolTCP := corev1.ProtocolTCP
+	mockKubeController.EXPECT().ListEndpointSlicesForService(tests.BookstoreV1Service).Return([]*discoveryv1beta1.EndpointSlice{
+		{
+			Ports: []discoveryv1beta1.EndpointPort{{Name: pointer.StringPtr("metrics"), Port: pointer.Int32Ptr(19091), Protocol: &protocolTCP}},
+		},
+	}, nil).AnyTimes()
+
+	testCases := []struct {
+		name             string
+		annotations      map[string]string
+		ports            []int
+		expectedPorts    []int
+		expectUnresolved bool
+	}{
+		{
+			name:          "ports in the spec are preserved without annotations",
+			ports:         []int{9000, 8000},
+			expectedPorts: []int{9000, 8000},
+		},
+		{
+			name: "port ranges are expanded",
+			annotations: map[string]string{
+				constants.TCPRoutePortRangesAnnotation: "8000-8002",
+			},
+			ports:         []int{9000, 8001},
+			expectedPorts: []int{8000, 8001, 8002, 9000},
+		},
+		{
+			name: "invalid port ranges are ignored",
+			annotations: map[string]string{
+				constants.TCPRoutePortRangesAnnotation: "8002-8000",
+			},
+			ports:         []int{9000},
+			expectedPorts: []int{9000},
+		},
+		{
+			name: "invalid port ranges without ports in the spec match no port",
+			annotations: map[string]string{
+				constants.TCPRoutePortRangesAnnotation: "8002-8000",
+			},
+			expectUnresolved: true,
+		},
+		{
+			name: "unknown named ports without ports in the spec match no port",
+			annotations: map[string]string{
+				constants.TCPRouteNamedPortsAnnotation: "unknown",
+			},
+			expectUnresolved: true,
+		},
+		{
+			name: "named ports are resolved to target ports",
+			annotations: map[string]string{
+				constants.TCPRouteNamedPortsAnnotation: "mysql, admin,metrics,unknown",
+			},
+			expectedPorts: []int{9090, 13306, 19091},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			tcpRoute := &smiSpecs.TCPRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "tcp-route",
+					Namespace:   tests.Namespace,
+					Annotations: tc.annotations,
+				},
+				Spec: smiSpecs.TCPRouteSpec{
+					Matches: smiSpecs.TCPMatch{
+						Ports: tc.ports,
+					},
+				},
+			}
+
+			ports, ok := mc.getTCPRoutePorts(tcpRoute, tests.BookstoreServiceAccount)
+			assert.Equal(tc.expectedPorts, ports)
+			assert.Equal(!tc.expectUnresolved, ok)
+		})
+	}
+}
+
+func TestGetServicePortToTargetPortMappingForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mc := MeshCatalog{
+		kubeController: mockKubeController,
+	}
+
+	mockKubeController.EXPECT().GetService(tests.BookstoreV1Service).Return(&corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)},
+				{Name: "grpc", Port: 9090},
+				{Name: "metrics", Port: 9091, TargetPort: intstr.FromString("metrics")},
+				{Name: "unknown", Port: 9092, TargetPort: intstr.FromString("unknown")},
+			},
+		},
+	}).Times(1)
+	mockKubeController.EXPECT().ListEndpointSlicesForService(tests.BookstoreV1Service).Return([]*discoveryv1beta1.EndpointSlice{
+		{
+			Ports: []discoveryv1beta1.EndpointPort{{Name: pointer.StringPtr("metrics"), Port: pointer.Int32Ptr(19091)}},
+		},
+	}, nil).AnyTimes()
+	mockKubeController.EXPECT().GetService(tests.BookstoreV2Service).Return(nil).Times(1)
+
+	portToTargetPortMap, err := mc.GetServicePortToTargetPortMappingForService(tests.BookstoreV1Service)
+	assert.Nil(err)
+	assert.Equal(map[uint32]uint32{80: 8080, 9090: 9090, 9091: 19091}, portToTargetPortMap)
+
+	_, err = mc.GetServicePortToTargetPortMappingForService(tests.BookstoreV2Service)
+	assert.NotNil(err)
+}
+
+func TestGetEgressPortSpecs(t *testing.T) {
+	assert := tassert.New(t)
+
+	egressPolicy := &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "egress-1",
+			Namespace: "test",
+		},
+		Spec: policyV1alpha1.EgressSpec{
+			Ports: []policyV1alpha1.PortSpec{
+				{Number: 80, Protocol: "http"},
+				{Number: 5000, EndNumber: 5002, Protocol: "tcp"},
+				{Number: 6000, EndNumber: 5000, Protocol: "tcp"},
+			},
+		},
+	}
+
+	expected := []policyV1alpha1.PortSpec{
+		{Number: 80, Protocol: "http"},
+		{Number: 5000, Protocol: "tcp"},
+		{Number: 5001, Protocol: "tcp"},
+		{Number: 5002, Protocol: "tcp"},
+	}
+	assert.Equal(expected, getEgressPortSpecs(egressPolicy))
+}
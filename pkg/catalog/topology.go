@@ -0,0 +1,66 @@
+package catalog
+
+import (
+	"sort"
+
+	mapset "github.com/deckarep/golang-set"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetMeshTopology returns the communication graph of the mesh, composed of the services in the mesh and
+// the connections allowed by the traffic policies from the service identities in the mesh to these services.
+func (mc *MeshCatalog) GetMeshTopology() *trafficpolicy.MeshTopology {
+	topology := &trafficpolicy.MeshTopology{
+		Services: []trafficpolicy.TopologyService{},
+		Edges:    []trafficpolicy.TopologyEdge{},
+	}
+
+	// The identities of clients that do not back any service are only known from their service accounts
+	identities := mapset.NewSet()
+	for _, sa := range mc.kubeController.ListServiceAccounts() {
+		identities.Add(identity.K8sServiceAccount{Name: sa.Name, Namespace: sa.Namespace}.ToServiceIdentity())
+	}
+
+	for _, svc := range mc.listMeshServices() {
+		topologyService := trafficpolicy.TopologyService{
+			Name:       svc.String(),
+			Identities: []string{},
+		}
+
+		svcIdentities, err := mc.ListServiceIdentitiesForService(svc)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting service identities for service %s", svc)
+		}
+		for _, svcIdentity := range svcIdentities {
+			identities.Add(svcIdentity)
+			topologyService.Identities = append(topologyService.Identities, svcIdentity.String())
+		}
+		sort.Strings(topologyService.Identities)
+
+		topology.Services = append(topology.Services, topologyService)
+	}
+
+	for elem := range identities.Iter() {
+		source := elem.(identity.ServiceIdentity)
+		for _, destination := range mc.ListAllowedOutboundServicesForIdentity(source) {
+			topology.Edges = append(topology.Edges, trafficpolicy.TopologyEdge{
+				Source:      source.String(),
+				Destination: destination.String(),
+			})
+		}
+	}
+
+	sort.Slice(topology.Services, func(i, j int) bool {
+		return topology.Services[i].Name < topology.Services[j].Name
+	})
+	sort.Slice(topology.Edges, func(i, j int) bool {
+		if topology.Edges[i].Source != topology.Edges[j].Source {
+			return topology.Edges[i].Source < topology.Edges[j].Source
+		}
+		return topology.Edges[i].Destination < topology.Edges[j].Destination
+	})
+
+	return topology
+}
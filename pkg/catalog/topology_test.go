@@ -0,0 +1,62 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/identity"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetMeshTopology(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	mc := MeshCatalog{
+		kubeController: mockKubeController,
+		configurator:   mockConfigurator,
+	}
+
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockKubeController.EXPECT().ListServiceAccounts().Return([]*corev1.ServiceAccount{
+		tests.NewServiceAccountFixture(tests.BookbuyerServiceAccountName, tests.Namespace),
+		tests.NewServiceAccountFixture("client", tests.Namespace),
+	}).Times(1)
+	mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{
+		tests.NewServiceFixture(tests.BookstoreV1ServiceName, tests.Namespace, nil),
+		tests.NewServiceFixture(tests.BookbuyerServiceName, tests.Namespace, nil),
+	}).AnyTimes()
+	mockKubeController.EXPECT().ListServiceIdentitiesForService(tests.BookstoreV1Service).Return([]identity.K8sServiceAccount{tests.BookstoreServiceAccount}, nil).Times(1)
+	mockKubeController.EXPECT().ListServiceIdentitiesForService(tests.BookbuyerService).Return([]identity.K8sServiceAccount{tests.BookbuyerServiceAccount}, nil).Times(1)
+
+	bookbuyer := tests.BookbuyerServiceIdentity.String()
+	bookstore := tests.BookstoreServiceIdentity.String()
+	client := identity.K8sServiceAccount{Name: "client", Namespace: tests.Namespace}.ToServiceIdentity().String()
+
+	expected := &trafficpolicy.MeshTopology{
+		Services: []trafficpolicy.TopologyService{
+			{Name: tests.BookbuyerService.String(), Identities: []string{bookbuyer}},
+			{Name: tests.BookstoreV1Service.String(), Identities: []string{bookstore}},
+		},
+		Edges: []trafficpolicy.TopologyEdge{
+			{Source: bookbuyer, Destination: tests.BookbuyerService.String()},
+			{Source: bookbuyer, Destination: tests.BookstoreV1Service.String()},
+			{Source: bookstore, Destination: tests.BookbuyerService.String()},
+			{Source: bookstore, Destination: tests.BookstoreV1Service.String()},
+			{Source: client, Destination: tests.BookbuyerService.String()},
+			{Source: client, Destination: tests.BookstoreV1Service.String()},
+		},
+	}
+
+	assert.Equal(expected, mc.GetMeshTopology())
+}
@@ -2,17 +2,20 @@ package catalog
 
 import (
 	"fmt"
+	"strings"
 
 	mapset "github.com/deckarep/golang-set"
 	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 const (
 	// serviceAccountKind is the kind specified for the destination and sources in an SMI TrafficTarget policy
-	serviceAccountKind = "ServiceAccount"
+	serviceAccountKind = identity.KubernetesServiceAccountKind
 
 	// tcpRouteKind is the kind specified for the TCP route rules in an SMI Traffictarget policy
 	tcpRouteKind = "TCPRoute"
@@ -42,7 +45,7 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.Ser
 		return nil, nil
 	}
 
-	for _, t := range mc.meshSpec.ListTrafficTargets() { // loop through all traffic targets
+	for _, t := range mc.listActiveTrafficTargets() { // loop through all traffic targets
 		if !isValidTrafficTarget(t) {
 			continue
 		}
@@ -63,7 +66,11 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.Ser
 		// Source identifies for this traffic target
 		var sourceIdentities []identity.ServiceIdentity
 		for _, source := range t.Spec.Sources {
-			srcIdentity := trafficTargetIdentityToServiceIdentity(source)
+			srcIdentity, err := mc.resolveTrafficTargetIdentity(source)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error resolving source %s of TrafficTarget %s/%s, ignoring it", source.Name, t.Namespace, t.Name)
+				continue
+			}
 			sourceIdentities = append(sourceIdentities, srcIdentity)
 		}
 		trafficTarget.Sources = sourceIdentities
@@ -86,7 +93,7 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 	svcAccount := svcIdentity.ToK8sServiceAccount()
 	allowed := mapset.NewSet()
 
-	allTrafficTargets := mc.meshSpec.ListTrafficTargets()
+	allTrafficTargets := mc.listActiveTrafficTargets()
 	for _, trafficTarget := range allTrafficTargets {
 		spec := trafficTarget.Spec
 
@@ -102,14 +109,15 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 				// This TrafficTarget has a destination that does not match the given service account, ignore it
 				continue
 			}
+			// Sources of kinds other than ServiceAccount, such as SPIFFE IDs, are resolved by their identity provider
 			for _, source := range spec.Sources {
-				if source.Kind != serviceAccountKind {
-					// Destination kind is not valid
-					log.Error().Msgf("Applied TrafficTarget policy %s has invalid Source kind: %s", trafficTarget.Name, spec.Destination.Kind)
+				srcIdentity, err := mc.resolveTrafficTargetIdentity(source)
+				if err != nil {
+					log.Error().Err(err).Msgf("Error resolving source %s of TrafficTarget %s/%s, ignoring it", source.Name, trafficTarget.Namespace, trafficTarget.Name)
 					continue
 				}
 
-				allowed.Add(trafficTargetIdentityToSvcAccount(source))
+				allowed.Add(srcIdentity)
 			}
 		}
 
@@ -117,8 +125,7 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 		if direction == outbound {
 			for _, source := range spec.Sources {
 				if source.Kind != serviceAccountKind {
-					// Destination kind is not valid
-					log.Error().Msgf("Applied TrafficTarget policy %s has invalid Source kind: %s", trafficTarget.Name, spec.Destination.Kind)
+					// Sources of other kinds, such as SPIFFE IDs, are not the identity of a proxy in the mesh
 					continue
 				}
 
@@ -127,14 +134,14 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 					continue
 				}
 
-				allowed.Add(trafficTargetIdentityToSvcAccount(spec.Destination))
+				allowed.Add(trafficTargetIdentityToSvcAccount(spec.Destination).ToServiceIdentity())
 			}
 		}
 	}
 
 	var allowedSvcIdentities []identity.ServiceIdentity
-	for svcAccount := range allowed.Iter() {
-		allowedSvcIdentities = append(allowedSvcIdentities, svcAccount.(identity.K8sServiceAccount).ToServiceIdentity())
+	for svcIdentity := range allowed.Iter() {
+		allowedSvcIdentities = append(allowedSvcIdentities, svcIdentity.(identity.ServiceIdentity))
 	}
 
 	return allowedSvcIdentities, nil
@@ -153,6 +160,44 @@ func trafficTargetIdentityToServiceIdentity(identitySubject smiAccess.IdentityBi
 	return identity.GetKubernetesServiceIdentity(svcAccount, identity.ClusterLocalTrustDomain)
 }
 
+// resolveTrafficTargetIdentity returns the service identity of the given TrafficTarget subject, resolved by the identity
+// provider registered for its kind
+func (mc *MeshCatalog) resolveTrafficTargetIdentity(identitySubject smiAccess.IdentityBindingSubject) (identity.ServiceIdentity, error) {
+	resolver := mc.identityResolver
+	if resolver == nil {
+		resolver = defaultIdentityResolver
+	}
+	return resolver.GetServiceIdentity(identitySubject.Kind, identitySubject.Name, identitySubject.Namespace)
+}
+
+// listTrafficTargetSourcePrincipals returns the service identities of the downstreams allowed by the given TrafficTarget sources,
+// those of the ServiceAccount sources being in the cluster local trust domain
+func (mc *MeshCatalog) listTrafficTargetSourcePrincipals(t *smiAccess.TrafficTarget) []identity.ServiceIdentity {
+	var svcAccountSources, otherSources []smiAccess.IdentityBindingSubject
+	for _, source := range t.Spec.Sources {
+		if source.Kind == serviceAccountKind {
+			svcAccountSources = append(svcAccountSources, source)
+		} else {
+			otherSources = append(otherSources, source)
+		}
+	}
+
+	var principals []identity.ServiceIdentity
+	for _, svcAccount := range trafficTargetIdentitiesToSvcAccounts(svcAccountSources) {
+		principals = append(principals, svcAccount.ToServiceIdentity())
+	}
+	for _, source := range otherSources {
+		srcIdentity, err := mc.resolveTrafficTargetIdentity(source)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error resolving source %s of TrafficTarget %s/%s, ignoring it", source.Name, t.Namespace, t.Name)
+			continue
+		}
+		principals = append(principals, srcIdentity)
+	}
+
+	return principals
+}
+
 // trafficTargetIdentitiesToSvcAccounts returns a list of Service Accounts from the given list of identities from a Traffic Target
 func trafficTargetIdentitiesToSvcAccounts(identities []smiAccess.IdentityBindingSubject) []identity.K8sServiceAccount {
 	serviceAccountsMap := map[identity.K8sServiceAccount]bool{}
@@ -172,6 +217,7 @@ func trafficTargetIdentitiesToSvcAccounts(identities []smiAccess.IdentityBinding
 
 func (mc *MeshCatalog) getTCPRouteMatchesFromTrafficTarget(trafficTarget smiAccess.TrafficTarget) ([]trafficpolicy.TCPRouteMatch, error) {
 	var matches []trafficpolicy.TCPRouteMatch
+	var unresolvedRoutes int
 
 	for _, rule := range trafficTarget.Spec.Rules {
 		if rule.Kind != tcpRouteKind {
@@ -186,15 +232,77 @@ func (mc *MeshCatalog) getTCPRouteMatchesFromTrafficTarget(trafficTarget smiAcce
 			return nil, ErrNoTrafficSpecFoundForTrafficPolicy
 		}
 
+		ports, ok := mc.getTCPRoutePorts(tcpRoute, trafficTargetIdentityToSvcAccount(trafficTarget.Spec.Destination))
+		if !ok {
+			// A TCPRoute without ports matches all ports, a route restricted to ports that cannot be resolved must not
+			log.Warn().Msgf("TCPRoute %s is restricted to ports that cannot be resolved, ignoring it", tcpRouteName)
+			unresolvedRoutes++
+			continue
+		}
+
 		tcpRouteMatch := trafficpolicy.TCPRouteMatch{
-			Ports: tcpRoute.Spec.Matches.Ports,
+			Ports: ports,
 		}
 		matches = append(matches, tcpRouteMatch)
 	}
 
+	if len(matches) == 0 && unresolvedRoutes > 0 {
+		return nil, ErrNoPortForTCPRoutes
+	}
+
 	return matches, nil
 }
 
+// getTCPRoutePorts returns the ports matched by the given TCPRoute. In addition to the ports in the spec of the TCPRoute,
+// port ranges and service port names can be specified using the 'openservicemesh.io/port-ranges' and
+// 'openservicemesh.io/named-ports' annotations. Port names are resolved to the target ports of the services
+// for the given destination service account. The returned boolean is false if the annotations restrict the TCPRoute to
+// ports of which none can be resolved, in which case the TCPRoute must not match any port.
+func (mc *MeshCatalog) getTCPRoutePorts(tcpRoute *smiSpecs.TCPRoute, destination identity.K8sServiceAccount) ([]int, bool) {
+	annotatedPorts, restricted := mc.getAnnotatedPorts(tcpRoute.Annotations, constants.TCPRoutePortRangesAnnotation, constants.TCPRouteNamedPortsAnnotation,
+		fmt.Sprintf("TCPRoute %s/%s", tcpRoute.Namespace, tcpRoute.Name), destination)
+	if !restricted {
+		return tcpRoute.Spec.Matches.Ports, true
+	}
+
+	ports := dedupAndSortPorts(append(append([]int(nil), tcpRoute.Spec.Matches.Ports...), annotatedPorts...))
+	return ports, len(ports) > 0
+}
+
+// getAnnotatedPorts returns the ports specified by the given port ranges and named ports annotations of the given route,
+// and a boolean indicating if any of the annotations is present. Port names are resolved to the target ports of the
+// services for the given destination service account.
+func (mc *MeshCatalog) getAnnotatedPorts(annotations map[string]string, portRangesKey, namedPortsKey, route string, destination identity.K8sServiceAccount) ([]int, bool) {
+	portRanges, hasPortRanges := annotations[portRangesKey]
+	namedPorts, hasNamedPorts := annotations[namedPortsKey]
+	if !hasPortRanges && !hasNamedPorts {
+		return nil, false
+	}
+
+	var ports []int
+
+	if hasPortRanges {
+		rangePorts, err := parsePortRanges(portRanges)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid annotation %s on %s, ignoring it", portRangesKey, route)
+		} else {
+			ports = append(ports, rangePorts...)
+		}
+	}
+
+	if hasNamedPorts {
+		var names []string
+		for _, name := range strings.Split(namedPorts, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		ports = append(ports, mc.resolveNamedPorts(names, destination)...)
+	}
+
+	return dedupAndSortPorts(ports), true
+}
+
 // isValidTrafficTarget checks if the given SMI TrafficTarget object is valid
 func isValidTrafficTarget(t *smiAccess.TrafficTarget) bool {
 	return t != nil && t.Spec.Rules != nil && len(t.Spec.Rules) > 0 && hasValidRulesKind(t.Spec.Rules)
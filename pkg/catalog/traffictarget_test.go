@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/smi"
@@ -24,7 +25,8 @@ func TestListAllowedInboundServiceIdentities(t *testing.T) {
 
 	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 	meshCatalog := MeshCatalog{
-		meshSpec: mockMeshSpec,
+		meshSpec:         mockMeshSpec,
+		identityResolver: identity.NewResolver(identity.NewSPIFFEProvider()),
 	}
 
 	testCases := []struct {
@@ -182,6 +184,63 @@ func TestListAllowedInboundServiceIdentities(t *testing.T) {
 			false, // will log an error but function will ignore policy with error
 		},
 		// Test case 3 end ------------------------------------
+
+		// Test case 4 begin ------------------------------------
+		// Sources of other kinds are resolved by their identity provider, unresolved sources are ignored
+		{
+			[]*smiAccess.TrafficTarget{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "access.smi-spec.io/v1alpha3",
+						Kind:       "TrafficTarget",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-1",
+						Namespace: "ns-2",
+					},
+					Spec: smiAccess.TrafficTargetSpec{
+						Destination: smiAccess.IdentityBindingSubject{
+							Kind:      "ServiceAccount",
+							Name:      "sa-2",
+							Namespace: "ns-2",
+						},
+						Sources: []smiAccess.IdentityBindingSubject{
+							{
+								Kind:      "ServiceAccount",
+								Name:      "sa-1",
+								Namespace: "ns-1",
+							},
+							{
+								Kind: "SPIFFEID",
+								Name: "spiffe://example.org/vm/billing",
+							},
+							{
+								Kind: "SPIFFEID",
+								Name: "not-a-spiffe-id",
+							},
+						},
+					},
+				},
+			},
+
+			// given service account to test
+			identity.K8sServiceAccount{
+				Name:      "sa-2",
+				Namespace: "ns-2",
+			}.ToServiceIdentity(),
+
+			// allowed inbound service identities: the service account and the SPIFFE ID
+			[]identity.ServiceIdentity{
+				identity.K8sServiceAccount{
+					Name:      "sa-1",
+					Namespace: "ns-1",
+				}.ToServiceIdentity(),
+				identity.ServiceIdentity("spiffe://example.org/vm/billing"),
+			},
+
+			false, // no errors expected
+		},
+		// Test case 4 end ------------------------------------
 	}
 
 	for i, tc := range testCases {
@@ -448,6 +507,36 @@ func TestTrafficTargetIdentitiesToSvcAccounts(t *testing.T) {
 	assert.ElementsMatch(expected, actual)
 }
 
+func TestListTrafficTargetSourcePrincipals(t *testing.T) {
+	assert := tassert.New(t)
+
+	trafficTarget := &smiAccess.TrafficTarget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-1",
+			Namespace: "ns-1",
+		},
+		Spec: smiAccess.TrafficTargetSpec{
+			Sources: []smiAccess.IdentityBindingSubject{
+				{Kind: "ServiceAccount", Name: "sa-2", Namespace: "ns-2"},
+				{Kind: "SPIFFEID", Name: "spiffe://example.org/vm/billing"},
+				{Kind: "SPIFFEID", Name: "not-a-spiffe-id"},
+			},
+		},
+	}
+
+	meshCatalog := MeshCatalog{identityResolver: identity.NewResolver(identity.NewSPIFFEProvider())}
+	assert.Equal([]identity.ServiceIdentity{
+		"sa-2.ns-2.cluster.local",
+		"spiffe://example.org/vm/billing",
+	}, meshCatalog.listTrafficTargetSourcePrincipals(trafficTarget))
+
+	// Without identity providers, only the service accounts are allowed
+	meshCatalog = MeshCatalog{}
+	assert.Equal([]identity.ServiceIdentity{
+		"sa-2.ns-2.cluster.local",
+	}, meshCatalog.listTrafficTargetSourcePrincipals(trafficTarget))
+}
+
 func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 	assert := tassert.New(t)
 	mockCtrl := gomock.NewController(t)
@@ -854,6 +943,138 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 			expectError: false, // no errors expected
 		},
 		// Test case 4 end ------------------------------------
+
+		// Test case 5 begin ------------------------------------
+		{
+			name: "Single traffic target with sources resolved by other identity providers",
+			trafficTargets: []*smiAccess.TrafficTarget{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "access.smi-spec.io/v1alpha3",
+						Kind:       "TrafficTarget",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-1",
+						Namespace: "ns-1",
+					},
+					Spec: smiAccess.TrafficTargetSpec{
+						Destination: smiAccess.IdentityBindingSubject{
+							Kind:      "ServiceAccount",
+							Name:      "sa-1",
+							Namespace: "ns-1",
+						},
+						Sources: []smiAccess.IdentityBindingSubject{
+							{
+								Kind:      "ServiceAccount",
+								Name:      "sa-2",
+								Namespace: "ns-2",
+							},
+							{
+								Kind: "SPIFFEID",
+								Name: "spiffe://example.org/vm/billing",
+							},
+							{
+								// No identity provider for this kind, ignored
+								Kind: "Group",
+								Name: "billing",
+							},
+						},
+						Rules: []smiAccess.TrafficTargetRule{
+							{
+								Kind: "TCPRoute",
+								Name: "route-1",
+							},
+						},
+					},
+				},
+			},
+
+			// Each route in this list corresponds to a TCPRoute
+			tcpRoutes: map[string]*smiSpecs.TCPRoute{
+				"ns-1/route-1": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "route-1",
+						Namespace: "ns-1",
+					},
+				},
+			},
+
+			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(),
+
+			expectedTrafficTargets: []trafficpolicy.TrafficTargetWithRoutes{
+				{
+					Name:        "ns-1/test-1",
+					Destination: identity.ServiceIdentity("sa-1.ns-1.cluster.local"),
+					Sources: []identity.ServiceIdentity{
+						identity.ServiceIdentity("sa-2.ns-2.cluster.local"),
+						identity.ServiceIdentity("spiffe://example.org/vm/billing"),
+					},
+					TCPRouteMatches: []trafficpolicy.TCPRouteMatch{
+						{
+							Ports: nil,
+						},
+					},
+				},
+			},
+
+			expectError: false, // no errors expected
+		},
+		// Test case 5 end ------------------------------------
+
+		// Test case 6 begin ------------------------------------
+		{
+			name: "Traffic target with a TCP route restricted to ports that cannot be resolved",
+			trafficTargets: []*smiAccess.TrafficTarget{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "access.smi-spec.io/v1alpha3",
+						Kind:       "TrafficTarget",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-1",
+						Namespace: "ns-1",
+					},
+					Spec: smiAccess.TrafficTargetSpec{
+						Destination: smiAccess.IdentityBindingSubject{
+							Kind:      "ServiceAccount",
+							Name:      "sa-1",
+							Namespace: "ns-1",
+						},
+						Sources: []smiAccess.IdentityBindingSubject{{
+							Kind:      "ServiceAccount",
+							Name:      "sa-2",
+							Namespace: "ns-2",
+						}},
+						Rules: []smiAccess.TrafficTargetRule{
+							{
+								Kind: "TCPRoute",
+								Name: "route-1",
+							},
+						},
+					},
+				},
+			},
+
+			// The port range of the TCPRoute is invalid and it has no ports in its spec, so it must not allow any port
+			tcpRoutes: map[string]*smiSpecs.TCPRoute{
+				"ns-1/route-1": {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "route-1",
+						Namespace: "ns-1",
+						Annotations: map[string]string{
+							constants.TCPRoutePortRangesAnnotation: "9000-8000",
+						},
+					},
+				},
+			},
+
+			upstreamServiceIdentity: identity.K8sServiceAccount{Namespace: "ns-1", Name: "sa-1"}.ToServiceIdentity(),
+
+			expectedTrafficTargets: nil,
+
+			expectError: false, // no errors expected
+		},
+		// Test case 6 end ------------------------------------
 	}
 
 	for i, tc := range testCases {
@@ -862,8 +1083,9 @@ func TestListInboundTrafficTargetsWithRoutes(t *testing.T) {
 			mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
 			mockCfg := configurator.NewMockConfigurator(mockCtrl)
 			meshCatalog := MeshCatalog{
-				meshSpec:     mockMeshSpec,
-				configurator: mockCfg,
+				meshSpec:         mockMeshSpec,
+				configurator:     mockCfg,
+				identityResolver: identity.NewResolver(identity.NewSPIFFEProvider()),
 			}
 
 			mockCfg.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
@@ -5,13 +5,19 @@
 package catalog
 
 import (
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
 	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/ingress"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
@@ -20,10 +26,14 @@ import (
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+	"github.com/openservicemesh/osm/pkg/validity"
 )
 
 var (
 	log = logger.New("mesh-catalog")
+
+	// defaultIdentityResolver resolves the subjects of SMI TrafficTarget policies for a MeshCatalog created without identity providers
+	defaultIdentityResolver = identity.NewResolver()
 )
 
 // MeshCatalog is the struct for the service catalog
@@ -34,6 +44,9 @@ type MeshCatalog struct {
 	ingressMonitor     ingress.Monitor
 	configurator       configurator.Configurator
 
+	// identityResolver resolves the subjects of SMI TrafficTarget policies into service identities
+	identityResolver *identity.Resolver
+
 	// Current assumption is that OSM is working with a single Kubernetes cluster.
 	// This is the API/REST interface to the cluster
 	kubeClient kubernetes.Interface
@@ -46,6 +59,15 @@ type MeshCatalog struct {
 	// policyController implements the functionality related to the resources part of the policy.openrservicemesh.io
 	// API group, such as egress.
 	policyController policy.Controller
+
+	// validityWindows caches the validity windows of the TrafficTargets and Egress policies
+	validityWindows *validity.Cache
+
+	// features are the optional features enabled for the catalog, the feature flags of the process are used when nil
+	features *featureflags.OptionalFeatures
+
+	// clock returns the time at which the validity windows of policies are evaluated, the current time when nil
+	clock func() time.Time
 }
 
 // MeshCataloger is the mechanism by which the Service Mesh controller discovers all Envoy proxies connected to the catalog.
@@ -80,9 +102,22 @@ type MeshCataloger interface {
 	// GetServicesForProxy returns a list of services the given Envoy is a member of based on its certificate, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
 	GetServicesForProxy(*envoy.Proxy) ([]service.MeshService, error)
 
+	// GetPermissiveTLSInboundPortsForProxy returns the ports on which the given proxy accepts plaintext traffic in addition to mTLS traffic
+	GetPermissiveTLSInboundPortsForProxy(*envoy.Proxy) (mapset.Set, error)
+
 	// GetIngressPoliciesForService returns the inbound traffic policies associated with an ingress service
 	GetIngressPoliciesForService(service.MeshService) ([]*trafficpolicy.InboundTrafficPolicy, error)
 
+	// GetIngressGatewayPolicies returns the outbound traffic policies programmed on the OSM managed ingress gateway
+	GetIngressGatewayPolicies() ([]*trafficpolicy.OutboundTrafficPolicy, error)
+
+	// GetIngressBackendTLSSecret returns the TLS Secret served by the sidecars of the given service to HTTPS ingress clients, if any
+	GetIngressBackendTLSSecret(service.MeshService) *corev1.Secret
+
+	// GetIngressBackendXFFSettings returns the handling of the x-forwarded-for header of the requests received by the
+	// sidecars of the given service from ingress
+	GetIngressBackendXFFSettings(service.MeshService) configurator.XFFSettings
+
 	// GetTargetPortToProtocolMappingForService returns a mapping of the service's ports to their corresponding application protocol.
 	// The ports returned are the actual ports on which the application exposes the service derived from the service's endpoints,
 	// ie. 'spec.ports[].targetPort' instead of 'spec.ports[].port' for a Kubernetes service.
@@ -93,6 +128,11 @@ type MeshCataloger interface {
 	// actually exposed by the application binary, ie. 'spec.ports[].port' instead of 'spec.ports[].targetPort' for a Kubernetes service.
 	GetPortToProtocolMappingForService(service.MeshService) (map[uint32]string, error)
 
+	// GetServicePortToTargetPortMappingForService returns a mapping of the service's ports used by downstream clients in their
+	// requests to the ports on which the application exposes them, ie. 'spec.ports[].port' to 'spec.ports[].targetPort' for a
+	// Kubernetes service.
+	GetServicePortToTargetPortMappingForService(service.MeshService) (map[uint32]uint32, error)
+
 	// ListInboundTrafficTargetsWithRoutes returns a list traffic target objects composed of its routes for the given destination service identity
 	ListInboundTrafficTargetsWithRoutes(identity.ServiceIdentity) ([]trafficpolicy.TrafficTargetWithRoutes, error)
 
@@ -104,6 +144,15 @@ type MeshCataloger interface {
 
 	// GetEgressTrafficPolicy returns the Egress traffic policy associated with the given service identity
 	GetEgressTrafficPolicy(identity.ServiceIdentity) (*trafficpolicy.EgressTrafficPolicy, error)
+
+	// GetEffectivePolicies returns the inbound, outbound, egress and ingress policies applied to the traffic of the given service identity
+	GetEffectivePolicies(identity.ServiceIdentity) (*trafficpolicy.EffectivePolicies, error)
+
+	// GetMeshTopology returns the communication graph of the mesh allowed by the traffic policies
+	GetMeshTopology() *trafficpolicy.MeshTopology
+
+	// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting applied to the traffic directed to the given upstream service
+	GetUpstreamTrafficSetting(service.MeshService) *policyV1alpha1.UpstreamTrafficSetting
 }
 
 // certificateCommonNameMeta is the type that stores the metadata present in the CommonName field in a proxy's certificate
@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"fmt"
+
+	mapset "github.com/deckarep/golang-set"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// validGRPCRetryOnConditions is the set of gRPC status codes on which requests can be retried
+var validGRPCRetryOnConditions = mapset.NewSetFromSlice([]interface{}{
+	"cancelled",
+	"deadline-exceeded",
+	"internal",
+	"resource-exhausted",
+	"unavailable",
+})
+
+// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting applied to the traffic directed to the given upstream service,
+// or nil if there is none
+func (mc *MeshCatalog) GetUpstreamTrafficSetting(upstream service.MeshService) *policyV1alpha1.UpstreamTrafficSetting {
+	return mc.policyController.GetUpstreamTrafficSetting(upstream)
+}
+
+// getGRPCRoutes returns the outbound routes directing the requests to the gRPC services and methods of the given
+// upstream service, as configured by its UpstreamTrafficSetting, to the given weighted clusters
+func (mc *MeshCatalog) getGRPCRoutes(upstream service.MeshService, weightedClusters ...service.WeightedCluster) []*trafficpolicy.RouteWeightedClusters {
+	upstreamTrafficSetting := mc.policyController.GetUpstreamTrafficSetting(upstream)
+	if upstreamTrafficSetting == nil {
+		return nil
+	}
+
+	var routes []*trafficpolicy.RouteWeightedClusters
+	for _, grpcRoute := range upstreamTrafficSetting.Spec.GRPCRoutes {
+		routeMatch := trafficpolicy.HTTPRouteMatch{
+			Methods: []string{constants.WildcardHTTPMethod},
+			GRPC:    true,
+		}
+		if grpcRoute.Method != "" {
+			routeMatch.Path = fmt.Sprintf("/%s/%s", grpcRoute.Service, grpcRoute.Method)
+			routeMatch.PathMatchType = trafficpolicy.PathMatchExact
+		} else {
+			routeMatch.Path = fmt.Sprintf("/%s/", grpcRoute.Service)
+			routeMatch.PathMatchType = trafficpolicy.PathMatchPrefix
+		}
+
+		route := trafficpolicy.NewRouteWeightedCluster(routeMatch, weightedClusters)
+		if grpcRoute.Retry != nil {
+			route.GRPCRetryPolicy = getGRPCRetryPolicy(grpcRoute.Retry, upstreamTrafficSetting)
+		}
+		routes = append(routes, route)
+	}
+
+	return routes
+}
+
+// getGRPCRetryPolicy returns the given retry policy without the conditions that are not valid gRPC status codes,
+// or nil if none of its conditions are valid
+func getGRPCRetryPolicy(retry *policyV1alpha1.GRPCRetryPolicySpec, upstreamTrafficSetting *policyV1alpha1.UpstreamTrafficSetting) *policyV1alpha1.GRPCRetryPolicySpec {
+	var retryOn []string
+	for _, condition := range retry.RetryOn {
+		if !validGRPCRetryOnConditions.Contains(condition) {
+			log.Error().Msgf("Ignoring invalid gRPC retry condition %q in UpstreamTrafficSetting %s/%s", condition, upstreamTrafficSetting.Namespace, upstreamTrafficSetting.Name)
+			continue
+		}
+		retryOn = append(retryOn, condition)
+	}
+	if len(retryOn) == 0 {
+		return nil
+	}
+
+	policy := retry.DeepCopy()
+	policy.RetryOn = retryOn
+	return policy
+}
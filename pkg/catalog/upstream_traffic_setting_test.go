@@ -0,0 +1,114 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetGRPCRoutes(t *testing.T) {
+	numRetries := uint32(3)
+	perTryTimeout := &metav1.Duration{Duration: time.Second}
+	weightedCluster := service.WeightedCluster{
+		ClusterName: service.ClusterName(tests.BookstoreV1Service.String()),
+		Weight:      constants.ClusterWeightAcceptAll,
+	}
+
+	testCases := []struct {
+		name           string
+		setting        *policyV1alpha1.UpstreamTrafficSetting
+		expectedRoutes []*trafficpolicy.RouteWeightedClusters
+	}{
+		{
+			name:           "no UpstreamTrafficSetting",
+			setting:        nil,
+			expectedRoutes: nil,
+		},
+		{
+			name: "UpstreamTrafficSetting without gRPC routes",
+			setting: &policyV1alpha1.UpstreamTrafficSetting{
+				Spec: policyV1alpha1.UpstreamTrafficSettingSpec{Host: tests.BookstoreV1Service.ServerName()},
+			},
+			expectedRoutes: nil,
+		},
+		{
+			name: "gRPC routes for a service and a method",
+			setting: &policyV1alpha1.UpstreamTrafficSetting{
+				ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: tests.BookstoreV1Service.Namespace},
+				Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+					Host: tests.BookstoreV1Service.ServerName(),
+					GRPCRoutes: []policyV1alpha1.GRPCRouteSpec{
+						{
+							Service: "bookstore.v1.Bookstore",
+							Method:  "Buy",
+							Retry: &policyV1alpha1.GRPCRetryPolicySpec{
+								RetryOn:       []string{"unavailable", "not-a-status-code", "resource-exhausted"},
+								NumRetries:    &numRetries,
+								PerTryTimeout: perTryTimeout,
+							},
+						},
+						{
+							Service: "bookstore.v1.Inventory",
+							Retry: &policyV1alpha1.GRPCRetryPolicySpec{
+								RetryOn: []string{"not-a-status-code"},
+							},
+						},
+					},
+				},
+			},
+			expectedRoutes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+						Path:          "/bookstore.v1.Bookstore/Buy",
+						PathMatchType: trafficpolicy.PathMatchExact,
+						Methods:       []string{constants.WildcardHTTPMethod},
+						GRPC:          true,
+					},
+					WeightedClusters: mapset.NewSet(weightedCluster),
+					GRPCRetryPolicy: &policyV1alpha1.GRPCRetryPolicySpec{
+						RetryOn:       []string{"unavailable", "resource-exhausted"},
+						NumRetries:    &numRetries,
+						PerTryTimeout: perTryTimeout,
+					},
+				},
+				{
+					HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+						Path:          "/bookstore.v1.Inventory/",
+						PathMatchType: trafficpolicy.PathMatchPrefix,
+						Methods:       []string{constants.WildcardHTTPMethod},
+						GRPC:          true,
+					},
+					WeightedClusters: mapset.NewSet(weightedCluster),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockPolicyController := policy.NewMockController(mockCtrl)
+			mockPolicyController.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV1Service).Return(tc.setting).Times(1)
+
+			mc := MeshCatalog{
+				policyController: mockPolicyController,
+			}
+
+			assert.Equal(tc.expectedRoutes, mc.getGRPCRoutes(tests.BookstoreV1Service, weightedCluster))
+		})
+	}
+}
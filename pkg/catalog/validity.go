@@ -0,0 +1,151 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/validity"
+)
+
+// policyValidityCheckInterval is the interval at which the validity windows of policies are evaluated, which is the
+// granularity of their schedules
+const policyValidityCheckInterval = time.Minute
+
+// listActiveTrafficTargets returns the TrafficTargets whose validity window is active at the current time, sorted by
+// namespace and name. The rules derived from the TrafficTargets follow their order, which must not depend on the order
+// of the informer cache for the same policies to always result in the same routes.
+func (mc *MeshCatalog) listActiveTrafficTargets() []*access.TrafficTarget {
+	now := mc.now()
+	var trafficTargets []*access.TrafficTarget
+	for _, t := range mc.meshSpec.ListTrafficTargets() {
+		if mc.isTrafficTargetActive(t, now) {
+			trafficTargets = append(trafficTargets, t)
+		}
+	}
+	sort.SliceStable(trafficTargets, func(i, j int) bool {
+		if trafficTargets[i].Namespace != trafficTargets[j].Namespace {
+			return trafficTargets[i].Namespace < trafficTargets[j].Namespace
+		}
+		return trafficTargets[i].Name < trafficTargets[j].Name
+	})
+	return trafficTargets
+}
+
+// now returns the time at which the validity windows of policies are evaluated
+func (mc *MeshCatalog) now() time.Time {
+	if mc.clock == nil {
+		return time.Now()
+	}
+	return mc.clock()
+}
+
+// isTrafficTargetActive returns a boolean indicating if the validity window of the given TrafficTarget, specified by its
+// annotations, is active at the given time. A TrafficTarget with an invalid validity window is not active.
+func (mc *MeshCatalog) isTrafficTargetActive(t *access.TrafficTarget, now time.Time) bool {
+	key := fmt.Sprintf("TrafficTarget/%s/%s", t.Namespace, t.Name)
+	window, err := mc.validityWindows.Get(key, t.ResourceVersion, func() (*validity.Window, error) {
+		return validity.FromAnnotations(t.Annotations)
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid validity window for TrafficTarget %s/%s; will be skipped", t.Namespace, t.Name)
+		return false
+	}
+	return window.IsActive(now)
+}
+
+// isEgressActive returns a boolean indicating if the validity window of the given Egress policy is active at the given
+// time. An Egress policy with an invalid validity window is not active.
+func (mc *MeshCatalog) isEgressActive(egress *policyV1alpha1.Egress, now time.Time) bool {
+	if egress.Spec.Validity == nil {
+		return true
+	}
+
+	key := fmt.Sprintf("Egress/%s/%s", egress.Namespace, egress.Name)
+	window, err := mc.validityWindows.Get(key, egress.ResourceVersion, func() (*validity.Window, error) {
+		return getEgressWindow(egress.Spec.Validity)
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid validity window for egress policy %s/%s; will be skipped", egress.Namespace, egress.Name)
+		return false
+	}
+	return window.IsActive(now)
+}
+
+// getEgressWindow returns the validity window specified by the given validity of an Egress policy
+func getEgressWindow(spec *policyV1alpha1.ValiditySpec) (*validity.Window, error) {
+	var notBefore, notAfter *time.Time
+	if spec.NotBefore != nil {
+		notBefore = &spec.NotBefore.Time
+	}
+	if spec.NotAfter != nil {
+		notAfter = &spec.NotAfter.Time
+	}
+	var duration time.Duration
+	if spec.Duration != nil {
+		duration = spec.Duration.Duration
+	}
+
+	return validity.NewWindow(notBefore, notAfter, spec.Schedule, duration)
+}
+
+// getInactivePolicies returns the keys of the TrafficTargets and Egress policies whose validity window is not active at
+// the given time
+func (mc *MeshCatalog) getInactivePolicies(now time.Time) map[string]bool {
+	inactive := make(map[string]bool)
+	for _, t := range mc.meshSpec.ListTrafficTargets() {
+		if !mc.isTrafficTargetActive(t, now) {
+			inactive[fmt.Sprintf("TrafficTarget/%s/%s", t.Namespace, t.Name)] = true
+		}
+	}
+	for _, egress := range mc.policyController.ListEgressPolicies() {
+		if !mc.isEgressActive(egress, now) {
+			inactive[fmt.Sprintf("Egress/%s/%s", egress.Namespace, egress.Name)] = true
+		}
+	}
+	return inactive
+}
+
+// watchPolicyValidity requests a global proxy update when the validity window of a policy opens or closes, since
+// no event is emitted for the policy at that time. Windows only depend on time, so the policies inactive at the time
+// of the previous check are evaluated again rather than recorded, as policy changes emit events of their own.
+func (mc *MeshCatalog) watchPolicyValidity(stop <-chan struct{}) {
+	ticker := time.NewTicker(policyValidityCheckInterval)
+	defer ticker.Stop()
+
+	lastCheck := time.Now()
+	for {
+		select {
+		case now := <-ticker.C:
+			if !equalKeys(mc.getInactivePolicies(lastCheck), mc.getInactivePolicies(now)) {
+				log.Info().Msgf("Validity window of policies opened or closed, requesting broadcast proxy update")
+				events.GetPubSubInstance().Publish(events.PubSubMessage{
+					AnnouncementType: announcements.ScheduleProxyBroadcast,
+				})
+			}
+			lastCheck = now
+
+			// All the policies were evaluated, the cached windows of the policies that were not are no longer needed
+			mc.validityWindows.Sweep()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func equalKeys(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if !b[key] {
+			return false
+		}
+	}
+	return true
+}
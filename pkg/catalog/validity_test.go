@@ -0,0 +1,141 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+func TestIsEgressActive(t *testing.T) {
+	notBefore := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		validity *policyV1alpha1.ValiditySpec
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "no validity window",
+			validity: nil,
+			now:      notBefore,
+			expected: true,
+		},
+		{
+			name: "within start and expiry times",
+			validity: &policyV1alpha1.ValiditySpec{
+				NotBefore: &metav1.Time{Time: notBefore},
+				NotAfter:  &metav1.Time{Time: notAfter},
+			},
+			now:      notBefore.Add(time.Hour),
+			expected: true,
+		},
+		{
+			name: "expired",
+			validity: &policyV1alpha1.ValiditySpec{
+				NotAfter: &metav1.Time{Time: notAfter},
+			},
+			now:      notAfter.Add(time.Hour),
+			expected: false,
+		},
+		{
+			name: "within a scheduled period",
+			validity: &policyV1alpha1.ValiditySpec{
+				Schedule: "0 2 * * 0",
+				Duration: &metav1.Duration{Duration: 2 * time.Hour},
+			},
+			now:      time.Date(2021, 6, 6, 3, 30, 0, 0, time.UTC), // Sunday
+			expected: true,
+		},
+		{
+			name: "outside a scheduled period",
+			validity: &policyV1alpha1.ValiditySpec{
+				Schedule: "0 2 * * 0",
+				Duration: &metav1.Duration{Duration: 2 * time.Hour},
+			},
+			now:      time.Date(2021, 6, 6, 4, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name: "invalid schedule",
+			validity: &policyV1alpha1.ValiditySpec{
+				Schedule: "0 2 * *",
+				Duration: &metav1.Duration{Duration: 2 * time.Hour},
+			},
+			now:      notBefore,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			egress := &policyV1alpha1.Egress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "egress"},
+				Spec:       policyV1alpha1.EgressSpec{Validity: tc.validity},
+			}
+			mc := &MeshCatalog{}
+			assert.Equal(tc.expected, mc.isEgressActive(egress, tc.now))
+		})
+	}
+}
+
+func TestGetInactivePolicies(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	notAfter := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mockPolicyController := policy.NewMockController(mockCtrl)
+	mc := &MeshCatalog{
+		meshSpec:         mockMeshSpec,
+		policyController: mockPolicyController,
+	}
+
+	mockMeshSpec.EXPECT().ListTrafficTargets().Return([]*smiAccess.TrafficTarget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "unbounded"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "test",
+				Name:        "vendor-access",
+				Annotations: map[string]string{constants.PolicyNotAfterAnnotation: notAfter.Format(time.RFC3339)},
+			},
+		},
+	}).AnyTimes()
+	mockPolicyController.EXPECT().ListEgressPolicies().Return([]*policyV1alpha1.Egress{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "maintenance"},
+			Spec: policyV1alpha1.EgressSpec{
+				Validity: &policyV1alpha1.ValiditySpec{
+					Schedule: "0 2 * * 0",
+					Duration: &metav1.Duration{Duration: 2 * time.Hour},
+				},
+			},
+		},
+	}).AnyTimes()
+
+	// Saturday before the expiry time
+	assert.Equal(map[string]bool{
+		"Egress/test/maintenance": true,
+	}, mc.getInactivePolicies(time.Date(2021, 6, 5, 12, 0, 0, 0, time.UTC)))
+
+	// Sunday at 2AM after the expiry time
+	assert.Equal(map[string]bool{
+		"TrafficTarget/test/vendor-access": true,
+	}, mc.getInactivePolicies(time.Date(2021, 7, 4, 2, 0, 0, 0, time.UTC)))
+}
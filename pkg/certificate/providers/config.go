@@ -160,12 +160,6 @@ func (c *Config) GetCertificateManager() (certificate.Manager, debugger.Certific
 func GetCertificateFromSecret(ns string, secretName string, cert certificate.Certificater, kubeClient kubernetes.Interface) (certificate.Certificater, error) {
 	// Attempt to create it in Kubernetes. When multiple agents attempt to create, only one of them will succeed.
 	// All others will get "AlreadyExists" error back.
-	secretData := map[string][]byte{
-		constants.KubernetesOpaqueSecretCAKey:             cert.GetCertificateChain(),
-		constants.KubernetesOpaqueSecretCAExpiration:      []byte(cert.GetExpiration().Format(constants.TimeDateLayout)),
-		constants.KubernetesOpaqueSecretRootPrivateKeyKey: cert.GetPrivateKey(),
-	}
-
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      secretName,
@@ -175,7 +169,7 @@ func GetCertificateFromSecret(ns string, secretName string, cert certificate.Cer
 				constants.OSMAppVersionLabelKey: version.Version,
 			},
 		},
-		Data: secretData,
+		Data: getCertificateSecretData(cert),
 	}
 
 	if _, err := kubeClient.CoreV1().Secrets(ns).Create(context.TODO(), secret, metav1.CreateOptions{}); err == nil {
@@ -198,6 +192,48 @@ func GetCertificateFromSecret(ns string, secretName string, cert certificate.Cer
 	return cert, nil
 }
 
+// RenewCertificateInSecret stores the given renewed certificate in the given Kubernetes secret storing the given current
+// certificate shared by multiple instances. When another instance already renewed the certificate stored in the secret,
+// the certificate it stored is returned instead, so that all the instances end up with the same renewed certificate.
+func RenewCertificateInSecret(ns string, secretName string, renewed certificate.Certificater, current certificate.Certificater, kubeClient kubernetes.Interface) (certificate.Certificater, error) {
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting certificate secret %s/%s", ns, secretName)
+		return nil, err
+	}
+
+	stored, err := getCertFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	if stored.GetExpiration().After(current.GetExpiration()) {
+		log.Info().Msgf("Certificate in secret %s/%s already renewed, loading.", ns, secretName)
+		return stored, nil
+	}
+
+	secret.Data = getCertificateSecretData(renewed)
+	if _, err := kubeClient.CoreV1().Secrets(ns).Update(context.Background(), secret, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			// Another instance renewed the certificate concurrently
+			log.Info().Msgf("Certificate in secret %s/%s renewed concurrently, loading.", ns, secretName)
+			return GetCertFromKubernetes(ns, secretName, kubeClient)
+		}
+		log.Error().Err(err).Msgf("Error updating certificate secret %s/%s", ns, secretName)
+		return nil, err
+	}
+
+	return renewed, nil
+}
+
+// getCertificateSecretData returns the data of the Kubernetes secret storing the given certificate
+func getCertificateSecretData(cert certificate.Certificater) map[string][]byte {
+	return map[string][]byte{
+		constants.KubernetesOpaqueSecretCAKey:             cert.GetCertificateChain(),
+		constants.KubernetesOpaqueSecretCAExpiration:      []byte(cert.GetExpiration().Format(constants.TimeDateLayout)),
+		constants.KubernetesOpaqueSecretRootPrivateKeyKey: cert.GetPrivateKey(),
+	}
+}
+
 // getTresorOSMCertificateManager returns a certificate manager instance with Tresor as the certificate provider
 func (c *Config) getTresorOSMCertificateManager() (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	var err error
@@ -244,6 +280,13 @@ func GetCertFromKubernetes(ns string, secretName string, kubeClient kubernetes.I
 		return nil, errSecretNotFound
 	}
 
+	return getCertFromSecret(certSecret)
+}
+
+// getCertFromSecret loads the certificate stored in the given Kubernetes secret
+func getCertFromSecret(certSecret *corev1.Secret) (certificate.Certificater, error) {
+	ns, secretName := certSecret.Namespace, certSecret.Name
+
 	pemCert, ok := certSecret.Data[constants.KubernetesOpaqueSecretCAKey]
 	if !ok {
 		log.Error().Err(errInvalidCertSecret).Msgf("Opaque k8s secret %s/%s does not have required field %q", ns, secretName, constants.KubernetesOpaqueSecretCAKey)
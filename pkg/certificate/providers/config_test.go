@@ -95,6 +95,38 @@ func TestSynchronizeCertificate(t *testing.T) {
 	}
 }
 
+func TestRenewCertificateInSecret(t *testing.T) {
+	assert := tassert.New(t)
+	kubeClient := fake.NewSimpleClientset()
+
+	// Create some certs, using tresor's api for simplicity
+	current, err := tresor.NewCA("common-name", time.Hour, "test-country", "test-locality", "test-org")
+	assert.NoError(err)
+	renewed, err := tresor.NewCA("common-name", 2*time.Hour, "test-country", "test-locality", "test-org")
+	assert.NoError(err)
+	renewedByOtherInstance, err := tresor.NewCA("common-name", 2*time.Hour, "test-country", "test-locality", "test-org")
+	assert.NoError(err)
+
+	_, err = RenewCertificateInSecret("test", "test", renewed, current, kubeClient)
+	assert.Error(err)
+
+	_, err = GetCertificateFromSecret("test", "test", current, kubeClient)
+	assert.NoError(err)
+
+	// The renewed certificate is stored in the secret
+	resCert, err := RenewCertificateInSecret("test", "test", renewed, current, kubeClient)
+	assert.NoError(err)
+	assert.Equal(renewed.GetCertificateChain(), resCert.GetCertificateChain())
+	storedCert, err := GetCertFromKubernetes("test", "test", kubeClient)
+	assert.NoError(err)
+	assert.Equal(renewed.GetCertificateChain(), storedCert.GetCertificateChain())
+
+	// The certificate already renewed by another instance is loaded
+	resCert, err = RenewCertificateInSecret("test", "test", renewedByOtherInstance, current, kubeClient)
+	assert.NoError(err)
+	assert.Equal(renewed.GetCertificateChain(), resCert.GetCertificateChain())
+}
+
 func TestGetCertificateFromKubernetes(t *testing.T) {
 	assert := tassert.New(t)
 
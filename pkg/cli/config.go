@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	osmConfigEnvVar = "OSM_CONFIG"
+
+	// configDirName is the name of the directory of the config file under the user's config directory
+	configDirName = "osm"
+
+	// configFileName is the name of the config file
+	configFileName = "config.yaml"
+)
+
+// Config is the configuration of the OSM cli persisted across invocations. It holds the mesh contexts, the meshes
+// targeted by the commands when they are not specified with flags or environment variables, per kubeconfig context
+// so that switching clusters does not target a mesh of another cluster.
+type Config struct {
+	// MeshContexts are the mesh contexts, keyed by the name of the kubeconfig context they apply to
+	MeshContexts map[string]MeshContext `yaml:"meshContexts,omitempty"`
+}
+
+// MeshContext is the mesh targeted by the commands run against the cluster of a kubeconfig context
+type MeshContext struct {
+	// MeshName is the name of the mesh targeted by the commands
+	MeshName string `yaml:"meshName,omitempty"`
+
+	// OSMNamespace is the namespace of the control plane of the mesh targeted by the commands
+	OSMNamespace string `yaml:"osmNamespace,omitempty"`
+}
+
+// GetMeshContext returns the mesh context of the given kubeconfig context, which is empty if it is not set
+func (c *Config) GetMeshContext(kubeContext string) MeshContext {
+	return c.MeshContexts[kubeContext]
+}
+
+// SetMeshContext sets the mesh context of the given kubeconfig context
+func (c *Config) SetMeshContext(kubeContext string, meshContext MeshContext) {
+	if c.MeshContexts == nil {
+		c.MeshContexts = make(map[string]MeshContext)
+	}
+	c.MeshContexts[kubeContext] = meshContext
+}
+
+// defaultConfigPath returns the path of the config file under the user's config directory, or a path relative to the
+// working directory if the user's config directory cannot be determined
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join("."+configDirName, configFileName)
+	}
+	return filepath.Join(dir, configDirName, configFileName)
+}
+
+// LoadConfig loads the config from the file at the given path. An empty config is returned if the file does not exist.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+
+	data, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, errors.Errorf("Error reading config file %s: %s", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, errors.Errorf("Error parsing config file %s: %s", path, err)
+	}
+	return config, nil
+}
+
+// Save writes the config to the file at the given path, creating its directory if needed
+func (c *Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return errors.Errorf("Error marshaling config: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Errorf("Error creating the directory of config file %s: %s", path, err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return errors.Errorf("Error writing config file %s: %s", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	dir, err := ioutil.TempDir("", "osm-cli")
+	trequire.Nil(t, err)
+	defer os.RemoveAll(dir) //nolint: errcheck,gosec
+	path := filepath.Join(dir, configDirName, configFileName)
+
+	// A missing config file is an empty config
+	config, err := LoadConfig(path)
+	assert.Nil(err)
+	assert.Equal(&Config{}, config)
+
+	expected := &Config{}
+	expected.SetMeshContext("cluster-1", MeshContext{MeshName: "osm-edge", OSMNamespace: "osm-edge-system"})
+	expected.SetMeshContext("cluster-2", MeshContext{MeshName: "osm", OSMNamespace: "osm-system"})
+	assert.Nil(expected.Save(path))
+	config, err = LoadConfig(path)
+	assert.Nil(err)
+	assert.Equal(expected, config)
+	assert.Equal(MeshContext{MeshName: "osm-edge", OSMNamespace: "osm-edge-system"}, config.GetMeshContext("cluster-1"))
+	assert.Equal(MeshContext{}, config.GetMeshContext("cluster-3"))
+
+	info, err := os.Stat(path)
+	trequire.Nil(t, err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	assert.Nil(ioutil.WriteFile(path, []byte("meshName: [invalid"), 0600))
+	_, err = LoadConfig(path)
+	assert.NotNil(err)
+}
@@ -52,27 +52,56 @@ import (
 const (
 	defaultOSMNamespace = "osm-system"
 	osmNamespaceEnvVar  = "OSM_NAMESPACE"
+
+	defaultMeshName = "osm"
+	meshNameEnvVar  = "OSM_MESH_NAME"
 )
 
 // EnvSettings describes all of the cli environment settings
 type EnvSettings struct {
-	namespace string
-	config    *genericclioptions.ConfigFlags
+	namespace   string
+	meshName    string
+	configPath  string
+	kubeContext string
+	config      *genericclioptions.ConfigFlags
 }
 
-// New relevant environment variables set and returns EnvSettings
+// New relevant environment variables set and returns EnvSettings.
+// Settings that are not set by environment variables default to the mesh context persisted in the config file
+// for the current kubeconfig context.
 func New() *EnvSettings {
 	env := &EnvSettings{
-		namespace: envOr(osmNamespaceEnvVar, defaultOSMNamespace),
+		configPath: envOr(osmConfigEnvVar, defaultConfigPath()),
 	}
 
 	// bind to kubernetes config flags
 	env.config = &genericclioptions.ConfigFlags{
 		Namespace: &env.namespace,
 	}
+	env.kubeContext = currentKubeContext(env.config)
+
+	persisted, err := LoadConfig(env.configPath)
+	if err != nil {
+		// An invalid config file is overwritten by the next mesh context set, so it is ignored
+		persisted = &Config{}
+	}
+	meshContext := persisted.GetMeshContext(env.kubeContext)
+
+	env.namespace = envOr(osmNamespaceEnvVar, stringOr(meshContext.OSMNamespace, defaultOSMNamespace))
+	env.meshName = envOr(meshNameEnvVar, stringOr(meshContext.MeshName, defaultMeshName))
 	return env
 }
 
+// currentKubeContext returns the name of the current context of the kubeconfig loaded by the given config flags, or
+// an empty string if the kubeconfig cannot be loaded, ex. when running in a pod
+func currentKubeContext(config *genericclioptions.ConfigFlags) string {
+	rawConfig, err := config.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return ""
+	}
+	return rawConfig.CurrentContext
+}
+
 func envOr(name, defaultVal string) string {
 	if v, ok := os.LookupEnv(name); ok {
 		return v
@@ -80,6 +109,13 @@ func envOr(name, defaultVal string) string {
 	return defaultVal
 }
 
+func stringOr(val, defaultVal string) string {
+	if val != "" {
+		return val
+	}
+	return defaultVal
+}
+
 // AddFlags binds flags to the given flagset.
 func (s *EnvSettings) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.namespace, "osm-namespace", s.namespace, "namespace for osm control plane")
@@ -89,6 +125,8 @@ func (s *EnvSettings) AddFlags(fs *pflag.FlagSet) {
 func (s *EnvSettings) EnvVars() map[string]string {
 	return map[string]string{
 		osmNamespaceEnvVar: s.Namespace(),
+		meshNameEnvVar:     s.MeshName(),
+		osmConfigEnvVar:    s.ConfigPath(),
 	}
 }
 
@@ -104,3 +142,18 @@ func (s *EnvSettings) Namespace() string {
 	}
 	return "default"
 }
+
+// MeshName gets the name of the mesh targeted by the commands
+func (s *EnvSettings) MeshName() string {
+	return s.meshName
+}
+
+// ConfigPath gets the path of the config file persisting the mesh contexts
+func (s *EnvSettings) ConfigPath() string {
+	return s.configPath
+}
+
+// KubeContext gets the name of the current kubeconfig context, which the mesh context applies to
+func (s *EnvSettings) KubeContext() string {
+	return s.kubeContext
+}
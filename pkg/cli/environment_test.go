@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/pflag"
@@ -13,27 +15,33 @@ func TestNew(t *testing.T) {
 		name              string
 		args              []string
 		envVars           map[string]string
+		persisted         *Config
 		expectedNamespace string
+		expectedMeshName  string
 	}{
 		{
 			name:              "default",
 			args:              nil,
 			envVars:           nil,
 			expectedNamespace: defaultOSMNamespace,
+			expectedMeshName:  defaultMeshName,
 		},
 		{
 			name:              "flag overrides default",
 			args:              []string{"--osm-namespace=osm-ns"},
 			envVars:           nil,
 			expectedNamespace: "osm-ns",
+			expectedMeshName:  defaultMeshName,
 		},
 		{
 			name: "env var overrides default",
 			args: nil,
 			envVars: map[string]string{
 				osmNamespaceEnvVar: "osm-env",
+				meshNameEnvVar:     "mesh-env",
 			},
 			expectedNamespace: "osm-env",
+			expectedMeshName:  "mesh-env",
 		},
 		{
 			name: "flag overrides env var",
@@ -42,6 +50,42 @@ func TestNew(t *testing.T) {
 				osmNamespaceEnvVar: "osm-env",
 			},
 			expectedNamespace: "osm-ns",
+			expectedMeshName:  defaultMeshName,
+		},
+		{
+			name:              "persisted mesh context overrides default",
+			args:              nil,
+			envVars:           nil,
+			persisted:         &Config{MeshContexts: map[string]MeshContext{"test-context": {MeshName: "mesh-ctx", OSMNamespace: "osm-ctx"}}},
+			expectedNamespace: "osm-ctx",
+			expectedMeshName:  "mesh-ctx",
+		},
+		{
+			name:              "persisted mesh context of another kubeconfig context ignored",
+			args:              nil,
+			envVars:           nil,
+			persisted:         &Config{MeshContexts: map[string]MeshContext{"other-context": {MeshName: "mesh-ctx", OSMNamespace: "osm-ctx"}}},
+			expectedNamespace: defaultOSMNamespace,
+			expectedMeshName:  defaultMeshName,
+		},
+		{
+			name: "env var overrides persisted mesh context",
+			args: nil,
+			envVars: map[string]string{
+				osmNamespaceEnvVar: "osm-env",
+				meshNameEnvVar:     "mesh-env",
+			},
+			persisted:         &Config{MeshContexts: map[string]MeshContext{"test-context": {MeshName: "mesh-ctx", OSMNamespace: "osm-ctx"}}},
+			expectedNamespace: "osm-env",
+			expectedMeshName:  "mesh-env",
+		},
+		{
+			name:              "flag overrides persisted mesh context",
+			args:              []string{"--osm-namespace=osm-ns"},
+			envVars:           nil,
+			persisted:         &Config{MeshContexts: map[string]MeshContext{"test-context": {MeshName: "mesh-ctx", OSMNamespace: "osm-ctx"}}},
+			expectedNamespace: "osm-ns",
+			expectedMeshName:  "mesh-ctx",
 		},
 	}
 
@@ -51,6 +95,23 @@ func TestNew(t *testing.T) {
 
 			flags := pflag.NewFlagSet("test-new", pflag.ContinueOnError)
 
+			dir, err := ioutil.TempDir("", "osm-cli")
+			assert.Nil(err)
+			defer os.RemoveAll(dir) //nolint: errcheck,gosec
+			configPath := filepath.Join(dir, configFileName)
+			if test.persisted != nil {
+				assert.Nil(test.persisted.Save(configPath))
+			}
+			if test.envVars == nil {
+				test.envVars = make(map[string]string)
+			}
+			test.envVars[osmConfigEnvVar] = configPath
+
+			// The mesh context applies to the current context of the kubeconfig
+			kubeConfigPath := filepath.Join(dir, "kubeconfig")
+			assert.Nil(ioutil.WriteFile(kubeConfigPath, []byte(testKubeConfig), 0600))
+			test.envVars["KUBECONFIG"] = kubeConfigPath
+
 			for k, v := range test.envVars {
 				oldv, found := os.LookupEnv(k)
 				defer func(k string, oldv string, found bool) {
@@ -68,13 +129,38 @@ func TestNew(t *testing.T) {
 
 			settings := New()
 			settings.AddFlags(flags)
-			err := flags.Parse(test.args)
+			err = flags.Parse(test.args)
 			assert.Nil(err)
 			assert.Equal(settings.Namespace(), test.expectedNamespace)
+			assert.Equal(settings.MeshName(), test.expectedMeshName)
+			assert.Equal(settings.ConfigPath(), configPath)
+			assert.Equal("test-context", settings.KubeContext())
 		})
 	}
 }
 
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test-cluster
+  cluster:
+    server: https://localhost:6443
+contexts:
+- name: test-context
+  context:
+    cluster: test-cluster
+    user: test-user
+- name: other-context
+  context:
+    cluster: test-cluster
+    user: test-user
+current-context: test-context
+users:
+- name: test-user
+  user:
+    token: test-token
+`
+
 func TestNamespaceErr(t *testing.T) {
 	env := New()
 
@@ -90,7 +176,14 @@ func TestNamespaceErr(t *testing.T) {
 
 func TestEnvVars(t *testing.T) {
 	env := New()
-	tassert.Equal(t, map[string]string{"OSM_NAMESPACE": "osm-system"}, env.EnvVars())
+	env.meshName = defaultMeshName
+	env.namespace = defaultOSMNamespace
+	env.configPath = "/home/osm/.config/osm/config.yaml"
+	tassert.Equal(t, map[string]string{
+		"OSM_NAMESPACE": "osm-system",
+		"OSM_MESH_NAME": "osm",
+		"OSM_CONFIG":    "/home/osm/.config/osm/config.yaml",
+	}, env.EnvVars())
 }
 
 func TestRESTClientGetter(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 )
@@ -35,6 +36,9 @@ const (
 	// maxDataPlaneConnectionsKey is the key name used for max data plane connections in the ConfigMap
 	maxDataPlaneConnectionsKey = "max_data_plane_connections"
 
+	// maxConcurrentXDSPushesKey is the key name used for the max number of concurrent xDS pushes in the ConfigMap
+	maxConcurrentXDSPushesKey = "max_concurrent_xds_pushes"
+
 	// tracingEnableKey is the key name used for tracing in the ConfigMap
 	tracingEnableKey = "tracing_enable"
 
@@ -56,6 +60,15 @@ const (
 	// initContainerImage is the key name used to specify the init container image in the ConfigMap
 	initContainerImage = "init_container_image"
 
+	// envoyArchImagesKey is the key name used to specify the images of the Envoy proxy for specific node architectures in the ConfigMap
+	envoyArchImagesKey = "envoy_arch_images"
+
+	// initContainerArchImagesKey is the key name used to specify the init container images for specific node architectures in the ConfigMap
+	initContainerArchImagesKey = "init_container_arch_images"
+
+	// imageRegistryOverrideKey is the key name used to specify the registry replacing the registry of the images injected into pods in the ConfigMap
+	imageRegistryOverrideKey = "image_registry_override"
+
 	// serviceCertValidityDurationKey is the key name used to specify the validity duration of service certificates in the ConfigMap
 	serviceCertValidityDurationKey = "service_cert_validity_duration"
 
@@ -65,11 +78,68 @@ const (
 	// outboundPortExclusionListKey is the key name used to specify the ports to exclude from outbound sidecar interception
 	outboundPortExclusionListKey = "outbound_port_exclusion_list"
 
+	// outboundWellKnownExclusionListKey is the key name used to specify the well-known destinations to exclude from outbound sidecar interception
+	outboundWellKnownExclusionListKey = "outbound_well_known_exclusion_list"
+
+	// nodeLocalDNSIPKey is the key name used to specify the IP address of the node-local DNS cache in the ConfigMap
+	nodeLocalDNSIPKey = "node_local_dns_ip"
+
 	// enablePrivilegedInitContainer is the key name used to specify whether init containers should be privileged in the ConfigMap
 	enablePrivilegedInitContainer = "enable_privileged_init_container"
 
 	// configResyncInterval is the key name used to configure the resync interval for regular proxy broadcast updates
 	configResyncInterval = "config_resync_interval"
+
+	// tlsMinProtocolVersionKey is the key name used to specify the minimum TLS protocol version negotiated by proxies in the ConfigMap
+	tlsMinProtocolVersionKey = "tls_min_protocol_version"
+
+	// tlsMaxProtocolVersionKey is the key name used to specify the maximum TLS protocol version negotiated by proxies in the ConfigMap
+	tlsMaxProtocolVersionKey = "tls_max_protocol_version"
+
+	// tlsCipherSuitesKey is the key name used to specify the cipher suites negotiated by proxies for TLS versions up to TLSv1_2 in the ConfigMap
+	tlsCipherSuitesKey = "tls_cipher_suites"
+
+	// tlsALPNProtocolsKey is the key name used to specify the ALPN protocols advertised by proxies to upstream services in the ConfigMap
+	tlsALPNProtocolsKey = "tls_alpn_protocols"
+
+	// xffHeaderModeKey is the key name used to specify the handling of the x-forwarded-for header by proxies in the ConfigMap
+	xffHeaderModeKey = "xff_header_mode"
+
+	// xffNumTrustedHopsKey is the key name used to specify the number of trusted proxies in front of the proxies in the ConfigMap
+	xffNumTrustedHopsKey = "xff_num_trusted_hops"
+
+	// xffUnixSocketsInternalKey is the key name used to specify whether requests received over unix sockets are internal in the ConfigMap
+	xffUnixSocketsInternalKey = "xff_unix_sockets_internal"
+
+	// envoyMinSupportedVersionKey is the key name used to specify the minimum Envoy version of the proxies receiving newer configuration features in the ConfigMap
+	envoyMinSupportedVersionKey = "envoy_min_supported_version"
+
+	// httpAcceptHTTP10Key is the key name used to specify whether proxies accept HTTP/1.0 requests in the ConfigMap
+	httpAcceptHTTP10Key = "http_" + HTTPProtocolOptionAcceptHTTP10
+
+	// httpEnableTrailersKey is the key name used to specify whether proxies forward HTTP/1.1 trailers in the ConfigMap
+	httpEnableTrailersKey = "http_" + HTTPProtocolOptionEnableTrailers
+
+	// httpNormalizePathKey is the key name used to specify whether proxies normalize the paths of requests in the ConfigMap
+	httpNormalizePathKey = "http_" + HTTPProtocolOptionNormalizePath
+
+	// httpMergeSlashesKey is the key name used to specify whether proxies merge the adjacent slashes of the paths of requests in the ConfigMap
+	httpMergeSlashesKey = "http_" + HTTPProtocolOptionMergeSlashes
+
+	// httpRejectEscapedSlashesKey is the key name used to specify whether proxies reject the requests whose paths contain escaped slashes in the ConfigMap
+	httpRejectEscapedSlashesKey = "http_" + HTTPProtocolOptionRejectEscapedSlashes
+
+	// httpProtocolOptionsOverridesKey is the key name used to specify the HTTP protocol options overridden for specific services in the ConfigMap
+	httpProtocolOptionsOverridesKey = "http_protocol_options_overrides"
+
+	// proxyConfigClassesKey is the key name used to specify the settings of the proxy configuration classes in the ConfigMap
+	proxyConfigClassesKey = "proxy_config_classes"
+
+	// inboundCaseInsensitivePathsKey is the key name used to specify whether the paths of inbound and ingress routes match requests case-insensitively in the ConfigMap
+	inboundCaseInsensitivePathsKey = "inbound_case_insensitive_paths"
+
+	// inboundStripEnvoyHeadersKey is the key name used to specify whether proxies strip the x-envoy-* headers of requests from external clients in the ConfigMap
+	inboundStripEnvoyHeadersKey = "inbound_strip_envoy_headers"
 )
 
 // NewConfigurator implements configurator.Configurator and creates the Kubernetes client to manage namespaces.
@@ -77,6 +147,27 @@ func NewConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmN
 	return newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
 }
 
+// NewStaticConfigurator returns a Configurator serving the configuration of the given OSM ConfigMap, without a Kubernetes
+// client. Default configuration values are used when the ConfigMap is nil.
+func NewStaticConfigurator(configMap *v1.ConfigMap) (Configurator, error) {
+	client := &Client{
+		cache:            cache.NewStore(cache.MetaNamespaceKeyFunc),
+		cacheSynced:      make(chan interface{}),
+		osmConfigMapName: constants.OSMConfigMap,
+	}
+	close(client.cacheSynced)
+
+	if configMap != nil {
+		client.osmNamespace = configMap.Namespace
+		client.osmConfigMapName = configMap.Name
+		if err := client.cache.Add(configMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
+
 func newConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmNamespace, osmConfigMapName string) *Client {
 	// Ensure this informer exclusively watches only the Namespace where OSM in installed and the particular 'osm-config' ConfigMap
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient,
@@ -179,6 +270,18 @@ func (c *Client) configMapListener(stop <-chan struct{}) {
 					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingEndpoint != newConfigMap.TracingEndpoint)
 					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.TracingPort != newConfigMap.TracingPort)
 					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.PrometheusScraping != newConfigMap.PrometheusScraping)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.XFFHeaderMode != newConfigMap.XFFHeaderMode)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.XFFNumTrustedHops != newConfigMap.XFFNumTrustedHops)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.XFFUnixSocketsInternal != newConfigMap.XFFUnixSocketsInternal)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.EnvoyMinSupportedVersion != newConfigMap.EnvoyMinSupportedVersion)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.HTTPAcceptHTTP10 != newConfigMap.HTTPAcceptHTTP10)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.HTTPEnableTrailers != newConfigMap.HTTPEnableTrailers)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.HTTPNormalizePath != newConfigMap.HTTPNormalizePath)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.HTTPMergeSlashes != newConfigMap.HTTPMergeSlashes)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.HTTPRejectEscapedSlashes != newConfigMap.HTTPRejectEscapedSlashes)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.HTTPProtocolOptionsOverrides != newConfigMap.HTTPProtocolOptionsOverrides)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.InboundCaseInsensitivePaths != newConfigMap.InboundCaseInsensitivePaths)
+					triggerGlobalBroadcast = triggerGlobalBroadcast || (prevConfigMap.InboundStripEnvoyHeaders != newConfigMap.InboundStripEnvoyHeaders)
 
 					if triggerGlobalBroadcast {
 						log.Debug().Msgf("[%s] OSM ConfigMap update triggered global proxy broadcast",
@@ -226,6 +329,9 @@ type osmConfig struct {
 	// MaxDataPlaneConnections indicates max allowed data plane connections
 	MaxDataPlaneConnections int `yaml:"max_data_plane_connections"`
 
+	// MaxConcurrentXDSPushes indicates the max number of xDS responses computed and sent to proxies concurrently
+	MaxConcurrentXDSPushes int `yaml:"max_concurrent_xds_pushes"`
+
 	// TracingEnabled is a bool toggle used to enable or disable tracing
 	TracingEnable bool `yaml:"tracing_enable"`
 
@@ -247,6 +353,15 @@ type osmConfig struct {
 	// InitContainerImage is the init container image
 	InitContainerImage string `yaml:"init_container_image"`
 
+	// EnvoyArchImages is the list of sidecar images for specific node architectures, of the form <arch>=<image>
+	EnvoyArchImages string `yaml:"envoy_arch_images"`
+
+	// InitContainerArchImages is the list of init container images for specific node architectures, of the form <arch>=<image>
+	InitContainerArchImages string `yaml:"init_container_arch_images"`
+
+	// ImageRegistryOverride is the registry replacing the registry of the sidecar and init container images
+	ImageRegistryOverride string `yaml:"image_registry_override"`
+
 	// ServiceCertValidityDuration is a string that defines the validity duration of service certificates
 	// It is represented as a sequence of decimal numbers each with optional fraction and a unit suffix.
 	// Ex: 1h to represent 1 hour, 30m to represent 30 minutes, 1.5h or 1h30m to represent 1 hour and 30 minutes.
@@ -258,10 +373,67 @@ type osmConfig struct {
 	// OutboundPortExclusionList is the list of outbound ports to exclude from sidecar interception
 	OutboundPortExclusionList string `yaml:"outbound_port_exclusion_list"`
 
+	// OutboundWellKnownExclusionList is the list of well-known destinations to exclude from outbound sidecar interception
+	OutboundWellKnownExclusionList string `yaml:"outbound_well_known_exclusion_list"`
+
+	// NodeLocalDNSIP is the IP address of the node-local DNS cache
+	NodeLocalDNSIP string `yaml:"node_local_dns_ip"`
+
 	EnablePrivilegedInitContainer bool `yaml:"enable_privileged_init_container"`
 
 	// ConfigResyncInterval is a flag to configure resync interval for regular proxy broadcast updates
 	ConfigResyncInterval string `yaml:"config_resync_interval"`
+
+	// TLSMinProtocolVersion is the minimum TLS protocol version negotiated by proxies
+	TLSMinProtocolVersion string `yaml:"tls_min_protocol_version"`
+
+	// TLSMaxProtocolVersion is the maximum TLS protocol version negotiated by proxies
+	TLSMaxProtocolVersion string `yaml:"tls_max_protocol_version"`
+
+	// TLSCipherSuites is the list of cipher suites negotiated by proxies for TLS versions up to TLSv1_2
+	TLSCipherSuites string `yaml:"tls_cipher_suites"`
+
+	// TLSALPNProtocols is the list of ALPN protocols advertised by proxies to upstream services
+	TLSALPNProtocols string `yaml:"tls_alpn_protocols"`
+
+	// XFFHeaderMode is the handling of the x-forwarded-for header by proxies
+	XFFHeaderMode string `yaml:"xff_header_mode"`
+
+	// XFFNumTrustedHops is the number of trusted proxies in front of the proxies
+	XFFNumTrustedHops int `yaml:"xff_num_trusted_hops"`
+
+	// XFFUnixSocketsInternal is a bool toggle defining whether the requests received over unix sockets are internal
+	XFFUnixSocketsInternal bool `yaml:"xff_unix_sockets_internal"`
+
+	// EnvoyMinSupportedVersion is the minimum Envoy version of the proxies receiving newer configuration features
+	EnvoyMinSupportedVersion string `yaml:"envoy_min_supported_version"`
+
+	// HTTPAcceptHTTP10 is a bool toggle defining whether proxies accept HTTP/1.0 requests
+	HTTPAcceptHTTP10 bool `yaml:"http_accept_http_10"`
+
+	// HTTPEnableTrailers is a bool toggle defining whether proxies forward HTTP/1.1 trailers
+	HTTPEnableTrailers bool `yaml:"http_enable_trailers"`
+
+	// HTTPNormalizePath is a bool toggle defining whether proxies normalize the paths of requests
+	HTTPNormalizePath bool `yaml:"http_normalize_path"`
+
+	// HTTPMergeSlashes is a bool toggle defining whether proxies merge the adjacent slashes of the paths of requests
+	HTTPMergeSlashes bool `yaml:"http_merge_slashes"`
+
+	// HTTPRejectEscapedSlashes is a bool toggle defining whether proxies reject the requests whose paths contain escaped slashes
+	HTTPRejectEscapedSlashes bool `yaml:"http_reject_escaped_slashes"`
+
+	// HTTPProtocolOptionsOverrides is the list of HTTP protocol options overridden for specific services, of the form <namespace>/<service>:<option>=<bool>
+	HTTPProtocolOptionsOverrides string `yaml:"http_protocol_options_overrides"`
+
+	// ProxyConfigClasses is the list of settings of the proxy configuration classes, of the form <class>:<setting>=<value>
+	ProxyConfigClasses string `yaml:"proxy_config_classes"`
+
+	// InboundCaseInsensitivePaths is a bool toggle defining whether the paths of inbound and ingress routes match requests case-insensitively
+	InboundCaseInsensitivePaths bool `yaml:"inbound_case_insensitive_paths"`
+
+	// InboundStripEnvoyHeaders is a bool toggle defining whether proxies strip the x-envoy-* headers of requests from external clients
+	InboundStripEnvoyHeaders bool `yaml:"inbound_strip_envoy_headers"`
 }
 
 func (c *Client) run(stop <-chan struct{}) {
@@ -311,15 +483,38 @@ func parseOSMConfigMap(configMap *v1.ConfigMap) *osmConfig {
 	osmConfigMap.PrometheusScraping, _ = GetBoolValueForKey(configMap, prometheusScrapingKey)
 	osmConfigMap.UseHTTPSIngress, _ = GetBoolValueForKey(configMap, useHTTPSIngressKey)
 	osmConfigMap.MaxDataPlaneConnections, _ = GetIntValueForKey(configMap, maxDataPlaneConnectionsKey)
+	osmConfigMap.MaxConcurrentXDSPushes, _ = GetIntValueForKey(configMap, maxConcurrentXDSPushesKey)
 	osmConfigMap.TracingEnable, _ = GetBoolValueForKey(configMap, tracingEnableKey)
 	osmConfigMap.EnvoyLogLevel, _ = GetStringValueForKey(configMap, envoyLogLevel)
 	osmConfigMap.EnvoyImage, _ = GetStringValueForKey(configMap, envoyImage)
 	osmConfigMap.InitContainerImage, _ = GetStringValueForKey(configMap, initContainerImage)
+	osmConfigMap.EnvoyArchImages, _ = GetStringValueForKey(configMap, envoyArchImagesKey)
+	osmConfigMap.InitContainerArchImages, _ = GetStringValueForKey(configMap, initContainerArchImagesKey)
+	osmConfigMap.ImageRegistryOverride, _ = GetStringValueForKey(configMap, imageRegistryOverrideKey)
 	osmConfigMap.ServiceCertValidityDuration, _ = GetStringValueForKey(configMap, serviceCertValidityDurationKey)
 	osmConfigMap.OutboundIPRangeExclusionList, _ = GetStringValueForKey(configMap, outboundIPRangeExclusionListKey)
 	osmConfigMap.OutboundPortExclusionList, _ = GetStringValueForKey(configMap, outboundPortExclusionListKey)
+	osmConfigMap.OutboundWellKnownExclusionList, _ = GetStringValueForKey(configMap, outboundWellKnownExclusionListKey)
+	osmConfigMap.NodeLocalDNSIP, _ = GetStringValueForKey(configMap, nodeLocalDNSIPKey)
 	osmConfigMap.EnablePrivilegedInitContainer, _ = GetBoolValueForKey(configMap, enablePrivilegedInitContainer)
 	osmConfigMap.ConfigResyncInterval, _ = GetStringValueForKey(configMap, configResyncInterval)
+	osmConfigMap.TLSMinProtocolVersion, _ = GetStringValueForKey(configMap, tlsMinProtocolVersionKey)
+	osmConfigMap.TLSMaxProtocolVersion, _ = GetStringValueForKey(configMap, tlsMaxProtocolVersionKey)
+	osmConfigMap.TLSCipherSuites, _ = GetStringValueForKey(configMap, tlsCipherSuitesKey)
+	osmConfigMap.TLSALPNProtocols, _ = GetStringValueForKey(configMap, tlsALPNProtocolsKey)
+	osmConfigMap.XFFHeaderMode, _ = GetStringValueForKey(configMap, xffHeaderModeKey)
+	osmConfigMap.XFFNumTrustedHops, _ = GetIntValueForKey(configMap, xffNumTrustedHopsKey)
+	osmConfigMap.XFFUnixSocketsInternal, _ = GetBoolValueForKey(configMap, xffUnixSocketsInternalKey)
+	osmConfigMap.EnvoyMinSupportedVersion, _ = GetStringValueForKey(configMap, envoyMinSupportedVersionKey)
+	osmConfigMap.HTTPAcceptHTTP10, _ = GetBoolValueForKey(configMap, httpAcceptHTTP10Key)
+	osmConfigMap.HTTPEnableTrailers, _ = GetBoolValueForKey(configMap, httpEnableTrailersKey)
+	osmConfigMap.HTTPNormalizePath, _ = GetBoolValueForKey(configMap, httpNormalizePathKey)
+	osmConfigMap.HTTPMergeSlashes, _ = GetBoolValueForKey(configMap, httpMergeSlashesKey)
+	osmConfigMap.HTTPRejectEscapedSlashes, _ = GetBoolValueForKey(configMap, httpRejectEscapedSlashesKey)
+	osmConfigMap.HTTPProtocolOptionsOverrides, _ = GetStringValueForKey(configMap, httpProtocolOptionsOverridesKey)
+	osmConfigMap.ProxyConfigClasses, _ = GetStringValueForKey(configMap, proxyConfigClassesKey)
+	osmConfigMap.InboundCaseInsensitivePaths, _ = GetBoolValueForKey(configMap, inboundCaseInsensitivePathsKey)
+	osmConfigMap.InboundStripEnvoyHeaders, _ = GetBoolValueForKey(configMap, inboundStripEnvoyHeadersKey)
 
 	if osmConfigMap.TracingEnable {
 		osmConfigMap.TracingAddress, _ = GetStringValueForKey(configMap, tracingAddressKey)
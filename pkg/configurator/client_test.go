@@ -53,24 +53,47 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 
 		It("Tag matches const key for all fields of OSM ConfigMap struct", func() {
 			fieldNameTag := map[string]string{
-				"PermissiveTrafficPolicyMode":   PermissiveTrafficPolicyModeKey,
-				"Egress":                        egressKey,
-				"EnableDebugServer":             enableDebugServer,
-				"PrometheusScraping":            prometheusScrapingKey,
-				"TracingEnable":                 tracingEnableKey,
-				"TracingAddress":                tracingAddressKey,
-				"TracingPort":                   tracingPortKey,
-				"TracingEndpoint":               tracingEndpointKey,
-				"UseHTTPSIngress":               useHTTPSIngressKey,
-				"MaxDataPlaneConnections":       maxDataPlaneConnectionsKey,
-				"EnvoyLogLevel":                 envoyLogLevel,
-				"EnvoyImage":                    envoyImage,
-				"InitContainerImage":            initContainerImage,
-				"ServiceCertValidityDuration":   serviceCertValidityDurationKey,
-				"OutboundIPRangeExclusionList":  outboundIPRangeExclusionListKey,
-				"OutboundPortExclusionList":     outboundPortExclusionListKey,
-				"EnablePrivilegedInitContainer": enablePrivilegedInitContainer,
-				"ConfigResyncInterval":          configResyncInterval,
+				"PermissiveTrafficPolicyMode":    PermissiveTrafficPolicyModeKey,
+				"Egress":                         egressKey,
+				"EnableDebugServer":              enableDebugServer,
+				"PrometheusScraping":             prometheusScrapingKey,
+				"TracingEnable":                  tracingEnableKey,
+				"TracingAddress":                 tracingAddressKey,
+				"TracingPort":                    tracingPortKey,
+				"TracingEndpoint":                tracingEndpointKey,
+				"UseHTTPSIngress":                useHTTPSIngressKey,
+				"MaxDataPlaneConnections":        maxDataPlaneConnectionsKey,
+				"MaxConcurrentXDSPushes":         maxConcurrentXDSPushesKey,
+				"EnvoyLogLevel":                  envoyLogLevel,
+				"EnvoyImage":                     envoyImage,
+				"InitContainerImage":             initContainerImage,
+				"ServiceCertValidityDuration":    serviceCertValidityDurationKey,
+				"OutboundIPRangeExclusionList":   outboundIPRangeExclusionListKey,
+				"OutboundPortExclusionList":      outboundPortExclusionListKey,
+				"EnablePrivilegedInitContainer":  enablePrivilegedInitContainer,
+				"ConfigResyncInterval":           configResyncInterval,
+				"TLSMinProtocolVersion":          tlsMinProtocolVersionKey,
+				"TLSMaxProtocolVersion":          tlsMaxProtocolVersionKey,
+				"TLSCipherSuites":                tlsCipherSuitesKey,
+				"TLSALPNProtocols":               tlsALPNProtocolsKey,
+				"EnvoyArchImages":                envoyArchImagesKey,
+				"InitContainerArchImages":        initContainerArchImagesKey,
+				"ImageRegistryOverride":          imageRegistryOverrideKey,
+				"OutboundWellKnownExclusionList": outboundWellKnownExclusionListKey,
+				"NodeLocalDNSIP":                 nodeLocalDNSIPKey,
+				"XFFHeaderMode":                  xffHeaderModeKey,
+				"XFFNumTrustedHops":              xffNumTrustedHopsKey,
+				"XFFUnixSocketsInternal":         xffUnixSocketsInternalKey,
+				"EnvoyMinSupportedVersion":       envoyMinSupportedVersionKey,
+				"HTTPAcceptHTTP10":               httpAcceptHTTP10Key,
+				"HTTPEnableTrailers":             httpEnableTrailersKey,
+				"HTTPNormalizePath":              httpNormalizePathKey,
+				"HTTPMergeSlashes":               httpMergeSlashesKey,
+				"HTTPRejectEscapedSlashes":       httpRejectEscapedSlashesKey,
+				"HTTPProtocolOptionsOverrides":   httpProtocolOptionsOverridesKey,
+				"ProxyConfigClasses":             proxyConfigClassesKey,
+				"InboundCaseInsensitivePaths":    inboundCaseInsensitivePathsKey,
+				"InboundStripEnvoyHeaders":       inboundStripEnvoyHeadersKey,
 			}
 			t := reflect.TypeOf(osmConfig{})
 
@@ -1,8 +1,13 @@
 package configurator
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
@@ -109,6 +114,7 @@ func parseOSMMeshConfig(meshConfig *v1alpha1.MeshConfig) *osmConfig {
 	// Unsupported fields in MeshConfig CRD:
 	// * PrometheusScraping
 	// * ConfigResyncInterval
+	// * MaxConcurrentXDSPushes
 
 	osmConfig := osmConfig{}
 	osmConfig.PermissiveTrafficPolicyMode = meshConfig.Spec.Traffic.EnablePermissiveTrafficPolicyMode
@@ -119,10 +125,29 @@ func parseOSMMeshConfig(meshConfig *v1alpha1.MeshConfig) *osmConfig {
 	osmConfig.EnvoyLogLevel = meshConfig.Spec.Sidecar.LogLevel
 	osmConfig.EnvoyImage = meshConfig.Spec.Sidecar.EnvoyImage
 	osmConfig.InitContainerImage = meshConfig.Spec.Sidecar.InitContainerImage
+	osmConfig.EnvoyArchImages = joinArchImages(meshConfig.Spec.Sidecar.EnvoyArchImages)
+	osmConfig.InitContainerArchImages = joinArchImages(meshConfig.Spec.Sidecar.InitContainerArchImages)
+	osmConfig.ImageRegistryOverride = meshConfig.Spec.Sidecar.ImageRegistryOverride
+	osmConfig.EnvoyMinSupportedVersion = meshConfig.Spec.Sidecar.EnvoyMinSupportedVersion
+	osmConfig.ProxyConfigClasses = joinProxyConfigClasses(meshConfig.Spec.Sidecar.ProxyConfigClasses)
 	osmConfig.ServiceCertValidityDuration = meshConfig.Spec.Certificate.ServiceCertValidityDuration
 	osmConfig.OutboundIPRangeExclusionList = strings.Join(meshConfig.Spec.Traffic.OutboundIPRangeExclusionList, ",")
 	osmConfig.OutboundPortExclusionList = strings.Join(meshConfig.Spec.Traffic.OutboundPortExclusionList, ",")
+	osmConfig.OutboundWellKnownExclusionList = strings.Join(meshConfig.Spec.Traffic.OutboundWellKnownExclusionList, ",")
+	osmConfig.NodeLocalDNSIP = meshConfig.Spec.Traffic.NodeLocalDNSIP
 	osmConfig.EnablePrivilegedInitContainer = meshConfig.Spec.Sidecar.EnablePrivilegedInitContainer
+	osmConfig.TLSMinProtocolVersion = meshConfig.Spec.Traffic.TLSMinProtocolVersion
+	osmConfig.TLSMaxProtocolVersion = meshConfig.Spec.Traffic.TLSMaxProtocolVersion
+	osmConfig.TLSCipherSuites = strings.Join(meshConfig.Spec.Traffic.TLSCipherSuites, ",")
+	osmConfig.TLSALPNProtocols = strings.Join(meshConfig.Spec.Traffic.TLSALPNProtocols, ",")
+	osmConfig.HTTPAcceptHTTP10 = meshConfig.Spec.Traffic.HTTPProtocolOptions.AcceptHTTP10
+	osmConfig.HTTPEnableTrailers = meshConfig.Spec.Traffic.HTTPProtocolOptions.EnableTrailers
+	osmConfig.HTTPNormalizePath = meshConfig.Spec.Traffic.HTTPProtocolOptions.NormalizePath
+	osmConfig.HTTPMergeSlashes = meshConfig.Spec.Traffic.HTTPProtocolOptions.MergeSlashes
+	osmConfig.HTTPRejectEscapedSlashes = meshConfig.Spec.Traffic.HTTPProtocolOptions.RejectEscapedSlashes
+	osmConfig.HTTPProtocolOptionsOverrides = joinHTTPProtocolOptionsOverrides(meshConfig.Spec.Traffic.HTTPProtocolOptions.Overrides)
+	osmConfig.InboundCaseInsensitivePaths = meshConfig.Spec.Traffic.InboundHardening.CaseInsensitivePaths
+	osmConfig.InboundStripEnvoyHeaders = meshConfig.Spec.Traffic.InboundHardening.StripEnvoyHeaders
 
 	if osmConfig.TracingEnable {
 		osmConfig.TracingAddress = meshConfig.Spec.Observability.Tracing.Address
@@ -178,6 +203,14 @@ func meshConfigUpdatedMessageHandler(psubMsg *events.PubSubMessage) {
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.TracingAddress != newMeshConfig.TracingAddress)
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.TracingEndpoint != newMeshConfig.TracingEndpoint)
 	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.TracingPort != newMeshConfig.TracingPort)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.HTTPAcceptHTTP10 != newMeshConfig.HTTPAcceptHTTP10)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.HTTPEnableTrailers != newMeshConfig.HTTPEnableTrailers)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.HTTPNormalizePath != newMeshConfig.HTTPNormalizePath)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.HTTPMergeSlashes != newMeshConfig.HTTPMergeSlashes)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.HTTPRejectEscapedSlashes != newMeshConfig.HTTPRejectEscapedSlashes)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.HTTPProtocolOptionsOverrides != newMeshConfig.HTTPProtocolOptionsOverrides)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.InboundCaseInsensitivePaths != newMeshConfig.InboundCaseInsensitivePaths)
+	triggerGlobalBroadcast = triggerGlobalBroadcast || (prevMeshConfig.InboundStripEnvoyHeaders != newMeshConfig.InboundStripEnvoyHeaders)
 
 	if triggerGlobalBroadcast {
 		log.Debug().Msgf("[%s] OSM MeshConfig update triggered global proxy broadcast",
@@ -192,3 +225,60 @@ func meshConfigUpdatedMessageHandler(psubMsg *events.PubSubMessage) {
 			psubMsg.AnnouncementType)
 	}
 }
+
+// joinArchImages returns the given map of node architectures to images as a comma separated list of <arch>=<image> pairs,
+// sorted by architecture
+func joinArchImages(archImages map[string]string) string {
+	var pairs []string
+	for arch, image := range archImages {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", arch, image))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// joinHTTPProtocolOptionsOverrides returns the given overrides of HTTP protocol options as a comma separated list of
+// <namespace>/<service>:<option>=<bool> overrides, sorted by service and option
+func joinHTTPProtocolOptionsOverrides(overrides []v1alpha1.HTTPProtocolOptionsOverrideSpec) string {
+	var pairs []string
+	for _, override := range overrides {
+		for option, value := range override.Options {
+			pairs = append(pairs, fmt.Sprintf("%s:%s=%t", override.Service, option, value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// joinProxyConfigClasses returns the settings of the given proxy configuration classes as a comma separated list of
+// <class>:<setting>=<value> settings, sorted by class and setting
+func joinProxyConfigClasses(classes []v1alpha1.ProxyConfigClassSpec) string {
+	var settings []string
+	for _, class := range classes {
+		values := map[string]string{
+			ProxyConfigClassSettingLogLevel:   class.LogLevel,
+			ProxyConfigClassSettingEnvoyImage: class.EnvoyImage,
+		}
+		if class.Concurrency > 0 {
+			values[ProxyConfigClassSettingConcurrency] = strconv.Itoa(class.Concurrency)
+		}
+		for setting, quantity := range map[string]resource.Quantity{
+			ProxyConfigClassSettingCPURequest:    class.Resources.Requests[corev1.ResourceCPU],
+			ProxyConfigClassSettingMemoryRequest: class.Resources.Requests[corev1.ResourceMemory],
+			ProxyConfigClassSettingCPULimit:      class.Resources.Limits[corev1.ResourceCPU],
+			ProxyConfigClassSettingMemoryLimit:   class.Resources.Limits[corev1.ResourceMemory],
+		} {
+			if !quantity.IsZero() {
+				values[setting] = quantity.String()
+			}
+		}
+
+		for setting, value := range values {
+			if value != "" {
+				settings = append(settings, fmt.Sprintf("%s:%s=%s", class.Name, setting, value))
+			}
+		}
+	}
+	sort.Strings(settings)
+	return strings.Join(settings, ",")
+}
@@ -13,6 +13,8 @@ import (
 	. "github.com/onsi/gomega"
 	tassert "github.com/stretchr/testify/assert"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	testclient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
@@ -57,24 +59,47 @@ var _ = Describe("Test OSM MeshConfig parsing", func() {
 
 		It("Tag matches const key for all fields of OSM MeshConfig struct", func() {
 			fieldNameTag := map[string]string{
-				"PermissiveTrafficPolicyMode":   PermissiveTrafficPolicyModeKey,
-				"Egress":                        egressKey,
-				"EnableDebugServer":             enableDebugServer,
-				"PrometheusScraping":            prometheusScrapingKey,
-				"TracingEnable":                 tracingEnableKey,
-				"TracingAddress":                tracingAddressKey,
-				"TracingPort":                   tracingPortKey,
-				"TracingEndpoint":               tracingEndpointKey,
-				"UseHTTPSIngress":               useHTTPSIngressKey,
-				"EnvoyLogLevel":                 envoyLogLevel,
-				"EnvoyImage":                    envoyImage,
-				"InitContainerImage":            initContainerImage,
-				"ServiceCertValidityDuration":   serviceCertValidityDurationKey,
-				"OutboundIPRangeExclusionList":  outboundIPRangeExclusionListKey,
-				"OutboundPortExclusionList":     outboundPortExclusionListKey,
-				"EnablePrivilegedInitContainer": enablePrivilegedInitContainer,
-				"ConfigResyncInterval":          configResyncInterval,
-				"MaxDataPlaneConnections":       maxDataPlaneConnectionsKey,
+				"PermissiveTrafficPolicyMode":    PermissiveTrafficPolicyModeKey,
+				"Egress":                         egressKey,
+				"EnableDebugServer":              enableDebugServer,
+				"PrometheusScraping":             prometheusScrapingKey,
+				"TracingEnable":                  tracingEnableKey,
+				"TracingAddress":                 tracingAddressKey,
+				"TracingPort":                    tracingPortKey,
+				"TracingEndpoint":                tracingEndpointKey,
+				"UseHTTPSIngress":                useHTTPSIngressKey,
+				"EnvoyLogLevel":                  envoyLogLevel,
+				"EnvoyImage":                     envoyImage,
+				"InitContainerImage":             initContainerImage,
+				"ServiceCertValidityDuration":    serviceCertValidityDurationKey,
+				"OutboundIPRangeExclusionList":   outboundIPRangeExclusionListKey,
+				"OutboundPortExclusionList":      outboundPortExclusionListKey,
+				"EnablePrivilegedInitContainer":  enablePrivilegedInitContainer,
+				"ConfigResyncInterval":           configResyncInterval,
+				"MaxDataPlaneConnections":        maxDataPlaneConnectionsKey,
+				"MaxConcurrentXDSPushes":         maxConcurrentXDSPushesKey,
+				"TLSMinProtocolVersion":          tlsMinProtocolVersionKey,
+				"TLSMaxProtocolVersion":          tlsMaxProtocolVersionKey,
+				"TLSCipherSuites":                tlsCipherSuitesKey,
+				"TLSALPNProtocols":               tlsALPNProtocolsKey,
+				"EnvoyArchImages":                envoyArchImagesKey,
+				"InitContainerArchImages":        initContainerArchImagesKey,
+				"ImageRegistryOverride":          imageRegistryOverrideKey,
+				"OutboundWellKnownExclusionList": outboundWellKnownExclusionListKey,
+				"NodeLocalDNSIP":                 nodeLocalDNSIPKey,
+				"XFFHeaderMode":                  xffHeaderModeKey,
+				"XFFNumTrustedHops":              xffNumTrustedHopsKey,
+				"XFFUnixSocketsInternal":         xffUnixSocketsInternalKey,
+				"EnvoyMinSupportedVersion":       envoyMinSupportedVersionKey,
+				"HTTPAcceptHTTP10":               httpAcceptHTTP10Key,
+				"HTTPEnableTrailers":             httpEnableTrailersKey,
+				"HTTPNormalizePath":              httpNormalizePathKey,
+				"HTTPMergeSlashes":               httpMergeSlashesKey,
+				"HTTPRejectEscapedSlashes":       httpRejectEscapedSlashesKey,
+				"HTTPProtocolOptionsOverrides":   httpProtocolOptionsOverridesKey,
+				"ProxyConfigClasses":             proxyConfigClassesKey,
+				"InboundCaseInsensitivePaths":    inboundCaseInsensitivePathsKey,
+				"InboundStripEnvoyHeaders":       inboundStripEnvoyHeadersKey,
 			}
 			t := reflect.TypeOf(osmConfig{})
 
@@ -268,3 +293,27 @@ func TestMeshConfigEventTriggers(t *testing.T) {
 		assert.Equal(tc.expectProxyBroadcast, proxyEventReceived)
 	}
 }
+
+func TestJoinProxyConfigClasses(t *testing.T) {
+	assert := tassert.New(t)
+
+	classes := []v1alpha1.ProxyConfigClassSpec{
+		{
+			Name:        "canary",
+			LogLevel:    "debug",
+			Concurrency: 2,
+			EnvoyImage:  "envoyproxy/envoy-alpine:v1.18.3",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			},
+		},
+		{
+			Name: "empty",
+		},
+	}
+
+	assert.Equal("canary:concurrency=2,canary:cpu_request=100m,canary:envoy_image=envoyproxy/envoy-alpine:v1.18.3,canary:log_level=debug,canary:memory_limit=256Mi",
+		joinProxyConfigClasses(classes))
+	assert.Empty(joinProxyConfigClasses(nil))
+}
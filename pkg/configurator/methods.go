@@ -3,10 +3,12 @@ package configurator
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
@@ -100,6 +102,11 @@ func (c *Client) GetMaxDataPlaneConnections() int {
 	return c.getConfigMap().MaxDataPlaneConnections
 }
 
+// GetMaxConcurrentXDSPushes returns the max number of xDS responses computed and sent to proxies concurrently, 0 if the default is used
+func (c *Client) GetMaxConcurrentXDSPushes() int {
+	return c.getConfigMap().MaxConcurrentXDSPushes
+}
+
 // GetEnvoyLogLevel returns the envoy log level
 func (c *Client) GetEnvoyLogLevel() string {
 	logLevel := c.getConfigMap().EnvoyLogLevel
@@ -127,6 +134,21 @@ func (c *Client) GetInitContainerImage() string {
 	return constants.DefaultInitContainerImage
 }
 
+// GetEnvoyArchImages returns the map of node architectures to the envoy image injected into pods scheduled on nodes of that architecture
+func (c *Client) GetEnvoyArchImages() map[string]string {
+	return parseArchImages(c.getConfigMap().EnvoyArchImages)
+}
+
+// GetInitContainerArchImages returns the map of node architectures to the init container image injected into pods scheduled on nodes of that architecture
+func (c *Client) GetInitContainerArchImages() map[string]string {
+	return parseArchImages(c.getConfigMap().InitContainerArchImages)
+}
+
+// GetImageRegistryOverride returns the registry replacing the registry of the envoy and init container images, empty if the registry is not overridden
+func (c *Client) GetImageRegistryOverride() string {
+	return strings.TrimSuffix(c.getConfigMap().ImageRegistryOverride, "/")
+}
+
 // GetServiceCertValidityPeriod returns the validity duration for service certificates, and a default in case of invalid duration
 func (c *Client) GetServiceCertValidityPeriod() time.Duration {
 	durationStr := c.getConfigMap().ServiceCertValidityDuration
@@ -169,6 +191,33 @@ func (c *Client) GetOutboundPortExclusionList() []string {
 	return portExclusionList
 }
 
+// GetOutboundWellKnownExclusionIPRanges returns the IP ranges of the form x.x.x.x/y of the well-known destinations
+// to exclude from outbound sidecar interception. Unknown destinations are ignored.
+func (c *Client) GetOutboundWellKnownExclusionIPRanges() []string {
+	cfg := c.getConfigMap()
+	if cfg.OutboundWellKnownExclusionList == "" {
+		return nil
+	}
+
+	var ipRanges []string
+	for _, destination := range strings.Split(cfg.OutboundWellKnownExclusionList, ",") {
+		switch strings.TrimSpace(destination) {
+		case WellKnownDestinationCloudMetadata:
+			ipRanges = append(ipRanges, constants.CloudMetadataServiceIP+"/32")
+		case WellKnownDestinationNodeLocalDNS:
+			nodeLocalDNSIP := strings.TrimSpace(cfg.NodeLocalDNSIP)
+			if nodeLocalDNSIP == "" {
+				nodeLocalDNSIP = constants.DefaultNodeLocalDNSIP
+			}
+			ipRanges = append(ipRanges, nodeLocalDNSIP+"/32")
+		default:
+			log.Error().Msgf("Ignoring unknown well-known destination %q in %s", destination, outboundWellKnownExclusionListKey)
+		}
+	}
+
+	return ipRanges
+}
+
 // IsPrivilegedInitContainer returns whether init containers should be privileged
 func (c *Client) IsPrivilegedInitContainer() bool {
 	return c.getConfigMap().EnablePrivilegedInitContainer
@@ -185,3 +234,184 @@ func (c *Client) GetConfigResyncInterval() time.Duration {
 	}
 	return duration
 }
+
+// GetTLSMinProtocolVersion returns the minimum TLS protocol version negotiated by proxies
+func (c *Client) GetTLSMinProtocolVersion() string {
+	version := c.getConfigMap().TLSMinProtocolVersion
+	if version != "" {
+		return version
+	}
+	return constants.DefaultTLSMinProtocolVersion
+}
+
+// GetTLSMaxProtocolVersion returns the maximum TLS protocol version negotiated by proxies
+func (c *Client) GetTLSMaxProtocolVersion() string {
+	version := c.getConfigMap().TLSMaxProtocolVersion
+	if version != "" {
+		return version
+	}
+	return constants.DefaultTLSMaxProtocolVersion
+}
+
+// GetTLSCipherSuites returns the list of cipher suites negotiated by proxies for TLS versions up to TLSv1_2, nil if Envoy's defaults are used
+func (c *Client) GetTLSCipherSuites() []string {
+	return splitCommaSeparatedList(c.getConfigMap().TLSCipherSuites)
+}
+
+// GetTLSALPNProtocols returns the list of ALPN protocols advertised by proxies to upstream services in addition to the in-mesh ALPN
+func (c *Client) GetTLSALPNProtocols() []string {
+	return splitCommaSeparatedList(c.getConfigMap().TLSALPNProtocols)
+}
+
+// GetXFFSettings returns the mesh-wide handling of the x-forwarded-for header of the HTTP requests received by proxies
+func (c *Client) GetXFFSettings() XFFSettings {
+	configMap := c.getConfigMap()
+	settings := XFFSettings{
+		HeaderMode:          configMap.XFFHeaderMode,
+		UnixSocketsInternal: configMap.XFFUnixSocketsInternal,
+	}
+	if configMap.XFFNumTrustedHops > 0 {
+		settings.NumTrustedHops = uint32(configMap.XFFNumTrustedHops)
+	}
+	return settings.WithDefaults()
+}
+
+// GetEnvoyMinSupportedVersion returns the minimum Envoy version of the proxies receiving the configuration features that rely on newer Envoy versions, empty if all versions are supported
+func (c *Client) GetEnvoyMinSupportedVersion() string {
+	return strings.TrimSpace(c.getConfigMap().EnvoyMinSupportedVersion)
+}
+
+// GetHTTPProtocolOptions returns the options of the HTTP connections of the given service, the mesh-wide options overridden by the options of the service
+func (c *Client) GetHTTPProtocolOptions(svc service.MeshService) HTTPProtocolOptions {
+	configMap := c.getConfigMap()
+	options := HTTPProtocolOptions{
+		AcceptHTTP10:         configMap.HTTPAcceptHTTP10,
+		EnableTrailers:       configMap.HTTPEnableTrailers,
+		NormalizePath:        configMap.HTTPNormalizePath,
+		MergeSlashes:         configMap.HTTPMergeSlashes,
+		RejectEscapedSlashes: configMap.HTTPRejectEscapedSlashes,
+	}
+
+	for _, override := range splitCommaSeparatedList(configMap.HTTPProtocolOptionsOverrides) {
+		overriddenSvc, option, value, ok := splitHTTPProtocolOptionOverride(override)
+		if !ok {
+			log.Error().Msgf("Ignoring invalid HTTP protocol option override %q, must be of the form <namespace>/<service>:<option>=<bool>", override)
+			continue
+		}
+		if overriddenSvc != svc.String() {
+			continue
+		}
+		if !options.set(option, value) {
+			log.Error().Msgf("Ignoring HTTP protocol option override %q of unknown option %s", override, option)
+		}
+	}
+
+	return options
+}
+
+// splitHTTPProtocolOptionOverride splits a <namespace>/<service>:<option>=<bool> override of an HTTP protocol option
+func splitHTTPProtocolOptionOverride(override string) (svc string, option string, value bool, ok bool) {
+	chunks := strings.SplitN(override, ":", 2)
+	if len(chunks) != 2 {
+		return "", "", false, false
+	}
+	svc = strings.TrimSpace(chunks[0])
+	if nsName := strings.Split(svc, "/"); len(nsName) != 2 || nsName[0] == "" || nsName[1] == "" {
+		return "", "", false, false
+	}
+
+	chunks = strings.SplitN(chunks[1], "=", 2)
+	if len(chunks) != 2 {
+		return "", "", false, false
+	}
+	value, err := strconv.ParseBool(strings.TrimSpace(chunks[1]))
+	if err != nil {
+		return "", "", false, false
+	}
+	return svc, strings.TrimSpace(chunks[0]), value, true
+}
+
+// GetProxyConfigClass returns the proxy configuration class of the given name, and whether the class is defined
+func (c *Client) GetProxyConfigClass(name string) (ProxyConfigClass, bool) {
+	var class ProxyConfigClass
+	found := false
+	for _, entry := range splitCommaSeparatedList(c.getConfigMap().ProxyConfigClasses) {
+		className, setting, value, ok := splitProxyConfigClassSetting(entry)
+		if !ok {
+			log.Error().Msgf("Ignoring invalid proxy configuration class setting %q, must be of the form <class>:<setting>=<value>", entry)
+			continue
+		}
+		if className != name {
+			continue
+		}
+		found = true
+		if !class.set(setting, value) {
+			log.Error().Msgf("Ignoring proxy configuration class setting %q of unknown setting %s or invalid value", entry, setting)
+		}
+	}
+	return class, found
+}
+
+// splitProxyConfigClassSetting splits a <class>:<setting>=<value> setting of a proxy configuration class.
+// The value may contain ':' and '=', such as the tag of an image.
+func splitProxyConfigClassSetting(entry string) (class string, setting string, value string, ok bool) {
+	chunks := strings.SplitN(entry, ":", 2)
+	if len(chunks) != 2 {
+		return "", "", "", false
+	}
+	class = strings.TrimSpace(chunks[0])
+
+	chunks = strings.SplitN(chunks[1], "=", 2)
+	if len(chunks) != 2 {
+		return "", "", "", false
+	}
+	setting, value = strings.TrimSpace(chunks[0]), strings.TrimSpace(chunks[1])
+	return class, setting, value, class != "" && setting != "" && value != ""
+}
+
+// splitCommaSeparatedList returns the trimmed items of the given comma separated list, nil if the list is empty
+func splitCommaSeparatedList(listStr string) []string {
+	if listStr == "" {
+		return nil
+	}
+
+	items := strings.Split(listStr, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+
+	return items
+}
+
+// parseArchImages parses a comma separated list of <arch>=<image> pairs, ignoring malformed pairs
+func parseArchImages(archImagesStr string) map[string]string {
+	archImages := make(map[string]string)
+	for _, pair := range splitCommaSeparatedList(archImagesStr) {
+		arch, image, ok := splitArchImage(pair)
+		if !ok {
+			log.Error().Msgf("Ignoring invalid architecture image %q, must be of the form <arch>=<image>", pair)
+			continue
+		}
+		archImages[arch] = image
+	}
+	return archImages
+}
+
+// splitArchImage splits an <arch>=<image> pair
+func splitArchImage(pair string) (arch string, image string, ok bool) {
+	chunks := strings.SplitN(pair, "=", 2)
+	if len(chunks) != 2 {
+		return "", "", false
+	}
+	arch, image = strings.TrimSpace(chunks[0]), strings.TrimSpace(chunks[1])
+	return arch, image, arch != "" && image != ""
+}
+
+// GetInboundHardeningSettings returns the mesh-wide hardening of the handling of the requests received by the inbound and ingress listeners of proxies
+func (c *Client) GetInboundHardeningSettings() InboundHardeningSettings {
+	configMap := c.getConfigMap()
+	return InboundHardeningSettings{
+		CaseInsensitivePaths: configMap.InboundCaseInsensitivePaths,
+		StripEnvoyHeaders:    configMap.InboundStripEnvoyHeaders,
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 func TestGetConfigMapCacheKey(t *testing.T) {
@@ -322,6 +323,201 @@ func TestCreateUpdateConfig(t *testing.T) {
 				assert.Equal(1000, cfg.GetMaxDataPlaneConnections())
 			},
 		},
+		{
+			name:                 "GetMaxConcurrentXDSPushes",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(0, cfg.GetMaxConcurrentXDSPushes())
+			},
+			updatedConfigMapData: map[string]string{
+				maxConcurrentXDSPushesKey: "50",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(50, cfg.GetMaxConcurrentXDSPushes())
+			},
+		},
+		{
+			name:                 "GetTLSProtocolVersions",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("TLSv1_2", cfg.GetTLSMinProtocolVersion())
+				assert.Equal("TLSv1_3", cfg.GetTLSMaxProtocolVersion())
+			},
+			updatedConfigMapData: map[string]string{
+				tlsMinProtocolVersionKey: "TLSv1_3",
+				tlsMaxProtocolVersionKey: "TLSv1_3",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("TLSv1_3", cfg.GetTLSMinProtocolVersion())
+				assert.Equal("TLSv1_3", cfg.GetTLSMaxProtocolVersion())
+			},
+		},
+		{
+			name:                 "GetTLSCipherSuites",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Nil(cfg.GetTLSCipherSuites())
+			},
+			updatedConfigMapData: map[string]string{
+				tlsCipherSuitesKey: "ECDHE-ECDSA-AES128-GCM-SHA256, ECDHE-RSA-AES128-GCM-SHA256",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"}, cfg.GetTLSCipherSuites())
+			},
+		},
+		{
+			name:                 "GetTLSALPNProtocols",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Nil(cfg.GetTLSALPNProtocols())
+			},
+			updatedConfigMapData: map[string]string{
+				tlsALPNProtocolsKey: "h2,http/1.1",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"h2", "http/1.1"}, cfg.GetTLSALPNProtocols())
+			},
+		},
+		{
+			name:                 "GetXFFSettings",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(XFFSettings{}, cfg.GetXFFSettings())
+			},
+			updatedConfigMapData: map[string]string{
+				xffNumTrustedHopsKey:      "2",
+				xffUnixSocketsInternalKey: "true",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(XFFSettings{HeaderMode: XFFHeaderModeAppend, NumTrustedHops: 2, UnixSocketsInternal: true}, cfg.GetXFFSettings())
+			},
+		},
+		{
+			name:                 "GetEnvoyMinSupportedVersion",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("", cfg.GetEnvoyMinSupportedVersion())
+			},
+			updatedConfigMapData: map[string]string{
+				envoyMinSupportedVersionKey: " 1.17.0 ",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("1.17.0", cfg.GetEnvoyMinSupportedVersion())
+			},
+		},
+		{
+			name:                 "GetHTTPProtocolOptions",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(HTTPProtocolOptions{}, cfg.GetHTTPProtocolOptions(service.MeshService{Namespace: "ns", Name: "legacy"}))
+			},
+			updatedConfigMapData: map[string]string{
+				httpNormalizePathKey:            "true",
+				httpMergeSlashesKey:             "true",
+				httpProtocolOptionsOverridesKey: "ns/legacy:accept_http_10=true, ns/legacy:merge_slashes=false,ns/legacy:unknown=true,invalid,ns/other:enable_trailers=true",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(HTTPProtocolOptions{AcceptHTTP10: true, NormalizePath: true}, cfg.GetHTTPProtocolOptions(service.MeshService{Namespace: "ns", Name: "legacy"}))
+				assert.Equal(HTTPProtocolOptions{EnableTrailers: true, NormalizePath: true, MergeSlashes: true}, cfg.GetHTTPProtocolOptions(service.MeshService{Namespace: "ns", Name: "other"}))
+				assert.Equal(HTTPProtocolOptions{NormalizePath: true, MergeSlashes: true}, cfg.GetHTTPProtocolOptions(service.MeshService{Namespace: "default", Name: "legacy"}))
+			},
+		},
+		{
+			name:                 "GetProxyConfigClass",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				class, ok := cfg.GetProxyConfigClass("canary")
+				assert.False(ok)
+				assert.Equal(ProxyConfigClass{}, class)
+			},
+			updatedConfigMapData: map[string]string{
+				proxyConfigClassesKey: "canary:log_level=debug, canary:concurrency=2,canary:envoy_image=envoyproxy/envoy-alpine:v1.18.3,canary:memory_limit=256Mi,canary:unknown=1,invalid,stable:concurrency=-1",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				class, ok := cfg.GetProxyConfigClass("canary")
+				assert.True(ok)
+				assert.Equal(ProxyConfigClass{LogLevel: "debug", Concurrency: 2, EnvoyImage: "envoyproxy/envoy-alpine:v1.18.3", MemoryLimit: "256Mi"}, class)
+
+				class, ok = cfg.GetProxyConfigClass("stable")
+				assert.True(ok)
+				assert.Equal(ProxyConfigClass{}, class)
+
+				_, ok = cfg.GetProxyConfigClass("other")
+				assert.False(ok)
+			},
+		},
+		{
+			name:                 "GetInboundHardeningSettings",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(InboundHardeningSettings{}, cfg.GetInboundHardeningSettings())
+			},
+			updatedConfigMapData: map[string]string{
+				inboundCaseInsensitivePathsKey: "true",
+				inboundStripEnvoyHeadersKey:    "true",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(InboundHardeningSettings{CaseInsensitivePaths: true, StripEnvoyHeaders: true}, cfg.GetInboundHardeningSettings())
+			},
+		},
+		{
+			name:                 "GetEnvoyArchImages",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Empty(cfg.GetEnvoyArchImages())
+				assert.Empty(cfg.GetInitContainerArchImages())
+			},
+			updatedConfigMapData: map[string]string{
+				envoyArchImagesKey:         "amd64=envoyproxy/envoy-alpine:v1.17.2, arm64=envoyproxy/envoy:v1.17.2",
+				initContainerArchImagesKey: "arm64=openservicemesh/init:latest-arm64,invalid",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal(map[string]string{"amd64": "envoyproxy/envoy-alpine:v1.17.2", "arm64": "envoyproxy/envoy:v1.17.2"}, cfg.GetEnvoyArchImages())
+				assert.Equal(map[string]string{"arm64": "openservicemesh/init:latest-arm64"}, cfg.GetInitContainerArchImages())
+			},
+		},
+		{
+			name:                 "GetOutboundWellKnownExclusionIPRanges",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Empty(cfg.GetOutboundWellKnownExclusionIPRanges())
+			},
+			updatedConfigMapData: map[string]string{
+				outboundWellKnownExclusionListKey: "cloud-metadata, node-local-dns",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"169.254.169.254/32", "169.254.20.10/32"}, cfg.GetOutboundWellKnownExclusionIPRanges())
+			},
+		},
+		{
+			name: "GetOutboundWellKnownExclusionIPRangesWithNodeLocalDNSIP",
+			initialConfigMapData: map[string]string{
+				outboundWellKnownExclusionListKey: "node-local-dns",
+			},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"169.254.20.10/32"}, cfg.GetOutboundWellKnownExclusionIPRanges())
+			},
+			updatedConfigMapData: map[string]string{
+				outboundWellKnownExclusionListKey: "node-local-dns,unknown",
+				nodeLocalDNSIPKey:                 "10.96.0.10",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal([]string{"10.96.0.10/32"}, cfg.GetOutboundWellKnownExclusionIPRanges())
+			},
+		},
+		{
+			name:                 "GetImageRegistryOverride",
+			initialConfigMapData: map[string]string{},
+			checkCreate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("", cfg.GetImageRegistryOverride())
+			},
+			updatedConfigMapData: map[string]string{
+				imageRegistryOverrideKey: "registry.example.com:5000/mirror/",
+			},
+			checkUpdate: func(assert *tassert.Assertions, cfg Configurator) {
+				assert.Equal("registry.example.com:5000/mirror", cfg.GetImageRegistryOverride())
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -9,6 +9,7 @@ import (
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	service "github.com/openservicemesh/osm/pkg/service"
 )
 
 // MockConfigurator is a mock of Configurator interface
@@ -63,6 +64,20 @@ func (mr *MockConfiguratorMockRecorder) GetConfigResyncInterval() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigResyncInterval", reflect.TypeOf((*MockConfigurator)(nil).GetConfigResyncInterval))
 }
 
+// GetEnvoyArchImages mocks base method
+func (m *MockConfigurator) GetEnvoyArchImages() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyArchImages")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetEnvoyArchImages indicates an expected call of GetEnvoyArchImages
+func (mr *MockConfiguratorMockRecorder) GetEnvoyArchImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyArchImages", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyArchImages))
+}
+
 // GetEnvoyImage mocks base method
 func (m *MockConfigurator) GetEnvoyImage() string {
 	m.ctrl.T.Helper()
@@ -91,6 +106,76 @@ func (mr *MockConfiguratorMockRecorder) GetEnvoyLogLevel() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogLevel", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogLevel))
 }
 
+// GetEnvoyMinSupportedVersion mocks base method
+func (m *MockConfigurator) GetEnvoyMinSupportedVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyMinSupportedVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEnvoyMinSupportedVersion indicates an expected call of GetEnvoyMinSupportedVersion
+func (mr *MockConfiguratorMockRecorder) GetEnvoyMinSupportedVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyMinSupportedVersion", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyMinSupportedVersion))
+}
+
+// GetHTTPProtocolOptions mocks base method
+func (m *MockConfigurator) GetHTTPProtocolOptions(arg0 service.MeshService) HTTPProtocolOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPProtocolOptions", arg0)
+	ret0, _ := ret[0].(HTTPProtocolOptions)
+	return ret0
+}
+
+// GetHTTPProtocolOptions indicates an expected call of GetHTTPProtocolOptions
+func (mr *MockConfiguratorMockRecorder) GetHTTPProtocolOptions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPProtocolOptions", reflect.TypeOf((*MockConfigurator)(nil).GetHTTPProtocolOptions), arg0)
+}
+
+// GetImageRegistryOverride mocks base method
+func (m *MockConfigurator) GetImageRegistryOverride() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageRegistryOverride")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetImageRegistryOverride indicates an expected call of GetImageRegistryOverride
+func (mr *MockConfiguratorMockRecorder) GetImageRegistryOverride() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageRegistryOverride", reflect.TypeOf((*MockConfigurator)(nil).GetImageRegistryOverride))
+}
+
+// GetInboundHardeningSettings mocks base method
+func (m *MockConfigurator) GetInboundHardeningSettings() InboundHardeningSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundHardeningSettings")
+	ret0, _ := ret[0].(InboundHardeningSettings)
+	return ret0
+}
+
+// GetInboundHardeningSettings indicates an expected call of GetInboundHardeningSettings
+func (mr *MockConfiguratorMockRecorder) GetInboundHardeningSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundHardeningSettings", reflect.TypeOf((*MockConfigurator)(nil).GetInboundHardeningSettings))
+}
+
+// GetInitContainerArchImages mocks base method
+func (m *MockConfigurator) GetInitContainerArchImages() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitContainerArchImages")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetInitContainerArchImages indicates an expected call of GetInitContainerArchImages
+func (mr *MockConfiguratorMockRecorder) GetInitContainerArchImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitContainerArchImages", reflect.TypeOf((*MockConfigurator)(nil).GetInitContainerArchImages))
+}
+
 // GetInitContainerImage mocks base method
 func (m *MockConfigurator) GetInitContainerImage() string {
 	m.ctrl.T.Helper()
@@ -105,6 +190,20 @@ func (mr *MockConfiguratorMockRecorder) GetInitContainerImage() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitContainerImage", reflect.TypeOf((*MockConfigurator)(nil).GetInitContainerImage))
 }
 
+// GetMaxConcurrentXDSPushes mocks base method
+func (m *MockConfigurator) GetMaxConcurrentXDSPushes() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxConcurrentXDSPushes")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxConcurrentXDSPushes indicates an expected call of GetMaxConcurrentXDSPushes
+func (mr *MockConfiguratorMockRecorder) GetMaxConcurrentXDSPushes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxConcurrentXDSPushes", reflect.TypeOf((*MockConfigurator)(nil).GetMaxConcurrentXDSPushes))
+}
+
 // GetMaxDataPlaneConnections mocks base method
 func (m *MockConfigurator) GetMaxDataPlaneConnections() int {
 	m.ctrl.T.Helper()
@@ -161,6 +260,35 @@ func (mr *MockConfiguratorMockRecorder) GetOutboundPortExclusionList() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundPortExclusionList", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundPortExclusionList))
 }
 
+// GetOutboundWellKnownExclusionIPRanges mocks base method
+func (m *MockConfigurator) GetOutboundWellKnownExclusionIPRanges() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboundWellKnownExclusionIPRanges")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetOutboundWellKnownExclusionIPRanges indicates an expected call of GetOutboundWellKnownExclusionIPRanges
+func (mr *MockConfiguratorMockRecorder) GetOutboundWellKnownExclusionIPRanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundWellKnownExclusionIPRanges", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundWellKnownExclusionIPRanges))
+}
+
+// GetProxyConfigClass mocks base method
+func (m *MockConfigurator) GetProxyConfigClass(arg0 string) (ProxyConfigClass, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyConfigClass", arg0)
+	ret0, _ := ret[0].(ProxyConfigClass)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetProxyConfigClass indicates an expected call of GetProxyConfigClass
+func (mr *MockConfiguratorMockRecorder) GetProxyConfigClass(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyConfigClass", reflect.TypeOf((*MockConfigurator)(nil).GetProxyConfigClass), arg0)
+}
+
 // GetServiceCertValidityPeriod mocks base method
 func (m *MockConfigurator) GetServiceCertValidityPeriod() time.Duration {
 	m.ctrl.T.Helper()
@@ -175,6 +303,62 @@ func (mr *MockConfiguratorMockRecorder) GetServiceCertValidityPeriod() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceCertValidityPeriod", reflect.TypeOf((*MockConfigurator)(nil).GetServiceCertValidityPeriod))
 }
 
+// GetTLSALPNProtocols mocks base method
+func (m *MockConfigurator) GetTLSALPNProtocols() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSALPNProtocols")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetTLSALPNProtocols indicates an expected call of GetTLSALPNProtocols
+func (mr *MockConfiguratorMockRecorder) GetTLSALPNProtocols() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSALPNProtocols", reflect.TypeOf((*MockConfigurator)(nil).GetTLSALPNProtocols))
+}
+
+// GetTLSCipherSuites mocks base method
+func (m *MockConfigurator) GetTLSCipherSuites() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSCipherSuites")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetTLSCipherSuites indicates an expected call of GetTLSCipherSuites
+func (mr *MockConfiguratorMockRecorder) GetTLSCipherSuites() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSCipherSuites", reflect.TypeOf((*MockConfigurator)(nil).GetTLSCipherSuites))
+}
+
+// GetTLSMaxProtocolVersion mocks base method
+func (m *MockConfigurator) GetTLSMaxProtocolVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSMaxProtocolVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTLSMaxProtocolVersion indicates an expected call of GetTLSMaxProtocolVersion
+func (mr *MockConfiguratorMockRecorder) GetTLSMaxProtocolVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSMaxProtocolVersion", reflect.TypeOf((*MockConfigurator)(nil).GetTLSMaxProtocolVersion))
+}
+
+// GetTLSMinProtocolVersion mocks base method
+func (m *MockConfigurator) GetTLSMinProtocolVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTLSMinProtocolVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTLSMinProtocolVersion indicates an expected call of GetTLSMinProtocolVersion
+func (mr *MockConfiguratorMockRecorder) GetTLSMinProtocolVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTLSMinProtocolVersion", reflect.TypeOf((*MockConfigurator)(nil).GetTLSMinProtocolVersion))
+}
+
 // GetTracingEndpoint mocks base method
 func (m *MockConfigurator) GetTracingEndpoint() string {
 	m.ctrl.T.Helper()
@@ -217,6 +401,20 @@ func (mr *MockConfiguratorMockRecorder) GetTracingPort() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingPort", reflect.TypeOf((*MockConfigurator)(nil).GetTracingPort))
 }
 
+// GetXFFSettings mocks base method
+func (m *MockConfigurator) GetXFFSettings() XFFSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXFFSettings")
+	ret0, _ := ret[0].(XFFSettings)
+	return ret0
+}
+
+// GetXFFSettings indicates an expected call of GetXFFSettings
+func (mr *MockConfiguratorMockRecorder) GetXFFSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXFFSettings", reflect.TypeOf((*MockConfigurator)(nil).GetXFFSettings))
+}
+
 // IsDebugServerEnabled mocks base method
 func (m *MockConfigurator) IsDebugServerEnabled() bool {
 	m.ctrl.T.Helper()
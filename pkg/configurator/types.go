@@ -2,17 +2,207 @@
 package configurator
 
 import (
+	"strconv"
 	"time"
 
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 var (
 	log = logger.New("configurator")
 )
 
+// Well-known destinations that can be excluded from outbound sidecar interception
+const (
+	// WellKnownDestinationCloudMetadata is the instance metadata service of cloud providers
+	WellKnownDestinationCloudMetadata = "cloud-metadata"
+
+	// WellKnownDestinationNodeLocalDNS is the node-local DNS cache
+	WellKnownDestinationNodeLocalDNS = "node-local-dns"
+)
+
+// Modes of handling of the x-forwarded-for header of the HTTP requests received by sidecars
+const (
+	// XFFHeaderModeAppend appends the address of the peer of the sidecar to the header
+	XFFHeaderModeAppend = "append"
+
+	// XFFHeaderModeOverwrite replaces the header with the address of the client, as determined by the sidecar
+	XFFHeaderModeOverwrite = "overwrite"
+
+	// XFFHeaderModePreserve forwards the header unchanged
+	XFFHeaderModePreserve = "preserve"
+)
+
+// XFFSettings defines how sidecars handle the x-forwarded-for header of the HTTP requests they receive, and determine
+// the address of the client sending them
+type XFFSettings struct {
+	// HeaderMode is the handling of the header, one of XFFHeaderModeAppend, XFFHeaderModeOverwrite, XFFHeaderModePreserve.
+	// When set, sidecars determine the address of the client from the address of their peer and the trusted hops.
+	// When empty, sidecars forward the header unchanged and trust its last address, which is Envoy's default.
+	HeaderMode string
+
+	// NumTrustedHops is the number of proxies in front of the sidecars, such as load balancers, whose addresses are
+	// appended to the header and trusted to report the address of the client
+	NumTrustedHops uint32
+
+	// UnixSocketsInternal defines whether the requests received over unix sockets are considered internal
+	UnixSocketsInternal bool
+}
+
+// WithDefaults returns the settings with the header appended to when the header mode is not set but trusted hops are,
+// since the address of the client can only be determined from the trusted hops when the address of the peer is used
+func (s XFFSettings) WithDefaults() XFFSettings {
+	if s.HeaderMode == "" && s.NumTrustedHops > 0 {
+		s.HeaderMode = XFFHeaderModeAppend
+	}
+	return s
+}
+
+// Options of the HTTP connections of services, used as the suffixes of the mesh-wide keys of the options in the
+// ConfigMap, and as the names of the options in the per-service overrides
+const (
+	// HTTPProtocolOptionAcceptHTTP10 accepts HTTP/1.0 requests
+	HTTPProtocolOptionAcceptHTTP10 = "accept_http_10"
+
+	// HTTPProtocolOptionEnableTrailers forwards the trailers of HTTP/1.1 requests and responses
+	HTTPProtocolOptionEnableTrailers = "enable_trailers"
+
+	// HTTPProtocolOptionNormalizePath normalizes the paths of requests as per RFC 3986 before routing them
+	HTTPProtocolOptionNormalizePath = "normalize_path"
+
+	// HTTPProtocolOptionMergeSlashes merges the adjacent slashes of the paths of requests before routing them
+	HTTPProtocolOptionMergeSlashes = "merge_slashes"
+
+	// HTTPProtocolOptionRejectEscapedSlashes rejects the requests whose paths contain escaped slashes or backslashes
+	HTTPProtocolOptionRejectEscapedSlashes = "reject_escaped_slashes"
+)
+
+// HTTPProtocolOptions defines the options of the HTTP connections of a service, which apply to the requests received
+// by the sidecars of the service and to the requests sent to the service by the sidecars of its clients
+type HTTPProtocolOptions struct {
+	// AcceptHTTP10 accepts HTTP/1.0 requests, which are rejected by default
+	AcceptHTTP10 bool
+
+	// EnableTrailers forwards the trailers of HTTP/1.1 requests and responses, which are dropped by default
+	EnableTrailers bool
+
+	// NormalizePath normalizes the paths of requests as per RFC 3986 before routing and authorizing them
+	NormalizePath bool
+
+	// MergeSlashes merges the adjacent slashes of the paths of requests before routing and authorizing them
+	MergeSlashes bool
+
+	// RejectEscapedSlashes rejects the requests whose paths contain escaped slashes or backslashes
+	RejectEscapedSlashes bool
+}
+
+// set sets the given option to the given value, returns false if the option is unknown
+func (o *HTTPProtocolOptions) set(option string, value bool) bool {
+	switch option {
+	case HTTPProtocolOptionAcceptHTTP10:
+		o.AcceptHTTP10 = value
+	case HTTPProtocolOptionEnableTrailers:
+		o.EnableTrailers = value
+	case HTTPProtocolOptionNormalizePath:
+		o.NormalizePath = value
+	case HTTPProtocolOptionMergeSlashes:
+		o.MergeSlashes = value
+	case HTTPProtocolOptionRejectEscapedSlashes:
+		o.RejectEscapedSlashes = value
+	default:
+		return false
+	}
+	return true
+}
+
+// Settings of the Envoy sidecars of proxy configuration classes, used as the names of the settings in the
+// <class>:<setting>=<value> entries of the ConfigMap
+const (
+	// ProxyConfigClassSettingLogLevel is the log level of the sidecars
+	ProxyConfigClassSettingLogLevel = "log_level"
+
+	// ProxyConfigClassSettingConcurrency is the number of worker threads of the sidecars
+	ProxyConfigClassSettingConcurrency = "concurrency"
+
+	// ProxyConfigClassSettingEnvoyImage is the image of the sidecars
+	ProxyConfigClassSettingEnvoyImage = "envoy_image"
+
+	// ProxyConfigClassSettingCPURequest is the CPU requested by the sidecars
+	ProxyConfigClassSettingCPURequest = "cpu_request"
+
+	// ProxyConfigClassSettingMemoryRequest is the memory requested by the sidecars
+	ProxyConfigClassSettingMemoryRequest = "memory_request"
+
+	// ProxyConfigClassSettingCPULimit is the CPU limit of the sidecars
+	ProxyConfigClassSettingCPULimit = "cpu_limit"
+
+	// ProxyConfigClassSettingMemoryLimit is the memory limit of the sidecars
+	ProxyConfigClassSettingMemoryLimit = "memory_limit"
+)
+
+// ProxyConfigClass is a named set of settings of the Envoy sidecars injected into the pods referencing the class,
+// used to roll out changes of the sidecars to a subset of the pods of the mesh. The settings that are not set
+// default to the mesh-wide settings. Classes are applied by the sidecar injector only, the xDS configuration
+// of a sidecar does not depend on its class.
+type ProxyConfigClass struct {
+	// LogLevel is the log level of the sidecars
+	LogLevel string
+
+	// Concurrency is the number of worker threads of the sidecars, 0 to use Envoy's default of one per CPU core
+	Concurrency int
+
+	// EnvoyImage is the image of the sidecars, which takes precedence over the images per node architecture
+	EnvoyImage string
+
+	// CPURequest, MemoryRequest, CPULimit and MemoryLimit are the resource requirements of the sidecars, as quantities
+	CPURequest    string
+	MemoryRequest string
+	CPULimit      string
+	MemoryLimit   string
+}
+
+// set sets the given setting to the given value, returns false if the setting is unknown or the value is invalid
+func (c *ProxyConfigClass) set(setting, value string) bool {
+	switch setting {
+	case ProxyConfigClassSettingLogLevel:
+		c.LogLevel = value
+	case ProxyConfigClassSettingConcurrency:
+		concurrency, err := strconv.Atoi(value)
+		if err != nil || concurrency < 0 {
+			return false
+		}
+		c.Concurrency = concurrency
+	case ProxyConfigClassSettingEnvoyImage:
+		c.EnvoyImage = value
+	case ProxyConfigClassSettingCPURequest:
+		c.CPURequest = value
+	case ProxyConfigClassSettingMemoryRequest:
+		c.MemoryRequest = value
+	case ProxyConfigClassSettingCPULimit:
+		c.CPULimit = value
+	case ProxyConfigClassSettingMemoryLimit:
+		c.MemoryLimit = value
+	default:
+		return false
+	}
+	return true
+}
+
+// InboundHardeningSettings defines how the sidecars harden the handling of the requests they receive on their inbound
+// and ingress listeners, and the ingress gateway the handling of the requests it receives, against path-confusion attacks
+type InboundHardeningSettings struct {
+	// CaseInsensitivePaths matches the paths of requests against the paths of routes case-insensitively, for
+	// applications treating paths case-insensitively
+	CaseInsensitivePaths bool
+
+	// StripEnvoyHeaders removes the x-envoy-* headers of the requests received from clients outside the mesh, which
+	// could otherwise alter how the requests are retried, timed out or routed
+	StripEnvoyHeaders bool
+}
+
 // Client is the k8s client struct for the OSM Config.
 type Client struct {
 	osmNamespace     string
@@ -69,6 +259,9 @@ type Configurator interface {
 	// GetMaxDataPlaneConnections returns the max data plane connections allowed, 0 if disabled
 	GetMaxDataPlaneConnections() int
 
+	// GetMaxConcurrentXDSPushes returns the max number of xDS responses computed and sent to proxies concurrently, 0 if the default is used
+	GetMaxConcurrentXDSPushes() int
+
 	// GetEnvoyLogLevel returns the envoy log level
 	GetEnvoyLogLevel() string
 
@@ -78,6 +271,15 @@ type Configurator interface {
 	// GetInitContainerImage returns the init container image
 	GetInitContainerImage() string
 
+	// GetEnvoyArchImages returns the map of node architectures to the envoy image injected into pods scheduled on nodes of that architecture
+	GetEnvoyArchImages() map[string]string
+
+	// GetInitContainerArchImages returns the map of node architectures to the init container image injected into pods scheduled on nodes of that architecture
+	GetInitContainerArchImages() map[string]string
+
+	// GetImageRegistryOverride returns the registry replacing the registry of the envoy and init container images, empty if the registry is not overridden
+	GetImageRegistryOverride() string
+
 	// GetServiceCertValidityPeriod returns the validity duration for service certificates
 	GetServiceCertValidityPeriod() time.Duration
 
@@ -87,10 +289,40 @@ type Configurator interface {
 	// GetOutboundPortExclusionList returns the list of ports to exclude from outbound sidecar interception
 	GetOutboundPortExclusionList() []string
 
+	// GetOutboundWellKnownExclusionIPRanges returns the IP ranges of the well-known destinations to exclude from outbound sidecar interception
+	GetOutboundWellKnownExclusionIPRanges() []string
+
 	// IsPrivilegedInitContainer determines whether init containers should be privileged
 	IsPrivilegedInitContainer() bool
 
 	// GetConfigResyncInterval returns the duration for resync interval.
 	// If error or non-parsable value, returns 0 duration
 	GetConfigResyncInterval() time.Duration
+
+	// GetTLSMinProtocolVersion returns the minimum TLS protocol version negotiated by proxies
+	GetTLSMinProtocolVersion() string
+
+	// GetTLSMaxProtocolVersion returns the maximum TLS protocol version negotiated by proxies
+	GetTLSMaxProtocolVersion() string
+
+	// GetTLSCipherSuites returns the list of cipher suites negotiated by proxies for TLS versions up to TLSv1_2, nil if Envoy's defaults are used
+	GetTLSCipherSuites() []string
+
+	// GetTLSALPNProtocols returns the list of ALPN protocols advertised by proxies to upstream services in addition to the in-mesh ALPN
+	GetTLSALPNProtocols() []string
+
+	// GetXFFSettings returns the mesh-wide handling of the x-forwarded-for header of the HTTP requests received by proxies
+	GetXFFSettings() XFFSettings
+
+	// GetEnvoyMinSupportedVersion returns the minimum Envoy version of the proxies receiving the configuration features that rely on newer Envoy versions, empty if all versions are supported
+	GetEnvoyMinSupportedVersion() string
+
+	// GetHTTPProtocolOptions returns the options of the HTTP connections of the given service, the mesh-wide options overridden by the options of the service
+	GetHTTPProtocolOptions(svc service.MeshService) HTTPProtocolOptions
+
+	// GetProxyConfigClass returns the proxy configuration class of the given name, and whether the class is defined
+	GetProxyConfigClass(name string) (ProxyConfigClass, bool)
+
+	// GetInboundHardeningSettings returns the mesh-wide hardening of the handling of the requests received by the inbound and ingress listeners of proxies
+	GetInboundHardeningSettings() InboundHardeningSettings
 }
@@ -12,13 +12,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
 	admissionregv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -31,11 +34,23 @@ var (
 	deserializer = codecs.UniversalDeserializer()
 
 	// boolFields are the fields in osm-config that take in a boolean
-	boolFields = []string{"egress", "enable_debug_server", "permissive_traffic_policy_mode", "prometheus_scraping", "tracing_enable", "use_https_ingress", "enable_privileged_init_container"}
+	boolFields = []string{"egress", "enable_debug_server", "permissive_traffic_policy_mode", "prometheus_scraping", "tracing_enable", "use_https_ingress", "enable_privileged_init_container", "xff_unix_sockets_internal", "http_accept_http_10", "http_enable_trailers", "http_normalize_path", "http_merge_slashes", "http_reject_escaped_slashes", "inbound_case_insensitive_paths", "inbound_strip_envoy_headers"}
 
 	// ValidEnvoyLogLevels is a list of envoy log levels
 	ValidEnvoyLogLevels = []string{"trace", "debug", "info", "warning", "warn", "error", "critical", "off"}
 
+	// ValidWellKnownDestinations is the list of well-known destinations that can be excluded from outbound sidecar interception
+	ValidWellKnownDestinations = []string{WellKnownDestinationCloudMetadata, WellKnownDestinationNodeLocalDNS}
+
+	// ValidXFFHeaderModes is the list of modes of handling of the x-forwarded-for header by proxies
+	ValidXFFHeaderModes = []string{XFFHeaderModeAppend, XFFHeaderModeOverwrite, XFFHeaderModePreserve}
+
+	// ValidHTTPProtocolOptions is the list of options of the HTTP connections of services that can be overridden per service
+	ValidHTTPProtocolOptions = []string{HTTPProtocolOptionAcceptHTTP10, HTTPProtocolOptionEnableTrailers, HTTPProtocolOptionNormalizePath, HTTPProtocolOptionMergeSlashes, HTTPProtocolOptionRejectEscapedSlashes}
+
+	// ValidTLSProtocolVersions is the list of TLS protocol versions, in increasing order
+	ValidTLSProtocolVersions = []string{"TLSv1_0", "TLSv1_1", "TLSv1_2", "TLSv1_3"}
+
 	// defaultFields are the default fields in osm-config
 	defaultFields = []string{"egress", "enable_debug_server", "permissive_traffic_policy_mode", "prometheus_scraping", "use_https_ingress", "envoy_log_level", "envoy_image", "service_cert_validity_duration", "tracing_enable", "enable_privileged_init_container", "max_data_plane_connections"}
 )
@@ -65,7 +80,7 @@ const (
 	// mustBeInt is the reason for denial for incorrect syntax for tracing_port field
 	mustBeInt = ": must be an integer"
 
-	// mustBePositiveInt is the reason for denial for max_data_plane_connections field
+	// mustBePositiveInt is the reason for denial for max_data_plane_connections, max_concurrent_xds_pushes and xff_num_trusted_hops fields
 	mustBePositiveInt = ": must be a positive integer"
 
 	// mustBeInPortRange is the reason for denial for tracing_port field
@@ -75,6 +90,39 @@ const (
 
 	mustBeValidPort = ": must be a positive integer"
 
+	// mustBeWellKnownDestinationList is the reason for denial for outbound_well_known_exclusion_list field
+	mustBeWellKnownDestinationList = ": must be a comma separated list of cloud-metadata, node-local-dns"
+
+	// mustBeValidIP is the reason for denial for node_local_dns_ip field
+	mustBeValidIP = ": must be a valid IPv4 address"
+
+	// mustBeValidTLSProtocolVersion is the reason for denial for tls_min_protocol_version and tls_max_protocol_version fields
+	mustBeValidTLSProtocolVersion = ": must be one of TLSv1_0, TLSv1_1, TLSv1_2, TLSv1_3"
+
+	// mustNotExceedTLSMaxProtocolVersion is the reason for denial for a tls_min_protocol_version field greater than the tls_max_protocol_version field
+	mustNotExceedTLSMaxProtocolVersion = ": must not be greater than tls_max_protocol_version"
+
+	// mustBeNonEmptyList is the reason for denial for tls_cipher_suites and tls_alpn_protocols fields
+	mustBeNonEmptyList = ": must be a comma separated list of non-empty values"
+
+	// mustBeArchImageList is the reason for denial for envoy_arch_images and init_container_arch_images fields
+	mustBeArchImageList = ": must be a comma separated list of <arch>=<image> pairs"
+
+	// mustBeValidXFFHeaderMode is the reason for denial for xff_header_mode field
+	mustBeValidXFFHeaderMode = ": must be one of append, overwrite, preserve"
+
+	// mustBeValidEnvoyVersion is the reason for denial for envoy_min_supported_version field
+	mustBeValidEnvoyVersion = ": must be an Envoy version of the form <major>.<minor>.<patch>"
+
+	// mustBeHTTPProtocolOptionsOverrideList is the reason for denial for http_protocol_options_overrides field
+	mustBeHTTPProtocolOptionsOverrideList = ": must be a comma separated list of <namespace>/<service>:<option>=<bool> overrides of accept_http_10, enable_trailers, normalize_path, merge_slashes, reject_escaped_slashes"
+
+	// mustBeProxyConfigClassList is the reason for denial for proxy_config_classes field
+	mustBeProxyConfigClassList = ": must be a comma separated list of <class>:<setting>=<value> settings of log_level, concurrency, envoy_image, cpu_request, memory_request, cpu_limit, memory_limit"
+
+	// mustBeValidRegistry is the reason for denial for image_registry_override field
+	mustBeValidRegistry = ": must be a registry host optionally followed by a path, without a scheme"
+
 	// cannotChangeMetadata is the reason for denial for changes to configmap metadata
 	cannotChangeMetadata = ": cannot change metadata"
 
@@ -88,7 +136,7 @@ const (
 
 type webhookConfig struct {
 	kubeClient   kubernetes.Interface
-	cert         certificate.Certificater
+	certRotator  *webhook.CertRotator
 	certManager  certificate.Manager
 	osmNamespace string
 }
@@ -102,18 +150,29 @@ func NewValidatingWebhook(kubeClient kubernetes.Interface, certManager certifica
 		return err
 	}
 
+	// Rotate the webhook certificate before it expires
+	certRotator, err := webhook.NewCertRotator(certManager, cn, cert, constants.XDSCertificateValidityPeriod,
+		func(caBundle []byte) error {
+			return updateValidatingWebhookCABundle(caBundle, webhookConfigName, kubeClient)
+		}, nil)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error creating certificate rotator for the validating webhook")
+		return err
+	}
+
 	whc := &webhookConfig{
 		kubeClient:   kubeClient,
 		certManager:  certManager,
 		osmNamespace: osmNamespace,
-		cert:         cert,
+		certRotator:  certRotator,
 	}
 
 	// Start the ValidatingWebhook web server
 	go whc.runValidatingWebhook(stop)
+	go certRotator.Run(stop)
 
 	// Update the ValidatingWebhookConfig with the OSM CA bundle
-	if err = updateValidatingWebhookCABundle(cert, webhookConfigName, whc.kubeClient); err != nil {
+	if err = updateValidatingWebhookCABundle(cert.GetCertificateChain(), webhookConfigName, whc.kubeClient); err != nil {
 		log.Error().Err(err).Msgf("Error configuring ValidatingWebhookConfiguration %s", webhookConfigName)
 		return err
 	}
@@ -136,21 +195,15 @@ func (whc *webhookConfig) runValidatingWebhook(stop <-chan struct{}) {
 	log.Info().Msgf("Starting configmap webhook server on port: %v", listenPort)
 
 	go func() {
-		if whc.cert == nil {
+		if whc.certRotator == nil {
 			log.Error().Msgf("Error certificate is nil")
 			return
 		}
 
-		// Generate a key pair from your pem-encoded cert and key ([]byte).
-		cert, err := tls.X509KeyPair(whc.cert.GetCertificateChain(), whc.cert.GetPrivateKey())
-		if err != nil {
-			log.Error().Err(err).Msg("Error parsing webhook certificate")
-			return
-		}
-
+		// The certificate served is rotated before it expires
 		// #nosec G402
 		server.TLSConfig = &tls.Config{
-			Certificates: []tls.Certificate{cert},
+			GetCertificate: whc.certRotator.GetCertificate,
 		}
 		if err := server.ListenAndServeTLS("", ""); err != nil {
 			log.Error().Err(err).Msg("Validating webhook HTTP server failed to start")
@@ -252,8 +305,27 @@ func checkDefaultFields(configMap corev1.ConfigMap, resp *admissionv1.AdmissionR
 	return resp
 }
 
-// validateFields checks whether the configmap field values are valid and rejects as necessary
+// validateFields checks whether the configmap field values and metadata are valid and rejects as necessary
 func (whc *webhookConfig) validateFields(configMap corev1.ConfigMap, resp *admissionv1.AdmissionResponse) *admissionv1.AdmissionResponse {
+	checkFieldValues(configMap, resp)
+
+	defConfigMap, _ := whc.kubeClient.CoreV1().ConfigMaps(whc.osmNamespace).Get(context.TODO(), constants.OSMConfigMap, metav1.GetOptions{})
+
+	for metadataAnnotation, val := range configMap.ObjectMeta.Annotations {
+		if defConfigMap.Annotations[metadataAnnotation] != val {
+			reasonForDenial(resp, cannotChangeMetadata, metadataAnnotation)
+		}
+	}
+	for metadataLabels, val := range configMap.ObjectMeta.Labels {
+		if defConfigMap.Labels[metadataLabels] != val {
+			reasonForDenial(resp, cannotChangeMetadata, metadataLabels)
+		}
+	}
+	return resp
+}
+
+// checkFieldValues checks whether the configmap field values are valid and rejects as necessary
+func checkFieldValues(configMap corev1.ConfigMap, resp *admissionv1.AdmissionResponse) *admissionv1.AdmissionResponse {
 	for field, value := range configMap.Data {
 		if !checkBoolFields(field, value, boolFields) {
 			reasonForDenial(resp, mustBeBool, field)
@@ -285,29 +357,68 @@ func (whc *webhookConfig) validateFields(configMap corev1.ConfigMap, resp *admis
 		if field == outboundPortExclusionListKey && !checkOutboundPortExclusionList(value) {
 			reasonForDenial(resp, mustBeValidPort, field)
 		}
-		if field == maxDataPlaneConnectionsKey {
+		if field == outboundWellKnownExclusionListKey && !checkWellKnownDestinationList(value) {
+			reasonForDenial(resp, mustBeWellKnownDestinationList, field)
+		}
+		if field == nodeLocalDNSIPKey && !checkIPv4Address(value) {
+			reasonForDenial(resp, mustBeValidIP, field)
+		}
+		if field == maxDataPlaneConnectionsKey || field == maxConcurrentXDSPushesKey || field == xffNumTrustedHopsKey {
 			maxNum, err := strconv.Atoi(value)
 			if err != nil || maxNum < 0 {
 				reasonForDenial(resp, mustBePositiveInt, field)
 			}
 		}
-	}
-
-	defConfigMap, _ := whc.kubeClient.CoreV1().ConfigMaps(whc.osmNamespace).Get(context.TODO(), constants.OSMConfigMap, metav1.GetOptions{})
-
-	for metadataAnnotation, val := range configMap.ObjectMeta.Annotations {
-		if defConfigMap.Annotations[metadataAnnotation] != val {
-			reasonForDenial(resp, cannotChangeMetadata, metadataAnnotation)
+		if field == xffHeaderModeKey && !IsValidXFFHeaderMode(value) {
+			reasonForDenial(resp, mustBeValidXFFHeaderMode, field)
+		}
+		if (field == tlsMinProtocolVersionKey || field == tlsMaxProtocolVersionKey) && getTLSProtocolVersionIndex(value) < 0 {
+			reasonForDenial(resp, mustBeValidTLSProtocolVersion, field)
+		}
+		if (field == tlsCipherSuitesKey || field == tlsALPNProtocolsKey) && !checkNonEmptyList(value) {
+			reasonForDenial(resp, mustBeNonEmptyList, field)
+		}
+		if (field == envoyArchImagesKey || field == initContainerArchImagesKey) && !checkArchImageList(value) {
+			reasonForDenial(resp, mustBeArchImageList, field)
+		}
+		if field == imageRegistryOverrideKey && !checkImageRegistry(value) {
+			reasonForDenial(resp, mustBeValidRegistry, field)
+		}
+		if field == envoyMinSupportedVersionKey && !checkEnvoyVersion(value) {
+			reasonForDenial(resp, mustBeValidEnvoyVersion, field)
+		}
+		if field == httpProtocolOptionsOverridesKey && !checkHTTPProtocolOptionsOverrideList(value) {
+			reasonForDenial(resp, mustBeHTTPProtocolOptionsOverrideList, field)
+		}
+		if field == proxyConfigClassesKey && !checkProxyConfigClassList(value) {
+			reasonForDenial(resp, mustBeProxyConfigClassList, field)
 		}
 	}
-	for metadataLabels, val := range configMap.ObjectMeta.Labels {
-		if defConfigMap.Labels[metadataLabels] != val {
-			reasonForDenial(resp, cannotChangeMetadata, metadataLabels)
+
+	if minVersion, ok := configMap.Data[tlsMinProtocolVersionKey]; ok {
+		if maxVersion, ok := configMap.Data[tlsMaxProtocolVersionKey]; ok && getTLSProtocolVersionIndex(maxVersion) >= 0 &&
+			getTLSProtocolVersionIndex(minVersion) > getTLSProtocolVersionIndex(maxVersion) {
+			reasonForDenial(resp, mustNotExceedTLSMaxProtocolVersion, tlsMinProtocolVersionKey)
 		}
 	}
 	return resp
 }
 
+// ValidateConfigMap returns an error listing the missing and invalid fields of the given osm-config ConfigMap, if any.
+// The fields are validated as they are by the validating webhook of the control plane.
+func ValidateConfigMap(configMap corev1.ConfigMap) error {
+	resp := &admissionv1.AdmissionResponse{
+		Allowed: true,
+		Result:  &metav1.Status{Reason: ""},
+	}
+	checkDefaultFields(configMap, resp)
+	checkFieldValues(configMap, resp)
+	if resp.Allowed {
+		return nil
+	}
+	return errors.Errorf("Invalid fields in ConfigMap %s/%s:%s", configMap.Namespace, configMap.Name, resp.Result.Reason)
+}
+
 // checkEnvoyLogLevels checks that the field value is a valid log level
 func checkEnvoyLogLevels(configMapField, configMapValue string) bool {
 	valid := false
@@ -325,6 +436,12 @@ func checkEnvoyImage(configMapField, configMapValue string) bool {
 	return match
 }
 
+// checkEnvoyVersion checks that the given Envoy version is of the form <major>.<minor>.<patch> or <major>.<minor>, optionally prefixed with 'v'
+func checkEnvoyVersion(version string) bool {
+	match, _ := regexp.MatchString(`^v?\d+\.\d+(\.\d+)?$`, strings.TrimSpace(version))
+	return match
+}
+
 func checkOutboundIPRangeExclusionList(ipRangesStr string) bool {
 	exclusionList := strings.Split(ipRangesStr, ",")
 	for i := range exclusionList {
@@ -336,6 +453,29 @@ func checkOutboundIPRangeExclusionList(ipRangesStr string) bool {
 	return true
 }
 
+// checkWellKnownDestinationList checks that the value is a comma separated list of well-known destinations
+func checkWellKnownDestinationList(destinationsStr string) bool {
+	for _, destination := range strings.Split(destinationsStr, ",") {
+		valid := false
+		for _, validDestination := range ValidWellKnownDestinations {
+			if strings.TrimSpace(destination) == validDestination {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return false
+		}
+	}
+	return true
+}
+
+// checkIPv4Address checks that the value is an IPv4 address
+func checkIPv4Address(ipStr string) bool {
+	ip := net.ParseIP(strings.TrimSpace(ipStr))
+	return ip != nil && ip.To4() != nil
+}
+
 func checkOutboundPortExclusionList(portsStr string) bool {
 	portsExclusionList := strings.Split(portsStr, ",")
 	for i := range portsExclusionList {
@@ -348,6 +488,99 @@ func checkOutboundPortExclusionList(portsStr string) bool {
 	return true
 }
 
+// getTLSProtocolVersionIndex returns the index of the given TLS protocol version in ValidTLSProtocolVersions, -1 if it is invalid
+func getTLSProtocolVersionIndex(version string) int {
+	for i, validVersion := range ValidTLSProtocolVersions {
+		if version == validVersion {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValidXFFHeaderMode returns whether the given mode of handling of the x-forwarded-for header is valid
+func IsValidXFFHeaderMode(mode string) bool {
+	for _, validMode := range ValidXFFHeaderModes {
+		if mode == validMode {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNonEmptyList checks that the items of the given comma separated list are not empty
+func checkNonEmptyList(listStr string) bool {
+	for _, item := range strings.Split(listStr, ",") {
+		if strings.TrimSpace(item) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// checkArchImageList checks that the value is a comma separated list of <arch>=<image> pairs
+func checkArchImageList(listStr string) bool {
+	for _, pair := range strings.Split(listStr, ",") {
+		if _, _, ok := splitArchImage(strings.TrimSpace(pair)); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// checkHTTPProtocolOptionsOverrideList checks that the value is a comma separated list of <namespace>/<service>:<option>=<bool>
+// overrides of valid HTTP protocol options
+func checkHTTPProtocolOptionsOverrideList(listStr string) bool {
+	for _, override := range strings.Split(listStr, ",") {
+		_, option, _, ok := splitHTTPProtocolOptionOverride(strings.TrimSpace(override))
+		if !ok || !IsValidHTTPProtocolOption(option) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidHTTPProtocolOption returns whether the given option of the HTTP connections of services can be overridden per service
+func IsValidHTTPProtocolOption(option string) bool {
+	for _, validOption := range ValidHTTPProtocolOptions {
+		if option == validOption {
+			return true
+		}
+	}
+	return false
+}
+
+// checkProxyConfigClassList checks that the value is a comma separated list of <class>:<setting>=<value> settings of
+// proxy configuration classes, whose names are DNS labels, with valid settings
+func checkProxyConfigClassList(listStr string) bool {
+	for _, entry := range strings.Split(listStr, ",") {
+		class, setting, value, ok := splitProxyConfigClassSetting(strings.TrimSpace(entry))
+		if !ok || len(validation.IsDNS1123Label(class)) > 0 {
+			return false
+		}
+		switch setting {
+		case ProxyConfigClassSettingLogLevel:
+			if !checkEnvoyLogLevels(setting, value) {
+				return false
+			}
+		case ProxyConfigClassSettingCPURequest, ProxyConfigClassSettingMemoryRequest, ProxyConfigClassSettingCPULimit, ProxyConfigClassSettingMemoryLimit:
+			if quantity, err := resource.ParseQuantity(value); err != nil || quantity.Sign() <= 0 {
+				return false
+			}
+		default:
+			if !(&ProxyConfigClass{}).set(setting, value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkImageRegistry checks that the value is a registry host optionally followed by a path
+func checkImageRegistry(registry string) bool {
+	return registry != "" && !strings.Contains(registry, "://") && !strings.ContainsAny(registry, " \t@")
+}
+
 // checkBoolFields checks that the value is a boolean for fields that take in a boolean
 func checkBoolFields(configMapField, configMapValue string, fields []string) bool {
 	for _, f := range fields {
@@ -371,7 +604,7 @@ func reasonForDenial(resp *admissionv1.AdmissionResponse, mustBe string, field s
 }
 
 // getPartialValidatingWebhookConfiguration returns only the portion of the ValidatingWebhookConfiguration that needs to be updated.
-func getPartialValidatingWebhookConfiguration(webhookName string, cert certificate.Certificater, webhookConfigName string) admissionregv1.ValidatingWebhookConfiguration {
+func getPartialValidatingWebhookConfiguration(webhookName string, caBundle []byte, webhookConfigName string) admissionregv1.ValidatingWebhookConfiguration {
 	return admissionregv1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: webhookConfigName,
@@ -380,7 +613,7 @@ func getPartialValidatingWebhookConfiguration(webhookName string, cert certifica
 			{
 				Name: webhookName,
 				ClientConfig: admissionregv1.WebhookClientConfig{
-					CABundle: cert.GetCertificateChain(),
+					CABundle: caBundle,
 				},
 				SideEffects: func() *admissionregv1.SideEffectClass {
 					sideEffect := admissionregv1.SideEffectClassNone
@@ -392,16 +625,16 @@ func getPartialValidatingWebhookConfiguration(webhookName string, cert certifica
 	}
 }
 
-// updateValidatingWebhookCABundle updates the existing ValidatingWebhookConfiguration with the CA this OSM instance runs with.
+// updateValidatingWebhookCABundle updates the existing ValidatingWebhookConfiguration with the given CA bundle this OSM instance runs with.
 // It is necessary to perform this patch because the original ValidatingWebhookConfig YAML does not contain the root certificate.
-func updateValidatingWebhookCABundle(cert certificate.Certificater, webhookName string, clientSet kubernetes.Interface) error {
+func updateValidatingWebhookCABundle(caBundle []byte, webhookName string, clientSet kubernetes.Interface) error {
 	vwc := clientSet.AdmissionregistrationV1().ValidatingWebhookConfigurations()
 	if _, err := vwc.Get(context.Background(), webhookName, metav1.GetOptions{}); err != nil {
 		log.Error().Err(err).Msgf("Error getting ValidatingWebhookConfiguration %s; Will not update CA Bundle for webhook", webhookName)
 		return err
 	}
 
-	patchJSON, err := json.Marshal(getPartialValidatingWebhookConfiguration(ValidatingWebhookName, cert, webhookName))
+	patchJSON, err := json.Marshal(getPartialValidatingWebhookConfiguration(ValidatingWebhookName, caBundle, webhookName))
 	if err != nil {
 		return err
 	}
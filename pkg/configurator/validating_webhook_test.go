@@ -22,6 +22,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var (
@@ -64,6 +65,13 @@ func TestNewValidatingWebhook(t *testing.T) {
 	certManager := certificate.NewMockManager(mockCtrl)
 	stop := make(<-chan struct{})
 
+	cn := certificate.CommonName(fmt.Sprintf("%s.%s.svc", validatorServiceName, whc.osmNamespace))
+	certPEM, keyPEM, err := tests.NewSelfSignedPEMCertificate(cn.String(), time.Now(), time.Now().Add(time.Hour))
+	assert.Nil(err)
+	cert := certificate.NewMockCertificater(mockCtrl)
+	cert.EXPECT().GetCertificateChain().Return([]byte(certPEM)).AnyTimes()
+	cert.EXPECT().GetPrivateKey().Return([]byte(keyPEM)).AnyTimes()
+
 	testCases := []struct {
 		testName    string
 		webhookName string
@@ -73,13 +81,13 @@ func TestNewValidatingWebhook(t *testing.T) {
 		{
 			testName:    "Error in updateValidatingWebhookCABundle",
 			webhookName: "-webhook-name-",
-			mockCall:    certManager.EXPECT().IssueCertificate(certificate.CommonName(fmt.Sprintf("%s.%s.svc", validatorServiceName, whc.osmNamespace)), constants.XDSCertificateValidityPeriod),
+			mockCall:    certManager.EXPECT().IssueCertificate(cn, constants.XDSCertificateValidityPeriod).Return(cert, nil),
 			expErr:      "validatingwebhookconfigurations.admissionregistration.k8s.io \"-webhook-name-\" not found",
 		},
 		{
 			testName:    "Error in IssueCertificate",
 			webhookName: "-webhook-name-",
-			mockCall:    certManager.EXPECT().IssueCertificate(certificate.CommonName(fmt.Sprintf("%s.%s.svc", validatorServiceName, whc.osmNamespace)), constants.XDSCertificateValidityPeriod).Return(nil, errors.New("error issuing certificate")),
+			mockCall:    certManager.EXPECT().IssueCertificate(cn, constants.XDSCertificateValidityPeriod).Return(nil, errors.New("error issuing certificate")),
 			expErr:      "error issuing certificate",
 		},
 	}
@@ -435,6 +443,258 @@ func TestValidateFields(t *testing.T) {
 				Result:  &metav1.Status{Reason: "\nmax_data_plane_connections" + mustBePositiveInt},
 			},
 		},
+		{
+			testName: "Reject invalid max_concurrent_xds_pushes update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"max_concurrent_xds_pushes": "-1",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nmax_concurrent_xds_pushes" + mustBePositiveInt},
+			},
+		},
+		{
+			testName: "Reject invalid tls_min_protocol_version update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_min_protocol_version": "TLSv1_4",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\ntls_min_protocol_version" + mustBeValidTLSProtocolVersion},
+			},
+		},
+		{
+			testName: "Reject tls_min_protocol_version greater than tls_max_protocol_version",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_min_protocol_version": "TLSv1_3",
+					"tls_max_protocol_version": "TLSv1_2",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\ntls_min_protocol_version" + mustNotExceedTLSMaxProtocolVersion},
+			},
+		},
+		{
+			testName: "Accept valid TLS parameters update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_min_protocol_version": "TLSv1_2",
+					"tls_max_protocol_version": "TLSv1_3",
+					"tls_cipher_suites":        "ECDHE-ECDSA-AES128-GCM-SHA256,ECDHE-RSA-AES128-GCM-SHA256",
+					"tls_alpn_protocols":       "h2",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject empty tls_cipher_suites item",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"tls_cipher_suites": "ECDHE-ECDSA-AES128-GCM-SHA256,",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\ntls_cipher_suites" + mustBeNonEmptyList},
+			},
+		},
+		{
+			testName: "Accept valid XFF settings update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"xff_header_mode":           "overwrite",
+					"xff_num_trusted_hops":      "1",
+					"xff_unix_sockets_internal": "true",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject invalid xff_header_mode",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"xff_header_mode": "replace",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nxff_header_mode" + mustBeValidXFFHeaderMode},
+			},
+		},
+		{
+			testName: "Reject negative xff_num_trusted_hops",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"xff_num_trusted_hops": "-1",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nxff_num_trusted_hops" + mustBePositiveInt},
+			},
+		},
+		{
+			testName: "Reject invalid envoy_min_supported_version",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_min_supported_version": "1.17.x",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nenvoy_min_supported_version" + mustBeValidEnvoyVersion},
+			},
+		},
+		{
+			testName: "Reject invalid http_protocol_options_overrides",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"http_protocol_options_overrides": "ns/legacy:accept_http_10=true,ns/legacy:unknown=true",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nhttp_protocol_options_overrides" + mustBeHTTPProtocolOptionsOverrideList},
+			},
+		},
+		{
+			testName: "Accept valid HTTP protocol options update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"http_normalize_path":             "true",
+					"http_reject_escaped_slashes":     "true",
+					"http_protocol_options_overrides": "ns/legacy:accept_http_10=true, ns/legacy:reject_escaped_slashes=false",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject invalid proxy_config_classes",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"proxy_config_classes": "canary:log_level=debug,canary:memory_limit=-128Mi",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nproxy_config_classes" + mustBeProxyConfigClassList},
+			},
+		},
+		{
+			testName: "Reject proxy_config_classes with invalid class name",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"proxy_config_classes": "Canary_1:concurrency=2",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nproxy_config_classes" + mustBeProxyConfigClassList},
+			},
+		},
+		{
+			testName: "Accept valid proxy_config_classes update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"proxy_config_classes": "canary:log_level=debug, canary:concurrency=2,canary:envoy_image=envoyproxy/envoy-alpine:v1.18.3,canary:cpu_request=100m,canary:memory_limit=256Mi",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Accept valid image settings update",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_arch_images":           "amd64=envoyproxy/envoy-alpine:v1.17.2,arm64=envoyproxy/envoy:v1.17.2",
+					"init_container_arch_images":  "arm64=openservicemesh/init:latest-arm64",
+					"image_registry_override":     "registry.example.com:5000/mirror",
+					"envoy_min_supported_version": "1.17.0",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject invalid envoy_arch_images pair",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"envoy_arch_images": "arm64",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nenvoy_arch_images" + mustBeArchImageList},
+			},
+		},
+		{
+			testName: "Reject image_registry_override with a scheme",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"image_registry_override": "https://registry.example.com",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nimage_registry_override" + mustBeValidRegistry},
+			},
+		},
+		{
+			testName: "Valid well-known exclusions",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"outbound_well_known_exclusion_list": "cloud-metadata, node-local-dns",
+					"node_local_dns_ip":                  "169.254.25.10",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: true,
+				Result:  &metav1.Status{Reason: ""},
+			},
+		},
+		{
+			testName: "Reject unknown well-known destination",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"outbound_well_known_exclusion_list": "cloud-metadata,kube-dns",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\noutbound_well_known_exclusion_list" + mustBeWellKnownDestinationList},
+			},
+		},
+		{
+			testName: "Reject invalid node_local_dns_ip",
+			configMap: corev1.ConfigMap{
+				Data: map[string]string{
+					"node_local_dns_ip": "169.254.20.10/32",
+				},
+			},
+			expectedResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &metav1.Status{Reason: "\nnode_local_dns_ip" + mustBeValidIP},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.testName, func(t *testing.T) {
@@ -498,7 +758,7 @@ func TestGetPartialValidatingWebhookConfiguration(t *testing.T) {
 	assert := tassert.New(t)
 	cert := mockCertificate{}
 	webhookConfigName := "-webhook-config-name-"
-	res := getPartialValidatingWebhookConfiguration(ValidatingWebhookName, cert, webhookConfigName)
+	res := getPartialValidatingWebhookConfiguration(ValidatingWebhookName, cert.GetCertificateChain(), webhookConfigName)
 
 	expectedRes := admissionregv1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
@@ -550,10 +810,42 @@ func TestUpdateValidatingWebhookCABundle(t *testing.T) {
 			},
 		},
 	})
-	err := updateValidatingWebhookCABundle(cert, webhookName, kubeClient)
+	err := updateValidatingWebhookCABundle(cert.GetCertificateChain(), webhookName, kubeClient)
 	assert.Nil(err)
 }
 
+func TestValidateConfigMapFields(t *testing.T) {
+	assert := tassert.New(t)
+
+	configMap := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "-osm-namespace-",
+			Name:      constants.OSMConfigMap,
+		},
+		Data: map[string]string{
+			"egress":                           "true",
+			"enable_debug_server":              "true",
+			"permissive_traffic_policy_mode":   "false",
+			"prometheus_scraping":              "true",
+			"use_https_ingress":                "false",
+			"envoy_log_level":                  "error",
+			"envoy_image":                      "envoyproxy/envoy-alpine:v1.17.2",
+			"service_cert_validity_duration":   "24h",
+			"tracing_enable":                   "false",
+			"enable_privileged_init_container": "false",
+			"max_data_plane_connections":       "0",
+		},
+	}
+	assert.Nil(ValidateConfigMap(configMap))
+
+	configMap.Data["envoy_log_level"] = "verbose"
+	delete(configMap.Data, "egress")
+	err := ValidateConfigMap(configMap)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "egress"+doesNotContainDef)
+	assert.Contains(err.Error(), "envoy_log_level"+mustBeValidLogLvl)
+}
+
 type mockCertificate struct{}
 
 func (mc mockCertificate) GetCommonName() certificate.CommonName     { return "" }
@@ -16,6 +16,16 @@ const (
 	// EnvoyAdminPortName is Envoy's admin port name
 	EnvoyAdminPortName = "proxy-admin"
 
+	// EnvoyAdminDir is the directory shared by the init container and the Envoy sidecar, holding Envoy's admin socket
+	// and the osm-healthcheck binary when Envoy's admin interface is bound to a Unix domain socket
+	EnvoyAdminDir = "/var/run/osm-envoy-admin"
+
+	// EnvoyAdminSocketPath is the path of the Unix domain socket Envoy's admin interface is bound to
+	EnvoyAdminSocketPath = EnvoyAdminDir + "/admin.sock"
+
+	// OSMHealthcheckPath is the path of the osm-healthcheck binary in the Envoy sidecar, used to reach Envoy's admin socket
+	OSMHealthcheckPath = EnvoyAdminDir + "/osm-healthcheck"
+
 	// EnvoyInboundListenerPort is Envoy's inbound listener port number.
 	EnvoyInboundListenerPort = 15003
 
@@ -58,12 +68,24 @@ const (
 	// DefaultEnvoyImage is the default envoy proxy sidecar image if not defined in the osm configmap
 	DefaultEnvoyImage = "envoyproxy/envoy-alpine:v1.17.2"
 
+	// DefaultTLSMinProtocolVersion is the default minimum TLS protocol version negotiated by proxies if not defined in the osm configmap
+	DefaultTLSMinProtocolVersion = "TLSv1_2"
+
+	// DefaultTLSMaxProtocolVersion is the default maximum TLS protocol version negotiated by proxies if not defined in the osm configmap
+	DefaultTLSMaxProtocolVersion = "TLSv1_3"
+
 	// DefaultInitContainerImage is the default init container image if not defined in the osm configmap
 	DefaultInitContainerImage = "openservicemesh/init:v0.8.3"
 
 	// EnvoyPrometheusInboundListenerPort is Envoy's inbound listener port number for prometheus
 	EnvoyPrometheusInboundListenerPort = 15010
 
+	// CloudMetadataServiceIP is the link-local IP address of the instance metadata service of cloud providers
+	CloudMetadataServiceIP = "169.254.169.254"
+
+	// DefaultNodeLocalDNSIP is the default link-local IP address of the node-local DNS cache
+	DefaultNodeLocalDNSIP = "169.254.20.10"
+
 	// InjectorWebhookPort is the port on which the sidecar injection webhook listens
 	InjectorWebhookPort = 9090
 
@@ -76,9 +98,24 @@ const (
 	// OSMControllerName is the name of the OSM Controller (formerly ADS service).
 	OSMControllerName = "osm-controller"
 
+	// OSMInjectorName is the name of the OSM sidecar injector.
+	OSMInjectorName = "osm-injector"
+
 	// OSMControllerPort is the port on which XDS listens for new connections.
 	OSMControllerPort = 15128
 
+	// OSMIngressGatewayName is the name of the OSM managed ingress gateway, which is also the name of its ServiceAccount.
+	OSMIngressGatewayName = "osm-ingress-gateway"
+
+	// OSMIngressGatewayBootstrapSecretName is the name of the secret holding the Envoy bootstrap config of the OSM managed ingress gateway.
+	OSMIngressGatewayBootstrapSecretName = "osm-ingress-gateway-bootstrap-config"
+
+	// OSMIngressGatewayListenerPort is the port on which the OSM managed ingress gateway listens for HTTP traffic.
+	OSMIngressGatewayListenerPort = 8080
+
+	// OSMIngressGatewayClass is the ingress class of the Kubernetes Ingress resources served by the OSM managed ingress gateway.
+	OSMIngressGatewayClass = "osm"
+
 	// PrometheusScrapePath is the path for prometheus to scrap envoy metrics from
 	PrometheusScrapePath = "/stats/prometheus"
 
@@ -115,6 +152,9 @@ const (
 	// EnvoyUniqueIDLabelName is the label applied to pods with the unique ID of the Envoy sidecar.
 	EnvoyUniqueIDLabelName = "osm-proxy-uuid"
 
+	// GRPCXDSBootstrapLabelName is the label applied to the Secrets holding the gRPC xDS bootstrap of proxyless gRPC pods.
+	GRPCXDSBootstrapLabelName = "openservicemesh.io/grpc-xds-bootstrap"
+
 	// TimeDateLayout is the layout for time.Parse used in this repo
 	TimeDateLayout = "2006-01-02T15:04:05.000Z"
 
@@ -156,6 +196,102 @@ const (
 
 	// MetricsAnnotation is the annotation used for enabling/disabling metrics
 	MetricsAnnotation = "openservicemesh.io/metrics"
+
+	// ProxyConfigClassAnnotation is the annotation used on pods and namespaces to specify the proxy configuration class of the injected sidecars
+	ProxyConfigClassAnnotation = "openservicemesh.io/proxy-config-class"
+
+	// ProxylessGRPCAnnotation is the annotation used to bootstrap a gRPC application as a proxyless xDS client instead of injecting a sidecar
+	ProxylessGRPCAnnotation = "openservicemesh.io/proxyless-grpc"
+
+	// InboundPermissiveTLSPortsAnnotation is the annotation used to specify the ports on which the sidecar accepts plaintext traffic in addition to mTLS traffic
+	InboundPermissiveTLSPortsAnnotation = "openservicemesh.io/inbound-permissive-tls-ports"
+
+	// InboundMetricsPortsAnnotation is the annotation used to specify the ports on which an application serves metrics to scrapers outside the mesh,
+	// exempted from inbound sidecar interception
+	InboundMetricsPortsAnnotation = "openservicemesh.io/inbound-metrics-ports"
+
+	// TCPRoutePortRangesAnnotation is the annotation used to specify the port ranges matched by a TCPRoute in addition to its ports
+	TCPRoutePortRangesAnnotation = "openservicemesh.io/port-ranges"
+
+	// TCPRouteNamedPortsAnnotation is the annotation used to specify the service port names matched by a TCPRoute in addition to its ports
+	TCPRouteNamedPortsAnnotation = "openservicemesh.io/named-ports"
+
+	// HTTPRouteGroupPortRangesAnnotation is the annotation used to specify the ports an HTTPRouteGroup is restricted to
+	HTTPRouteGroupPortRangesAnnotation = "openservicemesh.io/port-ranges"
+
+	// HTTPRouteGroupNamedPortsAnnotation is the annotation used to specify the service ports an HTTPRouteGroup is restricted to
+	HTTPRouteGroupNamedPortsAnnotation = "openservicemesh.io/named-ports"
+
+	// IngressBackendTLSSecretAnnotation is the annotation used to specify the TLS Secret served by the sidecars of a service to HTTPS ingress clients
+	IngressBackendTLSSecretAnnotation = "openservicemesh.io/ingress-backend-tls-secret"
+
+	// IngressBackendXFFHeaderModeAnnotation is the annotation used to specify the handling of the x-forwarded-for header
+	// of the requests received by the sidecars of a service from ingress, overriding the mesh-wide setting
+	IngressBackendXFFHeaderModeAnnotation = "openservicemesh.io/ingress-backend-xff-header-mode"
+
+	// IngressBackendXFFNumTrustedHopsAnnotation is the annotation used to specify the number of trusted proxies in front
+	// of the sidecars of a service receiving requests from ingress, overriding the mesh-wide setting
+	IngressBackendXFFNumTrustedHopsAnnotation = "openservicemesh.io/ingress-backend-xff-num-trusted-hops"
+)
+
+// Annotations used to restrict the time during which a TrafficTarget is enforced
+const (
+	// PolicyNotBeforeAnnotation is the annotation used to specify the RFC3339 time from which a policy is enforced
+	PolicyNotBeforeAnnotation = "openservicemesh.io/not-before"
+
+	// PolicyNotAfterAnnotation is the annotation used to specify the RFC3339 time from which a policy is no longer enforced
+	PolicyNotAfterAnnotation = "openservicemesh.io/not-after"
+
+	// PolicyScheduleAnnotation is the annotation used to specify the cron schedule starting the periods during which a policy is enforced
+	PolicyScheduleAnnotation = "openservicemesh.io/schedule"
+
+	// PolicyScheduleDurationAnnotation is the annotation used to specify the duration of the periods started by the schedule of a policy
+	PolicyScheduleDurationAnnotation = "openservicemesh.io/schedule-duration"
+)
+
+// Annotations used for progressive delivery of TrafficSplit backends
+const (
+	// ProgressiveDeliveryAnnotation is the annotation used to enable progressive delivery for a TrafficSplit
+	ProgressiveDeliveryAnnotation = "openservicemesh.io/progressive-delivery"
+
+	// CanaryBackendAnnotation is the annotation used to specify the TrafficSplit backend traffic is progressively shifted to
+	CanaryBackendAnnotation = "openservicemesh.io/canary-backend"
+
+	// CanaryStepWeightAnnotation is the annotation used to specify the percentage of traffic shifted to the canary backend at every step
+	CanaryStepWeightAnnotation = "openservicemesh.io/canary-step-weight"
+
+	// CanaryStepIntervalAnnotation is the annotation used to specify the interval between steps of a rollout
+	CanaryStepIntervalAnnotation = "openservicemesh.io/canary-step-interval"
+
+	// CanaryMaxErrorRateAnnotation is the annotation used to specify the percentage of failed requests to the canary backend that rolls back a rollout
+	CanaryMaxErrorRateAnnotation = "openservicemesh.io/canary-max-error-rate"
+
+	// CanaryDryRunAnnotation is the annotation used to analyze a rollout without shifting the weights of the TrafficSplit backends
+	CanaryDryRunAnnotation = "openservicemesh.io/canary-dry-run"
+
+	// CanaryStatusAnnotation is the annotation used by the controller to record the status of a rollout
+	CanaryStatusAnnotation = "openservicemesh.io/canary-status"
+
+	// CanaryWeightAnnotation is the annotation used by the controller to record the weight of the canary backend
+	CanaryWeightAnnotation = "openservicemesh.io/canary-weight"
+
+	// CanaryLastStepAnnotation is the annotation used by the controller to record the time of the last step of a rollout
+	CanaryLastStepAnnotation = "openservicemesh.io/canary-last-step"
+)
+
+// Annotations used to size the Envoy sidecars of the pods in a namespace
+const (
+	// SidecarCPURequestAnnotation is the annotation used to specify the CPU request of the Envoy sidecars injected into the pods of a namespace
+	SidecarCPURequestAnnotation = "openservicemesh.io/sidecar-cpu-request"
+
+	// SidecarMemoryRequestAnnotation is the annotation used to specify the memory request of the Envoy sidecars injected into the pods of a namespace
+	SidecarMemoryRequestAnnotation = "openservicemesh.io/sidecar-memory-request"
+
+	// RecommendedSidecarCPURequestAnnotation is the annotation used by the controller to record the CPU request recommended for the Envoy sidecars of a namespace
+	RecommendedSidecarCPURequestAnnotation = "openservicemesh.io/recommended-sidecar-cpu-request"
+
+	// RecommendedSidecarMemoryRequestAnnotation is the annotation used by the controller to record the memory request recommended for the Envoy sidecars of a namespace
+	RecommendedSidecarMemoryRequestAnnotation = "openservicemesh.io/recommended-sidecar-memory-request"
 )
 
 // Annotations used for Metrics
@@ -193,4 +329,7 @@ const (
 
 	// gRPC protocol
 	ProtocolGRPC = "grpc"
+
+	// HTTPS protocol, used for TLS traffic to external hosts forwarded without TLS termination
+	ProtocolHTTPS = "https"
 )
@@ -2,6 +2,7 @@ package debugger
 
 import (
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
@@ -10,6 +11,19 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
+// certFormatJSON is the format of the issued certificates rendered as JSON
+const certFormatJSON = "json"
+
+// IssuedCertificate is the JSON representation of a certificate issued by the control plane.
+// Private keys are never included.
+type IssuedCertificate struct {
+	CommonName   string    `json:"common_name"`
+	SerialNumber string    `json:"serial_number"`
+	Expiration   time.Time `json:"expiration"`
+	CertChain    string    `json:"cert_chain"`
+	IssuingCA    string    `json:"issuing_ca"`
+}
+
 func (ds DebugConfig) getCertHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		certs := ds.certDebugger.ListIssuedCertificates()
@@ -18,6 +32,28 @@ func (ds DebugConfig) getCertHandler() http.Handler {
 			return certs[i].GetCommonName() < certs[j].GetCommonName()
 		})
 
+		if r.URL.Query().Get(formatQueryKey) == certFormatJSON {
+			issued := []IssuedCertificate{}
+			for _, cert := range certs {
+				issued = append(issued, IssuedCertificate{
+					CommonName:   cert.GetCommonName().String(),
+					SerialNumber: cert.GetSerialNumber().String(),
+					Expiration:   cert.GetExpiration(),
+					CertChain:    string(cert.GetCertificateChain()),
+					IssuingCA:    string(cert.GetIssuingCA()),
+				})
+			}
+			jsonCerts, err := json.Marshal(issued)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error marshaling issued certificates: %+v", issued)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, string(jsonCerts))
+			return
+		}
+
 		for idx, cert := range certs {
 			ca := cert.GetIssuingCA()
 			chain := cert.GetCertificateChain()
@@ -1,6 +1,7 @@
 package debugger
 
 import (
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -33,7 +34,7 @@ func TestGetCertHandler(t *testing.T) {
 	handler := ds.getCertHandler()
 
 	responseRecorder := httptest.NewRecorder()
-	handler.ServeHTTP(responseRecorder, nil)
+	handler.ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/debug/certs", nil))
 
 	actualResponseBody := responseRecorder.Body.String()
 
@@ -44,3 +45,37 @@ func TestGetCertHandler(t *testing.T) {
 	assert.Contains(actualResponseBody, "x509.PublicKeyAlgorithm")
 	assert.Contains(actualResponseBody, "x509.SerialNumber")
 }
+
+// Tests getCertificateHandler returns the issued certificates as JSON when requested
+func TestGetCertHandlerJSON(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mock := NewMockCertificateManagerDebugger(mockCtrl)
+
+	ds := DebugConfig{
+		certDebugger: mock,
+	}
+
+	testCert, err := tresor.NewCA("commonName", 1*time.Hour, "Country", "Locale", "Org")
+	assert.Nil(err)
+
+	mock.EXPECT().ListIssuedCertificates().Return([]certificate.Certificater{
+		testCert,
+	})
+
+	handler := ds.getCertHandler()
+
+	responseRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/debug/certs?format=json", nil))
+
+	assert.Equal("application/json", responseRecorder.Header().Get("Content-Type"))
+
+	var issued []IssuedCertificate
+	assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &issued))
+	assert.Len(issued, 1)
+	assert.Equal(testCert.GetCommonName().String(), issued[0].CommonName)
+	assert.Equal(testCert.GetSerialNumber().String(), issued[0].SerialNumber)
+	assert.Equal(string(testCert.GetCertificateChain()), issued[0].CertChain)
+	assert.Equal(string(testCert.GetIssuingCA()), issued[0].IssuingCA)
+	assert.NotContains(responseRecorder.Body.String(), "PRIVATE KEY")
+}
@@ -12,6 +12,7 @@ import (
 	certificate "github.com/openservicemesh/osm/pkg/certificate"
 	envoy "github.com/openservicemesh/osm/pkg/envoy"
 	identity "github.com/openservicemesh/osm/pkg/identity"
+	trafficpolicy "github.com/openservicemesh/osm/pkg/trafficpolicy"
 	v1alpha3 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	v1alpha4 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
 	v1alpha2 "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
@@ -77,6 +78,35 @@ func (m *MockMeshCatalogDebugger) EXPECT() *MockMeshCatalogDebuggerMockRecorder
 	return m.recorder
 }
 
+// GetEffectivePolicies mocks base method
+func (m *MockMeshCatalogDebugger) GetEffectivePolicies(arg0 identity.ServiceIdentity) (*trafficpolicy.EffectivePolicies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEffectivePolicies", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.EffectivePolicies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEffectivePolicies indicates an expected call of GetEffectivePolicies
+func (mr *MockMeshCatalogDebuggerMockRecorder) GetEffectivePolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEffectivePolicies", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).GetEffectivePolicies), arg0)
+}
+
+// GetMeshTopology mocks base method
+func (m *MockMeshCatalogDebugger) GetMeshTopology() *trafficpolicy.MeshTopology {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshTopology")
+	ret0, _ := ret[0].(*trafficpolicy.MeshTopology)
+	return ret0
+}
+
+// GetMeshTopology indicates an expected call of GetMeshTopology
+func (mr *MockMeshCatalogDebuggerMockRecorder) GetMeshTopology() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshTopology", reflect.TypeOf((*MockMeshCatalogDebugger)(nil).GetMeshTopology))
+}
+
 // ListMonitoredNamespaces mocks base method
 func (m *MockMeshCatalogDebugger) ListMonitoredNamespaces() []string {
 	m.ctrl.T.Helper()
@@ -12,6 +12,11 @@ import (
 	"github.com/openservicemesh/osm/pkg/identity"
 )
 
+const (
+	serviceAccountQueryKey = "service_account"
+	namespaceQueryKey      = "namespace"
+)
+
 type policies struct {
 	TrafficSplits   []*split.TrafficSplit        `json:"traffic_splits"`
 	ServiceAccounts []identity.K8sServiceAccount `json:"service_accounts"`
@@ -43,3 +48,30 @@ func (ds DebugConfig) getSMIPoliciesHandler() http.Handler {
 		_, _ = fmt.Fprint(w, string(jsonPolicies))
 	})
 }
+
+func (ds DebugConfig) getEffectivePoliciesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serviceAccount := r.URL.Query().Get(serviceAccountQueryKey)
+		namespace := r.URL.Query().Get(namespaceQueryKey)
+		if serviceAccount == "" || namespace == "" {
+			http.Error(w, fmt.Sprintf("Query parameters '%s' and '%s' are required", serviceAccountQueryKey, namespaceQueryKey), http.StatusBadRequest)
+			return
+		}
+
+		svcIdentity := identity.K8sServiceAccount{Name: serviceAccount, Namespace: namespace}.ToServiceIdentity()
+		effectivePolicies, err := ds.meshCatalogDebugger.GetEffectivePolicies(svcIdentity)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting effective policies for identity %s", svcIdentity)
+			http.Error(w, fmt.Sprintf("Error getting effective policies for identity %s: %s", svcIdentity, err), http.StatusInternalServerError)
+			return
+		}
+
+		jsonPolicies, err := json.Marshal(effectivePolicies)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling effective policies %+v", effectivePolicies)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonPolicies))
+	})
+}
@@ -1,6 +1,8 @@
 package debugger
 
 import (
+	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
@@ -13,6 +15,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 // Tests TestGetSMIPolicies through HTTP handler returns the list of SMI policies extracted from MeshCatalog
@@ -52,3 +55,78 @@ func TestGetSMIPolicies(t *testing.T) {
 	expectedResponseBody := `{"traffic_splits":[{"metadata":{"name":"bar","namespace":"foo","creationTimestamp":null},"spec":{}}],"service_accounts":[{"Namespace":"default","Name":"bookbuyer"}],"route_groups":[{"kind":"HTTPRouteGroup","apiVersion":"specs.smi-spec.io/v1alpha4","metadata":{"name":"bookstore-service-routes","namespace":"default","creationTimestamp":null},"spec":{"matches":[{"name":"buy-books","methods":["GET"],"pathRegex":"/buy","headers":[{"user-agent":"test-UA"}]},{"name":"sell-books","methods":["GET"],"pathRegex":"/sell","headers":[{"user-agent":"test-UA"}]},{"name":"allow-everything-on-header","headers":[{"user-agent":"test-UA"}]}]}}],"traffic_targets":[{"kind":"TrafficTarget","apiVersion":"access.smi-spec.io/v1alpha3","metadata":{"name":"bookbuyer-access-bookstore","namespace":"default","creationTimestamp":null},"spec":{"destination":{"kind":"ServiceAccount","name":"bookstore","namespace":"default"},"sources":[{"kind":"ServiceAccount","name":"bookbuyer","namespace":"default"}],"rules":[{"kind":"HTTPRouteGroup","name":"bookstore-service-routes","matches":["buy-books","sell-books"]}]}}]}`
 	assert.Equal(expectedResponseBody, actualResponseBody, "Actual value did not match expectations:\n%s", actualResponseBody)
 }
+
+func TestGetEffectivePolicies(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		effectivePolicies    *trafficpolicy.EffectivePolicies
+		err                  error
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "effective policies of an identity",
+			query: "?service_account=bookbuyer&namespace=default",
+			effectivePolicies: &trafficpolicy.EffectivePolicies{
+				Identity:   "bookbuyer.default.cluster.local",
+				Inbound:    []trafficpolicy.EffectiveTrafficPolicy{},
+				InboundTCP: []trafficpolicy.EffectiveTCPTrafficTarget{},
+				Outbound: []trafficpolicy.EffectiveTrafficPolicy{
+					{
+						Name:      "bookstore.default",
+						Hostnames: []string{"bookstore.default"},
+						Routes: []trafficpolicy.EffectiveRoute{
+							{
+								Path:             ".*",
+								PathMatchType:    "regex",
+								WeightedClusters: []trafficpolicy.EffectiveWeightedCluster{{Cluster: "default/bookstore", Weight: 100}},
+							},
+						},
+					},
+				},
+				Egress:  []trafficpolicy.EffectiveEgressPolicy{},
+				Ingress: []trafficpolicy.EffectiveTrafficPolicy{},
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"identity":"bookbuyer.default.cluster.local","inbound":[],"inbound_tcp":[],"outbound":[{"name":"bookstore.default","hostnames":["bookstore.default"],"routes":[{"path":".*","path_match_type":"regex","weighted_clusters":[{"cluster":"default/bookstore","weight":100}]}]}],"egress":[],"ingress":[]}`,
+		},
+		{
+			name:               "missing namespace",
+			query:              "?service_account=bookbuyer",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:               "error getting effective policies",
+			query:              "?service_account=bookbuyer&namespace=default",
+			err:                errors.New("error"),
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+			ds := DebugConfig{
+				meshCatalogDebugger: mock,
+			}
+
+			if tc.effectivePolicies != nil || tc.err != nil {
+				mock.EXPECT().GetEffectivePolicies(tests.BookbuyerServiceIdentity).Return(tc.effectivePolicies, tc.err)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/debug/effective-policies"+tc.query, nil)
+			ds.getEffectivePoliciesHandler().ServeHTTP(responseRecorder, request)
+
+			assert.Equal(tc.expectedStatusCode, responseRecorder.Code)
+			if tc.expectedResponseBody != "" {
+				assert.Equal(tc.expectedResponseBody, responseRecorder.Body.String())
+			}
+		})
+	}
+}
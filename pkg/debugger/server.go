@@ -15,13 +15,15 @@ import (
 // GetHandlers implements DebugConfig interface and returns the rest of URLs and the handling functions.
 func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 	handlers := map[string]http.Handler{
-		"/debug/certs":         ds.getCertHandler(),
-		"/debug/xds":           ds.getXDSHandler(),
-		"/debug/proxy":         ds.getProxies(),
-		"/debug/policies":      ds.getSMIPoliciesHandler(),
-		"/debug/config":        ds.getOSMConfigHandler(),
-		"/debug/namespaces":    ds.getMonitoredNamespacesHandler(),
-		"/debug/feature-flags": ds.getFeatureFlags(),
+		"/debug/certs":              ds.getCertHandler(),
+		"/debug/xds":                ds.getXDSHandler(),
+		"/debug/proxy":              ds.getProxies(),
+		"/debug/policies":           ds.getSMIPoliciesHandler(),
+		"/debug/effective-policies": ds.getEffectivePoliciesHandler(),
+		"/debug/topology":           ds.getMeshTopologyHandler(),
+		"/debug/config":             ds.getOSMConfigHandler(),
+		"/debug/namespaces":         ds.getMonitoredNamespacesHandler(),
+		"/debug/feature-flags":      ds.getFeatureFlags(),
 
 		// Pprof handlers
 		"/debug/pprof/":        http.HandlerFunc(pprof.Index),
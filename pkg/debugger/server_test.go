@@ -41,6 +41,7 @@ func TestGetHandlers(t *testing.T) {
 		"/debug/xds",
 		"/debug/proxy",
 		"/debug/policies",
+		"/debug/effective-policies",
 		"/debug/config",
 		"/debug/namespaces",
 		// Pprof handlers
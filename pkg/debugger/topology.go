@@ -0,0 +1,94 @@
+package debugger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+const (
+	formatQueryKey = "format"
+
+	// topologyFormatJSON is the format of the mesh topology rendered as JSON
+	topologyFormatJSON = "json"
+
+	// topologyFormatDOT is the format of the mesh topology rendered as a GraphViz DOT graph
+	topologyFormatDOT = "dot"
+)
+
+func (ds DebugConfig) getMeshTopologyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get(formatQueryKey)
+		if format == "" {
+			format = topologyFormatJSON
+		}
+
+		switch format {
+		case topologyFormatJSON:
+			topology := ds.meshCatalogDebugger.GetMeshTopology()
+			jsonTopology, err := json.Marshal(topology)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error marshalling mesh topology %+v", topology)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, string(jsonTopology))
+
+		case topologyFormatDOT:
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			writeMeshTopologyDOT(w, ds.meshCatalogDebugger.GetMeshTopology())
+
+		default:
+			http.Error(w, fmt.Sprintf("Invalid value for query parameter '%s', must be one of: %s, %s", formatQueryKey, topologyFormatJSON, topologyFormatDOT), http.StatusBadRequest)
+		}
+	})
+}
+
+// writeMeshTopologyDOT writes the given mesh topology as a GraphViz DOT graph.
+// Service identities and services can share the same namespaced name, so their node IDs are prefixed with their kind.
+func writeMeshTopologyDOT(w io.Writer, topology *trafficpolicy.MeshTopology) {
+	identitySet := make(map[string]bool)
+	for _, edge := range topology.Edges {
+		identitySet[edge.Source] = true
+	}
+	for _, svc := range topology.Services {
+		for _, identity := range svc.Identities {
+			identitySet[identity] = true
+		}
+	}
+	var identities []string
+	for identity := range identitySet {
+		identities = append(identities, identity)
+	}
+	sort.Strings(identities)
+
+	_, _ = fmt.Fprintln(w, "digraph mesh {")
+	for _, svc := range topology.Services {
+		_, _ = fmt.Fprintf(w, "  %s [label=%s, shape=box];\n", dotNodeID("service", svc.Name), dotQuote(svc.Name))
+	}
+	for _, identity := range identities {
+		_, _ = fmt.Fprintf(w, "  %s [label=%s, shape=ellipse];\n", dotNodeID("identity", identity), dotQuote(identity))
+	}
+	// Dashed edges link the services to the identities of their backends
+	for _, svc := range topology.Services {
+		for _, identity := range svc.Identities {
+			_, _ = fmt.Fprintf(w, "  %s -> %s [style=dashed, arrowhead=none];\n", dotNodeID("identity", identity), dotNodeID("service", svc.Name))
+		}
+	}
+	for _, edge := range topology.Edges {
+		_, _ = fmt.Fprintf(w, "  %s -> %s;\n", dotNodeID("identity", edge.Source), dotNodeID("service", edge.Destination))
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+func dotNodeID(kind, name string) string {
+	return dotQuote(kind + ":" + name)
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,84 @@
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetMeshTopology(t *testing.T) {
+	topology := &trafficpolicy.MeshTopology{
+		Services: []trafficpolicy.TopologyService{
+			{Name: "default/bookstore", Identities: []string{"default/bookstore"}},
+		},
+		Edges: []trafficpolicy.TopologyEdge{
+			{Source: "default/bookbuyer", Destination: "default/bookstore"},
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedStatusCode   int
+		expectedContentType  string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "default format is JSON",
+			expectedStatusCode:   http.StatusOK,
+			expectedContentType:  "application/json",
+			expectedResponseBody: `{"services":[{"name":"default/bookstore","identities":["default/bookstore"]}],"edges":[{"source":"default/bookbuyer","destination":"default/bookstore"}]}`,
+		},
+		{
+			name:                "DOT format",
+			query:               "?format=dot",
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/vnd.graphviz",
+			expectedResponseBody: `digraph mesh {
+  "service:default/bookstore" [label="default/bookstore", shape=box];
+  "identity:default/bookbuyer" [label="default/bookbuyer", shape=ellipse];
+  "identity:default/bookstore" [label="default/bookstore", shape=ellipse];
+  "identity:default/bookstore" -> "service:default/bookstore" [style=dashed, arrowhead=none];
+  "identity:default/bookbuyer" -> "service:default/bookstore";
+}
+`,
+		},
+		{
+			name:               "invalid format",
+			query:              "?format=yaml",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mock := NewMockMeshCatalogDebugger(mockCtrl)
+
+			ds := DebugConfig{
+				meshCatalogDebugger: mock,
+			}
+
+			if tc.expectedStatusCode == http.StatusOK {
+				mock.EXPECT().GetMeshTopology().Return(topology)
+			}
+
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "/debug/topology"+tc.query, nil)
+			ds.getMeshTopologyHandler().ServeHTTP(responseRecorder, request)
+
+			assert.Equal(tc.expectedStatusCode, responseRecorder.Code)
+			if tc.expectedResponseBody != "" {
+				assert.Equal(tc.expectedContentType, responseRecorder.Header().Get("Content-Type"))
+				assert.Equal(tc.expectedResponseBody, responseRecorder.Body.String())
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/identity"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var log = logger.New("debugger")
@@ -46,6 +47,12 @@ type MeshCatalogDebugger interface {
 
 	// ListMonitoredNamespaces lists the namespaces that the control plan knows about.
 	ListMonitoredNamespaces() []string
+
+	// GetEffectivePolicies returns the policies applied to the traffic of the given service identity.
+	GetEffectivePolicies(identity.ServiceIdentity) (*trafficpolicy.EffectivePolicies, error)
+
+	// GetMeshTopology returns the communication graph of the mesh allowed by the traffic policies.
+	GetMeshTopology() *trafficpolicy.MeshTopology
 }
 
 // XDSDebugger is an interface providing debugging server with methods introspecting XDS.
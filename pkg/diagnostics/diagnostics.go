@@ -0,0 +1,204 @@
+package diagnostics
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+)
+
+const (
+	// window is the duration over which the occurrences of an error are counted
+	window = 10 * time.Minute
+
+	// recurringThreshold is the number of occurrences of an error within the window from which the error is recurring
+	recurringThreshold = 3
+
+	// maxOccurrences is the maximum number of occurrences of an error recorded within the window
+	maxOccurrences = 1000
+
+	// maxMessageLength is the maximum length of the message of a condition
+	maxMessageLength = 512
+)
+
+var defaultRecorder = &recorder{
+	occurrences: make(map[Code][]occurrence),
+}
+
+// Record records an occurrence of the given error, identified by the given error code.
+// It is safe to call from multiple goroutines, and is a no-op for a nil error.
+func Record(code Code, err error) {
+	if err == nil {
+		return
+	}
+	defaultRecorder.record(code, err.Error(), time.Now())
+}
+
+func (r *recorder) record(code Code, message string, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	occurrences := append(prune(r.occurrences[code], now), occurrence{time: now, message: message})
+	if len(occurrences) > maxOccurrences {
+		occurrences = occurrences[len(occurrences)-maxOccurrences:]
+	}
+	r.occurrences[code] = occurrences
+}
+
+// get returns the occurrences of the error with the given code within the window
+func (r *recorder) get(code Code, now time.Time) []occurrence {
+	r.Lock()
+	defer r.Unlock()
+	r.occurrences[code] = prune(r.occurrences[code], now)
+	return append([]occurrence(nil), r.occurrences[code]...)
+}
+
+// prune returns the given occurrences that are within the window
+func prune(occurrences []occurrence, now time.Time) []occurrence {
+	for i, o := range occurrences {
+		if now.Sub(o.time) < window {
+			return occurrences[i:]
+		}
+	}
+	return nil
+}
+
+// NewReporter creates a Reporter for the errors with the given codes recorded by the current process.
+func NewReporter(configClient configClientset.Interface, meshName string, codes ...Code) *Reporter {
+	return &Reporter{
+		configClient: configClient,
+		meshName:     meshName,
+		recorder:     defaultRecorder,
+		codes:        codes,
+	}
+}
+
+// Start starts reporting the recurring errors at the given interval.
+func (r *Reporter) Start(reportInterval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(reportInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			if err := r.report(time.Now()); err != nil {
+				log.Error().Err(err).Msgf("Error reporting diagnostics of mesh %s", r.meshName)
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// report updates the conditions of the MeshDiagnostic resource of the mesh for the error codes of the reporter
+func (r *Reporter) report(now time.Time) error {
+	diagnostic, err := r.configClient.ConfigV1alpha1().MeshDiagnostics().Get(context.Background(), r.meshName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		diagnostic = &configv1alpha1.MeshDiagnostic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: r.meshName,
+			},
+		}
+		diagnostic.Status.Conditions, _ = r.getConditions(nil, now)
+		if _, err := r.configClient.ConfigV1alpha1().MeshDiagnostics().Create(context.Background(), diagnostic, metav1.CreateOptions{}); err != nil {
+			return errors.Errorf("Error creating MeshDiagnostic %s: %s", r.meshName, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Errorf("Error getting MeshDiagnostic %s: %s", r.meshName, err)
+	}
+
+	conditions, changed := r.getConditions(diagnostic.Status.Conditions, now)
+	if !changed {
+		return nil
+	}
+
+	updated := diagnostic.DeepCopy()
+	updated.Status.Conditions = conditions
+	if _, err := r.configClient.ConfigV1alpha1().MeshDiagnostics().Update(context.Background(), updated, metav1.UpdateOptions{}); err != nil {
+		return errors.Errorf("Error updating MeshDiagnostic %s: %s", r.meshName, err)
+	}
+	return nil
+}
+
+// getConditions returns the given conditions, with the conditions of the error codes of the reporter updated
+// from the errors recorded within the window, and whether any condition changed.
+// Times are truncated to seconds, the precision at which they are stored by the API server.
+func (r *Reporter) getConditions(existing []configv1alpha1.DiagnosticCondition, now time.Time) ([]configv1alpha1.DiagnosticCondition, bool) {
+	conditions := append([]configv1alpha1.DiagnosticCondition(nil), existing...)
+	nowTime := metav1.NewTime(now).Rfc3339Copy()
+	var changed bool
+
+	for _, code := range r.codes {
+		idx := -1
+		for i := range conditions {
+			if conditions[i].Code == string(code) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			conditions = append(conditions, configv1alpha1.DiagnosticCondition{
+				Type:               conditionTypes[code],
+				Code:               string(code),
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: nowTime,
+			})
+			idx = len(conditions) - 1
+			changed = true
+		}
+		condition := &conditions[idx]
+
+		occurrences := r.recorder.get(code, now)
+		if condition.Count != len(occurrences) {
+			condition.Count = len(occurrences)
+			changed = true
+		}
+		if len(occurrences) > 0 {
+			last := occurrences[len(occurrences)-1]
+			lastTime := metav1.NewTime(last.time).Rfc3339Copy()
+			if condition.LastOccurrenceTime == nil || !condition.LastOccurrenceTime.Equal(&lastTime) {
+				condition.LastOccurrenceTime = &lastTime
+				changed = true
+			}
+			if message := truncate(last.message, maxMessageLength); condition.Message != message {
+				condition.Message = message
+				changed = true
+			}
+		}
+
+		status := metav1.ConditionFalse
+		if condition.Count >= recurringThreshold {
+			status = metav1.ConditionTrue
+		}
+		if condition.Status != status {
+			if status == metav1.ConditionTrue {
+				log.Warn().Msgf("Error %s (%s) is recurring: %d occurrences in the last %s", code, condition.Type, condition.Count, window)
+			}
+			condition.Status = status
+			condition.LastTransitionTime = nowTime
+			changed = true
+		}
+	}
+
+	return conditions, changed
+}
+
+// truncate returns the given string truncated to the given length
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length]
+}
+
+// IsRecurring returns true if the given condition reports a recurring error
+func IsRecurring(condition configv1alpha1.DiagnosticCondition) bool {
+	return condition.Status == metav1.ConditionTrue
+}
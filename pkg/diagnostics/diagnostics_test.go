@@ -0,0 +1,122 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	fakeConfigClient "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+const meshName = "osm"
+
+func newTestReporter(codes ...Code) *Reporter {
+	return &Reporter{
+		configClient: fakeConfigClient.NewSimpleClientset(),
+		meshName:     meshName,
+		recorder:     &recorder{occurrences: make(map[Code][]occurrence)},
+		codes:        codes,
+	}
+}
+
+func getConditions(t *testing.T, r *Reporter) map[Code]configv1alpha1.DiagnosticCondition {
+	diagnostic, err := r.configClient.ConfigV1alpha1().MeshDiagnostics().Get(context.Background(), meshName, metav1.GetOptions{})
+	trequire.Nil(t, err)
+
+	conditions := make(map[Code]configv1alpha1.DiagnosticCondition)
+	for _, condition := range diagnostic.Status.Conditions {
+		conditions[Code(condition.Code)] = condition
+	}
+	return conditions
+}
+
+func TestReport(t *testing.T) {
+	assert := tassert.New(t)
+	now := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	r := newTestReporter(ErrCertificateIssuance, ErrPolicyBuild)
+
+	// The resource is created with a condition per error code
+	assert.Nil(r.report(now))
+	conditions := getConditions(t, r)
+	assert.Len(conditions, 2)
+	assert.Equal("CertificateIssuanceFailed", conditions[ErrCertificateIssuance].Type)
+	assert.Equal(metav1.ConditionFalse, conditions[ErrCertificateIssuance].Status)
+	assert.Equal(metav1.ConditionFalse, conditions[ErrPolicyBuild].Status)
+
+	// An error occurring fewer times than the threshold is not recurring
+	r.recorder.record(ErrCertificateIssuance, "first error", now)
+	now = now.Add(time.Minute)
+	assert.Nil(r.report(now))
+	conditions = getConditions(t, r)
+	assert.Equal(metav1.ConditionFalse, conditions[ErrCertificateIssuance].Status)
+	assert.Equal(1, conditions[ErrCertificateIssuance].Count)
+	assert.Equal("first error", conditions[ErrCertificateIssuance].Message)
+
+	// An error occurring as many times as the threshold within the window is recurring
+	for i := 1; i < recurringThreshold; i++ {
+		r.recorder.record(ErrCertificateIssuance, "last error", now)
+	}
+	now = now.Add(time.Minute)
+	assert.Nil(r.report(now))
+	conditions = getConditions(t, r)
+	assert.True(IsRecurring(conditions[ErrCertificateIssuance]))
+	assert.Equal(recurringThreshold, conditions[ErrCertificateIssuance].Count)
+	assert.Equal("last error", conditions[ErrCertificateIssuance].Message)
+	assert.Equal(now, conditions[ErrCertificateIssuance].LastTransitionTime.UTC())
+	assert.False(IsRecurring(conditions[ErrPolicyBuild]))
+
+	// The error is no longer recurring once its occurrences are out of the window
+	now = now.Add(window)
+	assert.Nil(r.report(now))
+	conditions = getConditions(t, r)
+	assert.False(IsRecurring(conditions[ErrCertificateIssuance]))
+	assert.Equal(0, conditions[ErrCertificateIssuance].Count)
+	assert.Equal("last error", conditions[ErrCertificateIssuance].Message)
+}
+
+func TestReportPreservesConditionsOfOtherReporters(t *testing.T) {
+	assert := tassert.New(t)
+	now := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	controllerReporter := newTestReporter(ErrCertificateIssuance)
+	injectorReporter := newTestReporter(ErrResourceReconcile)
+	injectorReporter.configClient = controllerReporter.configClient
+
+	for i := 0; i < recurringThreshold; i++ {
+		injectorReporter.recorder.record(ErrResourceReconcile, "reconcile error", now)
+	}
+	assert.Nil(injectorReporter.report(now))
+	assert.Nil(controllerReporter.report(now))
+
+	conditions := getConditions(t, controllerReporter)
+	assert.Len(conditions, 2)
+	assert.True(IsRecurring(conditions[ErrResourceReconcile]))
+	assert.False(IsRecurring(conditions[ErrCertificateIssuance]))
+}
+
+func TestRecord(t *testing.T) {
+	assert := tassert.New(t)
+	now := time.Now()
+
+	Record(ErrPolicyBuild, nil)
+	assert.Empty(defaultRecorder.get(ErrPolicyBuild, now))
+
+	Record(ErrPolicyBuild, errors.New("policy error"))
+	occurrences := defaultRecorder.get(ErrPolicyBuild, time.Now())
+	assert.Len(occurrences, 1)
+	assert.Equal("policy error", occurrences[0].message)
+
+	// The number of occurrences recorded within the window is bounded
+	r := &recorder{occurrences: make(map[Code][]occurrence)}
+	for i := 0; i < maxOccurrences+1; i++ {
+		r.record(ErrPolicyBuild, "policy error", now)
+	}
+	assert.Len(r.get(ErrPolicyBuild, now), maxOccurrences)
+}
@@ -0,0 +1,61 @@
+// Package diagnostics summarizes the recurring errors of the control plane in the MeshDiagnostic resource of the mesh,
+// so that they can be inspected with `osm check` without reading the logs of the control plane.
+// Errors are recorded with an error code identifying the failing operation, and are reported as a condition
+// of the MeshDiagnostic resource per error code.
+package diagnostics
+
+import (
+	"sync"
+	"time"
+
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("diagnostics")
+)
+
+// Code is the error code of an operation of the control plane
+type Code string
+
+const (
+	// ErrCertificateIssuance is the error code of a failure to issue a certificate
+	ErrCertificateIssuance Code = "E1001"
+
+	// ErrResourceReconcile is the error code of a failure to reconcile a resource managed by the control plane
+	ErrResourceReconcile Code = "E1002"
+
+	// ErrPolicyBuild is the error code of a failure to build the configuration of a proxy from the policies of the mesh
+	ErrPolicyBuild Code = "E1003"
+)
+
+// conditionTypes maps the error codes to the type of the condition reporting them
+var conditionTypes = map[Code]string{
+	ErrCertificateIssuance: "CertificateIssuanceFailed",
+	ErrResourceReconcile:   "ResourceReconcileFailed",
+	ErrPolicyBuild:         "PolicyBuildFailed",
+}
+
+// recorder records the occurrences of errors
+type recorder struct {
+	sync.Mutex
+	occurrences map[Code][]occurrence
+}
+
+// occurrence is an occurrence of an error
+type occurrence struct {
+	time    time.Time
+	message string
+}
+
+// Reporter reports the recurring errors of the control plane in the MeshDiagnostic resource of the mesh.
+type Reporter struct {
+	configClient configClientset.Interface
+	meshName     string
+	recorder     *recorder
+
+	// codes are the error codes reported by the reporter, other conditions of the resource are reported by
+	// other components of the control plane and are preserved
+	codes []Code
+}
@@ -6,6 +6,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 
@@ -38,36 +39,67 @@ func (c Client) ListEndpointsForService(svc service.MeshService) []endpoint.Endp
 	log.Trace().Msgf("[%s] Getting Endpoints for service %s on Kubernetes", c.providerIdent, svc)
 	var endpoints []endpoint.Endpoint
 
-	kubernetesEndpoints, err := c.kubeController.GetEndpoints(svc)
-	if err != nil || kubernetesEndpoints == nil {
-		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes Endpoints from cache for service %s", c.providerIdent, svc)
+	endpointSlices, err := c.kubeController.ListEndpointSlicesForService(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes EndpointSlices from cache for service %s", c.providerIdent, svc)
 		return endpoints
 	}
 
-	if !c.kubeController.IsMonitoredNamespace(kubernetesEndpoints.Namespace) {
+	if !c.kubeController.IsMonitoredNamespace(svc.Namespace) {
 		// Doesn't belong to namespaces we are observing
 		return endpoints
 	}
 
-	for _, kubernetesEndpoint := range kubernetesEndpoints.Subsets {
-		for _, address := range kubernetesEndpoint.Addresses {
-			for _, port := range kubernetesEndpoint.Ports {
-				ip := net.ParseIP(address.IP)
+	for _, endpointSlice := range endpointSlices {
+		if endpointSlice.AddressType == discoveryv1beta1.AddressTypeFQDN {
+			// FQDN endpoints are not resolved by the control plane
+			continue
+		}
+		for _, kubernetesEndpoint := range endpointSlice.Endpoints {
+			health, ok := getEndpointHealth(kubernetesEndpoint.Conditions)
+			if !ok {
+				// Endpoints that are not ready and not draining must not receive traffic
+				continue
+			}
+			for _, address := range kubernetesEndpoint.Addresses {
+				ip := net.ParseIP(address)
 				if ip == nil {
-					log.Error().Msgf("[%s] Error parsing IP address %s", c.providerIdent, address.IP)
+					log.Error().Msgf("[%s] Error parsing IP address %s", c.providerIdent, address)
 					break
 				}
-				ept := endpoint.Endpoint{
-					IP:   ip,
-					Port: endpoint.Port(port.Port),
+				for _, port := range endpointSlice.Ports {
+					if port.Port == nil {
+						// A nil port indicates all ports, which is not supported
+						continue
+					}
+					ept := endpoint.Endpoint{
+						IP:     ip,
+						Port:   endpoint.Port(*port.Port),
+						Health: health,
+					}
+					endpoints = append(endpoints, ept)
 				}
-				endpoints = append(endpoints, ept)
 			}
 		}
 	}
 	return endpoints
 }
 
+// getEndpointHealth returns the health status of an endpoint given its conditions, and whether the endpoint can
+// receive traffic. Ready endpoints are healthy, and terminating endpoints that are still serving are draining.
+func getEndpointHealth(conditions discoveryv1beta1.EndpointConditions) (endpoint.HealthStatus, bool) {
+	// A nil ready condition must be interpreted as ready
+	if conditions.Ready == nil || *conditions.Ready {
+		return endpoint.Healthy, true
+	}
+
+	if conditions.Terminating != nil && *conditions.Terminating && conditions.Serving != nil && *conditions.Serving {
+		return endpoint.Draining, true
+	}
+
+	return endpoint.Healthy, false
+}
+
 // ListEndpointsForIdentity retrieves the list of IP addresses for the given service account
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
 func (c Client) ListEndpointsForIdentity(serviceIdentity identity.ServiceIdentity) []endpoint.Endpoint {
@@ -127,8 +159,8 @@ func (c Client) GetServicesForServiceAccount(svcAccount identity.K8sServiceAccou
 	}
 
 	if services.Cardinality() == 0 {
-		log.Error().Err(errServiceNotFound).Msgf("[%s] No services for service account %s", c.providerIdent, svcAccount)
-		return nil, errServiceNotFound
+		log.Error().Err(ErrServiceNotFound).Msgf("[%s] No services for service account %s", c.providerIdent, svcAccount)
+		return nil, ErrServiceNotFound
 	}
 
 	log.Trace().Msgf("[%s] Services for service account %s: %+v", c.providerIdent, svcAccount, services)
@@ -144,14 +176,14 @@ func (c Client) GetServicesForServiceAccount(svcAccount identity.K8sServiceAccou
 func (c Client) GetTargetPortToProtocolMappingForService(svc service.MeshService) (map[uint32]string, error) {
 	portToProtocolMap := make(map[uint32]string)
 
-	endpoints, err := c.kubeController.GetEndpoints(svc)
-	if err != nil || endpoints == nil {
-		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes Endpoints from cache", c.providerIdent)
+	endpointSlices, err := c.kubeController.ListEndpointSlicesForService(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("[%s] Error fetching Kubernetes EndpointSlices from cache", c.providerIdent)
 		return nil, err
 	}
 
-	if !c.kubeController.IsMonitoredNamespace(endpoints.Namespace) {
-		return nil, errors.Errorf("Error fetching endpoints for service %s, namespace %s is not monitored", svc, endpoints.Namespace)
+	if !c.kubeController.IsMonitoredNamespace(svc.Namespace) {
+		return nil, errors.Errorf("Error fetching endpoints for service %s, namespace %s is not monitored", svc, svc.Namespace)
 	}
 
 	// A given port can only map to a single application protocol. Even if the same
@@ -159,17 +191,25 @@ func (c Client) GetTargetPortToProtocolMappingForService(svc service.MeshService
 	// derived from the Service that fronts these endpoints, and a service's port
 	// can only have one application protocol. So for the same port we don't have
 	// to worry about different application protocols being set.
-	for _, endpointSet := range endpoints.Subsets {
-		for _, port := range endpointSet.Ports {
+	for _, endpointSlice := range endpointSlices {
+		for _, port := range endpointSlice.Ports {
+			if port.Port == nil {
+				continue
+			}
+
 			var appProtocol string
 			if port.AppProtocol != nil {
 				appProtocol = *port.AppProtocol
 			} else {
-				appProtocol = k8s.GetAppProtocolFromPortName(port.Name)
-				log.Debug().Msgf("endpoint port name: %s, appProtocol: %s", port.Name, appProtocol)
+				var portName string
+				if port.Name != nil {
+					portName = *port.Name
+				}
+				appProtocol = k8s.GetAppProtocolFromPortName(portName)
+				log.Debug().Msgf("endpoint port name: %s, appProtocol: %s", portName, appProtocol)
 			}
 
-			portToProtocolMap[uint32(port.Port)] = appProtocol
+			portToProtocolMap[uint32(*port.Port)] = appProtocol
 		}
 	}
 
@@ -212,7 +252,7 @@ func (c *Client) GetResolvableEndpointsForService(svc service.MeshService) ([]en
 	kubeService := c.kubeController.GetService(svc)
 	if kubeService == nil {
 		log.Error().Msgf("[%s] Could not find service %s", c.providerIdent, svc)
-		return nil, errServiceNotFound
+		return nil, ErrServiceNotFound
 	}
 
 	if len(kubeService.Spec.ClusterIP) == 0 {
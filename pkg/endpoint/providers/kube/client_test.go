@@ -10,8 +10,10 @@ import (
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -59,21 +61,20 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 
 	It("should correctly return a list of endpoints for a service", func() {
 		// Should be empty for now
-		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: tests.BookbuyerService.Namespace,
-			},
-			Subsets: []corev1.EndpointSubset{
-				{
-					Addresses: []corev1.EndpointAddress{
-						{
-							IP: "8.8.8.8",
-						},
+		mockKubeController.EXPECT().ListEndpointSlicesForService(tests.BookbuyerService).Return([]*discoveryv1beta1.EndpointSlice{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tests.BookbuyerService.Namespace,
+				},
+				AddressType: discoveryv1beta1.AddressTypeIPv4,
+				Endpoints: []discoveryv1beta1.Endpoint{
+					{
+						Addresses: []string{"8.8.8.8"},
 					},
-					Ports: []corev1.EndpointPort{
-						{
-							Port: 88,
-						},
+				},
+				Ports: []discoveryv1beta1.EndpointPort{
+					{
+						Port: pointer.Int32Ptr(88),
 					},
 				},
 			},
@@ -117,6 +118,7 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 
 	It("GetResolvableEndpoints should properly return actual endpoints without ClusterIP when ClusterIP is not set", func() {
 		// Expect the individual pod endpoints, when no cluster IP is assigned to the service
+		protocolTCP := corev1.ProtocolTCP
 		mockKubeController.EXPECT().GetService(tests.BookbuyerService).Return(&corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      tests.BookbuyerService.Name,
@@ -134,23 +136,22 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 			},
 		})
 
-		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: tests.BookbuyerService.Namespace,
-			},
-			Subsets: []corev1.EndpointSubset{
-				{
-					Addresses: []corev1.EndpointAddress{
-						{
-							IP: "8.8.8.8",
-						},
+		mockKubeController.EXPECT().ListEndpointSlicesForService(tests.BookbuyerService).Return([]*discoveryv1beta1.EndpointSlice{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tests.BookbuyerService.Namespace,
+				},
+				AddressType: discoveryv1beta1.AddressTypeIPv4,
+				Endpoints: []discoveryv1beta1.Endpoint{
+					{
+						Addresses: []string{"8.8.8.8"},
 					},
-					Ports: []corev1.EndpointPort{
-						{
-							Name:     "port",
-							Port:     88,
-							Protocol: corev1.ProtocolTCP,
-						},
+				},
+				Ports: []discoveryv1beta1.EndpointPort{
+					{
+						Name:     pointer.StringPtr("port"),
+						Port:     pointer.Int32Ptr(88),
+						Protocol: &protocolTCP,
 					},
 				},
 			},
@@ -168,57 +169,57 @@ var _ = Describe("Test Kube Client Provider (w/o kubecontroller)", func() {
 
 		appProtoHTTP := "http"
 		appProtoTCP := "tcp"
+		protocolTCP := corev1.ProtocolTCP
 
-		mockKubeController.EXPECT().GetEndpoints(tests.BookbuyerService).Return(&corev1.Endpoints{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: tests.BookbuyerService.Namespace,
-			},
-			Subsets: []corev1.EndpointSubset{
-				{
-					Addresses: []corev1.EndpointAddress{
-						{
-							IP: "8.8.8.8",
-						},
+		mockKubeController.EXPECT().ListEndpointSlicesForService(tests.BookbuyerService).Return([]*discoveryv1beta1.EndpointSlice{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tests.BookbuyerService.Namespace,
+				},
+				AddressType: discoveryv1beta1.AddressTypeIPv4,
+				Endpoints: []discoveryv1beta1.Endpoint{
+					{
+						Addresses: []string{"8.8.8.8"},
 					},
-					Ports: []corev1.EndpointPort{
-						{
-							Name:        "port1", // appProtocol specified
-							Port:        70,
-							Protocol:    corev1.ProtocolTCP,
-							AppProtocol: &appProtoTCP,
-						},
-						{
-							Name:        "port2", // appProtocol specified
-							Port:        80,
-							Protocol:    corev1.ProtocolTCP,
-							AppProtocol: &appProtoHTTP,
-						},
-						{
-							Name:     "http-port3", // appProtocol derived from port name
-							Port:     90,
-							Protocol: corev1.ProtocolTCP,
-						},
-						{
-							Name:     "tcp-port4", // appProtocol derived from port name
-							Port:     100,
-							Protocol: corev1.ProtocolTCP,
-						},
-						{
-							Name:     "grpc-port5", // appProtocol derived from port name
-							Port:     110,
-							Protocol: corev1.ProtocolTCP,
-						},
-						{
-							Name:     "no-protocol-prefix", // appProtocol defaults to http
-							Port:     120,
-							Protocol: corev1.ProtocolTCP,
-						},
-						{
-							Name:        "http-prefix",
-							Port:        130,
-							Protocol:    corev1.ProtocolTCP,
-							AppProtocol: &appProtoTCP, // AppProtocol takes precedence over Name
-						},
+				},
+				Ports: []discoveryv1beta1.EndpointPort{
+					{
+						Name:        pointer.StringPtr("port1"), // appProtocol specified
+						Port:        pointer.Int32Ptr(70),
+						Protocol:    &protocolTCP,
+						AppProtocol: &appProtoTCP,
+					},
+					{
+						Name:        pointer.StringPtr("port2"), // appProtocol specified
+						Port:        pointer.Int32Ptr(80),
+						Protocol:    &protocolTCP,
+						AppProtocol: &appProtoHTTP,
+					},
+					{
+						Name:     pointer.StringPtr("http-port3"), // appProtocol derived from port name
+						Port:     pointer.Int32Ptr(90),
+						Protocol: &protocolTCP,
+					},
+					{
+						Name:     pointer.StringPtr("tcp-port4"), // appProtocol derived from port name
+						Port:     pointer.Int32Ptr(100),
+						Protocol: &protocolTCP,
+					},
+					{
+						Name:     pointer.StringPtr("grpc-port5"), // appProtocol derived from port name
+						Port:     pointer.Int32Ptr(110),
+						Protocol: &protocolTCP,
+					},
+					{
+						Name:     pointer.StringPtr("no-protocol-prefix"), // appProtocol defaults to http
+						Port:     pointer.Int32Ptr(120),
+						Protocol: &protocolTCP,
+					},
+					{
+						Name:        pointer.StringPtr("http-prefix"),
+						Port:        pointer.Int32Ptr(130),
+						Protocol:    &protocolTCP,
+						AppProtocol: &appProtoTCP, // AppProtocol takes precedence over Name
 					},
 				},
 			},
@@ -700,3 +701,85 @@ func TestListEndpointsForIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestListEndpointsForServiceConditions(t *testing.T) {
+	testCases := []struct {
+		name              string
+		conditions        discoveryv1beta1.EndpointConditions
+		expectedEndpoints []endpoint.Endpoint
+	}{
+		{
+			name:       "endpoint without conditions is healthy",
+			conditions: discoveryv1beta1.EndpointConditions{},
+			expectedEndpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80, Health: endpoint.Healthy},
+			},
+		},
+		{
+			name:       "ready endpoint is healthy",
+			conditions: discoveryv1beta1.EndpointConditions{Ready: pointer.BoolPtr(true)},
+			expectedEndpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80, Health: endpoint.Healthy},
+			},
+		},
+		{
+			name: "terminating endpoint still serving is draining",
+			conditions: discoveryv1beta1.EndpointConditions{
+				Ready:       pointer.BoolPtr(false),
+				Serving:     pointer.BoolPtr(true),
+				Terminating: pointer.BoolPtr(true),
+			},
+			expectedEndpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80, Health: endpoint.Draining},
+			},
+		},
+		{
+			name: "terminating endpoint no longer serving is excluded",
+			conditions: discoveryv1beta1.EndpointConditions{
+				Ready:       pointer.BoolPtr(false),
+				Serving:     pointer.BoolPtr(false),
+				Terminating: pointer.BoolPtr(true),
+			},
+			expectedEndpoints: nil,
+		},
+		{
+			name:              "endpoint not ready is excluded",
+			conditions:        discoveryv1beta1.EndpointConditions{Ready: pointer.BoolPtr(false)},
+			expectedEndpoints: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().IsMonitoredNamespace(tests.BookbuyerService.Namespace).Return(true).AnyTimes()
+			mockKubeController.EXPECT().ListEndpointSlicesForService(tests.BookbuyerService).Return([]*discoveryv1beta1.EndpointSlice{
+				{
+					AddressType: discoveryv1beta1.AddressTypeIPv4,
+					Endpoints: []discoveryv1beta1.Endpoint{
+						{
+							Addresses:  []string{"10.0.0.1"},
+							Conditions: tc.conditions,
+						},
+					},
+					Ports: []discoveryv1beta1.EndpointPort{{Port: pointer.Int32Ptr(80)}},
+				},
+				{
+					// FQDN endpoints are ignored
+					AddressType: discoveryv1beta1.AddressTypeFQDN,
+					Endpoints:   []discoveryv1beta1.Endpoint{{Addresses: []string{"example.com"}}},
+					Ports:       []discoveryv1beta1.EndpointPort{{Port: pointer.Int32Ptr(80)}},
+				},
+			}, nil)
+
+			provider, err := NewProvider(testclient.NewSimpleClientset(), mockKubeController, "provider", configurator.NewMockConfigurator(mockCtrl))
+			assert.Nil(err)
+
+			assert.Equal(tc.expectedEndpoints, provider.ListEndpointsForService(tests.BookbuyerService))
+		})
+	}
+}
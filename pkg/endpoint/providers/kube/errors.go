@@ -3,6 +3,8 @@ package kube
 import "github.com/pkg/errors"
 
 var (
-	errServiceNotFound = errors.New("service not found")
-	errParseClusterIP  = errors.New("could not parse cluster IP")
+	// ErrServiceNotFound is the error returned when no service matches the given service account or IP
+	ErrServiceNotFound = errors.New("service not found")
+
+	errParseClusterIP = errors.New("could not parse cluster IP")
 )
@@ -77,7 +77,7 @@ func (f fakeClient) GetID() string {
 func (f fakeClient) GetResolvableEndpointsForService(svc service.MeshService) ([]endpoint.Endpoint, error) {
 	endpoints, found := f.endpoints[svc.String()]
 	if !found {
-		return nil, errServiceNotFound
+		return nil, ErrServiceNotFound
 	}
 	return endpoints, nil
 }
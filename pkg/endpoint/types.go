@@ -38,6 +38,9 @@ type Provider interface {
 type Endpoint struct {
 	net.IP `json:"ip"`
 	Port   `json:"port"`
+
+	// Health is the health status of the endpoint as reported by the compute provider
+	Health HealthStatus `json:"health,omitempty"`
 }
 
 func (ep Endpoint) String() string {
@@ -46,3 +49,27 @@ func (ep Endpoint) String() string {
 
 // Port is a numerical type representing a port on which a service is exposed
 type Port uint32
+
+// HealthStatus is the health status of an endpoint
+type HealthStatus int
+
+const (
+	// Healthy is the health status of an endpoint ready to serve traffic. It is the default health status
+	// of an endpoint, for compute providers not reporting the health of endpoints.
+	Healthy HealthStatus = iota
+
+	// Draining is the health status of a terminating endpoint still serving traffic, which must not
+	// receive new connections while existing connections are drained
+	Draining
+)
+
+func (hs HealthStatus) String() string {
+	switch hs {
+	case Healthy:
+		return "healthy"
+	case Draining:
+		return "draining"
+	default:
+		return "unknown"
+	}
+}
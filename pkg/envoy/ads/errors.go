@@ -8,3 +8,4 @@ var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errGrpcClosed = errors.New("grpc closed")
 var errTooManyConnections = errors.New("too many connections")
+var errResourcesNACKed = errors.New("resources NACKed by the proxy")
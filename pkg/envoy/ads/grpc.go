@@ -2,7 +2,9 @@ package ads
 
 import (
 	"io"
+	"strings"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"google.golang.org/grpc/codes"
@@ -10,8 +12,12 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 )
 
+// grpcUserAgentPrefix is the prefix of the user agent name set on the Node by gRPC xDS clients, ex. "gRPC Go", "gRPC Java"
+const grpcUserAgentPrefix = "gRPC"
+
 func receive(requests chan xds_discovery.DiscoveryRequest, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, proxy *envoy.Proxy, quit chan struct{}, proxyRegistry *registry.ProxyRegistry) {
 	defer close(requests)
 	defer close(quit)
@@ -26,7 +32,14 @@ func receive(requests chan xds_discovery.DiscoveryRequest, server *xds_discovery
 			log.Error().Err(recvErr).Msgf("[grpc] Connection error")
 			return
 		}
-		if !proxy.HasPodMetadata() {
+		if request.Node != nil && featureflags.IsProxylessGRPCEnabled() && isProxylessGRPCClient(request.Node) {
+			// The kind of xDS client is known from the Node sent on the first request of the stream
+			proxy.SetKind(envoy.KindProxylessGRPC)
+		}
+		if request.Node != nil && proxy.GetVersion() == nil {
+			recordEnvoyVersion(request.Node, proxy)
+		}
+		if !proxy.HasPodMetadata() && proxy.GetKind() == envoy.KindSidecar {
 			// Set the Pod metadata on the given proxy only once. This could arrive with the first few XDS requests.
 			recordEnvoyPodMetadata(request, proxy, proxyRegistry)
 		}
@@ -46,8 +59,17 @@ func recordEnvoyPodMetadata(request *xds_discovery.DiscoveryRequest, proxy *envo
 			// Set the Pod Metadata, which will be used in the RegisterProxy() invocation below!
 			proxy.PodMetadata = meta
 
+			// The workload metadata set in the node metadata by the sidecar injector is recorded in the access logs of the proxy
+			proxy.SetWorkloadMetadata(envoy.GetWorkloadNodeMetadata(request.Node.Metadata))
+			recordProxyConfigClass(proxy)
+
 			// We call RegisterProxy again, for a second time, on the ProxyRegistry to update the index on pod metadata
 			proxyRegistry.RegisterProxy(proxy) // Second of Two invocations. First one was on establishing the gRPC stream.
 		}
 	}
 }
+
+// isProxylessGRPCClient returns true if the given Node belongs to a gRPC application using the gRPC xDS client
+func isProxylessGRPCClient(node *xds_core.Node) bool {
+	return strings.HasPrefix(node.GetUserAgentName(), grpcUserAgentPrefix)
+}
@@ -0,0 +1,44 @@
+package ads
+
+import (
+	"testing"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestIsProxylessGRPCClient(t *testing.T) {
+	testCases := []struct {
+		name     string
+		node     *xds_core.Node
+		expected bool
+	}{
+		{
+			name:     "Envoy sidecar",
+			node:     &xds_core.Node{UserAgentName: "envoy"},
+			expected: false,
+		},
+		{
+			name:     "gRPC Go xDS client",
+			node:     &xds_core.Node{UserAgentName: "gRPC Go"},
+			expected: true,
+		},
+		{
+			name:     "gRPC Java xDS client",
+			node:     &xds_core.Node{UserAgentName: "gRPC Java"},
+			expected: true,
+		},
+		{
+			name:     "no user agent",
+			node:     &xds_core.Node{},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, isProxylessGRPCClient(tc.node))
+		})
+	}
+}
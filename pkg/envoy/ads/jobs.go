@@ -47,3 +47,47 @@ func (proxyJob *proxyResponseJob) Hash() uint64 {
 	// this avoid out-of-order mishandling of envoy updates by multiple workers
 	return proxyJob.proxy.GetHash()
 }
+
+// proxyNACKJob is the worker pool job handling a NACK sent by a proxy, re-pushing the last resources ACKed by the proxy
+// for the NACKed TypeURI if needed. It is hashed like proxyResponseJob so that it is serialized with the other pushes to the
+// same proxy, and does not block the goroutine receiving the requests of the proxy.
+type proxyNACKJob struct {
+	proxy     *envoy.Proxy
+	adsStream *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer
+	request   *xds_discovery.DiscoveryRequest
+	xdsServer *Server
+
+	// Optional waiter
+	done chan struct{}
+}
+
+// GetDoneCh returns the channel, which when closed, indicates the job has been finished.
+func (nackJob *proxyNACKJob) GetDoneCh() <-chan struct{} {
+	return nackJob.done
+}
+
+// Run implementation for `handleNACK` and `server.sendRollbackResponse` job
+func (nackJob *proxyNACKJob) Run() {
+	defer close(nackJob.done)
+
+	lastACKed := handleNACK(nackJob.proxy, nackJob.request)
+	if lastACKed == nil {
+		return
+	}
+	typeURI := envoy.TypeURI(nackJob.request.TypeUrl)
+	err := nackJob.xdsServer.sendRollbackResponse(typeURI, nackJob.proxy, nackJob.adsStream, lastACKed)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to roll back %s on Envoy with xDS Certificate SerialNumber=%s for PodUUID=%s",
+			typeURI, nackJob.proxy.GetCertificateSerialNumber(), nackJob.proxy.GetPodUID())
+	}
+}
+
+// JobName implementation for this job, for logging purposes
+func (nackJob *proxyNACKJob) JobName() string {
+	return fmt.Sprintf("nackJob-%s", nackJob.proxy.GetCertificateSerialNumber())
+}
+
+// Hash implementation for this job to hash into the worker queues
+func (nackJob *proxyNACKJob) Hash() uint64 {
+	return nackJob.proxy.GetHash()
+}
@@ -0,0 +1,68 @@
+package ads
+
+import (
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/any"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// handleNACK records a NACK sent by the proxy for the latest discovery response of the request's type, and returns the
+// resources last ACKed by the proxy for that type if they should be re-pushed to the proxy, or nil otherwise.
+// Re-pushing the last ACKed resources prevents a proxy from silently keeping a stale configuration while the control plane
+// believes the rejected configuration was sent successfully.
+func handleNACK(proxy *envoy.Proxy, discoveryRequest *xds_discovery.DiscoveryRequest) *envoy.ResourcesSnapshot {
+	typeURI, ok := envoy.ValidURI[discoveryRequest.TypeUrl]
+	if !ok {
+		return nil
+	}
+
+	lastSent := proxy.GetLastSentSnapshot(typeURI)
+	if lastSent == nil || discoveryRequest.ResponseNonce != proxy.GetLastSentNonce(typeURI) {
+		// The NACK is for a response that was since superseded, the latest response is still pending an ACK or NACK
+		log.Debug().Msgf("Proxy SerialNumber=%s PodUID=%s: Ignoring %s NACK for non-latest nonce %s",
+			proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), typeURI.Short(), discoveryRequest.ResponseNonce)
+		return nil
+	}
+
+	proxy.SetLastNACKedSnapshot(typeURI, lastSent)
+	metricsstore.DefaultMetricsStore.ProxyResponseNACKCount.WithLabelValues(typeURI.Short()).Inc()
+	events.GenericEventRecorder().WarnEvent(events.ProxyConfigNACKed,
+		"Proxy with xDS Certificate SerialNumber=%s on Pod with UID=%s rejected %s version %d: %s",
+		proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), typeURI.Short(), lastSent.Version, discoveryRequest.ErrorDetail.GetMessage())
+
+	if lastSent.IsRollback {
+		// Rolling back again would loop as long as the proxy rejects its last ACKed resources
+		log.Error().Msgf("Proxy SerialNumber=%s PodUID=%s: Rollback of %s to the last ACKed resources was rejected, not rolling back again",
+			proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), typeURI.Short())
+		return nil
+	}
+
+	lastACKed := proxy.GetLastACKedSnapshot(typeURI)
+	if lastACKed == nil {
+		log.Error().Msgf("Proxy SerialNumber=%s PodUID=%s: No %s resources were ACKed yet, cannot roll back",
+			proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), typeURI.Short())
+		return nil
+	}
+
+	return lastACKed
+}
+
+// isNACKed returns true if the given resources are the resources of the last discovery response NACKed by the proxy
+// for the given TypeURI. Sending them again would be rejected by the proxy as well, and would replace the configuration
+// the proxy was rolled back to.
+func isNACKed(proxy *envoy.Proxy, typeURI envoy.TypeURI, resources []*any.Any) bool {
+	lastNACKed := proxy.GetLastNACKedSnapshot(typeURI)
+	if lastNACKed == nil || len(lastNACKed.Resources) != len(resources) {
+		return false
+	}
+	for i := range resources {
+		if !proto.Equal(lastNACKed.Resources[i], resources[i]) {
+			return false
+		}
+	}
+	return true
+}
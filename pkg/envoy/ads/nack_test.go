@@ -0,0 +1,164 @@
+package ads
+
+import (
+	"testing"
+
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestHandleNACK(t *testing.T) {
+	newProxy := func() *envoy.Proxy {
+		return envoy.NewProxy(certificate.CommonName("abcd.sa.ns.cluster.local"), "123", nil)
+	}
+	acked := &envoy.ResourcesSnapshot{Version: 1}
+	sent := &envoy.ResourcesSnapshot{Version: 2}
+	rollback := &envoy.ResourcesSnapshot{Version: 3, IsRollback: true}
+	firstSent := &envoy.ResourcesSnapshot{Version: 1}
+
+	testCases := []struct {
+		name             string
+		setup            func(*envoy.Proxy) string
+		expectedRollback *envoy.ResourcesSnapshot
+		expectedNACKed   *envoy.ResourcesSnapshot
+	}{
+		{
+			name: "NACK for the latest nonce rolls back to the last ACKed resources",
+			setup: func(p *envoy.Proxy) string {
+				p.SetLastACKedSnapshot(envoy.TypeCDS, acked)
+				p.SetLastSentSnapshot(envoy.TypeCDS, sent)
+				return p.SetNewNonce(envoy.TypeCDS)
+			},
+			expectedRollback: acked,
+			expectedNACKed:   sent,
+		},
+		{
+			name: "NACK for a stale nonce is ignored",
+			setup: func(p *envoy.Proxy) string {
+				p.SetLastACKedSnapshot(envoy.TypeCDS, acked)
+				p.SetLastSentSnapshot(envoy.TypeCDS, sent)
+				p.SetNewNonce(envoy.TypeCDS)
+				return "stale-nonce"
+			},
+			expectedRollback: nil,
+			expectedNACKed:   nil,
+		},
+		{
+			name: "NACK of a rollback is not rolled back again",
+			setup: func(p *envoy.Proxy) string {
+				p.SetLastACKedSnapshot(envoy.TypeCDS, acked)
+				p.SetLastSentSnapshot(envoy.TypeCDS, rollback)
+				return p.SetNewNonce(envoy.TypeCDS)
+			},
+			expectedRollback: nil,
+			expectedNACKed:   rollback,
+		},
+		{
+			name: "NACK without ACKed resources is not rolled back",
+			setup: func(p *envoy.Proxy) string {
+				p.SetLastSentSnapshot(envoy.TypeCDS, firstSent)
+				return p.SetNewNonce(envoy.TypeCDS)
+			},
+			expectedRollback: nil,
+			expectedNACKed:   firstSent,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			proxy := newProxy()
+			nonce := tc.setup(proxy)
+			request := &xds_discovery.DiscoveryRequest{
+				TypeUrl:       envoy.TypeCDS.String(),
+				ResponseNonce: nonce,
+				ErrorDetail:   status.New(codes.InvalidArgument, "invalid cluster").Proto(),
+			}
+
+			assert.Equal(tc.expectedRollback, handleNACK(proxy, request))
+			assert.Equal(tc.expectedNACKed, proxy.GetLastNACKedSnapshot(envoy.TypeCDS))
+		})
+	}
+}
+
+func TestRespondToRequestRecordsACKedSnapshot(t *testing.T) {
+	assert := tassert.New(t)
+
+	proxy := envoy.NewProxy(certificate.CommonName("abcd.sa.ns.cluster.local"), "123", nil)
+	proxy.SetLastSentVersion(envoy.TypeCDS, 4)
+	sent := &envoy.ResourcesSnapshot{Version: 4}
+	proxy.SetLastSentSnapshot(envoy.TypeCDS, sent)
+	nonce := proxy.SetNewNonce(envoy.TypeCDS)
+
+	respondToRequest(proxy, &xds_discovery.DiscoveryRequest{
+		TypeUrl:       envoy.TypeCDS.String(),
+		VersionInfo:   "4",
+		ResponseNonce: nonce,
+	})
+	assert.Equal(sent, proxy.GetLastACKedSnapshot(envoy.TypeCDS))
+}
+
+func TestIsNACKed(t *testing.T) {
+	newResources := func(values ...string) []*any.Any {
+		var resources []*any.Any
+		for _, v := range values {
+			resource, err := ptypes.MarshalAny(&wrappers.StringValue{Value: v})
+			tassert.Nil(t, err)
+			resources = append(resources, resource)
+		}
+		return resources
+	}
+
+	testCases := []struct {
+		name       string
+		lastNACKed *envoy.ResourcesSnapshot
+		resources  []*any.Any
+		expected   bool
+	}{
+		{
+			name:      "nothing was NACKed",
+			resources: newResources("a"),
+			expected:  false,
+		},
+		{
+			name:       "same resources as NACKed",
+			lastNACKed: &envoy.ResourcesSnapshot{Version: 2, Resources: newResources("a", "b")},
+			resources:  newResources("a", "b"),
+			expected:   true,
+		},
+		{
+			name:       "changed resource",
+			lastNACKed: &envoy.ResourcesSnapshot{Version: 2, Resources: newResources("a", "b")},
+			resources:  newResources("a", "c"),
+			expected:   false,
+		},
+		{
+			name:       "removed resource",
+			lastNACKed: &envoy.ResourcesSnapshot{Version: 2, Resources: newResources("a", "b")},
+			resources:  newResources("a"),
+			expected:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			proxy := envoy.NewProxy(certificate.CommonName("abcd.sa.ns.cluster.local"), "123", nil)
+			if tc.lastNACKed != nil {
+				proxy.SetLastNACKedSnapshot(envoy.TypeCDS, tc.lastNACKed)
+			}
+			assert.Equal(tc.expected, isNACKed(proxy, envoy.TypeCDS, tc.resources))
+			assert.False(isNACKed(proxy, envoy.TypeLDS, tc.resources))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package ads
+
+import (
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// Proxy configuration classes are applied by the sidecar injector only, the xDS configuration of a proxy does not
+// depend on its class. The class is only recorded here for observability, to compare the sidecars of a class to the
+// others during a rollout.
+
+// recordProxyConfigClass records the given proxy in the count of sidecars per proxy configuration class, once its
+// workload metadata is known. Sidecars injected with the mesh-wide settings are not counted.
+func recordProxyConfigClass(proxy *envoy.Proxy) {
+	class := proxy.GetProxyConfigClass()
+	if class == "" {
+		return
+	}
+	log.Debug().Msgf("Proxy SerialNumber=%s PodUID=%s: proxy configuration class %s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), class)
+	metricsstore.DefaultMetricsStore.ProxyConfigClassCount.WithLabelValues(class).Inc()
+}
+
+// forgetProxyConfigClass removes the given disconnected proxy from the count of sidecars per proxy configuration class
+func forgetProxyConfigClass(proxy *envoy.Proxy) {
+	if class := proxy.GetProxyConfigClass(); class != "" {
+		metricsstore.DefaultMetricsStore.ProxyConfigClassCount.WithLabelValues(class).Dec()
+	}
+}
@@ -8,9 +8,13 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pkg/errors"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/diagnostics"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 // Wrapper to create and send a discovery response to an envoy server
@@ -19,7 +23,11 @@ func (s *Server) sendTypeResponse(typeURI envoy.TypeURI, proxy *envoy.Proxy, ser
 	startedAt := time.Now()
 	log.Trace().Msgf("[%s] Creating response for proxy with SerialNumber=%s on Pod with UID=%s", typeURI.Short(), proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
 
-	if discoveryResponse, err := s.newAggregatedDiscoveryResponse(proxy, req, cfg); err != nil {
+	if discoveryResponse, err := s.newAggregatedDiscoveryResponse(proxy, req, cfg); errors.Is(err, errResourcesNACKed) {
+		// The proxy keeps the configuration it was rolled back to until the resources change
+		log.Debug().Msgf("[%s] Not sending resources NACKed by proxy with SerialNumber=%s on Pod with UID=%s", typeURI.Short(), proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return nil
+	} else if err != nil {
 		log.Error().Err(err).Msgf("[%s] Failed to create response for proxy with SerialNumber=%s on Pod with UID=%s", typeURI.Short(), proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
 		xdsPathTimeTrack(startedAt, log.Debug(), typeURI, proxy, false)
 		return err
@@ -29,6 +37,7 @@ func (s *Server) sendTypeResponse(typeURI envoy.TypeURI, proxy *envoy.Proxy, ser
 		return err
 	}
 
+	proxy.SetLastUpdatedAt(time.Now())
 	xdsPathTimeTrack(startedAt, log.Debug(), typeURI, proxy, true)
 	return nil
 }
@@ -49,6 +58,10 @@ func (s *Server) sendResponse(proxy *envoy.Proxy, server *xds_discovery.Aggregat
 		// Handle request when is not provided, and the SDS case
 		var finalReq *xds_discovery.DiscoveryRequest
 		if fullUpdateRequested {
+			if typeURI == envoy.TypeSDS && proxy.GetKind() == envoy.KindProxylessGRPC {
+				// Proxyless gRPC clients load their certificates from the filesystem and never subscribe to SDS
+				continue
+			}
 			if typeURI == envoy.TypeSDS {
 				finalReq = makeRequestForAllSecrets(proxy, s.catalog)
 				if finalReq == nil {
@@ -98,15 +111,14 @@ func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds
 	resources, err := handler(s.catalog, proxy, request, cfg, s.certManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Handler errored TypeURL: %s, proxy: %s", request.TypeUrl, proxy.GetCertificateSerialNumber())
+		// Certificate issuance failures of the SDS handler are recorded by the handler
+		if typeURL != envoy.TypeSDS {
+			diagnostics.Record(diagnostics.ErrPolicyBuild, errors.Errorf("Error building %s configuration for proxy %s: %s", typeURL.Short(), proxy.GetCertificateCommonName(), err))
+		}
 		return nil, errCreatingResponse
 	}
 
-	response := &xds_discovery.DiscoveryResponse{
-		TypeUrl:     request.TypeUrl, // Request TypeURL
-		VersionInfo: strconv.FormatUint(proxy.IncrementLastSentVersion(typeURL), 10),
-		Nonce:       proxy.SetNewNonce(typeURL),
-	}
-
+	var marshalledResources []*any.Any
 	resourcesSent := mapset.NewSet()
 	for _, res := range resources {
 		proto, err := ptypes.MarshalAny(res)
@@ -114,18 +126,65 @@ func (s *Server) newAggregatedDiscoveryResponse(proxy *envoy.Proxy, request *xds
 			log.Error().Err(err).Msgf("Error marshalling resource %s for proxy %s", typeURL, proxy.GetCertificateSerialNumber())
 			continue
 		}
-		response.Resources = append(response.Resources, proto)
+		marshalledResources = append(marshalledResources, proto)
 		resourcesSent.Add(cache.GetResourceName(res))
 	}
 
+	if isNACKed(proxy, typeURL, marshalledResources) {
+		return nil, errResourcesNACKed
+	}
+
+	version := proxy.IncrementLastSentVersion(typeURL)
+	response := &xds_discovery.DiscoveryResponse{
+		TypeUrl:     request.TypeUrl, // Request TypeURL
+		VersionInfo: strconv.FormatUint(version, 10),
+		Nonce:       proxy.SetNewNonce(typeURL),
+		Resources:   marshalledResources,
+	}
+
 	// Validate the generated resources given the request
 	validateRequestResponse(proxy, request, resources)
 
 	// TODO: Move updating resources sent, version, and nonce after "server.Send()" has succeeded
 	proxy.SetLastResourcesSent(typeURL, resourcesSent)
+	proxy.SetLastSentSnapshot(typeURL, &envoy.ResourcesSnapshot{
+		Version:       version,
+		Resources:     response.Resources,
+		ResourceNames: resourcesSent,
+	})
 
 	// NOTE: Never log entire 'response' - will contain secrets!
 	log.Trace().Msgf("Constructed %s response: VersionInfo=%s", response.TypeUrl, response.VersionInfo)
 
 	return response, nil
 }
+
+// sendRollbackResponse re-pushes the given resources, previously ACKed by the proxy, with a new version and nonce
+func (s *Server) sendRollbackResponse(typeURI envoy.TypeURI, proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, lastACKed *envoy.ResourcesSnapshot) error {
+	version := proxy.IncrementLastSentVersion(typeURI)
+	response := &xds_discovery.DiscoveryResponse{
+		TypeUrl:     typeURI.String(),
+		VersionInfo: strconv.FormatUint(version, 10),
+		Nonce:       proxy.SetNewNonce(typeURI),
+		Resources:   lastACKed.Resources,
+	}
+
+	proxy.SetLastResourcesSent(typeURI, lastACKed.ResourceNames)
+	proxy.SetLastSentSnapshot(typeURI, &envoy.ResourcesSnapshot{
+		Version:       version,
+		Resources:     lastACKed.Resources,
+		ResourceNames: lastACKed.ResourceNames,
+		IsRollback:    true,
+	})
+
+	if err := (*server).Send(response); err != nil {
+		log.Error().Err(err).Msgf("[%s] Error sending rollback to proxy with SerialNumber=%s on Pod with UID=%s", typeURI.Short(), proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return err
+	}
+
+	proxy.SetLastUpdatedAt(time.Now())
+	metricsstore.DefaultMetricsStore.ProxyConfigRollbackCount.WithLabelValues(typeURI.Short()).Inc()
+	log.Info().Msgf("[%s] Rolled back proxy with SerialNumber=%s on Pod with UID=%s to version %d ACKed by the proxy, sent as version %d",
+		typeURI.Short(), proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), lastACKed.Version, version)
+	return nil
+}
@@ -119,6 +119,13 @@ var _ = Describe("Test ADS response functions", func() {
 		mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+		mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+		mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
 		mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(certDuration).AnyTimes()
 		mockConfigurator.EXPECT().IsDebugServerEnabled().Return(true).AnyTimes()
 
@@ -184,6 +191,24 @@ var _ = Describe("Test ADS response functions", func() {
 				CertType: envoy.RootCertTypeForHTTPS,
 			}.String()))
 		})
+
+		It("does not send resources NACKed by the proxy", func() {
+			s := NewADSServer(mc, proxyRegistry, true, tests.Namespace, mockConfigurator, mockCertManager)
+
+			err := s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS)
+			Expect(err).To(BeNil())
+			sentResponses := len(*actualResponses)
+			lastSent := proxy.GetLastSentSnapshot(envoy.TypeCDS)
+			Expect(lastSent).ToNot(BeNil())
+
+			// The same resources are not sent again once NACKed
+			proxy.SetLastNACKedSnapshot(envoy.TypeCDS, lastSent)
+			err = s.sendResponse(proxy, &server, nil, mockConfigurator, envoy.TypeCDS)
+			Expect(err).To(BeNil())
+			Expect(len(*actualResponses)).To(Equal(sentResponses))
+			Expect(proxy.GetLastSentVersion(envoy.TypeCDS)).To(Equal(lastSent.Version))
+			Expect(proxy.GetLastSentSnapshot(envoy.TypeCDS)).To(Equal(lastSent))
+		})
 	})
 
 	Context("Test sendSDSResponse()", func() {
@@ -199,6 +224,13 @@ var _ = Describe("Test ADS response functions", func() {
 		mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+		mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+		mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
 		mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(certDuration).AnyTimes()
 		mockConfigurator.EXPECT().IsDebugServerEnabled().Return(true).AnyTimes()
 
@@ -0,0 +1,146 @@
+package ads
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/workerpool"
+)
+
+// pushPriority is the priority class of an xDS push, lower values are dispatched first
+type pushPriority int
+
+const (
+	// pushPriorityRequest is the priority of pushes responding to a request from a proxy, which includes
+	// the requests of proxies that just connected and are waiting for their initial configuration
+	pushPriorityRequest pushPriority = iota
+
+	// pushPriorityUpdate is the priority of pushes initiated by the control plane, such as config updates
+	// broadcasted to all proxies and certificate rotations
+	pushPriorityUpdate
+)
+
+// pendingPush is an xDS push waiting to be dispatched by the pushScheduler
+type pendingPush struct {
+	job      workerpool.Job
+	priority pushPriority
+
+	// staleSince is the time a discovery response was last sent to the proxy, zero if none was sent yet
+	staleSince time.Time
+
+	// seq orders pushes of the same priority and staleness in the order they were scheduled
+	seq uint64
+}
+
+// pushQueue is a priority queue of pending pushes implementing heap.Interface.
+// Pushes are ordered by priority class, then by the staleness of the configuration of the proxy.
+type pushQueue []*pendingPush
+
+func (q pushQueue) Len() int { return len(q) }
+
+func (q pushQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	if !q[i].staleSince.Equal(q[j].staleSince) {
+		return q[i].staleSince.Before(q[j].staleSince)
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q pushQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *pushQueue) Push(x interface{}) {
+	*q = append(*q, x.(*pendingPush))
+}
+
+func (q *pushQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// pushScheduler limits the number of xDS responses computed and sent to proxies concurrently, so that the
+// control plane is not saturated when many proxies need to be updated simultaneously. Pushes exceeding the
+// limit are queued, and dispatched to the worker pool by priority: responses to proxy requests first, then
+// control plane updates to the proxies whose configuration is the most stale.
+type pushScheduler struct {
+	workqueues *workerpool.WorkerPool
+	cfg        configurator.Configurator
+
+	mutex   sync.Mutex
+	queue   pushQueue
+	running int
+	seq     uint64
+}
+
+// newPushScheduler creates a new pushScheduler dispatching pushes to the given worker pool
+func newPushScheduler(workqueues *workerpool.WorkerPool, cfg configurator.Configurator) *pushScheduler {
+	return &pushScheduler{
+		workqueues: workqueues,
+		cfg:        cfg,
+	}
+}
+
+// schedule queues the given job with the given priority and the time the configuration of the job's proxy is stale since,
+// and returns the channel closed once the job is done. The job's proxy must not be pushed to concurrently, which is
+// guaranteed by hashing the jobs of a proxy to the same worker. Waiting on the returned channel before scheduling
+// another job for the same proxy additionally guarantees the jobs are dispatched in the order they were scheduled.
+func (ps *pushScheduler) schedule(job workerpool.Job, priority pushPriority, staleSince time.Time) <-chan struct{} {
+	ps.mutex.Lock()
+	ps.seq++
+	heap.Push(&ps.queue, &pendingPush{
+		job:        job,
+		priority:   priority,
+		staleSince: staleSince,
+		seq:        ps.seq,
+	})
+	toDispatch := ps.nextLocked()
+	ps.mutex.Unlock()
+
+	ps.dispatch(toDispatch)
+	return job.GetDoneCh()
+}
+
+// maxConcurrency returns the max number of pushes running concurrently
+func (ps *pushScheduler) maxConcurrency() int {
+	if limit := ps.cfg.GetMaxConcurrentXDSPushes(); limit > 0 {
+		return limit
+	}
+	return ps.workqueues.GetWorkerNumber()
+}
+
+// nextLocked dequeues the pushes that can run within the concurrency limit. It must be called with the mutex held.
+func (ps *pushScheduler) nextLocked() []workerpool.Job {
+	var jobs []workerpool.Job
+	maxConcurrency := ps.maxConcurrency()
+	for ps.running < maxConcurrency && ps.queue.Len() > 0 {
+		push := heap.Pop(&ps.queue).(*pendingPush)
+		jobs = append(jobs, push.job)
+		ps.running++
+	}
+	metricsstore.DefaultMetricsStore.ProxyPendingPushCount.Set(float64(ps.queue.Len()))
+	return jobs
+}
+
+// dispatch adds the given jobs to the worker pool, and dispatches queued pushes as they complete
+func (ps *pushScheduler) dispatch(jobs []workerpool.Job) {
+	for _, job := range jobs {
+		done := ps.workqueues.AddJob(job)
+		go func() {
+			<-done
+			ps.mutex.Lock()
+			ps.running--
+			toDispatch := ps.nextLocked()
+			ps.mutex.Unlock()
+
+			ps.dispatch(toDispatch)
+		}()
+	}
+}
@@ -0,0 +1,171 @@
+package ads
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/workerpool"
+)
+
+// testPushJob is a workerpool job blocking until released, and recording the order in which jobs run
+type testPushJob struct {
+	name    string
+	hash    uint64
+	release chan struct{}
+	done    chan struct{}
+	onRun   func(name string)
+}
+
+func newTestPushJob(name string, hash uint64, onRun func(name string)) *testPushJob {
+	return &testPushJob{
+		name:    name,
+		hash:    hash,
+		release: make(chan struct{}),
+		done:    make(chan struct{}),
+		onRun:   onRun,
+	}
+}
+
+func (j *testPushJob) GetDoneCh() <-chan struct{} {
+	return j.done
+}
+
+func (j *testPushJob) Run() {
+	j.onRun(j.name)
+	<-j.release
+	close(j.done)
+}
+
+func (j *testPushJob) JobName() string {
+	return j.name
+}
+
+func (j *testPushJob) Hash() uint64 {
+	return j.hash
+}
+
+func TestPushQueue(t *testing.T) {
+	assert := tassert.New(t)
+
+	now := time.Now()
+	pushes := []*pendingPush{
+		{job: newTestPushJob("update-recent", 0, nil), priority: pushPriorityUpdate, staleSince: now, seq: 1},
+		{job: newTestPushJob("update-stale", 0, nil), priority: pushPriorityUpdate, staleSince: now.Add(-time.Minute), seq: 2},
+		{job: newTestPushJob("request", 0, nil), priority: pushPriorityRequest, staleSince: now, seq: 3},
+		{job: newTestPushJob("update-never-sent", 0, nil), priority: pushPriorityUpdate, staleSince: time.Time{}, seq: 4},
+		{job: newTestPushJob("update-recent-later", 0, nil), priority: pushPriorityUpdate, staleSince: now, seq: 5},
+	}
+
+	var queue pushQueue
+	for _, push := range pushes {
+		heap.Push(&queue, push)
+	}
+
+	var actual []string
+	for queue.Len() > 0 {
+		actual = append(actual, heap.Pop(&queue).(*pendingPush).job.JobName())
+	}
+
+	assert.Equal([]string{"request", "update-never-sent", "update-stale", "update-recent", "update-recent-later"}, actual)
+}
+
+func TestPushSchedulerConcurrency(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const maxConcurrency = 2
+	const numJobs = 6
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetMaxConcurrentXDSPushes().Return(maxConcurrency).AnyTimes()
+
+	workqueues := workerpool.NewWorkerPool(numJobs)
+	defer workqueues.Stop()
+	scheduler := newPushScheduler(workqueues, mockConfigurator)
+
+	var mutex sync.Mutex
+	var started []string
+	onRun := func(name string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		started = append(started, name)
+	}
+	startedCount := func() int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(started)
+	}
+
+	var jobs []*testPushJob
+	var doneChs []<-chan struct{}
+	for i := 0; i < numJobs; i++ {
+		job := newTestPushJob(fmt.Sprintf("job-%d", i), uint64(i), onRun)
+		jobs = append(jobs, job)
+		doneChs = append(doneChs, scheduler.schedule(job, pushPriorityUpdate, time.Time{}))
+	}
+
+	// Only maxConcurrency jobs run until jobs complete
+	assert.Eventually(func() bool { return startedCount() == maxConcurrency }, time.Second, 10*time.Millisecond)
+	assert.Never(func() bool { return startedCount() > maxConcurrency }, 100*time.Millisecond, 10*time.Millisecond)
+
+	for i, job := range jobs {
+		close(job.release)
+		<-doneChs[i]
+	}
+	assert.Equal(numJobs, startedCount())
+}
+
+func TestPushSchedulerPriority(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetMaxConcurrentXDSPushes().Return(1).AnyTimes()
+
+	workqueues := workerpool.NewWorkerPool(4)
+	defer workqueues.Stop()
+	scheduler := newPushScheduler(workqueues, mockConfigurator)
+
+	var mutex sync.Mutex
+	var started []string
+	onRun := func(name string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		started = append(started, name)
+	}
+
+	// The first job occupies the only slot, the following jobs are queued
+	now := time.Now()
+	blocking := newTestPushJob("blocking", 0, onRun)
+	blockingDone := scheduler.schedule(blocking, pushPriorityUpdate, now)
+
+	updateRecent := newTestPushJob("update-recent", 1, onRun)
+	updateStale := newTestPushJob("update-stale", 2, onRun)
+	request := newTestPushJob("request", 3, onRun)
+	queued := []*testPushJob{updateRecent, updateStale, request}
+
+	var doneChs []<-chan struct{}
+	doneChs = append(doneChs, scheduler.schedule(updateRecent, pushPriorityUpdate, now))
+	doneChs = append(doneChs, scheduler.schedule(updateStale, pushPriorityUpdate, now.Add(-time.Minute)))
+	doneChs = append(doneChs, scheduler.schedule(request, pushPriorityRequest, now))
+
+	for _, job := range queued {
+		close(job.release)
+	}
+	close(blocking.release)
+	<-blockingDone
+	for _, done := range doneChs {
+		<-done
+	}
+
+	assert.Equal([]string{"blocking", "request", "update-stale", "update-recent"}, started)
+}
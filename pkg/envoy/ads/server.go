@@ -32,6 +32,7 @@ const (
 
 // NewADSServer creates a new Aggregated Discovery Service server
 func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.ProxyRegistry, enableDebug bool, osmNamespace string, cfg configurator.Configurator, certManager certificate.Manager) *Server {
+	workqueues := workerpool.NewWorkerPool(workerPoolSize)
 	server := Server{
 		catalog:       meshCatalog,
 		proxyRegistry: proxyRegistry,
@@ -47,7 +48,8 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.Pro
 		certManager:    certManager,
 		xdsMapLogMutex: sync.Mutex{},
 		xdsLog:         make(map[certificate.CommonName]map[envoy.TypeURI][]time.Time),
-		workqueues:     workerpool.NewWorkerPool(workerPoolSize),
+		workqueues:     workqueues,
+		pushScheduler:  newPushScheduler(workqueues, cfg),
 	}
 
 	return &server
@@ -14,6 +14,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
@@ -43,9 +44,15 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 	//       Details on which Pod this Envoy is fronting will arrive via xDS in the NODE_ID string.
 	//       When this arrives we will call RegisterProxy() a second time - this time with Pod context!
 	proxy := envoy.NewProxy(certCommonName, certSerialNumber, utils.GetIPFromContext(server.Context()))
+	if featureflags.IsIngressGatewayEnabled() && isIngressGatewayProxy(proxy, s.osmNamespace) {
+		// The ingress gateway is not fronting a Pod in the mesh, it is known from the identity in its xDS certificate
+		proxy.SetKind(envoy.KindIngressGateway)
+	}
 	s.proxyRegistry.RegisterProxy(proxy) // First of Two invocations.  Second one will be during xDS hand-shake!
 
 	defer s.proxyRegistry.UnregisterProxy(proxy)
+	defer forgetEnvoyVersion(proxy)
+	defer forgetProxyConfigClass(proxy)
 
 	ctx, cancel := context.WithCancel(server.Context())
 	defer cancel()
@@ -92,6 +99,18 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 				return errGrpcClosed
 			}
 
+			if discoveryRequest.ErrorDetail != nil {
+				// The NACK is handled by the worker of the proxy, serialized with the pushes to the proxy, without waiting
+				// for a possible rollback to be sent
+				s.pushScheduler.schedule(&proxyNACKJob{
+					proxy:     proxy,
+					adsStream: &server,
+					request:   &discoveryRequest,
+					xdsServer: s,
+					done:      make(chan struct{}),
+				}, pushPriorityRequest, proxy.GetLastUpdatedAt())
+			}
+
 			// This function call runs xDS proto state machine given DiscoveryRequest as input.
 			// It's output is the decision to reply or not to this request.
 			if !respondToRequest(proxy, &discoveryRequest) {
@@ -100,11 +119,14 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 			typesRequest := []envoy.TypeURI{envoy.TypeURI(discoveryRequest.TypeUrl)}
 
-			<-s.workqueues.AddJob(newJob(typesRequest, &discoveryRequest))
+			<-s.pushScheduler.schedule(newJob(typesRequest, &discoveryRequest), pushPriorityRequest, proxy.GetLastUpdatedAt())
 
 		case <-broadcastUpdate:
 			log.Info().Msgf("Proxy SerialNumber=%s PodUID=%s: Broadcast wake", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
 
+			// Broadcasts received while the previous push was queued are covered by this full configuration update
+			drainBroadcasts(broadcastUpdate)
+
 			// Per protocol, we have to wait for the proxy to go through init phase (initial no-nonce request),
 			// otherwise we will be generating versions that will be ignored as empty nonce will generate a new version anyway.
 			// We only have to push an update from control plane if we have provided already something before.
@@ -115,7 +137,7 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			}
 
 			// Queue a full configuration update
-			<-s.workqueues.AddJob(newJob(envoy.XDSResponseOrder, nil))
+			<-s.pushScheduler.schedule(newJob(envoy.XDSResponseOrder, nil), pushPriorityUpdate, proxy.GetLastUpdatedAt())
 
 		case certUpdateMsg := <-certAnnouncement:
 			cert := certUpdateMsg.(events.PubSubMessage).NewObj.(certificate.Certificater)
@@ -126,12 +148,23 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 
 				// Empty DiscoveryRequest should create the SDS specific request
 				// Prepare to queue the SDS proxy response job on the worker pool
-				<-s.workqueues.AddJob(newJob([]envoy.TypeURI{envoy.TypeSDS}, nil))
+				<-s.pushScheduler.schedule(newJob([]envoy.TypeURI{envoy.TypeSDS}, nil), pushPriorityUpdate, proxy.GetLastUpdatedAt())
 			}
 		}
 	}
 }
 
+// drainBroadcasts discards the pending broadcast notifications on the given channel
+func drainBroadcasts(broadcastUpdate <-chan interface{}) {
+	for {
+		select {
+		case <-broadcastUpdate:
+		default:
+			return
+		}
+	}
+}
+
 // shouldPushUpdate handles allowing new updates to envoy from control-plane driven config changes.
 // Its use is to make sure we don't unintentintionally push new versions if at least a first request has not arrived yet.
 func shouldPushUpdate(proxy *envoy.Proxy) bool {
@@ -194,9 +227,7 @@ func respondToRequest(proxy *envoy.Proxy, discoveryRequest *xds_discovery.Discov
 	if discoveryRequest.ErrorDetail != nil {
 		log.Error().Msgf("Proxy SerialNumber=%s PodUID=%s: [NACK] err: \"%s\" for nonce %s, last version applied on request %s",
 			proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), discoveryRequest.ErrorDetail, discoveryRequest.ResponseNonce, discoveryRequest.VersionInfo)
-		// TODO: if NACK's on our latest nonce, we can also update lastAppliedVersion
-		// TODO: if the NACK's nonce is our latest nonce, we should retry to avoid leaving the envoy in a wrong config state and update
-		// last applied version to this requests one's, as it tells us what version is the proxy using.
+		// NACKs on the latest nonce are rolled back to the last ACKed resources by handleNACK
 		return false
 	}
 
@@ -234,6 +265,9 @@ func respondToRequest(proxy *envoy.Proxy, discoveryRequest *xds_discovery.Discov
 	// Nonces match
 	// At this point, there is no error and nonces match, it is guaranteed an ACK with last sent version.
 	proxy.SetLastAppliedVersion(typeURL, requestVersion)
+	if lastSent := proxy.GetLastSentSnapshot(typeURL); lastSent != nil && lastSent.Version == requestVersion {
+		proxy.SetLastACKedSnapshot(typeURL, lastSent)
+	}
 
 	// ----
 	// What's left is to check if the resources listed are the same. If they are not, we must respond
@@ -294,3 +328,14 @@ func isCNforProxy(proxy *envoy.Proxy, cn certificate.CommonName) bool {
 	identityForCN := identity.K8sServiceAccount{Name: chunks[0], Namespace: chunks[1]}
 	return identityForCN == proxyIdentity
 }
+
+// isIngressGatewayProxy returns true if the given proxy's xDS certificate was issued to the OSM managed ingress gateway,
+// whose identity is the ingress gateway's service account in the OSM namespace.
+func isIngressGatewayProxy(proxy *envoy.Proxy, osmNamespace string) bool {
+	proxyIdentity, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
+	if err != nil {
+		return false
+	}
+
+	return proxyIdentity == identity.K8sServiceAccount{Name: constants.OSMIngressGatewayName, Namespace: osmNamespace}
+}
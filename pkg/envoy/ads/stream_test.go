@@ -51,3 +51,57 @@ func TestIsCNForProxy(t *testing.T) {
 		})
 	}
 }
+
+func TestIsIngressGatewayProxy(t *testing.T) {
+	assert := tassert.New(t)
+
+	certSerialNumber := certificate.SerialNumber("123456")
+
+	testCases := []struct {
+		name     string
+		proxy    *envoy.Proxy
+		expected bool
+	}{
+		{
+			name:     "proxy with the ingress gateway identity",
+			proxy:    envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.osm-ingress-gateway.osm-system", uuid.New())), certSerialNumber, nil),
+			expected: true,
+		},
+		{
+			name:     "proxy with the ingress gateway service account in another namespace",
+			proxy:    envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.osm-ingress-gateway.default", uuid.New())), certSerialNumber, nil),
+			expected: false,
+		},
+		{
+			name:     "sidecar proxy",
+			proxy:    envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.svc-acc.osm-system", uuid.New())), certSerialNumber, nil),
+			expected: false,
+		},
+		{
+			name:     "invalid xDS certificate CN",
+			proxy:    envoy.NewProxy(certificate.CommonName("some-cn"), certSerialNumber, nil),
+			expected: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
+			assert.Equal(tc.expected, isIngressGatewayProxy(tc.proxy, "osm-system"))
+		})
+	}
+}
+
+func TestDrainBroadcasts(t *testing.T) {
+	assert := tassert.New(t)
+
+	broadcastUpdate := make(chan interface{}, 3)
+	broadcastUpdate <- struct{}{}
+	broadcastUpdate <- struct{}{}
+
+	drainBroadcasts(broadcastUpdate)
+	assert.Len(broadcastUpdate, 0)
+
+	// Draining an empty channel does not block
+	drainBroadcasts(broadcastUpdate)
+	assert.Len(broadcastUpdate, 0)
+}
@@ -33,4 +33,5 @@ type Server struct {
 	certManager    certificate.Manager
 	ready          bool
 	workqueues     *workerpool.WorkerPool
+	pushScheduler  *pushScheduler
 }
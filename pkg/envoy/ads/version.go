@@ -0,0 +1,28 @@
+package ads
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// recordEnvoyVersion records the version of the Envoy build reported by the given Node on the given proxy
+func recordEnvoyVersion(node *xds_core.Node, proxy *envoy.Proxy) {
+	version, ok := envoy.GetNodeVersion(node)
+	if !ok {
+		return
+	}
+	log.Debug().Msgf("Proxy SerialNumber=%s PodUID=%s: Envoy version %s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), version)
+	proxy.SetVersion(version)
+	metricsstore.DefaultMetricsStore.ProxyVersionCount.WithLabelValues(version.String()).Inc()
+}
+
+// forgetEnvoyVersion removes the given disconnected proxy from the count of proxies per Envoy version, and from the
+// count of proxies per withheld feature
+func forgetEnvoyVersion(proxy *envoy.Proxy) {
+	if version := proxy.GetVersion(); version != nil {
+		metricsstore.DefaultMetricsStore.ProxyVersionCount.WithLabelValues(version.String()).Dec()
+	}
+	envoy.ForgetWithheldFeatures(proxy)
+}
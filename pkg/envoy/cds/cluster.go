@@ -1,6 +1,7 @@
 package cds
 
 import (
+	"sort"
 	"time"
 
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
@@ -11,6 +12,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -30,7 +32,7 @@ const (
 func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
 	clusterName := upstreamSvc.String()
 	marshalledUpstreamTLSContext, err := ptypes.MarshalAny(
-		envoy.GetUpstreamTLSContext(downstreamIdentity, upstreamSvc))
+		envoy.GetUpstreamTLSContext(downstreamIdentity, upstreamSvc, cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +49,7 @@ func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, upst
 		ProtocolSelection:    xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL,
 		Http2ProtocolOptions: &xds_core.Http2ProtocolOptions{},
 	}
+	applyHTTPProtocolOptions(remoteCluster, cfg.GetHTTPProtocolOptions(upstreamSvc))
 
 	if cfg.IsPermissiveTrafficPolicyMode() {
 		// Since no traffic policies exist with permissive mode, rely on cluster provided service discovery.
@@ -57,8 +60,50 @@ func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, upst
 		remoteCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS}
 		remoteCluster.EdsClusterConfig = &xds_cluster.Cluster_EdsClusterConfig{EdsConfig: envoy.GetADSConfigSource()}
 		remoteCluster.LbPolicy = xds_cluster.Cluster_ROUND_ROBIN
+
+		// The endpoints of a service exposing multiple ports are labeled with the service ports they serve by EDS
+		remoteCluster.LbSubsetConfig = envoy.GetPortSubsetConfig(envoy.ServicePortSubsetKey)
+	}
+
+	return remoteCluster, nil
+}
+
+// getProxylessGRPCUpstreamServiceCluster returns a cluster corresponding to the given upstream service for a proxyless gRPC client.
+// gRPC clients only support EDS based clusters, so the cluster relies on EDS irrespective of permissive mode.
+func getProxylessGRPCUpstreamServiceCluster(upstreamSvc service.MeshService, upstreamIdentities []identity.ServiceIdentity) (*xds_cluster.Cluster, error) {
+	marshalledUpstreamTLSContext, err := ptypes.MarshalAny(
+		envoy.GetProxylessGRPCUpstreamTLSContext(upstreamSvc, upstreamIdentities))
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_cluster.Cluster{
+		Name:                 upstreamSvc.String(),
+		ConnectTimeout:       ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS},
+		EdsClusterConfig:     &xds_cluster.Cluster_EdsClusterConfig{EdsConfig: envoy.GetADSConfigSource()},
+		LbPolicy:             xds_cluster.Cluster_ROUND_ROBIN,
+		TransportSocket: &xds_core.TransportSocket{
+			Name: wellknown.TransportSocketTls,
+			ConfigType: &xds_core.TransportSocket_TypedConfig{
+				TypedConfig: marshalledUpstreamTLSContext,
+			},
+		},
+	}, nil
+}
+
+// getIngressGatewayUpstreamServiceCluster returns a cluster corresponding to the given upstream service for the OSM managed ingress gateway.
+// The ingress gateway does not intercept traffic destined to the original destination, so the cluster relies on EDS irrespective of permissive mode.
+func getIngressGatewayUpstreamServiceCluster(gatewayIdentity identity.ServiceIdentity, upstreamSvc service.MeshService, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
+	remoteCluster, err := getUpstreamServiceCluster(gatewayIdentity, upstreamSvc, cfg)
+	if err != nil {
+		return nil, err
 	}
 
+	remoteCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS}
+	remoteCluster.EdsClusterConfig = &xds_cluster.Cluster_EdsClusterConfig{EdsConfig: envoy.GetADSConfigSource()}
+	remoteCluster.LbPolicy = xds_cluster.Cluster_ROUND_ROBIN
+
 	return remoteCluster, nil
 }
 
@@ -106,7 +151,14 @@ func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName serv
 		return nil, err
 	}
 
+	// Endpoints are added in the order of their ports so that the cluster does not change across recomputations
+	var sortedPorts []uint32
 	for port := range ports {
+		sortedPorts = append(sortedPorts, port)
+	}
+	sort.Slice(sortedPorts, func(i, j int) bool { return sortedPorts[i] < sortedPorts[j] })
+
+	for _, port := range sortedPorts {
 		localityEndpoint := &xds_endpoint.LocalityLbEndpoints{
 			Locality: &xds_core.Locality{
 				Zone: "zone",
@@ -122,14 +174,30 @@ func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName serv
 				},
 			}},
 		}
+		if len(sortedPorts) > 1 {
+			localityEndpoint.LbEndpoints[0].Metadata = envoy.GetPortSubsetMetadata(envoy.TargetPortSubsetKey, []uint32{port})
+		}
 		xdsCluster.LoadAssignment.Endpoints = append(xdsCluster.LoadAssignment.Endpoints, localityEndpoint)
 	}
 
+	// The traffic received on a port of a service exposing multiple ports is forwarded to the endpoint of the same target port
+	if len(sortedPorts) > 1 {
+		xdsCluster.LbSubsetConfig = envoy.GetPortSubsetConfig(envoy.TargetPortSubsetKey)
+	}
+
 	return &xdsCluster, nil
 }
 
+// applyHTTPProtocolOptions configures the given cluster of a service with the given options of the HTTP connections of
+// the service. HTTP/1.1 trailers are only forwarded to the service when they are enabled on the cluster.
+func applyHTTPProtocolOptions(cluster *xds_cluster.Cluster, options configurator.HTTPProtocolOptions) {
+	if options.EnableTrailers {
+		cluster.HttpProtocolOptions = &xds_core.Http1ProtocolOptions{EnableTrailers: true}
+	}
+}
+
 // getPrometheusCluster returns an Envoy Cluster responsible for scraping metrics by Prometheus
-func getPrometheusCluster() *xds_cluster.Cluster {
+func getPrometheusCluster(proxy *envoy.Proxy) *xds_cluster.Cluster {
 	return &xds_cluster.Cluster{
 		Name:           constants.EnvoyMetricsCluster,
 		AltStatName:    constants.EnvoyMetricsCluster,
@@ -146,7 +214,7 @@ func getPrometheusCluster() *xds_cluster.Cluster {
 					LbEndpoints: []*xds_endpoint.LbEndpoint{{
 						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 							Endpoint: &xds_endpoint.Endpoint{
-								Address: envoy.GetAddress(constants.LocalhostIPAddress, constants.EnvoyAdminPort),
+								Address: getEnvoyAdminAddress(proxy),
 							},
 						},
 						LoadBalancingWeight: &wrappers.UInt32Value{
@@ -159,9 +227,18 @@ func getPrometheusCluster() *xds_cluster.Cluster {
 	}
 }
 
+// getEnvoyAdminAddress returns the address of Envoy's admin interface, which serves the metrics scraped by Prometheus,
+// bound to a Unix domain socket if the given proxy was injected with it
+func getEnvoyAdminAddress(proxy *envoy.Proxy) *xds_core.Address {
+	if proxy.IsEnvoyAdminUDSEnabled() {
+		return envoy.GetPipeAddress(constants.EnvoyAdminSocketPath)
+	}
+	return envoy.GetAddress(constants.LocalhostIPAddress, constants.EnvoyAdminPort)
+}
+
 // getEgressClusters returns a slice of XDS cluster objects for the given egress cluster configs.
 // If the cluster config is invalid, an error is logged and the corresponding cluster config is ignored.
-func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*xds_cluster.Cluster {
+func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig, proxy *envoy.Proxy) []*xds_cluster.Cluster {
 	if clusterConfigs == nil {
 		return nil
 	}
@@ -173,11 +250,18 @@ func getEgressClusters(clusterConfigs []*trafficpolicy.EgressClusterConfig) []*x
 			continue
 		}
 
-		if cluster, err := GetDNSResolvableEgressCluster(config); err != nil {
+		cluster, err := GetDNSResolvableEgressCluster(config)
+		if err != nil {
 			log.Error().Err(err).Msg("Error building cluster for the given egress cluster config")
-		} else {
-			egressClusters = append(egressClusters, cluster)
+			continue
+		}
+
+		// The statistics of clusters built for an Egress policy are tagged with the policy and the destination, by
+		// the stats tags of the proxies bootstrapped with them. Other proxies keep the statistics named after the cluster.
+		if config.PolicyName != "" && proxy.HasEgressStatsTags() {
+			cluster.AltStatName = envoy.GetEgressClusterStatName(config.PolicyName, config.Host, config.Port)
 		}
+		egressClusters = append(egressClusters, cluster)
 	}
 
 	return egressClusters
@@ -199,7 +283,7 @@ func GetDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*
 		return nil, errors.New("Invalid egress cluster config: Port unspecified")
 	}
 
-	return &xds_cluster.Cluster{
+	cluster := &xds_cluster.Cluster{
 		Name:           config.Name,
 		AltStatName:    config.Name,
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
@@ -224,5 +308,44 @@ func GetDNSResolvableEgressCluster(config *trafficpolicy.EgressClusterConfig) (*
 				},
 			},
 		},
-	}, nil
+	}
+	applyConnectionSettings(cluster, config.ConnectionSettings)
+
+	return cluster, nil
+}
+
+// applyConnectionSettings configures the circuit breaking thresholds, connect timeout and HTTP protocol options of the
+// given cluster from the given connection settings. Settings that are unspecified leave Envoy's defaults in place.
+func applyConnectionSettings(cluster *xds_cluster.Cluster, settings *policyV1alpha1.ConnectionSettingsSpec) {
+	if settings == nil {
+		return
+	}
+
+	threshold := &xds_cluster.CircuitBreakers_Thresholds{}
+	if tcp := settings.TCP; tcp != nil {
+		if tcp.MaxConnections != nil {
+			threshold.MaxConnections = &wrappers.UInt32Value{Value: *tcp.MaxConnections}
+		}
+		if tcp.ConnectTimeout != nil {
+			cluster.ConnectTimeout = ptypes.DurationProto(tcp.ConnectTimeout.Duration)
+		}
+	}
+	if http := settings.HTTP; http != nil {
+		if http.MaxPendingRequests != nil {
+			threshold.MaxPendingRequests = &wrappers.UInt32Value{Value: *http.MaxPendingRequests}
+		}
+		if http.MaxRequests != nil {
+			threshold.MaxRequests = &wrappers.UInt32Value{Value: *http.MaxRequests}
+		}
+		if http.MaxRetries != nil {
+			threshold.MaxRetries = &wrappers.UInt32Value{Value: *http.MaxRetries}
+		}
+		if http.MaxRequestsPerConnection != nil {
+			cluster.MaxRequestsPerConnection = &wrappers.UInt32Value{Value: *http.MaxRequestsPerConnection}
+		}
+	}
+
+	cluster.CircuitBreakers = &xds_cluster.CircuitBreakers{
+		Thresholds: []*xds_cluster.CircuitBreakers_Thresholds{threshold},
+	}
 }
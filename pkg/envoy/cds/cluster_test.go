@@ -12,8 +12,12 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -27,6 +31,11 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 
 	mockCtrl := gomock.NewController(t)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
 
 	downstreamSvcAccount := tests.BookbuyerServiceIdentity
 	upstreamSvc := tests.BookstoreV1Service
@@ -37,6 +46,7 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 		expectedClusterType       xds_cluster.Cluster_DiscoveryType
 		expectedLbPolicy          xds_cluster.Cluster_LbPolicy
 		expectedProtocolSelection xds_cluster.Cluster_ClusterProtocolSelection
+		expectedLbSubsetConfig    *xds_cluster.Cluster_LbSubsetConfig
 	}{
 		{
 			name:                      "Returns an EDS based cluster when permissive mode is disabled",
@@ -44,6 +54,7 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 			expectedClusterType:       xds_cluster.Cluster_EDS,
 			expectedLbPolicy:          xds_cluster.Cluster_ROUND_ROBIN,
 			expectedProtocolSelection: xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL,
+			expectedLbSubsetConfig:    envoy.GetPortSubsetConfig(envoy.ServicePortSubsetKey),
 		},
 		{
 			name:                      "Returns an Original Destination based cluster when permissive mode is enabled",
@@ -62,10 +73,22 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 			assert.Equal(tc.expectedClusterType, remoteCluster.GetType())
 			assert.Equal(tc.expectedLbPolicy, remoteCluster.LbPolicy)
 			assert.Equal(tc.expectedProtocolSelection, remoteCluster.ProtocolSelection)
+			assert.Equal(tc.expectedLbSubsetConfig, remoteCluster.LbSubsetConfig)
 		})
 	}
 }
 
+func TestApplyHTTPProtocolOptions(t *testing.T) {
+	assert := tassert.New(t)
+
+	cluster := &xds_cluster.Cluster{}
+	applyHTTPProtocolOptions(cluster, configurator.HTTPProtocolOptions{AcceptHTTP10: true})
+	assert.Nil(cluster.HttpProtocolOptions)
+
+	applyHTTPProtocolOptions(cluster, configurator.HTTPProtocolOptions{EnableTrailers: true})
+	assert.Equal(&xds_core.Http1ProtocolOptions{EnableTrailers: true}, cluster.HttpProtocolOptions)
+}
+
 func TestGetLocalServiceCluster(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -83,6 +106,7 @@ func TestGetLocalServiceCluster(t *testing.T) {
 		proxyService                     service.MeshService
 		portToProtocolMapping            map[uint32]string
 		expectedLocalityLbEndpoints      []*xds_endpoint.LocalityLbEndpoints
+		expectedLbSubsetConfig           *xds_cluster.Cluster_LbSubsetConfig
 		expectedLbPolicy                 xds_cluster.Cluster_LbPolicy
 		expectedProtocolSelection        xds_cluster.Cluster_ClusterProtocolSelection
 		expectedPortToProtocolMappingErr bool
@@ -112,6 +136,48 @@ func TestGetLocalServiceCluster(t *testing.T) {
 			expectedPortToProtocolMappingErr: false,
 			expectedErr:                      false,
 		},
+		{
+			name:                  "when service returns multiple ports",
+			proxyService:          proxyService,
+			portToProtocolMapping: map[uint32]string{uint32(8080): "http", uint32(9090): "grpc"},
+			expectedLocalityLbEndpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					Locality: &xds_core.Locality{
+						Zone: "zone",
+					},
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(constants.WildcardIPAddr, uint32(8080)),
+							},
+						},
+						Metadata: envoy.GetPortSubsetMetadata(envoy.TargetPortSubsetKey, []uint32{8080}),
+						LoadBalancingWeight: &wrappers.UInt32Value{
+							Value: constants.ClusterWeightAcceptAll,
+						},
+					}},
+				},
+				{
+					Locality: &xds_core.Locality{
+						Zone: "zone",
+					},
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(constants.WildcardIPAddr, uint32(9090)),
+							},
+						},
+						Metadata: envoy.GetPortSubsetMetadata(envoy.TargetPortSubsetKey, []uint32{9090}),
+						LoadBalancingWeight: &wrappers.UInt32Value{
+							Value: constants.ClusterWeightAcceptAll,
+						},
+					}},
+				},
+			},
+			expectedLbSubsetConfig:           envoy.GetPortSubsetConfig(envoy.TargetPortSubsetKey),
+			expectedPortToProtocolMappingErr: false,
+			expectedErr:                      false,
+		},
 		{
 			name:                             "when err fetching ports",
 			proxyService:                     proxyService,
@@ -147,6 +213,7 @@ func TestGetLocalServiceCluster(t *testing.T) {
 				assert.Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL, cluster.ProtocolSelection)
 				assert.Equal(len(tc.expectedLocalityLbEndpoints), len(cluster.LoadAssignment.Endpoints))
 				assert.ElementsMatch(tc.expectedLocalityLbEndpoints, cluster.LoadAssignment.Endpoints)
+				assert.Equal(tc.expectedLbSubsetConfig, cluster.LbSubsetConfig)
 			}
 		})
 	}
@@ -192,7 +259,7 @@ func TestGetPrometheusCluster(t *testing.T) {
 		},
 	}
 
-	actual := *getPrometheusCluster()
+	actual := *getPrometheusCluster(envoy.NewProxy(certificate.CommonName(""), "", nil))
 	assert.Equal(expectedCluster.LoadAssignment.ClusterName, actual.LoadAssignment.ClusterName)
 	assert.Equal(len(expectedCluster.LoadAssignment.Endpoints[0].LbEndpoints), len(actual.LoadAssignment.Endpoints))
 	assert.Equal(expectedCluster.LoadAssignment.Endpoints[0].LbEndpoints, actual.LoadAssignment.Endpoints[0].LbEndpoints)
@@ -268,12 +335,44 @@ func TestGetEgressClusters(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := getEgressClusters(tc.clusterConfigs)
+			actual := getEgressClusters(tc.clusterConfigs, envoy.NewProxy(certificate.CommonName(""), "", nil))
 			assert.Len(actual, tc.expectedClusterCount)
 		})
 	}
 }
 
+func TestGetEgressClustersStatName(t *testing.T) {
+	assert := tassert.New(t)
+
+	clusterConfigs := []*trafficpolicy.EgressClusterConfig{
+		{
+			Name:       "foo.com:80",
+			Host:       "foo.com",
+			Port:       80,
+			PolicyName: "test/egress",
+		},
+		{
+			Name: "bar.com:90",
+			Host: "bar.com",
+			Port: 90,
+		},
+	}
+
+	// Proxies bootstrapped without the stats tags of egress clusters keep the statistics named after the cluster
+	proxy := envoy.NewProxy(certificate.CommonName(""), "", nil)
+	actual := getEgressClusters(clusterConfigs, proxy)
+	assert.Len(actual, 2)
+	assert.Equal("foo.com:80", actual[0].AltStatName)
+	assert.Equal("bar.com:90", actual[1].AltStatName)
+
+	// The statistics of the clusters built for an Egress policy are tagged with the policy and the destination
+	proxy.SetWorkloadMetadata(map[string]string{envoy.NodeMetadataEgressStatsTags: "true"})
+	actual = getEgressClusters(clusterConfigs, proxy)
+	assert.Len(actual, 2)
+	assert.Equal("egress|test/egress|foo.com|80", actual[0].AltStatName)
+	assert.Equal("bar.com:90", actual[1].AltStatName)
+}
+
 func TestGetHTTPEgressCluster(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -361,3 +460,112 @@ func TestGetHTTPEgressCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyConnectionSettings(t *testing.T) {
+	uint32Ptr := func(v uint32) *uint32 { return &v }
+
+	testCases := []struct {
+		name            string
+		settings        *policyV1alpha1.ConnectionSettingsSpec
+		expectedCluster *xds_cluster.Cluster
+	}{
+		{
+			name:     "no connection settings",
+			settings: nil,
+			expectedCluster: &xds_cluster.Cluster{
+				ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+			},
+		},
+		{
+			name: "TCP and HTTP connection settings",
+			settings: &policyV1alpha1.ConnectionSettingsSpec{
+				TCP: &policyV1alpha1.TCPConnectionSettings{
+					MaxConnections: uint32Ptr(10),
+					ConnectTimeout: &metav1.Duration{Duration: 5 * time.Second},
+				},
+				HTTP: &policyV1alpha1.HTTPConnectionSettings{
+					MaxRequestsPerConnection: uint32Ptr(1),
+					MaxPendingRequests:       uint32Ptr(20),
+					MaxRequests:              uint32Ptr(30),
+					MaxRetries:               uint32Ptr(2),
+				},
+			},
+			expectedCluster: &xds_cluster.Cluster{
+				ConnectTimeout:           ptypes.DurationProto(5 * time.Second),
+				MaxRequestsPerConnection: &wrappers.UInt32Value{Value: 1},
+				CircuitBreakers: &xds_cluster.CircuitBreakers{
+					Thresholds: []*xds_cluster.CircuitBreakers_Thresholds{
+						{
+							MaxConnections:     &wrappers.UInt32Value{Value: 10},
+							MaxPendingRequests: &wrappers.UInt32Value{Value: 20},
+							MaxRequests:        &wrappers.UInt32Value{Value: 30},
+							MaxRetries:         &wrappers.UInt32Value{Value: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "partial TCP connection settings",
+			settings: &policyV1alpha1.ConnectionSettingsSpec{
+				TCP: &policyV1alpha1.TCPConnectionSettings{
+					MaxConnections: uint32Ptr(10),
+				},
+			},
+			expectedCluster: &xds_cluster.Cluster{
+				ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+				CircuitBreakers: &xds_cluster.CircuitBreakers{
+					Thresholds: []*xds_cluster.CircuitBreakers_Thresholds{
+						{
+							MaxConnections: &wrappers.UInt32Value{Value: 10},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			cluster := &xds_cluster.Cluster{
+				ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+			}
+			applyConnectionSettings(cluster, tc.settings)
+			assert.Equal(tc.expectedCluster, cluster)
+		})
+	}
+}
+
+func TestGetEnvoyAdminAddress(t *testing.T) {
+	testCases := []struct {
+		name            string
+		adminUDSEnabled bool
+		expected        *xds_core.Address
+	}{
+		{
+			name:            "admin interface bound to a TCP port",
+			adminUDSEnabled: false,
+			expected:        envoy.GetAddress(constants.LocalhostIPAddress, constants.EnvoyAdminPort),
+		},
+		{
+			name:            "admin interface bound to a Unix domain socket",
+			adminUDSEnabled: true,
+			expected:        envoy.GetPipeAddress(constants.EnvoyAdminSocketPath),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			proxy := envoy.NewProxy(certificate.CommonName(""), "", nil)
+			if tc.adminUDSEnabled {
+				proxy.SetWorkloadMetadata(map[string]string{envoy.NodeMetadataEnvoyAdminUDS: "true"})
+			}
+
+			assert.Equal(tc.expected, getEnvoyAdminAddress(proxy))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cds
 
 import (
+	"sort"
+
 	mapset "github.com/deckarep/golang-set"
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
@@ -10,16 +12,11 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 )
 
 // NewResponse creates a new Cluster Discovery Response.
 func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager) ([]types.Resource, error) {
-	svcList, err := meshCatalog.GetServicesForProxy(proxy)
-	if err != nil {
-		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
-		return nil, err
-	}
-
 	var clusters []*xds_cluster.Cluster
 
 	proxyIdentity, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
@@ -29,6 +26,32 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		return nil, err
 	}
 
+	if proxy.GetKind() == envoy.KindProxylessGRPC {
+		// Proxyless gRPC clients only consume the clusters for the upstream services they are allowed to connect to
+		clusters, err = getProxylessGRPCClusters(meshCatalog, proxyIdentity.ToServiceIdentity())
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to construct clusters for proxyless gRPC client with XDS Certificate SerialNumber=%s", proxy.GetCertificateSerialNumber())
+			return nil, err
+		}
+		return dedupClusters(clusters, proxy), nil
+	}
+
+	if proxy.GetKind() == envoy.KindIngressGateway {
+		// The ingress gateway only consumes the clusters for the upstream services it is allowed to route to
+		clusters, err = getIngressGatewayClusters(meshCatalog, proxyIdentity.ToServiceIdentity(), cfg)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to construct clusters for ingress gateway with XDS Certificate SerialNumber=%s", proxy.GetCertificateSerialNumber())
+			return nil, err
+		}
+		return dedupClusters(clusters, proxy), nil
+	}
+
+	svcList, err := meshCatalog.GetServicesForProxy(proxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return nil, err
+	}
+
 	// Build remote clusters based on allowed outbound services
 	for _, dstService := range meshCatalog.ListAllowedOutboundServicesForIdentity(proxyIdentity.ToServiceIdentity()) {
 		cluster, err := getUpstreamServiceCluster(proxyIdentity.ToServiceIdentity(), dstService, cfg)
@@ -50,6 +73,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 			log.Error().Err(err).Msgf("Failed to get local cluster config for proxy %s", proxyService)
 			return nil, err
 		}
+		applyHTTPProtocolOptions(localCluster, cfg.GetHTTPProtocolOptions(proxyService))
 		clusters = append(clusters, localCluster)
 	}
 
@@ -58,7 +82,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		log.Error().Err(err).Msgf("Error retrieving egress policies for proxy with identity %s, skipping egress clusters", proxyIdentity)
 	} else {
 		if egressTrafficPolicy != nil {
-			clusters = append(clusters, getEgressClusters(egressTrafficPolicy.ClustersConfigs)...)
+			clusters = append(clusters, getEgressClusters(egressTrafficPolicy.ClustersConfigs, proxy)...)
 		}
 	}
 
@@ -69,7 +93,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	// Add an inbound prometheus cluster (from Prometheus to localhost)
 	if cfg.IsPrometheusScrapingEnabled() {
-		clusters = append(clusters, getPrometheusCluster())
+		clusters = append(clusters, getPrometheusCluster(proxy))
 	}
 
 	// Add an outbound tracing cluster (from localhost to tracing sink)
@@ -77,6 +101,17 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		clusters = append(clusters, getTracingCluster(cfg))
 	}
 
+	return dedupClusters(clusters, proxy), nil
+}
+
+// dedupClusters returns the given clusters as xDS resources sorted by name, skipping clusters with duplicate names.
+// The first cluster with a given name is kept. The order of the clusters is not significant to Envoy, they are
+// sorted so that the same policies always result in the same response.
+func dedupClusters(clusters []*xds_cluster.Cluster, proxy *envoy.Proxy) []types.Resource {
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
 	alreadyAdded := mapset.NewSet()
 	var cdsResources []types.Resource
 	for _, cluster := range clusters {
@@ -89,5 +124,39 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		cdsResources = append(cdsResources, cluster)
 	}
 
-	return cdsResources, nil
+	return cdsResources
+}
+
+// getProxylessGRPCClusters returns the clusters for the upstream services the given proxyless gRPC client identity is allowed to connect to
+func getProxylessGRPCClusters(meshCatalog catalog.MeshCataloger, downstreamIdentity identity.ServiceIdentity) ([]*xds_cluster.Cluster, error) {
+	var clusters []*xds_cluster.Cluster
+	for _, dstService := range meshCatalog.ListAllowedOutboundServicesForIdentity(downstreamIdentity) {
+		upstreamIdentities, err := meshCatalog.ListServiceIdentitiesForService(dstService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error looking up service identities for service %s", dstService)
+			return nil, err
+		}
+
+		cluster, err := getProxylessGRPCUpstreamServiceCluster(dstService, upstreamIdentities)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to construct proxyless gRPC cluster for service %s", dstService)
+			return nil, err
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
+// getIngressGatewayClusters returns the clusters for the upstream services the ingress gateway identity is allowed to connect to
+func getIngressGatewayClusters(meshCatalog catalog.MeshCataloger, gatewayIdentity identity.ServiceIdentity, cfg configurator.Configurator) ([]*xds_cluster.Cluster, error) {
+	var clusters []*xds_cluster.Cluster
+	for _, dstService := range meshCatalog.ListAllowedOutboundServicesForIdentity(gatewayIdentity) {
+		cluster, err := getIngressGatewayUpstreamServiceCluster(gatewayIdentity, dstService, cfg)
+		if err != nil {
+			log.Error().Err(err).Msgf("Failed to construct ingress gateway cluster for service %s", dstService)
+			return nil, err
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
 }
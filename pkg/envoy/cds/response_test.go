@@ -25,6 +25,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -35,6 +36,7 @@ func TestNewResponse(t *testing.T) {
 
 	mockCtrl := gomock.NewController(t)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 
 	proxyUUID := uuid.New()
@@ -48,6 +50,10 @@ func TestNewResponse(t *testing.T) {
 	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookbuyerService).Return(map[uint32]string{uint32(80): "protocol"}, nil)
 	mockCatalog.EXPECT().GetEgressTrafficPolicy(tests.BookbuyerServiceIdentity).Return(nil, nil).AnyTimes()
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
 	mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(true).AnyTimes()
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true).AnyTimes()
@@ -115,7 +121,7 @@ func TestNewResponse(t *testing.T) {
 		},
 	}
 
-	upstreamTLSProto, err := ptypes.MarshalAny(envoy.GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service))
+	upstreamTLSProto, err := ptypes.MarshalAny(envoy.GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, mockConfigurator))
 	require.Nil(err)
 
 	expectedBookstoreV1Cluster := &xds_cluster.Cluster{
@@ -134,6 +140,7 @@ func TestNewResponse(t *testing.T) {
 			},
 			ServiceName: "",
 		},
+		LbSubsetConfig: envoy.GetPortSubsetConfig(envoy.ServicePortSubsetKey),
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
 		TransportSocket: &xds_core.TransportSocket{
 			Name: wellknown.TransportSocketTls,
@@ -146,7 +153,7 @@ func TestNewResponse(t *testing.T) {
 		},
 	}
 
-	upstreamTLSProto, err = ptypes.MarshalAny(envoy.GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV2Service))
+	upstreamTLSProto, err = ptypes.MarshalAny(envoy.GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV2Service, mockConfigurator))
 	require.Nil(err)
 	expectedBookstoreV2Cluster := &xds_cluster.Cluster{
 		TransportSocketMatches: nil,
@@ -164,6 +171,7 @@ func TestNewResponse(t *testing.T) {
 			},
 			ServiceName: "",
 		},
+		LbSubsetConfig: envoy.GetPortSubsetConfig(envoy.ServicePortSubsetKey),
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
 		TransportSocket: &xds_core.TransportSocket{
 			Name: wellknown.TransportSocketTls,
@@ -343,3 +351,93 @@ func TestNewResponse(t *testing.T) {
 
 	assert.ElementsMatch(expectedClusters, foundClusters)
 }
+
+func TestNewResponseForProxylessGRPC(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	proxyUUID := uuid.New()
+	xdsCertificate := certificate.CommonName(fmt.Sprintf("%s.%s.%s.foo.bar", proxyUUID, tests.BookbuyerServiceAccountName, tests.Namespace))
+	proxy := envoy.NewProxy(xdsCertificate, certificate.SerialNumber("123456"), nil)
+	proxy.SetKind(envoy.KindProxylessGRPC)
+
+	mockCatalog.EXPECT().GetServicesForProxy(proxy).Return(nil, nil).AnyTimes()
+	mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(tests.BookbuyerServiceIdentity).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+	mockCatalog.EXPECT().ListServiceIdentitiesForService(tests.BookstoreV1Service).Return([]identity.ServiceIdentity{tests.BookstoreServiceIdentity}, nil).Times(1)
+
+	resp, err := NewResponse(mockCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	require.Len(resp, 1)
+
+	cluster, ok := resp[0].(*xds_cluster.Cluster)
+	require.True(ok)
+	assert.Equal(tests.BookstoreV1Service.String(), cluster.Name)
+	assert.Equal(xds_cluster.Cluster_EDS, cluster.GetType())
+	assert.Equal(xds_cluster.Cluster_ROUND_ROBIN, cluster.LbPolicy)
+
+	upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+	require.Nil(ptypes.UnmarshalAny(cluster.TransportSocket.GetTypedConfig(), upstreamTLSContext))
+	assert.Equal(envoy.ProxylessGRPCCertProviderInstance, upstreamTLSContext.CommonTlsContext.TlsCertificateCertificateProviderInstance.InstanceName)
+}
+
+func TestNewResponseForIngressGateway(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	gatewayIdentity := identity.K8sServiceAccount{Name: constants.OSMIngressGatewayName, Namespace: "osm-system"}
+	xdsCertificate := certificate.CommonName(fmt.Sprintf("%s.%s.%s.foo.bar", uuid.New(), gatewayIdentity.Name, gatewayIdentity.Namespace))
+	proxy := envoy.NewProxy(xdsCertificate, certificate.SerialNumber("123456"), nil)
+	proxy.SetKind(envoy.KindIngressGateway)
+
+	mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(gatewayIdentity.ToServiceIdentity()).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+	// The gateway clusters must rely on EDS even in permissive mode
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+
+	resp, err := NewResponse(mockCatalog, proxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	require.Len(resp, 1)
+
+	cluster, ok := resp[0].(*xds_cluster.Cluster)
+	require.True(ok)
+	assert.Equal(tests.BookstoreV1Service.String(), cluster.Name)
+	assert.Equal(xds_cluster.Cluster_EDS, cluster.GetType())
+	assert.Equal(xds_cluster.Cluster_ROUND_ROBIN, cluster.LbPolicy)
+	assert.NotNil(cluster.EdsClusterConfig)
+}
+
+func TestDedupClusters(t *testing.T) {
+	assert := tassert.New(t)
+
+	proxy := envoy.NewProxy(certificate.CommonName(fmt.Sprintf("%s.sa.ns.foo.bar", uuid.New())), certificate.SerialNumber("123456"), nil)
+	clusters := []*xds_cluster.Cluster{
+		{Name: "ns/foo", AltStatName: "first"},
+		{Name: "ns/bar"},
+		{Name: "ns/foo", AltStatName: "duplicate"},
+		{Name: "ns/baz"},
+	}
+
+	// Clusters are sorted by name and the first cluster with a given name is kept
+	var names []string
+	for _, resource := range dedupClusters(clusters, proxy) {
+		cluster := resource.(*xds_cluster.Cluster)
+		names = append(names, cluster.Name)
+		if cluster.Name == "ns/foo" {
+			assert.Equal("first", cluster.AltStatName)
+		}
+	}
+	assert.Equal([]string{"ns/bar", "ns/baz", "ns/foo"}, names)
+}
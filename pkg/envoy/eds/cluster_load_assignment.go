@@ -15,8 +15,9 @@ const (
 	zone = "zone"
 )
 
-// newClusterLoadAssignment returns the cluster load assignments for the given service and its endpoints
-func newClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint) *xds_endpoint.ClusterLoadAssignment {
+// newClusterLoadAssignment returns the cluster load assignments for the given service and its endpoints.
+// Endpoints are labeled with the service ports served by their port in the given mapping, if any.
+func newClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints []endpoint.Endpoint, servicePortsByTargetPort map[uint32][]uint32) *xds_endpoint.ClusterLoadAssignment {
 	cla := &xds_endpoint.ClusterLoadAssignment{
 		ClusterName: serviceName.String(),
 		Endpoints: []*xds_endpoint.LocalityLbEndpoints{
@@ -43,12 +44,27 @@ func newClusterLoadAssignment(serviceName service.MeshService, serviceEndpoints
 					Address: envoy.GetAddress(meshEndpoint.IP.String(), uint32(meshEndpoint.Port)),
 				},
 			},
+			HealthStatus: getHealthStatus(meshEndpoint.Health),
 			LoadBalancingWeight: &wrappers.UInt32Value{
 				Value: weight,
 			},
 		}
+		if servicePorts, ok := servicePortsByTargetPort[uint32(meshEndpoint.Port)]; ok {
+			lbEpt.Metadata = envoy.GetPortSubsetMetadata(envoy.ServicePortSubsetKey, servicePorts)
+		}
 		cla.Endpoints[0].LbEndpoints = append(cla.Endpoints[0].LbEndpoints, &lbEpt)
 	}
 	log.Debug().Msgf("[EDS] Constructed ClusterLoadAssignment: %+v", cla)
 	return cla
 }
+
+// getHealthStatus returns the EDS health status of an endpoint with the given health status.
+// Draining endpoints are excluded from load balancing by Envoy, while their existing connections are preserved.
+func getHealthStatus(health endpoint.HealthStatus) xds_core.HealthStatus {
+	switch health {
+	case endpoint.Draining:
+		return xds_core.HealthStatus_DRAINING
+	default:
+		return xds_core.HealthStatus_HEALTHY
+	}
+}
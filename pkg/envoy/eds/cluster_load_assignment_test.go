@@ -3,7 +3,11 @@ package eds
 import (
 	"net"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
 
 	. "github.com/onsi/ginkgo"
@@ -29,13 +33,13 @@ var _ = Describe("Testing Cluster Load Assignment", func() {
 				},
 			}
 
-			cla := newClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]])
+			cla := newClusterLoadAssignment(namespacedServices[0], allServiceEndpoints[namespacedServices[0]], nil)
 			Expect(cla).NotTo(Equal(nil))
 			Expect(cla.ClusterName).To(Equal("osm/bookstore-1"))
 			Expect(len(cla.Endpoints)).To(Equal(1))
 			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(1))
 			Expect(cla.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value).To(Equal(uint32(100)))
-			cla2 := newClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]])
+			cla2 := newClusterLoadAssignment(namespacedServices[1], allServiceEndpoints[namespacedServices[1]], nil)
 			Expect(cla2).NotTo(Equal(nil))
 			Expect(cla2.ClusterName).To(Equal("osm/bookstore-2"))
 			Expect(len(cla2.Endpoints)).To(Equal(1))
@@ -43,5 +47,41 @@ var _ = Describe("Testing Cluster Load Assignment", func() {
 			Expect(cla2.Endpoints[0].LbEndpoints[0].GetLoadBalancingWeight().Value).To(Equal(uint32(50)))
 			Expect(cla2.Endpoints[0].LbEndpoints[1].GetLoadBalancingWeight().Value).To(Equal(uint32(50)))
 		})
+
+		It("Sets the health status of endpoints", func() {
+			svc := service.MeshService{Namespace: "osm", Name: "bookstore-1"}
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 80},
+				{IP: net.ParseIP("10.0.0.2"), Port: 80, Health: endpoint.Draining},
+			}
+
+			cla := newClusterLoadAssignment(svc, endpoints, nil)
+			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(2))
+			Expect(cla.Endpoints[0].LbEndpoints[0].HealthStatus).To(Equal(xds_core.HealthStatus_HEALTHY))
+			Expect(cla.Endpoints[0].LbEndpoints[1].HealthStatus).To(Equal(xds_core.HealthStatus_DRAINING))
+		})
+
+		It("Labels endpoints with the service ports they serve", func() {
+			svc := service.MeshService{Namespace: "osm", Name: "bookstore-1"}
+			endpoints := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.1"), Port: 8080},
+				{IP: net.ParseIP("10.0.0.1"), Port: 9090},
+				{IP: net.ParseIP("10.0.0.1"), Port: 7070},
+			}
+
+			cla := newClusterLoadAssignment(svc, endpoints, map[uint32][]uint32{8080: {80, 8080}, 9090: {9090}})
+			Expect(len(cla.Endpoints[0].LbEndpoints)).To(Equal(3))
+
+			servicePorts := func(lbEndpoint *xds_endpoint.LbEndpoint) []float64 {
+				var ports []float64
+				for _, value := range lbEndpoint.GetMetadata().GetFilterMetadata()["envoy.lb"].GetFields()[envoy.ServicePortSubsetKey].GetListValue().GetValues() {
+					ports = append(ports, value.GetNumberValue())
+				}
+				return ports
+			}
+			Expect(servicePorts(cla.Endpoints[0].LbEndpoints[0])).To(Equal([]float64{80, 8080}))
+			Expect(servicePorts(cla.Endpoints[0].LbEndpoints[1])).To(Equal([]float64{9090}))
+			Expect(cla.Endpoints[0].LbEndpoints[2].Metadata).To(BeNil())
+		})
 	})
 })
@@ -1,6 +1,8 @@
 package eds
 
 import (
+	"sort"
+
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 
@@ -29,7 +31,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	var rdsResources []types.Resource
 	for svc, endpoints := range allowedEndpoints {
-		loadAssignment := newClusterLoadAssignment(svc, endpoints)
+		loadAssignment := newClusterLoadAssignment(svc, endpoints, getServicePortsByTargetPort(meshCatalog, svc))
 		rdsResources = append(rdsResources, loadAssignment)
 	}
 
@@ -52,3 +54,25 @@ func getEndpointsForProxy(meshCatalog catalog.MeshCataloger, proxyIdentity ident
 	log.Trace().Msgf("Allowed outbound service endpoints for proxy with identity %s: %v", proxyIdentity, allowedServicesEndpoints)
 	return allowedServicesEndpoints, nil
 }
+
+// getServicePortsByTargetPort returns the service ports of the given service served by each of its target ports, when the
+// service exposes multiple ports. It returns nil for services exposing a single port, whose endpoints all serve the port.
+func getServicePortsByTargetPort(meshCatalog catalog.MeshCataloger, svc service.MeshService) map[uint32][]uint32 {
+	portToTargetPortMap, err := meshCatalog.GetServicePortToTargetPortMappingForService(svc)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up the target ports of service %s, its endpoints will not be labeled with their ports", svc)
+		return nil
+	}
+	if len(portToTargetPortMap) < 2 {
+		return nil
+	}
+
+	servicePortsByTargetPort := make(map[uint32][]uint32)
+	for port, targetPort := range portToTargetPortMap {
+		servicePortsByTargetPort[targetPort] = append(servicePortsByTargetPort[targetPort], port)
+	}
+	for _, ports := range servicePortsByTargetPort {
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	}
+	return servicePortsByTargetPort
+}
@@ -0,0 +1,127 @@
+package envoy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// The egress connections denied by the outbound listener are counted by the RBAC filters denying them. The stat prefix
+// of these filters encodes the source identity of the proxy and the destination host and port of the connections,
+// which the bootstrap config of the proxy extracts as tags of the statistics. The tags are exported as labels of the
+// 'envoy_egress_deny_rbac_denied' Prometheus counter.
+//
+// The HTTP requests denied by the routes of the hosts denied by Egress policies are responded to by the routes
+// themselves, without reaching an RBAC filter. They are counted per route by an HTTP local rate limit that is enabled
+// but never enforced, whose stat prefix encodes the same components and is exported with the same labels as the
+// 'envoy_egress_deny_http_local_rate_limit_enabled' Prometheus counter.
+//
+// Similarly, the alternative stat name of the clusters of egress destinations encodes the Egress policy the cluster is
+// built for and the host and port of the destination. They are extracted as tags of the statistics of the cluster and
+// exported as labels of the 'envoy_cluster_*' Prometheus metrics, so that the request rate, errors and latency of
+// each external dependency can be observed.
+const (
+	// EgressDenyStatPrefix is the prefix of the statistics of the RBAC filters denying egress connections
+	EgressDenyStatPrefix = "egress-deny"
+
+	// EgressDenyAnyDestination is the destination host and port of the denied connections that do not match any
+	// Egress policy, whose destination is unknown to the filter denying them
+	EgressDenyAnyDestination = "*"
+
+	// EgressSourceIdentityTag is the name of the tag of the source identity of the denied egress connections
+	EgressSourceIdentityTag = "osm_egress_source_identity"
+
+	// EgressDestinationHostTag is the name of the tag of the destination host of the denied egress connections and
+	// egress clusters
+	EgressDestinationHostTag = "osm_egress_destination_host"
+
+	// EgressDestinationPortTag is the name of the tag of the destination port of the denied egress connections and
+	// egress clusters
+	EgressDestinationPortTag = "osm_egress_destination_port"
+
+	// EgressPolicyTag is the name of the tag of the Egress policy egress clusters are built for
+	EgressPolicyTag = "osm_egress_policy"
+
+	// EgressClusterStatPrefix is the prefix of the alternative stat name of egress clusters
+	EgressClusterStatPrefix = "egress"
+
+	// egressStatSeparator separates the components of the stat prefix of the RBAC filters denying egress connections
+	// and of the alternative stat name of egress clusters
+	egressStatSeparator = "|"
+
+	// egressDeniedStatSuffix is the suffix of the counter of the connections denied by an RBAC filter
+	egressDeniedStatSuffix = "rbac.denied"
+
+	// egressDeniedRequestStatSuffix is the suffix of the counter of the requests matching the route of a denied host,
+	// incremented by the HTTP local rate limit of the route
+	egressDeniedRequestStatSuffix = "http_local_rate_limit.enabled"
+)
+
+// StatsTag describes a tag extracted from the names of the statistics of a proxy. The first capture group of Regex
+// is removed from the name of the statistics matching it, and the second capture group is the value of the tag.
+type StatsTag struct {
+	// Name is the name of the tag
+	Name string
+
+	// Regex is the regular expression extracting the tag from the names of the statistics
+	Regex string
+}
+
+// EgressStatsTags are the tags extracted from the statistics of the RBAC filters denying egress connections, of the
+// form 'egress-deny|<namespace>/<service account>|<host>|<port>.rbac.denied', from the statistics of the routes denying
+// HTTP requests, of the form 'egress-deny|<namespace>/<service account>|<host>|<port>.http_local_rate_limit.enabled', and from the statistics of egress
+// clusters, of the form 'cluster.egress|<namespace>/<policy>|<host>|<port>.<stat>'.
+// The tag of the Egress policy removes the whole alternative stat name of egress clusters, which Envoy's default tag
+// of the cluster name only partially removes when the host contains dots, so that the statistics of egress clusters
+// are exported as the same 'envoy_cluster_*' metrics as the statistics of the other clusters.
+var EgressStatsTags = []StatsTag{
+	{Name: EgressSourceIdentityTag, Regex: `^egress-deny(\|([^|]+))\|[^|]+\|[^|.]+\.`},
+	{Name: EgressDestinationHostTag, Regex: `^(?:egress-deny|cluster\.egress)\|[^|]+(\|([^|]+))\|[^|.]+\.`},
+	{Name: EgressDestinationPortTag, Regex: `^(?:egress-deny|cluster\.egress)\|[^|]+\|[^|]+(\|([^|.]+))\.`},
+	{Name: EgressPolicyTag, Regex: `^cluster\.(egress\|([^|]+)\|[^|]+\|[^|.]+\.)`},
+}
+
+// GetEgressDenyStatPrefix returns the stat prefix of the RBAC filter denying the egress connections from the given
+// source identity to the given destination host and port
+func GetEgressDenyStatPrefix(source identity.ServiceIdentity, host string, port string) string {
+	return fmt.Sprintf("%s.", strings.Join([]string{EgressDenyStatPrefix, source.ToK8sServiceAccount().String(), host, port}, egressStatSeparator))
+}
+
+// GetEgressDenyRequestStatPrefix returns the stat prefix of the HTTP local rate limit counting the requests from the
+// given source identity denied by the route of the given destination host and port. Unlike the stat prefix of RBAC
+// filters, it has no trailing dot as the HTTP local rate limit filter appends one.
+func GetEgressDenyRequestStatPrefix(source identity.ServiceIdentity, host string, port string) string {
+	return strings.TrimSuffix(GetEgressDenyStatPrefix(source, host, port), ".")
+}
+
+// GetEgressClusterStatName returns the alternative stat name of the cluster of the given destination host and port,
+// built for the given Egress policy, as '<namespace>/<name>'
+func GetEgressClusterStatName(policy string, host string, port int) string {
+	return strings.Join([]string{EgressClusterStatPrefix, policy, host, strconv.Itoa(port)}, egressStatSeparator)
+}
+
+// ParseEgressDeniedStat parses the name of the counter of denied egress connections or HTTP requests, as output by the
+// admin interface of a proxy. It returns the source identity, destination host and destination port of the connections
+// or requests counted, and a boolean indicating if the given name is the name of such a counter.
+func ParseEgressDeniedStat(name string) (source identity.K8sServiceAccount, host string, port string, ok bool) {
+	var prefix string
+	switch {
+	case strings.HasSuffix(name, "."+egressDeniedStatSuffix):
+		prefix = strings.TrimSuffix(name, "."+egressDeniedStatSuffix)
+	case strings.HasSuffix(name, "."+egressDeniedRequestStatSuffix):
+		prefix = strings.TrimSuffix(name, "."+egressDeniedRequestStatSuffix)
+	default:
+		return identity.K8sServiceAccount{}, "", "", false
+	}
+	chunks := strings.Split(prefix, egressStatSeparator)
+	if len(chunks) != 4 || chunks[0] != EgressDenyStatPrefix {
+		return identity.K8sServiceAccount{}, "", "", false
+	}
+	sourceChunks := strings.Split(chunks[1], "/")
+	if len(sourceChunks) != 2 {
+		return identity.K8sServiceAccount{}, "", "", false
+	}
+	return identity.K8sServiceAccount{Namespace: sourceChunks[0], Name: sourceChunks[1]}, chunks[2], chunks[3], true
+}
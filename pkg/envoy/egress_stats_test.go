@@ -0,0 +1,174 @@
+package envoy
+
+import (
+	"regexp"
+	"sort"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+func TestEgressDenyStats(t *testing.T) {
+	source := identity.K8sServiceAccount{Namespace: "curl", Name: "curl"}.ToServiceIdentity()
+
+	testCases := []struct {
+		name         string
+		host         string
+		port         string
+		expectedTags map[string]string
+	}{
+		{
+			name: "denied host",
+			host: "pastebin.com",
+			port: "443",
+			expectedTags: map[string]string{
+				EgressSourceIdentityTag:  "curl/curl",
+				EgressDestinationHostTag: "pastebin.com",
+				EgressDestinationPortTag: "443",
+			},
+		},
+		{
+			name: "any destination",
+			host: EgressDenyAnyDestination,
+			port: EgressDenyAnyDestination,
+			expectedTags: map[string]string{
+				EgressSourceIdentityTag:  "curl/curl",
+				EgressDestinationHostTag: "*",
+				EgressDestinationPortTag: "*",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			statName := GetEgressDenyStatPrefix(source, tc.host, tc.port) + egressDeniedStatSuffix
+
+			tags, taggedName := extractStatsTags(statName)
+			assert.Equal(tc.expectedTags, tags)
+			assert.Equal("egress-deny.rbac.denied", taggedName)
+
+			actualSource, actualHost, actualPort, ok := ParseEgressDeniedStat(statName)
+			assert.True(ok)
+			assert.Equal(source.ToK8sServiceAccount(), actualSource)
+			assert.Equal(tc.host, actualHost)
+			assert.Equal(tc.port, actualPort)
+		})
+	}
+}
+
+func TestEgressDenyRequestStats(t *testing.T) {
+	assert := tassert.New(t)
+
+	source := identity.K8sServiceAccount{Namespace: "curl", Name: "curl"}.ToServiceIdentity()
+	statName := GetEgressDenyRequestStatPrefix(source, "httpbin.org", "80") + "." + egressDeniedRequestStatSuffix
+
+	tags, taggedName := extractStatsTags(statName)
+	assert.Equal(map[string]string{
+		EgressSourceIdentityTag:  "curl/curl",
+		EgressDestinationHostTag: "httpbin.org",
+		EgressDestinationPortTag: "80",
+	}, tags)
+	assert.Equal("egress-deny.http_local_rate_limit.enabled", taggedName)
+
+	actualSource, actualHost, actualPort, ok := ParseEgressDeniedStat(statName)
+	assert.True(ok)
+	assert.Equal(source.ToK8sServiceAccount(), actualSource)
+	assert.Equal("httpbin.org", actualHost)
+	assert.Equal("80", actualPort)
+}
+
+func TestEgressClusterStats(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       string
+		host         string
+		port         int
+		expectedTags map[string]string
+	}{
+		{
+			name:   "host with dots",
+			policy: "curl/httpbin",
+			host:   "httpbin.org",
+			port:   80,
+			expectedTags: map[string]string{
+				EgressPolicyTag:          "curl/httpbin",
+				EgressDestinationHostTag: "httpbin.org",
+				EgressDestinationPortTag: "80",
+			},
+		},
+		{
+			name:   "host without dots",
+			policy: "curl/local",
+			host:   "localhost",
+			port:   8080,
+			expectedTags: map[string]string{
+				EgressPolicyTag:          "curl/local",
+				EgressDestinationHostTag: "localhost",
+				EgressDestinationPortTag: "8080",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			statName := "cluster." + GetEgressClusterStatName(tc.policy, tc.host, tc.port) + ".upstream_rq_2xx"
+
+			tags, taggedName := extractStatsTags(statName)
+			assert.Equal(tc.expectedTags, tags)
+			assert.Equal("cluster.upstream_rq_2xx", taggedName)
+		})
+	}
+}
+
+// extractStatsTags extracts the egress stats tags from the given stat name the way Envoy does: each tag is matched
+// against the whole name, its value is the second capture group, and the characters of the first capture groups of
+// all the tags matched are removed from the name
+func extractStatsTags(statName string) (map[string]string, string) {
+	tags := make(map[string]string)
+	var removed [][]int
+	for _, tag := range EgressStatsTags {
+		match := regexp.MustCompile(tag.Regex).FindStringSubmatchIndex(statName)
+		if match == nil {
+			continue
+		}
+		tags[tag.Name] = statName[match[4]:match[5]]
+		removed = append(removed, match[2:4])
+	}
+
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i][0] < removed[j][0]
+	})
+	taggedName := ""
+	next := 0
+	for _, interval := range removed {
+		if interval[0] > next {
+			taggedName += statName[next:interval[0]]
+		}
+		if interval[1] > next {
+			next = interval[1]
+		}
+	}
+	return tags, taggedName + statName[next:]
+}
+
+func TestParseEgressDeniedStatInvalid(t *testing.T) {
+	assert := tassert.New(t)
+
+	for _, name := range []string{
+		"egress-deny|curl/curl|pastebin.com|443.rbac.allowed",
+		"egress-deny|curl/curl|pastebin.com|80.http_local_rate_limit.rate_limited",
+		"egress-deny|curl/curl|pastebin.com.rbac.denied",
+		"egress-deny|curl|pastebin.com|443.rbac.denied",
+		"egress-local-rate-limit|curl/curl|pastebin.com|443.rbac.denied",
+		"cluster.outbound-passthrough-cluster.upstream_cx_total",
+	} {
+		_, _, _, ok := ParseEgressDeniedStat(name)
+		assert.False(ok, name)
+	}
+}
@@ -0,0 +1,78 @@
+package lds
+
+import (
+	"fmt"
+
+	udpa_type "github.com/cncf/udpa/go/udpa/type/v1"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/golang/protobuf/ptypes"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// connectionLimitFilterName is the name of Envoy's connection limit network filter
+	connectionLimitFilterName = "envoy.filters.network.connection_limit"
+
+	// connectionLimitTypeURL is the type URL of the connection limit filter's config. The filter was added in
+	// Envoy v1.18 and its config is not part of the go-control-plane version in use, so it is built as a TypedStruct.
+	connectionLimitTypeURL = "type.googleapis.com/envoy.extensions.filters.network.connection_limit.v3.ConnectionLimit"
+
+	inboundConnectionLimitStatPrefix = "inbound-connection-limit"
+)
+
+// getInboundConnectionLimitFilter returns the network filter limiting the number of concurrent connections accepted
+// by a filter chain of the given service, or nil if the given spec does not limit the number of connections
+func getInboundConnectionLimitFilter(spec *policyV1alpha1.InboundConnectionSettingsSpec, proxyService service.MeshService) (*xds_listener.Filter, error) {
+	if spec == nil || spec.MaxConnections == nil {
+		return nil, nil
+	}
+
+	connectionLimit := &udpa_type.TypedStruct{
+		TypeUrl: connectionLimitTypeURL,
+		Value: &structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"stat_prefix": {
+					Kind: &structpb.Value_StringValue{
+						StringValue: fmt.Sprintf("%s.%s", inboundConnectionLimitStatPrefix, envoy.GetLocalClusterNameForService(proxyService)),
+					},
+				},
+				"max_connections": {
+					Kind: &structpb.Value_NumberValue{
+						NumberValue: float64(*spec.MaxConnections),
+					},
+				},
+			},
+		},
+	}
+	marshalled, err := ptypes.MarshalAny(connectionLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling connection limit filter")
+	}
+
+	return &xds_listener.Filter{
+		Name:       connectionLimitFilterName,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalled},
+	}, nil
+}
+
+// getInboundPerConnectionBufferLimit returns the per connection buffer limit of the inbound listener. The limit
+// applies to the whole listener, so the smallest limit configured for the given services is used.
+func (lb *listenerBuilder) getInboundPerConnectionBufferLimit(svcList []service.MeshService) *wrapperspb.UInt32Value {
+	var limit *wrapperspb.UInt32Value
+	for _, svc := range svcList {
+		upstreamTrafficSetting := lb.meshCatalog.GetUpstreamTrafficSetting(svc)
+		if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.Inbound == nil || upstreamTrafficSetting.Spec.Inbound.PerConnectionBufferLimitBytes == nil {
+			continue
+		}
+		if bufferLimit := *upstreamTrafficSetting.Spec.Inbound.PerConnectionBufferLimitBytes; limit == nil || bufferLimit < limit.Value {
+			limit = wrapperspb.UInt32(bufferLimit)
+		}
+	}
+	return limit
+}
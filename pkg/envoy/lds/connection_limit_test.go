@@ -0,0 +1,120 @@
+package lds
+
+import (
+	"testing"
+
+	udpa_type "github.com/cncf/udpa/go/udpa/type/v1"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestGetInboundConnectionLimitFilter(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		spec                   *policyV1alpha1.InboundConnectionSettingsSpec
+		expectFilter           bool
+		expectedMaxConnections float64
+	}{
+		{
+			name:         "no inbound settings",
+			spec:         nil,
+			expectFilter: false,
+		},
+		{
+			name:         "buffer limit only",
+			spec:         &policyV1alpha1.InboundConnectionSettingsSpec{PerConnectionBufferLimitBytes: uint32Ptr(32768)},
+			expectFilter: false,
+		},
+		{
+			name:                   "connection limit",
+			spec:                   &policyV1alpha1.InboundConnectionSettingsSpec{MaxConnections: uint32Ptr(100)},
+			expectFilter:           true,
+			expectedMaxConnections: 100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			filter, err := getInboundConnectionLimitFilter(tc.spec, tests.BookstoreV1Service)
+			assert.Nil(err)
+			if !tc.expectFilter {
+				assert.Nil(filter)
+				return
+			}
+
+			assert.Equal(connectionLimitFilterName, filter.Name)
+			typedStruct := &udpa_type.TypedStruct{}
+			assert.Nil(ptypes.UnmarshalAny(filter.GetTypedConfig(), typedStruct))
+			assert.Equal(connectionLimitTypeURL, typedStruct.TypeUrl)
+			assert.Equal(tc.expectedMaxConnections, typedStruct.Value.Fields["max_connections"].GetNumberValue())
+			assert.Equal("inbound-connection-limit.default/bookstore-v1-local", typedStruct.Value.Fields["stat_prefix"].GetStringValue())
+		})
+	}
+}
+
+func TestGetInboundPerConnectionBufferLimit(t *testing.T) {
+	settingWithBufferLimit := func(limit uint32) *policyV1alpha1.UpstreamTrafficSetting {
+		return &policyV1alpha1.UpstreamTrafficSetting{
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Inbound: &policyV1alpha1.InboundConnectionSettingsSpec{PerConnectionBufferLimitBytes: uint32Ptr(limit)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		settings      map[service.MeshService]*policyV1alpha1.UpstreamTrafficSetting
+		expectedLimit *wrapperspb.UInt32Value
+	}{
+		{
+			name:          "no UpstreamTrafficSetting",
+			settings:      map[service.MeshService]*policyV1alpha1.UpstreamTrafficSetting{},
+			expectedLimit: nil,
+		},
+		{
+			name: "UpstreamTrafficSetting without buffer limit",
+			settings: map[service.MeshService]*policyV1alpha1.UpstreamTrafficSetting{
+				tests.BookstoreV1Service: {
+					Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+						Inbound: &policyV1alpha1.InboundConnectionSettingsSpec{MaxConnections: uint32Ptr(100)},
+					},
+				},
+			},
+			expectedLimit: nil,
+		},
+		{
+			name: "smallest buffer limit of the services",
+			settings: map[service.MeshService]*policyV1alpha1.UpstreamTrafficSetting{
+				tests.BookstoreV1Service:   settingWithBufferLimit(65536),
+				tests.BookstoreApexService: settingWithBufferLimit(32768),
+			},
+			expectedLimit: wrapperspb.UInt32(32768),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			svcList := []service.MeshService{tests.BookstoreV1Service, tests.BookstoreApexService}
+			for _, svc := range svcList {
+				mockCatalog.EXPECT().GetUpstreamTrafficSetting(svc).Return(tc.settings[svc]).Times(1)
+			}
+
+			lb := &listenerBuilder{meshCatalog: mockCatalog}
+			assert.Equal(tc.expectedLimit, lb.getInboundPerConnectionBufferLimit(svcList))
+		})
+	}
+}
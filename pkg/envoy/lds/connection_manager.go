@@ -23,7 +23,7 @@ const (
 	prometheusInboundVirtualHostName    = "prometheus-inbound-virtual-host"
 )
 
-func getHTTPConnectionManager(routeName string, cfg configurator.Configurator, headers map[string]string) *xds_hcm.HttpConnectionManager {
+func getHTTPConnectionManager(routeName string, cfg configurator.Configurator, headers map[string]string, workloadMetadata map[string]string) *xds_hcm.HttpConnectionManager {
 	connManager := &xds_hcm.HttpConnectionManager{
 		StatPrefix: fmt.Sprintf("%s.%s", meshHTTPConnManagerStatPrefix, routeName),
 		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
@@ -44,7 +44,7 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator, h
 				RouteConfigName: routeName,
 			},
 		},
-		AccessLog: envoy.GetAccessLog(),
+		AccessLog: envoy.GetAccessLog(workloadMetadata),
 	}
 
 	if cfg.IsTracingEnabled() {
@@ -115,7 +115,7 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator, h
 	return connManager
 }
 
-func getPrometheusConnectionManager() *xds_hcm.HttpConnectionManager {
+func getPrometheusConnectionManager(workloadMetadata map[string]string) *xds_hcm.HttpConnectionManager {
 	return &xds_hcm.HttpConnectionManager{
 		StatPrefix: prometheusHTTPConnManagerStatPrefix,
 		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
@@ -145,6 +145,22 @@ func getPrometheusConnectionManager() *xds_hcm.HttpConnectionManager {
 				}},
 			},
 		},
-		AccessLog: envoy.GetAccessLog(),
+		AccessLog: envoy.GetAccessLog(workloadMetadata),
 	}
 }
+
+// applyXFFSettings configures the handling of the x-forwarded-for header of the requests received by the given
+// connection manager. When the header is overwritten, the connection manager does not append to the header and
+// the routes replace it with the address of the client.
+func applyXFFSettings(connManager *xds_hcm.HttpConnectionManager, settings configurator.XFFSettings) {
+	if settings.UnixSocketsInternal {
+		connManager.InternalAddressConfig = &xds_hcm.HttpConnectionManager_InternalAddressConfig{UnixSockets: true}
+	}
+	if settings.HeaderMode == "" {
+		return
+	}
+
+	connManager.UseRemoteAddress = &wrappers.BoolValue{Value: true}
+	connManager.XffNumTrustedHops = settings.NumTrustedHops
+	connManager.SkipXffAppend = settings.HeaderMode != configurator.XFFHeaderModeAppend
+}
@@ -0,0 +1,60 @@
+package lds
+
+import (
+	"testing"
+
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+func TestApplyXFFSettings(t *testing.T) {
+	testCases := []struct {
+		name                string
+		settings            configurator.XFFSettings
+		expectedConnManager *xds_hcm.HttpConnectionManager
+	}{
+		{
+			name:                "default settings",
+			settings:            configurator.XFFSettings{},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{},
+		},
+		{
+			name:     "append with trusted hops",
+			settings: configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModeAppend, NumTrustedHops: 2},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{
+				UseRemoteAddress:  &wrappers.BoolValue{Value: true},
+				XffNumTrustedHops: 2,
+			},
+		},
+		{
+			name:     "overwrite",
+			settings: configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModeOverwrite},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{
+				UseRemoteAddress: &wrappers.BoolValue{Value: true},
+				SkipXffAppend:    true,
+			},
+		},
+		{
+			name:     "preserve with unix sockets internal",
+			settings: configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModePreserve, UnixSocketsInternal: true},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{
+				UseRemoteAddress:      &wrappers.BoolValue{Value: true},
+				SkipXffAppend:         true,
+				InternalAddressConfig: &xds_hcm.HttpConnectionManager_InternalAddressConfig{UnixSockets: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			connManager := &xds_hcm.HttpConnectionManager{}
+			applyXFFSettings(connManager, tc.settings)
+			assert.Equal(tc.expectedConnManager, connManager)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_grpc_stats "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+// getGRPCStatsFilter returns the HTTP filter emitting per method stats for the gRPC requests matching the given routes.
+// Stats are emitted for the listed methods only, unless a route matches all the methods of a service, in which case
+// they are emitted for all the methods called.
+func getGRPCStatsFilter(grpcRoutes []policyV1alpha1.GRPCRouteSpec) (*xds_hcm.HttpFilter, error) {
+	grpcStats := &xds_grpc_stats.FilterConfig{
+		EnableUpstreamStats: true,
+	}
+
+	var services []*xds_core.GrpcMethodList_Service
+	methodsPerService := make(map[string]*xds_core.GrpcMethodList_Service)
+	for _, route := range grpcRoutes {
+		if route.Method == "" {
+			grpcStats.PerMethodStatSpecifier = &xds_grpc_stats.FilterConfig_StatsForAllMethods{
+				StatsForAllMethods: wrapperspb.Bool(true),
+			}
+			break
+		}
+		svc, ok := methodsPerService[route.Service]
+		if !ok {
+			svc = &xds_core.GrpcMethodList_Service{Name: route.Service}
+			methodsPerService[route.Service] = svc
+			services = append(services, svc)
+		}
+		svc.MethodNames = append(svc.MethodNames, route.Method)
+	}
+	if grpcStats.PerMethodStatSpecifier == nil {
+		grpcStats.PerMethodStatSpecifier = &xds_grpc_stats.FilterConfig_IndividualMethodStatsAllowlist{
+			IndividualMethodStatsAllowlist: &xds_core.GrpcMethodList{Services: services},
+		}
+	}
+
+	marshalled, err := ptypes.MarshalAny(grpcStats)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling gRPC stats filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wellknown.HTTPGRPCStats,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
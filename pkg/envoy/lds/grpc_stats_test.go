@@ -0,0 +1,69 @@
+package lds
+
+import (
+	"testing"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_grpc_stats "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+func TestGetGRPCStatsFilter(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		grpcRoutes            []policyV1alpha1.GRPCRouteSpec
+		expectedAllowlist     []*xds_core.GrpcMethodList_Service
+		expectAllMethodsStats bool
+	}{
+		{
+			name: "routes for individual methods",
+			grpcRoutes: []policyV1alpha1.GRPCRouteSpec{
+				{Service: "bookstore.v1.Bookstore", Method: "Buy"},
+				{Service: "bookstore.v1.Inventory", Method: "List"},
+				{Service: "bookstore.v1.Bookstore", Method: "Sell"},
+			},
+			expectedAllowlist: []*xds_core.GrpcMethodList_Service{
+				{Name: "bookstore.v1.Bookstore", MethodNames: []string{"Buy", "Sell"}},
+				{Name: "bookstore.v1.Inventory", MethodNames: []string{"List"}},
+			},
+		},
+		{
+			name: "route for all the methods of a service",
+			grpcRoutes: []policyV1alpha1.GRPCRouteSpec{
+				{Service: "bookstore.v1.Bookstore", Method: "Buy"},
+				{Service: "bookstore.v1.Inventory"},
+			},
+			expectAllMethodsStats: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			filter, err := getGRPCStatsFilter(tc.grpcRoutes)
+			assert.Nil(err)
+			assert.Equal(wellknown.HTTPGRPCStats, filter.Name)
+
+			grpcStats := &xds_grpc_stats.FilterConfig{}
+			assert.Nil(ptypes.UnmarshalAny(filter.GetTypedConfig(), grpcStats))
+			assert.True(grpcStats.EnableUpstreamStats)
+			if tc.expectAllMethodsStats {
+				assert.True(grpcStats.GetStatsForAllMethods().GetValue())
+				assert.Nil(grpcStats.GetIndividualMethodStatsAllowlist())
+				return
+			}
+
+			services := grpcStats.GetIndividualMethodStatsAllowlist().GetServices()
+			assert.Len(services, len(tc.expectedAllowlist))
+			for i, expected := range tc.expectedAllowlist {
+				assert.Equal(expected.Name, services[i].Name)
+				assert.Equal(expected.MethodNames, services[i].MethodNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package lds
+
+import (
+	"fmt"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// rejectEscapedSlashesLua rejects the requests whose paths, excluding their query, contain escaped slashes or backslashes,
+// which could otherwise be routed or authorized differently by the sidecar and by the application
+const rejectEscapedSlashesLua = `
+function envoy_on_request(request_handle)
+  local path = string.lower(string.match(request_handle:headers():get(":path") or "", "^[^?#]*"))
+  if string.find(path, "%2f", 1, true) or string.find(path, "%5c", 1, true) then
+    request_handle:respond({[":status"] = "400"}, "escaped slashes are not allowed in the path")
+  end
+end
+`
+
+// applyHTTPProtocolOptions configures the given connection manager of the HTTP connections to or from the given service
+// with the given options of the HTTP connections of the service
+func applyHTTPProtocolOptions(connManager *xds_hcm.HttpConnectionManager, svc service.MeshService, options configurator.HTTPProtocolOptions) error {
+	if options.AcceptHTTP10 || options.EnableTrailers {
+		connManager.HttpProtocolOptions = &envoy_config_core_v3.Http1ProtocolOptions{
+			AcceptHttp_10:  options.AcceptHTTP10,
+			EnableTrailers: options.EnableTrailers,
+		}
+		if options.AcceptHTTP10 {
+			// HTTP/1.0 requests may not have a Host header, they are routed to the service
+			connManager.HttpProtocolOptions.DefaultHostForHttp_10 = fmt.Sprintf("%s.%s", svc.Name, svc.Namespace)
+		}
+	}
+
+	return applyPathNormalization(connManager, options)
+}
+
+// applyPathNormalization configures the given connection manager to normalize the paths of requests, and to reject
+// the requests whose paths contain escaped slashes, as per the given options
+func applyPathNormalization(connManager *xds_hcm.HttpConnectionManager, options configurator.HTTPProtocolOptions) error {
+	if options.NormalizePath {
+		connManager.NormalizePath = &wrappers.BoolValue{Value: true}
+	}
+	connManager.MergeSlashes = options.MergeSlashes
+
+	if options.RejectEscapedSlashes {
+		rejectFilter, err := getRejectEscapedSlashesFilter()
+		if err != nil {
+			return err
+		}
+		// Requests are rejected before being authorized
+		connManager.HttpFilters = append([]*xds_hcm.HttpFilter{rejectFilter}, connManager.HttpFilters...)
+	}
+
+	return nil
+}
+
+// getRejectEscapedSlashesFilter returns an HTTP filter rejecting the requests whose paths contain escaped slashes or backslashes
+func getRejectEscapedSlashesFilter() (*xds_hcm.HttpFilter, error) {
+	luaAny, err := ptypes.MarshalAny(&xds_lua.Lua{InlineCode: rejectEscapedSlashesLua})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling Lua filter rejecting escaped slashes")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wellknown.Lua,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: luaAny,
+		},
+	}, nil
+}
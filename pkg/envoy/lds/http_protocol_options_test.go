@@ -0,0 +1,78 @@
+package lds
+
+import (
+	"testing"
+
+	envoy_config_core_v3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestApplyHTTPProtocolOptions(t *testing.T) {
+	svc := service.MeshService{Namespace: "ns", Name: "legacy"}
+
+	testCases := []struct {
+		name                string
+		options             configurator.HTTPProtocolOptions
+		expectedConnManager *xds_hcm.HttpConnectionManager
+	}{
+		{
+			name:                "default options",
+			options:             configurator.HTTPProtocolOptions{},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{},
+		},
+		{
+			name:    "HTTP/1.0 and trailers",
+			options: configurator.HTTPProtocolOptions{AcceptHTTP10: true, EnableTrailers: true},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{
+				HttpProtocolOptions: &envoy_config_core_v3.Http1ProtocolOptions{
+					AcceptHttp_10:         true,
+					DefaultHostForHttp_10: "legacy.ns",
+					EnableTrailers:        true,
+				},
+			},
+		},
+		{
+			name:    "path normalization",
+			options: configurator.HTTPProtocolOptions{NormalizePath: true, MergeSlashes: true},
+			expectedConnManager: &xds_hcm.HttpConnectionManager{
+				NormalizePath: &wrappers.BoolValue{Value: true},
+				MergeSlashes:  true,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			connManager := &xds_hcm.HttpConnectionManager{}
+			assert.Nil(applyHTTPProtocolOptions(connManager, svc, tc.options))
+			assert.Equal(tc.expectedConnManager, connManager)
+		})
+	}
+}
+
+func TestApplyHTTPProtocolOptionsRejectEscapedSlashes(t *testing.T) {
+	assert := tassert.New(t)
+
+	connManager := &xds_hcm.HttpConnectionManager{
+		HttpFilters: []*xds_hcm.HttpFilter{
+			{Name: wellknown.HTTPRoleBasedAccessControl},
+			{Name: wellknown.Router},
+		},
+	}
+	err := applyHTTPProtocolOptions(connManager, service.MeshService{Namespace: "ns", Name: "legacy"}, configurator.HTTPProtocolOptions{RejectEscapedSlashes: true})
+	assert.Nil(err)
+
+	// Requests are rejected before being authorized
+	assert.Len(connManager.HttpFilters, 3)
+	assert.Equal(wellknown.Lua, connManager.HttpFilters[0].Name)
+	assert.Equal(wellknown.HTTPRoleBasedAccessControl, connManager.HttpFilters[1].Name)
+	assert.Equal(wellknown.Router, connManager.HttpFilters[2].Name)
+}
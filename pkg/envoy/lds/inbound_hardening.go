@@ -0,0 +1,57 @@
+package lds
+
+import (
+	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+// stripEnvoyHeadersLua removes the x-envoy-* headers of requests, which would otherwise let the clients sending them
+// control how the requests are retried, timed out or routed by the sidecar
+const stripEnvoyHeadersLua = `
+function envoy_on_request(request_handle)
+  local names = {}
+  for name, _ in pairs(request_handle:headers()) do
+    if string.sub(name, 1, 8) == "x-envoy-" then
+      table.insert(names, name)
+    end
+  end
+  for _, name in ipairs(names) do
+    request_handle:headers():remove(name)
+  end
+end
+`
+
+// applyInboundHardening hardens the given connection manager of an inbound or ingress listener with the given settings.
+// The x-envoy-* headers are only stripped from the requests of external clients, the clients outside the mesh.
+func applyInboundHardening(connManager *xds_hcm.HttpConnectionManager, settings configurator.InboundHardeningSettings, external bool) error {
+	if settings.StripEnvoyHeaders && external {
+		stripFilter, err := getStripEnvoyHeadersFilter()
+		if err != nil {
+			return err
+		}
+		// Headers are stripped before any other filter can act on them
+		connManager.HttpFilters = append([]*xds_hcm.HttpFilter{stripFilter}, connManager.HttpFilters...)
+	}
+
+	return nil
+}
+
+// getStripEnvoyHeadersFilter returns an HTTP filter removing the x-envoy-* headers of requests
+func getStripEnvoyHeadersFilter() (*xds_hcm.HttpFilter, error) {
+	luaAny, err := ptypes.MarshalAny(&xds_lua.Lua{InlineCode: stripEnvoyHeadersLua})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling Lua filter stripping x-envoy-* headers")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wellknown.Lua,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: luaAny,
+		},
+	}, nil
+}
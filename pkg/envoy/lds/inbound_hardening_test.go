@@ -0,0 +1,63 @@
+package lds
+
+import (
+	"testing"
+
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+func TestApplyInboundHardening(t *testing.T) {
+	testCases := []struct {
+		name            string
+		settings        configurator.InboundHardeningSettings
+		external        bool
+		expectedFilters []string
+	}{
+		{
+			name:            "default settings",
+			settings:        configurator.InboundHardeningSettings{},
+			external:        true,
+			expectedFilters: []string{wellknown.HTTPRoleBasedAccessControl, wellknown.Router},
+		},
+		{
+			name:            "x-envoy-* headers stripped from external clients",
+			settings:        configurator.InboundHardeningSettings{StripEnvoyHeaders: true},
+			external:        true,
+			expectedFilters: []string{wellknown.Lua, wellknown.HTTPRoleBasedAccessControl, wellknown.Router},
+		},
+		{
+			name:            "x-envoy-* headers kept for mesh clients",
+			settings:        configurator.InboundHardeningSettings{StripEnvoyHeaders: true},
+			external:        false,
+			expectedFilters: []string{wellknown.HTTPRoleBasedAccessControl, wellknown.Router},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			connManager := &xds_hcm.HttpConnectionManager{
+				HttpFilters: []*xds_hcm.HttpFilter{
+					{Name: wellknown.HTTPRoleBasedAccessControl},
+					{Name: wellknown.Router},
+				},
+			}
+			assert.Nil(applyInboundHardening(connManager, tc.settings, tc.external))
+
+			var filters []string
+			for _, filter := range connManager.HttpFilters {
+				filters = append(filters, filter.Name)
+			}
+			assert.Equal(tc.expectedFilters, filters)
+
+			// Paths are normalized as per the HTTP protocol options only
+			assert.Nil(connManager.NormalizePath)
+			assert.False(connManager.MergeSlashes)
+		})
+	}
+}
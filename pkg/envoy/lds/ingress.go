@@ -33,13 +33,30 @@ func getIngressTransportProtocol(forHTTPS bool) string {
 }
 
 func (lb *listenerBuilder) newIngressHTTPFilterChain(cfg configurator.Configurator, svc service.MeshService, svcPort uint32) *xds_listener.FilterChain {
-	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(lb.serviceIdentity, false /* TLS */))
+	// HTTPS ingress clients are served the TLS Secret referenced by the service if any, and the service certificate otherwise
+	downstreamTLSContext := envoy.GetDownstreamTLSContext(lb.serviceIdentity, false /* TLS */, lb.cfg)
+	if cfg.UseHTTPSIngress() {
+		if secret := lb.meshCatalog.GetIngressBackendTLSSecret(svc); secret != nil {
+			downstreamTLSContext = envoy.GetIngressBackendDownstreamTLSContext(secret.Namespace, secret.Name, lb.cfg)
+		}
+	}
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(downstreamTLSContext)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", svc)
 		return nil
 	}
 
-	ingressConnManager := getHTTPConnectionManager(route.IngressRouteConfigName, cfg, nil)
+	ingressConnManager := getHTTPConnectionManager(route.IngressRouteConfigName, cfg, nil, lb.workloadMetadata)
+	applyXFFSettings(ingressConnManager, lb.meshCatalog.GetIngressBackendXFFSettings(svc))
+	// Ingress requests are handled as the inbound requests of the service
+	if err := applyHTTPProtocolOptions(ingressConnManager, svc, cfg.GetHTTPProtocolOptions(svc)); err != nil {
+		log.Error().Err(err).Msgf("Error applying HTTP protocol options to ingress HttpConnectionManager object for proxy %s", svc)
+		return nil
+	}
+	if err := applyInboundHardening(ingressConnManager, cfg.GetInboundHardeningSettings(), true /* external */); err != nil {
+		log.Error().Err(err).Msgf("Error applying inbound hardening to ingress HttpConnectionManager object for proxy %s", svc)
+		return nil
+	}
 	marshalledIngressConnManager, err := ptypes.MarshalAny(ingressConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling ingress HttpConnectionManager object for proxy %s", svc)
@@ -82,16 +99,19 @@ func (lb *listenerBuilder) getIngressFilterChains(svc service.MeshService) []*xd
 			// Ingress filter chain for HTTP port
 			if lb.cfg.UseHTTPSIngress() {
 				// Filter chain with SNI matching enabled for HTTPS clients that set the SNI
-				ingressFilterChainWithSNI := lb.newIngressHTTPFilterChain(lb.cfg, svc, port)
-				ingressFilterChainWithSNI.Name = fmt.Sprintf("%s:%d", inboundIngressHTTPSFilterChain, port)
-				ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.ServerName()}
-				ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithSNI)
+				// Filter chains that could not be built are skipped, the error is logged by newIngressHTTPFilterChain
+				if ingressFilterChainWithSNI := lb.newIngressHTTPFilterChain(lb.cfg, svc, port); ingressFilterChainWithSNI != nil {
+					ingressFilterChainWithSNI.Name = fmt.Sprintf("%s:%d", inboundIngressHTTPSFilterChain, port)
+					ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.ServerName()}
+					ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithSNI)
+				}
 			}
 
 			// Filter chain without SNI matching enabled for HTTP clients and HTTPS clients that don't set the SNI
-			ingressFilterChainWithoutSNI := lb.newIngressHTTPFilterChain(lb.cfg, svc, port)
-			ingressFilterChainWithoutSNI.Name = fmt.Sprintf("%s:%d", inboundIngressNonSNIFilterChain, port)
-			ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithoutSNI)
+			if ingressFilterChainWithoutSNI := lb.newIngressHTTPFilterChain(lb.cfg, svc, port); ingressFilterChainWithoutSNI != nil {
+				ingressFilterChainWithoutSNI.Name = fmt.Sprintf("%s:%d", inboundIngressNonSNIFilterChain, port)
+				ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithoutSNI)
+			}
 
 		default:
 			log.Error().Msgf("Cannot build ingress filter chain. Protocol %s is not supported for service %s on port %d",
@@ -0,0 +1,53 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const ingressGatewayListenerName = "ingress-gateway-listener"
+
+// newIngressGatewayListener returns the listener for the OSM managed ingress gateway.
+// The listener accepts plaintext HTTP traffic from clients outside the mesh and routes it using the ingress gateway route configuration.
+func (lb *listenerBuilder) newIngressGatewayListener() (*xds_listener.Listener, error) {
+	gatewayConnManager := getHTTPConnectionManager(route.IngressGatewayRouteConfigName, lb.cfg, nil, lb.workloadMetadata)
+	// The gateway routes to any service, its requests are normalized as per the mesh-wide options
+	if err := applyPathNormalization(gatewayConnManager, lb.cfg.GetHTTPProtocolOptions(service.MeshService{})); err != nil {
+		log.Error().Err(err).Msgf("Error applying path normalization for the ingress gateway listener")
+		return nil, err
+	}
+	if err := applyInboundHardening(gatewayConnManager, lb.cfg.GetInboundHardeningSettings(), true /* external */); err != nil {
+		log.Error().Err(err).Msgf("Error applying inbound hardening for the ingress gateway listener")
+		return nil, err
+	}
+	marshalledConnManager, err := ptypes.MarshalAny(gatewayConnManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object for the ingress gateway listener")
+		return nil, err
+	}
+
+	return &xds_listener.Listener{
+		Name:             ingressGatewayListenerName,
+		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.OSMIngressGatewayListenerPort),
+		TrafficDirection: xds_core.TrafficDirection_INBOUND,
+		FilterChains: []*xds_listener.FilterChain{
+			{
+				Filters: []*xds_listener.Filter{
+					{
+						Name: wellknown.HTTPConnectionManager,
+						ConfigType: &xds_listener.Filter_TypedConfig{
+							TypedConfig: marshalledConnManager,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
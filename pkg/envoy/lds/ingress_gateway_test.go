@@ -0,0 +1,47 @@
+package lds
+
+import (
+	"testing"
+
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestNewIngressGatewayListener(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{StripEnvoyHeaders: true}).Times(1)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(service.MeshService{}).Return(configurator.HTTPProtocolOptions{NormalizePath: true, MergeSlashes: true}).Times(1)
+
+	lb := newListenerBuilder(nil, tests.BookbuyerServiceIdentity, mockConfigurator, nil, nil)
+	listener, err := lb.newIngressGatewayListener()
+	require.Nil(err)
+
+	assert.Equal(ingressGatewayListenerName, listener.Name)
+	assert.Equal(uint32(constants.OSMIngressGatewayListenerPort), listener.Address.GetSocketAddress().GetPortValue())
+	require.Len(listener.FilterChains, 1)
+	require.Len(listener.FilterChains[0].Filters, 1)
+
+	connManager := &xds_hcm.HttpConnectionManager{}
+	require.Nil(ptypes.UnmarshalAny(listener.FilterChains[0].Filters[0].GetTypedConfig(), connManager))
+	assert.Equal(route.IngressGatewayRouteConfigName, connManager.GetRds().RouteConfigName)
+
+	// Requests are normalized as per the mesh-wide options and hardened against clients outside the mesh
+	assert.True(connManager.GetNormalizePath().GetValue())
+	assert.True(connManager.MergeSlashes)
+	assert.Equal(wellknown.Lua, connManager.HttpFilters[0].Name)
+}
@@ -6,9 +6,14 @@ import (
 
 	"github.com/golang/mock/gomock"
 	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
@@ -90,6 +95,14 @@ func TestGetIngressFilterChains(t *testing.T) {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+			mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+			mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+			mockConfigurator.EXPECT().GetHTTPProtocolOptions(proxyService).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+			mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+			mockCatalog.EXPECT().GetIngressBackendXFFSettings(gomock.Any()).Return(configurator.XFFSettings{}).AnyTimes()
 
 			lb := &listenerBuilder{
 				meshCatalog:     mockCatalog,
@@ -99,6 +112,7 @@ func TestGetIngressFilterChains(t *testing.T) {
 
 			// Mock catalog call to get port:protocol mapping for service
 			mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(proxyService).Return(tc.svcPortToProtocolMap, tc.portToProtocolErr).Times(1)
+			mockCatalog.EXPECT().GetIngressBackendTLSSecret(proxyService).Return(nil).AnyTimes()
 			// Mock configurator calls to determine HTTP vs HTTPS ingress
 			mockConfigurator.EXPECT().UseHTTPSIngress().Return(tc.httpsIngress).AnyTimes()
 			// Mock calls used to build the HTTP connection manager
@@ -150,3 +164,106 @@ func TestGetIngressTransportProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestNewIngressHTTPFilterChainTLSSecret(t *testing.T) {
+	proxyService := tests.BookstoreV1Service
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore-tls",
+			Namespace: proxyService.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	testCases := []struct {
+		name               string
+		secret             *corev1.Secret
+		expectedSecretName string
+	}{
+		{
+			name:               "service certificate is served when the service does not reference a TLS secret",
+			secret:             nil,
+			expectedSecretName: "service-cert:default/bookstore",
+		},
+		{
+			name:               "TLS secret referenced by the service is served",
+			secret:             secret,
+			expectedSecretName: "ingress-backend-tls-cert:default/bookstore-tls",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+			mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+			mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+			mockConfigurator.EXPECT().GetHTTPProtocolOptions(proxyService).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+			mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+			mockCatalog.EXPECT().GetIngressBackendXFFSettings(gomock.Any()).Return(configurator.XFFSettings{}).AnyTimes()
+			mockConfigurator.EXPECT().UseHTTPSIngress().Return(true).AnyTimes()
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+			mockCatalog.EXPECT().GetIngressBackendTLSSecret(proxyService).Return(tc.secret).Times(1)
+
+			lb := &listenerBuilder{
+				meshCatalog:     mockCatalog,
+				cfg:             mockConfigurator,
+				serviceIdentity: tests.BookstoreServiceIdentity,
+			}
+
+			filterChain := lb.newIngressHTTPFilterChain(mockConfigurator, proxyService, 80)
+			assert.NotNil(filterChain.TransportSocket)
+
+			tlsContext := &xds_auth.DownstreamTlsContext{}
+			assert.Nil(ptypes.UnmarshalAny(filterChain.TransportSocket.GetTypedConfig(), tlsContext))
+			assert.Equal(tc.expectedSecretName, tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name)
+		})
+	}
+}
+
+func TestNewIngressHTTPFilterChainPathNormalization(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	proxyService := tests.BookstoreV1Service
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().UseHTTPSIngress().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockCatalog.EXPECT().GetIngressBackendXFFSettings(gomock.Any()).Return(configurator.XFFSettings{}).AnyTimes()
+
+	// The options of the service, including its overrides, apply to its ingress requests
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(proxyService).Return(configurator.HTTPProtocolOptions{NormalizePath: true, RejectEscapedSlashes: true}).Times(1)
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
+		serviceIdentity: tests.BookstoreServiceIdentity,
+	}
+
+	filterChain := lb.newIngressHTTPFilterChain(mockConfigurator, proxyService, 80)
+	assert.NotNil(filterChain)
+
+	connManager := &xds_hcm.HttpConnectionManager{}
+	assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), connManager))
+	assert.True(connManager.GetNormalizePath().GetValue())
+	assert.False(connManager.MergeSlashes)
+
+	// Ingress requests with escaped slashes are rejected before being routed
+	assert.Equal(wellknown.Lua, connManager.HttpFilters[0].Name)
+}
@@ -8,6 +8,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
@@ -15,9 +16,12 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -28,6 +32,9 @@ const (
 	outboundMeshTCPFilterChainPrefix  = "outbound-mesh-tcp-filter-chain"
 	inboundMeshTCPProxyStatPrefix     = "inbound-mesh-tcp-proxy"
 	outboundMeshTCPProxyStatPrefix    = "outbound-mesh-tcp-proxy"
+
+	inboundPlaintextTCPFilterChainPrefix = "inbound-plaintext-tcp-filter-chain"
+	inboundPlaintextTCPProxyStatPrefix   = "inbound-plaintext-tcp-proxy"
 )
 
 func (lb *listenerBuilder) getInboundMeshFilterChains(proxyService service.MeshService) []*xds_listener.FilterChain {
@@ -39,20 +46,28 @@ func (lb *listenerBuilder) getInboundMeshFilterChains(proxyService service.MeshS
 		return filterChains
 	}
 
+	// The filter chains of a service exposing multiple ports only forward the traffic to the local endpoint of their port
+	multiPort := len(protocolToPortMap) > 1
+
 	// Create protocol specific inbound filter chains per port to handle different ports serving different protocols
-	for port, appProtocol := range protocolToPortMap {
+	for _, port := range getSortedPorts(protocolToPortMap) {
+		appProtocol := protocolToPortMap[port]
 		switch strings.ToLower(appProtocol) {
 		case constants.ProtocolHTTP, constants.ProtocolGRPC:
 			// Filter chain for HTTP port
-			filterChainForPort, err := lb.getInboundMeshHTTPFilterChain(proxyService, port)
+			filterChainForPort, err := lb.getInboundMeshHTTPFilterChain(proxyService, port, multiPort)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error building inbound HTTP filter chain for proxy:port %s:%d", proxyService, port)
 				continue // continue building filter chains for other ports on the service
 			}
+			if strings.ToLower(appProtocol) == constants.ProtocolGRPC && featureflags.IsProxylessGRPCEnabled() {
+				// Proxyless gRPC clients advertise 'h2' instead of the in-mesh ALPN
+				filterChainForPort.FilterChainMatch.ApplicationProtocols = append(append([]string{}, envoy.ALPNInMesh...), alpnHTTP2)
+			}
 			filterChains = append(filterChains, filterChainForPort)
 
 		case constants.ProtocolTCP:
-			filterChainForPort, err := lb.getInboundMeshTCPFilterChain(proxyService, port)
+			filterChainForPort, err := lb.getInboundMeshTCPFilterChain(proxyService, port, multiPort)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error building inbound TCP filter chain for proxy:port %s:%d", proxyService, port)
 				continue // continue building filter chains for other ports on the service
@@ -67,10 +82,15 @@ func (lb *listenerBuilder) getInboundMeshFilterChains(proxyService service.MeshS
 	return filterChains
 }
 
-func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshService) ([]*xds_listener.Filter, error) {
-	var filters []*xds_listener.Filter
+func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshService, routeConfigName string) ([]*xds_listener.Filter, error) {
+	upstreamTrafficSetting := lb.meshCatalog.GetUpstreamTrafficSetting(proxyService)
+
+	filters, err := lb.getInboundConnectionLimitFilters(upstreamTrafficSetting, proxyService)
+	if err != nil {
+		return nil, err
+	}
 
-	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must be the first filter in the list of filters.
+	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must precede the filters handling the traffic.
 	if !lb.cfg.IsPermissiveTrafficPolicyMode() {
 		// Apply RBAC policies on the inbound filters based on configured policies
 		rbacFilter, err := lb.buildRBACFilter()
@@ -78,12 +98,75 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 			log.Error().Err(err).Msgf("Error applying RBAC filter for proxy service %s", proxyService)
 			return nil, err
 		}
-		// RBAC filter should be the very first filter in the filter chain
 		filters = append(filters, rbacFilter)
 	}
 
 	// Apply the HTTP Connection Manager Filter
-	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, lb.cfg, lb.statsHeaders)
+	inboundConnManager := getHTTPConnectionManager(routeConfigName, lb.cfg, lb.statsHeaders, lb.workloadMetadata)
+
+	// The peers of the sidecar are the sidecars of its clients within the mesh, which cannot be trusted to report the
+	// address of the client in the x-forwarded-for header
+	xffSettings := lb.cfg.GetXFFSettings()
+	xffSettings.NumTrustedHops = 0
+	applyXFFSettings(inboundConnManager, xffSettings)
+
+	if err := applyHTTPProtocolOptions(inboundConnManager, proxyService, lb.cfg.GetHTTPProtocolOptions(proxyService)); err != nil {
+		log.Error().Err(err).Msgf("Error applying HTTP protocol options for proxy service %s", proxyService)
+		return nil, err
+	}
+	if err := applyInboundHardening(inboundConnManager, lb.cfg.GetInboundHardeningSettings(), false /* external */); err != nil {
+		log.Error().Err(err).Msgf("Error applying inbound hardening for proxy service %s", proxyService)
+		return nil, err
+	}
+
+	if upstreamTrafficSetting != nil {
+		var httpFilters []*xds_hcm.HttpFilter
+
+		// Authorize the requests bearing a JWT based on the identity claimed by the token, in addition to the
+		// identity of the client's certificate. Requests are authorized before their load is accounted for.
+		if jwtAuthentication := upstreamTrafficSetting.Spec.JWTAuthentication; jwtAuthentication != nil {
+			jwtFilters, err := lb.getInboundJWTAuthenticationFilters(jwtAuthentication)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building JWT authentication filters for proxy service %s", proxyService)
+				return nil, err
+			}
+			httpFilters = append(httpFilters, jwtFilters...)
+		}
+
+		// Rate limit the authorized requests depending on the identity of the clients sending them
+		if getHTTPLocalRateLimitSpec(upstreamTrafficSetting.Spec) != nil && !lb.isFeatureWithheld(envoy.FeatureHTTPLocalRateLimit) {
+			rateLimitFilter, err := getHTTPLocalRateLimitFilter()
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building HTTP local rate limit filter for proxy service %s", proxyService)
+				return nil, err
+			}
+			httpFilters = append(httpFilters, rateLimitFilter)
+			forwardClientCertDNS(inboundConnManager)
+		}
+
+		// Shed the load of the service when it is overloaded, as configured by its UpstreamTrafficSetting
+		loadSheddingFilters, err := getLoadSheddingFilters(upstreamTrafficSetting.Spec)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building load shedding filters for proxy service %s", proxyService)
+			return nil, err
+		}
+		if len(loadSheddingFilters) > 0 && !lb.isFeatureWithheld(envoy.FeatureLoadShedding) {
+			httpFilters = append(httpFilters, loadSheddingFilters...)
+		}
+
+		// Emit per method stats for the gRPC requests matching the gRPC routes of the service
+		if len(upstreamTrafficSetting.Spec.GRPCRoutes) > 0 && !lb.isFeatureWithheld(envoy.FeatureGRPCStats) {
+			grpcStatsFilter, err := getGRPCStatsFilter(upstreamTrafficSetting.Spec.GRPCRoutes)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building gRPC stats filter for proxy service %s", proxyService)
+				return nil, err
+			}
+			httpFilters = append(httpFilters, grpcStatsFilter)
+		}
+
+		inboundConnManager.HttpFilters = insertBeforeRouterFilter(inboundConnManager.HttpFilters, httpFilters...)
+	}
+
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager for proxy  service %s", proxyService)
@@ -100,16 +183,59 @@ func (lb *listenerBuilder) getInboundHTTPFilters(proxyService service.MeshServic
 	return filters, nil
 }
 
-func (lb *listenerBuilder) getInboundMeshHTTPFilterChain(proxyService service.MeshService, servicePort uint32) (*xds_listener.FilterChain, error) {
+// getInboundConnectionLimitFilters returns the network filters limiting the number of connections accepted by
+// an inbound filter chain of the given service, as configured by its UpstreamTrafficSetting. These filters
+// must be the first filters of the filter chain so that excess connections are closed before being processed.
+func (lb *listenerBuilder) getInboundConnectionLimitFilters(upstreamTrafficSetting *policyV1alpha1.UpstreamTrafficSetting, proxyService service.MeshService) ([]*xds_listener.Filter, error) {
+	if upstreamTrafficSetting == nil {
+		return nil, nil
+	}
+
+	connectionLimitFilter, err := getInboundConnectionLimitFilter(upstreamTrafficSetting.Spec.Inbound, proxyService)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building connection limit filter for proxy service %s", proxyService)
+		return nil, err
+	}
+	if connectionLimitFilter == nil || lb.isFeatureWithheld(envoy.FeatureConnectionLimit) {
+		return nil, nil
+	}
+	return []*xds_listener.Filter{connectionLimitFilter}, nil
+}
+
+// getInboundJWTAuthenticationFilters returns the HTTP filters authorizing the requests bearing a JWT, as configured
+// in the given spec, based on the identities allowed to access the proxy by the SMI TrafficTarget policies
+func (lb *listenerBuilder) getInboundJWTAuthenticationFilters(spec *policyV1alpha1.JWTAuthenticationSpec) ([]*xds_hcm.HttpFilter, error) {
+	permissiveMode := lb.cfg.IsPermissiveTrafficPolicyMode()
+
+	var allowedIdentities []identity.ServiceIdentity
+	if !permissiveMode {
+		trafficTargets, err := lb.meshCatalog.ListInboundTrafficTargetsWithRoutes(lb.serviceIdentity)
+		if err != nil {
+			return nil, err
+		}
+		for _, trafficTarget := range trafficTargets {
+			allowedIdentities = append(allowedIdentities, trafficTarget.Sources...)
+		}
+	}
+
+	return getJWTAuthenticationFilters(spec, allowedIdentities, permissiveMode)
+}
+
+func (lb *listenerBuilder) getInboundMeshHTTPFilterChain(proxyService service.MeshService, servicePort uint32, multiPort bool) (*xds_listener.FilterChain, error) {
+	routeConfigName := route.InboundRouteConfigName
+	if multiPort {
+		routeConfigName = route.GetInboundRouteConfigNameForPort(int(servicePort))
+	}
+
 	// Construct HTTP filters
-	filters, err := lb.getInboundHTTPFilters(proxyService)
+	filters, err := lb.getInboundHTTPFilters(proxyService, routeConfigName)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error constructing inbound HTTP filters for proxy service %s", proxyService)
 		return nil, err
 	}
 
 	// Construct downstream TLS context
-	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(lb.serviceIdentity, true /* mTLS */))
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(lb.serviceIdentity, true /* mTLS */, lb.cfg))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext for proxy service %s", proxyService)
 		return nil, err
@@ -149,16 +275,21 @@ func (lb *listenerBuilder) getInboundMeshHTTPFilterChain(proxyService service.Me
 	return filterChain, nil
 }
 
-func (lb *listenerBuilder) getInboundMeshTCPFilterChain(proxyService service.MeshService, servicePort uint32) (*xds_listener.FilterChain, error) {
+func (lb *listenerBuilder) getInboundMeshTCPFilterChain(proxyService service.MeshService, servicePort uint32, multiPort bool) (*xds_listener.FilterChain, error) {
+	var metadataMatch *xds_core.Metadata
+	if multiPort {
+		metadataMatch = envoy.GetPortMetadataMatch(envoy.TargetPortSubsetKey, servicePort)
+	}
+
 	// Construct TCP filters
-	filters, err := lb.getInboundTCPFilters(proxyService)
+	filters, err := lb.getInboundTCPFilters(proxyService, metadataMatch)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error constructing inbound TCP filters for proxy service %s", proxyService)
 		return nil, err
 	}
 
 	// Construct downstream TLS context
-	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(lb.serviceIdentity, true /* mTLS */))
+	marshalledDownstreamTLSContext, err := ptypes.MarshalAny(envoy.GetDownstreamTLSContext(lb.serviceIdentity, true /* mTLS */, lb.cfg))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext for proxy service %s", proxyService)
 		return nil, err
@@ -193,10 +324,15 @@ func (lb *listenerBuilder) getInboundMeshTCPFilterChain(proxyService service.Mes
 	}, nil
 }
 
-func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService) ([]*xds_listener.Filter, error) {
-	var filters []*xds_listener.Filter
+// getInboundTCPFilters returns the network filters proxying the TCP traffic of the given service to its local cluster.
+// A non nil metadata match restricts the TCP proxy to the subset of the local cluster's endpoints it selects.
+func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService, metadataMatch *xds_core.Metadata) ([]*xds_listener.Filter, error) {
+	filters, err := lb.getInboundConnectionLimitFilters(lb.meshCatalog.GetUpstreamTrafficSetting(proxyService), proxyService)
+	if err != nil {
+		return nil, err
+	}
 
-	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must be the first filter in the list of filters.
+	// Apply an RBAC filter when permissive mode is disabled. The RBAC filter must precede the filters handling the traffic.
 	if !lb.cfg.IsPermissiveTrafficPolicyMode() {
 		// Apply RBAC policies on the inbound filters based on configured policies
 		rbacFilter, err := lb.buildRBACFilter()
@@ -204,7 +340,6 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 			log.Error().Err(err).Msgf("Error applying RBAC filter for proxy service %s", proxyService)
 			return nil, err
 		}
-		// RBAC filter should be the very first filter in the filter chain
 		filters = append(filters, rbacFilter)
 	}
 
@@ -213,6 +348,7 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       fmt.Sprintf("%s.%s", inboundMeshTCPProxyStatPrefix, localServiceCluster),
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: localServiceCluster},
+		MetadataMatch:    metadataMatch,
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
@@ -229,12 +365,18 @@ func (lb *listenerBuilder) getInboundTCPFilters(proxyService service.MeshService
 }
 
 // getOutboundHTTPFilter returns an HTTP connection manager network filter used to filter outbound HTTP traffic
-func (lb *listenerBuilder) getOutboundHTTPFilter() (*xds_listener.Filter, error) {
+// to the given upstream service with the given route configuration
+func (lb *listenerBuilder) getOutboundHTTPFilter(upstream service.MeshService, routeConfigName string) (*xds_listener.Filter, error) {
 	var marshalledFilter *any.Any
 	var err error
 
-	marshalledFilter, err = ptypes.MarshalAny(
-		getHTTPConnectionManager(route.OutboundRouteConfigName, lb.cfg, lb.statsHeaders))
+	connManager := getHTTPConnectionManager(routeConfigName, lb.cfg, lb.statsHeaders, lb.workloadMetadata)
+	if err := applyHTTPProtocolOptions(connManager, upstream, lb.cfg.GetHTTPProtocolOptions(upstream)); err != nil {
+		log.Error().Err(err).Msgf("Error applying HTTP protocol options for upstream service %s", upstream)
+		return nil, err
+	}
+
+	marshalledFilter, err = ptypes.MarshalAny(connManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling HTTP connection manager object")
 		return nil, err
@@ -294,9 +436,14 @@ func (lb *listenerBuilder) getOutboundFilterChainMatchForService(dstSvc service.
 	return filterMatch, nil
 }
 
-func (lb *listenerBuilder) getOutboundHTTPFilterChainForService(upstream service.MeshService, port uint32) (*xds_listener.FilterChain, error) {
+func (lb *listenerBuilder) getOutboundHTTPFilterChainForService(upstream service.MeshService, port uint32, multiPort bool) (*xds_listener.FilterChain, error) {
+	routeConfigName := route.OutboundRouteConfigName
+	if multiPort {
+		routeConfigName = route.GetOutboundRouteConfigNameForPort(int(port))
+	}
+
 	// Get HTTP filter for service
-	filter, err := lb.getOutboundHTTPFilter()
+	filter, err := lb.getOutboundHTTPFilter(upstream, routeConfigName)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting HTTP filter for upstream service %s", upstream)
 		return nil, err
@@ -309,7 +456,7 @@ func (lb *listenerBuilder) getOutboundHTTPFilterChainForService(upstream service
 		return nil, err
 	}
 
-	filterChainName := fmt.Sprintf("%s:%s", outboundMeshHTTPFilterChainPrefix, upstream)
+	filterChainName := fmt.Sprintf("%s:%s:%d", outboundMeshHTTPFilterChainPrefix, upstream, port)
 	return &xds_listener.FilterChain{
 		Name:             filterChainName,
 		Filters:          []*xds_listener.Filter{filter},
@@ -317,9 +464,14 @@ func (lb *listenerBuilder) getOutboundHTTPFilterChainForService(upstream service
 	}, nil
 }
 
-func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.MeshService, port uint32) (*xds_listener.FilterChain, error) {
+func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.MeshService, port uint32, multiPort bool) (*xds_listener.FilterChain, error) {
+	var metadataMatch *xds_core.Metadata
+	if multiPort {
+		metadataMatch = envoy.GetPortMetadataMatch(envoy.ServicePortSubsetKey, port)
+	}
+
 	// Get TCP filter for service
-	filter, err := lb.getOutboundTCPFilter(upstream)
+	filter, err := lb.getOutboundTCPFilter(upstream, metadataMatch)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting outbound TCP filter for upstream service %s", upstream)
 		return nil, err
@@ -332,7 +484,7 @@ func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.
 		return nil, err
 	}
 
-	filterChainName := fmt.Sprintf("%s:%s", outboundMeshTCPFilterChainPrefix, upstream)
+	filterChainName := fmt.Sprintf("%s:%s:%d", outboundMeshTCPFilterChainPrefix, upstream, port)
 	return &xds_listener.FilterChain{
 		Name:             filterChainName,
 		Filters:          []*xds_listener.Filter{filter},
@@ -340,10 +492,13 @@ func (lb *listenerBuilder) getOutboundTCPFilterChainForService(upstream service.
 	}, nil
 }
 
-func (lb *listenerBuilder) getOutboundTCPFilter(upstream service.MeshService) (*xds_listener.Filter, error) {
+// getOutboundTCPFilter returns the network filter proxying the TCP traffic directed to the given upstream service.
+// A non nil metadata match restricts the TCP proxy to the subset of the upstream endpoints it selects.
+func (lb *listenerBuilder) getOutboundTCPFilter(upstream service.MeshService, metadataMatch *xds_core.Metadata) (*xds_listener.Filter, error) {
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       fmt.Sprintf("%s.%s", outboundMeshTCPProxyStatPrefix, upstream),
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: upstream.String()},
+		MetadataMatch:    metadataMatch,
 	}
 
 	weightedClusters := lb.meshCatalog.GetWeightedClustersForUpstream(upstream)
@@ -400,12 +555,17 @@ func (lb *listenerBuilder) getOutboundFilterChainPerUpstream() []*xds_listener.F
 			continue
 		}
 
-		// Create protocol specific inbound filter chains per port to handle different ports serving different protocols
-		for port, appProtocol := range protocolToPortMap {
+		// The filter chains of an upstream service exposing multiple ports only forward the traffic to the upstream
+		// endpoints serving their port
+		multiPort := len(protocolToPortMap) > 1
+
+		// Create protocol specific outbound filter chains per port to handle different ports serving different protocols
+		for _, port := range getSortedPorts(protocolToPortMap) {
+			appProtocol := protocolToPortMap[port]
 			switch strings.ToLower(appProtocol) {
 			case constants.ProtocolHTTP, constants.ProtocolGRPC:
 				// Construct HTTP filter chain
-				if httpFilterChain, err := lb.getOutboundHTTPFilterChainForService(upstream, port); err != nil {
+				if httpFilterChain, err := lb.getOutboundHTTPFilterChainForService(upstream, port, multiPort); err != nil {
 					log.Error().Err(err).Msgf("Error constructing outbound HTTP filter chain for upstream service %s on proxy with identity %s", upstream, lb.serviceIdentity)
 				} else {
 					filterChains = append(filterChains, httpFilterChain)
@@ -413,7 +573,7 @@ func (lb *listenerBuilder) getOutboundFilterChainPerUpstream() []*xds_listener.F
 
 			case constants.ProtocolTCP:
 				// Construct TCP filter chain
-				if tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(upstream, port); err != nil {
+				if tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(upstream, port, multiPort); err != nil {
 					log.Error().Err(err).Msgf("Error constructing outbound TCP filter chain for upstream service %s on proxy with identity %s", upstream, lb.serviceIdentity)
 				} else {
 					filterChains = append(filterChains, tcpFilterChain)
@@ -427,3 +587,100 @@ func (lb *listenerBuilder) getOutboundFilterChainPerUpstream() []*xds_listener.F
 
 	return filterChains
 }
+
+// getInboundPlaintextFilterChains returns the filter chains accepting plaintext traffic on the given ports, in addition to
+// the filter chains accepting mTLS traffic from in-mesh clients.
+// Plaintext traffic does not carry a client identity, so it is proxied to the local service cluster at L4 without applying RBAC policies.
+// The given ingress ports are skipped, so that plaintext traffic on them remains authorized by the ingress filter chains.
+func (lb *listenerBuilder) getInboundPlaintextFilterChains(proxyServices []service.MeshService, permissiveTLSPorts mapset.Set, ingressPorts mapset.Set) []*xds_listener.FilterChain {
+	var filterChains []*xds_listener.FilterChain
+	if permissiveTLSPorts == nil || permissiveTLSPorts.Cardinality() == 0 {
+		return filterChains
+	}
+
+	// A plaintext filter chain only matches on the destination port, so only 1 filter chain can be programmed per port
+	// even if multiple services on the proxy share the port.
+	programmedPorts := mapset.NewSet()
+	for _, proxyService := range proxyServices {
+		portToProtocolMap, err := lb.meshCatalog.GetTargetPortToProtocolMappingForService(proxyService)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error retrieving port to protocol mapping for service %s", proxyService)
+			continue
+		}
+
+		var ports []int
+		for port := range portToProtocolMap {
+			if !permissiveTLSPorts.Contains(port) || programmedPorts.Contains(port) {
+				continue
+			}
+			if ingressPorts != nil && ingressPorts.Contains(port) {
+				log.Warn().Msgf("Port %d of service %s is an ingress port, ignoring it as a permissive TLS port", port, proxyService)
+				continue
+			}
+			ports = append(ports, int(port))
+		}
+		sort.Ints(ports)
+		multiPort := len(portToProtocolMap) > 1
+
+		for _, port := range ports {
+			filterChain, err := lb.getInboundPlaintextFilterChain(proxyService, uint32(port), multiPort)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building inbound plaintext filter chain for proxy:port %s:%d", proxyService, port)
+				continue
+			}
+			filterChains = append(filterChains, filterChain)
+			programmedPorts.Add(uint32(port))
+		}
+	}
+
+	return filterChains
+}
+
+func (lb *listenerBuilder) getInboundPlaintextFilterChain(proxyService service.MeshService, servicePort uint32, multiPort bool) (*xds_listener.FilterChain, error) {
+	localServiceCluster := envoy.GetLocalClusterNameForService(proxyService)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       fmt.Sprintf("%s.%s", inboundPlaintextTCPProxyStatPrefix, localServiceCluster),
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: localServiceCluster},
+	}
+	if multiPort {
+		tcpProxy.MetadataMatch = envoy.GetPortMetadataMatch(envoy.TargetPortSubsetKey, servicePort)
+	}
+	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for inbound plaintext filter chain")
+		return nil, err
+	}
+
+	filterchainName := fmt.Sprintf("%s:%s:%d", inboundPlaintextTCPFilterChainPrefix, proxyService, servicePort)
+	return &xds_listener.FilterChain{
+		Name: filterchainName,
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			// The DestinationPort is the service port the downstream directs traffic to
+			DestinationPort: &wrapperspb.UInt32Value{
+				Value: servicePort,
+			},
+
+			// Only match when transport protocol is plaintext, TLS traffic is matched by the in-mesh filter chains
+			TransportProtocol: envoy.TransportProtocolRawBuffer,
+		},
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
+
+// getSortedPorts returns the ports of the given port to protocol mapping in ascending order, so that the filter chains
+// of the ports are built in a deterministic order
+func getSortedPorts(portToProtocolMap map[uint32]string) []uint32 {
+	ports := make([]uint32, 0, len(portToProtocolMap))
+	for port := range portToProtocolMap {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i] < ports[j]
+	})
+	return ports
+}
@@ -5,8 +5,10 @@ import (
 	"net"
 	"testing"
 
+	mapset "github.com/deckarep/golang-set"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/mock/gomock"
@@ -17,6 +19,8 @@ import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -30,6 +34,8 @@ func TestGetOutboundHTTPFilterChainForService(t *testing.T) {
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
 
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
@@ -87,7 +93,7 @@ func TestGetOutboundHTTPFilterChainForService(t *testing.T) {
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			mockCatalog.EXPECT().GetResolvableServiceEndpoints(tests.BookstoreApexService).Return(tc.expectedEndpoints, nil)
-			httpFilterChain, err := lb.getOutboundHTTPFilterChainForService(tests.BookstoreApexService, tc.servicePort)
+			httpFilterChain, err := lb.getOutboundHTTPFilterChainForService(tests.BookstoreApexService, tc.servicePort, false)
 
 			assert.Equal(err != nil, tc.expectError)
 
@@ -112,6 +118,8 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
 
 	lb := &listenerBuilder{
 		meshCatalog:     mockCatalog,
@@ -167,7 +175,7 @@ func TestGetOutboundTCPFilterChainForService(t *testing.T) {
 			mockCatalog.EXPECT().GetResolvableServiceEndpoints(tests.BookstoreApexService).Return(tc.expectedEndpoints, nil)
 			mockCatalog.EXPECT().GetWeightedClustersForUpstream(tests.BookstoreApexService).Times(1)
 
-			tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(tests.BookstoreApexService, tc.servicePort)
+			tcpFilterChain, err := lb.getOutboundTCPFilterChainForService(tests.BookstoreApexService, tc.servicePort, false)
 
 			assert.Equal(err != nil, tc.expectError)
 
@@ -192,6 +200,13 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
 
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
@@ -205,6 +220,9 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 
 	proxyService := tests.BookbuyerService
 
+	// Mock calls used to build the load shedding filters
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(proxyService).Return(nil).AnyTimes()
+
 	testCases := []struct {
 		name           string
 		permissiveMode bool
@@ -263,7 +281,7 @@ func TestGetInboundMeshHTTPFilterChain(t *testing.T) {
 				mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(lb.serviceIdentity).Return(trafficTargets, nil).Times(1)
 			}
 
-			filterChain, err := lb.getInboundMeshHTTPFilterChain(proxyService, tc.port)
+			filterChain, err := lb.getInboundMeshHTTPFilterChain(proxyService, tc.port, false)
 
 			assert.Equal(err != nil, tc.expectError)
 			assert.Equal(filterChain.FilterChainMatch, tc.expectedFilterChainMatch)
@@ -282,6 +300,12 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
 
 	// Mock calls used to build the HTTP connection manager
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
@@ -295,6 +319,9 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 
 	proxyService := tests.BookbuyerService
 
+	// Mock calls used to build the connection limit filters
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(proxyService).Return(nil).AnyTimes()
+
 	testCases := []struct {
 		name           string
 		permissiveMode bool
@@ -353,7 +380,7 @@ func TestGetInboundMeshTCPFilterChain(t *testing.T) {
 				mockCatalog.EXPECT().ListInboundTrafficTargetsWithRoutes(lb.serviceIdentity).Return(trafficTargets, nil).Times(1)
 			}
 
-			filterChain, err := lb.getInboundMeshTCPFilterChain(proxyService, tc.port)
+			filterChain, err := lb.getInboundMeshTCPFilterChain(proxyService, tc.port, false)
 
 			assert.Equal(err != nil, tc.expectError)
 			assert.Equal(filterChain.FilterChainMatch, tc.expectedFilterChainMatch)
@@ -371,9 +398,12 @@ func TestGetOutboundFilterChainMatchForService(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
 	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+
 	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 
-	lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil)
+	lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil, nil)
 
 	testCases := []struct {
 		name        string
@@ -567,11 +597,13 @@ func TestGetOutboundTCPFilter(t *testing.T) {
 		t.Run(fmt.Sprintf("Testing test case %d: %s", i, tc.name), func(t *testing.T) {
 			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+			mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
 
 			mockCatalog.EXPECT().GetWeightedClustersForUpstream(tc.upstream).Return(tc.clusterWeights).Times(1)
 
-			lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil)
-			filter, err := lb.getOutboundTCPFilter(tc.upstream)
+			lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, mockConfigurator, nil, nil)
+			filter, err := lb.getOutboundTCPFilter(tc.upstream, nil)
 
 			assert := tassert.New(t)
 			assert.Equal(tc.expectError, err != nil)
@@ -586,3 +618,110 @@ func TestGetOutboundTCPFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInboundPlaintextFilterChains(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		serviceIdentity: tests.BookstoreServiceIdentity,
+	}
+
+	// Both services expose port 9090, a single plaintext filter chain must be programmed for it
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{
+		80:   "http",
+		9090: "http",
+		9091: "tcp",
+	}, nil).Times(1)
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookstoreApexService).Return(map[uint32]string{
+		9090: "http",
+	}, nil).Times(1)
+
+	filterChains := lb.getInboundPlaintextFilterChains([]service.MeshService{tests.BookstoreV1Service, tests.BookstoreApexService}, mapset.NewSet(uint32(9090), uint32(9091)), mapset.NewSet())
+	assert.Len(filterChains, 2)
+
+	for i, expectedPort := range []uint32{9090, 9091} {
+		filterChain := filterChains[i]
+		assert.Equal(fmt.Sprintf("%s:%s:%d", inboundPlaintextTCPFilterChainPrefix, tests.BookstoreV1Service, expectedPort), filterChain.Name)
+		assert.Equal(expectedPort, filterChain.FilterChainMatch.DestinationPort.GetValue())
+		assert.Equal("raw_buffer", filterChain.FilterChainMatch.TransportProtocol)
+		assert.Nil(filterChain.TransportSocket)
+
+		// Plaintext traffic is proxied to the local cluster without RBAC
+		assert.Len(filterChain.Filters, 1)
+		assert.Equal(wellknown.TCPProxy, filterChain.Filters[0].Name)
+		tcpProxy := &xds_tcp_proxy.TcpProxy{}
+		assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy))
+		assert.Equal("default/bookstore-v1-local", tcpProxy.GetCluster())
+
+		// bookstore-v1 exposes multiple ports, the traffic is proxied to the local endpoint of the port
+		assert.Equal(float64(expectedPort), tcpProxy.GetMetadataMatch().GetFilterMetadata()["envoy.lb"].GetFields()[envoy.TargetPortSubsetKey].GetNumberValue())
+	}
+
+	// No plaintext filter chains are programmed when no ports are permissive
+	assert.Empty(lb.getInboundPlaintextFilterChains([]service.MeshService{tests.BookstoreV1Service}, mapset.NewSet(), mapset.NewSet()))
+
+	// A permissive port that is also an ingress port is skipped, plaintext traffic on it must be authorized by the ingress filter chain
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{
+		80:   "http",
+		9090: "http",
+		9091: "tcp",
+	}, nil).Times(1)
+	filterChains = lb.getInboundPlaintextFilterChains([]service.MeshService{tests.BookstoreV1Service}, mapset.NewSet(uint32(80), uint32(9091)), mapset.NewSet(uint32(80)))
+	assert.Len(filterChains, 1)
+	assert.Equal(uint32(9091), filterChains[0].FilterChainMatch.DestinationPort.GetValue())
+}
+
+func TestGetOutboundFilterChainsForMultiPortService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
+		serviceIdentity: tests.BookbuyerServiceIdentity,
+	}
+
+	mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(tests.BookbuyerServiceIdentity).Return([]service.MeshService{tests.BookstoreApexService}).Times(1)
+	mockCatalog.EXPECT().ListMeshServicesForIdentity(tests.BookbuyerServiceIdentity).Return([]service.MeshService{tests.BookstoreApexService}).Times(1)
+	mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreApexService).Return(map[uint32]string{
+		9090: "grpc",
+		80:   "http",
+		3306: "tcp",
+	}, nil).Times(1)
+	mockCatalog.EXPECT().GetResolvableServiceEndpoints(tests.BookstoreApexService).Return([]endpoint.Endpoint{
+		{IP: net.ParseIP("1.1.1.1"), Port: 8080},
+	}, nil).AnyTimes()
+	mockCatalog.EXPECT().GetWeightedClustersForUpstream(tests.BookstoreApexService).Return(nil).AnyTimes()
+
+	filterChains := lb.getOutboundFilterChainPerUpstream()
+	assert.Len(filterChains, 3)
+
+	// The filter chains are built in the order of their port, and named after it
+	assert.Equal(fmt.Sprintf("%s:%s:80", outboundMeshHTTPFilterChainPrefix, tests.BookstoreApexService), filterChains[0].Name)
+	assert.Equal(fmt.Sprintf("%s:%s:3306", outboundMeshTCPFilterChainPrefix, tests.BookstoreApexService), filterChains[1].Name)
+	assert.Equal(fmt.Sprintf("%s:%s:9090", outboundMeshHTTPFilterChainPrefix, tests.BookstoreApexService), filterChains[2].Name)
+
+	// HTTP filter chains use the route configuration of their port
+	for i, expectedPort := range map[int]int{0: 80, 2: 9090} {
+		connManager := &xds_hcm.HttpConnectionManager{}
+		assert.Nil(ptypes.UnmarshalAny(filterChains[i].Filters[0].GetTypedConfig(), connManager))
+		assert.Equal(route.GetOutboundRouteConfigNameForPort(expectedPort), connManager.GetRds().RouteConfigName)
+	}
+
+	// TCP filter chains only proxy the traffic to the endpoints serving their port
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(ptypes.UnmarshalAny(filterChains[1].Filters[0].GetTypedConfig(), tcpProxy))
+	assert.Equal(float64(3306), tcpProxy.GetMetadataMatch().GetFilterMetadata()["envoy.lb"].GetFields()[envoy.ServicePortSubsetKey].GetNumberValue())
+}
@@ -0,0 +1,207 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_jwt_authn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	// jwtAuthnFilterName is the name of Envoy's JWT authentication HTTP filter
+	jwtAuthnFilterName = "envoy.filters.http.jwt_authn"
+
+	// jwtProviderName is the name of the JWT provider configured in the JWT authentication filter
+	jwtProviderName = "osm-jwt-provider"
+
+	// jwtPayloadMetadataKey is the key of the dynamic metadata of the JWT authentication filter
+	// holding the payload of the verified tokens
+	jwtPayloadMetadataKey = "jwt_payload"
+
+	// jwtIssuerClaim is the claim holding the issuer of a token, always present in verified tokens
+	jwtIssuerClaim = "iss"
+
+	// defaultJWTIdentityClaim is the claim holding the identity of the client when none is configured
+	defaultJWTIdentityClaim = "sub"
+)
+
+// getJWTAuthenticationFilters returns the HTTP filters verifying the JWTs of the requests directed to the upstream host,
+// as configured in the given spec, and authorizing the requests bearing a verified token based on the identity claimed
+// by the token. The claimed identity must be one of the given allowed identities. Requests without a token
+// are not subject to the authorization filter, and remain authorized based on the identity of the client's certificate.
+// The authorization filter is omitted in permissive traffic policy mode.
+func getJWTAuthenticationFilters(spec *policyV1alpha1.JWTAuthenticationSpec, allowedIdentities []identity.ServiceIdentity, permissiveMode bool) ([]*xds_hcm.HttpFilter, error) {
+	jwtAuthnFilter, err := getJWTAuthnFilter(spec)
+	if err != nil {
+		return nil, err
+	}
+	filters := []*xds_hcm.HttpFilter{jwtAuthnFilter}
+
+	if permissiveMode {
+		return filters, nil
+	}
+
+	jwtRBACFilter, err := getJWTRBACFilter(spec, allowedIdentities)
+	if err != nil {
+		return nil, err
+	}
+	return append(filters, jwtRBACFilter), nil
+}
+
+// getJWTAuthnFilter returns the HTTP filter verifying the JWTs of the requests, as configured in the given spec
+func getJWTAuthnFilter(spec *policyV1alpha1.JWTAuthenticationSpec) (*xds_hcm.HttpFilter, error) {
+	requirement := &xds_jwt_authn.JwtRequirement{
+		RequiresType: &xds_jwt_authn.JwtRequirement_ProviderName{
+			ProviderName: jwtProviderName,
+		},
+	}
+	if !spec.Required {
+		requirement = &xds_jwt_authn.JwtRequirement{
+			RequiresType: &xds_jwt_authn.JwtRequirement_RequiresAny{
+				RequiresAny: &xds_jwt_authn.JwtRequirementOrList{
+					Requirements: []*xds_jwt_authn.JwtRequirement{
+						requirement,
+						{RequiresType: &xds_jwt_authn.JwtRequirement_AllowMissing{AllowMissing: &emptypb.Empty{}}},
+					},
+				},
+			},
+		}
+	}
+
+	jwtAuthn := &xds_jwt_authn.JwtAuthentication{
+		Providers: map[string]*xds_jwt_authn.JwtProvider{
+			jwtProviderName: {
+				Issuer:    spec.Issuer,
+				Audiences: spec.Audiences,
+				JwksSourceSpecifier: &xds_jwt_authn.JwtProvider_LocalJwks{
+					LocalJwks: &xds_core.DataSource{
+						Specifier: &xds_core.DataSource_InlineString{
+							InlineString: spec.JWKS,
+						},
+					},
+				},
+				Forward:           true,
+				PayloadInMetadata: jwtPayloadMetadataKey,
+			},
+		},
+		Rules: []*xds_jwt_authn.RequirementRule{
+			{
+				Match: &xds_route.RouteMatch{
+					PathSpecifier: &xds_route.RouteMatch_Prefix{
+						Prefix: "/",
+					},
+				},
+				RequirementType: &xds_jwt_authn.RequirementRule_Requires{
+					Requires: requirement,
+				},
+			},
+		},
+	}
+
+	marshalled, err := ptypes.MarshalAny(jwtAuthn)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling JWT authentication filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: jwtAuthnFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
+
+// getJWTRBACFilter returns the HTTP filter authorizing the requests bearing a verified JWT whose identity claim
+// is one of the given allowed identities
+func getJWTRBACFilter(spec *policyV1alpha1.JWTAuthenticationSpec, allowedIdentities []identity.ServiceIdentity) (*xds_hcm.HttpFilter, error) {
+	identityClaim := spec.IdentityClaim
+	if identityClaim == "" {
+		identityClaim = defaultJWTIdentityClaim
+	}
+
+	// Requests without a verified token have no issuer in their JWT payload metadata
+	principals := []*xds_rbac.Principal{
+		{
+			Identifier: &xds_rbac.Principal_NotId{
+				NotId: &xds_rbac.Principal{
+					Identifier: &xds_rbac.Principal_Metadata{
+						Metadata: &xds_matcher.MetadataMatcher{
+							Filter: jwtAuthnFilterName,
+							Path:   jwtPayloadPath(jwtIssuerClaim),
+							Value: &xds_matcher.ValueMatcher{
+								MatchPattern: &xds_matcher.ValueMatcher_PresentMatch{PresentMatch: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, allowedIdentity := range allowedIdentities {
+		if !allowedIdentity.IsK8sServiceAccount() {
+			// The identity claimed by a token is a Kubernetes service account
+			continue
+		}
+		principals = append(principals, &xds_rbac.Principal{
+			Identifier: &xds_rbac.Principal_Metadata{
+				Metadata: &xds_matcher.MetadataMatcher{
+					Filter: jwtAuthnFilterName,
+					Path:   jwtPayloadPath(identityClaim),
+					Value: &xds_matcher.ValueMatcher{
+						MatchPattern: &xds_matcher.ValueMatcher_StringMatch{
+							StringMatch: &xds_matcher.StringMatcher{
+								MatchPattern: &xds_matcher.StringMatcher_Exact{
+									Exact: allowedIdentity.ToK8sServiceAccount().String(),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	httpRBAC := &xds_http_rbac.RBAC{
+		Rules: &xds_rbac.RBAC{
+			Action: xds_rbac.RBAC_ALLOW,
+			Policies: map[string]*xds_rbac.Policy{
+				"jwt-identity": {
+					Permissions: []*xds_rbac.Permission{
+						{Rule: &xds_rbac.Permission_Any{Any: true}},
+					},
+					Principals: principals,
+				},
+			},
+		},
+	}
+
+	marshalled, err := ptypes.MarshalAny(httpRBAC)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling JWT RBAC filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wellknown.HTTPRoleBasedAccessControl,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
+
+// jwtPayloadPath returns the path to the given claim in the JWT payload metadata
+func jwtPayloadPath(claim string) []*xds_matcher.MetadataMatcher_PathSegment {
+	return []*xds_matcher.MetadataMatcher_PathSegment{
+		{Segment: &xds_matcher.MetadataMatcher_PathSegment_Key{Key: jwtPayloadMetadataKey}},
+		{Segment: &xds_matcher.MetadataMatcher_PathSegment_Key{Key: claim}},
+	}
+}
@@ -0,0 +1,169 @@
+package lds
+
+import (
+	"testing"
+
+	xds_jwt_authn "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
+	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+const testJWKS = `{"keys":[{"kty":"RSA","e":"AQAB","n":"xAE7eB6qugXyCAG3yhh7pkDkT65pHymX-P7KfIupjf59vsdo91bSP9C8H07pSAGQO1MV_xFj9VswgsCg4R6otmg5PV2He95lZdHtOcU5DXIg_pbhLdKXbi66GlVeK6ABZOUW3WYtnNHD-91gVuoeJT_DwtGGcp4ignkgXfkiEm4sw-4sfb4qdt5oLbyVpmW6x9cfa7vs2WTfURiCrBoUqgBo_-4WTiULmmHSGZHOjzwa8WtrtOQGsAFjIbno85jp6MnGGGZPYZbDAa_b3y5u-YpW7ypZrvD8BgtKVjgtQgZhLAGezMt0ua3DRrWnKqTZ0BJ_EyxOGuHJrLsn00fnMQ"}]}`
+
+func TestGetJWTAuthenticationFilters(t *testing.T) {
+	testCases := []struct {
+		name                string
+		permissiveMode      bool
+		expectedFilterNames []string
+	}{
+		{
+			name:                "permissive mode",
+			permissiveMode:      true,
+			expectedFilterNames: []string{jwtAuthnFilterName},
+		},
+		{
+			name:                "SMI mode",
+			permissiveMode:      false,
+			expectedFilterNames: []string{jwtAuthnFilterName, wellknown.HTTPRoleBasedAccessControl},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			spec := &policyV1alpha1.JWTAuthenticationSpec{
+				Issuer: "https://issuer.example.com",
+				JWKS:   testJWKS,
+			}
+			filters, err := getJWTAuthenticationFilters(spec, []identity.ServiceIdentity{tests.BookbuyerServiceIdentity}, tc.permissiveMode)
+			assert.Nil(err)
+
+			var filterNames []string
+			for _, filter := range filters {
+				filterNames = append(filterNames, filter.Name)
+			}
+			assert.Equal(tc.expectedFilterNames, filterNames)
+		})
+	}
+}
+
+func TestGetJWTAuthnFilter(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		required             bool
+		expectedAllowMissing bool
+	}{
+		{
+			name:                 "token required",
+			required:             true,
+			expectedAllowMissing: false,
+		},
+		{
+			name:                 "token optional",
+			required:             false,
+			expectedAllowMissing: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			filter, err := getJWTAuthnFilter(&policyV1alpha1.JWTAuthenticationSpec{
+				Issuer:    "https://issuer.example.com",
+				Audiences: []string{"bookstore"},
+				JWKS:      testJWKS,
+				Required:  tc.required,
+			})
+			assert.Nil(err)
+			assert.Equal(jwtAuthnFilterName, filter.Name)
+
+			jwtAuthn := &xds_jwt_authn.JwtAuthentication{}
+			err = ptypes.UnmarshalAny(filter.GetTypedConfig(), jwtAuthn)
+			assert.Nil(err)
+			assert.Nil(jwtAuthn.Validate())
+
+			provider := jwtAuthn.Providers[jwtProviderName]
+			assert.NotNil(provider)
+			assert.Equal("https://issuer.example.com", provider.Issuer)
+			assert.Equal([]string{"bookstore"}, provider.Audiences)
+			assert.Equal(testJWKS, provider.GetLocalJwks().GetInlineString())
+			assert.Equal(jwtPayloadMetadataKey, provider.PayloadInMetadata)
+
+			assert.Len(jwtAuthn.Rules, 1)
+			requires := jwtAuthn.Rules[0].GetRequires()
+			if tc.expectedAllowMissing {
+				requirements := requires.GetRequiresAny().GetRequirements()
+				assert.Len(requirements, 2)
+				assert.Equal(jwtProviderName, requirements[0].GetProviderName())
+				assert.NotNil(requirements[1].GetAllowMissing())
+			} else {
+				assert.Equal(jwtProviderName, requires.GetProviderName())
+			}
+		})
+	}
+}
+
+func TestGetJWTRBACFilter(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		identityClaim         string
+		expectedIdentityClaim string
+	}{
+		{
+			name:                  "default identity claim",
+			expectedIdentityClaim: defaultJWTIdentityClaim,
+		},
+		{
+			name:                  "custom identity claim",
+			identityClaim:         "client_id",
+			expectedIdentityClaim: "client_id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			spec := &policyV1alpha1.JWTAuthenticationSpec{
+				Issuer:        "https://issuer.example.com",
+				JWKS:          testJWKS,
+				IdentityClaim: tc.identityClaim,
+			}
+			// Identities that are not Kubernetes service accounts cannot be claimed by a token
+			filter, err := getJWTRBACFilter(spec, []identity.ServiceIdentity{tests.BookbuyerServiceIdentity, "spiffe://example.org/vm/billing"})
+			assert.Nil(err)
+			assert.Equal(wellknown.HTTPRoleBasedAccessControl, filter.Name)
+
+			httpRBAC := &xds_http_rbac.RBAC{}
+			err = ptypes.UnmarshalAny(filter.GetTypedConfig(), httpRBAC)
+			assert.Nil(err)
+			assert.Nil(httpRBAC.Validate())
+
+			assert.Len(httpRBAC.Rules.Policies, 1)
+			for _, policy := range httpRBAC.Rules.Policies {
+				assert.Len(policy.Principals, 2)
+
+				// Requests without a token are allowed
+				noToken := policy.Principals[0].GetNotId().GetMetadata()
+				assert.Equal(jwtAuthnFilterName, noToken.Filter)
+				assert.Equal(jwtIssuerClaim, noToken.Path[1].GetKey())
+				assert.True(noToken.Value.GetPresentMatch())
+
+				// Requests with a token claiming an allowed identity are allowed
+				allowedIdentity := policy.Principals[1].GetMetadata()
+				assert.Equal(jwtAuthnFilterName, allowedIdentity.Filter)
+				assert.Equal(jwtPayloadMetadataKey, allowedIdentity.Path[0].GetKey())
+				assert.Equal(tc.expectedIdentityClaim, allowedIdentity.Path[1].GetKey())
+				assert.Equal(tests.BookbuyerServiceAccount.String(), allowedIdentity.Value.GetStringMatch().GetExact())
+			}
+		})
+	}
+}
@@ -2,16 +2,28 @@ package lds
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/local_ratelimit/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 const (
@@ -19,7 +31,12 @@ const (
 	outboundListenerName          = "outbound-listener"
 	prometheusListenerName        = "inbound-prometheus-listener"
 	outboundEgressFilterChainName = "outbound-egress-filter-chain"
+	outboundEgressTLSFilterChain  = "outbound-egress-tls-filter-chain"
 	egressTCPProxyStatPrefix      = "egress-tcp-proxy"
+	egressLocalRateLimitPrefix    = "egress-local-rate-limit"
+	outboundEgressDenyFilterChain = "outbound-egress-deny-filter-chain"
+	egressDenyPolicyName          = "deny-all"
+	localRateLimitFilterName      = "envoy.filters.network.local_ratelimit"
 	singleIpv4Mask                = 32
 )
 
@@ -50,6 +67,30 @@ func (lb *listenerBuilder) newOutboundListener() (*xds_listener.Listener, error)
 			return nil, err
 		}
 		listener.DefaultFilterChain = egressFilterChain
+	} else if featureflags.IsEgressPolicyEnabled() {
+		// Traffic not matching any filter chain is denied. Denying it with a filter, as opposed to leaving it
+		// unmatched, counts the denied connections in the statistics of the proxy.
+		denyFilterChain, err := buildEgressDefaultDenyFilterChain(lb.serviceIdentity)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting default deny filter chain for Egress")
+			return nil, err
+		}
+		listener.DefaultFilterChain = denyFilterChain
+	}
+
+	// Create filter chains matching the SNI of TLS traffic to the hosts allowed by Egress policies, so that
+	// TLS egress traffic is restricted by hostname without being terminated
+	egressTLSFilterChains, err := lb.getEgressTLSFilterChains()
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting TLS filter chains for Egress")
+		return nil, err
+	}
+	if len(egressTLSFilterChains) > 0 {
+		listener.FilterChains = append(listener.FilterChains, egressTLSFilterChains...)
+		// The TlsInspector ListenerFilter is used to detect the SNI of TLS traffic
+		listener.ListenerFilters = append(listener.ListenerFilters, &xds_listener.ListenerFilter{
+			Name: wellknown.TlsInspector,
+		})
 	}
 
 	if len(listener.FilterChains) == 0 && listener.DefaultFilterChain == nil {
@@ -113,6 +154,8 @@ func buildEgressFilterChain() (*xds_listener.FilterChain, error) {
 	tcpProxy := &xds_tcp_proxy.TcpProxy{
 		StatPrefix:       fmt.Sprintf("%s.%s", egressTCPProxyStatPrefix, envoy.OutboundPassthroughCluster),
 		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.OutboundPassthroughCluster},
+		// Log traffic passed through to its original destination so that traffic bypassing policy is observable
+		AccessLog: envoy.GetPassthroughAccessLog(),
 	}
 	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
 	if err != nil {
@@ -130,3 +173,194 @@ func buildEgressFilterChain() (*xds_listener.FilterChain, error) {
 		},
 	}, nil
 }
+
+// getEgressTLSFilterChains returns the filter chains forwarding TLS traffic to the hosts allowed by the Egress policies
+// applicable to the proxy's service identity. TLS traffic is matched using its SNI and is not terminated.
+func (lb *listenerBuilder) getEgressTLSFilterChains() ([]*xds_listener.FilterChain, error) {
+	egressPolicy, err := lb.meshCatalog.GetEgressTrafficPolicy(lb.serviceIdentity)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting egress traffic policy for identity %s", lb.serviceIdentity)
+		return nil, err
+	}
+	if egressPolicy == nil {
+		return nil, nil
+	}
+
+	var filterChains []*xds_listener.FilterChain
+	for _, trafficMatch := range egressPolicy.TrafficMatches {
+		if len(trafficMatch.ServerNames) == 0 || trafficMatch.Cluster == "" {
+			continue
+		}
+
+		for _, hostMatch := range splitEgressDenyTrafficMatch(trafficMatch) {
+			filterChain, err := buildEgressTLSFilterChain(hostMatch, lb.serviceIdentity)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building egress TLS filter chain for cluster %s", hostMatch.Cluster)
+				return nil, err
+			}
+			filterChains = append(filterChains, filterChain)
+		}
+	}
+
+	return filterChains, nil
+}
+
+// splitEgressDenyTrafficMatch returns a traffic match per server name of the given traffic match if it denies the
+// traffic, so that the connections denied are counted per host, and the given traffic match otherwise
+func splitEgressDenyTrafficMatch(trafficMatch *trafficpolicy.TrafficMatch) []*trafficpolicy.TrafficMatch {
+	if !trafficMatch.Deny || len(trafficMatch.ServerNames) <= 1 {
+		return []*trafficpolicy.TrafficMatch{trafficMatch}
+	}
+
+	var hostMatches []*trafficpolicy.TrafficMatch
+	for _, serverName := range trafficMatch.ServerNames {
+		hostMatch := *trafficMatch
+		hostMatch.ServerNames = []string{serverName}
+		hostMatch.Cluster = fmt.Sprintf("%s:%d", serverName, trafficMatch.DestinationPort.Number)
+		hostMatches = append(hostMatches, &hostMatch)
+	}
+	return hostMatches
+}
+
+// buildEgressTLSFilterChain returns the filter chain for the given TLS traffic match of the given source identity.
+// A traffic match denying the traffic must have a single server name, whose denied connections are counted.
+func buildEgressTLSFilterChain(trafficMatch *trafficpolicy.TrafficMatch, source identity.ServiceIdentity) (*xds_listener.FilterChain, error) {
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       fmt.Sprintf("%s.%s", egressTCPProxyStatPrefix, trafficMatch.Cluster),
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: trafficMatch.Cluster},
+	}
+	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for egress TLS filter chain")
+		return nil, err
+	}
+
+	// The connections denied are closed before reaching the TCP proxy, which requires a cluster that is not programmed
+	var filters []*xds_listener.Filter
+	if trafficMatch.Deny {
+		statPrefix := envoy.GetEgressDenyStatPrefix(source, trafficMatch.ServerNames[0], strconv.Itoa(trafficMatch.DestinationPort.Number))
+		denyFilter, err := buildEgressDenyFilter(statPrefix)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building deny filter for egress TLS filter chain")
+			return nil, err
+		}
+		filters = append(filters, denyFilter)
+	} else if trafficMatch.RateLimit != nil && trafficMatch.RateLimit.Local != nil && trafficMatch.RateLimit.Local.TCP != nil {
+		rateLimitFilter, err := buildTCPLocalRateLimitFilter(trafficMatch.RateLimit.Local.TCP, trafficMatch.Cluster)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building local rate limit filter for egress TLS filter chain")
+			return nil, err
+		}
+		filters = append(filters, rateLimitFilter)
+	}
+	filters = append(filters, &xds_listener.Filter{
+		Name:       wellknown.TCPProxy,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+	})
+
+	return &xds_listener.FilterChain{
+		Name: fmt.Sprintf("%s:%s", outboundEgressTLSFilterChain, trafficMatch.Cluster),
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			DestinationPort: &wrapperspb.UInt32Value{
+				Value: uint32(trafficMatch.DestinationPort.Number),
+			},
+			ServerNames:       trafficMatch.ServerNames,
+			TransportProtocol: envoy.TransportProtocolTLS,
+		},
+		Filters: filters,
+	}, nil
+}
+
+// buildEgressDefaultDenyFilterChain returns the filter chain denying the egress connections of the given source identity
+// that do not match any other filter chain
+func buildEgressDefaultDenyFilterChain(source identity.ServiceIdentity) (*xds_listener.FilterChain, error) {
+	denyFilter, err := buildEgressDenyFilter(envoy.GetEgressDenyStatPrefix(source, envoy.EgressDenyAnyDestination, envoy.EgressDenyAnyDestination))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building deny filter for egress default filter chain")
+		return nil, err
+	}
+
+	// A network filter chain must end with a terminal filter. The TCP proxy is never reached as all the connections
+	// are denied, and the passthrough cluster is not programmed when egress is disabled.
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       fmt.Sprintf("%s.%s", egressTCPProxyStatPrefix, envoy.OutboundPassthroughCluster),
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: envoy.OutboundPassthroughCluster},
+	}
+	marshalledTCPProxy, err := ptypes.MarshalAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for egress default deny filter chain")
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: outboundEgressDenyFilterChain,
+		Filters: []*xds_listener.Filter{
+			denyFilter,
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
+
+// buildEgressDenyFilter returns a network filter denying all the connections, counted in the statistics with the
+// given stat prefix
+func buildEgressDenyFilter(statPrefix string) (*xds_listener.Filter, error) {
+	denyRBAC := &xds_network_rbac.RBAC{
+		StatPrefix: statPrefix,
+		Rules: &xds_rbac.RBAC{
+			Action: xds_rbac.RBAC_DENY,
+			Policies: map[string]*xds_rbac.Policy{
+				egressDenyPolicyName: {
+					Permissions: []*xds_rbac.Permission{{Rule: &xds_rbac.Permission_Any{Any: true}}},
+					Principals:  []*xds_rbac.Principal{{Identifier: &xds_rbac.Principal_Any{Any: true}}},
+				},
+			},
+		},
+	}
+	marshalledDenyRBAC, err := ptypes.MarshalAny(denyRBAC)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_listener.Filter{
+		Name:       wellknown.RoleBasedAccessControl,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledDenyRBAC},
+	}, nil
+}
+
+// buildTCPLocalRateLimitFilter returns a network filter limiting the rate of the connections forwarded to the given
+// cluster. Connections are limited using a token bucket that is refilled with the number of connections allowed per
+// unit of time, and that can hold up to the burst of connections allowed on top of that.
+func buildTCPLocalRateLimitFilter(rateLimit *policyV1alpha1.TCPLocalRateLimitSpec, cluster string) (*xds_listener.Filter, error) {
+	var fillInterval time.Duration
+	switch rateLimit.Unit {
+	case "second":
+		fillInterval = time.Second
+	case "minute":
+		fillInterval = time.Minute
+	case "hour":
+		fillInterval = time.Hour
+	default:
+		return nil, errors.Errorf("Invalid unit %q for TCP local rate limit, must be one of: second, minute, hour", rateLimit.Unit)
+	}
+
+	localRateLimit := &xds_local_ratelimit.LocalRateLimit{
+		StatPrefix: fmt.Sprintf("%s.%s", egressLocalRateLimitPrefix, cluster),
+		TokenBucket: &xds_type.TokenBucket{
+			MaxTokens:     rateLimit.Connections + rateLimit.Burst,
+			TokensPerFill: wrapperspb.UInt32(rateLimit.Connections),
+			FillInterval:  ptypes.DurationProto(fillInterval),
+		},
+	}
+	marshalledLocalRateLimit, err := ptypes.MarshalAny(localRateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_listener.Filter{
+		Name:       localRateLimitFilterName,
+		ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledLocalRateLimit},
+	}, nil
+}
@@ -2,20 +2,33 @@ package lds
 
 import (
 	"testing"
+	"time"
 
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/local_ratelimit/v3"
+	xds_network_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/rbac/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
 
 var testWASM = "some bytes"
@@ -34,9 +47,11 @@ func TestGetFilterForService(t *testing.T) {
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false)
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true)
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-endpoint")
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(tests.BookstoreV1Service).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
 
 	// Check we get HTTP connection manager filter without Permissive mode
-	filter, err := lb.getOutboundHTTPFilter()
+	filter, err := lb.getOutboundHTTPFilter(tests.BookstoreV1Service, route.OutboundRouteConfigName)
 
 	assert.NoError(err)
 	assert.Equal(filter.Name, wellknown.HTTPConnectionManager)
@@ -46,7 +61,7 @@ func TestGetFilterForService(t *testing.T) {
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(true)
 	mockConfigurator.EXPECT().GetTracingEndpoint().Return("test-endpoint")
 
-	filter, err = lb.getOutboundHTTPFilter()
+	filter, err = lb.getOutboundHTTPFilter(tests.BookstoreV1Service, route.OutboundRouteConfigName)
 	assert.NoError(err)
 	assert.Equal(filter.Name, wellknown.HTTPConnectionManager)
 }
@@ -76,7 +91,7 @@ var _ = Describe("Construct inbound listeners", func() {
 
 	Context("Test creation of Prometheus listener", func() {
 		It("Tests the Prometheus listener config", func() {
-			connManager := getPrometheusConnectionManager()
+			connManager := getPrometheusConnectionManager(nil)
 			listener, _ := buildPrometheusListener(connManager)
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyPrometheusInboundListenerPort)))
 			Expect(len(listener.ListenerFilters)).To(Equal(0)) //  no listener filters
@@ -97,11 +112,11 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 	Context("Test creation of HTTP connection manager", func() {
 		It("Should have the correct StatPrefix", func() {
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
-			connManager := getHTTPConnectionManager("foo", mockConfigurator, nil)
+			connManager := getHTTPConnectionManager("foo", mockConfigurator, nil, nil)
 			Expect(connManager.StatPrefix).To(Equal("mesh-http-conn-manager.foo"))
 
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
-			connManager = getHTTPConnectionManager("bar", mockConfigurator, nil)
+			connManager = getHTTPConnectionManager("bar", mockConfigurator, nil, nil)
 			Expect(connManager.StatPrefix).To(Equal("mesh-http-conn-manager.bar"))
 		})
 
@@ -111,7 +126,7 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			mockConfigurator.EXPECT().GetTracingEndpoint().Return(constants.DefaultTracingEndpoint).Times(1)
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).Times(1)
 
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, nil)
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, nil, nil)
 
 			Expect(connManager.Tracing.Verbose).To(Equal(true))
 			Expect(connManager.Tracing.Provider.Name).To(Equal("envoy.tracers.zipkin"))
@@ -120,7 +135,7 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 		It("Returns proper Zipkin config given when tracing is disabled", func() {
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
 
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, nil)
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, nil, nil)
 			var nilHcmTrace *xds_hcm.HttpConnectionManager_Tracing = nil
 
 			Expect(connManager.Tracing).To(Equal(nilHcmTrace))
@@ -134,7 +149,7 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			oldStatsWASMBytes := statsWASMBytes
 			statsWASMBytes = testWASM
 
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, map[string]string{"k1": "v1"})
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, map[string]string{"k1": "v1"}, nil)
 
 			Expect(connManager.HttpFilters).To(HaveLen(2))
 			Expect(connManager.HttpFilters[0].GetName()).To(Equal(wellknown.HTTPRoleBasedAccessControl))
@@ -154,7 +169,7 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			oldStatsWASMBytes := statsWASMBytes
 			statsWASMBytes = ""
 
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, map[string]string{"k1": "v1"})
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, map[string]string{"k1": "v1"}, nil)
 
 			Expect(connManager.HttpFilters).To(HaveLen(2))
 			Expect(connManager.HttpFilters[0].GetName()).To(Equal(wellknown.HTTPRoleBasedAccessControl))
@@ -174,7 +189,7 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			oldStatsWASMBytes := statsWASMBytes
 			statsWASMBytes = testWASM
 
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, nil)
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, nil, nil)
 
 			Expect(connManager.HttpFilters).To(HaveLen(3))
 			Expect(connManager.HttpFilters[0].GetName()).To(Equal("envoy.filters.http.wasm"))
@@ -195,7 +210,7 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			oldStatsWASMBytes := statsWASMBytes
 			statsWASMBytes = testWASM
 
-			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, map[string]string{"k1": "v1"})
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator, map[string]string{"k1": "v1"}, nil)
 
 			Expect(connManager.GetHttpFilters()).To(HaveLen(4))
 			Expect(connManager.GetHttpFilters()[0].GetName()).To(Equal(wellknown.Lua))
@@ -211,3 +226,244 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 		})
 	})
 })
+
+func TestBuildEgressFilterChain(t *testing.T) {
+	assert := tassert.New(t)
+
+	filterChain, err := buildEgressFilterChain()
+	assert.Nil(err)
+	assert.Len(filterChain.Filters, 1)
+	assert.Equal(wellknown.TCPProxy, filterChain.Filters[0].Name)
+
+	tcpProxy := &xds_tcp_proxy.TcpProxy{}
+	assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy))
+	assert.Equal(envoy.OutboundPassthroughCluster, tcpProxy.GetCluster())
+	assert.Equal("egress-tcp-proxy.passthrough-outbound", tcpProxy.StatPrefix)
+	assert.Len(tcpProxy.AccessLog, 1)
+}
+
+func TestGetEgressTLSFilterChains(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		egressPolicy         *trafficpolicy.EgressTrafficPolicy
+		expectedFilterChains []string
+		expectedServerNames  [][]string
+	}{
+		{
+			name:                 "no egress policy",
+			egressPolicy:         nil,
+			expectedFilterChains: nil,
+		},
+		{
+			name: "egress policy for HTTP and HTTPS traffic",
+			egressPolicy: &trafficpolicy.EgressTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						DestinationPort: policyV1alpha1.PortSpec{Number: 80, Protocol: "http"},
+					},
+					{
+						DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"},
+						ServerNames:     []string{"foo.com"},
+						Cluster:         "foo.com:443",
+					},
+					{
+						DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"},
+						ServerNames:     []string{"bar.com"},
+						Cluster:         "bar.com:443",
+					},
+				},
+			},
+			expectedFilterChains: []string{"outbound-egress-tls-filter-chain:foo.com:443", "outbound-egress-tls-filter-chain:bar.com:443"},
+			expectedServerNames:  [][]string{{"foo.com"}, {"bar.com"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(tc.egressPolicy, nil).Times(1)
+
+			lb := &listenerBuilder{
+				meshCatalog:     mockCatalog,
+				serviceIdentity: identity.ServiceIdentity("foo.bar.cluster.local"),
+			}
+
+			filterChains, err := lb.getEgressTLSFilterChains()
+			assert.Nil(err)
+			assert.Len(filterChains, len(tc.expectedFilterChains))
+
+			for i, filterChain := range filterChains {
+				assert.Equal(tc.expectedFilterChains[i], filterChain.Name)
+				assert.Equal(tc.expectedServerNames[i], filterChain.FilterChainMatch.ServerNames)
+				assert.Equal(uint32(443), filterChain.FilterChainMatch.DestinationPort.GetValue())
+				assert.Equal(envoy.TransportProtocolTLS, filterChain.FilterChainMatch.TransportProtocol)
+				assert.Len(filterChain.Filters, 1)
+				assert.Equal(wellknown.TCPProxy, filterChain.Filters[0].Name)
+
+				tcpProxy := &xds_tcp_proxy.TcpProxy{}
+				assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), tcpProxy))
+				assert.Equal(tc.egressPolicy.TrafficMatches[i+1].Cluster, tcpProxy.GetCluster())
+			}
+		})
+	}
+}
+
+func TestBuildEgressTLSFilterChainWithRateLimit(t *testing.T) {
+	testCases := []struct {
+		name                string
+		rateLimit           *policyV1alpha1.TCPLocalRateLimitSpec
+		expectedTokenBucket *xds_type.TokenBucket
+		expectError         bool
+	}{
+		{
+			name: "connections per minute with burst",
+			rateLimit: &policyV1alpha1.TCPLocalRateLimitSpec{
+				Connections: 100,
+				Unit:        "minute",
+				Burst:       10,
+			},
+			expectedTokenBucket: &xds_type.TokenBucket{
+				MaxTokens:     110,
+				TokensPerFill: wrapperspb.UInt32(100),
+				FillInterval:  ptypes.DurationProto(time.Minute),
+			},
+		},
+		{
+			name: "invalid unit",
+			rateLimit: &policyV1alpha1.TCPLocalRateLimitSpec{
+				Connections: 100,
+				Unit:        "day",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			trafficMatch := &trafficpolicy.TrafficMatch{
+				DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"},
+				ServerNames:     []string{"foo.com"},
+				Cluster:         "foo.com:443",
+				RateLimit: &policyV1alpha1.RateLimitSpec{
+					Local: &policyV1alpha1.LocalRateLimitSpec{
+						TCP: tc.rateLimit,
+					},
+				},
+			}
+
+			filterChain, err := buildEgressTLSFilterChain(trafficMatch, tests.BookbuyerServiceIdentity)
+			assert.Equal(tc.expectError, err != nil)
+			if tc.expectError {
+				return
+			}
+
+			// The rate limit filter must precede the TCP proxy filter
+			assert.Len(filterChain.Filters, 2)
+			assert.Equal(localRateLimitFilterName, filterChain.Filters[0].Name)
+			assert.Equal(wellknown.TCPProxy, filterChain.Filters[1].Name)
+
+			localRateLimit := &xds_local_ratelimit.LocalRateLimit{}
+			assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), localRateLimit))
+			assert.Equal("egress-local-rate-limit.foo.com:443", localRateLimit.StatPrefix)
+			assert.Equal(tc.expectedTokenBucket.MaxTokens, localRateLimit.TokenBucket.MaxTokens)
+			assert.Equal(tc.expectedTokenBucket.TokensPerFill.GetValue(), localRateLimit.TokenBucket.TokensPerFill.GetValue())
+			assert.Equal(tc.expectedTokenBucket.FillInterval.AsDuration(), localRateLimit.TokenBucket.FillInterval.AsDuration())
+		})
+	}
+}
+
+func TestBuildEgressTLSFilterChainDeny(t *testing.T) {
+	assert := tassert.New(t)
+
+	trafficMatch := &trafficpolicy.TrafficMatch{
+		DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"},
+		ServerNames:     []string{"foo.com"},
+		Cluster:         "foo.com:443",
+		Deny:            true,
+	}
+
+	filterChain, err := buildEgressTLSFilterChain(trafficMatch, tests.BookbuyerServiceIdentity)
+	assert.Nil(err)
+	assert.Equal([]string{"foo.com"}, filterChain.FilterChainMatch.ServerNames)
+
+	// The deny filter must precede the TCP proxy filter
+	assert.Len(filterChain.Filters, 2)
+	assert.Equal(wellknown.RoleBasedAccessControl, filterChain.Filters[0].Name)
+	assert.Equal(wellknown.TCPProxy, filterChain.Filters[1].Name)
+
+	denyRBAC := &xds_network_rbac.RBAC{}
+	assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), denyRBAC))
+	assert.Equal("egress-deny|default/bookbuyer|foo.com|443.", denyRBAC.StatPrefix)
+	assert.Equal(xds_rbac.RBAC_DENY, denyRBAC.Rules.Action)
+	assert.True(denyRBAC.Rules.Policies[egressDenyPolicyName].Permissions[0].GetAny())
+	assert.True(denyRBAC.Rules.Policies[egressDenyPolicyName].Principals[0].GetAny())
+}
+
+func TestGetEgressTLSFilterChainsDenyPerHost(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(&trafficpolicy.EgressTrafficPolicy{
+		TrafficMatches: []*trafficpolicy.TrafficMatch{
+			{
+				DestinationPort: policyV1alpha1.PortSpec{Number: 443, Protocol: "https"},
+				ServerNames:     []string{"foo.com", "bar.com"},
+				Cluster:         "foo.com:443",
+				Deny:            true,
+			},
+		},
+	}, nil).Times(1)
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		serviceIdentity: tests.BookbuyerServiceIdentity,
+	}
+
+	filterChains, err := lb.getEgressTLSFilterChains()
+	assert.Nil(err)
+	assert.Len(filterChains, 2)
+
+	expected := []struct {
+		name       string
+		serverName string
+		statPrefix string
+	}{
+		{"outbound-egress-tls-filter-chain:foo.com:443", "foo.com", "egress-deny|default/bookbuyer|foo.com|443."},
+		{"outbound-egress-tls-filter-chain:bar.com:443", "bar.com", "egress-deny|default/bookbuyer|bar.com|443."},
+	}
+	for i, filterChain := range filterChains {
+		assert.Equal(expected[i].name, filterChain.Name)
+		assert.Equal([]string{expected[i].serverName}, filterChain.FilterChainMatch.ServerNames)
+
+		denyRBAC := &xds_network_rbac.RBAC{}
+		assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), denyRBAC))
+		assert.Equal(expected[i].statPrefix, denyRBAC.StatPrefix)
+	}
+}
+
+func TestBuildEgressDefaultDenyFilterChain(t *testing.T) {
+	assert := tassert.New(t)
+
+	filterChain, err := buildEgressDefaultDenyFilterChain(tests.BookbuyerServiceIdentity)
+	assert.Nil(err)
+	assert.Equal(outboundEgressDenyFilterChain, filterChain.Name)
+	assert.Nil(filterChain.FilterChainMatch)
+
+	// The deny filter must precede the terminal TCP proxy filter
+	assert.Len(filterChain.Filters, 2)
+	assert.Equal(wellknown.RoleBasedAccessControl, filterChain.Filters[0].Name)
+	assert.Equal(wellknown.TCPProxy, filterChain.Filters[1].Name)
+
+	denyRBAC := &xds_network_rbac.RBAC{}
+	assert.Nil(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), denyRBAC))
+	assert.Equal("egress-deny|default/bookbuyer|*|*.", denyRBAC.StatPrefix)
+	assert.Equal(xds_rbac.RBAC_DENY, denyRBAC.Rules.Action)
+}
@@ -0,0 +1,201 @@
+package lds
+
+import (
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_adaptive_concurrency "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/adaptive_concurrency/v3"
+	xds_admission_control "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/admission_control/v3alpha"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	// admissionControlFilterName is the name of Envoy's admission control HTTP filter
+	admissionControlFilterName = "envoy.filters.http.admission_control"
+
+	// adaptiveConcurrencyFilterName is the name of Envoy's adaptive concurrency HTTP filter
+	adaptiveConcurrencyFilterName = "envoy.filters.http.adaptive_concurrency"
+
+	// Runtime keys used to override the admission control and adaptive concurrency settings at runtime
+	admissionControlEnabledRuntimeKey              = "admission_control.enabled"
+	admissionControlAggressionRuntimeKey           = "admission_control.aggression"
+	admissionControlSuccessRateThresholdRuntimeKey = "admission_control.sr_threshold"
+	adaptiveConcurrencyEnabledRuntimeKey           = "adaptive_concurrency.enabled"
+
+	defaultAdmissionControlSamplingWindow       = 60 * time.Second
+	defaultAdmissionControlSuccessRateThreshold = 95
+	defaultAdmissionControlAggression           = 1.0
+
+	defaultAdaptiveConcurrencyUpdateInterval = 100 * time.Millisecond
+	defaultAdaptiveConcurrencyMinRTTInterval = 60 * time.Second
+)
+
+// getLoadSheddingFilters returns the HTTP filters shedding the load of an overloaded service, as configured
+// in the given UpstreamTrafficSetting spec. The admission control filter precedes the adaptive concurrency
+// filter so that requests are rejected before being counted against the concurrency limit.
+func getLoadSheddingFilters(spec policyV1alpha1.UpstreamTrafficSettingSpec) ([]*xds_hcm.HttpFilter, error) {
+	var filters []*xds_hcm.HttpFilter
+
+	// Envoy rejects a listener whose filters are configured with non-positive durations, so such filters are skipped
+	// rather than failing the whole listener
+	if spec.AdmissionControl != nil {
+		if hasNonPositiveDuration(spec.AdmissionControl.SamplingWindow) {
+			log.Warn().Msgf("Skipping the admission control filter, its sampling window must be positive")
+		} else {
+			filter, err := getAdmissionControlFilter(spec.AdmissionControl)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	if spec.AdaptiveConcurrency != nil {
+		if hasNonPositiveDuration(spec.AdaptiveConcurrency.ConcurrencyLimitUpdateInterval, spec.AdaptiveConcurrency.MinRTTCalcInterval) {
+			log.Warn().Msgf("Skipping the adaptive concurrency filter, its update and round-trip time calculation intervals must be positive")
+		} else {
+			filter, err := getAdaptiveConcurrencyFilter(spec.AdaptiveConcurrency)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, filter)
+		}
+	}
+
+	return filters, nil
+}
+
+// hasNonPositiveDuration returns true if any of the given durations is set to zero or less
+func hasNonPositiveDuration(durations ...*metav1.Duration) bool {
+	for _, duration := range durations {
+		if duration != nil && duration.Duration <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getAdmissionControlFilter returns the admission control HTTP filter for the given spec
+func getAdmissionControlFilter(spec *policyV1alpha1.AdmissionControlSpec) (*xds_hcm.HttpFilter, error) {
+	samplingWindow := defaultAdmissionControlSamplingWindow
+	if spec.SamplingWindow != nil {
+		samplingWindow = spec.SamplingWindow.Duration
+	}
+	successRateThreshold := defaultAdmissionControlSuccessRateThreshold
+	if spec.SuccessRateThreshold != nil {
+		successRateThreshold = *spec.SuccessRateThreshold
+	}
+	aggression := defaultAdmissionControlAggression
+	if spec.Aggression != nil {
+		aggression = *spec.Aggression
+	}
+
+	admissionControl := &xds_admission_control.AdmissionControl{
+		Enabled: &xds_core.RuntimeFeatureFlag{
+			DefaultValue: wrapperspb.Bool(true),
+			RuntimeKey:   admissionControlEnabledRuntimeKey,
+		},
+		SamplingWindow: ptypes.DurationProto(samplingWindow),
+		Aggression: &xds_core.RuntimeDouble{
+			DefaultValue: aggression,
+			RuntimeKey:   admissionControlAggressionRuntimeKey,
+		},
+		SrThreshold: &xds_core.RuntimePercent{
+			DefaultValue: &xds_type.Percent{Value: float64(successRateThreshold)},
+			RuntimeKey:   admissionControlSuccessRateThresholdRuntimeKey,
+		},
+		// Envoy's default success criteria consider 5xx HTTP responses and gRPC errors indicating
+		// an overloaded server as failures
+		EvaluationCriteria: &xds_admission_control.AdmissionControl_SuccessCriteria_{
+			SuccessCriteria: &xds_admission_control.AdmissionControl_SuccessCriteria{},
+		},
+	}
+
+	marshalled, err := ptypes.MarshalAny(admissionControl)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling admission control filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: admissionControlFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
+
+// getAdaptiveConcurrencyFilter returns the adaptive concurrency HTTP filter for the given spec
+func getAdaptiveConcurrencyFilter(spec *policyV1alpha1.AdaptiveConcurrencySpec) (*xds_hcm.HttpFilter, error) {
+	updateInterval := defaultAdaptiveConcurrencyUpdateInterval
+	if spec.ConcurrencyLimitUpdateInterval != nil {
+		updateInterval = spec.ConcurrencyLimitUpdateInterval.Duration
+	}
+	minRTTInterval := defaultAdaptiveConcurrencyMinRTTInterval
+	if spec.MinRTTCalcInterval != nil {
+		minRTTInterval = spec.MinRTTCalcInterval.Duration
+	}
+
+	gradientConfig := &xds_adaptive_concurrency.GradientControllerConfig{
+		ConcurrencyLimitParams: &xds_adaptive_concurrency.GradientControllerConfig_ConcurrencyLimitCalculationParams{
+			ConcurrencyUpdateInterval: ptypes.DurationProto(updateInterval),
+		},
+		MinRttCalcParams: &xds_adaptive_concurrency.GradientControllerConfig_MinimumRTTCalculationParams{
+			Interval: ptypes.DurationProto(minRTTInterval),
+		},
+	}
+	if spec.SampleAggregatePercentile != nil {
+		gradientConfig.SampleAggregatePercentile = &xds_type.Percent{Value: float64(*spec.SampleAggregatePercentile)}
+	}
+	if spec.MaxConcurrencyLimit != nil {
+		gradientConfig.ConcurrencyLimitParams.MaxConcurrencyLimit = wrapperspb.UInt32(*spec.MaxConcurrencyLimit)
+	}
+	if spec.MinRTTRequestCount != nil {
+		gradientConfig.MinRttCalcParams.RequestCount = wrapperspb.UInt32(*spec.MinRTTRequestCount)
+	}
+	if spec.MinConcurrency != nil {
+		gradientConfig.MinRttCalcParams.MinConcurrency = wrapperspb.UInt32(*spec.MinConcurrency)
+	}
+
+	adaptiveConcurrency := &xds_adaptive_concurrency.AdaptiveConcurrency{
+		ConcurrencyControllerConfig: &xds_adaptive_concurrency.AdaptiveConcurrency_GradientControllerConfig{
+			GradientControllerConfig: gradientConfig,
+		},
+		Enabled: &xds_core.RuntimeFeatureFlag{
+			DefaultValue: wrapperspb.Bool(true),
+			RuntimeKey:   adaptiveConcurrencyEnabledRuntimeKey,
+		},
+	}
+
+	marshalled, err := ptypes.MarshalAny(adaptiveConcurrency)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling adaptive concurrency filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: adaptiveConcurrencyFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
+
+// insertBeforeRouterFilter returns the given HTTP filters with the given filters inserted before the router filter,
+// which must be the last filter
+func insertBeforeRouterFilter(httpFilters []*xds_hcm.HttpFilter, filters ...*xds_hcm.HttpFilter) []*xds_hcm.HttpFilter {
+	if len(httpFilters) == 0 {
+		return filters
+	}
+	routerFilter := httpFilters[len(httpFilters)-1]
+
+	var result []*xds_hcm.HttpFilter
+	result = append(result, httpFilters[:len(httpFilters)-1]...)
+	result = append(result, filters...)
+	return append(result, routerFilter)
+}
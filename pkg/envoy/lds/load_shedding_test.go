@@ -0,0 +1,196 @@
+package lds
+
+import (
+	"testing"
+	"time"
+
+	xds_adaptive_concurrency "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/adaptive_concurrency/v3"
+	xds_admission_control "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/admission_control/v3alpha"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+func TestGetLoadSheddingFilters(t *testing.T) {
+	testCases := []struct {
+		name                string
+		spec                policyV1alpha1.UpstreamTrafficSettingSpec
+		expectedFilterNames []string
+	}{
+		{
+			name:                "no load shedding settings",
+			spec:                policyV1alpha1.UpstreamTrafficSettingSpec{Host: "bookstore.default.svc.cluster.local"},
+			expectedFilterNames: nil,
+		},
+		{
+			name: "admission control only",
+			spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host:             "bookstore.default.svc.cluster.local",
+				AdmissionControl: &policyV1alpha1.AdmissionControlSpec{},
+			},
+			expectedFilterNames: []string{admissionControlFilterName},
+		},
+		{
+			name: "admission control and adaptive concurrency",
+			spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host:                "bookstore.default.svc.cluster.local",
+				AdmissionControl:    &policyV1alpha1.AdmissionControlSpec{},
+				AdaptiveConcurrency: &policyV1alpha1.AdaptiveConcurrencySpec{},
+			},
+			expectedFilterNames: []string{admissionControlFilterName, adaptiveConcurrencyFilterName},
+		},
+		{
+			name: "admission control with a zero sampling window is skipped",
+			spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host:                "bookstore.default.svc.cluster.local",
+				AdmissionControl:    &policyV1alpha1.AdmissionControlSpec{SamplingWindow: &metav1.Duration{Duration: 0}},
+				AdaptiveConcurrency: &policyV1alpha1.AdaptiveConcurrencySpec{},
+			},
+			expectedFilterNames: []string{adaptiveConcurrencyFilterName},
+		},
+		{
+			name: "adaptive concurrency with a negative interval is skipped",
+			spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host:             "bookstore.default.svc.cluster.local",
+				AdmissionControl: &policyV1alpha1.AdmissionControlSpec{},
+				AdaptiveConcurrency: &policyV1alpha1.AdaptiveConcurrencySpec{
+					ConcurrencyLimitUpdateInterval: &metav1.Duration{Duration: time.Second},
+					MinRTTCalcInterval:             &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			expectedFilterNames: []string{admissionControlFilterName},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			filters, err := getLoadSheddingFilters(tc.spec)
+			assert.Nil(err)
+
+			var filterNames []string
+			for _, filter := range filters {
+				filterNames = append(filterNames, filter.Name)
+			}
+			assert.Equal(tc.expectedFilterNames, filterNames)
+		})
+	}
+}
+
+func TestGetAdmissionControlFilter(t *testing.T) {
+	testCases := []struct {
+		name                         string
+		spec                         *policyV1alpha1.AdmissionControlSpec
+		expectedSamplingWindow       time.Duration
+		expectedSuccessRateThreshold float64
+		expectedAggression           float64
+	}{
+		{
+			name:                         "defaults",
+			spec:                         &policyV1alpha1.AdmissionControlSpec{},
+			expectedSamplingWindow:       defaultAdmissionControlSamplingWindow,
+			expectedSuccessRateThreshold: defaultAdmissionControlSuccessRateThreshold,
+			expectedAggression:           defaultAdmissionControlAggression,
+		},
+		{
+			name: "custom settings",
+			spec: &policyV1alpha1.AdmissionControlSpec{
+				SamplingWindow:       &metav1.Duration{Duration: 30 * time.Second},
+				SuccessRateThreshold: intPtr(90),
+				Aggression:           pointer.Float64Ptr(1.5),
+			},
+			expectedSamplingWindow:       30 * time.Second,
+			expectedSuccessRateThreshold: 90,
+			expectedAggression:           1.5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			filter, err := getAdmissionControlFilter(tc.spec)
+			assert.Nil(err)
+			assert.Equal(admissionControlFilterName, filter.Name)
+
+			admissionControl := &xds_admission_control.AdmissionControl{}
+			err = ptypes.UnmarshalAny(filter.GetTypedConfig(), admissionControl)
+			assert.Nil(err)
+			assert.Nil(admissionControl.Validate())
+
+			samplingWindow, err := ptypes.Duration(admissionControl.SamplingWindow)
+			assert.Nil(err)
+			assert.Equal(tc.expectedSamplingWindow, samplingWindow)
+			assert.Equal(tc.expectedSuccessRateThreshold, admissionControl.SrThreshold.DefaultValue.Value)
+			assert.Equal(tc.expectedAggression, admissionControl.Aggression.DefaultValue)
+			assert.True(admissionControl.Enabled.DefaultValue.Value)
+		})
+	}
+}
+
+func TestGetAdaptiveConcurrencyFilter(t *testing.T) {
+	assert := tassert.New(t)
+
+	filter, err := getAdaptiveConcurrencyFilter(&policyV1alpha1.AdaptiveConcurrencySpec{
+		SampleAggregatePercentile: intPtr(90),
+		MaxConcurrencyLimit:       uint32Ptr(500),
+		MinRTTRequestCount:        uint32Ptr(20),
+		MinConcurrency:            uint32Ptr(5),
+	})
+	assert.Nil(err)
+	assert.Equal(adaptiveConcurrencyFilterName, filter.Name)
+
+	adaptiveConcurrency := &xds_adaptive_concurrency.AdaptiveConcurrency{}
+	err = ptypes.UnmarshalAny(filter.GetTypedConfig(), adaptiveConcurrency)
+	assert.Nil(err)
+	assert.Nil(adaptiveConcurrency.Validate())
+
+	gradientConfig := adaptiveConcurrency.GetGradientControllerConfig()
+	assert.Equal(float64(90), gradientConfig.SampleAggregatePercentile.Value)
+	assert.Equal(uint32(500), gradientConfig.ConcurrencyLimitParams.MaxConcurrencyLimit.Value)
+	assert.Equal(uint32(20), gradientConfig.MinRttCalcParams.RequestCount.Value)
+	assert.Equal(uint32(5), gradientConfig.MinRttCalcParams.MinConcurrency.Value)
+
+	updateInterval, err := ptypes.Duration(gradientConfig.ConcurrencyLimitParams.ConcurrencyUpdateInterval)
+	assert.Nil(err)
+	assert.Equal(defaultAdaptiveConcurrencyUpdateInterval, updateInterval)
+
+	minRTTInterval, err := ptypes.Duration(gradientConfig.MinRttCalcParams.Interval)
+	assert.Nil(err)
+	assert.Equal(defaultAdaptiveConcurrencyMinRTTInterval, minRTTInterval)
+}
+
+func TestInsertBeforeRouterFilter(t *testing.T) {
+	assert := tassert.New(t)
+
+	httpFilters := []*xds_hcm.HttpFilter{
+		{Name: wellknown.HTTPRoleBasedAccessControl},
+		{Name: wellknown.Router},
+	}
+
+	actual := insertBeforeRouterFilter(httpFilters, &xds_hcm.HttpFilter{Name: admissionControlFilterName}, &xds_hcm.HttpFilter{Name: adaptiveConcurrencyFilterName})
+
+	var filterNames []string
+	for _, filter := range actual {
+		filterNames = append(filterNames, filter.Name)
+	}
+	assert.Equal([]string{wellknown.HTTPRoleBasedAccessControl, admissionControlFilterName, adaptiveConcurrencyFilterName, wellknown.Router}, filterNames)
+
+	// The given filters are left untouched
+	assert.Len(httpFilters, 2)
+	assert.Equal(wellknown.Router, httpFilters[1].Name)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func uint32Ptr(i uint32) *uint32 {
+	return &i
+}
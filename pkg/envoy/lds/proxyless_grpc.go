@@ -0,0 +1,95 @@
+package lds
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// alpnHTTP2 is the ALPN protocol advertised by gRPC clients
+const alpnHTTP2 = "h2"
+
+// getProxylessGRPCListeners returns the API listeners for a proxyless gRPC client.
+// A gRPC client using the 'xds:///<host>:<port>' target requests an API listener named '<host>:<port>',
+// so an API listener is built for every HTTP and gRPC port of the upstream services the client is allowed to connect to.
+func (lb *listenerBuilder) getProxylessGRPCListeners() []types.Resource {
+	var listeners []types.Resource
+
+	for _, upstreamSvc := range lb.meshCatalog.ListAllowedOutboundServicesForIdentity(lb.serviceIdentity) {
+		portToProtocolMap, err := lb.meshCatalog.GetPortToProtocolMappingForService(upstreamSvc)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error retrieving port to protocol mapping for service %s", upstreamSvc)
+			continue
+		}
+
+		var ports []int
+		for port, appProtocol := range portToProtocolMap {
+			switch strings.ToLower(appProtocol) {
+			case constants.ProtocolHTTP, constants.ProtocolGRPC:
+				ports = append(ports, int(port))
+			default:
+				log.Debug().Msgf("Skipping API listener for service %s port %d with unsupported protocol %s", upstreamSvc, port, appProtocol)
+			}
+		}
+		sort.Ints(ports)
+
+		for _, port := range ports {
+			listener, err := buildProxylessGRPCAPIListener(upstreamSvc, uint32(port))
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building API listener for service %s port %d", upstreamSvc, port)
+				continue
+			}
+			listeners = append(listeners, listener)
+		}
+	}
+
+	return listeners
+}
+
+// buildProxylessGRPCAPIListener returns an API listener for the given upstream service port.
+// The listener routes requests using the outbound route configuration, whose virtual hosts match the service's hostnames.
+func buildProxylessGRPCAPIListener(upstreamSvc service.MeshService, port uint32) (*xds_listener.Listener, error) {
+	connManager := &xds_hcm.HttpConnectionManager{
+		HttpFilters: []*xds_hcm.HttpFilter{
+			{
+				// HTTP Router filter
+				Name: wellknown.Router,
+			},
+		},
+		RouteSpecifier: &xds_hcm.HttpConnectionManager_Rds{
+			Rds: &xds_hcm.Rds{
+				ConfigSource:    envoy.GetADSConfigSource(),
+				RouteConfigName: route.OutboundRouteConfigName,
+			},
+		},
+	}
+
+	marshalledConnManager, err := ptypes.MarshalAny(connManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager for API listener of service %s", upstreamSvc)
+		return nil, err
+	}
+
+	return &xds_listener.Listener{
+		Name: getProxylessGRPCListenerName(upstreamSvc, port),
+		ApiListener: &xds_listener.ApiListener{
+			ApiListener: marshalledConnManager,
+		},
+	}, nil
+}
+
+// getProxylessGRPCListenerName returns the name of the API listener for the given service port, ex. bookstore.bookstore.svc.cluster.local:14001
+func getProxylessGRPCListenerName(upstreamSvc service.MeshService, port uint32) string {
+	return fmt.Sprintf("%s:%d", upstreamSvc.ServerName(), port)
+}
@@ -0,0 +1,54 @@
+package lds
+
+import (
+	"testing"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestGetProxylessGRPCListeners(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	mockCatalog.EXPECT().ListAllowedOutboundServicesForIdentity(tests.BookbuyerServiceIdentity).Return([]service.MeshService{tests.BookstoreV1Service}).Times(1)
+	mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{
+		14001: "grpc",
+		8080:  "http",
+		9090:  "tcp",
+	}, nil).Times(1)
+
+	lb := newListenerBuilder(mockCatalog, tests.BookbuyerServiceIdentity, nil, nil, nil)
+	listeners := lb.getProxylessGRPCListeners()
+
+	// The TCP port does not have an API listener, and listeners are ordered by port
+	require.Len(listeners, 2)
+	expectedNames := []string{
+		"bookstore-v1.default.svc.cluster.local:8080",
+		"bookstore-v1.default.svc.cluster.local:14001",
+	}
+
+	for i, resource := range listeners {
+		listener, ok := resource.(*xds_listener.Listener)
+		require.True(ok)
+		assert.Equal(expectedNames[i], listener.Name)
+		assert.Nil(listener.Address)
+		require.NotNil(listener.ApiListener)
+
+		connManager := &xds_hcm.HttpConnectionManager{}
+		require.Nil(ptypes.UnmarshalAny(listener.ApiListener.ApiListener, connManager))
+		assert.Equal(route.OutboundRouteConfigName, connManager.GetRds().RouteConfigName)
+	}
+}
@@ -0,0 +1,50 @@
+package lds
+
+import (
+	xds_http_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+)
+
+// inboundHTTPLocalRateLimitStatPrefix is the stat prefix of the HTTP local rate limit filter of inbound connection managers
+const inboundHTTPLocalRateLimitStatPrefix = "inbound-http-local-rate-limit"
+
+// getHTTPLocalRateLimitSpec returns the HTTP local rate limit configured in the given UpstreamTrafficSetting spec, if any
+func getHTTPLocalRateLimitSpec(spec policyV1alpha1.UpstreamTrafficSettingSpec) *policyV1alpha1.HTTPLocalRateLimitSpec {
+	if spec.RateLimit == nil || spec.RateLimit.Local == nil {
+		return nil
+	}
+	return spec.RateLimit.Local.HTTP
+}
+
+// getHTTPLocalRateLimitFilter returns the HTTP local rate limit filter of an inbound connection manager. The filter does
+// not rate limit requests by itself, the rate limits are configured on the routes, depending on the source of the requests.
+func getHTTPLocalRateLimitFilter() (*xds_hcm.HttpFilter, error) {
+	localRateLimit := &xds_http_local_ratelimit.LocalRateLimit{
+		StatPrefix: inboundHTTPLocalRateLimitStatPrefix,
+	}
+
+	marshalled, err := ptypes.MarshalAny(localRateLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling HTTP local rate limit filter")
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: route.HTTPLocalRateLimitFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalled,
+		},
+	}, nil
+}
+
+// forwardClientCertDNS sets the x-forwarded-client-cert header of the requests received by the given connection manager
+// with the DNS SANs of the certificate of the client, which identify the source of the requests matched by the routes.
+// The header is sanitized when received from clients so that it cannot be spoofed.
+func forwardClientCertDNS(connManager *xds_hcm.HttpConnectionManager) {
+	connManager.ForwardClientCertDetails = xds_hcm.HttpConnectionManager_SANITIZE_SET
+	connManager.SetCurrentClientCertDetails = &xds_hcm.HttpConnectionManager_SetCurrentClientCertDetails{Dns: true}
+}
@@ -0,0 +1,130 @@
+package lds
+
+import (
+	"testing"
+
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestGetInboundHTTPFiltersWithHTTPRateLimit(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
+		serviceIdentity: tests.BookstoreServiceIdentity,
+	}
+
+	getConnManager := func(rateLimit *policyV1alpha1.RateLimitSpec) *xds_hcm.HttpConnectionManager {
+		mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV1Service).Return(&policyV1alpha1.UpstreamTrafficSetting{
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host:      tests.BookstoreV1Service.ServerName(),
+				RateLimit: rateLimit,
+			},
+		}).Times(1)
+
+		filters, err := lb.getInboundHTTPFilters(tests.BookstoreV1Service, route.InboundRouteConfigName)
+		assert.Nil(err)
+		assert.Len(filters, 1)
+		assert.Equal(wellknown.HTTPConnectionManager, filters[0].Name)
+
+		connManager := &xds_hcm.HttpConnectionManager{}
+		assert.Nil(ptypes.UnmarshalAny(filters[0].GetTypedConfig(), connManager))
+		return connManager
+	}
+
+	// The certificate of the clients identifies the source of the requests rate limited by the routes
+	connManager := getConnManager(&policyV1alpha1.RateLimitSpec{
+		Local: &policyV1alpha1.LocalRateLimitSpec{
+			HTTP: &policyV1alpha1.HTTPLocalRateLimitSpec{Requests: 10, Unit: "second"},
+		},
+	})
+	assert.Equal(xds_hcm.HttpConnectionManager_SANITIZE_SET, connManager.ForwardClientCertDetails)
+	assert.True(connManager.SetCurrentClientCertDetails.GetDns())
+	assert.Len(connManager.HttpFilters, 3)
+	assert.Equal(route.HTTPLocalRateLimitFilterName, connManager.HttpFilters[1].Name)
+	assert.Equal(wellknown.Router, connManager.HttpFilters[2].Name)
+
+	// TCP rate limits are not applied by the upstream host
+	connManager = getConnManager(&policyV1alpha1.RateLimitSpec{
+		Local: &policyV1alpha1.LocalRateLimitSpec{
+			TCP: &policyV1alpha1.TCPLocalRateLimitSpec{Connections: 10, Unit: "second"},
+		},
+	})
+	assert.Nil(connManager.SetCurrentClientCertDetails)
+	assert.Len(connManager.HttpFilters, 2)
+}
+
+func TestGetInboundHTTPFiltersWithheldFeatures(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetHTTPProtocolOptions(gomock.Any()).Return(configurator.HTTPProtocolOptions{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetEnvoyMinSupportedVersion().Return("1.17.0").AnyTimes()
+
+	maxConnections := uint32(100)
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV1Service).Return(&policyV1alpha1.UpstreamTrafficSetting{
+		Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+			Host:    tests.BookstoreV1Service.ServerName(),
+			Inbound: &policyV1alpha1.InboundConnectionSettingsSpec{MaxConnections: &maxConnections},
+			RateLimit: &policyV1alpha1.RateLimitSpec{
+				Local: &policyV1alpha1.LocalRateLimitSpec{
+					HTTP: &policyV1alpha1.HTTPLocalRateLimitSpec{Requests: 10, Unit: "second"},
+				},
+			},
+			GRPCRoutes: []policyV1alpha1.GRPCRouteSpec{{Service: "bookstore.Books"}},
+		},
+	}).Times(1)
+
+	// The newer features are withheld from a proxy running an Envoy version below the minimum supported version,
+	// which keeps receiving the rest of its configuration
+	proxy := envoy.NewProxy(certificate.CommonName("abc.bookstore.default"), "1", tests.NewMockAddress("1.2.3.4"))
+	proxy.SetVersion(envoy.Version{Major: 1, Minor: 16, Patch: 5})
+	lb := &listenerBuilder{
+		meshCatalog:     mockCatalog,
+		cfg:             mockConfigurator,
+		serviceIdentity: tests.BookstoreServiceIdentity,
+		proxy:           proxy,
+	}
+
+	filters, err := lb.getInboundHTTPFilters(tests.BookstoreV1Service, route.InboundRouteConfigName)
+	assert.Nil(err)
+	assert.Len(filters, 1)
+	assert.Equal(wellknown.HTTPConnectionManager, filters[0].Name)
+
+	connManager := &xds_hcm.HttpConnectionManager{}
+	assert.Nil(ptypes.UnmarshalAny(filters[0].GetTypedConfig(), connManager))
+	assert.Nil(connManager.SetCurrentClientCertDetails)
+	assert.Len(connManager.HttpFilters, 2)
+	assert.Equal(wellknown.HTTPRoleBasedAccessControl, connManager.HttpFilters[0].Name)
+	assert.Equal(wellknown.Router, connManager.HttpFilters[1].Name)
+}
@@ -1,6 +1,7 @@
 package lds
 
 import (
+	mapset "github.com/deckarep/golang-set"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 
@@ -18,12 +19,6 @@ import (
 // 2. Outbound listener to handle outgoing traffic
 // 3. Prometheus listener for metrics
 func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator, _ certificate.Manager) ([]types.Resource, error) {
-	svcList, err := meshCatalog.GetServicesForProxy(proxy)
-	if err != nil {
-		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
-		return nil, err
-	}
-
 	svcAccount, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error retrieving ServiceAccount for Envoy with certificate with SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
@@ -37,7 +32,29 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 		statsHeaders = proxy.StatsHeaders()
 	}
 
-	lb := newListenerBuilder(meshCatalog, svcAccount.ToServiceIdentity(), cfg, statsHeaders)
+	lb := newListenerBuilder(meshCatalog, svcAccount.ToServiceIdentity(), cfg, statsHeaders, proxy.GetWorkloadMetadata())
+	lb.proxy = proxy
+
+	if proxy.GetKind() == envoy.KindProxylessGRPC {
+		// Proxyless gRPC clients do not intercept traffic, they only consume API listeners for their upstream services
+		return lb.getProxylessGRPCListeners(), nil
+	}
+
+	if proxy.GetKind() == envoy.KindIngressGateway {
+		// The ingress gateway is not fronting any mesh service, it only accepts traffic from clients outside the mesh
+		gatewayListener, err := lb.newIngressGatewayListener()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error making ingress gateway listener config for proxy with XDS Certificate SerialNumber=%s", proxy.GetCertificateSerialNumber())
+			return nil, err
+		}
+		return []types.Resource{gatewayListener}, nil
+	}
+
+	svcList, err := meshCatalog.GetServicesForProxy(proxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy certificate SerialNumber=%s on Pod with UID=%s", proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+		return nil, err
+	}
 
 	// --- OUTBOUND -------------------
 	outboundListener, err := lb.newOutboundListener()
@@ -57,6 +74,8 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	// --- INBOUND -------------------
 	inboundListener := newInboundListener()
+	// Ports with ingress filter chains, plaintext traffic on these ports must be authorized by the ingress filter chains
+	ingressPorts := mapset.NewSet()
 	// Create inbound filter chains per service behind proxy
 	for _, proxyService := range svcList {
 		// Create in-mesh filter chains
@@ -73,12 +92,26 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 				// This proxy is fronting a service that is a backend for an ingress, add a FilterChain for it
 				ingressFilterChains := lb.getIngressFilterChains(proxyService)
 				inboundListener.FilterChains = append(inboundListener.FilterChains, ingressFilterChains...)
+				for _, filterChain := range ingressFilterChains {
+					ingressPorts.Add(filterChain.FilterChainMatch.GetDestinationPort().GetValue())
+				}
 			} else {
 				log.Trace().Msgf("There is no k8s Ingress for service %s", proxyService)
 			}
 		}
 	}
 
+	// Create plaintext filter chains for the ports accepting plaintext traffic in addition to mTLS traffic
+	if permissiveTLSInboundPorts, err := meshCatalog.GetPermissiveTLSInboundPortsForProxy(proxy); err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound ports with permissive TLS for proxy with XDS Certificate SerialNumber=%s on Pod with UID=%s",
+			proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+	} else {
+		inboundListener.FilterChains = append(inboundListener.FilterChains, lb.getInboundPlaintextFilterChains(svcList, permissiveTLSInboundPorts, ingressPorts)...)
+	}
+
+	// Limit the size of the buffers of the connections accepted by the inbound listener
+	inboundListener.PerConnectionBufferLimitBytes = lb.getInboundPerConnectionBufferLimit(svcList)
+
 	if len(inboundListener.FilterChains) > 0 {
 		// Inbound filter chains can be empty if the there both ingress and in-mesh policies are not configured.
 		// Configuring a listener without a filter chain is an error.
@@ -87,7 +120,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 
 	if cfg.IsPrometheusScrapingEnabled() {
 		// Build Prometheus listener config
-		prometheusConnManager := getPrometheusConnectionManager()
+		prometheusConnManager := getPrometheusConnectionManager(proxy.GetWorkloadMetadata())
 		if prometheusListener, err := buildPrometheusListener(prometheusConnManager); err != nil {
 			log.Error().Err(err).Msgf("Error building Prometheus listener config for proxy with XDS Certificate SerialNumber=%s on Pod with UID=%s",
 				proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
@@ -100,11 +133,17 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_d
 }
 
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
-func newListenerBuilder(meshCatalog catalog.MeshCataloger, svcIdentity identity.ServiceIdentity, cfg configurator.Configurator, statsHeaders map[string]string) *listenerBuilder {
+func newListenerBuilder(meshCatalog catalog.MeshCataloger, svcIdentity identity.ServiceIdentity, cfg configurator.Configurator, statsHeaders map[string]string, workloadMetadata map[string]string) *listenerBuilder {
 	return &listenerBuilder{
-		meshCatalog:     meshCatalog,
-		serviceIdentity: svcIdentity,
-		cfg:             cfg,
-		statsHeaders:    statsHeaders,
+		meshCatalog:      meshCatalog,
+		serviceIdentity:  svcIdentity,
+		cfg:              cfg,
+		statsHeaders:     statsHeaders,
+		workloadMetadata: workloadMetadata,
 	}
 }
+
+// isFeatureWithheld returns whether the given newer feature must be withheld from the listeners of the proxy
+func (lb *listenerBuilder) isFeatureWithheld(feature envoy.NewerFeature) bool {
+	return lb.proxy != nil && envoy.IsFeatureWithheld(lb.proxy, lb.cfg, feature)
+}
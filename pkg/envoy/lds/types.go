@@ -4,6 +4,7 @@ package lds
 import (
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/envoy"
 
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -19,4 +20,10 @@ type listenerBuilder struct {
 	meshCatalog     catalog.MeshCataloger
 	cfg             configurator.Configurator
 	statsHeaders    map[string]string
+
+	// workloadMetadata is the workload metadata of the proxy, recorded in its access logs
+	workloadMetadata map[string]string
+
+	// proxy is the proxy the listeners are built for, newer features are withheld from it depending on its Envoy version
+	proxy *envoy.Proxy
 }
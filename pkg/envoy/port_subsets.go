@@ -0,0 +1,74 @@
+package envoy
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// The endpoints of a service exposing multiple ports are all part of the same cluster, so the endpoints of a cluster are
+// labeled with the ports they serve and the routes and TCP proxies forwarding the traffic received on a port only select
+// the subset of the endpoints serving this port. Traffic that does not select a subset is balanced across all endpoints.
+const (
+	// ServicePortSubsetKey is the key of the endpoint metadata listing the service ports served by an endpoint of an
+	// upstream service cluster
+	ServicePortSubsetKey = "service_port"
+
+	// TargetPortSubsetKey is the key of the endpoint metadata of the target port served by an endpoint of a local cluster
+	TargetPortSubsetKey = "target_port"
+
+	// lbSubsetMetadataFilter is the metadata namespace of the endpoint metadata used for subset load balancing
+	lbSubsetMetadataFilter = "envoy.lb"
+)
+
+// GetPortSubsetConfig returns the subset load balancing config of a cluster whose endpoints are labeled with the ports
+// they serve under the given key
+func GetPortSubsetConfig(key string) *xds_cluster.Cluster_LbSubsetConfig {
+	return &xds_cluster.Cluster_LbSubsetConfig{
+		FallbackPolicy: xds_cluster.Cluster_LbSubsetConfig_ANY_ENDPOINT,
+		SubsetSelectors: []*xds_cluster.Cluster_LbSubsetConfig_LbSubsetSelector{
+			{Keys: []string{key}},
+		},
+		// An endpoint can serve multiple service ports mapped to the same target port
+		ListAsAny: true,
+	}
+}
+
+// GetPortSubsetMetadata returns the metadata labeling an endpoint with the given ports under the given key
+func GetPortSubsetMetadata(key string, ports []uint32) *xds_core.Metadata {
+	values := make([]*structpb.Value, 0, len(ports))
+	for _, port := range ports {
+		values = append(values, pbNumberValue(port))
+	}
+
+	return &xds_core.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			lbSubsetMetadataFilter: {
+				Fields: map[string]*structpb.Value{
+					key: {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}}},
+				},
+			},
+		},
+	}
+}
+
+// GetPortMetadataMatch returns the metadata match selecting the endpoints labeled with the given port under the given key
+func GetPortMetadataMatch(key string, port uint32) *xds_core.Metadata {
+	return &xds_core.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			lbSubsetMetadataFilter: {
+				Fields: map[string]*structpb.Value{
+					key: pbNumberValue(port),
+				},
+			},
+		},
+	}
+}
+
+func pbNumberValue(v uint32) *structpb.Value {
+	return &structpb.Value{
+		Kind: &structpb.Value_NumberValue{
+			NumberValue: float64(v),
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package envoy
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestPortSubsets(t *testing.T) {
+	assert := tassert.New(t)
+
+	subsetConfig := GetPortSubsetConfig(ServicePortSubsetKey)
+	assert.True(subsetConfig.ListAsAny)
+	assert.Len(subsetConfig.SubsetSelectors, 1)
+	assert.Equal([]string{ServicePortSubsetKey}, subsetConfig.SubsetSelectors[0].Keys)
+
+	metadata := GetPortSubsetMetadata(ServicePortSubsetKey, []uint32{80, 8080})
+	ports := metadata.FilterMetadata["envoy.lb"].Fields[ServicePortSubsetKey].GetListValue().GetValues()
+	assert.Len(ports, 2)
+	assert.Equal(float64(80), ports[0].GetNumberValue())
+	assert.Equal(float64(8080), ports[1].GetNumberValue())
+
+	// A metadata match selects the endpoints whose list of ports contains the port
+	match := GetPortMetadataMatch(ServicePortSubsetKey, 8080)
+	assert.Equal(float64(8080), match.FilterMetadata["envoy.lb"].Fields[ServicePortSubsetKey].GetNumberValue())
+}
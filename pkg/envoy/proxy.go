@@ -4,15 +4,63 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes/any"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/utils"
 )
 
+// ProxyKind is the type used to represent the kind of xDS client connected to the control plane
+type ProxyKind string
+
+const (
+	// KindSidecar is the kind used for Envoy sidecars fronting application containers
+	KindSidecar ProxyKind = "sidecar"
+
+	// KindProxylessGRPC is the kind used for gRPC applications connecting to the control plane with the gRPC xDS client
+	KindProxylessGRPC ProxyKind = "proxyless-grpc"
+
+	// KindIngressGateway is the kind used for the Envoy instances of the OSM managed ingress gateway
+	KindIngressGateway ProxyKind = "ingress-gateway"
+)
+
+// ResourcesSnapshot is the set of resources of a given type sent to a proxy in a discovery response
+type ResourcesSnapshot struct {
+	// Version is the version of the discovery response
+	Version uint64
+
+	// Resources are the marshalled resources of the discovery response
+	Resources []*any.Any
+
+	// ResourceNames is the set of names of the resources of the discovery response
+	ResourceNames mapset.Set
+
+	// IsRollback indicates the discovery response re-pushed resources previously ACKed by the proxy
+	IsRollback bool
+}
+
+// resourcesSnapshots holds the resources of the discovery responses sent to a proxy, ACKed and NACKed by the proxy.
+// They are read and written by the goroutine receiving the requests of the proxy and by the workers pushing to the proxy.
+type resourcesSnapshots struct {
+	sync.Mutex
+	lastSent   map[TypeURI]*ResourcesSnapshot
+	lastACKed  map[TypeURI]*ResourcesSnapshot
+	lastNACKed map[TypeURI]*ResourcesSnapshot
+}
+
+// withheldFeatures holds the NewerFeatures withheld from the configuration of a proxy, mapped to the Envoy version of
+// the proxy they were withheld for. They are read and written by the workers computing the configuration of the proxy.
+type withheldFeatures struct {
+	sync.Mutex
+	versions map[NewerFeature]string
+}
+
 // Proxy is a representation of an Envoy proxy connected to the xDS server.
 // This should at some point have a 1:1 match to an Endpoint (which is a member of a meshed service).
 type Proxy struct {
@@ -27,6 +75,9 @@ type Proxy struct {
 	// The time this Proxy connected to the OSM control plane
 	connectedAt time.Time
 
+	// The time a discovery response was last sent to this Proxy
+	lastUpdatedAt time.Time
+
 	lastSentVersion    map[TypeURI]uint64
 	lastAppliedVersion map[TypeURI]uint64
 	lastNonce          map[TypeURI]string
@@ -34,9 +85,29 @@ type Proxy struct {
 	// Contains the last resource names sent for a given proxy and TypeURL
 	lastxDSResourcesSent map[TypeURI]mapset.Set
 
+	// Contains the resources of the last discovery responses sent, ACKed and NACKed for a given TypeURL,
+	// used to roll back the proxy to the last ACKed resources when it NACKs a discovery response
+	snapshots *resourcesSnapshots
+
 	// hash is based on CommonName
 	hash uint64
 
+	// kind is the ProxyKind of xDS client this proxy represents. It is set by the goroutine receiving the requests of
+	// the proxy and read by the goroutines sending responses, hence stored atomically.
+	kind atomic.Value
+
+	// workloadMetadata is the workload metadata set in the node metadata of the proxy
+	workloadMetadata map[string]string
+
+	// version is the Version of the Envoy build of the proxy, unset until the proxy reports it in its node. It is set by
+	// the goroutine receiving the requests of the proxy and read by the goroutines computing its configuration, hence
+	// stored atomically.
+	version atomic.Value
+
+	// Contains the NewerFeatures withheld from the configuration of the proxy, so that a withheld feature is only
+	// reported once per proxy
+	withheldFeatures *withheldFeatures
+
 	// Records metadata around the Kubernetes Pod on which this Envoy Proxy is installed.
 	// This could be nil if the Envoy is not operating in a Kubernetes cluster (VM for example)
 	// NOTE: This field may be not be set at the time Proxy struct is initialized. This would
@@ -178,6 +249,68 @@ func (p *Proxy) GetConnectedAt() time.Time {
 	return p.connectedAt
 }
 
+// GetLastUpdatedAt returns the timestamp of when a discovery response was last sent to the given proxy,
+// or the zero time if no discovery response was sent yet.
+func (p *Proxy) GetLastUpdatedAt() time.Time {
+	return p.lastUpdatedAt
+}
+
+// SetLastUpdatedAt records the timestamp of when a discovery response was last sent to the given proxy.
+func (p *Proxy) SetLastUpdatedAt(t time.Time) {
+	p.lastUpdatedAt = t
+}
+
+// GetKind returns the kind of xDS client the proxy represents.
+func (p *Proxy) GetKind() ProxyKind {
+	kind, _ := p.kind.Load().(ProxyKind)
+	return kind
+}
+
+// SetKind records the kind of xDS client the proxy represents.
+func (p *Proxy) SetKind(kind ProxyKind) {
+	p.kind.Store(kind)
+}
+
+// GetWorkloadMetadata returns the workload metadata set in the node metadata of the proxy, or nil if none was set.
+func (p *Proxy) GetWorkloadMetadata() map[string]string {
+	return p.workloadMetadata
+}
+
+// SetWorkloadMetadata records the workload metadata set in the node metadata of the proxy.
+func (p *Proxy) SetWorkloadMetadata(workloadMetadata map[string]string) {
+	p.workloadMetadata = workloadMetadata
+}
+
+// GetProxyConfigClass returns the proxy configuration class the sidecar was injected with, empty if the sidecar was
+// injected with the mesh-wide settings.
+func (p *Proxy) GetProxyConfigClass() string {
+	return p.workloadMetadata[NodeMetadataProxyConfigClass]
+}
+
+// IsEnvoyAdminUDSEnabled returns true if the admin interface of the sidecar was injected bound to a Unix domain socket.
+func (p *Proxy) IsEnvoyAdminUDSEnabled() bool {
+	return p.workloadMetadata[NodeMetadataEnvoyAdminUDS] == "true"
+}
+
+// HasEgressStatsTags returns true if the bootstrap config of the sidecar defines the stats tags of egress clusters.
+func (p *Proxy) HasEgressStatsTags() bool {
+	return p.workloadMetadata[NodeMetadataEgressStatsTags] == "true"
+}
+
+// GetVersion returns the version of the Envoy build of the proxy, or nil if the proxy did not report it.
+func (p *Proxy) GetVersion() *Version {
+	version, ok := p.version.Load().(Version)
+	if !ok {
+		return nil
+	}
+	return &version
+}
+
+// SetVersion records the version of the Envoy build of the proxy.
+func (p *Proxy) SetVersion(version Version) {
+	p.version.Store(version)
+}
+
 // GetIP returns the IP address of the Envoy proxy connected to xDS.
 func (p *Proxy) GetIP() net.Addr {
 	return p.Addr
@@ -198,6 +331,51 @@ func (p *Proxy) SetLastResourcesSent(typeURI TypeURI, resourcesSet mapset.Set) {
 	p.lastxDSResourcesSent[typeURI] = resourcesSet
 }
 
+// GetLastSentSnapshot returns the resources of the last discovery response sent to the proxy for the given TypeURL,
+// or nil if none was sent.
+func (p *Proxy) GetLastSentSnapshot(typeURI TypeURI) *ResourcesSnapshot {
+	p.snapshots.Lock()
+	defer p.snapshots.Unlock()
+	return p.snapshots.lastSent[typeURI]
+}
+
+// SetLastSentSnapshot records the resources of the last discovery response sent to the proxy for the given TypeURL.
+func (p *Proxy) SetLastSentSnapshot(typeURI TypeURI, snapshot *ResourcesSnapshot) {
+	p.snapshots.Lock()
+	defer p.snapshots.Unlock()
+	p.snapshots.lastSent[typeURI] = snapshot
+}
+
+// GetLastACKedSnapshot returns the resources of the last discovery response ACKed by the proxy for the given TypeURL,
+// or nil if none was ACKed.
+func (p *Proxy) GetLastACKedSnapshot(typeURI TypeURI) *ResourcesSnapshot {
+	p.snapshots.Lock()
+	defer p.snapshots.Unlock()
+	return p.snapshots.lastACKed[typeURI]
+}
+
+// SetLastACKedSnapshot records the resources of the last discovery response ACKed by the proxy for the given TypeURL.
+func (p *Proxy) SetLastACKedSnapshot(typeURI TypeURI, snapshot *ResourcesSnapshot) {
+	p.snapshots.Lock()
+	defer p.snapshots.Unlock()
+	p.snapshots.lastACKed[typeURI] = snapshot
+}
+
+// GetLastNACKedSnapshot returns the resources of the last discovery response NACKed by the proxy for the given TypeURL,
+// or nil if none was NACKed.
+func (p *Proxy) GetLastNACKedSnapshot(typeURI TypeURI) *ResourcesSnapshot {
+	p.snapshots.Lock()
+	defer p.snapshots.Unlock()
+	return p.snapshots.lastNACKed[typeURI]
+}
+
+// SetLastNACKedSnapshot records the resources of the last discovery response NACKed by the proxy for the given TypeURL.
+func (p *Proxy) SetLastNACKedSnapshot(typeURI TypeURI, snapshot *ResourcesSnapshot) {
+	p.snapshots.Lock()
+	defer p.snapshots.Unlock()
+	p.snapshots.lastNACKed[typeURI] = snapshot
+}
+
 // NewProxy creates a new instance of an Envoy proxy connected to the xDS servers.
 func NewProxy(certCommonName certificate.CommonName, certSerialNumber certificate.SerialNumber, ip net.Addr) *Proxy {
 	// Get CommonName hash for this proxy
@@ -206,7 +384,7 @@ func NewProxy(certCommonName certificate.CommonName, certSerialNumber certificat
 		log.Error().Err(err).Msgf("Failed to get hash for proxy serial %s, 0 hash will be used", certSerialNumber)
 	}
 
-	return &Proxy{
+	proxy := &Proxy{
 		xDSCertificateCommonName:   certCommonName,
 		xDSCertificateSerialNumber: certSerialNumber,
 
@@ -219,5 +397,16 @@ func NewProxy(certCommonName certificate.CommonName, certSerialNumber certificat
 		lastSentVersion:      make(map[TypeURI]uint64),
 		lastAppliedVersion:   make(map[TypeURI]uint64),
 		lastxDSResourcesSent: make(map[TypeURI]mapset.Set),
+		snapshots: &resourcesSnapshots{
+			lastSent:   make(map[TypeURI]*ResourcesSnapshot),
+			lastACKed:  make(map[TypeURI]*ResourcesSnapshot),
+			lastNACKed: make(map[TypeURI]*ResourcesSnapshot),
+		},
+		withheldFeatures: &withheldFeatures{
+			versions: make(map[NewerFeature]string),
+		},
 	}
+	proxy.SetKind(KindSidecar)
+
+	return proxy
 }
@@ -3,6 +3,7 @@ package envoy
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -86,6 +87,16 @@ var _ = Describe("Test proxy methods", func() {
 		})
 	})
 
+	Context("test GetLastUpdatedAt()", func() {
+		It("returns correct values", func() {
+			Expect(proxy.GetLastUpdatedAt().IsZero()).To(BeTrue())
+
+			updatedAt := time.Now()
+			proxy.SetLastUpdatedAt(updatedAt)
+			Expect(proxy.GetLastUpdatedAt()).To(Equal(updatedAt))
+		})
+	})
+
 	Context("test GetIP()", func() {
 		It("returns correct values", func() {
 			actual := proxy.GetIP()
@@ -101,6 +112,69 @@ var _ = Describe("Test proxy methods", func() {
 		})
 	})
 
+	Context("test GetKind()", func() {
+		It("defaults to a sidecar and can be updated", func() {
+			p := NewProxy(certCommonName, certSerialNumber, tests.NewMockAddress("1.2.3.4"))
+			Expect(p.GetKind()).To(Equal(KindSidecar))
+
+			p.SetKind(KindProxylessGRPC)
+			Expect(p.GetKind()).To(Equal(KindProxylessGRPC))
+		})
+
+		It("can be updated while it is read concurrently", func() {
+			p := NewProxy(certCommonName, certSerialNumber, tests.NewMockAddress("1.2.3.4"))
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				p.SetKind(KindProxylessGRPC)
+			}()
+			Expect(p.GetKind()).To(Or(Equal(KindSidecar), Equal(KindProxylessGRPC)))
+			<-done
+			Expect(p.GetKind()).To(Equal(KindProxylessGRPC))
+		})
+	})
+
+	Context("test GetWorkloadMetadata()", func() {
+		It("returns correct values", func() {
+			p := NewProxy(certCommonName, certSerialNumber, tests.NewMockAddress("1.2.3.4"))
+			Expect(p.GetWorkloadMetadata()).To(BeNil())
+
+			workloadMetadata := map[string]string{NodeMetadataPodName: "pod-1"}
+			p.SetWorkloadMetadata(workloadMetadata)
+			Expect(p.GetWorkloadMetadata()).To(Equal(workloadMetadata))
+		})
+	})
+
+	Context("test GetLastSentSnapshot() and GetLastACKedSnapshot()", func() {
+		It("returns correct values", func() {
+			p := NewProxy(certCommonName, certSerialNumber, tests.NewMockAddress("1.2.3.4"))
+			Expect(p.GetLastSentSnapshot(TypeCDS)).To(BeNil())
+			Expect(p.GetLastACKedSnapshot(TypeCDS)).To(BeNil())
+
+			snapshot := &ResourcesSnapshot{Version: 2}
+			p.SetLastSentSnapshot(TypeCDS, snapshot)
+			Expect(p.GetLastSentSnapshot(TypeCDS)).To(Equal(snapshot))
+			Expect(p.GetLastACKedSnapshot(TypeCDS)).To(BeNil())
+
+			p.SetLastACKedSnapshot(TypeCDS, snapshot)
+			Expect(p.GetLastACKedSnapshot(TypeCDS)).To(Equal(snapshot))
+			Expect(p.GetLastSentSnapshot(TypeLDS)).To(BeNil())
+		})
+	})
+
+	Context("test GetLastNACKedSnapshot()", func() {
+		It("returns correct values", func() {
+			p := NewProxy(certCommonName, certSerialNumber, tests.NewMockAddress("1.2.3.4"))
+			Expect(p.GetLastNACKedSnapshot(TypeRDS)).To(BeNil())
+
+			snapshot := &ResourcesSnapshot{Version: 7}
+			p.SetLastNACKedSnapshot(TypeRDS, snapshot)
+			Expect(p.GetLastNACKedSnapshot(TypeRDS)).To(Equal(snapshot))
+			Expect(p.GetLastNACKedSnapshot(TypeCDS)).To(BeNil())
+		})
+	})
+
 	Context("test StatsHeaders()", func() {
 		It("returns correct values", func() {
 			actual := proxy.StatsHeaders()
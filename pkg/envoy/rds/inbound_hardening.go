@@ -0,0 +1,37 @@
+package rds
+
+import (
+	"strings"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+// caseInsensitiveRegexFlag is the RE2 flag making a regex match case-insensitively
+const caseInsensitiveRegexFlag = "(?i)"
+
+// applyCaseInsensitivePaths makes the routes of the given route configurations match the paths of requests
+// case-insensitively. Exact and prefix paths are matched case-insensitively by Envoy, and regex paths are prefixed
+// with the case-insensitive flag.
+func applyCaseInsensitivePaths(routeConfigs ...*xds_route.RouteConfiguration) {
+	for _, routeConfig := range routeConfigs {
+		if routeConfig == nil {
+			continue
+		}
+		for _, virtualHost := range routeConfig.VirtualHosts {
+			for _, route := range virtualHost.Routes {
+				if route.Match == nil {
+					continue
+				}
+				switch pathSpecifier := route.Match.PathSpecifier.(type) {
+				case *xds_route.RouteMatch_SafeRegex:
+					if !strings.HasPrefix(pathSpecifier.SafeRegex.Regex, caseInsensitiveRegexFlag) {
+						pathSpecifier.SafeRegex.Regex = caseInsensitiveRegexFlag + pathSpecifier.SafeRegex.Regex
+					}
+				case *xds_route.RouteMatch_Path, *xds_route.RouteMatch_Prefix:
+					route.Match.CaseSensitive = &wrappers.BoolValue{Value: false}
+				}
+			}
+		}
+	}
+}
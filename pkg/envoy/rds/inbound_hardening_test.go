@@ -0,0 +1,36 @@
+package rds
+
+import (
+	"testing"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestApplyCaseInsensitivePaths(t *testing.T) {
+	assert := tassert.New(t)
+
+	routeConfig := &xds_route.RouteConfiguration{
+		VirtualHosts: []*xds_route.VirtualHost{
+			{
+				Routes: []*xds_route.Route{
+					{Match: &xds_route.RouteMatch{PathSpecifier: &xds_route.RouteMatch_SafeRegex{SafeRegex: &xds_matcher.RegexMatcher{Regex: "/books/.*"}}}},
+					{Match: &xds_route.RouteMatch{PathSpecifier: &xds_route.RouteMatch_SafeRegex{SafeRegex: &xds_matcher.RegexMatcher{Regex: "(?i)/authors"}}}},
+					{Match: &xds_route.RouteMatch{PathSpecifier: &xds_route.RouteMatch_Path{Path: "/health"}}},
+					{Match: &xds_route.RouteMatch{PathSpecifier: &xds_route.RouteMatch_Prefix{Prefix: "/api/"}}},
+				},
+			},
+		},
+	}
+
+	applyCaseInsensitivePaths(routeConfig, nil)
+
+	routes := routeConfig.VirtualHosts[0].Routes
+	assert.Equal("(?i)/books/.*", routes[0].Match.GetSafeRegex().Regex)
+	assert.Nil(routes[0].Match.CaseSensitive)
+	assert.Equal("(?i)/authors", routes[1].Match.GetSafeRegex().Regex)
+	assert.Equal(&wrappers.BoolValue{Value: false}, routes[2].Match.CaseSensitive)
+	assert.Equal(&wrappers.BoolValue{Value: false}, routes[3].Match.CaseSensitive)
+}
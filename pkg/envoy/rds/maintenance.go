@@ -0,0 +1,44 @@
+package rds
+
+import (
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getMaintenanceSpecs returns the maintenance specs of the given services in maintenance mode, as configured by
+// their UpstreamTrafficSetting, keyed by the name of the cluster of the service the inbound routes are directed to
+func getMaintenanceSpecs(cataloger catalog.MeshCataloger, services []service.MeshService) map[service.ClusterName]*policyV1alpha1.MaintenanceSpec {
+	maintenanceSpecs := make(map[service.ClusterName]*policyV1alpha1.MaintenanceSpec)
+	for _, svc := range services {
+		upstreamTrafficSetting := cataloger.GetUpstreamTrafficSetting(svc)
+		if upstreamTrafficSetting == nil || upstreamTrafficSetting.Spec.Maintenance == nil {
+			continue
+		}
+		log.Debug().Msgf("Service %s is in maintenance mode as configured by UpstreamTrafficSetting %s/%s",
+			svc, upstreamTrafficSetting.Namespace, upstreamTrafficSetting.Name)
+		maintenanceSpecs[service.ClusterName(svc.String())] = upstreamTrafficSetting.Spec.Maintenance
+	}
+	return maintenanceSpecs
+}
+
+// applyMaintenanceMode sets the maintenance response of the rules of the given inbound policies whose route is
+// directed to a service in maintenance mode
+func applyMaintenanceMode(maintenanceSpecs map[service.ClusterName]*policyV1alpha1.MaintenanceSpec, policies []*trafficpolicy.InboundTrafficPolicy) {
+	if len(maintenanceSpecs) == 0 {
+		return
+	}
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if rule.Route.WeightedClusters == nil {
+				continue
+			}
+			for clusterInterface := range rule.Route.WeightedClusters.Iter() {
+				if maintenance, ok := maintenanceSpecs[clusterInterface.(service.WeightedCluster).ClusterName]; ok {
+					rule.Route.Maintenance = maintenance
+				}
+			}
+		}
+	}
+}
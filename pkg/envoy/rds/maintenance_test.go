@@ -0,0 +1,66 @@
+package rds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyMaintenanceMode(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	maintenance := &policyV1alpha1.MaintenanceSpec{
+		RetryAfter: &v1.Duration{Duration: 2 * time.Minute},
+	}
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV1Service).Return(&policyV1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: v1.ObjectMeta{Name: "bookstore-v1", Namespace: tests.Namespace},
+		Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+			Host:        tests.BookstoreV1Service.ServerName(),
+			Maintenance: maintenance,
+		},
+	}).Times(1)
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV2Service).Return(&policyV1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: v1.ObjectMeta{Name: "bookstore-v2", Namespace: tests.Namespace},
+		Spec:       policyV1alpha1.UpstreamTrafficSettingSpec{Host: tests.BookstoreV2Service.ServerName()},
+	}).Times(1)
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookbuyerService).Return(nil).Times(1)
+
+	maintenanceSpecs := getMaintenanceSpecs(mockCatalog, []service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service, tests.BookbuyerService})
+	assert.Equal(map[service.ClusterName]*policyV1alpha1.MaintenanceSpec{
+		service.ClusterName(tests.BookstoreV1Service.String()): maintenance,
+	}, maintenanceSpecs)
+
+	newPolicy := func(svc service.MeshService) *trafficpolicy.InboundTrafficPolicy {
+		policy := trafficpolicy.NewInboundTrafficPolicy(svc.Name, []string{svc.Name})
+		policy.AddRule(*trafficpolicy.NewRouteWeightedCluster(tests.BookstoreBuyHTTPRoute, []service.WeightedCluster{{
+			ClusterName: service.ClusterName(svc.String()),
+			Weight:      100,
+		}}), tests.BookbuyerServiceIdentity)
+		policy.AddRule(*trafficpolicy.NewRouteWeightedCluster(tests.BookstoreSellHTTPRoute, []service.WeightedCluster{{
+			ClusterName: service.ClusterName(svc.String()),
+			Weight:      100,
+		}}), tests.BookbuyerServiceIdentity)
+		return policy
+	}
+	policies := []*trafficpolicy.InboundTrafficPolicy{newPolicy(tests.BookstoreV1Service), newPolicy(tests.BookstoreV2Service)}
+
+	applyMaintenanceMode(maintenanceSpecs, policies)
+	for _, rule := range policies[0].Rules {
+		assert.Equal(maintenance, rule.Route.Maintenance)
+	}
+	for _, rule := range policies[1].Rules {
+		assert.Nil(rule.Route.Maintenance)
+	}
+}
@@ -0,0 +1,55 @@
+package rds
+
+import (
+	"sort"
+
+	mapset "github.com/deckarep/golang-set"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// getInboundPortSpecificRoutePorts returns the HTTP and gRPC target ports of the given services exposing multiple
+// ports, whose inbound filter chains use the route configurations specific to their port
+func getInboundPortSpecificRoutePorts(cataloger catalog.MeshCataloger, services []service.MeshService) []int {
+	ports := mapset.NewSet()
+	for _, svc := range services {
+		portToProtocolMap, err := cataloger.GetTargetPortToProtocolMappingForService(svc)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error retrieving port to protocol mapping for service %s", svc)
+			continue
+		}
+		for _, port := range route.GetPortSpecificRoutePorts(portToProtocolMap) {
+			ports.Add(port)
+		}
+	}
+	return toSortedPorts(ports)
+}
+
+// getOutboundPortSpecificRoutePorts returns the HTTP and gRPC ports of the upstream services of the given identity
+// exposing multiple ports, whose outbound filter chains use the route configurations specific to their port
+func getOutboundPortSpecificRoutePorts(cataloger catalog.MeshCataloger, proxyIdentity identity.ServiceIdentity) []int {
+	ports := mapset.NewSet()
+	for _, upstream := range cataloger.ListMeshServicesForIdentity(proxyIdentity) {
+		portToProtocolMap, err := cataloger.GetPortToProtocolMappingForService(upstream)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error retrieving port to protocol mapping for upstream service %s", upstream)
+			continue
+		}
+		for _, port := range route.GetPortSpecificRoutePorts(portToProtocolMap) {
+			ports.Add(port)
+		}
+	}
+	return toSortedPorts(ports)
+}
+
+func toSortedPorts(ports mapset.Set) []int {
+	var sortedPorts []int
+	for port := range ports.Iter() {
+		sortedPorts = append(sortedPorts, port.(int))
+	}
+	sort.Ints(sortedPorts)
+	return sortedPorts
+}
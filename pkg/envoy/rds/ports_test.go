@@ -0,0 +1,42 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestGetPortSpecificRoutePorts(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{
+		8080: "http",
+		9090: "grpc",
+		3306: "tcp",
+	}, nil).Times(1)
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(tests.BookstoreV2Service).Return(map[uint32]string{
+		7070: "http",
+	}, nil).Times(1)
+	assert.Equal([]int{8080, 9090}, getInboundPortSpecificRoutePorts(mockCatalog, []service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service}))
+
+	mockCatalog.EXPECT().ListMeshServicesForIdentity(tests.BookbuyerServiceIdentity).Return([]service.MeshService{
+		tests.BookstoreV1Service, tests.BookstoreApexService,
+	}).Times(1)
+	mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreV1Service).Return(map[uint32]string{
+		80:   "http",
+		9090: "grpc",
+	}, nil).Times(1)
+	mockCatalog.EXPECT().GetPortToProtocolMappingForService(tests.BookstoreApexService).Return(map[uint32]string{
+		80:   "http",
+		8081: "http",
+	}, nil).Times(1)
+	assert.Equal([]int{80, 8081, 9090}, getOutboundPortSpecificRoutePorts(mockCatalog, tests.BookbuyerServiceIdentity))
+}
@@ -0,0 +1,46 @@
+package rds
+
+import (
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getHTTPRateLimitSpecs returns the HTTP local rate limits of the given services, as configured by their
+// UpstreamTrafficSetting, keyed by the name of the cluster of the service the inbound routes are directed to
+func getHTTPRateLimitSpecs(cataloger catalog.MeshCataloger, services []service.MeshService) map[service.ClusterName]*policyV1alpha1.HTTPLocalRateLimitSpec {
+	rateLimitSpecs := make(map[service.ClusterName]*policyV1alpha1.HTTPLocalRateLimitSpec)
+	for _, svc := range services {
+		upstreamTrafficSetting := cataloger.GetUpstreamTrafficSetting(svc)
+		if upstreamTrafficSetting == nil {
+			continue
+		}
+		rateLimit := upstreamTrafficSetting.Spec.RateLimit
+		if rateLimit == nil || rateLimit.Local == nil || rateLimit.Local.HTTP == nil {
+			continue
+		}
+		rateLimitSpecs[service.ClusterName(svc.String())] = rateLimit.Local.HTTP
+	}
+	return rateLimitSpecs
+}
+
+// applyHTTPRateLimits sets the rate limit of the rules of the given inbound policies whose route is directed to a
+// rate limited service
+func applyHTTPRateLimits(rateLimitSpecs map[service.ClusterName]*policyV1alpha1.HTTPLocalRateLimitSpec, policies []*trafficpolicy.InboundTrafficPolicy) {
+	if len(rateLimitSpecs) == 0 {
+		return
+	}
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if rule.Route.WeightedClusters == nil {
+				continue
+			}
+			for clusterInterface := range rule.Route.WeightedClusters.Iter() {
+				if rateLimit, ok := rateLimitSpecs[clusterInterface.(service.WeightedCluster).ClusterName]; ok {
+					rule.Route.RateLimit = rateLimit
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyHTTPRateLimits(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	rateLimit := &policyV1alpha1.HTTPLocalRateLimitSpec{
+		Requests: 10,
+		Unit:     "second",
+		Sources:  []policyV1alpha1.HTTPSourceLocalRateLimitSpec{{Name: "bookbuyer", Namespace: tests.Namespace, Requests: 100}},
+	}
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV1Service).Return(&policyV1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: v1.ObjectMeta{Name: "bookstore-v1", Namespace: tests.Namespace},
+		Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+			Host:      tests.BookstoreV1Service.ServerName(),
+			RateLimit: &policyV1alpha1.RateLimitSpec{Local: &policyV1alpha1.LocalRateLimitSpec{HTTP: rateLimit}},
+		},
+	}).Times(1)
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookstoreV2Service).Return(&policyV1alpha1.UpstreamTrafficSetting{
+		ObjectMeta: v1.ObjectMeta{Name: "bookstore-v2", Namespace: tests.Namespace},
+		Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+			Host:      tests.BookstoreV2Service.ServerName(),
+			RateLimit: &policyV1alpha1.RateLimitSpec{Local: &policyV1alpha1.LocalRateLimitSpec{}},
+		},
+	}).Times(1)
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(tests.BookbuyerService).Return(nil).Times(1)
+
+	rateLimitSpecs := getHTTPRateLimitSpecs(mockCatalog, []service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service, tests.BookbuyerService})
+	assert.Equal(map[service.ClusterName]*policyV1alpha1.HTTPLocalRateLimitSpec{
+		service.ClusterName(tests.BookstoreV1Service.String()): rateLimit,
+	}, rateLimitSpecs)
+
+	newPolicy := func(svc service.MeshService) *trafficpolicy.InboundTrafficPolicy {
+		policy := trafficpolicy.NewInboundTrafficPolicy(svc.Name, []string{svc.Name})
+		policy.AddRule(*trafficpolicy.NewRouteWeightedCluster(tests.BookstoreBuyHTTPRoute, []service.WeightedCluster{{
+			ClusterName: service.ClusterName(svc.String()),
+			Weight:      100,
+		}}), tests.BookbuyerServiceIdentity)
+		return policy
+	}
+	policies := []*trafficpolicy.InboundTrafficPolicy{newPolicy(tests.BookstoreV1Service), newPolicy(tests.BookstoreV2Service)}
+
+	applyHTTPRateLimits(rateLimitSpecs, policies)
+	assert.Equal(rateLimit, policies[0].Rules[0].Route.RateLimit)
+	assert.Nil(policies[1].Rules[0].Route.RateLimit)
+}
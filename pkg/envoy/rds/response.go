@@ -1,6 +1,8 @@
 package rds
 
 import (
+	"sort"
+
 	mapset "github.com/deckarep/golang-set"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
@@ -20,6 +22,20 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 	var outboundTrafficPolicies []*trafficpolicy.OutboundTrafficPolicy
 	var ingressTrafficPolicies []*trafficpolicy.InboundTrafficPolicy
 
+	if proxy.GetKind() == envoy.KindIngressGateway {
+		// The ingress gateway only routes the traffic of the ingress resources it serves to their upstream services
+		gatewayPolicies, err := cataloger.GetIngressGatewayPolicies()
+		if err != nil {
+			log.Error().Err(err).Msgf("Error looking up ingress gateway policies for Envoy with serial number=%q", proxy.GetCertificateSerialNumber())
+			return nil, err
+		}
+		gatewayRouteConfig := route.BuildIngressGatewayRouteConfiguration(gatewayPolicies)
+		if cfg.GetInboundHardeningSettings().CaseInsensitivePaths {
+			applyCaseInsensitivePaths(gatewayRouteConfig)
+		}
+		return []types.Resource{gatewayRouteConfig}, nil
+	}
+
 	proxyIdentity, err := catalog.GetServiceAccountFromProxyCertificate(proxy.GetCertificateCommonName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error looking up Service Account for Envoy with serial number=%q", proxy.GetCertificateSerialNumber())
@@ -37,10 +53,43 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 	inboundTrafficPolicies = cataloger.ListInboundTrafficPolicies(proxyIdentity.ToServiceIdentity(), services)
 	outboundTrafficPolicies = cataloger.ListOutboundTrafficPolicies(proxyIdentity.ToServiceIdentity())
 
+	// Respond to the requests directed to the services in maintenance mode instead of forwarding them
+	maintenanceSpecs := getMaintenanceSpecs(cataloger, services)
+	applyMaintenanceMode(maintenanceSpecs, inboundTrafficPolicies)
+
+	// Rate limit the requests directed to the services depending on the identity of the clients sending them, unless
+	// the rate limit filter is withheld from the listeners of the proxy
+	if rateLimitSpecs := getHTTPRateLimitSpecs(cataloger, services); len(rateLimitSpecs) > 0 && !envoy.IsFeatureWithheld(proxy, cfg, envoy.FeatureHTTPLocalRateLimit) {
+		applyHTTPRateLimits(rateLimitSpecs, inboundTrafficPolicies)
+	}
+
+	// Replace the x-forwarded-for header of the requests with the address of the client when configured mesh-wide
+	applyXFFOverwrite(getXFFOverwriteClusters(cfg, services), inboundTrafficPolicies)
+
+	// Match the paths of the inbound and ingress requests case-insensitively when configured mesh-wide
+	caseInsensitivePaths := cfg.GetInboundHardeningSettings().CaseInsensitivePaths
+
 	routeConfiguration := route.BuildRouteConfiguration(inboundTrafficPolicies, outboundTrafficPolicies, proxy)
 	var rdsResources []types.Resource
 
 	for _, config := range routeConfiguration {
+		if caseInsensitivePaths && config.Name == route.InboundRouteConfigName {
+			applyCaseInsensitivePaths(config)
+		}
+		rdsResources = append(rdsResources, config)
+	}
+
+	// Build the route configurations of the filter chains of the ports of services exposing multiple ports, which only
+	// forward the requests to the endpoints serving the port
+	inboundPorts := getInboundPortSpecificRoutePorts(cataloger, services)
+	for _, config := range route.BuildInboundRouteConfigurationForPorts(inboundTrafficPolicies, inboundPorts, proxy) {
+		if caseInsensitivePaths {
+			applyCaseInsensitivePaths(config)
+		}
+		rdsResources = append(rdsResources, config)
+	}
+	outboundPorts := getOutboundPortSpecificRoutePorts(cataloger, proxyIdentity.ToServiceIdentity())
+	for _, config := range route.BuildOutboundRouteConfigurationForPorts(outboundTrafficPolicies, outboundPorts) {
 		rdsResources = append(rdsResources, config)
 	}
 
@@ -53,8 +102,13 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 		}
 		ingressTrafficPolicies = trafficpolicy.MergeInboundPolicies(catalog.AllowPartialHostnamesMatch, ingressTrafficPolicies, ingressInboundPolicies...)
 	}
+	applyMaintenanceMode(maintenanceSpecs, ingressTrafficPolicies)
+	applyXFFOverwrite(getIngressXFFOverwriteClusters(cataloger, services), ingressTrafficPolicies)
 	if len(ingressTrafficPolicies) > 0 {
 		ingressRouteConfig := route.BuildIngressConfiguration(ingressTrafficPolicies, proxy)
+		if caseInsensitivePaths {
+			applyCaseInsensitivePaths(ingressRouteConfig)
+		}
 		rdsResources = append(rdsResources, ingressRouteConfig)
 	}
 
@@ -64,7 +118,14 @@ func NewResponse(cataloger catalog.MeshCataloger, proxy *envoy.Proxy, discoveryR
 		log.Error().Err(err).Msgf("Error retrieving egress traffic policies for proxy with identity %s, skipping egress route configuration", proxyIdentity)
 	}
 	if egressTrafficPolicy != nil {
-		egressRouteConfigs := route.BuildEgressRouteConfiguration(egressTrafficPolicy.HTTPRouteConfigsPerPort)
+		// The requests denied by Egress policies are counted by an HTTP local rate limit, unless the filter is withheld
+		// from the listeners of the proxy
+		countDenied := !envoy.IsFeatureWithheld(proxy, cfg, envoy.FeatureHTTPLocalRateLimit)
+		egressRouteConfigs, err := route.BuildEgressRouteConfiguration(proxyIdentity.ToServiceIdentity(), egressTrafficPolicy.HTTPRouteConfigsPerPort, countDenied)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error building egress route configurations for proxy with identity %s", proxyIdentity)
+			return nil, err
+		}
 		for _, egressConfig := range egressRouteConfigs {
 			rdsResources = append(rdsResources, egressConfig)
 		}
@@ -96,8 +157,12 @@ func ensureRDSRequestCompletion(discoveryReq *xds_discovery.DiscoveryRequest, rd
 	// If there were any requested elements we didn't reply to, create empty RDS resources
 	// for those now
 	requestDifference := requestMapset.Difference(responseMapset)
+	var unfulfilledRequestedResources []string
 	for reqDif := range requestDifference.Iterator().C {
-		unfulfilledRequestedResource := reqDif.(string)
+		unfulfilledRequestedResources = append(unfulfilledRequestedResources, reqDif.(string))
+	}
+	sort.Strings(unfulfilledRequestedResources)
+	for _, unfulfilledRequestedResource := range unfulfilledRequestedResources {
 		rdsResources = append(rdsResources, route.NewRouteConfigurationStub(unfulfilledRequestedResource))
 	}
 
@@ -116,7 +116,7 @@ func TestNewResponse(t *testing.T) {
 								},
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceIdentity),
 						},
 					},
 				},
@@ -133,7 +133,7 @@ func TestNewResponse(t *testing.T) {
 								},
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceAccount),
+							AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceIdentity),
 						},
 					},
 				},
@@ -165,7 +165,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -178,7 +178,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -208,7 +208,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}),
+							}.ToServiceIdentity()),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
@@ -221,7 +221,7 @@ func TestNewResponse(t *testing.T) {
 							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{
 								Name:      tests.BookbuyerServiceAccountName,
 								Namespace: tests.Namespace,
-							}),
+							}.ToServiceIdentity()),
 						},
 					},
 				},
@@ -275,7 +275,13 @@ func TestNewResponse(t *testing.T) {
 			mockCatalog.EXPECT().ListInboundTrafficPolicies(gomock.Any(), gomock.Any()).Return(tc.expectedInboundPolicies).AnyTimes()
 			mockCatalog.EXPECT().ListOutboundTrafficPolicies(gomock.Any()).Return(tc.expectedOutboundPolicies).AnyTimes()
 			mockCatalog.EXPECT().GetIngressPoliciesForService(gomock.Any()).Return(tc.ingressInboundPolicies, nil).AnyTimes()
+			mockCatalog.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+			mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+			mockCatalog.EXPECT().GetIngressBackendXFFSettings(gomock.Any()).Return(configurator.XFFSettings{}).AnyTimes()
 			mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(nil, nil).AnyTimes()
+			mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(gomock.Any()).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+			mockCatalog.EXPECT().ListMeshServicesForIdentity(gomock.Any()).Return(nil).AnyTimes()
 
 			// Empty discovery request
 			discoveryRequest := xds_discovery.DiscoveryRequest{
@@ -297,23 +303,13 @@ func TestNewResponse(t *testing.T) {
 			assert.True(ok)
 
 			// The rds-inbound will have the following virtual hosts :
-			// inbound_virtual-host|bookstore-v1.default
 			// inbound_virtual-host|bookstore-apex
+			// inbound_virtual-host|bookstore-v1.default
 			assert.Equal("rds-inbound", routeConfig.Name)
 			assert.Equal(2, len(routeConfig.VirtualHosts))
 
-			assert.Equal("inbound_virtual-host|bookstore-v1.default", routeConfig.VirtualHosts[0].Name)
-			assert.Equal(tests.BookstoreV1Hostnames, routeConfig.VirtualHosts[0].Domains)
-			assert.Equal(2, len(routeConfig.VirtualHosts[0].Routes))
-			assert.Equal(tests.BookstoreBuyHTTPRoute.Path, routeConfig.VirtualHosts[0].Routes[0].GetMatch().GetSafeRegex().Regex)
-			assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().Clusters))
-			assert.Equal(routeConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().TotalWeight, &wrappers.UInt32Value{Value: uint32(100)})
-			assert.Equal(tests.BookstoreSellHTTPRoute.Path, routeConfig.VirtualHosts[0].Routes[1].GetMatch().GetSafeRegex().Regex)
-			assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes[1].GetRoute().GetWeightedClusters().Clusters))
-			assert.Equal(routeConfig.VirtualHosts[0].Routes[1].GetRoute().GetWeightedClusters().TotalWeight, &wrappers.UInt32Value{Value: uint32(100)})
-
-			assert.Equal("inbound_virtual-host|bookstore-apex", routeConfig.VirtualHosts[1].Name)
-			assert.Equal(tests.BookstoreApexHostnames, routeConfig.VirtualHosts[1].Domains)
+			assert.Equal("inbound_virtual-host|bookstore-v1.default", routeConfig.VirtualHosts[1].Name)
+			assert.ElementsMatch(tests.BookstoreV1Hostnames, routeConfig.VirtualHosts[1].Domains)
 			assert.Equal(2, len(routeConfig.VirtualHosts[1].Routes))
 			assert.Equal(tests.BookstoreBuyHTTPRoute.Path, routeConfig.VirtualHosts[1].Routes[0].GetMatch().GetSafeRegex().Regex)
 			assert.Equal(1, len(routeConfig.VirtualHosts[1].Routes[0].GetRoute().GetWeightedClusters().Clusters))
@@ -322,6 +318,16 @@ func TestNewResponse(t *testing.T) {
 			assert.Equal(1, len(routeConfig.VirtualHosts[1].Routes[1].GetRoute().GetWeightedClusters().Clusters))
 			assert.Equal(routeConfig.VirtualHosts[1].Routes[1].GetRoute().GetWeightedClusters().TotalWeight, &wrappers.UInt32Value{Value: uint32(100)})
 
+			assert.Equal("inbound_virtual-host|bookstore-apex", routeConfig.VirtualHosts[0].Name)
+			assert.ElementsMatch(tests.BookstoreApexHostnames, routeConfig.VirtualHosts[0].Domains)
+			assert.Equal(2, len(routeConfig.VirtualHosts[0].Routes))
+			assert.Equal(tests.BookstoreBuyHTTPRoute.Path, routeConfig.VirtualHosts[0].Routes[0].GetMatch().GetSafeRegex().Regex)
+			assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().Clusters))
+			assert.Equal(routeConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().TotalWeight, &wrappers.UInt32Value{Value: uint32(100)})
+			assert.Equal(tests.BookstoreSellHTTPRoute.Path, routeConfig.VirtualHosts[0].Routes[1].GetMatch().GetSafeRegex().Regex)
+			assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes[1].GetRoute().GetWeightedClusters().Clusters))
+			assert.Equal(routeConfig.VirtualHosts[0].Routes[1].GetRoute().GetWeightedClusters().TotalWeight, &wrappers.UInt32Value{Value: uint32(100)})
+
 			// Check the outbound route configuration
 			routeConfig, ok = resources[1].(*xds_route.RouteConfiguration)
 			assert.True(ok)
@@ -332,7 +338,7 @@ func TestNewResponse(t *testing.T) {
 			assert.Equal(1, len(routeConfig.VirtualHosts))
 
 			assert.Equal("outbound_virtual-host|bookstore-apex", routeConfig.VirtualHosts[0].Name)
-			assert.Equal(tests.BookstoreApexHostnames, routeConfig.VirtualHosts[0].Domains)
+			assert.ElementsMatch(tests.BookstoreApexHostnames, routeConfig.VirtualHosts[0].Domains)
 			assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes))
 			assert.Equal(tests.WildCardRouteMatch.Path, routeConfig.VirtualHosts[0].Routes[0].GetMatch().GetSafeRegex().Regex)
 			assert.Equal(2, len(routeConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().Clusters))
@@ -447,7 +453,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 						},
 						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 					},
-					AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceAccount),
+					AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceIdentity),
 				},
 			},
 		},
@@ -493,7 +499,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 						},
 						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 					},
-					AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceAccount),
+					AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceIdentity),
 				},
 			},
 		},
@@ -510,7 +516,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 						},
 						WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 					},
-					AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceAccount),
+					AllowedServiceAccounts: mapset.NewSet(tests.BookstoreServiceIdentity),
 				},
 			},
 		},
@@ -520,7 +526,13 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 	mockCatalog.EXPECT().ListInboundTrafficPolicies(gomock.Any(), gomock.Any()).Return(testPermissiveInbound).AnyTimes()
 	mockCatalog.EXPECT().ListOutboundTrafficPolicies(gomock.Any()).Return(testPermissiveOutbound).AnyTimes()
 	mockCatalog.EXPECT().GetIngressPoliciesForService(gomock.Any()).Return(testIngressInbound, nil).AnyTimes()
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockCatalog.EXPECT().GetIngressBackendXFFSettings(gomock.Any()).Return(configurator.XFFSettings{}).AnyTimes()
 	mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(gomock.Any()).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+	mockCatalog.EXPECT().ListMeshServicesForIdentity(gomock.Any()).Return(nil).AnyTimes()
 
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).AnyTimes()
 
@@ -535,7 +547,7 @@ func TestNewResponseWithPermissiveMode(t *testing.T) {
 	assert.Equal(1, len(routeConfig.VirtualHosts))
 
 	assert.Equal("inbound_virtual-host|bookstore-v1.default", routeConfig.VirtualHosts[0].Name)
-	assert.Equal(tests.BookstoreV1Hostnames, routeConfig.VirtualHosts[0].Domains)
+	assert.ElementsMatch(tests.BookstoreV1Hostnames, routeConfig.VirtualHosts[0].Domains)
 	assert.Equal(1, len(routeConfig.VirtualHosts[0].Routes))
 	assert.Equal(constants.RegexMatchAll, routeConfig.VirtualHosts[0].Routes[0].GetMatch().GetSafeRegex().Regex)
 
@@ -582,7 +594,13 @@ func TestResponseRequestCompletion(t *testing.T) {
 	mockCatalog.EXPECT().ListInboundTrafficPolicies(gomock.Any(), gomock.Any()).Return([]*trafficpolicy.InboundTrafficPolicy{}).AnyTimes()
 	mockCatalog.EXPECT().ListOutboundTrafficPolicies(gomock.Any()).Return([]*trafficpolicy.OutboundTrafficPolicy{}).AnyTimes()
 	mockCatalog.EXPECT().GetIngressPoliciesForService(gomock.Any()).Return([]*trafficpolicy.InboundTrafficPolicy{}, nil).AnyTimes()
+	mockCatalog.EXPECT().GetUpstreamTrafficSetting(gomock.Any()).Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{}).AnyTimes()
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{}).AnyTimes()
+	mockCatalog.EXPECT().GetIngressBackendXFFSettings(gomock.Any()).Return(configurator.XFFSettings{}).AnyTimes()
 	mockCatalog.EXPECT().GetEgressTrafficPolicy(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockCatalog.EXPECT().GetTargetPortToProtocolMappingForService(gomock.Any()).Return(map[uint32]string{80: "http"}, nil).AnyTimes()
+	mockCatalog.EXPECT().ListMeshServicesForIdentity(gomock.Any()).Return(nil).AnyTimes()
 	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
 
 	testCases := []struct {
@@ -629,3 +647,46 @@ func TestResponseRequestCompletion(t *testing.T) {
 		}
 	}
 }
+
+func TestNewResponseForIngressGateway(t *testing.T) {
+	assert := tassert.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	certCommonName := certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), constants.OSMIngressGatewayName, "osm-system"))
+	testProxy := envoy.NewProxy(certCommonName, certificate.SerialNumber("123456"), nil)
+	testProxy.SetKind(envoy.KindIngressGateway)
+
+	gatewayPolicies := []*trafficpolicy.OutboundTrafficPolicy{
+		{
+			Name:      "ingress-1.default|foo.com",
+			Hostnames: []string{"foo.com"},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+			},
+		},
+	}
+	// The ingress gateway is not fronting any pod, so none of the sidecar specific lookups are expected
+	mockCatalog.EXPECT().GetIngressGatewayPolicies().Return(gatewayPolicies, nil).Times(1)
+	mockConfigurator.EXPECT().GetInboundHardeningSettings().Return(configurator.InboundHardeningSettings{CaseInsensitivePaths: true}).Times(1)
+
+	resources, err := NewResponse(mockCatalog, testProxy, nil, mockConfigurator, nil)
+	assert.Nil(err)
+	assert.Len(resources, 1)
+
+	routeConfig, ok := resources[0].(*xds_route.RouteConfiguration)
+	assert.True(ok)
+	assert.Equal("rds-ingress-gateway", routeConfig.Name)
+	assert.Len(routeConfig.VirtualHosts, 1)
+	assert.Equal([]string{"foo.com"}, routeConfig.VirtualHosts[0].Domains)
+
+	// The paths of the requests from clients outside the mesh are matched case-insensitively
+	assert.Len(routeConfig.VirtualHosts[0].Routes, 1)
+	assert.Equal("(?i)"+constants.RegexMatchAll, routeConfig.VirtualHosts[0].Routes[0].Match.GetSafeRegex().Regex)
+}
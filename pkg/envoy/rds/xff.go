@@ -0,0 +1,53 @@
+package rds
+
+import (
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getXFFOverwriteClusters returns the names of the clusters of the given services whose inbound routes overwrite the
+// x-forwarded-for header of the requests received from the mesh, as configured mesh-wide
+func getXFFOverwriteClusters(cfg configurator.Configurator, services []service.MeshService) map[service.ClusterName]bool {
+	if cfg.GetXFFSettings().HeaderMode != configurator.XFFHeaderModeOverwrite {
+		return nil
+	}
+	clusters := make(map[service.ClusterName]bool)
+	for _, svc := range services {
+		clusters[service.ClusterName(svc.String())] = true
+	}
+	return clusters
+}
+
+// getIngressXFFOverwriteClusters returns the names of the clusters of the given services whose ingress routes overwrite
+// the x-forwarded-for header of the requests received from the ingress, as configured for the ingress backends
+func getIngressXFFOverwriteClusters(cataloger catalog.MeshCataloger, services []service.MeshService) map[service.ClusterName]bool {
+	clusters := make(map[service.ClusterName]bool)
+	for _, svc := range services {
+		if cataloger.GetIngressBackendXFFSettings(svc).HeaderMode == configurator.XFFHeaderModeOverwrite {
+			clusters[service.ClusterName(svc.String())] = true
+		}
+	}
+	return clusters
+}
+
+// applyXFFOverwrite makes the rules of the given policies whose route is directed to one of the given clusters
+// overwrite the x-forwarded-for header of the requests
+func applyXFFOverwrite(clusters map[service.ClusterName]bool, policies []*trafficpolicy.InboundTrafficPolicy) {
+	if len(clusters) == 0 {
+		return
+	}
+	for _, policy := range policies {
+		for _, rule := range policy.Rules {
+			if rule.Route.WeightedClusters == nil {
+				continue
+			}
+			for clusterInterface := range rule.Route.WeightedClusters.Iter() {
+				if clusters[clusterInterface.(service.WeightedCluster).ClusterName] {
+					rule.Route.XFFOverwrite = true
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyXFFOverwrite(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	services := []service.MeshService{tests.BookstoreV1Service, tests.BookstoreV2Service}
+
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModeAppend}).Times(1)
+	assert.Nil(getXFFOverwriteClusters(mockConfigurator, services))
+
+	mockConfigurator.EXPECT().GetXFFSettings().Return(configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModeOverwrite}).Times(1)
+	assert.Equal(map[service.ClusterName]bool{
+		service.ClusterName(tests.BookstoreV1Service.String()): true,
+		service.ClusterName(tests.BookstoreV2Service.String()): true,
+	}, getXFFOverwriteClusters(mockConfigurator, services))
+
+	mockCatalog.EXPECT().GetIngressBackendXFFSettings(tests.BookstoreV1Service).Return(configurator.XFFSettings{HeaderMode: configurator.XFFHeaderModeOverwrite}).Times(1)
+	mockCatalog.EXPECT().GetIngressBackendXFFSettings(tests.BookstoreV2Service).Return(configurator.XFFSettings{}).Times(1)
+	clusters := getIngressXFFOverwriteClusters(mockCatalog, services)
+	assert.Equal(map[service.ClusterName]bool{
+		service.ClusterName(tests.BookstoreV1Service.String()): true,
+	}, clusters)
+
+	newPolicy := func(svc service.MeshService) *trafficpolicy.InboundTrafficPolicy {
+		policy := trafficpolicy.NewInboundTrafficPolicy(svc.Name, []string{svc.Name})
+		policy.AddRule(*trafficpolicy.NewRouteWeightedCluster(tests.BookstoreBuyHTTPRoute, []service.WeightedCluster{{
+			ClusterName: service.ClusterName(svc.String()),
+			Weight:      100,
+		}}), tests.BookbuyerServiceIdentity)
+		return policy
+	}
+	policies := []*trafficpolicy.InboundTrafficPolicy{newPolicy(tests.BookstoreV1Service), newPolicy(tests.BookstoreV2Service)}
+
+	applyXFFOverwrite(clusters, policies)
+	assert.True(policies[0].Rules[0].Route.XFFOverwrite)
+	assert.False(policies[1].Rules[0].Route.XFFOverwrite)
+}
@@ -0,0 +1,43 @@
+package route
+
+import (
+	"strings"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// buildGRPCRoute returns a route matching the gRPC requests to the service or method of the given route,
+// retrying them according to its retry policy
+func buildGRPCRoute(grpcRoute *trafficpolicy.RouteWeightedClusters, direction Direction) *xds_route.Route {
+	route := buildRoute(grpcRoute.HTTPRouteMatch.PathMatchType, grpcRoute.HTTPRouteMatch.Path, constants.WildcardHTTPMethod, grpcRoute.HTTPRouteMatch.Headers,
+		grpcRoute.WeightedClusters, grpcRoute.TotalClustersWeight(), direction)
+
+	// Only match requests with the 'application/grpc' content type
+	route.Match.Grpc = &xds_route.RouteMatch_GrpcRouteMatchOptions{}
+
+	if grpcRoute.GRPCRetryPolicy != nil {
+		route.GetRoute().RetryPolicy = buildGRPCRetryPolicy(grpcRoute.GRPCRetryPolicy)
+	}
+
+	return route
+}
+
+// buildGRPCRetryPolicy returns the Envoy retry policy retrying requests on the gRPC status codes of the given policy
+func buildGRPCRetryPolicy(retry *policyV1alpha1.GRPCRetryPolicySpec) *xds_route.RetryPolicy {
+	retryPolicy := &xds_route.RetryPolicy{
+		RetryOn: strings.Join(retry.RetryOn, ","),
+	}
+	if retry.NumRetries != nil {
+		retryPolicy.NumRetries = &wrappers.UInt32Value{Value: *retry.NumRetries}
+	}
+	if retry.PerTryTimeout != nil {
+		retryPolicy.PerTryTimeout = ptypes.DurationProto(retry.PerTryTimeout.Duration)
+	}
+	return retryPolicy
+}
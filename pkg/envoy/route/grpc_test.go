@@ -0,0 +1,70 @@
+package route
+
+import (
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildOutboundGRPCRoutes(t *testing.T) {
+	assert := tassert.New(t)
+
+	numRetries := uint32(2)
+	weightedClusters := mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-v1", Weight: 100})
+	input := []*trafficpolicy.RouteWeightedClusters{
+		{
+			HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+			WeightedClusters: weightedClusters,
+		},
+		{
+			HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/bookstore.v1.Bookstore/Buy",
+				PathMatchType: trafficpolicy.PathMatchExact,
+				Methods:       []string{constants.WildcardHTTPMethod},
+				GRPC:          true,
+			},
+			WeightedClusters: weightedClusters,
+			GRPCRetryPolicy: &policyV1alpha1.GRPCRetryPolicySpec{
+				RetryOn:       []string{"unavailable", "resource-exhausted"},
+				NumRetries:    &numRetries,
+				PerTryTimeout: &metav1.Duration{Duration: 500 * time.Millisecond},
+			},
+		},
+		{
+			HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/bookstore.v1.Inventory/",
+				PathMatchType: trafficpolicy.PathMatchPrefix,
+				Methods:       []string{constants.WildcardHTTPMethod},
+				GRPC:          true,
+			},
+			WeightedClusters: weightedClusters,
+		},
+	}
+
+	actual := buildOutboundRoutes(input)
+	assert.Len(actual, 3)
+
+	// The gRPC routes precede the wildcard route
+	assert.Equal("/bookstore.v1.Bookstore/Buy", actual[0].GetMatch().GetPath())
+	assert.NotNil(actual[0].GetMatch().GetGrpc())
+	retryPolicy := actual[0].GetRoute().GetRetryPolicy()
+	assert.Equal("unavailable,resource-exhausted", retryPolicy.RetryOn)
+	assert.Equal(uint32(2), retryPolicy.NumRetries.GetValue())
+	assert.Equal(500*time.Millisecond, retryPolicy.PerTryTimeout.AsDuration())
+
+	assert.Equal("/bookstore.v1.Inventory/", actual[1].GetMatch().GetPrefix())
+	assert.NotNil(actual[1].GetMatch().GetGrpc())
+	assert.Nil(actual[1].GetRoute().GetRetryPolicy())
+
+	assert.Equal(constants.RegexMatchAll, actual[2].GetMatch().GetSafeRegex().Regex)
+	assert.Nil(actual[2].GetMatch().GetGrpc())
+	assert.Equal("default/bookstore-v1", actual[2].GetRoute().GetWeightedClusters().Clusters[0].Name)
+}
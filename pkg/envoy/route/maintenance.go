@@ -0,0 +1,55 @@
+package route
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	// defaultMaintenanceStatusCode is the status code of the responses returned by a service in maintenance mode
+	defaultMaintenanceStatusCode = http.StatusServiceUnavailable
+
+	// retryAfterHeader is the header advising clients of the delay after which they can retry their requests
+	retryAfterHeader = "Retry-After"
+)
+
+// applyMaintenanceResponse makes the given route respond to the requests matching it with the response configured by
+// the given maintenance spec instead of forwarding them to the service. The other settings of the route, such as its
+// RBAC policy, remain in effect.
+func applyMaintenanceResponse(route *xds_route.Route, maintenance *policyV1alpha1.MaintenanceSpec) {
+	statusCode := uint32(defaultMaintenanceStatusCode)
+	if maintenance.StatusCode != nil {
+		if *maintenance.StatusCode >= 200 && *maintenance.StatusCode < 600 {
+			statusCode = *maintenance.StatusCode
+		} else {
+			log.Error().Msgf("Invalid maintenance status code %d, using %d instead", *maintenance.StatusCode, defaultMaintenanceStatusCode)
+		}
+	}
+
+	directResponse := &xds_route.DirectResponseAction{Status: statusCode}
+	if maintenance.Body != "" {
+		directResponse.Body = &core.DataSource{
+			Specifier: &core.DataSource_InlineString{InlineString: maintenance.Body},
+		}
+	}
+	route.Action = &xds_route.Route_DirectResponse{DirectResponse: directResponse}
+
+	if maintenance.RetryAfter != nil {
+		// Retry-After is expressed in whole seconds, rounded up so clients never retry too early
+		seconds := int64(math.Ceil(maintenance.RetryAfter.Seconds()))
+		route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, &core.HeaderValueOption{
+			Header: &core.HeaderValue{
+				Key:   retryAfterHeader,
+				Value: strconv.FormatInt(seconds, 10),
+			},
+			Append: &wrappers.BoolValue{Value: false},
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package route
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestApplyMaintenanceResponse(t *testing.T) {
+	validStatusCode := uint32(http.StatusBadGateway)
+	invalidStatusCode := uint32(1000)
+
+	testCases := []struct {
+		name                    string
+		maintenance             *policyV1alpha1.MaintenanceSpec
+		expectedDirectResponse  *xds_route.DirectResponseAction
+		expectedResponseHeaders []*core.HeaderValueOption
+	}{
+		{
+			name:                   "default response",
+			maintenance:            &policyV1alpha1.MaintenanceSpec{},
+			expectedDirectResponse: &xds_route.DirectResponseAction{Status: http.StatusServiceUnavailable},
+		},
+		{
+			name: "custom response with Retry-After rounded up to the second",
+			maintenance: &policyV1alpha1.MaintenanceSpec{
+				StatusCode: &validStatusCode,
+				RetryAfter: &metav1.Duration{Duration: 90*time.Second + 500*time.Millisecond},
+				Body:       "migration in progress",
+			},
+			expectedDirectResponse: &xds_route.DirectResponseAction{
+				Status: http.StatusBadGateway,
+				Body:   &core.DataSource{Specifier: &core.DataSource_InlineString{InlineString: "migration in progress"}},
+			},
+			expectedResponseHeaders: []*core.HeaderValueOption{
+				{
+					Header: &core.HeaderValue{Key: "Retry-After", Value: "91"},
+					Append: &wrappers.BoolValue{Value: false},
+				},
+			},
+		},
+		{
+			name: "invalid status code",
+			maintenance: &policyV1alpha1.MaintenanceSpec{
+				StatusCode: &invalidStatusCode,
+			},
+			expectedDirectResponse: &xds_route.DirectResponseAction{Status: http.StatusServiceUnavailable},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			rule := &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-v1", Weight: 100}),
+					Maintenance:      tc.maintenance,
+				},
+				AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{Namespace: "default", Name: "bookbuyer"}.ToServiceIdentity()),
+			}
+
+			routes := buildInboundRoutes([]*trafficpolicy.Rule{rule})
+			assert.Len(routes, 1)
+			assert.Nil(routes[0].GetRoute())
+			assert.Equal(tc.expectedDirectResponse, routes[0].GetDirectResponse())
+			assert.Equal(tc.expectedResponseHeaders, routes[0].ResponseHeadersToAdd)
+
+			// The RBAC policy of the route still applies to the requests
+			assert.NotEmpty(routes[0].TypedPerFilterConfig)
+		})
+	}
+}
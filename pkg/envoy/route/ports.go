@@ -0,0 +1,119 @@
+package route
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// BuildInboundRouteConfigurationForPorts constructs the inbound route configurations used by the filter chains of the
+// given target ports of services exposing multiple ports. The routes of a configuration only forward requests to the
+// local endpoint of its port, and exclude the rules restricted to other ports.
+func BuildInboundRouteConfigurationForPorts(inbound []*trafficpolicy.InboundTrafficPolicy, ports []int, proxy *envoy.Proxy) []*xds_route.RouteConfiguration {
+	var routeConfigs []*xds_route.RouteConfiguration
+	for _, port := range ports {
+		routeConfig := NewRouteConfigurationStub(GetInboundRouteConfigNameForPort(port))
+		for _, in := range inbound {
+			rules := getRulesForPort(in.Rules, port)
+			if len(rules) == 0 {
+				continue
+			}
+			virtualHost := buildVirtualHostStub(inboundVirtualHost, in.Name, in.Hostnames)
+			virtualHost.Routes = buildInboundRoutes(rules)
+			setPortMetadataMatch(virtualHost.Routes, envoy.TargetPortSubsetKey, port)
+			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
+		}
+
+		if featureflags.IsWASMStatsEnabled() {
+			routeConfig.ResponseHeadersToAdd = buildStatsHeaders(proxy)
+		}
+		sortVirtualHosts(routeConfig)
+		routeConfigs = append(routeConfigs, routeConfig)
+	}
+	return routeConfigs
+}
+
+// BuildOutboundRouteConfigurationForPorts constructs the outbound route configurations used by the filter chains of the
+// given ports of upstream services exposing multiple ports. The routes of a configuration only forward requests to the
+// upstream endpoints serving its port.
+func BuildOutboundRouteConfigurationForPorts(outbound []*trafficpolicy.OutboundTrafficPolicy, ports []int) []*xds_route.RouteConfiguration {
+	var routeConfigs []*xds_route.RouteConfiguration
+	for _, port := range ports {
+		routeConfig := NewRouteConfigurationStub(GetOutboundRouteConfigNameForPort(port))
+		for _, out := range outbound {
+			virtualHost := buildVirtualHostStub(outboundVirtualHost, out.Name, out.Hostnames)
+			virtualHost.Routes = buildOutboundRoutes(out.Routes)
+			setPortMetadataMatch(virtualHost.Routes, envoy.ServicePortSubsetKey, port)
+			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
+		}
+		sortVirtualHosts(routeConfig)
+		routeConfigs = append(routeConfigs, routeConfig)
+	}
+	return routeConfigs
+}
+
+// getRulesForPort returns the given rules that are not restricted to ports, or are restricted to the given port
+func getRulesForPort(rules []*trafficpolicy.Rule, port int) []*trafficpolicy.Rule {
+	var rulesForPort []*trafficpolicy.Rule
+	for _, rule := range rules {
+		if len(rule.Route.HTTPRouteMatch.Ports) == 0 {
+			rulesForPort = append(rulesForPort, rule)
+			continue
+		}
+		for _, rulePort := range rule.Route.HTTPRouteMatch.Ports {
+			if rulePort == port {
+				rulesForPort = append(rulesForPort, rule)
+				break
+			}
+		}
+	}
+	return rulesForPort
+}
+
+// setPortMetadataMatch restricts the given routes forwarding requests to the endpoints labeled with the given port
+// under the given key
+func setPortMetadataMatch(routes []*xds_route.Route, key string, port int) {
+	for _, route := range routes {
+		if routeAction := route.GetRoute(); routeAction != nil {
+			routeAction.MetadataMatch = envoy.GetPortMetadataMatch(key, uint32(port))
+		}
+	}
+}
+
+// GetPortSpecificRoutePorts returns the HTTP and gRPC ports in the given port to protocol mapping of a service exposing
+// multiple ports, whose filter chains use the route configurations specific to their port. It returns nil for a service
+// exposing a single port, whose endpoints all serve the port.
+func GetPortSpecificRoutePorts(portToProtocolMap map[uint32]string) []int {
+	if len(portToProtocolMap) < 2 {
+		return nil
+	}
+
+	var ports []int
+	for port, appProtocol := range portToProtocolMap {
+		switch strings.ToLower(appProtocol) {
+		case constants.ProtocolHTTP, constants.ProtocolGRPC:
+			ports = append(ports, int(port))
+		}
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// GetInboundRouteConfigNameForPort returns the name of the inbound route configuration used by the filter chains of the
+// given target port of services exposing multiple ports
+func GetInboundRouteConfigNameForPort(port int) string {
+	return fmt.Sprintf("%s:%d", InboundRouteConfigName, port)
+}
+
+// GetOutboundRouteConfigNameForPort returns the name of the outbound route configuration used by the filter chains of the
+// given port of upstream services exposing multiple ports
+func GetOutboundRouteConfigNameForPort(port int) string {
+	return fmt.Sprintf("%s:%d", OutboundRouteConfigName, port)
+}
@@ -0,0 +1,100 @@
+package route
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// getPortMetadataMatch returns the port matched by the given route under the given key, or 0 if the route does not match a port
+func getPortMetadataMatch(route *xds_route.Route, key string) float64 {
+	return route.GetRoute().GetMetadataMatch().GetFilterMetadata()["envoy.lb"].GetFields()[key].GetNumberValue()
+}
+
+func TestBuildInboundRouteConfigurationForPorts(t *testing.T) {
+	assert := tassert.New(t)
+
+	newRule := func(path string, ports []int) *trafficpolicy.Rule {
+		return &trafficpolicy.Rule{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+					Path:          path,
+					PathMatchType: trafficpolicy.PathMatchRegex,
+					Methods:       []string{"GET"},
+					Ports:         ports,
+				},
+				WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-local", Weight: 100}),
+			},
+			AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{Namespace: "default", Name: "bookbuyer"}.ToServiceIdentity()),
+		}
+	}
+	inbound := []*trafficpolicy.InboundTrafficPolicy{
+		{
+			Name:      "bookstore.default",
+			Hostnames: []string{"bookstore.default"},
+			Rules:     []*trafficpolicy.Rule{newRule("/books", nil), newRule("/grpc", []int{9090})},
+		},
+		{
+			Name:      "bookstore-admin.default",
+			Hostnames: []string{"bookstore-admin.default"},
+			Rules:     []*trafficpolicy.Rule{newRule("/admin", []int{9090})},
+		},
+	}
+
+	routeConfigs := BuildInboundRouteConfigurationForPorts(inbound, []int{8080, 9090}, nil)
+	assert.Len(routeConfigs, 2)
+
+	// Rules restricted to other ports are excluded
+	assert.Equal("rds-inbound:8080", routeConfigs[0].Name)
+	assert.Len(routeConfigs[0].VirtualHosts, 1)
+	assert.Len(routeConfigs[0].VirtualHosts[0].Routes, 1)
+	assert.Equal(float64(8080), getPortMetadataMatch(routeConfigs[0].VirtualHosts[0].Routes[0], envoy.TargetPortSubsetKey))
+
+	assert.Equal("rds-inbound:9090", routeConfigs[1].Name)
+	assert.Len(routeConfigs[1].VirtualHosts, 2)
+	assert.Len(routeConfigs[1].VirtualHosts[0].Routes, 1)
+	assert.Len(routeConfigs[1].VirtualHosts[1].Routes, 2)
+	for _, virtualHost := range routeConfigs[1].VirtualHosts {
+		for _, route := range virtualHost.Routes {
+			assert.Equal(float64(9090), getPortMetadataMatch(route, envoy.TargetPortSubsetKey))
+		}
+	}
+}
+
+func TestBuildOutboundRouteConfigurationForPorts(t *testing.T) {
+	assert := tassert.New(t)
+
+	outbound := []*trafficpolicy.OutboundTrafficPolicy{
+		{
+			Name:      "bookstore.default",
+			Hostnames: []string{"bookstore.default"},
+			Routes: []*trafficpolicy.RouteWeightedClusters{
+				{
+					HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+					WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore", Weight: 100}),
+				},
+			},
+		},
+	}
+
+	routeConfigs := BuildOutboundRouteConfigurationForPorts(outbound, []int{80})
+	assert.Len(routeConfigs, 1)
+	assert.Equal("rds-outbound:80", routeConfigs[0].Name)
+	assert.Len(routeConfigs[0].VirtualHosts, 1)
+	assert.Len(routeConfigs[0].VirtualHosts[0].Routes, 1)
+	assert.Equal(float64(80), getPortMetadataMatch(routeConfigs[0].VirtualHosts[0].Routes[0], envoy.ServicePortSubsetKey))
+}
+
+func TestGetPortSpecificRoutePorts(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Nil(GetPortSpecificRoutePorts(map[uint32]string{80: "http"}))
+	assert.Equal([]int{80, 9090}, GetPortSpecificRoutePorts(map[uint32]string{9090: "grpc", 80: "http", 3306: "tcp"}))
+}
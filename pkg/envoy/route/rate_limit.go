@@ -0,0 +1,158 @@
+package route
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_http_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	// HTTPLocalRateLimitFilterName is the name of Envoy's HTTP local rate limit filter, configured per route
+	HTTPLocalRateLimitFilterName = "envoy.filters.http.local_ratelimit"
+
+	// ForwardedClientCertHeader is the header set by the inbound HTTP connection managers with the DNS SANs of the
+	// certificate of the client, used to match the requests from a source identity
+	ForwardedClientCertHeader = "x-forwarded-client-cert"
+
+	// inboundLocalRateLimitStatPrefix is the stat prefix of the HTTP local rate limit applied to inbound routes
+	inboundLocalRateLimitStatPrefix = "inbound-local-rate-limit"
+
+	// Runtime keys used to override the enablement and enforcement of the HTTP local rate limits at runtime
+	localRateLimitEnabledRuntimeKey  = "local_rate_limit.enabled"
+	localRateLimitEnforcedRuntimeKey = "local_rate_limit.enforced"
+)
+
+// buildRateLimitedRoutes returns the given inbound route rate limiting the requests from the clients not listed in the
+// sources of the given rate limit, preceded by a copy of the route for each of these sources, matching the requests
+// from the source and applying its own rate limit. Each route has its own token bucket.
+func buildRateLimitedRoutes(route *xds_route.Route, rateLimit *policyV1alpha1.HTTPLocalRateLimitSpec) ([]*xds_route.Route, error) {
+	fillInterval, err := getRateLimitFillInterval(rateLimit.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	var routes []*xds_route.Route
+	for _, source := range rateLimit.Sources {
+		sourceIdentity := identity.K8sServiceAccount{Namespace: source.Namespace, Name: source.Name}.ToServiceIdentity()
+		sourceRoute := proto.Clone(route).(*xds_route.Route)
+		sourceRoute.Match.Headers = append(sourceRoute.Match.Headers, &xds_route.HeaderMatcher{
+			Name: ForwardedClientCertHeader,
+			HeaderMatchSpecifier: &xds_route.HeaderMatcher_SafeRegexMatch{
+				SafeRegexMatch: &xds_matcher.RegexMatcher{
+					EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+					Regex:      getForwardedClientCertRegex(sourceIdentity),
+				},
+			},
+		})
+		statPrefix := fmt.Sprintf("%s.%s", inboundLocalRateLimitStatPrefix, sourceIdentity)
+		if sourceRoute.TypedPerFilterConfig, err = withLocalRateLimit(route.TypedPerFilterConfig, statPrefix, source.Requests, source.Burst, fillInterval); err != nil {
+			return nil, err
+		}
+		routes = append(routes, sourceRoute)
+	}
+
+	if rateLimit.Requests > 0 {
+		if route.TypedPerFilterConfig, err = withLocalRateLimit(route.TypedPerFilterConfig, inboundLocalRateLimitStatPrefix, rateLimit.Requests, rateLimit.Burst, fillInterval); err != nil {
+			return nil, err
+		}
+	}
+
+	// Envoy uses the first route matching a request, so the routes of the sources must precede the route of the other clients
+	return append(routes, route), nil
+}
+
+// withLocalRateLimit returns a copy of the given per filter config of a route with an HTTP local rate limit allowing
+// the given number of requests, in addition to the given burst, per fill interval
+func withLocalRateLimit(typedPerFilterConfig map[string]*any.Any, statPrefix string, requests, burst uint32, fillInterval time.Duration) (map[string]*any.Any, error) {
+	localRateLimit := &xds_http_local_ratelimit.LocalRateLimit{
+		StatPrefix: statPrefix,
+		TokenBucket: &xds_type.TokenBucket{
+			MaxTokens:     requests + burst,
+			TokensPerFill: wrapperspb.UInt32(requests),
+			FillInterval:  ptypes.DurationProto(fillInterval),
+		},
+		// The filter is disabled unless enabled and enforced for all the requests matching the route
+		FilterEnabled: &xds_core.RuntimeFractionalPercent{
+			DefaultValue: &xds_type.FractionalPercent{Numerator: 100, Denominator: xds_type.FractionalPercent_HUNDRED},
+			RuntimeKey:   localRateLimitEnabledRuntimeKey,
+		},
+		FilterEnforced: &xds_core.RuntimeFractionalPercent{
+			DefaultValue: &xds_type.FractionalPercent{Numerator: 100, Denominator: xds_type.FractionalPercent_HUNDRED},
+			RuntimeKey:   localRateLimitEnforcedRuntimeKey,
+		},
+	}
+	marshalled, err := ptypes.MarshalAny(localRateLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling HTTP local rate limit")
+	}
+
+	config := make(map[string]*any.Any, len(typedPerFilterConfig)+1)
+	for name, filterConfig := range typedPerFilterConfig {
+		config[name] = filterConfig
+	}
+	config[HTTPLocalRateLimitFilterName] = marshalled
+	return config, nil
+}
+
+// withRequestCounter returns a copy of the given per filter config of a route with an HTTP local rate limit counting
+// the requests matching the route with the given stat prefix, as '<stat prefix>.http_local_rate_limit.enabled'.
+// The rate limit is enabled for all the requests but never enforced, so it does not limit the requests.
+func withRequestCounter(typedPerFilterConfig map[string]*any.Any, statPrefix string) (map[string]*any.Any, error) {
+	localRateLimit := &xds_http_local_ratelimit.LocalRateLimit{
+		StatPrefix: statPrefix,
+		// The token bucket is required but unused, as the rate limit is not enforced without FilterEnforced
+		TokenBucket: &xds_type.TokenBucket{
+			MaxTokens:     1,
+			TokensPerFill: wrapperspb.UInt32(1),
+			FillInterval:  ptypes.DurationProto(time.Second),
+		},
+		FilterEnabled: &xds_core.RuntimeFractionalPercent{
+			DefaultValue: &xds_type.FractionalPercent{Numerator: 100, Denominator: xds_type.FractionalPercent_HUNDRED},
+		},
+	}
+	marshalled, err := ptypes.MarshalAny(localRateLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling HTTP local rate limit counting requests")
+	}
+
+	config := make(map[string]*any.Any, len(typedPerFilterConfig)+1)
+	for name, filterConfig := range typedPerFilterConfig {
+		config[name] = filterConfig
+	}
+	config[HTTPLocalRateLimitFilterName] = marshalled
+	return config, nil
+}
+
+// getForwardedClientCertRegex returns the regex matching the x-forwarded-client-cert header of the requests from the
+// given source identity, whose certificate has the identity as a DNS SAN
+func getForwardedClientCertRegex(sourceIdentity identity.ServiceIdentity) string {
+	return fmt.Sprintf(".*DNS=%s([;,].*)?", regexp.QuoteMeta(sourceIdentity.String()))
+}
+
+// getRateLimitFillInterval returns the interval at which the tokens of a rate limit with the given unit are refilled
+func getRateLimitFillInterval(unit string) (time.Duration, error) {
+	switch unit {
+	case "second":
+		return time.Second, nil
+	case "minute":
+		return time.Minute, nil
+	case "hour":
+		return time.Hour, nil
+	default:
+		return 0, errors.Errorf("Invalid unit %q for HTTP local rate limit, must be one of: second, minute, hour", unit)
+	}
+}
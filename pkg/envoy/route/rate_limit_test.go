@@ -0,0 +1,82 @@
+package route
+
+import (
+	"regexp"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	xds_http_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes"
+	tassert "github.com/stretchr/testify/assert"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildInboundRoutesWithRateLimit(t *testing.T) {
+	assert := tassert.New(t)
+
+	rule := &trafficpolicy.Rule{
+		Route: trafficpolicy.RouteWeightedClusters{
+			HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+				Path:          "/books",
+				PathMatchType: trafficpolicy.PathMatchRegex,
+				Methods:       []string{"GET"},
+			},
+			WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-local", Weight: 100}),
+			RateLimit: &policyV1alpha1.HTTPLocalRateLimitSpec{
+				Requests: 10,
+				Unit:     "second",
+				Sources: []policyV1alpha1.HTTPSourceLocalRateLimitSpec{
+					{Name: "bookbuyer", Namespace: "default", Requests: 100, Burst: 20},
+				},
+			},
+		},
+		AllowedServiceAccounts: mapset.NewSet(identity.ServiceIdentity("")),
+	}
+
+	routes := buildInboundRoutes([]*trafficpolicy.Rule{rule})
+	assert.Len(routes, 2)
+
+	// The route of the source precedes the route of the other clients, and both are authorized by the same RBAC policy
+	sourceRoute, otherRoute := routes[0], routes[1]
+	assert.Len(sourceRoute.Match.Headers, len(otherRoute.Match.Headers)+1)
+	xfccMatcher := sourceRoute.Match.Headers[len(sourceRoute.Match.Headers)-1]
+	assert.Equal(ForwardedClientCertHeader, xfccMatcher.Name)
+	assert.Equal(otherRoute.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl], sourceRoute.TypedPerFilterConfig[wellknown.HTTPRoleBasedAccessControl])
+
+	getTokenBucket := func(index int) (uint32, uint32) {
+		localRateLimit := &xds_http_local_ratelimit.LocalRateLimit{}
+		assert.Nil(ptypes.UnmarshalAny(routes[index].TypedPerFilterConfig[HTTPLocalRateLimitFilterName], localRateLimit))
+		return localRateLimit.TokenBucket.MaxTokens, localRateLimit.TokenBucket.TokensPerFill.GetValue()
+	}
+	maxTokens, tokensPerFill := getTokenBucket(0)
+	assert.Equal(uint32(120), maxTokens)
+	assert.Equal(uint32(100), tokensPerFill)
+	maxTokens, tokensPerFill = getTokenBucket(1)
+	assert.Equal(uint32(10), maxTokens)
+	assert.Equal(uint32(10), tokensPerFill)
+
+	// The requests from the other clients are not rate limited without a default limit
+	rule.Route.RateLimit.Requests = 0
+	routes = buildInboundRoutes([]*trafficpolicy.Rule{rule})
+	assert.Len(routes, 2)
+	assert.NotContains(routes[1].TypedPerFilterConfig, HTTPLocalRateLimitFilterName)
+
+	// Routes with an invalid rate limit are skipped
+	rule.Route.RateLimit.Unit = "day"
+	assert.Empty(buildInboundRoutes([]*trafficpolicy.Rule{rule}))
+}
+
+func TestGetForwardedClientCertRegex(t *testing.T) {
+	assert := tassert.New(t)
+
+	regex := regexp.MustCompile("^(?:" + getForwardedClientCertRegex("bookbuyer.default.cluster.local") + ")$")
+	assert.True(regex.MatchString("By=spiffe://bookstore;Hash=abc;DNS=bookbuyer.default.cluster.local"))
+	assert.True(regex.MatchString("Hash=abc;DNS=bookbuyer.default.cluster.local;DNS=other"))
+	assert.False(regex.MatchString("Hash=abc;DNS=bookbuyer.default.cluster.local.evil"))
+	assert.False(regex.MatchString("Hash=abc;DNS=bookbuyerXdefault.cluster.local"))
+}
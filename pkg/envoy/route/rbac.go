@@ -1,6 +1,8 @@
 package route
 
 import (
+	"sort"
+
 	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
 	xds_http_rbac "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
@@ -28,13 +30,24 @@ func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule) (map[string]*any.An
 
 	policy := &rbac.Policy{}
 
-	// Create the list of principals for this policy
-	var principalRuleList []rbac.RulesList
+	// Create the list of principals for this policy, sorted so that the same rule always results in the same policy
+	var downstreamIdentities []identity.ServiceIdentity
 	for downstream := range rule.AllowedServiceAccounts.Iter() {
+		downstreamIdentity, ok := downstream.(identity.ServiceIdentity)
+		if !ok {
+			return nil, errors.Errorf("invalid principal %v in traffipolicy.Rule.AllowedServiceAccounts", downstream)
+		}
+		downstreamIdentities = append(downstreamIdentities, downstreamIdentity)
+	}
+	sort.Slice(downstreamIdentities, func(i, j int) bool {
+		return downstreamIdentities[i] < downstreamIdentities[j]
+	})
+
+	var principalRuleList []rbac.RulesList
+	for _, downstreamIdentity := range downstreamIdentities {
 		var principalRule rbac.RulesList
-		downstreamIdentity := downstream.(identity.K8sServiceAccount)
 
-		if downstreamIdentity.IsEmpty() {
+		if downstreamIdentity == "" {
 			// When the downstream identity in a traffic policy rule is set to be empty, it implies
 			// we must allow all downstream principals. This can be accomplished by setting an empty
 			// principal rules list to generate an RBAC policy with principals set to ANY (all downstreams).
@@ -43,10 +56,9 @@ func buildInboundRBACFilterForRule(rule *trafficpolicy.Rule) (map[string]*any.An
 			// The downstream principal in an RBAC policy is an authenticated principal type, which
 			// means the principal must correspond to the fully qualified SAN in the certificate presented
 			// by the downstream.
-			downstreamPrincipal := identity.GetKubernetesServiceIdentity(downstreamIdentity, identity.ClusterLocalTrustDomain)
 			principalRule = rbac.RulesList{
 				OrRules: []rbac.Rule{
-					{Attribute: rbac.DownstreamAuthPrincipal, Value: downstreamPrincipal.String()},
+					{Attribute: rbac.DownstreamAuthPrincipal, Value: downstreamIdentity.String()},
 				},
 			}
 		}
@@ -34,8 +34,8 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
 				AllowedServiceAccounts: mapset.NewSetFromSlice([]interface{}{
-					identity.K8sServiceAccount{Name: "foo", Namespace: "ns-1"},
-					identity.K8sServiceAccount{Name: "bar", Namespace: "ns-2"},
+					identity.ServiceIdentity("foo.ns-1.cluster.local"),
+					identity.ServiceIdentity("bar.ns-2.cluster.local"),
 				}),
 			},
 			expectedRBACPolicy: &xds_rbac.Policy{
@@ -44,7 +44,7 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 						Identifier: &xds_rbac.Principal_OrIds{
 							OrIds: &xds_rbac.Principal_Set{
 								Ids: []*xds_rbac.Principal{
-									rbac.GetAuthenticatedPrincipal("foo.ns-1.cluster.local"),
+									rbac.GetAuthenticatedPrincipal("bar.ns-2.cluster.local"),
 								},
 							},
 						},
@@ -53,7 +53,7 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 						Identifier: &xds_rbac.Principal_OrIds{
 							OrIds: &xds_rbac.Principal_Set{
 								Ids: []*xds_rbac.Principal{
-									rbac.GetAuthenticatedPrincipal("bar.ns-2.cluster.local"),
+									rbac.GetAuthenticatedPrincipal("foo.ns-1.cluster.local"),
 								},
 							},
 						},
@@ -75,7 +75,7 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
 				AllowedServiceAccounts: mapset.NewSetFromSlice([]interface{}{
-					identity.K8sServiceAccount{}, // setting an empty service account will result in all downstreams being allowed
+					identity.ServiceIdentity(""), // setting an empty service identity will result in all downstreams being allowed
 				}),
 			},
 			expectedRBACPolicy: &xds_rbac.Policy{
@@ -92,6 +92,59 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "valid trafficpolicy rule with a downstream identity resolved by another identity provider",
+			rule: &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+				AllowedServiceAccounts: mapset.NewSetFromSlice([]interface{}{
+					identity.ServiceIdentity("spiffe://example.org/vm/billing"),
+					identity.ServiceIdentity("foo.ns-1.cluster.local"),
+				}),
+			},
+			expectedRBACPolicy: &xds_rbac.Policy{
+				Principals: []*xds_rbac.Principal{
+					{
+						Identifier: &xds_rbac.Principal_OrIds{
+							OrIds: &xds_rbac.Principal_Set{
+								Ids: []*xds_rbac.Principal{
+									rbac.GetAuthenticatedPrincipal("foo.ns-1.cluster.local"),
+								},
+							},
+						},
+					},
+					{
+						Identifier: &xds_rbac.Principal_OrIds{
+							OrIds: &xds_rbac.Principal_Set{
+								Ids: []*xds_rbac.Principal{
+									rbac.GetAuthenticatedPrincipal("spiffe://example.org/vm/billing"),
+								},
+							},
+						},
+					},
+				},
+				Permissions: []*xds_rbac.Permission{
+					{
+						Rule: &xds_rbac.Permission_Any{Any: true},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid trafficpolicy rule with an unknown principal type",
+			rule: &trafficpolicy.Rule{
+				Route: trafficpolicy.RouteWeightedClusters{
+					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
+					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
+				},
+				AllowedServiceAccounts: mapset.NewSet("ns-1/foo"),
+			},
+			expectedRBACPolicy: nil,
+			expectError:        true,
+		},
 		{
 			name: "invalid trafficpolicy rule with Rule.AllowedServiceAccounts not specified",
 			rule: &trafficpolicy.Rule{
@@ -126,8 +179,8 @@ func TestBuildInboundRBACFilterForRule(t *testing.T) {
 
 			rbacPolicy := rbacRules.Policies[rbacPerRoutePolicyName]
 
-			// Match principals regardless of their order in the generated RBAC policy
-			assert.ElementsMatch(tc.expectedRBACPolicy.Principals, rbacPolicy.Principals)
+			// Principals are sorted by downstream identity
+			assert.Equal(tc.expectedRBACPolicy.Principals, rbacPolicy.Principals)
 			assert.Equal(tc.expectedRBACPolicy.Permissions, rbacPolicy.Permissions)
 		})
 	}
@@ -2,7 +2,9 @@ package route
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
+	"strconv"
 
 	mapset "github.com/deckarep/golang-set"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -13,6 +15,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
 )
@@ -38,6 +41,9 @@ const (
 	// IngressRouteConfigName is the name of the ingress RDS route configuration
 	IngressRouteConfigName = "rds-ingress"
 
+	// IngressGatewayRouteConfigName is the name of the RDS route configuration of the OSM managed ingress gateway
+	IngressGatewayRouteConfigName = "rds-ingress-gateway"
+
 	// egressRouteConfigNamePrefix is the prefix for the name of the egress RDS route configuration
 	egressRouteConfigNamePrefix = "rds-egress"
 
@@ -53,6 +59,9 @@ const (
 	// ingressVirtualHost is the prefix for the virtual host's name in the ingress route configuration
 	ingressVirtualHost = "ingress_virtual-host"
 
+	// ingressGatewayVirtualHost is the prefix for the virtual host's name in the ingress gateway route configuration
+	ingressGatewayVirtualHost = "ingress-gateway_virtual-host"
+
 	// methodHeaderKey is the key of the header for HTTP methods
 	methodHeaderKey = ":method"
 
@@ -78,15 +87,9 @@ func BuildRouteConfiguration(inbound []*trafficpolicy.InboundTrafficPolicy, outb
 	}
 
 	if featureflags.IsWASMStatsEnabled() {
-		for k, v := range proxy.StatsHeaders() {
-			inboundRouteConfig.ResponseHeadersToAdd = append(inboundRouteConfig.ResponseHeadersToAdd, &core.HeaderValueOption{
-				Header: &core.HeaderValue{
-					Key:   k,
-					Value: v,
-				},
-			})
-		}
+		inboundRouteConfig.ResponseHeadersToAdd = buildStatsHeaders(proxy)
 	}
+	sortVirtualHosts(inboundRouteConfig)
 
 	routeConfiguration = append(routeConfiguration, inboundRouteConfig)
 	outboundRouteConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
@@ -96,6 +99,7 @@ func BuildRouteConfiguration(inbound []*trafficpolicy.InboundTrafficPolicy, outb
 		virtualHost.Routes = buildOutboundRoutes(out.Routes)
 		outboundRouteConfig.VirtualHosts = append(outboundRouteConfig.VirtualHosts, virtualHost)
 	}
+	sortVirtualHosts(outboundRouteConfig)
 	routeConfiguration = append(routeConfiguration, outboundRouteConfig)
 
 	return routeConfiguration
@@ -115,37 +119,65 @@ func BuildIngressConfiguration(ingress []*trafficpolicy.InboundTrafficPolicy, pr
 	}
 
 	if featureflags.IsWASMStatsEnabled() {
-		for k, v := range proxy.StatsHeaders() {
-			ingressRouteConfig.ResponseHeadersToAdd = append(ingressRouteConfig.ResponseHeadersToAdd, &core.HeaderValueOption{
-				Header: &core.HeaderValue{
-					Key:   k,
-					Value: v,
-				},
-			})
-		}
+		ingressRouteConfig.ResponseHeadersToAdd = buildStatsHeaders(proxy)
 	}
+	sortVirtualHosts(ingressRouteConfig)
 
 	return ingressRouteConfig
 }
 
+// BuildIngressGatewayRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) routing the traffic
+// received by the OSM managed ingress gateway to the upstream services of the given ingress gateway policies
+func BuildIngressGatewayRouteConfiguration(gatewayPolicies []*trafficpolicy.OutboundTrafficPolicy) *xds_route.RouteConfiguration {
+	// The route configuration is always generated as it is referenced by the ingress gateway listener
+	routeConfig := NewRouteConfigurationStub(IngressGatewayRouteConfigName)
+	for _, policy := range gatewayPolicies {
+		virtualHost := buildVirtualHostStub(ingressGatewayVirtualHost, policy.Name, policy.Hostnames)
+		virtualHost.Routes = buildIngressGatewayRoutes(policy.Routes)
+		routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
+	}
+	sortVirtualHosts(routeConfig)
+	return routeConfig
+}
+
 // BuildEgressRouteConfiguration constructs the Envoy construct (*xds_route.RouteConfiguration) for the given egress route configs
-func BuildEgressRouteConfiguration(portSpecificRouteConfigs map[int][]*trafficpolicy.EgressHTTPRouteConfig) []*xds_route.RouteConfiguration {
+// of the given source identity. The requests denied by the routes of denied hosts are counted per host when countDenied is set.
+func BuildEgressRouteConfiguration(source identity.ServiceIdentity, portSpecificRouteConfigs map[int][]*trafficpolicy.EgressHTTPRouteConfig, countDenied bool) ([]*xds_route.RouteConfiguration, error) {
 	var routeConfigs []*xds_route.RouteConfiguration
 
 	// An Envoy RouteConfiguration will exist for each HTTP egress port.
 	// This is required to avoid route conflicts that can arise when the same host header
 	// has different routes on different destination ports for that host.
-	for port, configs := range portSpecificRouteConfigs {
+	var ports []int
+	for port := range portSpecificRouteConfigs {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+
+	for _, port := range ports {
 		routeConfig := NewRouteConfigurationStub(GetEgressRouteConfigNameForPort(port))
-		for _, config := range configs {
+		for _, config := range portSpecificRouteConfigs[port] {
 			virtualHost := buildVirtualHostStub(egressVirtualHost, config.Name, config.Hostnames)
-			virtualHost.Routes = buildEgressRoutes(config.RoutingRules)
+			if config.Deny {
+				var statPrefix string
+				if countDenied {
+					statPrefix = envoy.GetEgressDenyRequestStatPrefix(source, config.Name, strconv.Itoa(port))
+				}
+				denyRoute, err := buildEgressDenyRoute(statPrefix)
+				if err != nil {
+					return nil, err
+				}
+				virtualHost.Routes = []*xds_route.Route{denyRoute}
+			} else {
+				virtualHost.Routes = buildEgressRoutes(config.RoutingRules, config.HostRewrite)
+			}
 			routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
 		}
+		sortVirtualHosts(routeConfig)
 		routeConfigs = append(routeConfigs, routeConfig)
 	}
 
-	return routeConfigs
+	return routeConfigs, nil
 }
 
 //NewRouteConfigurationStub creates the route configuration placeholder
@@ -163,13 +195,50 @@ func NewRouteConfigurationStub(routeConfigName string) *xds_route.RouteConfigura
 
 func buildVirtualHostStub(namePrefix string, host string, domains []string) *xds_route.VirtualHost {
 	name := fmt.Sprintf("%s|%s", namePrefix, host)
+
+	// The order of the domains of a virtual host is not significant to Envoy, they are sorted so that the
+	// same policies always result in the same route configuration
+	sortedDomains := make([]string, len(domains))
+	copy(sortedDomains, domains)
+	sort.Strings(sortedDomains)
+
 	virtualHost := xds_route.VirtualHost{
 		Name:    name,
-		Domains: domains,
+		Domains: sortedDomains,
 	}
 	return &virtualHost
 }
 
+// sortVirtualHosts sorts the virtual hosts of the given route configuration by name. Envoy selects a virtual host
+// by the most specific domain matching a request regardless of its position, so the order only matters to the
+// stability of the configuration.
+func sortVirtualHosts(routeConfig *xds_route.RouteConfiguration) {
+	sort.SliceStable(routeConfig.VirtualHosts, func(i, j int) bool {
+		return routeConfig.VirtualHosts[i].Name < routeConfig.VirtualHosts[j].Name
+	})
+}
+
+// buildStatsHeaders returns the response headers used by the WASM stats filter for the given proxy, sorted by name
+func buildStatsHeaders(proxy *envoy.Proxy) []*core.HeaderValueOption {
+	statsHeaders := proxy.StatsHeaders()
+	var keys []string
+	for key := range statsHeaders {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var headers []*core.HeaderValueOption
+	for _, key := range keys {
+		headers = append(headers, &core.HeaderValueOption{
+			Header: &core.HeaderValue{
+				Key:   key,
+				Value: statsHeaders[key],
+			},
+		})
+	}
+	return headers
+}
+
 // buildInboundRoutes takes a route information from the given inbound traffic policy and returns a list of xds routes
 func buildInboundRoutes(rules []*trafficpolicy.Rule) []*xds_route.Route {
 	var routes []*xds_route.Route
@@ -190,6 +259,21 @@ func buildInboundRoutes(rules []*trafficpolicy.Rule) []*xds_route.Route {
 		for _, method := range allowedMethods {
 			route := buildRoute(rule.Route.HTTPRouteMatch.PathMatchType, rule.Route.HTTPRouteMatch.Path, method, rule.Route.HTTPRouteMatch.Headers, rule.Route.WeightedClusters, 100, inboundRoute)
 			route.TypedPerFilterConfig = rbacPolicyForRoute
+			if rule.Route.XFFOverwrite {
+				route.RequestHeadersToAdd = append(route.RequestHeadersToAdd, buildXFFOverwriteHeader())
+			}
+			if rule.Route.Maintenance != nil {
+				applyMaintenanceResponse(route, rule.Route.Maintenance)
+			}
+			if rule.Route.RateLimit != nil {
+				rateLimitedRoutes, err := buildRateLimitedRoutes(route, rule.Route.RateLimit)
+				if err != nil {
+					log.Error().Err(err).Msgf("Error building rate limit for rule [%v], skipping route addition", rule)
+					continue
+				}
+				routes = append(routes, rateLimitedRoutes...)
+				continue
+			}
 			routes = append(routes, route)
 		}
 	}
@@ -198,14 +282,32 @@ func buildInboundRoutes(rules []*trafficpolicy.Rule) []*xds_route.Route {
 
 func buildOutboundRoutes(outRoutes []*trafficpolicy.RouteWeightedClusters) []*xds_route.Route {
 	var routes []*xds_route.Route
+	var grpcRoutes []*xds_route.Route
 	for _, outRoute := range outRoutes {
+		if outRoute.HTTPRouteMatch.GRPC {
+			grpcRoutes = append(grpcRoutes, buildGRPCRoute(outRoute, outboundRoute))
+			continue
+		}
 		emptyHeaders := map[string]string{}
 		routes = append(routes, buildRoute(trafficpolicy.PathMatchRegex, constants.RegexMatchAll, constants.WildcardHTTPMethod, emptyHeaders, outRoute.WeightedClusters, outRoute.TotalClustersWeight(), outboundRoute))
 	}
+	// Envoy uses the first route matching a request, so the gRPC routes must precede the wildcard route
+	return append(grpcRoutes, routes...)
+}
+
+// buildIngressGatewayRoutes returns the routes matching the paths of the given ingress gateway routes
+func buildIngressGatewayRoutes(gatewayRoutes []*trafficpolicy.RouteWeightedClusters) []*xds_route.Route {
+	var routes []*xds_route.Route
+	for _, gatewayRoute := range gatewayRoutes {
+		routes = append(routes, buildRoute(gatewayRoute.HTTPRouteMatch.PathMatchType, gatewayRoute.HTTPRouteMatch.Path, constants.WildcardHTTPMethod, gatewayRoute.HTTPRouteMatch.Headers,
+			gatewayRoute.WeightedClusters, gatewayRoute.TotalClustersWeight(), outboundRoute))
+	}
 	return routes
 }
 
-func buildEgressRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule) []*xds_route.Route {
+// buildEgressRoutes returns the routes for the given egress routing rules, rewriting the Host/authority header of
+// the requests to the given host when it is non-empty
+func buildEgressRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule, hostRewrite string) []*xds_route.Route {
 	var routes []*xds_route.Route
 	for _, rule := range routingRules {
 		// For a given route path, sanitize the methods in case there
@@ -216,12 +318,38 @@ func buildEgressRoutes(routingRules []*trafficpolicy.EgressHTTPRoutingRule) []*x
 		// Each HTTP method corresponds to a separate route
 		for _, httpMethod := range allowedHTTPMethods {
 			route := buildRoute(rule.Route.HTTPRouteMatch.PathMatchType, rule.Route.HTTPRouteMatch.Path, httpMethod, nil, rule.Route.WeightedClusters, rule.Route.TotalClustersWeight(), outboundRoute)
+			if hostRewrite != "" {
+				route.GetRoute().HostRewriteSpecifier = &xds_route.RouteAction_HostRewriteLiteral{
+					HostRewriteLiteral: hostRewrite,
+				}
+			}
 			routes = append(routes, route)
 		}
 	}
 	return routes
 }
 
+// buildEgressDenyRoute returns the route responding with a 403 Forbidden to all the requests, used for the hosts
+// denied by Egress policies. The requests are counted in the statistics with the given stat prefix, unless empty.
+func buildEgressDenyRoute(statPrefix string) (*xds_route.Route, error) {
+	route := &xds_route.Route{
+		Match: &xds_route.RouteMatch{
+			PathSpecifier: &xds_route.RouteMatch_Prefix{Prefix: "/"},
+		},
+		Action: &xds_route.Route_DirectResponse{
+			DirectResponse: &xds_route.DirectResponseAction{Status: http.StatusForbidden},
+		},
+	}
+
+	if statPrefix != "" {
+		var err error
+		if route.TypedPerFilterConfig, err = withRequestCounter(route.TypedPerFilterConfig, statPrefix); err != nil {
+			return nil, err
+		}
+	}
+	return route, nil
+}
+
 func buildRoute(pathMatchTypeType trafficpolicy.PathMatchType, path string, method string, headersMap map[string]string, weightedClusters mapset.Set, totalWeight int, direction Direction) *xds_route.Route {
 	route := xds_route.Route{
 		Match: &xds_route.RouteMatch{
@@ -345,18 +473,24 @@ func getHeadersForRoute(method string, headersMap map[string]string) []*xds_rout
 		headers = append(headers, hostHeader)
 	}
 
-	// add all other custom headers
-	for headerKey, headerValue := range headersMap {
+	// add all other custom headers, sorted by name since all of them must match regardless of their order
+	var headerKeys []string
+	for headerKey := range headersMap {
 		// omit the host header as this is configured above
 		if headerKey == httpHostHeaderKey {
 			continue
 		}
+		headerKeys = append(headerKeys, headerKey)
+	}
+	sort.Strings(headerKeys)
+
+	for _, headerKey := range headerKeys {
 		header := xds_route.HeaderMatcher{
 			Name: headerKey,
 			HeaderMatchSpecifier: &xds_route.HeaderMatcher_SafeRegexMatch{
 				SafeRegexMatch: &xds_matcher.RegexMatcher{
 					EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
-					Regex:      headerValue,
+					Regex:      headersMap[headerKey],
 				},
 			},
 		}
@@ -2,11 +2,14 @@ package route
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	mapset "github.com/deckarep/golang-set"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	xds_http_local_ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/local_ratelimit/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	tassert "github.com/stretchr/testify/assert"
 
@@ -30,14 +33,14 @@ func TestBuildRouteConfiguration(t *testing.T) {
 					HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
-				AllowedServiceAccounts: mapset.NewSet(tests.BookbuyerServiceAccount),
+				AllowedServiceAccounts: mapset.NewSet(tests.BookbuyerServiceIdentity),
 			},
 			{
 				Route: trafficpolicy.RouteWeightedClusters{
 					HTTPRouteMatch:   tests.BookstoreSellHTTPRoute,
 					WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 				},
-				AllowedServiceAccounts: mapset.NewSet(tests.BookbuyerServiceAccount),
+				AllowedServiceAccounts: mapset.NewSet(tests.BookbuyerServiceIdentity),
 			},
 		},
 	}
@@ -151,14 +154,14 @@ func TestBuildIngressRouteConfiguration(t *testing.T) {
 								HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{}),
+							AllowedServiceAccounts: mapset.NewSet(identity.ServiceIdentity("")),
 						},
 						{
 							Route: trafficpolicy.RouteWeightedClusters{
 								HTTPRouteMatch:   tests.BookstoreSellHTTPRoute,
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{}),
+							AllowedServiceAccounts: mapset.NewSet(identity.ServiceIdentity("")),
 						},
 					},
 				},
@@ -171,7 +174,7 @@ func TestBuildIngressRouteConfiguration(t *testing.T) {
 								HTTPRouteMatch:   tests.BookstoreBuyHTTPRoute,
 								WeightedClusters: mapset.NewSet(tests.BookstoreV1DefaultWeightedCluster),
 							},
-							AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{}),
+							AllowedServiceAccounts: mapset.NewSet(identity.ServiceIdentity("")),
 						},
 					},
 				},
@@ -227,33 +230,39 @@ func TestBuildVirtualHostStub(t *testing.T) {
 	assert := tassert.New(t)
 
 	testCases := []struct {
-		name         string
-		namePrefix   string
-		host         string
-		domains      []string
-		expectedName string
+		name            string
+		namePrefix      string
+		host            string
+		domains         []string
+		expectedName    string
+		expectedDomains []string
 	}{
 		{
-			name:         "inbound virtual host",
-			namePrefix:   inboundVirtualHost,
-			host:         httpHostHeaderKey,
-			domains:      []string{"domain1", "domain2"},
-			expectedName: "inbound_virtual-host|host",
+			name:            "inbound virtual host",
+			namePrefix:      inboundVirtualHost,
+			host:            httpHostHeaderKey,
+			domains:         []string{"domain1", "domain2"},
+			expectedName:    "inbound_virtual-host|host",
+			expectedDomains: []string{"domain1", "domain2"},
 		},
 		{
-			name:         "outbound virtual host",
-			namePrefix:   outboundVirtualHost,
-			host:         httpHostHeaderKey,
-			domains:      []string{"domain1", "domain2"},
-			expectedName: "outbound_virtual-host|host",
+			name:            "outbound virtual host with unsorted domains",
+			namePrefix:      outboundVirtualHost,
+			host:            httpHostHeaderKey,
+			domains:         []string{"domain2", "domain1"},
+			expectedName:    "outbound_virtual-host|host",
+			expectedDomains: []string{"domain1", "domain2"},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			domains := append([]string(nil), tc.domains...)
 			actual := buildVirtualHostStub(tc.namePrefix, tc.host, tc.domains)
 			assert.Equal(tc.expectedName, actual.Name)
-			assert.Equal(tc.domains, actual.Domains)
+			assert.Equal(tc.expectedDomains, actual.Domains)
+			// The domains of the policy are not modified
+			assert.Equal(domains, tc.domains)
 		})
 	}
 }
@@ -284,7 +293,7 @@ func TestBuildInboundRoutes(t *testing.T) {
 						WeightedClusters: mapset.NewSet(testWeightedCluster),
 					},
 					AllowedServiceAccounts: mapset.NewSetFromSlice(
-						[]interface{}{identity.K8sServiceAccount{Name: "foo", Namespace: "bar"}},
+						[]interface{}{identity.K8sServiceAccount{Name: "foo", Namespace: "bar"}.ToServiceIdentity()},
 					),
 				},
 			},
@@ -357,6 +366,66 @@ func TestBuildOutboundRoutes(t *testing.T) {
 	assert.Equal(uint32(100), actual[0].GetRoute().GetWeightedClusters().Clusters[0].Weight.GetValue())
 }
 
+func TestBuildIngressGatewayRouteConfiguration(t *testing.T) {
+	assert := tassert.New(t)
+
+	testWeightedCluster := service.WeightedCluster{
+		ClusterName: "default/bookstore-v1",
+		Weight:      100,
+	}
+
+	t.Run("no ingress gateway policies", func(t *testing.T) {
+		actual := BuildIngressGatewayRouteConfiguration(nil)
+		assert.Equal("rds-ingress-gateway", actual.Name)
+		assert.Empty(actual.VirtualHosts)
+	})
+
+	t.Run("multiple ingress gateway policies", func(t *testing.T) {
+		gatewayPolicies := []*trafficpolicy.OutboundTrafficPolicy{
+			{
+				Name:      "ingress-1.default|foo.com",
+				Hostnames: []string{"foo.com"},
+				Routes: []*trafficpolicy.RouteWeightedClusters{
+					{
+						HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+							Path:          "/books",
+							PathMatchType: trafficpolicy.PathMatchExact,
+							Methods:       []string{constants.WildcardHTTPMethod},
+						},
+						WeightedClusters: mapset.NewSet(testWeightedCluster),
+					},
+				},
+			},
+			{
+				Name:      "ingress-1.default|*",
+				Hostnames: []string{"*"},
+				Routes: []*trafficpolicy.RouteWeightedClusters{
+					{
+						HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+						WeightedClusters: mapset.NewSet(testWeightedCluster),
+					},
+				},
+			},
+		}
+
+		actual := BuildIngressGatewayRouteConfiguration(gatewayPolicies)
+		assert.Equal("rds-ingress-gateway", actual.Name)
+		assert.Len(actual.VirtualHosts, 2)
+
+		// Virtual hosts are sorted by name
+		assert.Equal("ingress-gateway_virtual-host|ingress-1.default|*", actual.VirtualHosts[0].Name)
+		assert.Len(actual.VirtualHosts[0].Routes, 1)
+		assert.Equal(constants.RegexMatchAll, actual.VirtualHosts[0].Routes[0].GetMatch().GetSafeRegex().Regex)
+
+		assert.Equal("ingress-gateway_virtual-host|ingress-1.default|foo.com", actual.VirtualHosts[1].Name)
+		assert.Equal([]string{"foo.com"}, actual.VirtualHosts[1].Domains)
+		assert.Len(actual.VirtualHosts[1].Routes, 1)
+		assert.Equal("/books", actual.VirtualHosts[1].Routes[0].GetMatch().GetPath())
+		// Ingress gateway routes are outbound routes, referencing the upstream service cluster
+		assert.Equal("default/bookstore-v1", actual.VirtualHosts[1].Routes[0].GetRoute().GetWeightedClusters().Clusters[0].Name)
+	})
+}
+
 func TestBuildRoute(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -770,6 +839,23 @@ func TestGetHeadersForRoute(t *testing.T) {
 	assert.Equal(routePolicy.Methods[0], actual[0].GetSafeRegexMatch().Regex)
 	assert.Equal(authorityHeaderKey, actual[1].Name)
 	assert.Equal(tests.HTTPHostHeader, actual[1].GetSafeRegexMatch().Regex)
+
+	// Returns the custom HeaderMatchers sorted by name
+	headers := map[string]string{
+		"x-c":           "c",
+		"x-a":           "a",
+		"host":          tests.HTTPHostHeader,
+		userAgentHeader: "This is a test header",
+		"x-b":           "b",
+	}
+	for i := 0; i < 10; i++ {
+		actual = getHeadersForRoute(constants.WildcardHTTPMethod, headers)
+		var names []string
+		for _, header := range actual {
+			names = append(names, header.Name)
+		}
+		assert.Equal([]string{methodHeaderKey, authorityHeaderKey, userAgentHeader, "x-a", "x-b", "x-c"}, names)
+	}
 }
 
 func TestLen(t *testing.T) {
@@ -861,6 +947,7 @@ func TestBuildEgressRoute(t *testing.T) {
 	testCases := []struct {
 		name           string
 		routingRules   []*trafficpolicy.EgressHTTPRoutingRule
+		hostRewrite    string
 		expectedRoutes []*xds_route.Route
 	}{
 		{
@@ -868,6 +955,62 @@ func TestBuildEgressRoute(t *testing.T) {
 			routingRules:   nil,
 			expectedRoutes: nil,
 		},
+		{
+			name: "routing rule with host rewrite",
+			routingRules: []*trafficpolicy.EgressHTTPRoutingRule{
+				{
+					Route: trafficpolicy.RouteWeightedClusters{
+						HTTPRouteMatch: trafficpolicy.HTTPRouteMatch{
+							PathMatchType: trafficpolicy.PathMatchPrefix,
+							Path:          "/",
+							Methods:       []string{"GET"},
+						},
+						WeightedClusters: mapset.NewSetFromSlice([]interface{}{
+							service.WeightedCluster{ClusterName: "foo.com:80", Weight: 100},
+						}),
+					},
+				},
+			},
+			hostRewrite: "foo.com",
+			expectedRoutes: []*xds_route.Route{
+				{
+					Match: &xds_route.RouteMatch{
+						PathSpecifier: &xds_route.RouteMatch_Prefix{
+							Prefix: "/",
+						},
+						Headers: []*xds_route.HeaderMatcher{
+							{
+								Name: ":method",
+								HeaderMatchSpecifier: &xds_route.HeaderMatcher_SafeRegexMatch{
+									SafeRegexMatch: &xds_matcher.RegexMatcher{
+										EngineType: &xds_matcher.RegexMatcher_GoogleRe2{GoogleRe2: &xds_matcher.RegexMatcher_GoogleRE2{}},
+										Regex:      "GET",
+									},
+								},
+							},
+						},
+					},
+					Action: &xds_route.Route_Route{
+						Route: &xds_route.RouteAction{
+							ClusterSpecifier: &xds_route.RouteAction_WeightedClusters{
+								WeightedClusters: &xds_route.WeightedCluster{
+									Clusters: []*xds_route.WeightedCluster_ClusterWeight{
+										{
+											Name:   "foo.com:80",
+											Weight: &wrappers.UInt32Value{Value: 100},
+										},
+									},
+									TotalWeight: &wrappers.UInt32Value{Value: 100},
+								},
+							},
+							HostRewriteSpecifier: &xds_route.RouteAction_HostRewriteLiteral{
+								HostRewriteLiteral: "foo.com",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "multiple routing rules",
 			routingRules: []*trafficpolicy.EgressHTTPRoutingRule{
@@ -975,7 +1118,7 @@ func TestBuildEgressRoute(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := buildEgressRoutes(tc.routingRules)
+			actual := buildEgressRoutes(tc.routingRules, tc.hostRewrite)
 			assert.ElementsMatch(tc.expectedRoutes, actual)
 		})
 	}
@@ -1054,10 +1197,10 @@ func TestBuildEgressRouteConfiguration(t *testing.T) {
 					ValidateClusters: &wrappers.BoolValue{Value: false},
 					VirtualHosts: []*xds_route.VirtualHost{
 						{
-							Name: "egress_virtual-host|foo.com",
+							Name: "egress_virtual-host|bar.com",
 							Domains: []string{
-								"foo.com",
-								"foo.com:80",
+								"bar.com",
+								"bar.com:80",
 							},
 							Routes: []*xds_route.Route{
 								{
@@ -1086,7 +1229,7 @@ func TestBuildEgressRouteConfiguration(t *testing.T) {
 												WeightedClusters: &xds_route.WeightedCluster{
 													Clusters: []*xds_route.WeightedCluster_ClusterWeight{
 														{
-															Name:   "foo.com:80",
+															Name:   "bar.com:80",
 															Weight: &wrappers.UInt32Value{Value: 100},
 														},
 													},
@@ -1099,10 +1242,10 @@ func TestBuildEgressRouteConfiguration(t *testing.T) {
 							},
 						},
 						{
-							Name: "egress_virtual-host|bar.com",
+							Name: "egress_virtual-host|foo.com",
 							Domains: []string{
-								"bar.com",
-								"bar.com:80",
+								"foo.com",
+								"foo.com:80",
 							},
 							Routes: []*xds_route.Route{
 								{
@@ -1131,7 +1274,7 @@ func TestBuildEgressRouteConfiguration(t *testing.T) {
 												WeightedClusters: &xds_route.WeightedCluster{
 													Clusters: []*xds_route.WeightedCluster_ClusterWeight{
 														{
-															Name:   "bar.com:80",
+															Name:   "foo.com:80",
 															Weight: &wrappers.UInt32Value{Value: 100},
 														},
 													},
@@ -1207,8 +1350,79 @@ func TestBuildEgressRouteConfiguration(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual := BuildEgressRouteConfiguration(tc.portSpecificRouteConfigs)
-			assert.ElementsMatch(tc.expectedRouteConfigs, actual)
+			actual, err := BuildEgressRouteConfiguration(tests.BookbuyerServiceIdentity, tc.portSpecificRouteConfigs, true)
+			assert.Nil(err)
+			assert.Equal(tc.expectedRouteConfigs, actual)
+		})
+	}
+}
+
+func TestBuildEgressRouteConfigurationDeny(t *testing.T) {
+	portSpecificRouteConfigs := map[int][]*trafficpolicy.EgressHTTPRouteConfig{
+		80: {
+			{
+				Name:      "foo.com",
+				Hostnames: []string{"foo.com", "foo.com:80"},
+				Deny:      true,
+			},
+			{
+				Name:      "bar.com",
+				Hostnames: []string{"bar.com", "bar.com:80"},
+				Deny:      true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                 string
+		countDenied          bool
+		expectedStatPrefixes map[string]string
+	}{
+		{
+			name:        "denied requests counted per host",
+			countDenied: true,
+			expectedStatPrefixes: map[string]string{
+				"foo.com": "egress-deny|default/bookbuyer|foo.com|80",
+				"bar.com": "egress-deny|default/bookbuyer|bar.com|80",
+			},
+		},
+		{
+			name:                 "denied requests not counted",
+			countDenied:          false,
+			expectedStatPrefixes: map[string]string{"foo.com": "", "bar.com": ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			routeConfigs, err := BuildEgressRouteConfiguration(tests.BookbuyerServiceIdentity, portSpecificRouteConfigs, tc.countDenied)
+			assert.Nil(err)
+			assert.Len(routeConfigs, 1)
+			assert.Len(routeConfigs[0].VirtualHosts, 2)
+
+			for _, virtualHost := range routeConfigs[0].VirtualHosts {
+				host := virtualHost.Domains[0]
+				assert.Equal([]string{host, host + ":80"}, virtualHost.Domains)
+				assert.Len(virtualHost.Routes, 1)
+				assert.Equal("/", virtualHost.Routes[0].GetMatch().GetPrefix())
+				assert.Equal(uint32(http.StatusForbidden), virtualHost.Routes[0].GetDirectResponse().GetStatus())
+				assert.Nil(virtualHost.Routes[0].GetRoute())
+
+				config, ok := virtualHost.Routes[0].TypedPerFilterConfig[HTTPLocalRateLimitFilterName]
+				if tc.expectedStatPrefixes[host] == "" {
+					assert.False(ok)
+					continue
+				}
+				assert.True(ok)
+				localRateLimit := &xds_http_local_ratelimit.LocalRateLimit{}
+				assert.Nil(ptypes.UnmarshalAny(config, localRateLimit))
+				assert.Equal(tc.expectedStatPrefixes[host], localRateLimit.StatPrefix)
+				assert.Equal(uint32(100), localRateLimit.FilterEnabled.GetDefaultValue().GetNumerator())
+				// The rate limit only counts the requests, they are denied by the route
+				assert.Nil(localRateLimit.FilterEnforced)
+			}
 		})
 	}
 }
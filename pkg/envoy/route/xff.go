@@ -0,0 +1,27 @@
+package route
+
+import (
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+)
+
+const (
+	// xffHeader is the header listing the addresses of the client and of the proxies a request went through
+	xffHeader = "x-forwarded-for"
+
+	// downstreamAddressFormat is the Envoy command operator formatting the address of the client, as determined by
+	// the connection manager of the listener receiving the request, without its port
+	downstreamAddressFormat = "%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%"
+)
+
+// buildXFFOverwriteHeader returns the request header replacing the x-forwarded-for header of the requests matching a
+// route with the address of the client
+func buildXFFOverwriteHeader() *core.HeaderValueOption {
+	return &core.HeaderValueOption{
+		Header: &core.HeaderValue{
+			Key:   xffHeader,
+			Value: downstreamAddressFormat,
+		},
+		Append: &wrappers.BoolValue{Value: false},
+	}
+}
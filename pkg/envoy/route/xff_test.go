@@ -0,0 +1,39 @@
+package route
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildInboundRoutesWithXFFOverwrite(t *testing.T) {
+	assert := tassert.New(t)
+
+	newRule := func(xffOverwrite bool) *trafficpolicy.Rule {
+		return &trafficpolicy.Rule{
+			Route: trafficpolicy.RouteWeightedClusters{
+				HTTPRouteMatch:   trafficpolicy.WildCardRouteMatch,
+				WeightedClusters: mapset.NewSet(service.WeightedCluster{ClusterName: "default/bookstore-v1", Weight: 100}),
+				XFFOverwrite:     xffOverwrite,
+			},
+			AllowedServiceAccounts: mapset.NewSet(identity.K8sServiceAccount{Namespace: "default", Name: "bookbuyer"}.ToServiceIdentity()),
+		}
+	}
+
+	routes := buildInboundRoutes([]*trafficpolicy.Rule{newRule(true), newRule(false)})
+	assert.Len(routes, 2)
+	assert.Equal([]*core.HeaderValueOption{
+		{
+			Header: &core.HeaderValue{Key: "x-forwarded-for", Value: "%DOWNSTREAM_REMOTE_ADDRESS_WITHOUT_PORT%"},
+			Append: &wrappers.BoolValue{Value: false},
+		},
+	}, routes[0].RequestHeadersToAdd)
+	assert.Nil(routes[1].RequestHeadersToAdd)
+}
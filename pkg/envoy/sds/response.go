@@ -1,15 +1,20 @@
 package sds
 
 import (
+	"fmt"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/diagnostics"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -44,6 +49,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, request
 	cert, err := certManager.IssueCertificate(s.serviceIdentity.GetCertificateCommonName(), cfg.GetServiceCertValidityPeriod())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error issuing a certificate for proxy with certificate SerialNumber=%s", proxy.GetCertificateSerialNumber())
+		diagnostics.Record(diagnostics.ErrCertificateIssuance, errors.Errorf("Error issuing certificate %s: %s", s.serviceIdentity.GetCertificateCommonName(), err))
 		return nil, err
 	}
 
@@ -62,6 +68,7 @@ func (s *sdsImpl) getSDSSecrets(cert certificate.Certificater, requestedCerts []
 	// - "root-cert-for-mtls-outbound:namespace/service"
 	// - "root-cert-for-mtls-inbound:namespace/service-service-account"
 	// - "root-cert-for-https:namespace/service-service-account"
+	// - "ingress-backend-tls-cert:namespace/secret"
 
 	// The Envoy makes a request for a list of resources (aka certificates), which we will send as a response to the SDS request.
 	for _, requestedCertificate := range requestedCerts {
@@ -93,12 +100,60 @@ func (s *sdsImpl) getSDSSecrets(cert certificate.Certificater, requestedCerts []
 				continue
 			}
 			certs = append(certs, envoySecret)
+
+		// A TLS Secret served to HTTPS ingress clients is requested
+		case envoy.IngressBackendTLSCertType:
+			envoySecret, err := s.getIngressBackendTLSSecret(*sdsCert, proxy)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error creating cert %s for Envoy with xDS Certificate SerialNumber=%s on Pod with UID=%s",
+					requestedCertificate, proxy.GetCertificateSerialNumber(), proxy.GetPodUID())
+				continue
+			}
+			certs = append(certs, envoySecret)
 		}
 	}
 
 	return certs
 }
 
+// getIngressBackendTLSSecret creates the struct with the certificate and key of the TLS Secret served to HTTPS ingress clients.
+// The TLS Secret must be referenced by one of the services of the proxy, so that proxies can only retrieve the Secrets of their services.
+func (s *sdsImpl) getIngressBackendTLSSecret(sdscert envoy.SDSCert, proxy *envoy.Proxy) (*xds_auth.Secret, error) {
+	services, err := s.meshCatalog.GetServicesForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, svc := range services {
+		secret := s.meshCatalog.GetIngressBackendTLSSecret(svc)
+		if secret == nil || fmt.Sprintf("%s/%s", secret.Namespace, secret.Name) != sdscert.Name {
+			continue
+		}
+
+		return &xds_auth.Secret{
+			// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
+			Name: sdscert.String(),
+			Type: &xds_auth.Secret_TlsCertificate{
+				TlsCertificate: &xds_auth.TlsCertificate{
+					CertificateChain: &xds_core.DataSource{
+						Specifier: &xds_core.DataSource_InlineBytes{
+							InlineBytes: secret.Data[corev1.TLSCertKey],
+						},
+					},
+					PrivateKey: &xds_core.DataSource{
+						Specifier: &xds_core.DataSource_InlineBytes{
+							InlineBytes: secret.Data[corev1.TLSPrivateKeyKey],
+						},
+					},
+				},
+			},
+		}, nil
+	}
+
+	log.Error().Err(errCertMismatch).Msgf("TLS Secret %s is not referenced by the services of proxy with identity %s", sdscert.Name, s.serviceIdentity)
+	return nil, errCertMismatch
+}
+
 // getServiceCertSecret creates the struct with certificates for the service, which the
 // connected Envoy proxy belongs to.
 func getServiceCertSecret(cert certificate.Certificater, name string) (*xds_auth.Secret, error) {
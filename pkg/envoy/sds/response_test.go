@@ -3,22 +3,33 @@ package sds
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/identity"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
 )
 
 // TestNewResponse sets up a fake kube client, then a pod and makes an SDS request,
@@ -233,6 +244,56 @@ func TestGetRootCert(t *testing.T) {
 	}
 }
 
+func TestGetRootCertInboundSPIFFESource(t *testing.T) {
+	require := trequire.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+	mockCertificater := certificate.NewMockCertificater(mockCtrl)
+	mockCertificater.EXPECT().GetIssuingCA().Return([]byte("foo")).Times(1)
+
+	// The bookstore service account allows a service account and a workload outside the cluster with a SPIFFE ID
+	objects := []runtime.Object{
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "bookstore",
+				Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"},
+			},
+		},
+		&smiAccess.TrafficTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+			Spec: smiAccess.TrafficTargetSpec{
+				Destination: smiAccess.IdentityBindingSubject{Kind: "ServiceAccount", Name: "bookstore", Namespace: "bookstore"},
+				Sources: []smiAccess.IdentityBindingSubject{
+					{Kind: "ServiceAccount", Name: "bookbuyer", Namespace: "bookbuyer"},
+					{Kind: identity.SPIFFEIDKind, Name: "spiffe://example.org/vm/billing"},
+				},
+			},
+		},
+	}
+	kubeController, err := k8s.NewStaticController("osm", objects)
+	require.Nil(err)
+	meshSpec, err := smi.NewStaticMeshSpec(kubeController, objects)
+	require.Nil(err)
+	policyController, err := policy.NewStaticController(kubeController, objects)
+	require.Nil(err)
+	meshCatalog := catalog.NewStaticMeshCatalog(kubeController, meshSpec, policyController, []identity.Provider{identity.NewSPIFFEProvider()}, featureflags.OptionalFeatures{}, time.Now(), mockConfigurator)
+
+	svcIdentity := identity.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore"}.ToServiceIdentity()
+	s := &sdsImpl{
+		serviceIdentity: svcIdentity,
+		meshCatalog:     meshCatalog,
+		cfg:             mockConfigurator,
+	}
+
+	sdsSecret, err := s.getRootCert(mockCertificater, envoy.SDSCert{Name: "bookstore/bookstore", CertType: envoy.RootCertTypeForMTLSInbound})
+	require.Nil(err)
+	tassert.ElementsMatch(t, []string{"bookbuyer.bookbuyer.cluster.local", "spiffe://example.org/vm/billing"},
+		subjectAltNamesToStr(sdsSecret.GetValidationContext().GetMatchSubjectAltNames()))
+}
+
 func TestGetServiceCert(t *testing.T) {
 	assert := tassert.New(t)
 	mockCtrl := gomock.NewController(t)
@@ -390,7 +451,54 @@ func TestGetSDSSecrets(t *testing.T) {
 		},
 		// Test case 4 end -------------------------------
 
-		// Test case 5: invalid cert type requested -------------------------------
+		// Test case 5: ingress-backend-tls-cert requested -------------------------------
+		{
+			name:            "test ingress-backend-tls-cert cert type request",
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+
+			prepare: func(d *dynamicMock) {
+				svc := service.MeshService{Name: "service-1", Namespace: "ns-1"}
+				d.mockCatalog.EXPECT().GetServicesForProxy(gomock.Any()).Return([]service.MeshService{svc}, nil).Times(1)
+				d.mockCatalog.EXPECT().GetIngressBackendTLSSecret(svc).Return(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls-secret", Namespace: "ns-1"},
+					Type:       corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       []byte("cert"),
+						corev1.TLSPrivateKeyKey: []byte("key"),
+					},
+				}).Times(1)
+			},
+
+			sdsCertType:    envoy.IngressBackendTLSCertType,
+			requestedCerts: []string{"ingress-backend-tls-cert:ns-1/tls-secret"}, // ingress backend TLS secret requested
+
+			// expectations
+			expectedSANs:        []string{},
+			expectedSecretCount: 1,
+		},
+		// Test case 5 end -------------------------------
+
+		// Test case 6: ingress-backend-tls-cert not referenced by the services of the proxy requested -------------------------------
+		{
+			name:            "test ingress-backend-tls-cert cert type request for a secret not referenced by the proxy's services",
+			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+
+			prepare: func(d *dynamicMock) {
+				svc := service.MeshService{Name: "service-1", Namespace: "ns-1"}
+				d.mockCatalog.EXPECT().GetServicesForProxy(gomock.Any()).Return([]service.MeshService{svc}, nil).Times(1)
+				d.mockCatalog.EXPECT().GetIngressBackendTLSSecret(svc).Return(nil).Times(1)
+			},
+
+			sdsCertType:    envoy.IngressBackendTLSCertType,
+			requestedCerts: []string{"ingress-backend-tls-cert:ns-2/tls-secret"}, // ingress backend TLS secret requested
+
+			// expectations
+			expectedSANs:        []string{},
+			expectedSecretCount: 0, // error is logged and no SDS secret is created
+		},
+		// Test case 6 end -------------------------------
+
+		// Test case 7: invalid cert type requested -------------------------------
 		{
 			name:            "test invalid cert type request",
 			serviceIdentity: identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
@@ -404,7 +512,7 @@ func TestGetSDSSecrets(t *testing.T) {
 			expectedSANs:        []string{},
 			expectedSecretCount: 0, // error is logged and no SDS secret is created
 		},
-		// Test case 5 end -------------------------------
+		// Test case 7 end -------------------------------
 	}
 
 	for i, tc := range testCases {
@@ -459,7 +567,7 @@ func TestGetSDSSecrets(t *testing.T) {
 				// Check trusted CA
 				assert.NotNil(sdsSecret.GetValidationContext().GetTrustedCa().GetInlineBytes())
 
-			case envoy.ServiceCertType:
+			case envoy.ServiceCertType, envoy.IngressBackendTLSCertType:
 				assert.NotNil(sdsSecret.GetTlsCertificate().GetCertificateChain().GetInlineBytes())
 				assert.NotNil(sdsSecret.GetTlsCertificate().GetPrivateKey().GetInlineBytes())
 			}
@@ -0,0 +1,171 @@
+package envoy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// NewerFeature is a configuration feature relying on Envoy extensions more recent than the Envoy versions of the proxies
+// that may still run during a partial upgrade of the sidecars. Newer features are withheld from the proxies running an
+// Envoy version below the minimum supported version, which keep receiving the rest of their configuration.
+type NewerFeature string
+
+const (
+	// FeatureConnectionLimit is the connection limit network filter configured by UpstreamTrafficSetting
+	FeatureConnectionLimit NewerFeature = "connection-limit"
+
+	// FeatureHTTPLocalRateLimit is the HTTP local rate limit filter and its per route rate limits configured by UpstreamTrafficSetting
+	FeatureHTTPLocalRateLimit NewerFeature = "http-local-rate-limit"
+
+	// FeatureLoadShedding is the admission control and adaptive concurrency filters configured by UpstreamTrafficSetting
+	FeatureLoadShedding NewerFeature = "load-shedding"
+
+	// FeatureGRPCStats is the per method gRPC stats filter configured by UpstreamTrafficSetting
+	FeatureGRPCStats NewerFeature = "grpc-stats"
+)
+
+// Version is the semantic version of an Envoy build
+type Version struct {
+	Major uint32
+	Minor uint32
+	Patch uint32
+}
+
+// String returns the version in the form <major>.<minor>.<patch>
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less returns whether the version precedes the given version
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// ParseVersion parses a version of the form <major>.<minor>.<patch> or <major>.<minor>, optionally prefixed with 'v'
+func ParseVersion(version string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, errors.Errorf("Invalid Envoy version %q, must be of the form <major>.<minor>.<patch>", version)
+	}
+
+	var numbers [3]uint32
+	for i, part := range parts {
+		number, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return Version{}, errors.Errorf("Invalid Envoy version %q, must be of the form <major>.<minor>.<patch>", version)
+		}
+		numbers[i] = uint32(number)
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// GetNodeVersion returns the version of the Envoy build of the given Node, and false if the Node does not report it
+func GetNodeVersion(node *xds_core.Node) (Version, bool) {
+	semver := node.GetUserAgentBuildVersion().GetVersion()
+	if semver == nil {
+		return Version{}, false
+	}
+	return Version{Major: semver.MajorNumber, Minor: semver.MinorNumber, Patch: semver.Patch}, true
+}
+
+// IsVersionSupported returns whether the Envoy version of the given proxy is at least the minimum supported version.
+// Proxies whose version is unknown are assumed to be supported, as are all proxies when the minimum supported version
+// is not set or invalid.
+func IsVersionSupported(proxy *Proxy, cfg configurator.Configurator) bool {
+	version := proxy.GetVersion()
+	if version == nil {
+		return true
+	}
+
+	minVersionStr := cfg.GetEnvoyMinSupportedVersion()
+	if minVersionStr == "" {
+		return true
+	}
+	minVersion, err := ParseVersion(minVersionStr)
+	if err != nil {
+		log.Error().Err(err).Msg("Error parsing the minimum supported Envoy version, all versions are supported")
+		return true
+	}
+
+	return !version.Less(minVersion)
+}
+
+// IsFeatureWithheld returns whether the given newer feature must be withheld from the configuration of the given proxy,
+// because the proxy runs an Envoy version below the minimum supported version. A warning is logged the first time a
+// feature is withheld from a proxy, and the proxy is counted among the proxies the feature is withheld from until the
+// feature is configured again or the proxy disconnects.
+func IsFeatureWithheld(proxy *Proxy, cfg configurator.Configurator, feature NewerFeature) bool {
+	if IsVersionSupported(proxy, cfg) {
+		if version, wasWithheld := proxy.withheldFeatures.remove(feature); wasWithheld {
+			metricsstore.DefaultMetricsStore.ProxyUnsupportedVersionWithheldFeatureCount.WithLabelValues(version, string(feature)).Dec()
+		}
+		return false
+	}
+
+	version := proxy.GetVersion().String()
+	if proxy.withheldFeatures.add(feature, version) {
+		log.Warn().Msgf("Proxy SerialNumber=%s PodUID=%s: Envoy version %s is below the minimum supported version %s, not configuring %s",
+			proxy.GetCertificateSerialNumber(), proxy.GetPodUID(), version, cfg.GetEnvoyMinSupportedVersion(), feature)
+		metricsstore.DefaultMetricsStore.ProxyUnsupportedVersionWithheldFeatureCount.WithLabelValues(version, string(feature)).Inc()
+	}
+	return true
+}
+
+// ForgetWithheldFeatures removes the given disconnected proxy from the count of proxies per withheld feature
+func ForgetWithheldFeatures(proxy *Proxy) {
+	for feature, version := range proxy.withheldFeatures.removeAll() {
+		metricsstore.DefaultMetricsStore.ProxyUnsupportedVersionWithheldFeatureCount.WithLabelValues(version, string(feature)).Dec()
+	}
+}
+
+// add records the given feature as withheld for the given Envoy version, and returns false if it was already recorded
+func (w *withheldFeatures) add(feature NewerFeature, version string) bool {
+	if w == nil {
+		return true
+	}
+	w.Lock()
+	defer w.Unlock()
+	if _, ok := w.versions[feature]; ok {
+		return false
+	}
+	w.versions[feature] = version
+	return true
+}
+
+// remove removes the given feature from the withheld features, and returns the Envoy version it was withheld for
+func (w *withheldFeatures) remove(feature NewerFeature) (string, bool) {
+	if w == nil {
+		return "", false
+	}
+	w.Lock()
+	defer w.Unlock()
+	version, ok := w.versions[feature]
+	delete(w.versions, feature)
+	return version, ok
+}
+
+// removeAll removes all the withheld features, and returns them mapped to the Envoy version they were withheld for
+func (w *withheldFeatures) removeAll() map[NewerFeature]string {
+	if w == nil {
+		return nil
+	}
+	w.Lock()
+	defer w.Unlock()
+	versions := w.versions
+	w.versions = make(map[NewerFeature]string)
+	return versions
+}
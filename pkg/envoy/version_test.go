@@ -0,0 +1,162 @@
+package envoy
+
+import (
+	"testing"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestParseVersion(t *testing.T) {
+	testCases := []struct {
+		version         string
+		expectedVersion Version
+		expectErr       bool
+	}{
+		{version: "1.17.2", expectedVersion: Version{Major: 1, Minor: 17, Patch: 2}},
+		{version: "v1.18.3", expectedVersion: Version{Major: 1, Minor: 18, Patch: 3}},
+		{version: "1.16", expectedVersion: Version{Major: 1, Minor: 16}},
+		{version: "1", expectErr: true},
+		{version: "1.17.2.1", expectErr: true},
+		{version: "1.17.x", expectErr: true},
+		{version: "", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			version, err := ParseVersion(tc.version)
+			assert.Equal(tc.expectErr, err != nil)
+			assert.Equal(tc.expectedVersion, version)
+		})
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	assert := tassert.New(t)
+
+	v1172 := Version{Major: 1, Minor: 17, Patch: 2}
+	assert.True(v1172.Less(Version{Major: 1, Minor: 17, Patch: 3}))
+	assert.True(v1172.Less(Version{Major: 1, Minor: 18}))
+	assert.True(v1172.Less(Version{Major: 2}))
+	assert.False(v1172.Less(v1172))
+	assert.False(v1172.Less(Version{Major: 1, Minor: 16, Patch: 5}))
+	assert.Equal("1.17.2", v1172.String())
+}
+
+func TestGetNodeVersion(t *testing.T) {
+	assert := tassert.New(t)
+
+	version, ok := GetNodeVersion(&xds_core.Node{
+		UserAgentVersionType: &xds_core.Node_UserAgentBuildVersion{
+			UserAgentBuildVersion: &xds_core.BuildVersion{
+				Version: &xds_type.SemanticVersion{MajorNumber: 1, MinorNumber: 17, Patch: 2},
+			},
+		},
+	})
+	assert.True(ok)
+	assert.Equal(Version{Major: 1, Minor: 17, Patch: 2}, version)
+
+	_, ok = GetNodeVersion(&xds_core.Node{UserAgentName: "gRPC Go"})
+	assert.False(ok)
+}
+
+func TestIsVersionSupported(t *testing.T) {
+	testCases := []struct {
+		name              string
+		version           *Version
+		minVersion        string
+		expectedSupported bool
+	}{
+		{
+			name:              "unknown version",
+			version:           nil,
+			minVersion:        "1.17.0",
+			expectedSupported: true,
+		},
+		{
+			name:              "no minimum version",
+			version:           &Version{Major: 1, Minor: 16},
+			minVersion:        "",
+			expectedSupported: true,
+		},
+		{
+			name:              "version above the minimum version",
+			version:           &Version{Major: 1, Minor: 17, Patch: 2},
+			minVersion:        "1.17",
+			expectedSupported: true,
+		},
+		{
+			name:              "version below the minimum version",
+			version:           &Version{Major: 1, Minor: 16, Patch: 5},
+			minVersion:        "1.17",
+			expectedSupported: false,
+		},
+		{
+			name:              "invalid minimum version",
+			version:           &Version{Major: 1, Minor: 16, Patch: 5},
+			minVersion:        "latest",
+			expectedSupported: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetEnvoyMinSupportedVersion().Return(tc.minVersion).AnyTimes()
+
+			proxy := NewProxy(certificate.CommonName("abc.bookbuyer.default"), "1", tests.NewMockAddress("1.2.3.4"))
+			if tc.version != nil {
+				proxy.SetVersion(*tc.version)
+			}
+
+			assert.Equal(tc.expectedSupported, IsVersionSupported(proxy, mockConfigurator))
+			assert.Equal(!tc.expectedSupported, IsFeatureWithheld(proxy, mockConfigurator, FeatureConnectionLimit))
+		})
+	}
+}
+
+func TestIsFeatureWithheldCountsProxiesOnce(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	withheldCount := metricsstore.DefaultMetricsStore.ProxyUnsupportedVersionWithheldFeatureCount.WithLabelValues("1.15.4", string(FeatureLoadShedding))
+	initialCount := testutil.ToFloat64(withheldCount)
+
+	proxy := NewProxy(certificate.CommonName("abc.bookbuyer.default"), "1", tests.NewMockAddress("1.2.3.4"))
+	proxy.SetVersion(Version{Major: 1, Minor: 15, Patch: 4})
+
+	minVersion := "1.17.0"
+	mockConfigurator.EXPECT().GetEnvoyMinSupportedVersion().DoAndReturn(func() string { return minVersion }).AnyTimes()
+
+	// The feature is withheld on every recompute, but the proxy is only counted once
+	assert.True(IsFeatureWithheld(proxy, mockConfigurator, FeatureLoadShedding))
+	assert.True(IsFeatureWithheld(proxy, mockConfigurator, FeatureLoadShedding))
+	assert.Equal(initialCount+1, testutil.ToFloat64(withheldCount))
+
+	// The proxy is no longer counted once the feature is configured again
+	minVersion = ""
+	assert.False(IsFeatureWithheld(proxy, mockConfigurator, FeatureLoadShedding))
+	assert.Equal(initialCount, testutil.ToFloat64(withheldCount))
+
+	// or once it disconnects
+	minVersion = "1.17.0"
+	assert.True(IsFeatureWithheld(proxy, mockConfigurator, FeatureLoadShedding))
+	assert.Equal(initialCount+1, testutil.ToFloat64(withheldCount))
+	ForgetWithheldFeatures(proxy)
+	assert.Equal(initialCount, testutil.ToFloat64(withheldCount))
+}
@@ -9,11 +9,13 @@ import (
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -49,6 +51,9 @@ const (
 
 	// RootCertTypeForHTTPS is the prefix for the HTTPS root certificate resource name. Example: "root-cert-https:webservice"
 	RootCertTypeForHTTPS SDSCertType = "root-cert-https"
+
+	// IngressBackendTLSCertType is the prefix for the resource name of a TLS Secret served to HTTPS ingress clients. Example: "ingress-backend-tls-cert:namespace/secret"
+	IngressBackendTLSCertType SDSCertType = "ingress-backend-tls-cert"
 )
 
 const (
@@ -58,16 +63,76 @@ const (
 	// TransportProtocolTLS is the TLS transport protocol used in Envoy configurations
 	TransportProtocolTLS = "tls"
 
+	// TransportProtocolRawBuffer is the plaintext transport protocol used in Envoy configurations
+	TransportProtocolRawBuffer = "raw_buffer"
+
 	// OutboundPassthroughCluster is the outbound passthrough cluster name
 	OutboundPassthroughCluster = "passthrough-outbound"
+
+	// PassthroughTrafficType is the label used in telemetry for traffic proxied to its original destination by the passthrough cluster
+	PassthroughTrafficType = "passthrough"
+
+	// ProxylessGRPCCertProviderInstance is the name of the certificate provider instance defined in the gRPC xDS bootstrap.
+	// It serves the workload certificate and the mesh root certificate to proxyless gRPC clients.
+	ProxylessGRPCCertProviderInstance = "osm-workload"
+)
+
+// Keys of the workload metadata set in the node metadata of Envoy sidecars, and recorded in their access logs
+const (
+	// NodeMetadataPodName is the node metadata key for the name of the pod
+	NodeMetadataPodName = "pod_name"
+
+	// NodeMetadataPodNamespace is the node metadata key for the namespace of the pod
+	NodeMetadataPodNamespace = "pod_namespace"
+
+	// NodeMetadataServiceAccount is the node metadata key for the service account of the pod
+	NodeMetadataServiceAccount = "service_account"
+
+	// NodeMetadataWorkloadKind is the node metadata key for the kind of the controller of the pod
+	NodeMetadataWorkloadKind = "workload_kind"
+
+	// NodeMetadataWorkloadName is the node metadata key for the name of the controller of the pod
+	NodeMetadataWorkloadName = "workload_name"
+
+	// NodeMetadataProxyConfigClass is the node metadata key for the proxy configuration class of the sidecar
+	NodeMetadataProxyConfigClass = "proxy_config_class"
 )
 
+// workloadNodeMetadataKeys are the node metadata keys identifying the workload of a proxy, and the proxy configuration
+// class of its sidecar
+var workloadNodeMetadataKeys = []string{
+	NodeMetadataPodName,
+	NodeMetadataPodNamespace,
+	NodeMetadataServiceAccount,
+	NodeMetadataWorkloadKind,
+	NodeMetadataWorkloadName,
+	NodeMetadataProxyConfigClass,
+}
+
+// Keys of the node metadata of Envoy sidecars set for the features they were injected with
+const (
+	// NodeMetadataEnvoyAdminUDS is the node metadata key set when the admin interface of the sidecar is bound to a Unix domain socket
+	NodeMetadataEnvoyAdminUDS = "envoy_admin_uds"
+
+	// NodeMetadataEgressStatsTags is the node metadata key set when the bootstrap config of the sidecar defines the
+	// stats tags of egress clusters
+	NodeMetadataEgressStatsTags = "egress_stats_tags"
+)
+
+// featureNodeMetadataKeys are the node metadata keys of the features a sidecar was injected with, which are not
+// recorded in its access logs
+var featureNodeMetadataKeys = []string{
+	NodeMetadataEnvoyAdminUDS,
+	NodeMetadataEgressStatsTags,
+}
+
 // Defines valid cert types
 var validCertTypes = map[SDSCertType]interface{}{
 	ServiceCertType:             nil,
 	RootCertTypeForMTLSOutbound: nil,
 	RootCertTypeForMTLSInbound:  nil,
 	RootCertTypeForHTTPS:        nil,
+	IngressBackendTLSCertType:   nil,
 }
 
 // ALPNInMesh indicates that the proxy is connecting to an in-mesh destination.
@@ -130,17 +195,41 @@ func GetAddress(address string, port uint32) *xds_core.Address {
 	}
 }
 
-// GetTLSParams creates Envoy TlsParameters struct.
-func GetTLSParams() *xds_auth.TlsParameters {
+// GetPipeAddress creates an Envoy Address struct for the Unix domain socket at the given path.
+func GetPipeAddress(path string) *xds_core.Address {
+	return &xds_core.Address{
+		Address: &xds_core.Address_Pipe{
+			Pipe: &xds_core.Pipe{
+				Path: path,
+			},
+		},
+	}
+}
+
+// GetTLSParams creates Envoy TlsParameters struct using the TLS protocol versions and cipher suites configured in the mesh.
+func GetTLSParams(cfg configurator.Configurator) *xds_auth.TlsParameters {
 	return &xds_auth.TlsParameters{
-		TlsMinimumProtocolVersion: xds_auth.TlsParameters_TLSv1_2,
-		TlsMaximumProtocolVersion: xds_auth.TlsParameters_TLSv1_3,
+		TlsMinimumProtocolVersion: getTLSProtocol(cfg.GetTLSMinProtocolVersion(), xds_auth.TlsParameters_TLSv1_2),
+		TlsMaximumProtocolVersion: getTLSProtocol(cfg.GetTLSMaxProtocolVersion(), xds_auth.TlsParameters_TLSv1_3),
+		CipherSuites:              cfg.GetTLSCipherSuites(),
+	}
+}
+
+// getTLSProtocol returns the Envoy TLS protocol corresponding to the given TLS protocol version, or the given default
+// protocol if the version is invalid
+func getTLSProtocol(version string, defaultProtocol xds_auth.TlsParameters_TlsProtocol) xds_auth.TlsParameters_TlsProtocol {
+	protocol, ok := xds_auth.TlsParameters_TlsProtocol_value[version]
+	if !ok {
+		log.Error().Msgf("Invalid TLS protocol version %s, using %s instead", version, defaultProtocol)
+		return defaultProtocol
 	}
+	return xds_auth.TlsParameters_TlsProtocol(protocol)
 }
 
 // GetAccessLog creates an Envoy AccessLog struct.
-func GetAccessLog() []*xds_accesslog_filter.AccessLog {
-	accessLog, err := ptypes.MarshalAny(getFileAccessLog())
+// Entries are labeled with the given workload metadata of the proxy, if any.
+func GetAccessLog(workloadMetadata map[string]string) []*xds_accesslog_filter.AccessLog {
+	accessLog, err := ptypes.MarshalAny(getFileAccessLog(workloadMetadata))
 	if err != nil {
 		log.Error().Err(err).Msg("Error marshalling AccessLog object")
 		return nil
@@ -153,7 +242,50 @@ func GetAccessLog() []*xds_accesslog_filter.AccessLog {
 	}
 }
 
-func getFileAccessLog() *xds_accesslog.FileAccessLog {
+// GetPassthroughAccessLog creates an Envoy AccessLog struct for traffic proxied to its original destination
+// by the passthrough cluster. Entries are labeled with the "passthrough" traffic type and record the destination of the traffic.
+func GetPassthroughAccessLog() []*xds_accesslog_filter.AccessLog {
+	accessLog, err := ptypes.MarshalAny(getPassthroughFileAccessLog())
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling passthrough AccessLog object")
+		return nil
+	}
+	return []*xds_accesslog_filter.AccessLog{{
+		Name: wellknown.FileAccessLog,
+		ConfigType: &xds_accesslog_filter.AccessLog_TypedConfig{
+			TypedConfig: accessLog,
+		}},
+	}
+}
+
+func getPassthroughFileAccessLog() *xds_accesslog.FileAccessLog {
+	return &xds_accesslog.FileAccessLog{
+		Path: accessLogPath,
+		AccessLogFormat: &xds_accesslog.FileAccessLog_LogFormat{
+			LogFormat: &xds_core.SubstitutionFormatString{
+				Format: &xds_core.SubstitutionFormatString_JsonFormat{
+					JsonFormat: &structpb.Struct{
+						Fields: map[string]*structpb.Value{
+							"traffic_type":          pbStringValue(PassthroughTrafficType),
+							"start_time":            pbStringValue(`%START_TIME%`),
+							"upstream_cluster":      pbStringValue(`%UPSTREAM_CLUSTER%`),
+							"upstream_host":         pbStringValue(`%UPSTREAM_HOST%`),
+							"original_destination":  pbStringValue(`%DOWNSTREAM_LOCAL_ADDRESS%`),
+							"downstream_address":    pbStringValue(`%DOWNSTREAM_REMOTE_ADDRESS%`),
+							"requested_server_name": pbStringValue(`%REQUESTED_SERVER_NAME%`),
+							"response_flags":        pbStringValue(`%RESPONSE_FLAGS%`),
+							"bytes_received":        pbStringValue(`%BYTES_RECEIVED%`),
+							"bytes_sent":            pbStringValue(`%BYTES_SENT%`),
+							"duration":              pbStringValue(`%DURATION%`),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getFileAccessLog(workloadMetadata map[string]string) *xds_accesslog.FileAccessLog {
 	accessLogger := &xds_accesslog.FileAccessLog{
 		Path: accessLogPath,
 		AccessLogFormat: &xds_accesslog.FileAccessLog_LogFormat{
@@ -186,6 +318,15 @@ func getFileAccessLog() *xds_accesslog.FileAccessLog {
 			},
 		},
 	}
+
+	// The workload metadata does not change for the lifetime of the proxy, so it is logged as literal values
+	fields := accessLogger.GetLogFormat().GetJsonFormat().GetFields()
+	for _, key := range workloadNodeMetadataKeys {
+		if value, ok := workloadMetadata[key]; ok {
+			fields[key] = pbStringValue(value)
+		}
+	}
+
 	return accessLogger
 }
 
@@ -200,9 +341,9 @@ func pbStringValue(v string) *structpb.Value {
 // getCommonTLSContext returns a CommonTlsContext type for a given 'tlsSDSCert' and 'peerValidationSDSCert' pair.
 // 'tlsSDSCert' determines the SDS Secret config used to present the TLS certificate.
 // 'peerValidationSDSCert' determines the SDS Secret configs used to validate the peer TLS certificate.
-func getCommonTLSContext(tlsSDSCert, peerValidationSDSCert SDSCert) *xds_auth.CommonTlsContext {
+func getCommonTLSContext(tlsSDSCert, peerValidationSDSCert SDSCert, cfg configurator.Configurator) *xds_auth.CommonTlsContext {
 	return &xds_auth.CommonTlsContext{
-		TlsParams: GetTLSParams(),
+		TlsParams: GetTLSParams(cfg),
 		TlsCertificateSdsSecretConfigs: []*xds_auth.SdsSecretConfig{{
 			// Example ==> Name: "service-cert:NameSpaceHere/ServiceNameHere"
 			Name:      tlsSDSCert.String(),
@@ -220,7 +361,7 @@ func getCommonTLSContext(tlsSDSCert, peerValidationSDSCert SDSCert) *xds_auth.Co
 
 // GetDownstreamTLSContext creates a downstream Envoy TLS Context to be configured on the upstream for the given upstream's identity
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
-func GetDownstreamTLSContext(upstreamIdentity identity.ServiceIdentity, mTLS bool) *xds_auth.DownstreamTlsContext {
+func GetDownstreamTLSContext(upstreamIdentity identity.ServiceIdentity, mTLS bool, cfg configurator.Configurator) *xds_auth.DownstreamTlsContext {
 	upstreamSDSCert := SDSCert{
 		Name:     upstreamIdentity.GetSDSCSecretName(),
 		CertType: ServiceCertType,
@@ -245,16 +386,37 @@ func GetDownstreamTLSContext(upstreamIdentity identity.ServiceIdentity, mTLS boo
 	}
 
 	tlsConfig := &xds_auth.DownstreamTlsContext{
-		CommonTlsContext: getCommonTLSContext(upstreamSDSCert, downstreamPeerValidationSDSCert),
+		CommonTlsContext: getCommonTLSContext(upstreamSDSCert, downstreamPeerValidationSDSCert, cfg),
 		// When RequireClientCertificate is enabled trusted CA certs must be provided via ValidationContextType
 		RequireClientCertificate: &wrappers.BoolValue{Value: mTLS},
 	}
 	return tlsConfig
 }
 
+// GetIngressBackendDownstreamTLSContext creates a downstream Envoy TLS Context serving the given TLS Secret to HTTPS ingress clients.
+// Ingress clients are not required to present a client certificate.
+func GetIngressBackendDownstreamTLSContext(secretNamespace, secretName string, cfg configurator.Configurator) *xds_auth.DownstreamTlsContext {
+	secretSDSCert := SDSCert{
+		Name:     fmt.Sprintf("%s/%s", secretNamespace, secretName),
+		CertType: IngressBackendTLSCertType,
+	}
+
+	return &xds_auth.DownstreamTlsContext{
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsParams: GetTLSParams(cfg),
+			TlsCertificateSdsSecretConfigs: []*xds_auth.SdsSecretConfig{{
+				// Example ==> Name: "ingress-backend-tls-cert:NameSpaceHere/SecretNameHere"
+				Name:      secretSDSCert.String(),
+				SdsConfig: GetADSConfigSource(),
+			}},
+		},
+		RequireClientCertificate: &wrappers.BoolValue{Value: false},
+	}
+}
+
 // GetUpstreamTLSContext creates an upstream Envoy TLS Context for the given downstream identity and upstream service pair
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
-func GetUpstreamTLSContext(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService) *xds_auth.UpstreamTlsContext {
+func GetUpstreamTLSContext(downstreamIdentity identity.ServiceIdentity, upstreamSvc service.MeshService, cfg configurator.Configurator) *xds_auth.UpstreamTlsContext {
 	downstreamSDSCert := SDSCert{
 		Name:     downstreamIdentity.GetSDSCSecretName(),
 		CertType: ServiceCertType,
@@ -263,10 +425,10 @@ func GetUpstreamTLSContext(downstreamIdentity identity.ServiceIdentity, upstream
 		Name:     upstreamSvc.String(),
 		CertType: RootCertTypeForMTLSOutbound,
 	}
-	commonTLSContext := getCommonTLSContext(downstreamSDSCert, upstreamPeerValidationSDSCert)
+	commonTLSContext := getCommonTLSContext(downstreamSDSCert, upstreamPeerValidationSDSCert, cfg)
 
-	// Advertise in-mesh using UpstreamTlsContext.CommonTlsContext.AlpnProtocols
-	commonTLSContext.AlpnProtocols = ALPNInMesh
+	// Advertise in-mesh using UpstreamTlsContext.CommonTlsContext.AlpnProtocols, followed by the ALPN protocols configured in the mesh
+	commonTLSContext.AlpnProtocols = append(append([]string{}, ALPNInMesh...), cfg.GetTLSALPNProtocols()...)
 	tlsConfig := &xds_auth.UpstreamTlsContext{
 		CommonTlsContext: commonTLSContext,
 
@@ -278,6 +440,38 @@ func GetUpstreamTLSContext(downstreamIdentity identity.ServiceIdentity, upstream
 	return tlsConfig
 }
 
+// GetProxylessGRPCUpstreamTLSContext creates an upstream TLS Context for a proxyless gRPC client connecting to the given upstream service.
+// gRPC clients do not support SDS, so certificates are sourced from the certificate provider instance defined in the gRPC xDS bootstrap.
+func GetProxylessGRPCUpstreamTLSContext(upstreamSvc service.MeshService, upstreamIdentities []identity.ServiceIdentity) *xds_auth.UpstreamTlsContext {
+	var matchSANs []*xds_matcher.StringMatcher
+	for _, si := range upstreamIdentities {
+		matchSANs = append(matchSANs, &xds_matcher.StringMatcher{
+			MatchPattern: &xds_matcher.StringMatcher_Exact{
+				Exact: si.String(),
+			},
+		})
+	}
+
+	certProviderInstance := &xds_auth.CommonTlsContext_CertificateProviderInstance{
+		InstanceName: ProxylessGRPCCertProviderInstance,
+	}
+
+	return &xds_auth.UpstreamTlsContext{
+		CommonTlsContext: &xds_auth.CommonTlsContext{
+			TlsCertificateCertificateProviderInstance: certProviderInstance,
+			ValidationContextType: &xds_auth.CommonTlsContext_CombinedValidationContext{
+				CombinedValidationContext: &xds_auth.CommonTlsContext_CombinedCertificateValidationContext{
+					DefaultValidationContext: &xds_auth.CertificateValidationContext{
+						MatchSubjectAltNames: matchSANs,
+					},
+					ValidationContextCertificateProviderInstance: certProviderInstance,
+				},
+			},
+		},
+		Sni: upstreamSvc.ServerName(),
+	}
+}
+
 // GetADSConfigSource creates an Envoy ConfigSource struct.
 func GetADSConfigSource() *xds_core.ConfigSource {
 	return &xds_core.ConfigSource{
@@ -304,6 +498,36 @@ func GetEnvoyServiceNodeID(nodeID, workloadKind, workloadName string) string {
 	return strings.Join(items, constants.EnvoyServiceNodeSeparator)
 }
 
+// GetEnvoyNodeMetadataConfig returns the bootstrap config setting the workload metadata of an Envoy sidecar in its node metadata.
+// The config is passed to Envoy with the --config-yaml option, which is merged with the bootstrap config file,
+// and references the environment variables of the sidecar container that are expanded by Kubernetes.
+// The proxy configuration class is only set when the sidecar was injected with the settings of a class, and the keys
+// of the given features the sidecar was injected with are set to "true".
+func GetEnvoyNodeMetadataConfig(workloadKind, workloadName, proxyConfigClass string, features ...string) string {
+	var optionalFields string
+	if proxyConfigClass != "" {
+		optionalFields = fmt.Sprintf(`,"%s":"%s"`, NodeMetadataProxyConfigClass, proxyConfigClass)
+	}
+	for _, feature := range features {
+		optionalFields += fmt.Sprintf(`,"%s":"true"`, feature)
+	}
+	return fmt.Sprintf(`{"node":{"metadata":{"%s":"$(POD_NAME)","%s":"$(POD_NAMESPACE)","%s":"$(SERVICE_ACCOUNT)","%s":"%s","%s":"%s"%s}}}`,
+		NodeMetadataPodName, NodeMetadataPodNamespace, NodeMetadataServiceAccount,
+		NodeMetadataWorkloadKind, workloadKind, NodeMetadataWorkloadName, workloadName, optionalFields)
+}
+
+// GetWorkloadNodeMetadata returns the workload metadata, and the features the sidecar was injected with, set in the
+// given node metadata of an Envoy sidecar
+func GetWorkloadNodeMetadata(nodeMetadata *structpb.Struct) map[string]string {
+	workloadMetadata := make(map[string]string)
+	for _, key := range append(workloadNodeMetadataKeys, featureNodeMetadataKeys...) {
+		if value := nodeMetadata.GetFields()[key].GetStringValue(); value != "" {
+			workloadMetadata[key] = value
+		}
+	}
+	return workloadMetadata
+}
+
 // ParseEnvoyServiceNodeID parses the given Envoy service node ID and returns the encoded metadata
 func ParseEnvoyServiceNodeID(serviceNodeID string) (*PodMetadata, error) {
 	chunks := strings.Split(serviceNodeID, constants.EnvoyServiceNodeSeparator)
@@ -7,6 +7,7 @@ import (
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_accesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/file/v3"
 	auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/mock/gomock"
 	structpb "github.com/golang/protobuf/ptypes/struct"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	tassert "github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -28,10 +30,22 @@ func TestGetLocalClusterNameForService(t *testing.T) {
 func TestGetAccessLog(t *testing.T) {
 	assert := tassert.New(t)
 
-	res := GetAccessLog()
+	res := GetAccessLog(nil)
 	assert.NotNil(res)
 }
 
+func TestGetPassthroughAccessLog(t *testing.T) {
+	assert := tassert.New(t)
+
+	res := GetPassthroughAccessLog()
+	assert.Len(res, 1)
+
+	fields := getPassthroughFileAccessLog().GetLogFormat().GetJsonFormat().GetFields()
+	assert.Equal(PassthroughTrafficType, fields["traffic_type"].GetStringValue())
+	assert.Equal(`%DOWNSTREAM_LOCAL_ADDRESS%`, fields["original_destination"].GetStringValue())
+	assert.Equal(`%UPSTREAM_HOST%`, fields["upstream_host"].GetStringValue())
+}
+
 func TestGetFileAccessLog(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -67,12 +81,157 @@ func TestGetFileAccessLog(t *testing.T) {
 			},
 		},
 	}
-	resAccessLogger := getFileAccessLog()
+	resAccessLogger := getFileAccessLog(nil)
 
 	assert.Equal(resAccessLogger, expAccessLogger)
 }
 
+func TestGetFileAccessLogWithWorkloadMetadata(t *testing.T) {
+	assert := tassert.New(t)
+
+	workloadMetadata := map[string]string{
+		NodeMetadataPodName:        "bookbuyer-1234",
+		NodeMetadataPodNamespace:   "bookbuyer-ns",
+		NodeMetadataServiceAccount: "bookbuyer",
+		NodeMetadataWorkloadKind:   "Deployment",
+		NodeMetadataWorkloadName:   "bookbuyer",
+		"unknown":                  "ignored",
+	}
+
+	fields := getFileAccessLog(workloadMetadata).GetLogFormat().GetJsonFormat().GetFields()
+	assert.Equal("bookbuyer-1234", fields[NodeMetadataPodName].GetStringValue())
+	assert.Equal("bookbuyer-ns", fields[NodeMetadataPodNamespace].GetStringValue())
+	assert.Equal("bookbuyer", fields[NodeMetadataServiceAccount].GetStringValue())
+	assert.Equal("Deployment", fields[NodeMetadataWorkloadKind].GetStringValue())
+	assert.Equal("bookbuyer", fields[NodeMetadataWorkloadName].GetStringValue())
+	assert.NotContains(fields, "unknown")
+	assert.Equal(`%RESPONSE_CODE%`, fields["response_code"].GetStringValue())
+}
+
+func TestGetEnvoyNodeMetadataConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	actual := GetEnvoyNodeMetadataConfig("Deployment", "bookbuyer", "")
+	expected := `{"node":{"metadata":{"pod_name":"$(POD_NAME)","pod_namespace":"$(POD_NAMESPACE)","service_account":"$(SERVICE_ACCOUNT)","workload_kind":"Deployment","workload_name":"bookbuyer"}}}`
+	assert.Equal(expected, actual)
+
+	actual = GetEnvoyNodeMetadataConfig("Deployment", "bookbuyer", "canary")
+	expected = `{"node":{"metadata":{"pod_name":"$(POD_NAME)","pod_namespace":"$(POD_NAMESPACE)","service_account":"$(SERVICE_ACCOUNT)","workload_kind":"Deployment","workload_name":"bookbuyer","proxy_config_class":"canary"}}}`
+	assert.Equal(expected, actual)
+
+	actual = GetEnvoyNodeMetadataConfig("Deployment", "bookbuyer", "", NodeMetadataEnvoyAdminUDS)
+	expected = `{"node":{"metadata":{"pod_name":"$(POD_NAME)","pod_namespace":"$(POD_NAMESPACE)","service_account":"$(SERVICE_ACCOUNT)","workload_kind":"Deployment","workload_name":"bookbuyer","envoy_admin_uds":"true"}}}`
+	assert.Equal(expected, actual)
+}
+
+func TestGetWorkloadNodeMetadata(t *testing.T) {
+	assert := tassert.New(t)
+
+	nodeMetadata := &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			NodeMetadataPodName:          pbStringValue("bookbuyer-1234"),
+			NodeMetadataWorkloadKind:     pbStringValue("Deployment"),
+			NodeMetadataProxyConfigClass: pbStringValue("canary"),
+			NodeMetadataEnvoyAdminUDS:    pbStringValue("true"),
+			"other":                      pbStringValue("ignored"),
+		},
+	}
+
+	assert.Equal(map[string]string{
+		NodeMetadataPodName:          "bookbuyer-1234",
+		NodeMetadataWorkloadKind:     "Deployment",
+		NodeMetadataProxyConfigClass: "canary",
+		NodeMetadataEnvoyAdminUDS:    "true",
+	}, GetWorkloadNodeMetadata(nodeMetadata))
+	assert.Empty(GetWorkloadNodeMetadata(nil))
+}
+
+func TestGetTLSParams(t *testing.T) {
+	testCases := []struct {
+		name              string
+		minVersion        string
+		maxVersion        string
+		cipherSuites      []string
+		expectedTLSParams *auth.TlsParameters
+	}{
+		{
+			name:       "default TLS parameters",
+			minVersion: "TLSv1_2",
+			maxVersion: "TLSv1_3",
+			expectedTLSParams: &auth.TlsParameters{
+				TlsMinimumProtocolVersion: auth.TlsParameters_TLSv1_2,
+				TlsMaximumProtocolVersion: auth.TlsParameters_TLSv1_3,
+			},
+		},
+		{
+			name:         "custom TLS versions and cipher suites",
+			minVersion:   "TLSv1_2",
+			maxVersion:   "TLSv1_2",
+			cipherSuites: []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+			expectedTLSParams: &auth.TlsParameters{
+				TlsMinimumProtocolVersion: auth.TlsParameters_TLSv1_2,
+				TlsMaximumProtocolVersion: auth.TlsParameters_TLSv1_2,
+				CipherSuites:              []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+			},
+		},
+		{
+			name:       "invalid TLS versions fall back to the defaults",
+			minVersion: "TLSv1_9",
+			maxVersion: "",
+			expectedTLSParams: &auth.TlsParameters{
+				TlsMinimumProtocolVersion: auth.TlsParameters_TLSv1_2,
+				TlsMaximumProtocolVersion: auth.TlsParameters_TLSv1_3,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return(tc.minVersion).Times(1)
+			mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return(tc.maxVersion).Times(1)
+			mockConfigurator.EXPECT().GetTLSCipherSuites().Return(tc.cipherSuites).Times(1)
+
+			assert.Equal(tc.expectedTLSParams, GetTLSParams(mockConfigurator))
+		})
+	}
+}
+
+func TestGetUpstreamTLSContextALPNProtocols(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").Times(1)
+	mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").Times(1)
+	mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).Times(1)
+	mockConfigurator.EXPECT().GetTLSALPNProtocols().Return([]string{"h2", "http/1.1"}).Times(1)
+
+	tlsContext := GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, mockConfigurator)
+	assert.Equal([]string{"osm", "h2", "http/1.1"}, tlsContext.CommonTlsContext.AlpnProtocols)
+	assert.Equal([]string{"osm"}, ALPNInMesh)
+}
+
 var _ = Describe("Test Envoy tools", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetTLSMinProtocolVersion().Return("TLSv1_2").AnyTimes()
+		mockConfigurator.EXPECT().GetTLSMaxProtocolVersion().Return("TLSv1_3").AnyTimes()
+		mockConfigurator.EXPECT().GetTLSCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetTLSALPNProtocols().Return(nil).AnyTimes()
+	})
+
 	Context("Test GetLocalClusterNameForServiceCluster", func() {
 		It("", func() {
 			clusterName := "-cluster-name-"
@@ -104,6 +263,23 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetPipeAddress()", func() {
+		It("should return pipe address", func() {
+			path := "/var/run/blah.sock"
+			actual := GetPipeAddress(path)
+
+			expected := &core.Address{
+				Address: &core.Address_Pipe{
+					Pipe: &core.Pipe{
+						Path: path,
+					},
+				},
+			}
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
 	Context("Test UnmarshalSDSCert()", func() {
 		It("Interface marshals and unmarshals preserving the exact same data", func() {
 			InitialObj := SDSCert{
@@ -142,6 +318,13 @@ var _ = Describe("Test Envoy tools", func() {
 			Expect(actual.Name).To(Equal("namespace-test/blahBlahBlahCert"))
 		})
 
+		It("returns ingress backend TLS cert", func() {
+			actual, err := UnmarshalSDSCert("ingress-backend-tls-cert:namespace-test/blahBlahBlahSecret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.CertType).To(Equal(IngressBackendTLSCertType))
+			Expect(actual.Name).To(Equal("namespace-test/blahBlahBlahSecret"))
+		})
+
 		It("returns an error (invalid formatting)", func() {
 			_, err := UnmarshalSDSCert("blahBlahBlahCert")
 			Expect(err).To(HaveOccurred())
@@ -176,7 +359,7 @@ var _ = Describe("Test Envoy tools", func() {
 	Context("Test GetDownstreamTLSContext()", func() {
 		It("should return TLS context", func() {
 			svcAccount := identity.K8sServiceAccount{Name: "foo", Namespace: "test"}
-			tlsContext := GetDownstreamTLSContext(svcAccount.ToServiceIdentity(), true)
+			tlsContext := GetDownstreamTLSContext(svcAccount.ToServiceIdentity(), true, mockConfigurator)
 
 			expectedTLSContext := &auth.DownstreamTlsContext{
 				CommonTlsContext: &auth.CommonTlsContext{
@@ -222,22 +405,32 @@ var _ = Describe("Test Envoy tools", func() {
 
 	Context("Test GetDownstreamTLSContext() for mTLS", func() {
 		It("should return TLS context with client certificate validation enabled", func() {
-			tlsContext := GetDownstreamTLSContext(tests.BookstoreServiceIdentity, true)
+			tlsContext := GetDownstreamTLSContext(tests.BookstoreServiceIdentity, true, mockConfigurator)
 			Expect(tlsContext.RequireClientCertificate).To(Equal(&wrappers.BoolValue{Value: true}))
 		})
 	})
 
 	Context("Test GetDownstreamTLSContext() for TLS", func() {
 		It("should return TLS context with client certificate validation disabled", func() {
-			tlsContext := GetDownstreamTLSContext(tests.BookstoreServiceIdentity, false)
+			tlsContext := GetDownstreamTLSContext(tests.BookstoreServiceIdentity, false, mockConfigurator)
+			Expect(tlsContext.RequireClientCertificate).To(Equal(&wrappers.BoolValue{Value: false}))
+		})
+	})
+
+	Context("Test GetIngressBackendDownstreamTLSContext()", func() {
+		It("should return TLS context serving the given secret without client certificate validation", func() {
+			tlsContext := GetIngressBackendDownstreamTLSContext("bookstore-ns", "bookstore-tls", mockConfigurator)
 			Expect(tlsContext.RequireClientCertificate).To(Equal(&wrappers.BoolValue{Value: false}))
+			Expect(tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs).To(HaveLen(1))
+			Expect(tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs[0].Name).To(Equal("ingress-backend-tls-cert:bookstore-ns/bookstore-tls"))
+			Expect(tlsContext.CommonTlsContext.ValidationContextType).To(BeNil())
 		})
 	})
 
 	Context("Test GetUpstreamTLSContext()", func() {
 		It("should return TLS context", func() {
 			sni := "bookstore-v1.default.svc.cluster.local"
-			tlsContext := GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service)
+			tlsContext := GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, mockConfigurator)
 
 			expectedTLSContext := &auth.UpstreamTlsContext{
 				CommonTlsContext: &auth.CommonTlsContext{
@@ -286,12 +479,28 @@ var _ = Describe("Test Envoy tools", func() {
 
 	Context("Test GetUpstreamTLSContext()", func() {
 		It("creates correct UpstreamTlsContext.Sni field", func() {
-			tlsContext := GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service)
+			tlsContext := GetUpstreamTLSContext(tests.BookbuyerServiceIdentity, tests.BookstoreV1Service, mockConfigurator)
 			// To show the actual string for human comprehension
 			Expect(tlsContext.Sni).To(Equal(tests.BookstoreV1Service.ServerName()))
 		})
 	})
 
+	Context("Test GetProxylessGRPCUpstreamTLSContext()", func() {
+		It("sources certificates from the certificate provider instance and validates upstream SANs", func() {
+			tlsContext := GetProxylessGRPCUpstreamTLSContext(tests.BookstoreV1Service, []identity.ServiceIdentity{tests.BookstoreServiceIdentity})
+
+			Expect(tlsContext.Sni).To(Equal(tests.BookstoreV1Service.ServerName()))
+			Expect(tlsContext.CommonTlsContext.TlsCertificateCertificateProviderInstance.InstanceName).To(Equal(ProxylessGRPCCertProviderInstance))
+			Expect(tlsContext.CommonTlsContext.TlsCertificateSdsSecretConfigs).To(BeNil())
+
+			combined := tlsContext.CommonTlsContext.GetCombinedValidationContext()
+			Expect(combined).ToNot(BeNil())
+			Expect(combined.ValidationContextCertificateProviderInstance.InstanceName).To(Equal(ProxylessGRPCCertProviderInstance))
+			Expect(combined.DefaultValidationContext.MatchSubjectAltNames).To(HaveLen(1))
+			Expect(combined.DefaultValidationContext.MatchSubjectAltNames[0].GetExact()).To(Equal(tests.BookstoreServiceIdentity.String()))
+		})
+	})
+
 	Context("Test pbStringValue()", func() {
 		It("returns structpb", func() {
 			exp := &structpb.Value{
@@ -315,10 +524,10 @@ var _ = Describe("Test Envoy tools", func() {
 				CertType: RootCertTypeForMTLSOutbound,
 			}
 
-			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert)
+			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert, mockConfigurator)
 
 			expected := &auth.CommonTlsContext{
-				TlsParams: GetTLSParams(),
+				TlsParams: GetTLSParams(mockConfigurator),
 				TlsCertificateSdsSecretConfigs: []*auth.SdsSecretConfig{{
 					Name:      "service-cert:default/bookbuyer",
 					SdsConfig: GetADSConfigSource(),
@@ -345,10 +554,10 @@ var _ = Describe("Test Envoy tools", func() {
 				CertType: RootCertTypeForMTLSInbound,
 			}
 
-			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert)
+			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert, mockConfigurator)
 
 			expected := &auth.CommonTlsContext{
-				TlsParams: GetTLSParams(),
+				TlsParams: GetTLSParams(mockConfigurator),
 				TlsCertificateSdsSecretConfigs: []*auth.SdsSecretConfig{{
 					Name:      "service-cert:default/bookstore-v1",
 					SdsConfig: GetADSConfigSource(),
@@ -375,10 +584,10 @@ var _ = Describe("Test Envoy tools", func() {
 				CertType: RootCertTypeForHTTPS,
 			}
 
-			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert)
+			actual := getCommonTLSContext(tlsSDSCert, peerValidationSDSCert, mockConfigurator)
 
 			expected := &auth.CommonTlsContext{
-				TlsParams: GetTLSParams(),
+				TlsParams: GetTLSParams(mockConfigurator),
 				TlsCertificateSdsSecretConfigs: []*auth.SdsSecretConfig{{
 					Name:      "service-cert:default/bookstore-v1",
 					SdsConfig: GetADSConfigSource(),
@@ -7,8 +7,13 @@ import (
 
 // OptionalFeatures is a struct to enable/disable optional features
 type OptionalFeatures struct {
-	WASMStats    bool
-	EgressPolicy bool
+	WASMStats           bool
+	EgressPolicy        bool
+	ProxylessGRPC       bool
+	ProgressiveDelivery bool
+	EnvoyAdminUDS       bool
+	IngressGateway      bool
+	SidecarSizing       bool
 }
 
 var (
@@ -41,3 +46,28 @@ func IsWASMStatsEnabled() bool {
 func IsEgressPolicyEnabled() bool {
 	return Features.EgressPolicy
 }
+
+// IsProxylessGRPCEnabled returns a boolean indicating if gRPC applications using the xDS client can connect to OSM without a sidecar
+func IsProxylessGRPCEnabled() bool {
+	return Features.ProxylessGRPC
+}
+
+// IsProgressiveDeliveryEnabled returns a boolean indicating if OSM automatically shifts the weights of TrafficSplits annotated for progressive delivery
+func IsProgressiveDeliveryEnabled() bool {
+	return Features.ProgressiveDelivery
+}
+
+// IsEnvoyAdminUDSEnabled returns a boolean indicating if the admin interface of injected Envoy sidecars is bound to a Unix domain socket
+func IsEnvoyAdminUDSEnabled() bool {
+	return Features.EnvoyAdminUDS
+}
+
+// IsIngressGatewayEnabled returns a boolean indicating if the OSM managed ingress gateway is programmed by the control plane
+func IsIngressGatewayEnabled() bool {
+	return Features.IngressGateway
+}
+
+// IsSidecarSizingEnabled returns a boolean indicating if OSM recommends the resource requests of the Envoy sidecars of each namespace
+func IsSidecarSizingEnabled() bool {
+	return Features.SidecarSizing
+}
@@ -12,22 +12,47 @@ func TestFlags(t *testing.T) {
 	// 1. Verify all optional features are disabled by default
 	assert.Equal(false, IsWASMStatsEnabled())
 	assert.Equal(false, IsEgressPolicyEnabled())
+	assert.Equal(false, IsProxylessGRPCEnabled())
+	assert.Equal(false, IsProgressiveDeliveryEnabled())
+	assert.Equal(false, IsEnvoyAdminUDSEnabled())
+	assert.Equal(false, IsIngressGatewayEnabled())
+	assert.Equal(false, IsSidecarSizingEnabled())
 
 	// 2. Enable all optional features and verify they are enabled
 	optionalFeatures := OptionalFeatures{
-		WASMStats:    true,
-		EgressPolicy: true,
+		WASMStats:           true,
+		EgressPolicy:        true,
+		ProxylessGRPC:       true,
+		ProgressiveDelivery: true,
+		EnvoyAdminUDS:       true,
+		IngressGateway:      true,
+		SidecarSizing:       true,
 	}
 	Initialize(optionalFeatures)
 	assert.Equal(true, IsWASMStatsEnabled())
 	assert.Equal(true, IsEgressPolicyEnabled())
+	assert.Equal(true, IsProxylessGRPCEnabled())
+	assert.Equal(true, IsProgressiveDeliveryEnabled())
+	assert.Equal(true, IsEnvoyAdminUDSEnabled())
+	assert.Equal(true, IsIngressGatewayEnabled())
+	assert.Equal(true, IsSidecarSizingEnabled())
 
 	// 3. Verify features cannot be reinitialized
 	optionalFeatures = OptionalFeatures{
-		WASMStats:    false,
-		EgressPolicy: false,
+		WASMStats:           false,
+		EgressPolicy:        false,
+		ProxylessGRPC:       false,
+		ProgressiveDelivery: false,
+		EnvoyAdminUDS:       false,
+		IngressGateway:      false,
+		SidecarSizing:       false,
 	}
 	Initialize(optionalFeatures)
 	assert.Equal(true, IsWASMStatsEnabled())
 	assert.Equal(true, IsEgressPolicyEnabled())
+	assert.Equal(true, IsProxylessGRPCEnabled())
+	assert.Equal(true, IsProgressiveDeliveryEnabled())
+	assert.Equal(true, IsEnvoyAdminUDSEnabled())
+	assert.Equal(true, IsIngressGatewayEnabled())
+	assert.Equal(true, IsSidecarSizingEnabled())
 }
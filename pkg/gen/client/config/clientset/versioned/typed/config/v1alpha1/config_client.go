@@ -24,6 +24,7 @@ import (
 type ConfigV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	MeshConfigsGetter
+	MeshDiagnosticsGetter
 }
 
 // ConfigV1alpha1Client is used to interact with features provided by the config.openservicemesh.io group.
@@ -35,6 +36,10 @@ func (c *ConfigV1alpha1Client) MeshConfigs(namespace string) MeshConfigInterface
 	return newMeshConfigs(c, namespace)
 }
 
+func (c *ConfigV1alpha1Client) MeshDiagnostics() MeshDiagnosticInterface {
+	return newMeshDiagnostics(c)
+}
+
 // NewForConfig creates a new ConfigV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*ConfigV1alpha1Client, error) {
 	config := *c
@@ -16,3 +16,5 @@ limitations under the License.
 package v1alpha1
 
 type MeshConfigExpansion interface{}
+
+type MeshDiagnosticExpansion interface{}
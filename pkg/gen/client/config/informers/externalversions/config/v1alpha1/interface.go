@@ -23,6 +23,8 @@ import (
 type Interface interface {
 	// MeshConfigs returns a MeshConfigInformer.
 	MeshConfigs() MeshConfigInformer
+	// MeshDiagnostics returns a MeshDiagnosticInformer.
+	MeshDiagnostics() MeshDiagnosticInformer
 }
 
 type version struct {
@@ -40,3 +42,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) MeshConfigs() MeshConfigInformer {
 	return &meshConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// MeshDiagnostics returns a MeshDiagnosticInformer.
+func (v *version) MeshDiagnostics() MeshDiagnosticInformer {
+	return &meshDiagnosticInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
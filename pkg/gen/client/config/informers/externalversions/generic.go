@@ -52,6 +52,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=config.openservicemesh.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("meshconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha1().MeshConfigs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("meshdiagnostics"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha1().MeshDiagnostics().Informer()}, nil
 
 	}
 
@@ -22,3 +22,7 @@ type MeshConfigListerExpansion interface{}
 // MeshConfigNamespaceListerExpansion allows custom methods to be added to
 // MeshConfigNamespaceLister.
 type MeshConfigNamespaceListerExpansion interface{}
+
+// MeshDiagnosticListerExpansion allows custom methods to be added to
+// MeshDiagnosticLister.
+type MeshDiagnosticListerExpansion interface{}
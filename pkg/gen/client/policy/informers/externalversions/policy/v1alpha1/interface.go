@@ -23,6 +23,8 @@ import (
 type Interface interface {
 	// Egresses returns a EgressInformer.
 	Egresses() EgressInformer
+	// UpstreamTrafficSettings returns a UpstreamTrafficSettingInformer.
+	UpstreamTrafficSettings() UpstreamTrafficSettingInformer
 }
 
 type version struct {
@@ -40,3 +42,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Egresses() EgressInformer {
 	return &egressInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// UpstreamTrafficSettings returns a UpstreamTrafficSettingInformer.
+func (v *version) UpstreamTrafficSettings() UpstreamTrafficSettingInformer {
+	return &upstreamTrafficSettingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
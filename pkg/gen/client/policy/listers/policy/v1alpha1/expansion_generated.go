@@ -22,3 +22,11 @@ type EgressListerExpansion interface{}
 // EgressNamespaceListerExpansion allows custom methods to be added to
 // EgressNamespaceLister.
 type EgressNamespaceListerExpansion interface{}
+
+// UpstreamTrafficSettingListerExpansion allows custom methods to be added to
+// UpstreamTrafficSettingLister.
+type UpstreamTrafficSettingListerExpansion interface{}
+
+// UpstreamTrafficSettingNamespaceListerExpansion allows custom methods to be added to
+// UpstreamTrafficSettingNamespaceLister.
+type UpstreamTrafficSettingNamespaceListerExpansion interface{}
@@ -5,4 +5,10 @@ import "errors"
 var (
 	// ErrInvalidNamespacedServiceStringFormat is an error returned when the K8sServiceAccount string cannot be parsed (is invalid for some reason)
 	ErrInvalidNamespacedServiceStringFormat = errors.New("invalid namespaced service string format")
+
+	// ErrUnknownSubjectKind is an error returned when no identity provider is registered for the kind of a policy subject
+	ErrUnknownSubjectKind = errors.New("no identity provider for the kind of policy subject")
+
+	// ErrInvalidSPIFFEID is an error returned when the name of a policy subject is not a valid SPIFFE ID
+	ErrInvalidSPIFFEID = errors.New("invalid SPIFFE ID")
 )
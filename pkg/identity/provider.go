@@ -0,0 +1,109 @@
+package identity
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// KubernetesServiceAccountKind is the kind of the policy subjects referencing Kubernetes service accounts
+	KubernetesServiceAccountKind = "ServiceAccount"
+
+	// SPIFFEIDKind is the kind of the policy subjects referencing SPIFFE IDs, such as spiffe://example.org/vm/billing
+	SPIFFEIDKind = "SPIFFEID"
+
+	spiffeScheme = "spiffe"
+)
+
+// Provider resolves the policy subjects of a given kind into service identities. The service identity of a subject
+// is the principal authenticated by the certificates presented by the workloads having this identity, which allows
+// policies to reference principals that are not Kubernetes service accounts, such as SPIFFE IDs, cloud IAM bindings
+// or the identities issued by custom workload attestors.
+type Provider interface {
+	// GetKind returns the kind of the policy subjects resolved by the provider
+	GetKind() string
+
+	// GetServiceIdentity returns the service identity of the policy subject with the given name and namespace
+	GetServiceIdentity(name, namespace string) (ServiceIdentity, error)
+}
+
+// Resolver resolves policy subjects into service identities using the provider registered for their kind
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver returns a Resolver for the given providers. Kubernetes service accounts are resolved in the cluster
+// local trust domain unless one of the given providers is registered for their kind.
+func NewResolver(providers ...Provider) *Resolver {
+	r := &Resolver{
+		providers: map[string]Provider{
+			KubernetesServiceAccountKind: NewKubernetesProvider(ClusterLocalTrustDomain),
+		},
+	}
+	for _, provider := range providers {
+		r.providers[provider.GetKind()] = provider
+	}
+	return r
+}
+
+// GetServiceIdentity returns the service identity of the policy subject with the given kind, name and namespace
+func (r *Resolver) GetServiceIdentity(kind, name, namespace string) (ServiceIdentity, error) {
+	provider, ok := r.providers[kind]
+	if !ok {
+		return "", errors.Wrapf(ErrUnknownSubjectKind, "kind %s", kind)
+	}
+	return provider.GetServiceIdentity(name, namespace)
+}
+
+// kubernetesProvider resolves Kubernetes service accounts into the service identities of a trust domain
+type kubernetesProvider struct {
+	trustDomain string
+}
+
+// NewKubernetesProvider returns a Provider resolving Kubernetes service accounts in the given trust domain
+func NewKubernetesProvider(trustDomain string) Provider {
+	return kubernetesProvider{trustDomain: trustDomain}
+}
+
+// GetKind returns the kind of the policy subjects resolved by the provider
+func (p kubernetesProvider) GetKind() string {
+	return KubernetesServiceAccountKind
+}
+
+// GetServiceIdentity returns the service identity of the service account with the given name and namespace
+func (p kubernetesProvider) GetServiceIdentity(name, namespace string) (ServiceIdentity, error) {
+	if name == "" || namespace == "" {
+		return "", errors.Errorf("service account %s/%s must have a name and a namespace", namespace, name)
+	}
+	return GetKubernetesServiceIdentity(K8sServiceAccount{Name: name, Namespace: namespace}, p.trustDomain), nil
+}
+
+// spiffeProvider resolves SPIFFE IDs, which are authenticated as the URI SAN of the certificates of their workloads
+type spiffeProvider struct{}
+
+// NewSPIFFEProvider returns a Provider resolving the SPIFFE IDs referenced by the name of the policy subjects
+func NewSPIFFEProvider() Provider {
+	return spiffeProvider{}
+}
+
+// GetKind returns the kind of the policy subjects resolved by the provider
+func (p spiffeProvider) GetKind() string {
+	return SPIFFEIDKind
+}
+
+// GetServiceIdentity returns the SPIFFE ID given as name, the namespace of SPIFFE IDs being irrelevant
+func (p spiffeProvider) GetServiceIdentity(name, _ string) (ServiceIdentity, error) {
+	id, err := url.Parse(name)
+	if err != nil {
+		return "", errors.Wrapf(ErrInvalidSPIFFEID, "%s: %s", name, err)
+	}
+	if id.Scheme != spiffeScheme || id.Host == "" || id.User != nil || id.Port() != "" || id.RawQuery != "" || id.Fragment != "" {
+		return "", errors.Wrap(ErrInvalidSPIFFEID, name)
+	}
+	if strings.HasSuffix(id.Path, "/") {
+		return "", errors.Wrap(ErrInvalidSPIFFEID, name)
+	}
+	return ServiceIdentity(name), nil
+}
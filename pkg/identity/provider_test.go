@@ -0,0 +1,114 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+type fakeIAMProvider struct{}
+
+func (p fakeIAMProvider) GetKind() string {
+	return "IAMRole"
+}
+
+func (p fakeIAMProvider) GetServiceIdentity(name, _ string) (ServiceIdentity, error) {
+	return ServiceIdentity("iam://" + name), nil
+}
+
+func TestResolver(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		providers               []Provider
+		kind                    string
+		subjectName             string
+		subjectNamespace        string
+		expectedServiceIdentity ServiceIdentity
+		expectedErr             error
+	}{
+		{
+			name:                    "service account resolved by default",
+			kind:                    KubernetesServiceAccountKind,
+			subjectName:             "bookbuyer",
+			subjectNamespace:        "bookbuyer-ns",
+			expectedServiceIdentity: "bookbuyer.bookbuyer-ns.cluster.local",
+		},
+		{
+			name:                    "service account resolved in another trust domain",
+			providers:               []Provider{NewKubernetesProvider("cluster.east")},
+			kind:                    KubernetesServiceAccountKind,
+			subjectName:             "bookbuyer",
+			subjectNamespace:        "bookbuyer-ns",
+			expectedServiceIdentity: "bookbuyer.bookbuyer-ns.cluster.east",
+		},
+		{
+			name:             "service account without namespace",
+			kind:             KubernetesServiceAccountKind,
+			subjectName:      "bookbuyer",
+			subjectNamespace: "",
+			expectedErr:      errors.New("service account /bookbuyer must have a name and a namespace"),
+		},
+		{
+			name:                    "SPIFFE ID",
+			providers:               []Provider{NewSPIFFEProvider()},
+			kind:                    SPIFFEIDKind,
+			subjectName:             "spiffe://example.org/vm/billing",
+			expectedServiceIdentity: "spiffe://example.org/vm/billing",
+		},
+		{
+			name:        "invalid SPIFFE ID",
+			providers:   []Provider{NewSPIFFEProvider()},
+			kind:        SPIFFEIDKind,
+			subjectName: "https://example.org/vm/billing",
+			expectedErr: errors.Wrap(ErrInvalidSPIFFEID, "https://example.org/vm/billing"),
+		},
+		{
+			name:        "SPIFFE ID without registered provider",
+			kind:        SPIFFEIDKind,
+			subjectName: "spiffe://example.org/vm/billing",
+			expectedErr: errors.Wrapf(ErrUnknownSubjectKind, "kind %s", SPIFFEIDKind),
+		},
+		{
+			name:                    "custom provider",
+			providers:               []Provider{fakeIAMProvider{}},
+			kind:                    "IAMRole",
+			subjectName:             "billing",
+			expectedServiceIdentity: "iam://billing",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			si, err := NewResolver(tc.providers...).GetServiceIdentity(tc.kind, tc.subjectName, tc.subjectNamespace)
+			if tc.expectedErr != nil {
+				assert.EqualError(err, tc.expectedErr.Error())
+				return
+			}
+			assert.Nil(err)
+			assert.Equal(tc.expectedServiceIdentity, si)
+		})
+	}
+}
+
+func TestSPIFFEProviderInvalidIDs(t *testing.T) {
+	assert := tassert.New(t)
+
+	provider := NewSPIFFEProvider()
+	for _, id := range []string{"spiffe:///vm/billing", "spiffe://example.org/vm/", "spiffe://example.org:8080/vm", "spiffe://user@example.org/vm", "spiffe://example.org/vm?x=y", "vm/billing"} {
+		_, err := provider.GetServiceIdentity(id, "")
+		assert.True(errors.Is(err, ErrInvalidSPIFFEID), id)
+	}
+}
+
+func TestIsK8sServiceAccount(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.True(ServiceIdentity("bookbuyer.bookbuyer-ns.cluster.local").IsK8sServiceAccount())
+	assert.False(ServiceIdentity("spiffe://example.org/vm/billing").IsK8sServiceAccount())
+	assert.False(ServiceIdentity("spiffe://cluster.local/ns/default.cluster.local").IsK8sServiceAccount())
+	assert.False(ServiceIdentity("bookbuyer.cluster.local").IsK8sServiceAccount())
+	assert.False(ServiceIdentity("bookbuyer.bookbuyer-ns.cluster.east").IsK8sServiceAccount())
+}
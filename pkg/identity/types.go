@@ -52,6 +52,17 @@ func (si ServiceIdentity) ToK8sServiceAccount() K8sServiceAccount {
 	}
 }
 
+// IsK8sServiceAccount returns whether the ServiceIdentity is the identity of a Kubernetes service account in the
+// cluster local trust domain, as opposed to a principal resolved by another identity Provider
+func (si ServiceIdentity) IsK8sServiceAccount() bool {
+	svcAccount := strings.TrimSuffix(si.String(), identityDelimiter+ClusterLocalTrustDomain)
+	if svcAccount == si.String() {
+		return false
+	}
+	chunks := strings.Split(svcAccount, identityDelimiter)
+	return len(chunks) == 2 && chunks[0] != "" && chunks[1] != "" && !strings.ContainsAny(svcAccount, "/:")
+}
+
 // K8sServiceAccount is a type for a namespaced service account
 type K8sServiceAccount struct {
 	Namespace string
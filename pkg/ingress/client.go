@@ -12,6 +12,8 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
 )
@@ -19,6 +21,9 @@ import (
 const (
 	// ingressKind denotes the Kind attribute of the Ingress k8s resource
 	ingressKind = "Ingress"
+
+	// ingressClassAnnotation is the deprecated annotation used to specify the class of an Ingress resource
+	ingressClassAnnotation = "kubernetes.io/ingress.class"
 )
 
 var candidateVersions = []string{networkingV1.SchemeGroupVersion.String(), networkingV1beta1.SchemeGroupVersion.String()}
@@ -126,6 +131,11 @@ func (c Client) GetIngressNetworkingV1beta1(meshService service.MeshService) ([]
 			continue
 		}
 
+		// Ingress resources served by the OSM managed ingress gateway reach their backends as in-mesh traffic
+		if featureflags.IsIngressGatewayEnabled() && isGatewayIngress(ingress.Spec.IngressClassName, ingress.Annotations) {
+			continue
+		}
+
 		// Check if the ingress resource belongs to the same namespace as the service
 		if ingress.Namespace != meshService.Namespace {
 			// The ingress resource does not belong to the namespace of the service
@@ -170,6 +180,11 @@ func (c Client) GetIngressNetworkingV1(meshService service.MeshService) ([]*netw
 			continue
 		}
 
+		// Ingress resources served by the OSM managed ingress gateway reach their backends as in-mesh traffic
+		if featureflags.IsIngressGatewayEnabled() && isGatewayIngress(ingress.Spec.IngressClassName, ingress.Annotations) {
+			continue
+		}
+
 		// Check if the ingress resource belongs to the same namespace as the service
 		if ingress.Namespace != meshService.Namespace {
 			// The ingress resource does not belong to the namespace of the service
@@ -194,6 +209,69 @@ func (c Client) GetIngressNetworkingV1(meshService service.MeshService) ([]*netw
 	return ingressResources, nil
 }
 
+// GetGatewayIngressNetworkingV1beta1 returns the networking.k8s.io/v1beta1 ingress resources served by the OSM managed ingress gateway
+func (c Client) GetGatewayIngressNetworkingV1beta1() ([]*networkingV1beta1.Ingress, error) {
+	if c.cacheV1Beta1 == nil {
+		// The v1beta1 version is not served by the controller, return an empty list
+		return nil, nil
+	}
+
+	var ingressResources []*networkingV1beta1.Ingress
+	for _, ingressInterface := range c.cacheV1Beta1.List() {
+		ingress, ok := ingressInterface.(*networkingV1beta1.Ingress)
+		if !ok {
+			log.Error().Msg("Failed type assertion for Ingress in ingress cache")
+			continue
+		}
+
+		// Extra safety - make sure we do not pay attention to Ingresses outside of observed namespaces
+		if !c.kubeController.IsMonitoredNamespace(ingress.Namespace) {
+			continue
+		}
+
+		if isGatewayIngress(ingress.Spec.IngressClassName, ingress.Annotations) {
+			ingressResources = append(ingressResources, ingress)
+		}
+	}
+	return ingressResources, nil
+}
+
+// GetGatewayIngressNetworkingV1 returns the networking.k8s.io/v1 ingress resources served by the OSM managed ingress gateway
+func (c Client) GetGatewayIngressNetworkingV1() ([]*networkingV1.Ingress, error) {
+	if c.cacheV1 == nil {
+		// The v1 version is not served by the controller, return an empty list
+		return nil, nil
+	}
+
+	var ingressResources []*networkingV1.Ingress
+	for _, ingressInterface := range c.cacheV1.List() {
+		ingress, ok := ingressInterface.(*networkingV1.Ingress)
+		if !ok {
+			log.Error().Msg("Failed type assertion for Ingress in ingress cache")
+			continue
+		}
+
+		// Extra safety - make sure we do not pay attention to Ingresses outside of observed namespaces
+		if !c.kubeController.IsMonitoredNamespace(ingress.Namespace) {
+			continue
+		}
+
+		if isGatewayIngress(ingress.Spec.IngressClassName, ingress.Annotations) {
+			ingressResources = append(ingressResources, ingress)
+		}
+	}
+	return ingressResources, nil
+}
+
+// isGatewayIngress returns true if an ingress resource with the given class name and annotations is served by the
+// OSM managed ingress gateway. The class name takes precedence over the deprecated ingress class annotation.
+func isGatewayIngress(ingressClassName *string, annotations map[string]string) bool {
+	if ingressClassName != nil {
+		return *ingressClassName == constants.OSMIngressGatewayClass
+	}
+	return annotations[ingressClassAnnotation] == constants.OSMIngressGatewayClass
+}
+
 // getSupportedIngressVersions returns a map comprising of keys matching candidate ingress API versions
 // and corresponding values indidicating if they are supported by the k8s API server or not. An error
 // is returned in case this cannot be determined.
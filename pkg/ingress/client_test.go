@@ -112,3 +112,49 @@ func TestGetSupportedIngressVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestIsGatewayIngress(t *testing.T) {
+	assert := tassert.New(t)
+
+	osmClass := "osm"
+	otherClass := "nginx"
+
+	testCases := []struct {
+		name             string
+		ingressClassName *string
+		annotations      map[string]string
+		expected         bool
+	}{
+		{
+			name:             "ingress class name is osm",
+			ingressClassName: &osmClass,
+			expected:         true,
+		},
+		{
+			name:             "ingress class name is not osm",
+			ingressClassName: &otherClass,
+			expected:         false,
+		},
+		{
+			name:        "ingress class annotation is osm",
+			annotations: map[string]string{"kubernetes.io/ingress.class": "osm"},
+			expected:    true,
+		},
+		{
+			name:             "ingress class name takes precedence over the annotation",
+			ingressClassName: &otherClass,
+			annotations:      map[string]string{"kubernetes.io/ingress.class": "osm"},
+			expected:         false,
+		},
+		{
+			name:     "ingress class is not specified",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(tc.expected, isGatewayIngress(tc.ingressClassName, tc.annotations))
+		})
+	}
+}
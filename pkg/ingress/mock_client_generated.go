@@ -36,6 +36,36 @@ func (m *MockMonitor) EXPECT() *MockMonitorMockRecorder {
 	return m.recorder
 }
 
+// GetGatewayIngressNetworkingV1 mocks base method
+func (m *MockMonitor) GetGatewayIngressNetworkingV1() ([]*v1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGatewayIngressNetworkingV1")
+	ret0, _ := ret[0].([]*v1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGatewayIngressNetworkingV1 indicates an expected call of GetGatewayIngressNetworkingV1
+func (mr *MockMonitorMockRecorder) GetGatewayIngressNetworkingV1() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGatewayIngressNetworkingV1", reflect.TypeOf((*MockMonitor)(nil).GetGatewayIngressNetworkingV1))
+}
+
+// GetGatewayIngressNetworkingV1beta1 mocks base method
+func (m *MockMonitor) GetGatewayIngressNetworkingV1beta1() ([]*v1beta1.Ingress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGatewayIngressNetworkingV1beta1")
+	ret0, _ := ret[0].([]*v1beta1.Ingress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGatewayIngressNetworkingV1beta1 indicates an expected call of GetGatewayIngressNetworkingV1beta1
+func (mr *MockMonitorMockRecorder) GetGatewayIngressNetworkingV1beta1() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGatewayIngressNetworkingV1beta1", reflect.TypeOf((*MockMonitor)(nil).GetGatewayIngressNetworkingV1beta1))
+}
+
 // GetIngressNetworkingV1 mocks base method
 func (m *MockMonitor) GetIngressNetworkingV1(arg0 service.MeshService) ([]*v1.Ingress, error) {
 	m.ctrl.T.Helper()
@@ -32,4 +32,10 @@ type Monitor interface {
 
 	// GetIngressNetworkingV1 returns the networking.k8s.io/v1 ingress resources whose backends correspond to the service
 	GetIngressNetworkingV1(service.MeshService) ([]*networkingV1.Ingress, error)
+
+	// GetGatewayIngressNetworkingV1beta1 returns the networking.k8s.io/v1beta1 ingress resources served by the OSM managed ingress gateway
+	GetGatewayIngressNetworkingV1beta1() ([]*networkingV1beta1.Ingress, error)
+
+	// GetGatewayIngressNetworkingV1 returns the networking.k8s.io/v1 ingress resources served by the OSM managed ingress gateway
+	GetGatewayIngressNetworkingV1() ([]*networkingV1.Ingress, error)
 }
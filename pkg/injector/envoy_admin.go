@@ -0,0 +1,82 @@
+package injector
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	envoyAdminVolume = "envoy-admin-volume"
+
+	// envoyAdminSocketMode restricts connections to the admin socket to the user the Envoy sidecar runs as
+	envoyAdminSocketMode = 0600
+
+	// osmHealthcheckImagePath is the path of the osm-healthcheck binary in the init container image
+	osmHealthcheckImagePath = "/usr/local/bin/osm-healthcheck"
+
+	envoyReadinessProbePeriodSeconds = 5
+	envoyReadinessProbeTimeout       = 3
+)
+
+// getEnvoyAdminAddress returns the address Envoy's admin interface is bound to, which is a Unix domain socket
+// if a socket path is specified, and a localhost TCP port otherwise.
+func getEnvoyAdminAddress(config envoyBootstrapConfigMeta) map[string]interface{} {
+	if config.EnvoyAdminSocketPath != "" {
+		return map[string]interface{}{
+			"pipe": map[string]interface{}{
+				"path": config.EnvoyAdminSocketPath,
+				"mode": envoyAdminSocketMode,
+			},
+		}
+	}
+
+	return map[string]interface{}{
+		"socket_address": map[string]string{
+			"address":    constants.LocalhostIPAddress,
+			"port_value": strconv.Itoa(config.EnvoyAdminPort),
+		},
+	}
+}
+
+// getEnvoyAdminVolume returns the volume shared by the init container and the Envoy sidecar, holding Envoy's admin socket
+// and the osm-healthcheck binary
+func getEnvoyAdminVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: envoyAdminVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// getEnvoyAdminVolumeMount returns the mount of the volume holding Envoy's admin socket and the osm-healthcheck binary
+func getEnvoyAdminVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      envoyAdminVolume,
+		MountPath: constants.EnvoyAdminDir,
+	}
+}
+
+// getCopyOSMHealthcheckCommand returns the command run by the init container to copy the osm-healthcheck binary
+// into the volume shared with the Envoy sidecar
+func getCopyOSMHealthcheckCommand() string {
+	return fmt.Sprintf("cp %s %s", osmHealthcheckImagePath, constants.OSMHealthcheckPath)
+}
+
+// getEnvoyReadinessProbe returns the readiness probe of the Envoy sidecar, which checks the readiness of Envoy
+// over its admin socket using the osm-healthcheck binary
+func getEnvoyReadinessProbe() *corev1.Probe {
+	return &corev1.Probe{
+		Handler: corev1.Handler{
+			Exec: &corev1.ExecAction{
+				Command: []string{constants.OSMHealthcheckPath, "ready"},
+			},
+		},
+		PeriodSeconds:  envoyReadinessProbePeriodSeconds,
+		TimeoutSeconds: envoyReadinessProbeTimeout,
+	}
+}
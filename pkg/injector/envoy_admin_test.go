@@ -0,0 +1,118 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+)
+
+func TestGetEnvoyAdminAddress(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   envoyBootstrapConfigMeta
+		expected map[string]interface{}
+	}{
+		{
+			name: "admin interface bound to a TCP port",
+			config: envoyBootstrapConfigMeta{
+				EnvoyAdminPort: constants.EnvoyAdminPort,
+			},
+			expected: map[string]interface{}{
+				"socket_address": map[string]string{
+					"address":    "127.0.0.1",
+					"port_value": "15000",
+				},
+			},
+		},
+		{
+			name: "admin interface bound to a Unix domain socket",
+			config: envoyBootstrapConfigMeta{
+				EnvoyAdminPort:       constants.EnvoyAdminPort,
+				EnvoyAdminSocketPath: constants.EnvoyAdminSocketPath,
+			},
+			expected: map[string]interface{}{
+				"pipe": map[string]interface{}{
+					"path": "/var/run/osm-envoy-admin/admin.sock",
+					"mode": 0600,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, getEnvoyAdminAddress(tc.config))
+		})
+	}
+}
+
+func TestEnvoyAdminUDSInjection(t *testing.T) {
+	testCases := []struct {
+		name            string
+		adminUDSEnabled bool
+	}{
+		{
+			name:            "admin interface bound to a TCP port",
+			adminUDSEnabled: false,
+		},
+		{
+			name:            "admin interface bound to a Unix domain socket",
+			adminUDSEnabled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			oldAdminUDSFlag := featureflags.Features.EnvoyAdminUDS
+			featureflags.Features.EnvoyAdminUDS = tc.adminUDSEnabled
+			defer func() {
+				featureflags.Features.EnvoyAdminUDS = oldAdminUDSFlag
+			}()
+
+			volumes := getVolumeSpec("-envoy-config-")
+			assert.Equal(tc.adminUDSEnabled, containsVolume(volumes, envoyAdminVolume))
+
+			ports := getEnvoyContainerPorts(healthProbes{})
+			assert.Equal(!tc.adminUDSEnabled, containsPort(ports, constants.EnvoyAdminPortName))
+		})
+	}
+}
+
+func TestGetEnvoyReadinessProbe(t *testing.T) {
+	assert := tassert.New(t)
+
+	probe := getEnvoyReadinessProbe()
+	assert.NotNil(probe.Exec)
+	assert.Equal([]string{"/var/run/osm-envoy-admin/osm-healthcheck", "ready"}, probe.Exec.Command)
+	assert.Nil(probe.HTTPGet)
+}
+
+func TestGetCopyOSMHealthcheckCommand(t *testing.T) {
+	assert := tassert.New(t)
+	assert.Equal("cp /usr/local/bin/osm-healthcheck /var/run/osm-envoy-admin/osm-healthcheck", getCopyOSMHealthcheckCommand())
+}
+
+func containsVolume(volumes []corev1.Volume, name string) bool {
+	for _, v := range volumes {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPort(ports []corev1.ContainerPort, name string) bool {
+	for _, p := range ports {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
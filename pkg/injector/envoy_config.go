@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
-	"strconv"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -13,6 +12,8 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 	"github.com/openservicemesh/osm/pkg/version"
 )
 
@@ -20,12 +21,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 	m := map[interface{}]interface{}{
 		"admin": map[string]interface{}{
 			"access_log_path": "/dev/stdout",
-			"address": map[string]interface{}{
-				"socket_address": map[string]string{
-					"address":    constants.LocalhostIPAddress,
-					"port_value": strconv.Itoa(config.EnvoyAdminPort),
-				},
-			},
+			"address":         getEnvoyAdminAddress(config),
 		},
 
 		"dynamic_resources": map[string]interface{}{
@@ -53,6 +49,7 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 	}
 
 	m["static_resources"] = getStaticResources(config)
+	m["stats_config"] = getStatsConfig()
 
 	configYAML, err := yaml.Marshal(&m)
 	if err != nil {
@@ -102,6 +99,23 @@ func getStaticResources(config envoyBootstrapConfigMeta) map[string]interface{}
 	return staticResources
 }
 
+// getStatsConfig returns the configuration of the statistics of the proxy, which extracts the source identity and
+// destination of the egress connections denied, and the Egress policy and destination of egress clusters, from the
+// names of their statistics as tags
+func getStatsConfig() map[string]interface{} {
+	var statsTags []map[string]interface{}
+	for _, tag := range envoy.EgressStatsTags {
+		statsTags = append(statsTags, map[string]interface{}{
+			"tag_name": tag.Name,
+			"regex":    tag.Regex,
+		})
+	}
+
+	return map[string]interface{}{
+		"stats_tags": statsTags,
+	}
+}
+
 func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert certificate.Certificater, originalHealthProbes healthProbes) (*corev1.Secret, error) {
 	configMeta := envoyBootstrapConfigMeta{
 		EnvoyAdminPort: constants.EnvoyAdminPort,
@@ -118,6 +132,9 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(name, namespace, osmNamesp
 		// defined on the Pod Spec.
 		OriginalHealthProbes: originalHealthProbes,
 	}
+	if featureflags.IsEnvoyAdminUDSEnabled() {
+		configMeta.EnvoyAdminSocketPath = constants.EnvoyAdminSocketPath
+	}
 	yamlContent, err := getEnvoyConfigYAML(configMeta, wh.configurator)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating Envoy bootstrap YAML")
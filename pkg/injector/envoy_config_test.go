@@ -246,7 +246,8 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 		It("creates Envoy sidecar spec", func() {
 			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("debug").Times(1)
 			mockConfigurator.EXPECT().GetEnvoyImage().Return(envoyImage).Times(1)
-			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes)
+			mockConfigurator.EXPECT().GetImageRegistryOverride().Return("").Times(1)
+			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes, "", configurator.ProxyConfigClass{})
 
 			expected := corev1.Container{
 				Name:            constants.EnvoyContainerName,
@@ -273,6 +274,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 					"--log-level", "debug",
 					"--config-path", "/etc/envoy/bootstrap.yaml",
 					"--service-node", "$(POD_UID)/$(POD_NAMESPACE)/$(POD_IP)/$(SERVICE_ACCOUNT)/svcacc/$(POD_NAME)/workload-kind/workload-name",
+					"--config-yaml", `{"node":{"metadata":{"pod_name":"$(POD_NAME)","pod_namespace":"$(POD_NAMESPACE)","service_account":"$(SERVICE_ACCOUNT)","workload_kind":"workload-kind","workload_name":"workload-name","egress_stats_tags":"true"}}}`,
 					"--service-cluster", "svcacc.namespace",
 					"--bootstrap-version 3",
 				},
@@ -347,5 +349,26 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("creates Envoy sidecar spec with the settings of a proxy configuration class", func() {
+			mockConfigurator.EXPECT().GetImageRegistryOverride().Return("").Times(1)
+			class := configurator.ProxyConfigClass{
+				LogLevel:    "trace",
+				Concurrency: 2,
+				EnvoyImage:  "envoyproxy/envoy-alpine:v1.18.3",
+			}
+			actual := getEnvoySidecarContainerSpec(pod, mockConfigurator, originalHealthProbes, "canary", class)
+
+			Expect(actual.Image).To(Equal("envoyproxy/envoy-alpine:v1.18.3"))
+			Expect(actual.Args).To(Equal([]string{
+				"--log-level", "trace",
+				"--config-path", "/etc/envoy/bootstrap.yaml",
+				"--service-node", "$(POD_UID)/$(POD_NAMESPACE)/$(POD_IP)/$(SERVICE_ACCOUNT)/svcacc/$(POD_NAME)/workload-kind/workload-name",
+				"--config-yaml", `{"node":{"metadata":{"pod_name":"$(POD_NAME)","pod_namespace":"$(POD_NAMESPACE)","service_account":"$(SERVICE_ACCOUNT)","workload_kind":"workload-kind","workload_name":"workload-name","proxy_config_class":"canary","egress_stats_tags":"true"}}}`,
+				"--service-cluster", "svcacc.namespace",
+				"--bootstrap-version 3",
+				"--concurrency", "2",
+			}))
+		})
 	})
 })
@@ -2,6 +2,7 @@ package injector
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -9,6 +10,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 )
 
 const (
@@ -16,7 +18,9 @@ const (
 	envoyProxyConfigPath     = "/etc/envoy"
 )
 
-func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator, originalHealthProbes healthProbes) corev1.Container {
+// getEnvoySidecarContainerSpec returns the spec of the Envoy sidecar container injected into the given pod, with the settings
+// of the given proxy configuration class taking precedence over the mesh-wide settings
+func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator, originalHealthProbes healthProbes, proxyConfigClassName string, proxyConfigClass configurator.ProxyConfigClass) corev1.Container {
 	// nodeID and clusterID are required for Envoy proxy to start.
 	nodeID := pod.Spec.ServiceAccountName
 	// cluster ID will be used as an identifier to the tracing sink
@@ -32,9 +36,39 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator
 		}
 	}
 
+	volumeMounts := []corev1.VolumeMount{{
+		Name:      envoyBootstrapConfigVolume,
+		ReadOnly:  true,
+		MountPath: envoyProxyConfigPath,
+	}}
+	var readinessProbe *corev1.Probe
+	// The bootstrap config of the sidecar always defines the stats tags of egress clusters
+	features := []string{envoy.NodeMetadataEgressStatsTags}
+	if featureflags.IsEnvoyAdminUDSEnabled() {
+		volumeMounts = append(volumeMounts, getEnvoyAdminVolumeMount())
+		readinessProbe = getEnvoyReadinessProbe()
+		features = append(features, envoy.NodeMetadataEnvoyAdminUDS)
+	}
+
+	logLevel := proxyConfigClass.LogLevel
+	if logLevel == "" {
+		logLevel = cfg.GetEnvoyLogLevel()
+	}
+	args := []string{
+		"--log-level", logLevel,
+		"--config-path", strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
+		"--service-node", envoy.GetEnvoyServiceNodeID(nodeID, workloadKind, workloadName),
+		"--config-yaml", envoy.GetEnvoyNodeMetadataConfig(workloadKind, workloadName, proxyConfigClassName, features...),
+		"--service-cluster", clusterID,
+		"--bootstrap-version 3",
+	}
+	if proxyConfigClass.Concurrency > 0 {
+		args = append(args, "--concurrency", strconv.Itoa(proxyConfigClass.Concurrency))
+	}
+
 	return corev1.Container{
 		Name:            constants.EnvoyContainerName,
-		Image:           cfg.GetEnvoyImage(),
+		Image:           getEnvoyImage(cfg, getPodArch(pod), proxyConfigClass.EnvoyImage),
 		ImagePullPolicy: corev1.PullAlways,
 		SecurityContext: &corev1.SecurityContext{
 			RunAsUser: func() *int64 {
@@ -42,20 +76,11 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator
 				return &uid
 			}(),
 		},
-		Ports: getEnvoyContainerPorts(originalHealthProbes),
-		VolumeMounts: []corev1.VolumeMount{{
-			Name:      envoyBootstrapConfigVolume,
-			ReadOnly:  true,
-			MountPath: envoyProxyConfigPath,
-		}},
-		Command: []string{"envoy"},
-		Args: []string{
-			"--log-level", cfg.GetEnvoyLogLevel(),
-			"--config-path", strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
-			"--service-node", envoy.GetEnvoyServiceNodeID(nodeID, workloadKind, workloadName),
-			"--service-cluster", clusterID,
-			"--bootstrap-version 3",
-		},
+		Ports:          getEnvoyContainerPorts(originalHealthProbes),
+		VolumeMounts:   volumeMounts,
+		ReadinessProbe: readinessProbe,
+		Command:        []string{"envoy"},
+		Args:           args,
 		Env: []corev1.EnvVar{
 			{
 				Name: "POD_UID",
@@ -102,11 +127,17 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, cfg configurator.Configurator
 }
 
 func getEnvoyContainerPorts(originalHealthProbes healthProbes) []corev1.ContainerPort {
-	containerPorts := []corev1.ContainerPort{
-		{
+	var containerPorts []corev1.ContainerPort
+
+	// The admin port is not exposed when the admin interface is bound to a Unix domain socket
+	if !featureflags.IsEnvoyAdminUDSEnabled() {
+		containerPorts = append(containerPorts, corev1.ContainerPort{
 			Name:          constants.EnvoyAdminPortName,
 			ContainerPort: constants.EnvoyAdminPort,
-		},
+		})
+	}
+
+	containerPorts = append(containerPorts, []corev1.ContainerPort{
 		{
 			Name:          constants.EnvoyInboundListenerPortName,
 			ContainerPort: constants.EnvoyInboundListenerPort,
@@ -115,7 +146,7 @@ func getEnvoyContainerPorts(originalHealthProbes healthProbes) []corev1.Containe
 			Name:          constants.EnvoyInboundPrometheusListenerPortName,
 			ContainerPort: constants.EnvoyPrometheusInboundListenerPort,
 		},
-	}
+	}...)
 
 	if originalHealthProbes.liveness != nil {
 		livenessPort := corev1.ContainerPort{
@@ -0,0 +1,182 @@
+package injector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+const (
+	grpcBootstrapVolume    = "grpc-xds-bootstrap-volume"
+	grpcBootstrapMountPath = "/etc/osm/grpc-xds"
+	grpcXDSBootstrapEnvVar = "GRPC_XDS_BOOTSTRAP"
+
+	grpcBootstrapFile      = "bootstrap.json"
+	grpcRootCertFile       = "ca.pem"
+	grpcXDSCertFile        = "xds-cert.pem"
+	grpcXDSKeyFile         = "xds-key.pem"
+	grpcWorkloadCertFile   = "cert.pem"
+	grpcWorkloadKeyFile    = "key.pem"
+	grpcCertRefreshSeconds = "600s"
+)
+
+// isProxylessGRPCPod returns true if the pod is annotated to bootstrap its gRPC application as a proxyless xDS client
+func isProxylessGRPCPod(pod *corev1.Pod) bool {
+	if !featureflags.IsProxylessGRPCEnabled() {
+		return false
+	}
+
+	switch strings.ToLower(pod.Annotations[constants.ProxylessGRPCAnnotation]) {
+	case "enabled", "yes", "true":
+		return true
+	default:
+		return false
+	}
+}
+
+// createProxylessGRPCPatch mutates the pod to bootstrap its containers as proxyless gRPC xDS clients.
+// No sidecar or init container is injected: the containers connect to the xDS server with the bootstrap certificate,
+// and use a workload certificate for the service identity of the pod to connect to upstream services.
+func (wh *mutatingWebhook) createProxylessGRPCPatch(pod *corev1.Pod, req *admissionv1.AdmissionRequest, proxyUUID uuid.UUID, bootstrapCertificate certificate.Certificater) ([]byte, error) {
+	namespace := req.Namespace
+	svcIdentity := identity.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: namespace}.ToServiceIdentity()
+
+	workloadCertificate, err := wh.certManager.IssueCertificate(svcIdentity.GetCertificateCommonName(), wh.configurator.GetServiceCertValidityPeriod())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing workload certificate for proxyless gRPC pod with identity %s", svcIdentity)
+		return nil, err
+	}
+
+	grpcBootstrapName := fmt.Sprintf("grpc-xds-bootstrap-%s", proxyUUID)
+	if req.DryRun != nil && *req.DryRun {
+		log.Debug().Msgf("Skipping gRPC xDS bootstrap creation for dry-run request: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
+	} else if _, err = wh.createGRPCBootstrapSecret(grpcBootstrapName, namespace, proxyUUID, bootstrapCertificate, workloadCertificate); err != nil {
+		log.Error().Err(err).Msgf("Failed to create gRPC xDS bootstrap for pod: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
+		return nil, err
+	}
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: grpcBootstrapVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: grpcBootstrapName,
+			},
+		},
+	})
+
+	// Every container in the pod can use the xDS client
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      grpcBootstrapVolume,
+			ReadOnly:  true,
+			MountPath: grpcBootstrapMountPath,
+		})
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  grpcXDSBootstrapEnvVar,
+			Value: strings.Join([]string{grpcBootstrapMountPath, grpcBootstrapFile}, "/"),
+		})
+	}
+
+	// The proxy UUID label matches the pod to the xDS client that connects with the bootstrap certificate
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	pod.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID.String()
+
+	return json.Marshal(makePatches(req, pod))
+}
+
+func (wh *mutatingWebhook) createGRPCBootstrapSecret(name, namespace string, proxyUUID uuid.UUID, bootstrapCertificate, workloadCertificate certificate.Certificater) (*corev1.Secret, error) {
+	bootstrap, err := getGRPCBootstrapJSON(proxyUUID, fmt.Sprintf("%s.%s.svc.cluster.local:%d", constants.OSMControllerName, wh.osmNamespace, constants.OSMControllerPort))
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating gRPC xDS bootstrap JSON")
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				constants.OSMAppNameLabelKey:        constants.OSMAppNameLabelValue,
+				constants.OSMAppInstanceLabelKey:    wh.meshName,
+				constants.OSMAppVersionLabelKey:     version.Version,
+				constants.GRPCXDSBootstrapLabelName: "true",
+				// Matches the Secret to its pod, so that it is deleted once the pod no longer exists
+				constants.EnvoyUniqueIDLabelName: proxyUUID.String(),
+			},
+		},
+		Data: map[string][]byte{
+			grpcBootstrapFile:    bootstrap,
+			grpcRootCertFile:     bootstrapCertificate.GetIssuingCA(),
+			grpcXDSCertFile:      bootstrapCertificate.GetCertificateChain(),
+			grpcXDSKeyFile:       bootstrapCertificate.GetPrivateKey(),
+			grpcWorkloadCertFile: workloadCertificate.GetCertificateChain(),
+			grpcWorkloadKeyFile:  workloadCertificate.GetPrivateKey(),
+		},
+	}
+	if existing, err := wh.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{}); err == nil {
+		log.Debug().Msgf("Updating gRPC xDS bootstrap: name=%s, namespace=%s", name, namespace)
+		existing.Data = secret.Data
+		return wh.kubeClient.CoreV1().Secrets(namespace).Update(context.Background(), existing, metav1.UpdateOptions{})
+	}
+
+	log.Debug().Msgf("Creating gRPC xDS bootstrap: name=%s, namespace=%s", name, namespace)
+	return wh.kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+}
+
+// getGRPCBootstrapJSON returns the gRPC xDS bootstrap pointing the xDS client to the given xDS server.
+// The certificate provider instance is referenced by the clusters OSM programs for proxyless gRPC clients.
+func getGRPCBootstrapJSON(proxyUUID uuid.UUID, xdsServerURI string) ([]byte, error) {
+	certPath := func(file string) string {
+		return strings.Join([]string{grpcBootstrapMountPath, file}, "/")
+	}
+
+	bootstrap := map[string]interface{}{
+		"xds_servers": []map[string]interface{}{
+			{
+				"server_uri": xdsServerURI,
+				"channel_creds": []map[string]interface{}{
+					{
+						"type": "tls",
+						"config": map[string]string{
+							"ca_certificate_file": certPath(grpcRootCertFile),
+							"certificate_file":    certPath(grpcXDSCertFile),
+							"private_key_file":    certPath(grpcXDSKeyFile),
+						},
+					},
+				},
+				"server_features": []string{"xds_v3"},
+			},
+		},
+		"node": map[string]interface{}{
+			"id": proxyUUID.String(),
+		},
+		"certificate_providers": map[string]interface{}{
+			envoy.ProxylessGRPCCertProviderInstance: map[string]interface{}{
+				"plugin_name": "file_watcher",
+				"config": map[string]string{
+					"ca_certificate_file": certPath(grpcRootCertFile),
+					"certificate_file":    certPath(grpcWorkloadCertFile),
+					"private_key_file":    certPath(grpcWorkloadKeyFile),
+					"refresh_interval":    grpcCertRefreshSeconds,
+				},
+			},
+		},
+	}
+
+	return json.Marshal(bootstrap)
+}
@@ -0,0 +1,155 @@
+package injector
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// grpcWorkloadCertRenewDivisor defines when a certificate in the bootstrap of a proxyless gRPC pod is reissued: once less
+// than 1/grpcWorkloadCertRenewDivisor of its validity period remains. This leaves time for the kubelet to sync the
+// bootstrap Secret volume, and for the gRPC xDS client to reload the certificates.
+const grpcWorkloadCertRenewDivisor = 3
+
+// grpcBootstrapCleanupGracePeriod is the age from which a gRPC xDS bootstrap Secret without a pod is deleted.
+// The injector creates the Secret before the pod, so recently created Secrets are kept.
+const grpcBootstrapCleanupGracePeriod = 10 * time.Minute
+
+// StartGRPCBootstrapCertRotation periodically reissues the xDS and workload certificates held in the gRPC xDS bootstrap
+// Secrets of the proxyless gRPC pods of the given mesh before they expire, and deletes the bootstrap Secrets of the pods
+// that no longer exist, until the stop channel is closed.
+func StartGRPCBootstrapCertRotation(kubeClient kubernetes.Interface, certManager certificate.Manager, cfg configurator.Configurator, meshName string, checkInterval time.Duration, stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			rotateGRPCBootstrapCertificates(kubeClient, certManager, cfg.GetServiceCertValidityPeriod(), meshName, time.Now())
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func rotateGRPCBootstrapCertificates(kubeClient kubernetes.Interface, certManager certificate.Manager, validityPeriod time.Duration, meshName string, now time.Time) {
+	selector := labels.SelectorFromSet(labels.Set{
+		constants.GRPCXDSBootstrapLabelName: "true",
+		constants.OSMAppInstanceLabelKey:    meshName,
+	})
+	secrets, err := kubeClient.CoreV1().Secrets(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing gRPC xDS bootstrap Secrets")
+		return
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		deleted, err := deleteOrphanedGRPCBootstrapSecret(kubeClient, secret, now)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error deleting gRPC xDS bootstrap %s/%s", secret.Namespace, secret.Name)
+			continue
+		}
+		if deleted {
+			continue
+		}
+		if err := rotateGRPCBootstrapSecretCertificates(kubeClient, certManager, secret, validityPeriod, now); err != nil {
+			// Conflicting updates, ex. from another replica, are retried at the next check
+			log.Error().Err(err).Msgf("Error rotating the certificates of gRPC xDS bootstrap %s/%s", secret.Namespace, secret.Name)
+		}
+	}
+}
+
+// deleteOrphanedGRPCBootstrapSecret deletes the given gRPC xDS bootstrap Secret if the pod it was created for does not
+// exist, and returns true if it was deleted. The pod is matched using the proxy UUID label of the Secret.
+func deleteOrphanedGRPCBootstrapSecret(kubeClient kubernetes.Interface, secret *corev1.Secret, now time.Time) (bool, error) {
+	proxyUUID, ok := secret.Labels[constants.EnvoyUniqueIDLabelName]
+	if !ok || now.Sub(secret.CreationTimestamp.Time) < grpcBootstrapCleanupGracePeriod {
+		return false, nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{constants.EnvoyUniqueIDLabelName: proxyUUID})
+	pods, err := kubeClient.CoreV1().Pods(secret.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return false, errors.Wrap(err, "error listing the pod of the proxy")
+	}
+	if len(pods.Items) > 0 {
+		return false, nil
+	}
+
+	if err := kubeClient.CoreV1().Secrets(secret.Namespace).Delete(context.Background(), secret.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	log.Debug().Msgf("Deleted gRPC xDS bootstrap %s/%s of proxy %s without a pod", secret.Namespace, secret.Name, proxyUUID)
+	return true, nil
+}
+
+// rotateGRPCBootstrapSecretCertificates reissues the xDS certificate and the workload certificate held in the given
+// gRPC xDS bootstrap Secret if they expire soon
+func rotateGRPCBootstrapSecretCertificates(kubeClient kubernetes.Interface, certManager certificate.Manager, secret *corev1.Secret, validityPeriod time.Duration, now time.Time) error {
+	xdsCertRotated, err := rotateGRPCBootstrapCertificate(certManager, secret, grpcXDSCertFile, grpcXDSKeyFile, constants.XDSCertificateValidityPeriod, now)
+	if err != nil {
+		return errors.Wrap(err, "error rotating the xDS certificate")
+	}
+	workloadCertRotated, err := rotateGRPCBootstrapCertificate(certManager, secret, grpcWorkloadCertFile, grpcWorkloadKeyFile, validityPeriod, now)
+	if err != nil {
+		return errors.Wrap(err, "error rotating the workload certificate")
+	}
+	if !xdsCertRotated && !workloadCertRotated {
+		return nil
+	}
+
+	_, err = kubeClient.CoreV1().Secrets(secret.Namespace).Update(context.Background(), secret, metav1.UpdateOptions{})
+	return err
+}
+
+// rotateGRPCBootstrapCertificate reissues the certificate held in the given files of the given gRPC xDS bootstrap Secret
+// if it expires soon, and returns true if the Secret was updated with the reissued certificate. Bootstraps without the
+// given certificate are left as is.
+func rotateGRPCBootstrapCertificate(certManager certificate.Manager, secret *corev1.Secret, certFile, keyFile string, validityPeriod time.Duration, now time.Time) (bool, error) {
+	if _, ok := secret.Data[certFile]; !ok {
+		return false, nil
+	}
+
+	cert, err := certificate.DecodePEMCertificate(secret.Data[certFile])
+	if err != nil {
+		return false, errors.Wrap(err, "error decoding the certificate")
+	}
+	if !shouldRenewGRPCWorkloadCert(cert.NotAfter, validityPeriod, now) {
+		return false, nil
+	}
+
+	cn := certificate.CommonName(cert.Subject.CommonName)
+	newCert, err := certManager.IssueCertificate(cn, validityPeriod)
+	// The certificate manager caches the certificates it issues per common name, the cached certificate is released
+	// and reissued with the given validity period if it expires soon as well
+	if err == nil && shouldRenewGRPCWorkloadCert(newCert.GetExpiration(), validityPeriod, now) {
+		certManager.ReleaseCertificate(cn)
+		newCert, err = certManager.IssueCertificate(cn, validityPeriod)
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error issuing certificate %s", cn)
+	}
+
+	secret.Data[grpcRootCertFile] = newCert.GetIssuingCA()
+	secret.Data[certFile] = newCert.GetCertificateChain()
+	secret.Data[keyFile] = newCert.GetPrivateKey()
+	log.Debug().Msgf("Rotated the certificate %s of gRPC xDS bootstrap %s/%s, expiring at %s", cn, secret.Namespace, secret.Name, newCert.GetExpiration())
+	return true, nil
+}
+
+// shouldRenewGRPCWorkloadCert returns true if a certificate expiring at the given time should be reissued
+func shouldRenewGRPCWorkloadCert(expiration time.Time, validityPeriod time.Duration, now time.Time) bool {
+	return expiration.Sub(now) < validityPeriod/grpcWorkloadCertRenewDivisor
+}
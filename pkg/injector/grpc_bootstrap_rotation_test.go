@@ -0,0 +1,164 @@
+package injector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestRotateGRPCBootstrapCertificates(t *testing.T) {
+	const validityPeriod = 24 * time.Hour
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	mockConfigurator.EXPECT().GetServiceCertValidityPeriod().Return(validityPeriod).AnyTimes()
+	certManager := tresor.NewFakeCertManager(mockConfigurator)
+
+	newBootstrapSecret := func(name, meshName string, cn certificate.CommonName, certValidity time.Duration) *corev1.Secret {
+		cert, err := certManager.IssueCertificate(cn, certValidity)
+		trequire.Nil(t, err)
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "bookbuyer",
+				Labels: map[string]string{
+					constants.OSMAppInstanceLabelKey:    meshName,
+					constants.GRPCXDSBootstrapLabelName: "true",
+				},
+			},
+			Data: map[string][]byte{
+				grpcRootCertFile:     cert.GetIssuingCA(),
+				grpcWorkloadCertFile: cert.GetCertificateChain(),
+				grpcWorkloadKeyFile:  cert.GetPrivateKey(),
+			},
+		}
+	}
+	withXDSCert := func(secret *corev1.Secret, cn certificate.CommonName, certValidity time.Duration) *corev1.Secret {
+		cert, err := certManager.IssueCertificate(cn, certValidity)
+		trequire.Nil(t, err)
+		secret.Data[grpcXDSCertFile] = cert.GetCertificateChain()
+		secret.Data[grpcXDSKeyFile] = cert.GetPrivateKey()
+		return secret
+	}
+	withProxyUUID := func(secret *corev1.Secret, proxyUUID string, age time.Duration) *corev1.Secret {
+		secret.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID
+		secret.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		return secret
+	}
+
+	testCases := []struct {
+		name                 string
+		secret               *corev1.Secret
+		pods                 []*corev1.Pod
+		expectRotation       bool
+		expectXDSCertRotated bool
+		expectDeleted        bool
+	}{
+		{
+			name:   "certificate not expiring soon",
+			secret: newBootstrapSecret("grpc-xds-bootstrap-1", "osm", "bookbuyer-1.bookbuyer.cluster.local", 23*time.Hour),
+		},
+		{
+			name:           "certificate expiring soon",
+			secret:         newBootstrapSecret("grpc-xds-bootstrap-2", "osm", "bookbuyer-2.bookbuyer.cluster.local", time.Hour),
+			expectRotation: true,
+		},
+		{
+			name:   "certificate expiring soon in the bootstrap of another mesh",
+			secret: newBootstrapSecret("grpc-xds-bootstrap-3", "other-mesh", "bookbuyer-3.bookbuyer.cluster.local", time.Hour),
+		},
+		{
+			name: "xDS certificate expiring soon",
+			secret: withXDSCert(newBootstrapSecret("grpc-xds-bootstrap-4", "osm", "bookbuyer-4.bookbuyer.cluster.local", 23*time.Hour),
+				"proxy-4.bookbuyer.bookbuyer", time.Hour),
+			expectXDSCertRotated: true,
+		},
+		{
+			name: "xDS certificate not expiring soon",
+			secret: withXDSCert(newBootstrapSecret("grpc-xds-bootstrap-5", "osm", "bookbuyer-5.bookbuyer.cluster.local", 23*time.Hour),
+				"proxy-5.bookbuyer.bookbuyer", constants.XDSCertificateValidityPeriod),
+		},
+		{
+			name:          "bootstrap of a pod that no longer exists",
+			secret:        withProxyUUID(newBootstrapSecret("grpc-xds-bootstrap-6", "osm", "bookbuyer-6.bookbuyer.cluster.local", time.Hour), "proxy-6", time.Hour),
+			expectDeleted: true,
+		},
+		{
+			name:           "recently created bootstrap of a pod that does not exist yet",
+			secret:         withProxyUUID(newBootstrapSecret("grpc-xds-bootstrap-7", "osm", "bookbuyer-7.bookbuyer.cluster.local", time.Hour), "proxy-7", time.Minute),
+			expectRotation: true,
+		},
+		{
+			name:   "bootstrap of an existing pod",
+			secret: withProxyUUID(newBootstrapSecret("grpc-xds-bootstrap-8", "osm", "bookbuyer-8.bookbuyer.cluster.local", 23*time.Hour), "proxy-8", time.Hour),
+			pods: []*corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bookbuyer-8",
+					Namespace: "bookbuyer",
+					Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "proxy-8"},
+				},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			require := trequire.New(t)
+
+			kubeClient := fake.NewSimpleClientset(tc.secret)
+			for _, pod := range tc.pods {
+				_, err := kubeClient.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+				require.Nil(err)
+			}
+			now := time.Now()
+			rotateGRPCBootstrapCertificates(kubeClient, certManager, validityPeriod, "osm", now)
+
+			secret, err := kubeClient.CoreV1().Secrets(tc.secret.Namespace).Get(context.Background(), tc.secret.Name, metav1.GetOptions{})
+			if tc.expectDeleted {
+				assert.True(apierrors.IsNotFound(err))
+				return
+			}
+			require.Nil(err)
+
+			if tc.expectXDSCertRotated {
+				assert.NotEqual(tc.secret.Data[grpcXDSCertFile], secret.Data[grpcXDSCertFile])
+				assert.NotEqual(tc.secret.Data[grpcXDSKeyFile], secret.Data[grpcXDSKeyFile])
+				cert, err := certificate.DecodePEMCertificate(secret.Data[grpcXDSCertFile])
+				require.Nil(err)
+				assert.False(shouldRenewGRPCWorkloadCert(cert.NotAfter, constants.XDSCertificateValidityPeriod, now))
+				assert.Equal(tc.secret.Data[grpcWorkloadCertFile], secret.Data[grpcWorkloadCertFile])
+				return
+			}
+			assert.Equal(tc.secret.Data[grpcXDSCertFile], secret.Data[grpcXDSCertFile])
+
+			if !tc.expectRotation {
+				assert.Equal(tc.secret.Data, secret.Data)
+				return
+			}
+
+			assert.NotEqual(tc.secret.Data[grpcWorkloadCertFile], secret.Data[grpcWorkloadCertFile])
+			assert.NotEqual(tc.secret.Data[grpcWorkloadKeyFile], secret.Data[grpcWorkloadKeyFile])
+			cert, err := certificate.DecodePEMCertificate(secret.Data[grpcWorkloadCertFile])
+			require.Nil(err)
+			workloadCert, err := certificate.DecodePEMCertificate(tc.secret.Data[grpcWorkloadCertFile])
+			require.Nil(err)
+			assert.Equal(workloadCert.Subject.CommonName, cert.Subject.CommonName)
+			assert.False(shouldRenewGRPCWorkloadCert(cert.NotAfter, validityPeriod, now))
+		})
+	}
+}
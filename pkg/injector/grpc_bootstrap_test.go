@@ -0,0 +1,56 @@
+package injector
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestGetGRPCBootstrapJSON(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	proxyUUID := uuid.New()
+	bootstrapJSON, err := getGRPCBootstrapJSON(proxyUUID, "osm-controller.osm-system.svc.cluster.local:15128")
+	require.Nil(err)
+
+	var bootstrap struct {
+		XDSServers []struct {
+			ServerURI    string `json:"server_uri"`
+			ChannelCreds []struct {
+				Type   string            `json:"type"`
+				Config map[string]string `json:"config"`
+			} `json:"channel_creds"`
+			ServerFeatures []string `json:"server_features"`
+		} `json:"xds_servers"`
+		Node struct {
+			ID string `json:"id"`
+		} `json:"node"`
+		CertificateProviders map[string]struct {
+			PluginName string            `json:"plugin_name"`
+			Config     map[string]string `json:"config"`
+		} `json:"certificate_providers"`
+	}
+	require.Nil(json.Unmarshal(bootstrapJSON, &bootstrap))
+
+	require.Len(bootstrap.XDSServers, 1)
+	assert.Equal("osm-controller.osm-system.svc.cluster.local:15128", bootstrap.XDSServers[0].ServerURI)
+	assert.Equal([]string{"xds_v3"}, bootstrap.XDSServers[0].ServerFeatures)
+	require.Len(bootstrap.XDSServers[0].ChannelCreds, 1)
+	assert.Equal("tls", bootstrap.XDSServers[0].ChannelCreds[0].Type)
+	assert.Equal("/etc/osm/grpc-xds/xds-cert.pem", bootstrap.XDSServers[0].ChannelCreds[0].Config["certificate_file"])
+
+	assert.Equal(proxyUUID.String(), bootstrap.Node.ID)
+
+	provider, ok := bootstrap.CertificateProviders[envoy.ProxylessGRPCCertProviderInstance]
+	require.True(ok)
+	assert.Equal("file_watcher", provider.PluginName)
+	assert.Equal("/etc/osm/grpc-xds/cert.pem", provider.Config["certificate_file"])
+	assert.Equal("/etc/osm/grpc-xds/key.pem", provider.Config["private_key_file"])
+	assert.Equal("/etc/osm/grpc-xds/ca.pem", provider.Config["ca_certificate_file"])
+}
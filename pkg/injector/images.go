@@ -0,0 +1,59 @@
+package injector
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+// getPodArch returns the node architecture the given pod is constrained to by its node selector,
+// empty if the pod can be scheduled on nodes of any architecture
+func getPodArch(pod *corev1.Pod) string {
+	return pod.Spec.NodeSelector[corev1.LabelArchStable]
+}
+
+// getEnvoyImage returns the Envoy image injected into a pod constrained to nodes of the given architecture.
+// The image of the proxy configuration class of the pod, if any, takes precedence over the images per architecture.
+func getEnvoyImage(cfg configurator.Configurator, arch string, classImage string) string {
+	if classImage != "" {
+		return overrideImageRegistry(classImage, cfg.GetImageRegistryOverride())
+	}
+
+	image := cfg.GetEnvoyImage()
+	if arch != "" {
+		if archImage, ok := cfg.GetEnvoyArchImages()[arch]; ok {
+			image = archImage
+		}
+	}
+	return overrideImageRegistry(image, cfg.GetImageRegistryOverride())
+}
+
+// getInitContainerImage returns the init container image injected into a pod constrained to nodes of the given architecture
+func getInitContainerImage(cfg configurator.Configurator, arch string) string {
+	image := cfg.GetInitContainerImage()
+	if arch != "" {
+		if archImage, ok := cfg.GetInitContainerArchImages()[arch]; ok {
+			image = archImage
+		}
+	}
+	return overrideImageRegistry(image, cfg.GetImageRegistryOverride())
+}
+
+// overrideImageRegistry returns the given image pulled from the given registry instead of its own registry.
+// As with the container runtime, the first component of the image name is the registry of the image if it
+// contains a '.' or a ':', or is 'localhost'. Images without a registry are pulled from Docker Hub by default.
+func overrideImageRegistry(image, registry string) string {
+	if registry == "" {
+		return image
+	}
+
+	name := image
+	if i := strings.Index(image, "/"); i >= 0 {
+		if host := image[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			name = image[i+1:]
+		}
+	}
+	return registry + "/" + name
+}
@@ -0,0 +1,121 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+func TestGetEnvoyImage(t *testing.T) {
+	testCases := []struct {
+		name             string
+		arch             string
+		archImages       map[string]string
+		classImage       string
+		registryOverride string
+		expectedImage    string
+	}{
+		{
+			name:          "pod not constrained to an architecture",
+			arch:          "",
+			expectedImage: "envoyproxy/envoy-alpine:v1.17.2",
+		},
+		{
+			name:          "architecture with a configured image",
+			arch:          "arm64",
+			archImages:    map[string]string{"arm64": "envoyproxy/envoy:v1.17.2"},
+			expectedImage: "envoyproxy/envoy:v1.17.2",
+		},
+		{
+			name:          "architecture without a configured image",
+			arch:          "amd64",
+			archImages:    map[string]string{"arm64": "envoyproxy/envoy:v1.17.2"},
+			expectedImage: "envoyproxy/envoy-alpine:v1.17.2",
+		},
+		{
+			name:             "registry override",
+			arch:             "arm64",
+			archImages:       map[string]string{"arm64": "envoyproxy/envoy:v1.17.2"},
+			registryOverride: "registry.example.com/mirror",
+			expectedImage:    "registry.example.com/mirror/envoyproxy/envoy:v1.17.2",
+		},
+		{
+			name:          "proxy configuration class image",
+			arch:          "arm64",
+			archImages:    map[string]string{"arm64": "envoyproxy/envoy:v1.17.2"},
+			classImage:    "envoyproxy/envoy:v1.18.3",
+			expectedImage: "envoyproxy/envoy:v1.18.3",
+		},
+		{
+			name:             "proxy configuration class image with registry override",
+			classImage:       "envoyproxy/envoy:v1.18.3",
+			registryOverride: "registry.example.com/mirror",
+			expectedImage:    "registry.example.com/mirror/envoyproxy/envoy:v1.18.3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+			mockConfigurator.EXPECT().GetEnvoyImage().Return("envoyproxy/envoy-alpine:v1.17.2").AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyArchImages().Return(tc.archImages).AnyTimes()
+			mockConfigurator.EXPECT().GetImageRegistryOverride().Return(tc.registryOverride).Times(1)
+
+			assert.Equal(tc.expectedImage, getEnvoyImage(mockConfigurator, tc.arch, tc.classImage))
+		})
+	}
+}
+
+func TestGetInitContainerImage(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+
+	mockConfigurator.EXPECT().GetInitContainerImage().Return("openservicemesh/init:latest").Times(1)
+	mockConfigurator.EXPECT().GetInitContainerArchImages().Return(map[string]string{"arm64": "openservicemesh/init:latest-arm64"}).Times(1)
+	mockConfigurator.EXPECT().GetImageRegistryOverride().Return("").Times(1)
+
+	assert.Equal("openservicemesh/init:latest-arm64", getInitContainerImage(mockConfigurator, "arm64"))
+}
+
+func TestGetPodArch(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal("", getPodArch(&corev1.Pod{}))
+	assert.Equal("arm64", getPodArch(&corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"},
+		},
+	}))
+}
+
+func TestOverrideImageRegistry(t *testing.T) {
+	testCases := []struct {
+		image         string
+		registry      string
+		expectedImage string
+	}{
+		{"envoyproxy/envoy-alpine:v1.17.2", "", "envoyproxy/envoy-alpine:v1.17.2"},
+		{"envoyproxy/envoy-alpine:v1.17.2", "mirror.example.com", "mirror.example.com/envoyproxy/envoy-alpine:v1.17.2"},
+		{"docker.io/openservicemesh/init:v0.8.0", "mirror.example.com/osm", "mirror.example.com/osm/openservicemesh/init:v0.8.0"},
+		{"localhost:5000/init:v0.8.0", "mirror.example.com", "mirror.example.com/init:v0.8.0"},
+		{"localhost/init:v0.8.0", "mirror.example.com", "mirror.example.com/init:v0.8.0"},
+		{"busybox", "mirror.example.com", "mirror.example.com/busybox"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.image, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expectedImage, overrideImageRegistry(tc.image, tc.registry))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package injector
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// ingressGatewayBootstrap is the part of the Envoy bootstrap config of the ingress gateway holding the certificate
+// the gateway connects to the xDS server with
+type ingressGatewayBootstrap struct {
+	StaticResources struct {
+		Clusters []struct {
+			Name            string `yaml:"name"`
+			TransportSocket struct {
+				TypedConfig struct {
+					CommonTLSContext struct {
+						TLSCertificates []struct {
+							CertificateChain struct {
+								InlineBytes string `yaml:"inline_bytes"`
+							} `yaml:"certificate_chain"`
+						} `yaml:"tls_certificates"`
+					} `yaml:"common_tls_context"`
+				} `yaml:"typed_config"`
+			} `yaml:"transport_socket"`
+		} `yaml:"clusters"`
+	} `yaml:"static_resources"`
+}
+
+// CreateIngressGatewayBootstrapConfig creates or updates the secret holding the Envoy bootstrap config of the OSM managed ingress gateway.
+// The ingress gateway is not injected by the webhook, so its xDS certificate is issued for the gateway's service account in the OSM namespace.
+// An existing secret is reused as long as its certificate is valid and issued by the current root certificate, so that the
+// running gateway pods keep a bootstrap config matching the mounted secret.
+func CreateIngressGatewayBootstrapConfig(kubeClient kubernetes.Interface, certManager certificate.Manager, cfg configurator.Configurator, meshName, osmNamespace string) error {
+	if existing, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), constants.OSMIngressGatewayBootstrapSecretName, metav1.GetOptions{}); err == nil {
+		err := validateIngressGatewayBootstrapCert(existing, certManager, time.Now())
+		if err == nil {
+			log.Debug().Msgf("Reusing the bootstrap config of the ingress gateway in secret %s/%s", osmNamespace, constants.OSMIngressGatewayBootstrapSecretName)
+			return nil
+		}
+		log.Info().Err(err).Msgf("Recreating the bootstrap config of the ingress gateway in secret %s/%s", osmNamespace, constants.OSMIngressGatewayBootstrapSecretName)
+	}
+
+	cn := catalog.NewCertCommonNameWithProxyID(uuid.New(), constants.OSMIngressGatewayName, osmNamespace)
+	bootstrapCertificate, err := certManager.IssueCertificate(cn, constants.XDSCertificateValidityPeriod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for the ingress gateway with CN=%s", cn)
+		return err
+	}
+
+	wh := &mutatingWebhook{
+		kubeClient:   kubeClient,
+		certManager:  certManager,
+		osmNamespace: osmNamespace,
+		meshName:     meshName,
+		configurator: cfg,
+	}
+
+	// The ingress gateway does not have application health probes to rewrite
+	if _, err := wh.createEnvoyBootstrapConfig(constants.OSMIngressGatewayBootstrapSecretName, osmNamespace, osmNamespace, bootstrapCertificate, healthProbes{}); err != nil {
+		log.Error().Err(err).Msgf("Failed to create Envoy bootstrap config for the ingress gateway with certificate CN=%s", cn)
+		return err
+	}
+
+	return nil
+}
+
+// validateIngressGatewayBootstrapCert returns an error if the certificate in the given bootstrap config secret of the
+// ingress gateway is expired, or is not issued by the root certificate of the given certificate manager
+func validateIngressGatewayBootstrapCert(secret *corev1.Secret, certManager certificate.Manager, now time.Time) error {
+	var bootstrap ingressGatewayBootstrap
+	if err := yaml.Unmarshal(secret.Data[envoyBootstrapConfigFile], &bootstrap); err != nil {
+		return errors.Wrap(err, "error parsing the bootstrap config")
+	}
+
+	for _, cluster := range bootstrap.StaticResources.Clusters {
+		tlsCertificates := cluster.TransportSocket.TypedConfig.CommonTLSContext.TLSCertificates
+		if cluster.Name != constants.OSMControllerName || len(tlsCertificates) == 0 {
+			continue
+		}
+
+		certChain, err := base64.StdEncoding.DecodeString(tlsCertificates[0].CertificateChain.InlineBytes)
+		if err != nil {
+			return errors.Wrap(err, "error decoding the certificate")
+		}
+		cert, err := certificate.DecodePEMCertificate(certChain)
+		if err != nil {
+			return errors.Wrap(err, "error decoding the certificate")
+		}
+		if !now.Before(cert.NotAfter) {
+			return errors.Errorf("certificate expired at %s", cert.NotAfter)
+		}
+
+		rootCertificate, err := certManager.GetRootCertificate()
+		if err != nil {
+			return errors.Wrap(err, "error getting the root certificate")
+		}
+		rootCert, err := certificate.DecodePEMCertificate(rootCertificate.GetCertificateChain())
+		if err != nil {
+			return errors.Wrap(err, "error decoding the root certificate")
+		}
+		if err := cert.CheckSignatureFrom(rootCert); err != nil {
+			return errors.Wrap(err, "certificate not issued by the root certificate")
+		}
+		return nil
+	}
+
+	return errors.Errorf("no certificate found for the %s cluster", constants.OSMControllerName)
+}
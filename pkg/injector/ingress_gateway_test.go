@@ -0,0 +1,74 @@
+package injector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestCreateIngressGatewayBootstrapConfig(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	kubeClient := fake.NewSimpleClientset()
+	certManager := tresor.NewFakeCertManager(mockConfigurator)
+	osmNamespace := "osm-system"
+
+	err := CreateIngressGatewayBootstrapConfig(kubeClient, certManager, mockConfigurator, "osm", osmNamespace)
+	require.Nil(err)
+
+	secret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), constants.OSMIngressGatewayBootstrapSecretName, metav1.GetOptions{})
+	require.Nil(err)
+	assert.Contains(string(secret.Data[envoyBootstrapConfigFile]), "osm-controller.osm-system.svc.cluster.local")
+	firstBootstrap := secret.Data[envoyBootstrapConfigFile]
+
+	// Creating the bootstrap config again reuses the existing secret while its certificate is valid
+	err = CreateIngressGatewayBootstrapConfig(kubeClient, certManager, mockConfigurator, "osm", osmNamespace)
+	require.Nil(err)
+
+	secret, err = kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), constants.OSMIngressGatewayBootstrapSecretName, metav1.GetOptions{})
+	require.Nil(err)
+	assert.Equal(firstBootstrap, secret.Data[envoyBootstrapConfigFile])
+
+	// The secret is updated with a new certificate once the root certificate changes
+	err = CreateIngressGatewayBootstrapConfig(kubeClient, tresor.NewFakeCertManager(mockConfigurator), mockConfigurator, "osm", osmNamespace)
+	require.Nil(err)
+
+	secret, err = kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), constants.OSMIngressGatewayBootstrapSecretName, metav1.GetOptions{})
+	require.Nil(err)
+	assert.NotEqual(firstBootstrap, secret.Data[envoyBootstrapConfigFile])
+}
+
+func TestValidateIngressGatewayBootstrapCert(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+	kubeClient := fake.NewSimpleClientset()
+	certManager := tresor.NewFakeCertManager(mockConfigurator)
+	osmNamespace := "osm-system"
+
+	err := CreateIngressGatewayBootstrapConfig(kubeClient, certManager, mockConfigurator, "osm", osmNamespace)
+	require.Nil(err)
+	secret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.Background(), constants.OSMIngressGatewayBootstrapSecretName, metav1.GetOptions{})
+	require.Nil(err)
+
+	assert.Nil(validateIngressGatewayBootstrapCert(secret, certManager, time.Now()))
+	assert.NotNil(validateIngressGatewayBootstrapCert(secret, certManager, time.Now().Add(constants.XDSCertificateValidityPeriod+time.Hour)))
+	assert.NotNil(validateIngressGatewayBootstrapCert(secret, tresor.NewFakeCertManager(mockConfigurator), time.Now()))
+	assert.NotNil(validateIngressGatewayBootstrapCert(&corev1.Secret{}, certManager, time.Now()))
+}
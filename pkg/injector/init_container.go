@@ -6,16 +6,22 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/featureflags"
 )
 
-func getInitContainerSpec(containerName string, cfg configurator.Configurator, outboundIPRangeExclusionList []string, outboundPortExclusionList []string,
-	enablePrivilegedInitContainer bool) corev1.Container {
-	iptablesInitCommandsList := generateIptablesCommands(outboundIPRangeExclusionList, outboundPortExclusionList)
+func getInitContainerSpec(containerName string, cfg configurator.Configurator, arch string, outboundIPRangeExclusionList []string, outboundPortExclusionList []string,
+	inboundPortExclusionList []string, enablePrivilegedInitContainer bool) corev1.Container {
+	iptablesInitCommandsList := generateIptablesCommands(outboundIPRangeExclusionList, outboundPortExclusionList, inboundPortExclusionList)
+	var volumeMounts []corev1.VolumeMount
+	if featureflags.IsEnvoyAdminUDSEnabled() {
+		iptablesInitCommandsList = append(iptablesInitCommandsList, getCopyOSMHealthcheckCommand())
+		volumeMounts = append(volumeMounts, getEnvoyAdminVolumeMount())
+	}
 	iptablesInitCommand := strings.Join(iptablesInitCommandsList, " && ")
 
 	return corev1.Container{
 		Name:  containerName,
-		Image: cfg.GetInitContainerImage(),
+		Image: getInitContainerImage(cfg, arch),
 		SecurityContext: &corev1.SecurityContext{
 			Privileged: &enablePrivilegedInitContainer,
 			Capabilities: &corev1.Capabilities{
@@ -29,5 +35,6 @@ func getInitContainerSpec(containerName string, cfg configurator.Configurator, o
 			"-c",
 			iptablesInitCommand,
 		},
+		VolumeMounts: volumeMounts,
 	}
 }
@@ -7,6 +7,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
+// maxMultiportPorts is the max number of ports an iptables rule matching multiple ports with the multiport module accepts
+const maxMultiportPorts = 15
+
 // iptablesRedirectionChains is the list of iptables chains created for traffic redirection via the proxy sidecar
 var iptablesRedirectionChains = []string{
 	// Chain to intercept inbound traffic
@@ -68,7 +71,7 @@ var iptablesInboundStaticRules = []string{
 }
 
 // generateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection
-func generateIptablesCommands(outboundIPRangeExclusionList, outboundPortExclusionList []string) []string {
+func generateIptablesCommands(outboundIPRangeExclusionList, outboundPortExclusionList, inboundPortExclusionList []string) []string {
 	var cmd []string
 
 	// 1. Create redirection chains
@@ -95,5 +98,12 @@ func generateIptablesCommands(outboundIPRangeExclusionList, outboundPortExclusio
 		cmd = append(cmd, rule)
 	}
 
+	// 6. Create dynamic inbound ports exclusion rule
+	if len(inboundPortExclusionList) > 0 {
+		inboundPortsToExclude := strings.Join(inboundPortExclusionList, ",")
+		rule := fmt.Sprintf("iptables -t nat -I PROXY_INBOUND -p tcp --match multiport --dports %s -j RETURN", inboundPortsToExclude)
+		cmd = append(cmd, rule)
+	}
+
 	return cmd
 }
@@ -0,0 +1,44 @@
+package injector
+
+import (
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+const (
+	// nativeSidecarMinKubernetesMinorVersion is the minor version of Kubernetes v1 from which sidecar containers are enabled by default
+	nativeSidecarMinKubernetesMinorVersion = 29
+)
+
+// isNativeSidecarSupported returns true if the Kubernetes server supports sidecar containers, which are
+// init containers with an 'Always' restart policy that run alongside the containers of the pod.
+func isNativeSidecarSupported(kubeClient kubernetes.Interface) bool {
+	version, err := k8s.GetKubernetesServerVersionNumber(kubeClient)
+	if err != nil {
+		log.Error().Err(err).Msg("Error determining if the Kubernetes server supports sidecar containers, assuming it does not")
+		return false
+	}
+
+	if len(version) < 2 {
+		return false
+	}
+
+	major, minor := version[0], version[1]
+	return major > 1 || (major == 1 && minor >= nativeSidecarMinKubernetesMinorVersion)
+}
+
+// getNativeSidecarPatch returns the patch to run the init container at the given index as a sidecar container.
+// The restart policy of a container is not part of the Kubernetes API version the injector is built with,
+// so it is set using a separate patch operation.
+func getNativeSidecarPatch(initContainerIdx int) jsonpatch.JsonPatchOperation {
+	return jsonpatch.JsonPatchOperation{
+		Operation: "add",
+		Path:      fmt.Sprintf("/spec/initContainers/%d/restartPolicy", initContainerIdx),
+		Value:     string(corev1.RestartPolicyAlways),
+	}
+}
@@ -0,0 +1,56 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestIsNativeSidecarSupported(t *testing.T) {
+	testCases := []struct {
+		name     string
+		version  string
+		expected bool
+	}{
+		{
+			name:     "Kubernetes version without sidecar containers",
+			version:  "v1.20.5",
+			expected: false,
+		},
+		{
+			name:     "Kubernetes version with sidecar containers enabled by default",
+			version:  "v1.29.0",
+			expected: true,
+		},
+		{
+			name:     "invalid Kubernetes version",
+			version:  "foo",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			kubeClient := fakeclient.NewSimpleClientset()
+			kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{
+				GitVersion: tc.version,
+			}
+
+			assert.Equal(tc.expected, isNativeSidecarSupported(kubeClient))
+		})
+	}
+}
+
+func TestGetNativeSidecarPatch(t *testing.T) {
+	assert := tassert.New(t)
+
+	patch := getNativeSidecarPatch(2)
+	assert.Equal("add", patch.Operation)
+	assert.Equal("/spec/initContainers/2/restartPolicy", patch.Path)
+	assert.Equal("Always", patch.Value)
+}
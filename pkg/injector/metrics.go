@@ -1,9 +1,11 @@
 package injector
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 )
@@ -34,3 +36,43 @@ func (wh *mutatingWebhook) isMetricsEnabled(namespace string) (enabled bool, err
 	}
 	return
 }
+
+// getInboundMetricsPorts returns the ports specified by the 'openservicemesh.io/inbound-metrics-ports' annotation
+// on the given pod, as a comma separated list of ports. Traffic to these ports bypasses the sidecar so that the metrics
+// served by the application on these ports can be scraped in plaintext by a Prometheus instance outside the mesh.
+func getInboundMetricsPorts(pod *corev1.Pod) ([]string, error) {
+	portsStr, ok := pod.Annotations[constants.InboundMetricsPortsAnnotation]
+	if !ok || strings.TrimSpace(portsStr) == "" {
+		return nil, nil
+	}
+
+	var ports []string
+	for _, portStr := range strings.Split(portsStr, ",") {
+		portStr = strings.TrimSpace(portStr)
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil || port == 0 {
+			return nil, errors.Errorf("Invalid port %q specified by annotation %s", portStr, constants.InboundMetricsPortsAnnotation)
+		}
+		if isSidecarPort(int32(port)) {
+			return nil, errors.Errorf("Port %d specified by annotation %s is reserved by the sidecar", port, constants.InboundMetricsPortsAnnotation)
+		}
+		ports = append(ports, portStr)
+	}
+
+	// The ports are exempted from interception by a single iptables multiport rule
+	if len(ports) > maxMultiportPorts {
+		return nil, errors.Errorf("Annotation %s specifies %d ports, at most %d ports can be specified", constants.InboundMetricsPortsAnnotation, len(ports), maxMultiportPorts)
+	}
+
+	return ports, nil
+}
+
+// isSidecarPort returns true if the given port is one of the ports the sidecar listens on
+func isSidecarPort(port int32) bool {
+	switch port {
+	case constants.EnvoyAdminPort, constants.EnvoyOutboundListenerPort, constants.EnvoyInboundListenerPort,
+		constants.EnvoyPrometheusInboundListenerPort, livenessProbePort, readinessProbePort, startupProbePort:
+		return true
+	}
+	return false
+}
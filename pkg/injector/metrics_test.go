@@ -86,3 +86,66 @@ func TestIsMetricsEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInboundMetricsPorts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		annotations   map[string]string
+		expectedPorts []string
+		expectError   bool
+	}{
+		{
+			name:          "no annotation",
+			annotations:   nil,
+			expectedPorts: nil,
+		},
+		{
+			name:          "valid ports",
+			annotations:   map[string]string{constants.InboundMetricsPortsAnnotation: "9090, 9091"},
+			expectedPorts: []string{"9090", "9091"},
+		},
+		{
+			name:        "invalid port",
+			annotations: map[string]string{constants.InboundMetricsPortsAnnotation: "9090,metrics"},
+			expectError: true,
+		},
+		{
+			name:        "port out of range",
+			annotations: map[string]string{constants.InboundMetricsPortsAnnotation: "70000"},
+			expectError: true,
+		},
+		{
+			name:          "max number of ports",
+			annotations:   map[string]string{constants.InboundMetricsPortsAnnotation: "9001,9002,9003,9004,9005,9006,9007,9008,9009,9010,9011,9012,9013,9014,9015"},
+			expectedPorts: []string{"9001", "9002", "9003", "9004", "9005", "9006", "9007", "9008", "9009", "9010", "9011", "9012", "9013", "9014", "9015"},
+		},
+		{
+			name:        "too many ports",
+			annotations: map[string]string{constants.InboundMetricsPortsAnnotation: "9001,9002,9003,9004,9005,9006,9007,9008,9009,9010,9011,9012,9013,9014,9015,9016"},
+			expectError: true,
+		},
+		{
+			name:        "sidecar port",
+			annotations: map[string]string{constants.InboundMetricsPortsAnnotation: fmt.Sprintf("%d", constants.EnvoyInboundListenerPort)},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod",
+					Namespace:   "ns",
+					Annotations: tc.annotations,
+				},
+			}
+
+			ports, err := getInboundMetricsPorts(pod)
+			assert.Equal(tc.expectError, err != nil)
+			assert.Equal(tc.expectedPorts, ports)
+		})
+	}
+}
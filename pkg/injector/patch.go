@@ -14,6 +14,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
@@ -34,6 +35,12 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	metricsstore.DefaultMetricsStore.CertIssuedCount.Inc()
 	metricsstore.DefaultMetricsStore.CertIssuedTime.
 		WithLabelValues().Observe(elapsed.Seconds())
+
+	if isProxylessGRPCPod(pod) {
+		// Proxyless gRPC applications connect to xDS themselves, the sidecar is not injected
+		return wh.createProxylessGRPCPatch(pod, req, proxyUUID, bootstrapCertificate)
+	}
+
 	originalHealthProbes := rewriteHealthProbes(pod)
 
 	// Create the bootstrap configuration for the Envoy proxy for the given pod
@@ -53,13 +60,42 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	// Create volume for envoy TLS secret
 	pod.Spec.Volumes = append(pod.Spec.Volumes, getVolumeSpec(envoyBootstrapConfigName)...)
 
+	// Exempt the ports serving application metrics to scrapers outside the mesh from inbound interception
+	inboundMetricsPorts, err := getInboundMetricsPorts(pod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting inbound metrics ports for pod: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
+		return nil, err
+	}
+
+	// Exclude the well-known destinations, such as the cloud metadata service, from outbound interception
+	// along with the IP ranges explicitly excluded
+	outboundIPRangeExclusionList := append(wh.configurator.GetOutboundIPRangeExclusionList(), wh.configurator.GetOutboundWellKnownExclusionIPRanges()...)
+
 	// Add the Init Container
-	initContainer := getInitContainerSpec(constants.InitContainerName, wh.configurator, wh.configurator.GetOutboundIPRangeExclusionList(), wh.configurator.GetOutboundPortExclusionList(), wh.configurator.IsPrivilegedInitContainer())
+	initContainer := getInitContainerSpec(constants.InitContainerName, wh.configurator, getPodArch(pod), outboundIPRangeExclusionList, wh.configurator.GetOutboundPortExclusionList(),
+		inboundMetricsPorts, wh.configurator.IsPrivilegedInitContainer())
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
 
 	// Add the Envoy sidecar
-	sidecar := getEnvoySidecarContainerSpec(pod, wh.configurator, originalHealthProbes)
-	pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	// The settings of the proxy configuration class of the pod, if any, take precedence over the mesh-wide settings
+	proxyConfigClassName, proxyConfigClass := wh.getProxyConfigClass(pod, namespace)
+	sidecar := getEnvoySidecarContainerSpec(pod, wh.configurator, originalHealthProbes, proxyConfigClassName, proxyConfigClass)
+	sidecar.Resources, err = wh.getSidecarResources(namespace, proxyConfigClass)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting sidecar resources for pod: service-account=%s, namespace=%s", pod.Spec.ServiceAccountName, namespace)
+		return nil, err
+	}
+	nativeSidecarIdx := -1
+	if wh.nativeSidecarSupported && k8s.IsJobPod(pod) {
+		// A Job completes once all the containers of its pod exit, so the Envoy sidecar of a Job pod runs as a
+		// sidecar container, which is stopped by the kubelet after the containers of the pod exit.
+		// On Kubernetes versions without sidecar containers, the Envoy sidecar of a Job pod is requested to exit
+		// by the controller instead.
+		nativeSidecarIdx = len(pod.Spec.InitContainers)
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, sidecar)
+	} else {
+		pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	}
 
 	enableMetrics, err := wh.isMetricsEnabled(namespace)
 	if err != nil {
@@ -83,7 +119,12 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	}
 	pod.Labels[constants.EnvoyUniqueIDLabelName] = proxyUUID.String()
 
-	return json.Marshal(makePatches(req, pod))
+	patches := makePatches(req, pod)
+	if nativeSidecarIdx >= 0 {
+		patches = append(patches, getNativeSidecarPatch(nativeSidecarIdx))
+	}
+
+	return json.Marshal(patches)
 }
 
 func makePatches(req *admissionv1.AdmissionRequest, pod *corev1.Pod) []jsonpatch.JsonPatchOperation {
@@ -36,7 +36,7 @@ var _ = Describe("Test all patch operations", func() {
 			mockCtrl := gomock.NewController(GinkgoT())
 			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
 			mockNsController := k8s.NewMockController(mockCtrl)
-			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{})
+			mockNsController.EXPECT().GetNamespace(namespace).Return(&corev1.Namespace{}).Times(3)
 			testNamespace := &corev1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "default",
@@ -58,8 +58,10 @@ var _ = Describe("Test all patch operations", func() {
 			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("").Times(1)
 			mockConfigurator.EXPECT().GetEnvoyImage().Return("").Times(1)
 			mockConfigurator.EXPECT().GetInitContainerImage().Return("").Times(1)
+			mockConfigurator.EXPECT().GetImageRegistryOverride().Return("").Times(2)
 			mockConfigurator.EXPECT().IsPrivilegedInitContainer().Return(false).Times(1)
 			mockConfigurator.EXPECT().GetOutboundIPRangeExclusionList().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOutboundWellKnownExclusionIPRanges().Return(nil).Times(1)
 			mockConfigurator.EXPECT().GetOutboundPortExclusionList().Return(nil).Times(1)
 
 			req := &admissionv1.AdmissionRequest{Namespace: namespace}
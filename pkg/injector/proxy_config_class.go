@@ -0,0 +1,31 @@
+package injector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// getProxyConfigClass returns the name and settings of the proxy configuration class referenced by the annotation of
+// the given pod, or by the annotation of its namespace when the pod is not annotated. The name is empty when no class
+// is referenced or the referenced class is not defined, in which case the sidecar is injected with the mesh-wide settings.
+func (wh *mutatingWebhook) getProxyConfigClass(pod *corev1.Pod, namespace string) (string, configurator.ProxyConfigClass) {
+	name, ok := pod.Annotations[constants.ProxyConfigClassAnnotation]
+	if !ok {
+		if ns := wh.kubeController.GetNamespace(namespace); ns != nil {
+			name = ns.Annotations[constants.ProxyConfigClassAnnotation]
+		}
+	}
+	if name == "" {
+		return "", configurator.ProxyConfigClass{}
+	}
+
+	class, ok := wh.configurator.GetProxyConfigClass(name)
+	if !ok {
+		log.Warn().Msgf("Proxy configuration class %s referenced by pod with service-account=%s, namespace=%s is not defined, injecting the sidecar with the mesh-wide settings",
+			name, pod.Spec.ServiceAccountName, namespace)
+		return "", configurator.ProxyConfigClass{}
+	}
+	return name, class
+}
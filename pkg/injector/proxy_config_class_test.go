@@ -0,0 +1,82 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+func TestGetProxyConfigClass(t *testing.T) {
+	canary := configurator.ProxyConfigClass{LogLevel: "debug", Concurrency: 2}
+
+	testCases := []struct {
+		name              string
+		podAnnotations    map[string]string
+		namespace         *corev1.Namespace
+		expectedClassName string
+		expectedClass     configurator.ProxyConfigClass
+	}{
+		{
+			name:      "no class referenced",
+			namespace: newNamespace("ns-1", nil),
+		},
+		{
+			name:              "class referenced by the pod",
+			podAnnotations:    map[string]string{constants.ProxyConfigClassAnnotation: "canary"},
+			expectedClassName: "canary",
+			expectedClass:     canary,
+		},
+		{
+			name:              "class referenced by the namespace",
+			namespace:         newNamespace("ns-1", map[string]string{constants.ProxyConfigClassAnnotation: "canary"}),
+			expectedClassName: "canary",
+			expectedClass:     canary,
+		},
+		{
+			name:           "pod opting out of the class of the namespace",
+			podAnnotations: map[string]string{constants.ProxyConfigClassAnnotation: ""},
+		},
+		{
+			name:           "undefined class",
+			podAnnotations: map[string]string{constants.ProxyConfigClassAnnotation: "unknown"},
+		},
+		{
+			name: "namespace not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockController := k8s.NewMockController(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockController.EXPECT().GetNamespace("ns-1").Return(tc.namespace).AnyTimes()
+			mockConfigurator.EXPECT().GetProxyConfigClass("canary").Return(canary, true).AnyTimes()
+			mockConfigurator.EXPECT().GetProxyConfigClass("unknown").Return(configurator.ProxyConfigClass{}, false).AnyTimes()
+
+			wh := &mutatingWebhook{
+				kubeController: mockController,
+				configurator:   mockConfigurator,
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns-1",
+					Annotations: tc.podAnnotations,
+				},
+			}
+
+			className, class := wh.getProxyConfigClass(pod, "ns-1")
+			assert.Equal(tc.expectedClassName, className)
+			assert.Equal(tc.expectedClass, class)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package injector
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// getSidecarResources returns the resource requirements of the Envoy sidecars injected into the pods of the given namespace,
+// as specified by the annotations of the namespace or by the given proxy configuration class. A class that sets any
+// resource requirement is applied as a unit, replacing the requests of the namespace, so that the limits of a class are
+// never combined with requests they were not sized for. The requirements are left unset when neither the namespace nor
+// the class specify them, and an error is returned when a limit is lower than the corresponding request.
+func (wh *mutatingWebhook) getSidecarResources(namespace string, class configurator.ProxyConfigClass) (corev1.ResourceRequirements, error) {
+	ns := wh.kubeController.GetNamespace(namespace)
+	if ns == nil {
+		log.Error().Err(errNamespaceNotFound).Msgf("Error retrieving namespace %s", namespace)
+		return corev1.ResourceRequirements{}, errNamespaceNotFound
+	}
+
+	if classSetsResources(class) {
+		return getClassSidecarResources(class)
+	}
+
+	requests := corev1.ResourceList{}
+	for resourceName, annotation := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    constants.SidecarCPURequestAnnotation,
+		corev1.ResourceMemory: constants.SidecarMemoryRequestAnnotation,
+	} {
+		val, ok := ns.Annotations[annotation]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(val)
+		if err != nil || quantity.Sign() <= 0 {
+			return corev1.ResourceRequirements{}, errors.Errorf("Invalid value %q for annotation %s on namespace %s, must be a positive quantity", val, annotation, namespace)
+		}
+		requests[resourceName] = quantity
+	}
+
+	var resources corev1.ResourceRequirements
+	if len(requests) > 0 {
+		resources.Requests = requests
+	}
+	return resources, nil
+}
+
+// classSetsResources returns whether the given proxy configuration class sets any resource requirement
+func classSetsResources(class configurator.ProxyConfigClass) bool {
+	return class.CPURequest != "" || class.MemoryRequest != "" || class.CPULimit != "" || class.MemoryLimit != ""
+}
+
+// getClassSidecarResources returns the resource requirements of the Envoy sidecars set by the given proxy configuration class
+func getClassSidecarResources(class configurator.ProxyConfigClass) (corev1.ResourceRequirements, error) {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	for _, classResource := range []struct {
+		list  corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+	}{
+		{requests, corev1.ResourceCPU, class.CPURequest},
+		{requests, corev1.ResourceMemory, class.MemoryRequest},
+		{limits, corev1.ResourceCPU, class.CPULimit},
+		{limits, corev1.ResourceMemory, class.MemoryLimit},
+	} {
+		if classResource.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(classResource.value)
+		if err != nil || quantity.Sign() <= 0 {
+			return corev1.ResourceRequirements{}, errors.Errorf("Invalid %s quantity %q in proxy configuration class, must be a positive quantity", classResource.name, classResource.value)
+		}
+		classResource.list[classResource.name] = quantity
+	}
+
+	for resourceName, limit := range limits {
+		if request, ok := requests[resourceName]; ok && limit.Cmp(request) < 0 {
+			return corev1.ResourceRequirements{}, errors.Errorf("Invalid proxy configuration class, %s limit %s is lower than the %s request %s", resourceName, limit.String(), resourceName, request.String())
+		}
+	}
+
+	var resources corev1.ResourceRequirements
+	if len(requests) > 0 {
+		resources.Requests = requests
+	}
+	if len(limits) > 0 {
+		resources.Limits = limits
+	}
+	return resources, nil
+}
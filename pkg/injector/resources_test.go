@@ -0,0 +1,144 @@
+package injector
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+func TestGetSidecarResources(t *testing.T) {
+	testCases := []struct {
+		name              string
+		namespace         *corev1.Namespace
+		class             configurator.ProxyConfigClass
+		expectedResources corev1.ResourceRequirements
+		expectErr         bool
+	}{
+		{
+			name:              "namespace without annotations",
+			namespace:         newNamespace("ns-1", nil),
+			expectedResources: corev1.ResourceRequirements{},
+		},
+		{
+			name: "namespace with CPU and memory requests",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarCPURequestAnnotation:    "50m",
+				constants.SidecarMemoryRequestAnnotation: "64Mi",
+			}),
+			expectedResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("50m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+			},
+		},
+		{
+			name: "namespace with memory request only",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarMemoryRequestAnnotation: "128Mi",
+			}),
+			expectedResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+			},
+		},
+		{
+			name: "invalid quantity",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarCPURequestAnnotation: "a lot",
+			}),
+			expectErr: true,
+		},
+		{
+			name: "negative quantity",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarMemoryRequestAnnotation: "-64Mi",
+			}),
+			expectErr: true,
+		},
+		{
+			name: "proxy configuration class replacing the namespace requests",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarCPURequestAnnotation:    "50m",
+				constants.SidecarMemoryRequestAnnotation: "64Mi",
+			}),
+			class: configurator.ProxyConfigClass{CPURequest: "100m", CPULimit: "1", MemoryLimit: "256Mi"},
+			expectedResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("100m"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+		{
+			name: "proxy configuration class limits not combined with the namespace requests",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarMemoryRequestAnnotation: "512Mi",
+			}),
+			class: configurator.ProxyConfigClass{MemoryLimit: "256Mi"},
+			expectedResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+		{
+			name: "proxy configuration class without resources keeping the namespace requests",
+			namespace: newNamespace("ns-1", map[string]string{
+				constants.SidecarCPURequestAnnotation: "50m",
+			}),
+			class: configurator.ProxyConfigClass{LogLevel: "debug"},
+			expectedResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("50m"),
+				},
+			},
+		},
+		{
+			name:      "proxy configuration class limit lower than its request",
+			namespace: newNamespace("ns-1", nil),
+			class:     configurator.ProxyConfigClass{CPURequest: "500m", CPULimit: "250m"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid proxy configuration class quantity",
+			namespace: newNamespace("ns-1", nil),
+			class:     configurator.ProxyConfigClass{MemoryRequest: "0"},
+			expectErr: true,
+		},
+		{
+			name:      "namespace not found",
+			namespace: nil,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockController := k8s.NewMockController(gomock.NewController(t))
+			mockController.EXPECT().GetNamespace("ns-1").Return(tc.namespace)
+
+			wh := &mutatingWebhook{
+				kubeController: mockController,
+			}
+
+			resources, err := wh.getSidecarResources("ns-1", tc.class)
+			assert.Equal(tc.expectErr, err != nil)
+			if !tc.expectErr {
+				assert.Equal(tc.expectedResources, resources)
+			}
+		})
+	}
+}
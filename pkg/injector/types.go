@@ -10,6 +10,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/configurator"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/webhook"
 )
 
 const (
@@ -26,9 +27,12 @@ type mutatingWebhook struct {
 	kubeController k8s.Controller
 	osmNamespace   string
 	meshName       string
-	cert           certificate.Certificater
+	certRotator    *webhook.CertRotator
 	configurator   configurator.Configurator
 
+	// nativeSidecarSupported indicates whether the Kubernetes server supports sidecar containers
+	nativeSidecarSupported bool
+
 	nonInjectNamespaces mapset.Set
 }
 
@@ -41,6 +45,11 @@ type Config struct {
 // Context needed to compose the Envoy bootstrap YAML.
 type envoyBootstrapConfigMeta struct {
 	EnvoyAdminPort int
+
+	// EnvoyAdminSocketPath is the path of the Unix domain socket the admin interface is bound to.
+	// If empty, the admin interface is bound to EnvoyAdminPort on localhost.
+	EnvoyAdminSocketPath string
+
 	XDSClusterName string
 	RootCert       string
 	Cert           string
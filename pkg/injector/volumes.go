@@ -2,11 +2,13 @@ package injector
 
 import (
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
 )
 
 // getVolumeSpec returns a list of volumes to add to the POD
 func getVolumeSpec(envoyBootstrapConfigName string) []corev1.Volume {
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: envoyBootstrapConfigVolume,
 			VolumeSource: corev1.VolumeSource{
@@ -16,4 +18,10 @@ func getVolumeSpec(envoyBootstrapConfigName string) []corev1.Volume {
 			},
 		},
 	}
+
+	if featureflags.IsEnvoyAdminUDSEnabled() {
+		volumes = append(volumes, getEnvoyAdminVolume())
+	}
+
+	return volumes
 }
@@ -60,9 +60,8 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 	// This is a certificate issued for the webhook handler
 	// This cert does not have to be related to the Envoy certs, but it does have to match
 	// the cert provisioned with the MutatingWebhookConfiguration
-	webhookHandlerCert, err := certManager.IssueCertificate(
-		certificate.CommonName(fmt.Sprintf("%s.%s.svc", injectorServiceName, osmNamespace)),
-		constants.XDSCertificateValidityPeriod)
+	cn := certificate.CommonName(fmt.Sprintf("%s.%s.svc", injectorServiceName, osmNamespace))
+	webhookHandlerCert, err := certManager.IssueCertificate(cn, constants.XDSCertificateValidityPeriod)
 	if err != nil {
 		return errors.Errorf("Error issuing certificate for the mutating webhook: %+v", err)
 	}
@@ -74,6 +73,19 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 		return errors.Errorf("Error fetching webhook certificate from k8s secret: %s", err)
 	}
 
+	// Rotate the webhook certificate before it expires. All the instances serve the renewed certificate stored in the
+	// k8s secret by the first instance renewing it.
+	certRotator, err := webhook.NewCertRotator(certManager, cn, webhookHandlerCert, constants.XDSCertificateValidityPeriod,
+		func(caBundle []byte) error {
+			return updateMutatingWebhookCABundle(caBundle, webhookConfigName, kubeClient)
+		},
+		func(renewed, current certificate.Certificater) (certificate.Certificater, error) {
+			return providers.RenewCertificateInSecret(osmNamespace, constants.WebhookCertificateSecretName, renewed, current, kubeClient)
+		})
+	if err != nil {
+		return errors.Errorf("Error creating webhook certificate rotator: %s", err)
+	}
+
 	wh := mutatingWebhook{
 		config:         config,
 		kubeClient:     kubeClient,
@@ -81,9 +93,11 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 		kubeController: kubeController,
 		osmNamespace:   osmNamespace,
 		meshName:       meshName,
-		cert:           webhookHandlerCert,
+		certRotator:    certRotator,
 		configurator:   cfg,
 
+		nativeSidecarSupported: isNativeSidecarSupported(kubeClient),
+
 		// Envoy sidecars should never be injected in these namespaces
 		nonInjectNamespaces: mapset.NewSetFromSlice([]interface{}{
 			metav1.NamespaceSystem,
@@ -94,9 +108,10 @@ func NewMutatingWebhook(config Config, kubeClient kubernetes.Interface, certMana
 
 	// Start the MutatingWebhook web server
 	go wh.run(stop)
+	go certRotator.Run(stop)
 
 	// Update the MutatingWebhookConfig with the OSM CA bundle
-	if err = updateMutatingWebhookCABundle(webhookHandlerCert, webhookConfigName, wh.kubeClient); err != nil {
+	if err = updateMutatingWebhookCABundle(webhookHandlerCert.GetCertificateChain(), webhookConfigName, wh.kubeClient); err != nil {
 		return errors.Errorf("Error configuring MutatingWebhookConfiguration %s: %+v", webhookConfigName, err)
 	}
 	return nil
@@ -121,16 +136,10 @@ func (wh *mutatingWebhook) run(stop <-chan struct{}) {
 
 	log.Info().Msgf("Starting sidecar-injection webhook server on port: %v", wh.config.ListenPort)
 	go func() {
-		// Generate a key pair from your pem-encoded cert and key ([]byte).
-		cert, err := tls.X509KeyPair(wh.cert.GetCertificateChain(), wh.cert.GetPrivateKey())
-		if err != nil {
-			log.Error().Err(err).Msg("Error parsing webhook certificate")
-			return
-		}
-
+		// The certificate served is rotated before it expires
 		// #nosec G402
 		server.TLSConfig = &tls.Config{
-			Certificates: []tls.Certificate{cert},
+			GetCertificate: wh.certRotator.GetCertificate,
 		}
 
 		if err := server.ListenAndServeTLS("", ""); err != nil {
@@ -339,7 +348,7 @@ func patchAdmissionResponse(resp *admissionv1.AdmissionResponse, patchBytes []by
 }
 
 // getPartialMutatingWebhookConfiguration returns only the portion of the MutatingWebhookConfiguration that needs to be updated.
-func getPartialMutatingWebhookConfiguration(cert certificate.Certificater, webhookConfigName string) admissionregv1.MutatingWebhookConfiguration {
+func getPartialMutatingWebhookConfiguration(caBundle []byte, webhookConfigName string) admissionregv1.MutatingWebhookConfiguration {
 	return admissionregv1.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: webhookConfigName,
@@ -348,7 +357,7 @@ func getPartialMutatingWebhookConfiguration(cert certificate.Certificater, webho
 			{
 				Name: MutatingWebhookName,
 				ClientConfig: admissionregv1.WebhookClientConfig{
-					CABundle: cert.GetCertificateChain(),
+					CABundle: caBundle,
 				},
 				SideEffects: func() *admissionregv1.SideEffectClass {
 					sideEffect := admissionregv1.SideEffectClassNoneOnDryRun
@@ -360,12 +369,12 @@ func getPartialMutatingWebhookConfiguration(cert certificate.Certificater, webho
 	}
 }
 
-// updateMutatingWebhookCABundle updates the existing MutatingWebhookConfiguration with the CA this OSM instance runs with.
+// updateMutatingWebhookCABundle updates the existing MutatingWebhookConfiguration with the given CA bundle this OSM instance runs with.
 // It is necessary to perform this patch because the original MutatingWebhookConfig YAML does not contain the root certificate.
-func updateMutatingWebhookCABundle(cert certificate.Certificater, webhookName string, clientSet kubernetes.Interface) error {
+func updateMutatingWebhookCABundle(caBundle []byte, webhookName string, clientSet kubernetes.Interface) error {
 	mwc := clientSet.AdmissionregistrationV1().MutatingWebhookConfigurations()
 
-	patchJSON, err := json.Marshal(getPartialMutatingWebhookConfiguration(cert, webhookName))
+	patchJSON, err := json.Marshal(getPartialMutatingWebhookConfiguration(caBundle, webhookName))
 	if err != nil {
 		return err
 	}
@@ -72,7 +72,7 @@ var _ = Describe("Test MutatingWebhookConfiguration patch", func() {
 		})
 
 		It("patches a webhook", func() {
-			err := updateMutatingWebhookCABundle(cert, webhookName, kubeClient)
+			err := updateMutatingWebhookCABundle(cert.GetCertificateChain(), webhookName, kubeClient)
 			Expect(err).ToNot(HaveOccurred())
 
 		})
@@ -630,7 +630,7 @@ var _ = Describe("Testing Injector Functions", func() {
 		cert := mockCertificate{}
 		webhookConfigName := "-webhook-config-name-"
 
-		actual := getPartialMutatingWebhookConfiguration(cert, webhookConfigName)
+		actual := getPartialMutatingWebhookConfiguration(cert.GetCertificateChain(), webhookConfigName)
 
 		expected := admissionregv1.MutatingWebhookConfiguration{
 			ObjectMeta: metav1.ObjectMeta{
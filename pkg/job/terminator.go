@@ -0,0 +1,172 @@
+package job
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+	"github.com/openservicemesh/osm/pkg/leader"
+)
+
+const (
+	// envoyQuitPath is the path of the Envoy admin endpoint that cleanly exits the server
+	envoyQuitPath = "quitquitquit"
+)
+
+// NewProxyTerminator creates a new ProxyTerminator.
+func NewProxyTerminator(kubeClient kubernetes.Interface, kubeConfig *rest.Config, leader leader.Checker) *ProxyTerminator {
+	t := &ProxyTerminator{
+		kubeClient: kubeClient,
+		kubeConfig: kubeConfig,
+		leader:     leader,
+	}
+	t.quitProxy = t.quitProxyWithAdmin
+	return t
+}
+
+// Start starts processing pod updates to terminate the Envoy sidecars of Job pods.
+func (t *ProxyTerminator) Start(stop <-chan struct{}) {
+	podSubscription := events.GetPubSubInstance().Subscribe(announcements.PodUpdated, announcements.PodDeleted)
+
+	go func() {
+		defer events.GetPubSubInstance().Unsub(podSubscription)
+		for {
+			select {
+			case <-stop:
+				return
+			case msg := <-podSubscription:
+				psubMessage, castOk := msg.(events.PubSubMessage)
+				if !castOk {
+					log.Error().Msgf("Error casting PubSubMessage: %v", msg)
+					continue
+				}
+
+				switch psubMessage.AnnouncementType {
+				case announcements.PodUpdated:
+					if pod, ok := psubMessage.NewObj.(*corev1.Pod); ok {
+						t.handlePodUpdate(pod)
+					}
+				case announcements.PodDeleted:
+					if pod, ok := psubMessage.OldObj.(*corev1.Pod); ok {
+						t.quitProxies.Delete(pod.UID)
+					}
+				}
+			}
+		}
+	}()
+}
+
+func (t *ProxyTerminator) handlePodUpdate(pod *corev1.Pod) {
+	// Only the leader requests the Envoy sidecars to exit. The pods are updated on every resync of the informers,
+	// so a replica becoming the leader handles the pods the previous leader did not.
+	if !t.leader.IsLeader() || !shouldQuitProxy(pod) {
+		return
+	}
+
+	if _, alreadyRequested := t.quitProxies.LoadOrStore(pod.UID, struct{}{}); alreadyRequested {
+		return
+	}
+
+	go func() {
+		log.Info().Msgf("Containers of Job pod %s/%s exited, requesting its Envoy sidecar to exit", pod.Namespace, pod.Name)
+		if err := t.quitProxy(pod); err != nil {
+			log.Error().Err(err).Msgf("Error requesting Envoy sidecar of Job pod %s/%s to exit", pod.Namespace, pod.Name)
+			// Retry on the next update of the pod
+			t.quitProxies.Delete(pod.UID)
+		}
+	}()
+}
+
+// shouldQuitProxy returns true if the given pod is a running Job pod whose containers, except for the Envoy sidecar, exited
+// and will not be restarted.
+func shouldQuitProxy(pod *corev1.Pod) bool {
+	if !k8s.IsJobPod(pod) || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	envoyRunning := false
+	exitedContainers := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == constants.EnvoyContainerName {
+			envoyRunning = status.State.Running != nil
+			continue
+		}
+
+		terminated := status.State.Terminated
+		if terminated == nil {
+			return false
+		}
+		// A container that failed is restarted, unless the restart policy of the pod is Never
+		if terminated.ExitCode != 0 && pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+			return false
+		}
+		exitedContainers++
+	}
+
+	return envoyRunning && exitedContainers > 0
+}
+
+// quitProxyWithAdmin requests the Envoy sidecar of the given pod to exit using its admin interface, which is reached
+// by port forwarding to its admin port if exposed, or with osm-healthcheck if bound to a Unix domain socket.
+func (t *ProxyTerminator) quitProxyWithAdmin(pod *corev1.Pod) error {
+	if k8s.IsEnvoyAdminPortExposed(pod) {
+		return t.quitProxyWithPortForward(pod)
+	}
+	return t.quitProxyWithExec(pod)
+}
+
+// quitProxyWithExec requests the Envoy sidecar of the given pod to exit using its admin interface bound to a
+// Unix domain socket, which is reached by executing osm-healthcheck in the Envoy sidecar container.
+func (t *ProxyTerminator) quitProxyWithExec(pod *corev1.Pod) error {
+	command := []string{constants.OSMHealthcheckPath, "admin", http.MethodPost, envoyQuitPath}
+	return k8s.ExecInPod(t.kubeConfig, t.kubeClient, pod.Name, pod.Namespace, constants.EnvoyContainerName, command, ioutil.Discard, ioutil.Discard)
+}
+
+// quitProxyWithPortForward requests the Envoy sidecar of the given pod to exit using its admin interface,
+// which is reached by port forwarding to the pod.
+func (t *ProxyTerminator) quitProxyWithPortForward(pod *corev1.Pod) error {
+	dialer, err := k8s.DialerToPod(t.kubeConfig, t.kubeClient, pod.Name, pod.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Forward a random local port to the Envoy admin port
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf(":%d", constants.EnvoyAdminPort))
+	if err != nil {
+		return err
+	}
+
+	return portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		defer pf.Stop()
+
+		ports, err := pf.GetForwardedPorts()
+		if err != nil {
+			return errors.Errorf("Error getting forwarded ports: %s", err)
+		}
+		if len(ports) == 0 {
+			return errors.New("No port forwarded to the Envoy admin port")
+		}
+
+		url := fmt.Sprintf("http://localhost:%d/%s", ports[0].Local, envoyQuitPath)
+		// #nosec G107: Potential HTTP request made with variable url
+		resp, err := http.Post(url, "", nil)
+		if err != nil {
+			return errors.Errorf("Error requesting url %s: %s", url, err)
+		}
+		defer resp.Body.Close() //nolint: errcheck,gosec
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("Unexpected status code %d requesting url %s", resp.StatusCode, url)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,167 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func newJobPod(ownerKind string, restartPolicy corev1.RestartPolicy, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	isController := true
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pi-x7k2d",
+			Namespace: "default",
+			UID:       types.UID("pi-x7k2d-uid"),
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: ownerKind, Name: "pi", Controller: &isController},
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: restartPolicy,
+		},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: statuses,
+		},
+	}
+}
+
+func running(name string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  name,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+}
+
+func terminated(name string, exitCode int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  name,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+	}
+}
+
+func TestShouldQuitProxy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected bool
+	}{
+		{
+			name:     "Job container succeeded",
+			pod:      newJobPod("Job", corev1.RestartPolicyOnFailure, corev1.PodRunning, terminated("pi", 0), running(constants.EnvoyContainerName)),
+			expected: true,
+		},
+		{
+			name:     "Job container failed and is not restarted",
+			pod:      newJobPod("Job", corev1.RestartPolicyNever, corev1.PodRunning, terminated("pi", 1), running(constants.EnvoyContainerName)),
+			expected: true,
+		},
+		{
+			name:     "Job container failed and is restarted",
+			pod:      newJobPod("Job", corev1.RestartPolicyOnFailure, corev1.PodRunning, terminated("pi", 1), running(constants.EnvoyContainerName)),
+			expected: false,
+		},
+		{
+			name:     "Job container is running",
+			pod:      newJobPod("Job", corev1.RestartPolicyNever, corev1.PodRunning, running("pi"), running(constants.EnvoyContainerName)),
+			expected: false,
+		},
+		{
+			name:     "Envoy sidecar already exited",
+			pod:      newJobPod("Job", corev1.RestartPolicyNever, corev1.PodRunning, terminated("pi", 0), terminated(constants.EnvoyContainerName, 0)),
+			expected: false,
+		},
+		{
+			name:     "pod without Envoy sidecar",
+			pod:      newJobPod("Job", corev1.RestartPolicyNever, corev1.PodRunning, terminated("pi", 0)),
+			expected: false,
+		},
+		{
+			name:     "pod not controlled by a Job",
+			pod:      newJobPod("ReplicaSet", corev1.RestartPolicyAlways, corev1.PodRunning, terminated("pi", 0), running(constants.EnvoyContainerName)),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, shouldQuitProxy(tc.pod))
+		})
+	}
+}
+
+type fakeLeader bool
+
+func (f fakeLeader) IsLeader() bool {
+	return bool(f)
+}
+
+func TestHandlePodUpdate(t *testing.T) {
+	assert := tassert.New(t)
+
+	quitRequests := make(chan *corev1.Pod, 10)
+	quitErr := errors.New("port forwarding failed")
+	terminator := &ProxyTerminator{
+		leader: fakeLeader(true),
+		quitProxy: func(pod *corev1.Pod) error {
+			quitRequests <- pod
+			return quitErr
+		},
+	}
+
+	pod := newJobPod("Job", corev1.RestartPolicyNever, corev1.PodRunning, terminated("pi", 0), running(constants.EnvoyContainerName))
+
+	// A failed request is retried on the next update of the pod
+	terminator.handlePodUpdate(pod)
+	assert.Equal(pod, <-quitRequests)
+	assert.Eventually(func() bool {
+		_, ok := terminator.quitProxies.Load(pod.UID)
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	quitErr = nil
+	terminator.handlePodUpdate(pod)
+	assert.Equal(pod, <-quitRequests)
+
+	// A successful request is not repeated
+	terminator.handlePodUpdate(pod)
+	select {
+	case <-quitRequests:
+		assert.Fail("Envoy sidecar was requested to exit more than once")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHandlePodUpdateNotLeader(t *testing.T) {
+	assert := tassert.New(t)
+
+	quitRequests := make(chan *corev1.Pod, 10)
+	terminator := &ProxyTerminator{
+		leader: fakeLeader(false),
+		quitProxy: func(pod *corev1.Pod) error {
+			quitRequests <- pod
+			return nil
+		},
+	}
+
+	pod := newJobPod("Job", corev1.RestartPolicyNever, corev1.PodRunning, terminated("pi", 0), running(constants.EnvoyContainerName))
+
+	// Only the leader requests the Envoy sidecar to exit
+	terminator.handlePodUpdate(pod)
+	select {
+	case <-quitRequests:
+		assert.Fail("Envoy sidecar was requested to exit by a replica that is not the leader")
+	case <-time.After(100 * time.Millisecond):
+	}
+	_, ok := terminator.quitProxies.Load(pod.UID)
+	assert.False(ok)
+}
@@ -0,0 +1,33 @@
+// Package job implements the termination of the Envoy sidecars of Job pods. A Job completes once all the containers
+// of its pod exit, which never happens while the Envoy sidecar keeps running after the containers of the Job exit.
+// On Kubernetes versions without sidecar containers, the controller requests the Envoy sidecar to exit instead.
+package job
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/leader"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("job")
+)
+
+// ProxyTerminator requests the Envoy sidecars of Job pods to exit once the other containers of the pod exit.
+// Only the leader among the replicas of osm-controller requests the Envoy sidecars to exit.
+type ProxyTerminator struct {
+	kubeClient kubernetes.Interface
+	kubeConfig *rest.Config
+	leader     leader.Checker
+
+	// quitProxy requests the Envoy sidecar of the given pod to exit
+	quitProxy func(pod *corev1.Pod) error
+
+	// quitProxies is the set of UIDs of the pods whose Envoy sidecar was requested to exit
+	quitProxies sync.Map
+}
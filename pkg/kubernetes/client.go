@@ -1,21 +1,27 @@
 package kubernetes
 
 import (
+	"fmt"
 	"reflect"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	discoveryinformers "k8s.io/client-go/informers/discovery/v1beta1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -23,29 +29,37 @@ import (
 func NewKubernetesController(kubeClient kubernetes.Interface, meshName string, stop chan struct{}, selectInformers ...InformerKey) (Controller, error) {
 	// Initialize client object
 	client := Client{
-		kubeClient:  kubeClient,
-		meshName:    meshName,
-		informers:   informerCollection{},
-		cacheSynced: make(chan interface{}),
+		kubeClient:     kubeClient,
+		meshName:       meshName,
+		informers:      informerCollection{},
+		cacheSynced:    make(chan interface{}),
+		namespaceQueue: workqueue.NewNamed("namespaces"),
 	}
 
-	// Initialize informers
-	informerInitHandlerMap := map[InformerKey]func(){
-		Namespaces:      client.initNamespaceMonitor,
-		Services:        client.initServicesMonitor,
-		ServiceAccounts: client.initServiceAccountsMonitor,
-		Pods:            client.initPodMonitor,
-		Endpoints:       client.initEndpointMonitor,
+	// Informers of namespaced resources only watch the resources of monitored namespaces
+	namespacedInformerInitFuncs := map[InformerKey]namespacedInformerInitFunc{
+		Services:        client.newServiceInformer,
+		ServiceAccounts: client.newServiceAccountInformer,
+		Pods:            client.newPodInformer,
+		EndpointSlices:  client.newEndpointSliceInformer,
+		Secrets:         client.newSecretInformer,
 	}
 
 	// If specific informers are not selected to be initialized, initialize all informers
 	if len(selectInformers) == 0 {
-		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, Endpoints}
+		selectInformers = []InformerKey{Namespaces, Services, ServiceAccounts, Pods, EndpointSlices, Secrets}
 	}
 
+	selectedInitFuncs := make(map[InformerKey]namespacedInformerInitFunc)
 	for _, informer := range selectInformers {
-		informerInitHandlerMap[informer]()
+		if initFunc, ok := namespacedInformerInitFuncs[informer]; ok {
+			selectedInitFuncs[informer] = initFunc
+		}
 	}
+	client.namespacedInformers = newNamespacedInformers(selectedInitFuncs, stop)
+
+	// The Namespaces informer is always initialized, as it determines the namespaces watched by the other informers
+	client.initNamespaceMonitor()
 
 	if err := client.run(stop); err != nil {
 		log.Error().Err(err).Msg("Could not start Kubernetes Namespaces client")
@@ -75,7 +89,78 @@ func (c *Client) initNamespaceMonitor() {
 		Update: announcements.NamespaceUpdated,
 		Delete: announcements.NamespaceDeleted,
 	}
-	c.informers[Namespaces].AddEventHandler(GetKubernetesEventHandlers((string)(Namespaces), providerName, nil, nsEventTypes))
+	c.namespaceEventHandlers = GetKubernetesEventHandlers((string)(Namespaces), providerName, nil, nsEventTypes)
+
+	// Start and stop the informers of namespaced resources as namespaces are added to and removed from the mesh.
+	// Namespaces are queued and processed by workers, so that syncing the caches of a namespace does not block the
+	// delivery of namespace events. An added namespace is announced by the workers once the caches of its informers
+	// have synced, so that its resources are cached by the time subscribers process the announcement.
+	c.informers[Namespaces].AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if ns, ok := obj.(*corev1.Namespace); ok {
+				c.namespaceQueue.Add(ns.Name)
+			}
+		},
+		UpdateFunc: c.namespaceEventHandlers.OnUpdate,
+		DeleteFunc: func(obj interface{}) {
+			nsObj := obj
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				nsObj = tombstone.Obj
+			}
+			if ns, ok := nsObj.(*corev1.Namespace); ok {
+				c.namespaceQueue.Add(ns.Name)
+			}
+			c.namespaceEventHandlers.OnDelete(obj)
+		},
+	})
+}
+
+// runNamespaceWorkers processes the queued namespaces until the given stop channel is closed
+func (c *Client) runNamespaceWorkers(stop <-chan struct{}) {
+	for i := 0; i < namespaceWorkers; i++ {
+		go func() {
+			for c.processNextNamespace() {
+			}
+		}()
+	}
+
+	go func() {
+		<-stop
+		c.namespaceQueue.ShutDown()
+	}()
+}
+
+// processNextNamespace starts or stops the informers of the next queued namespace, depending on whether the namespace
+// is still monitored. It returns false once the queue is shut down.
+func (c *Client) processNextNamespace() bool {
+	key, shutdown := c.namespaceQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.namespaceQueue.Done(key)
+
+	namespace := key.(string)
+	nsObj, exists, err := c.informers[Namespaces].GetStore().GetByKey(namespace)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting namespace %s from the cache", namespace)
+		return true
+	}
+
+	if !exists {
+		if c.namespacedInformers.stopNamespace(namespace) {
+			// The resources of the namespace are dropped from the caches without Delete events being emitted,
+			// so request all proxies to be updated without them
+			events.GetPubSubInstance().Publish(events.PubSubMessage{
+				AnnouncementType: announcements.ScheduleProxyBroadcast,
+			})
+		}
+		return true
+	}
+
+	if c.namespacedInformers.startAndWaitForCacheSync(namespace) {
+		c.namespaceEventHandlers.OnAdd(nsObj)
+	}
+	return true
 }
 
 // Function to filter K8s meta Objects by OSM's isMonitoredNamespace
@@ -84,54 +169,105 @@ func (c *Client) shouldObserve(obj interface{}) bool {
 	return c.IsMonitoredNamespace(ns)
 }
 
-// Initializes Service monitoring
-func (c *Client) initServicesMonitor() {
-	informerFactory := informers.NewSharedInformerFactory(c.kubeClient, DefaultKubeEventResyncInterval)
-	c.informers[Services] = informerFactory.Core().V1().Services().Informer()
+// newServiceInformer creates the informer of the Services in the given namespace
+func (c *Client) newServiceInformer(namespace string) cache.SharedIndexInformer {
+	informer := coreinformers.NewServiceInformer(c.kubeClient, namespace, DefaultKubeEventResyncInterval, cache.Indexers{})
 
 	svcEventTypes := EventTypes{
 		Add:    announcements.ServiceAdded,
 		Update: announcements.ServiceUpdated,
 		Delete: announcements.ServiceDeleted,
 	}
-	c.informers[Services].AddEventHandler(GetKubernetesEventHandlers((string)(Services), providerName, c.shouldObserve, svcEventTypes))
+	informer.AddEventHandler(GetKubernetesEventHandlers((string)(Services), providerName, c.shouldObserve, svcEventTypes))
+	return informer
 }
 
-// Initializes Service Account monitoring
-func (c *Client) initServiceAccountsMonitor() {
-	informerFactory := informers.NewSharedInformerFactory(c.kubeClient, DefaultKubeEventResyncInterval)
-	c.informers[ServiceAccounts] = informerFactory.Core().V1().ServiceAccounts().Informer()
+// newServiceAccountInformer creates the informer of the ServiceAccounts in the given namespace
+func (c *Client) newServiceAccountInformer(namespace string) cache.SharedIndexInformer {
+	informer := coreinformers.NewServiceAccountInformer(c.kubeClient, namespace, DefaultKubeEventResyncInterval, cache.Indexers{})
 
 	svcEventTypes := EventTypes{
 		Add:    announcements.ServiceAccountAdded,
 		Update: announcements.ServiceAccountUpdated,
 		Delete: announcements.ServiceAccountDeleted,
 	}
-	c.informers[ServiceAccounts].AddEventHandler(GetKubernetesEventHandlers((string)(ServiceAccounts), providerName, c.shouldObserve, svcEventTypes))
+	informer.AddEventHandler(GetKubernetesEventHandlers((string)(ServiceAccounts), providerName, c.shouldObserve, svcEventTypes))
+	return informer
 }
 
-func (c *Client) initPodMonitor() {
-	informerFactory := informers.NewSharedInformerFactory(c.kubeClient, DefaultKubeEventResyncInterval)
-	c.informers[Pods] = informerFactory.Core().V1().Pods().Informer()
+// newPodInformer creates the informer of the Pods in the given namespace. Only pods with a sidecar injected,
+// which are labeled with the proxy's unique ID, are watched. Pods are indexed by their service account, so that
+// the pods of a service identity are retrieved without iterating over all the pods.
+func (c *Client) newPodInformer(namespace string) cache.SharedIndexInformer {
+	tweakListOptions := func(opt *metav1.ListOptions) {
+		opt.LabelSelector = constants.EnvoyUniqueIDLabelName
+	}
+	informer := coreinformers.NewFilteredPodInformer(c.kubeClient, namespace, DefaultKubeEventResyncInterval,
+		cache.Indexers{podServiceAccountIndex: podServiceAccountIndexFunc}, tweakListOptions)
 
 	podEventTypes := EventTypes{
 		Add:    announcements.PodAdded,
 		Update: announcements.PodUpdated,
 		Delete: announcements.PodDeleted,
 	}
-	c.informers[Pods].AddEventHandler(GetKubernetesEventHandlers((string)(Pods), providerName, c.shouldObserve, podEventTypes))
+	informer.AddEventHandler(GetKubernetesEventHandlers((string)(Pods), providerName, c.shouldObserve, podEventTypes))
+	return informer
 }
 
-func (c *Client) initEndpointMonitor() {
-	informerFactory := informers.NewSharedInformerFactory(c.kubeClient, DefaultKubeEventResyncInterval)
-	c.informers[Endpoints] = informerFactory.Core().V1().Endpoints().Informer()
+// newEndpointSliceInformer creates the informer of the EndpointSlices in the given namespace. EndpointSlices are indexed
+// by the name of the service they belong to, so that the endpoints of a service are retrieved without iterating over
+// the EndpointSlices of all services.
+func (c *Client) newEndpointSliceInformer(namespace string) cache.SharedIndexInformer {
+	informer := discoveryinformers.NewEndpointSliceInformer(c.kubeClient, namespace, DefaultKubeEventResyncInterval,
+		cache.Indexers{endpointSliceServiceIndex: endpointSliceServiceIndexFunc})
 
 	eptEventTypes := EventTypes{
 		Add:    announcements.EndpointAdded,
 		Update: announcements.EndpointUpdated,
 		Delete: announcements.EndpointDeleted,
 	}
-	c.informers[Endpoints].AddEventHandler(GetKubernetesEventHandlers((string)(Endpoints), providerName, c.shouldObserve, eptEventTypes))
+	informer.AddEventHandler(GetKubernetesEventHandlers((string)(EndpointSlices), providerName, c.shouldObserve, eptEventTypes))
+	return informer
+}
+
+// newSecretInformer creates the informer of the Secrets in the given namespace. Only TLS Secrets, which can be
+// served by sidecars to HTTPS ingress clients, are watched.
+func (c *Client) newSecretInformer(namespace string) cache.SharedIndexInformer {
+	tweakListOptions := func(opt *metav1.ListOptions) {
+		opt.FieldSelector = fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String()
+	}
+	informer := coreinformers.NewFilteredSecretInformer(c.kubeClient, namespace, DefaultKubeEventResyncInterval, cache.Indexers{}, tweakListOptions)
+
+	secretEventTypes := EventTypes{
+		Add:    announcements.SecretAdded,
+		Update: announcements.SecretUpdated,
+		Delete: announcements.SecretDeleted,
+	}
+	informer.AddEventHandler(GetKubernetesEventHandlers((string)(Secrets), providerName, c.shouldObserve, secretEventTypes))
+	return informer
+}
+
+// endpointSliceServiceIndexFunc indexes an EndpointSlice by the <namespace>/<name> key of the service it belongs to
+func endpointSliceServiceIndexFunc(obj interface{}) ([]string, error) {
+	endpointSlice, ok := obj.(*discoveryv1beta1.EndpointSlice)
+	if !ok {
+		return nil, errors.Errorf("Expected an EndpointSlice, got %T", obj)
+	}
+	svcName, ok := endpointSlice.Labels[discoveryv1beta1.LabelServiceName]
+	if !ok || svcName == "" {
+		// EndpointSlices not managed on behalf of a service are not indexed
+		return nil, nil
+	}
+	return []string{service.MeshService{Namespace: endpointSlice.Namespace, Name: svcName}.String()}, nil
+}
+
+// podServiceAccountIndexFunc indexes a Pod by the <namespace>/<name> key of its service account
+func podServiceAccountIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, errors.Errorf("Expected a Pod, got %T", obj)
+	}
+	return []string{identity.K8sServiceAccount{Namespace: pod.Namespace, Name: pod.Spec.ServiceAccountName}.String()}, nil
 }
 
 func (c *Client) run(stop <-chan struct{}) error {
@@ -143,6 +279,8 @@ func (c *Client) run(stop <-chan struct{}) error {
 		return errInitInformers
 	}
 
+	c.runNamespaceWorkers(stop)
+
 	for name, informer := range c.informers {
 		if informer == nil {
 			continue
@@ -158,6 +296,15 @@ func (c *Client) run(stop <-chan struct{}) error {
 		return errSyncingCaches
 	}
 
+	// Wait for the informers of the namespaces monitored at startup to sync, which may not have been started yet
+	// by the namespace event handlers
+	for _, ns := range c.informers[Namespaces].GetStore().List() {
+		namespace := ns.(*corev1.Namespace)
+		if !c.namespacedInformers.startAndWaitForCacheSync(namespace.Name) {
+			return errSyncingCaches
+		}
+	}
+
 	// Closing the cacheSynced channel signals to the rest of the system that caches have synced.
 	close(c.cacheSynced)
 	log.Info().Msgf("Caches for %+s synced successfully", names)
@@ -189,7 +336,7 @@ func (c Client) ListMonitoredNamespaces() ([]string, error) {
 // GetService retrieves the Kubernetes Services resource for the given MeshService
 func (c Client) GetService(svc service.MeshService) *corev1.Service {
 	// client-go cache uses <namespace>/<name> as key
-	svcIf, exists, err := c.informers[Services].GetStore().GetByKey(svc.String())
+	svcIf, exists, err := c.namespacedInformers.getByKey(Services, svc.Namespace, svc.String())
 	if exists && err == nil {
 		svc := svcIf.(*corev1.Service)
 		return svc
@@ -197,11 +344,25 @@ func (c Client) GetService(svc service.MeshService) *corev1.Service {
 	return nil
 }
 
+// GetSecret retrieves the TLS Secret with the given name in the given namespace
+func (c Client) GetSecret(namespace string, name string) *corev1.Secret {
+	// client-go cache uses <namespace>/<name> as key
+	secretIf, exists, err := c.namespacedInformers.getByKey(Secrets, namespace, fmt.Sprintf("%s/%s", namespace, name))
+	if !exists || err != nil {
+		return nil
+	}
+	secret := secretIf.(*corev1.Secret)
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil
+	}
+	return secret
+}
+
 // ListServices returns a list of services that are part of monitored namespaces
 func (c Client) ListServices() []*corev1.Service {
 	var services []*corev1.Service
 
-	for _, serviceInterface := range c.informers[Services].GetStore().List() {
+	for _, serviceInterface := range c.namespacedInformers.list(Services) {
 		svc := serviceInterface.(*corev1.Service)
 
 		if !c.IsMonitoredNamespace(svc.Namespace) {
@@ -216,7 +377,7 @@ func (c Client) ListServices() []*corev1.Service {
 func (c Client) ListServiceAccounts() []*corev1.ServiceAccount {
 	var serviceAccounts []*corev1.ServiceAccount
 
-	for _, serviceInterface := range c.informers[ServiceAccounts].GetStore().List() {
+	for _, serviceInterface := range c.namespacedInformers.list(ServiceAccounts) {
 		sa := serviceInterface.(*corev1.ServiceAccount)
 
 		if !c.IsMonitoredNamespace(sa.Namespace) {
@@ -243,7 +404,7 @@ func (c Client) GetNamespace(ns string) *corev1.Namespace {
 func (c Client) ListPods() []*corev1.Pod {
 	var pods []*corev1.Pod
 
-	for _, podInterface := range c.informers[Pods].GetStore().List() {
+	for _, podInterface := range c.namespacedInformers.list(Pods) {
 		pod := podInterface.(*corev1.Pod)
 		if !c.IsMonitoredNamespace(pod.Namespace) {
 			continue
@@ -253,30 +414,64 @@ func (c Client) ListPods() []*corev1.Pod {
 	return pods
 }
 
-// GetEndpoints returns the endpoint for a given service, otherwise returns nil if not found
-// or error if the API errored out.
-func (c Client) GetEndpoints(svc service.MeshService) (*corev1.Endpoints, error) {
-	ep, exists, err := c.informers[Endpoints].GetStore().GetByKey(svc.String())
+// ListPodsForServiceAccount returns the pods part of the mesh running as the given service account
+func (c Client) ListPodsForServiceAccount(sa identity.K8sServiceAccount) []*corev1.Pod {
+	if !c.IsMonitoredNamespace(sa.Namespace) {
+		return nil
+	}
+	indexer := c.namespacedInformers.getIndexer(Pods, sa.Namespace)
+	if indexer == nil {
+		return nil
+	}
+
+	objs, err := indexer.ByIndex(podServiceAccountIndex, sa.String())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error listing the pods of service account %s", sa)
+		return nil
+	}
+
+	pods := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		pods = append(pods, obj.(*corev1.Pod))
+	}
+	return pods
+}
+
+// ListEndpointSlicesForService returns the EndpointSlices of the given service, which is an empty list if the service
+// has no endpoints, or an error if the cache errored out.
+func (c Client) ListEndpointSlicesForService(svc service.MeshService) ([]*discoveryv1beta1.EndpointSlice, error) {
+	indexer := c.namespacedInformers.getIndexer(EndpointSlices, svc.Namespace)
+	if indexer == nil {
+		return nil, nil
+	}
+
+	objs, err := indexer.ByIndex(endpointSliceServiceIndex, svc.String())
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return ep.(*corev1.Endpoints), nil
+
+	endpointSlices := make([]*discoveryv1beta1.EndpointSlice, 0, len(objs))
+	for _, obj := range objs {
+		endpointSlices = append(endpointSlices, obj.(*discoveryv1beta1.EndpointSlice))
 	}
-	return nil, nil
+	return endpointSlices, nil
 }
 
 // ListServiceIdentitiesForService lists ServiceAccounts associated with the given service
 func (c Client) ListServiceIdentitiesForService(svc service.MeshService) ([]identity.K8sServiceAccount, error) {
-	var svcAccounts []identity.K8sServiceAccount
-
 	k8sSvc := c.GetService(svc)
 	if k8sSvc == nil {
 		return nil, errors.Errorf("Error fetching service %q: %s", svc, errServiceNotFound)
 	}
 
+	return getServiceAccountsForService(k8sSvc, c.ListPods()), nil
+}
+
+// getServiceAccountsForService returns the service accounts of the given pods selected by the given service
+func getServiceAccountsForService(k8sSvc *corev1.Service, pods []*corev1.Pod) []identity.K8sServiceAccount {
+	var svcAccounts []identity.K8sServiceAccount
+
 	svcAccountsSet := mapset.NewSet()
-	pods := c.ListPods()
 	for _, pod := range pods {
 		svcRawSelector := k8sSvc.Spec.Selector
 		selector := labels.Set(svcRawSelector).AsSelector()
@@ -296,5 +491,5 @@ func (c Client) ListServiceIdentitiesForService(svc service.MeshService) ([]iden
 	for svcAcc := range svcAccountsSet.Iter() {
 		svcAccounts = append(svcAccounts, svcAcc.(identity.K8sServiceAccount))
 	}
-	return svcAccounts, nil
+	return svcAccounts
 }
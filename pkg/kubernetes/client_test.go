@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	mapset "github.com/deckarep/golang-set"
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -345,6 +347,200 @@ var _ = Describe("Test Namespace KubeController Methods", func() {
 		})
 	})
 
+	Context("endpoint slice controller", func() {
+		var kubeClient *testclient.Clientset
+		var kubeController Controller
+		var err error
+
+		BeforeEach(func() {
+			kubeClient = testclient.NewSimpleClientset()
+			kubeController, err = NewKubernetesController(kubeClient, testMeshName, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeController).ToNot(BeNil())
+		})
+
+		It("should return an empty list when the service has no EndpointSlices", func() {
+			endpointSlices, err := kubeController.ListEndpointSlicesForService(tests.BookbuyerService)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(endpointSlices).To(BeEmpty())
+		})
+
+		It("should return the EndpointSlices of the given service", func() {
+			meshSvc := tests.BookbuyerService
+			newEndpointSlice := func(name string, svcName string) *discoveryv1beta1.EndpointSlice {
+				return &discoveryv1beta1.EndpointSlice{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: meshSvc.Namespace,
+						Labels:    map[string]string{discoveryv1beta1.LabelServiceName: svcName},
+					},
+					AddressType: discoveryv1beta1.AddressTypeIPv4,
+				}
+			}
+
+			// EndpointSlices are only cached for monitored namespaces
+			_, err := kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   meshSvc.Namespace,
+					Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: testMeshName},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			endpointSlices := []*discoveryv1beta1.EndpointSlice{
+				newEndpointSlice(meshSvc.Name+"-1", meshSvc.Name),
+				newEndpointSlice(meshSvc.Name+"-2", meshSvc.Name),
+				newEndpointSlice("other-1", "other"),
+			}
+			for _, endpointSlice := range endpointSlices {
+				_, err := kubeClient.DiscoveryV1beta1().EndpointSlices(meshSvc.Namespace).Create(context.TODO(), endpointSlice, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Eventually(func() ([]string, error) {
+				slices, err := kubeController.ListEndpointSlicesForService(meshSvc)
+				var names []string
+				for _, slice := range slices {
+					names = append(names, slice.Name)
+				}
+				sort.Strings(names)
+				return names, err
+			}, nsInformerSyncTimeout).Should(Equal([]string{meshSvc.Name + "-1", meshSvc.Name + "-2"}))
+		})
+	})
+
+	Context("secret controller", func() {
+		var kubeClient *testclient.Clientset
+		var kubeController Controller
+		var err error
+
+		BeforeEach(func() {
+			kubeClient = testclient.NewSimpleClientset()
+			kubeController, err = NewKubernetesController(kubeClient, testMeshName, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kubeController).ToNot(BeNil())
+		})
+
+		It("should return nil when the given Secret is not found", func() {
+			Expect(kubeController.GetSecret(tests.Namespace, "tls-secret")).To(BeNil())
+		})
+
+		It("should return the TLS Secrets of monitored namespaces", func() {
+			_, err := kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   tests.Namespace,
+					Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: testMeshName},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets := []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls-secret", Namespace: tests.Namespace},
+					Type:       corev1.SecretTypeTLS,
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "opaque-secret", Namespace: tests.Namespace},
+					Type:       corev1.SecretTypeOpaque,
+				},
+			}
+			for _, secret := range secrets {
+				_, err := kubeClient.CoreV1().Secrets(secret.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Eventually(func() *corev1.Secret {
+				return kubeController.GetSecret(tests.Namespace, "tls-secret")
+			}, nsInformerSyncTimeout).ShouldNot(BeNil())
+			Expect(kubeController.GetSecret(tests.Namespace, "opaque-secret")).To(BeNil())
+		})
+	})
+
+	Context("namespace-scoped informers", func() {
+		It("should only cache the resources of monitored namespaces and the pods with a sidecar", func() {
+			kubeClient := testclient.NewSimpleClientset()
+
+			monitoredNamespace := uuid.New().String()
+			unmonitoredNamespace := uuid.New().String()
+
+			// Create resources before the namespaces exist, so that they are listed by the informers once started
+			var err error
+			for _, ns := range []string{monitoredNamespace, unmonitoredNamespace} {
+				_, err = kubeClient.CoreV1().Services(ns).Create(context.TODO(), tests.NewServiceFixture("svc", ns, nil), metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				meshedPod := tests.NewPodFixture(ns, "meshed", "sa", map[string]string{constants.EnvoyUniqueIDLabelName: uuid.New().String()})
+				_, err = kubeClient.CoreV1().Pods(ns).Create(context.TODO(), &meshedPod, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				unmeshedPod := tests.NewPodFixture(ns, "unmeshed", "sa", nil)
+				_, err = kubeClient.CoreV1().Pods(ns).Create(context.TODO(), &unmeshedPod, metav1.CreateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			// The fake clientset does not filter watch events by label, so the unmonitored namespace is created
+			// before the controller lists the monitored namespaces
+			_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: unmonitoredNamespace,
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			kubeController, err := NewKubernetesController(kubeClient, testMeshName, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   monitoredNamespace,
+					Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: testMeshName},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() int {
+				return len(kubeController.ListServices())
+			}, nsInformerSyncTimeout).Should(Equal(1))
+			Expect(kubeController.GetService(service.MeshService{Name: "svc", Namespace: monitoredNamespace})).ToNot(BeNil())
+			Expect(kubeController.GetService(service.MeshService{Name: "svc", Namespace: unmonitoredNamespace})).To(BeNil())
+
+			pods := kubeController.ListPods()
+			Expect(pods).To(HaveLen(1))
+			Expect(pods[0].Name).To(Equal("meshed"))
+			Expect(pods[0].Namespace).To(Equal(monitoredNamespace))
+		})
+
+		It("should drop the resources of a namespace no longer monitored and request a proxy broadcast", func() {
+			kubeClient := testclient.NewSimpleClientset()
+			kubeController, err := NewKubernetesController(kubeClient, testMeshName, make(chan struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			ns := uuid.New().String()
+			_, err = kubeClient.CoreV1().Services(ns).Create(context.TODO(), tests.NewServiceFixture("svc", ns, nil), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = kubeClient.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   ns,
+					Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: testMeshName},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(func() *corev1.Service {
+				return kubeController.GetService(service.MeshService{Name: "svc", Namespace: ns})
+			}, nsInformerSyncTimeout).ShouldNot(BeNil())
+
+			broadcastChannel := events.GetPubSubInstance().Subscribe(announcements.ScheduleProxyBroadcast)
+			defer events.GetPubSubInstance().Unsub(broadcastChannel)
+
+			err = kubeClient.CoreV1().Namespaces().Delete(context.TODO(), ns, metav1.DeleteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(broadcastChannel, nsInformerSyncTimeout).Should(Receive())
+			Expect(kubeController.GetService(service.MeshService{Name: "svc", Namespace: ns})).To(BeNil())
+		})
+	})
+
 	Context("Test ListServiceIdentitiesForService()", func() {
 		var kubeClient *testclient.Clientset
 		var kubeController Controller
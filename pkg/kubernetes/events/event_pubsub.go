@@ -1,16 +1,34 @@
 package events
 
 import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/cskr/pubsub"
 
 	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 const (
 	// Default number of events a subscriber channel will buffer
 	defaultAnnouncementChannelSize = 512
+
+	// queueLengthRecordInterval is the minimum interval between two recordings of the queue length metrics
+	queueLengthRecordInterval = 5 * time.Second
 )
 
+// relayedAnnouncements are the announcement types relayed to the other replicas of osm-controller when a Relay is set.
+// Only ScheduleProxyBroadcast is relayed: a Relay carries the type of an announcement but not its payload, so the
+// announcements bearing a payload, such as the Kubernetes events or the announcements targeting a single proxy, are
+// never relayed and only reach the subscribers of the replica publishing them. The announcements derived from
+// Kubernetes events do not need to be relayed as every replica observes them through its own informers.
+var relayedAnnouncements = map[announcements.AnnouncementType]bool{
+	announcements.ScheduleProxyBroadcast: true,
+}
+
 var (
 	// Globally accessible instance, through singleton pattern GetPubSubInstance
 	pubSubInstance *osmPubsub
@@ -19,6 +37,12 @@ var (
 // Object which implements the PubSub interface
 type osmPubsub struct {
 	pSub *pubsub.PubSub
+
+	mutex *sync.Mutex
+	// subscriptions maps the subscribed channels to the announcement types they are subscribed to
+	subscriptions      map[chan interface{}]string
+	queueLenRecordedAt time.Time
+	relay              Relay
 }
 
 // Subscribe is the Subscribe implementation for PubSub
@@ -28,12 +52,76 @@ func (c *osmPubsub) Subscribe(aTypes ...announcements.AnnouncementType) chan int
 		subTypes = append(subTypes, string(v))
 	}
 
-	return c.pSub.Sub(subTypes...)
+	sort.Strings(subTypes)
+
+	ch := c.pSub.Sub(subTypes...)
+
+	c.mutex.Lock()
+	c.subscriptions[ch] = strings.Join(subTypes, ",")
+	c.mutex.Unlock()
+
+	return ch
 }
 
 // Publish is the Publish implementation for PubSub
 func (c *osmPubsub) Publish(message PubSubMessage) {
+	c.recordQueueLengths()
 	c.pSub.Pub(message, message.AnnouncementType.String())
+
+	c.mutex.Lock()
+	relay := c.relay
+	c.mutex.Unlock()
+	if relay == nil || !relayedAnnouncements[message.AnnouncementType] {
+		return
+	}
+	if err := relay.Publish(message.AnnouncementType); err != nil {
+		log.Error().Err(err).Msgf("Error relaying announcement %s", message.AnnouncementType)
+		metricsstore.DefaultMetricsStore.BrokerDroppedCount.WithLabelValues("publish-error").Inc()
+		return
+	}
+	metricsstore.DefaultMetricsStore.BrokerRelayedCount.WithLabelValues("sent").Inc()
+}
+
+// SetRelay sets the relay of the announcements exchanged with the other replicas of osm-controller. The announcements
+// received through the relay are published to the local subscribers until the given stop channel is closed, without
+// payload. Only the announcement types in relayedAnnouncements are relayed.
+func (c *osmPubsub) SetRelay(relay Relay, stop <-chan struct{}) error {
+	err := relay.Receive(func(aType announcements.AnnouncementType) {
+		metricsstore.DefaultMetricsStore.BrokerRelayedCount.WithLabelValues("received").Inc()
+		// Received announcements are only published locally, to not relay them back
+		c.recordQueueLengths()
+		c.pSub.Pub(PubSubMessage{AnnouncementType: aType}, aType.String())
+	}, stop)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	c.relay = relay
+	c.mutex.Unlock()
+	return nil
+}
+
+// recordQueueLengths records the number of announcements queued for the subscribers, grouped by the announcement types
+// they are subscribed to. A subscription whose queue keeps growing does not consume its announcements fast enough.
+func (c *osmPubsub) recordQueueLengths() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.queueLenRecordedAt) < queueLengthRecordInterval {
+		return
+	}
+	c.queueLenRecordedAt = now
+
+	queueLengths := make(map[string]int)
+	for ch, subscription := range c.subscriptions {
+		queueLengths[subscription] += len(ch)
+	}
+	metricsstore.DefaultMetricsStore.BrokerQueueLength.Reset()
+	for subscription, queueLength := range queueLengths {
+		metricsstore.DefaultMetricsStore.BrokerQueueLength.WithLabelValues(subscription).Set(float64(queueLength))
+	}
 }
 
 // Unsub is the Unsub implementation for PubSub.
@@ -57,6 +145,10 @@ func (c *osmPubsub) Unsub(unsubChan chan interface{}) {
 	}()
 
 	<-syncCh
+
+	c.mutex.Lock()
+	delete(c.subscriptions, unsubChan)
+	c.mutex.Unlock()
 }
 
 // GetPubSubInstance returns a unique, global scope PubSub interface instance
@@ -65,8 +157,18 @@ func (c *osmPubsub) Unsub(unsubChan chan interface{}) {
 func GetPubSubInstance() PubSub {
 	if pubSubInstance == nil {
 		pubSubInstance = &osmPubsub{
-			pSub: pubsub.New(defaultAnnouncementChannelSize),
+			pSub:          pubsub.New(defaultAnnouncementChannelSize),
+			mutex:         &sync.Mutex{},
+			subscriptions: make(map[chan interface{}]string),
 		}
 	}
 	return pubSubInstance
 }
+
+// SetRelay sets the relay of the announcements exchanged with the other replicas of osm-controller on the global
+// PubSub instance
+func SetRelay(relay Relay, stop <-chan struct{}) error {
+	// Ensure the instance is created
+	GetPubSubInstance()
+	return pubSubInstance.SetRelay(relay, stop)
+}
@@ -2,9 +2,11 @@ package events
 
 import (
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/cskr/pubsub"
 	"github.com/golang/mock/gomock"
 	tassert "github.com/stretchr/testify/assert"
 
@@ -80,3 +82,59 @@ func TestPubSubClose(t *testing.T) {
 	_, ok := <-subChannel
 	assert.False(ok)
 }
+
+// fakeRelay is a Relay recording the announcements published, and exposing the handler of received announcements
+type fakeRelay struct {
+	published []announcements.AnnouncementType
+	handler   func(aType announcements.AnnouncementType)
+}
+
+func (r *fakeRelay) Publish(aType announcements.AnnouncementType) error {
+	r.published = append(r.published, aType)
+	return nil
+}
+
+func (r *fakeRelay) Receive(handler func(aType announcements.AnnouncementType), stop <-chan struct{}) error {
+	r.handler = handler
+	return nil
+}
+
+func TestPubSubRelay(t *testing.T) {
+	assert := tassert.New(t)
+
+	ps := &osmPubsub{
+		pSub:          pubsub.New(defaultAnnouncementChannelSize),
+		mutex:         &sync.Mutex{},
+		subscriptions: make(map[chan interface{}]string),
+	}
+	relay := &fakeRelay{}
+	assert.Nil(ps.SetRelay(relay, make(chan struct{})))
+
+	subChannel := ps.Subscribe(announcements.ScheduleProxyBroadcast, announcements.EndpointUpdated)
+	assert.Equal("endpoint-updated,schedule-proxy-broadcast", ps.subscriptions[subChannel])
+
+	// Only announcements without payload are relayed
+	ps.Publish(PubSubMessage{AnnouncementType: announcements.EndpointUpdated})
+	ps.Publish(PubSubMessage{AnnouncementType: announcements.ScheduleProxyBroadcast})
+	assert.Equal([]announcements.AnnouncementType{announcements.ScheduleProxyBroadcast}, relay.published)
+
+	// Received announcements are published locally, and not relayed back
+	relay.handler(announcements.ScheduleProxyBroadcast)
+	assert.Len(relay.published, 1)
+
+	for _, expected := range []announcements.AnnouncementType{
+		announcements.EndpointUpdated,
+		announcements.ScheduleProxyBroadcast,
+		announcements.ScheduleProxyBroadcast,
+	} {
+		select {
+		case msg := <-subChannel:
+			assert.Equal(expected, msg.(PubSubMessage).AnnouncementType)
+		case <-time.After(1 * time.Second):
+			assert.Fail("announcement not received", expected)
+		}
+	}
+
+	ps.Unsub(subChannel)
+	assert.Empty(ps.subscriptions)
+}
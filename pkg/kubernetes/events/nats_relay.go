@@ -0,0 +1,113 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+const (
+	// NATSRelayKind is the kind of the relay exchanging announcements through a NATS server
+	NATSRelayKind = "nats"
+
+	// natsSubjectFmt is the format of the NATS subject announcements are exchanged on, per mesh
+	natsSubjectFmt = "osm.%s.announcements"
+)
+
+// RelayKinds are the kinds of relay osm-controller can exchange announcements through
+var RelayKinds = []string{NATSRelayKind}
+
+// relayMessage is the message exchanged through the message broker for an announcement
+type relayMessage struct {
+	// Origin is the replica of osm-controller the announcement originates from
+	Origin string `json:"origin"`
+
+	// AnnouncementType is the type of the announcement
+	AnnouncementType announcements.AnnouncementType `json:"announcementType"`
+}
+
+// natsRelay is the Relay exchanging announcements through a NATS server
+type natsRelay struct {
+	conn    *nats.Conn
+	subject string
+	origin  string
+}
+
+// NewNATSRelay returns a Relay exchanging the announcements of the given mesh through the NATS server at the given
+// URL. The origin identifies the replica of osm-controller, the announcements it sends are not received back.
+func NewNATSRelay(url, meshName, origin string) (Relay, error) {
+	conn, err := nats.Connect(url,
+		nats.Name(origin),
+		// Reconnect indefinitely, announcements are dropped while disconnected
+		nats.MaxReconnects(-1),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			if err == nats.ErrSlowConsumer {
+				metricsstore.DefaultMetricsStore.BrokerDroppedCount.WithLabelValues("slow-consumer").Inc()
+			}
+			log.Error().Err(err).Msgf("Error exchanging announcements through NATS server %s", url)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error connecting to NATS server %s", url)
+	}
+
+	return &natsRelay{
+		conn:    conn,
+		subject: fmt.Sprintf(natsSubjectFmt, meshName),
+		origin:  origin,
+	}, nil
+}
+
+// Publish is the Publish implementation for Relay
+func (r *natsRelay) Publish(aType announcements.AnnouncementType) error {
+	data, err := json.Marshal(relayMessage{Origin: r.origin, AnnouncementType: aType})
+	if err != nil {
+		return errors.Wrapf(err, "error encoding announcement %s", aType)
+	}
+	return r.conn.Publish(r.subject, data)
+}
+
+// Receive is the Receive implementation for Relay. The connection to the NATS server is closed when the stop channel
+// is closed.
+func (r *natsRelay) Receive(handler func(aType announcements.AnnouncementType), stop <-chan struct{}) error {
+	sub, err := r.conn.Subscribe(r.subject, func(msg *nats.Msg) {
+		handleRelayMessage(msg.Data, r.origin, handler)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error subscribing to NATS subject %s", r.subject)
+	}
+
+	go func() {
+		<-stop
+		if err := sub.Unsubscribe(); err != nil {
+			log.Error().Err(err).Msgf("Error unsubscribing from NATS subject %s", r.subject)
+		}
+		r.conn.Close()
+	}()
+	return nil
+}
+
+// handleRelayMessage calls the handler with the announcement of the given message, unless the announcement originates
+// from the given origin
+func handleRelayMessage(data []byte, origin string, handler func(aType announcements.AnnouncementType)) {
+	var msg relayMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		log.Error().Err(err).Msg("Error decoding relayed announcement")
+		metricsstore.DefaultMetricsStore.BrokerDroppedCount.WithLabelValues("decode-error").Inc()
+		return
+	}
+	if msg.Origin == origin {
+		return
+	}
+	if !relayedAnnouncements[msg.AnnouncementType] {
+		log.Error().Msgf("Ignoring relayed announcement %s which is not relayable", msg.AnnouncementType)
+		metricsstore.DefaultMetricsStore.BrokerDroppedCount.WithLabelValues("unsupported-announcement").Inc()
+		return
+	}
+	handler(msg.AnnouncementType)
+}
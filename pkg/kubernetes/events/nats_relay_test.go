@@ -0,0 +1,54 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+)
+
+func TestHandleRelayMessage(t *testing.T) {
+	encode := func(msg relayMessage) []byte {
+		data, err := json.Marshal(msg)
+		tassert.Nil(t, err)
+		return data
+	}
+
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected []announcements.AnnouncementType
+	}{
+		{
+			name:     "announcement from another replica",
+			data:     encode(relayMessage{Origin: "osm-controller-2", AnnouncementType: announcements.ScheduleProxyBroadcast}),
+			expected: []announcements.AnnouncementType{announcements.ScheduleProxyBroadcast},
+		},
+		{
+			name: "announcement from the same replica",
+			data: encode(relayMessage{Origin: "osm-controller-1", AnnouncementType: announcements.ScheduleProxyBroadcast}),
+		},
+		{
+			name: "announcement which is not relayable",
+			data: encode(relayMessage{Origin: "osm-controller-2", AnnouncementType: announcements.PodAdded}),
+		},
+		{
+			name: "invalid message",
+			data: []byte("invalid"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var received []announcements.AnnouncementType
+			handleRelayMessage(tc.data, "osm-controller-1", func(aType announcements.AnnouncementType) {
+				received = append(received, aType)
+			})
+			assert.Equal(tc.expected, received)
+		})
+	}
+}
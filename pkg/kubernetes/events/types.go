@@ -28,6 +28,12 @@ const (
 	CertificateIssuanceFailure = "FatalCertificateIssuanceFailure"
 )
 
+// Kubernetes Event reasons
+const (
+	// ProxyConfigNACKed signifies that a proxy rejected the configuration sent to it
+	ProxyConfigNACKed = "ProxyConfigNACKed"
+)
+
 // PubSubMessage represents a common messages abstraction to pass through the PubSub interface
 type PubSubMessage struct {
 	AnnouncementType announcements.AnnouncementType
@@ -48,3 +54,13 @@ type PubSub interface {
 	// garbage collected when it is freed.
 	Unsub(unsubChan chan interface{})
 }
+
+// Relay relays announcements between the replicas of osm-controller through an external message broker.
+// Only the type of the announcements is relayed, so only the announcement types without payload can be relayed.
+type Relay interface {
+	// Publish sends the announcement to the other replicas
+	Publish(aType announcements.AnnouncementType) error
+
+	// Receive calls the handler with the announcements sent by the other replicas until the stop channel is closed
+	Receive(handler func(aType announcements.AnnouncementType), stop <-chan struct{}) error
+}
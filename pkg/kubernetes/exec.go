@@ -0,0 +1,41 @@
+package kubernetes
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// ExecInPod executes the given command in a container of a pod, and writes the output of the command to stdout and stderr
+func ExecInPod(conf *rest.Config, clientSet kubernetes.Interface, podName string, namespace string, containerName string, command []string, stdout io.Writer, stderr io.Writer) error {
+	req := clientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: containerName,
+			Command:   command,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(conf, http.MethodPost, req.URL())
+	if err != nil {
+		return errors.Errorf("Error setting up executor for exec: %s", err)
+	}
+
+	if err := executor.Stream(remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	}); err != nil {
+		return errors.Errorf("Error executing command %v in container %s of pod %s/%s: %s", command, containerName, namespace, podName, err)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	identity "github.com/openservicemesh/osm/pkg/identity"
 	service "github.com/openservicemesh/osm/pkg/service"
 	v1 "k8s.io/api/core/v1"
+	v1beta1 "k8s.io/api/discovery/v1beta1"
 )
 
 // MockController is a mock of Controller interface
@@ -36,21 +37,6 @@ func (m *MockController) EXPECT() *MockControllerMockRecorder {
 	return m.recorder
 }
 
-// GetEndpoints mocks base method
-func (m *MockController) GetEndpoints(arg0 service.MeshService) (*v1.Endpoints, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEndpoints", arg0)
-	ret0, _ := ret[0].(*v1.Endpoints)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetEndpoints indicates an expected call of GetEndpoints
-func (mr *MockControllerMockRecorder) GetEndpoints(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockController)(nil).GetEndpoints), arg0)
-}
-
 // GetNamespace mocks base method
 func (m *MockController) GetNamespace(arg0 string) *v1.Namespace {
 	m.ctrl.T.Helper()
@@ -79,6 +65,20 @@ func (mr *MockControllerMockRecorder) GetService(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetService", reflect.TypeOf((*MockController)(nil).GetService), arg0)
 }
 
+// GetSecret mocks base method
+func (m *MockController) GetSecret(arg0, arg1 string) *v1.Secret {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecret", arg0, arg1)
+	ret0, _ := ret[0].(*v1.Secret)
+	return ret0
+}
+
+// GetSecret indicates an expected call of GetSecret
+func (mr *MockControllerMockRecorder) GetSecret(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecret", reflect.TypeOf((*MockController)(nil).GetSecret), arg0, arg1)
+}
+
 // IsMonitoredNamespace mocks base method
 func (m *MockController) IsMonitoredNamespace(arg0 string) bool {
 	m.ctrl.T.Helper()
@@ -93,6 +93,21 @@ func (mr *MockControllerMockRecorder) IsMonitoredNamespace(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMonitoredNamespace", reflect.TypeOf((*MockController)(nil).IsMonitoredNamespace), arg0)
 }
 
+// ListEndpointSlicesForService mocks base method
+func (m *MockController) ListEndpointSlicesForService(arg0 service.MeshService) ([]*v1beta1.EndpointSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEndpointSlicesForService", arg0)
+	ret0, _ := ret[0].([]*v1beta1.EndpointSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEndpointSlicesForService indicates an expected call of ListEndpointSlicesForService
+func (mr *MockControllerMockRecorder) ListEndpointSlicesForService(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpointSlicesForService", reflect.TypeOf((*MockController)(nil).ListEndpointSlicesForService), arg0)
+}
+
 // ListMonitoredNamespaces mocks base method
 func (m *MockController) ListMonitoredNamespaces() ([]string, error) {
 	m.ctrl.T.Helper()
@@ -136,6 +151,20 @@ func (mr *MockControllerMockRecorder) ListServiceAccounts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceAccounts", reflect.TypeOf((*MockController)(nil).ListServiceAccounts))
 }
 
+// ListPodsForServiceAccount mocks base method
+func (m *MockController) ListPodsForServiceAccount(arg0 identity.K8sServiceAccount) []*v1.Pod {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPodsForServiceAccount", arg0)
+	ret0, _ := ret[0].([]*v1.Pod)
+	return ret0
+}
+
+// ListPodsForServiceAccount indicates an expected call of ListPodsForServiceAccount
+func (mr *MockControllerMockRecorder) ListPodsForServiceAccount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPodsForServiceAccount", reflect.TypeOf((*MockController)(nil).ListPodsForServiceAccount), arg0)
+}
+
 // ListServiceIdentitiesForService mocks base method
 func (m *MockController) ListServiceIdentitiesForService(arg0 service.MeshService) ([]identity.K8sServiceAccount, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,157 @@
+package kubernetes
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// namespacedInformerInitFunc creates the informer of a namespaced resource, watching the resources of the given namespace only
+type namespacedInformerInitFunc func(namespace string) cache.SharedIndexInformer
+
+// namespaceInformers is the collection of informers watching the resources of a single monitored namespace
+type namespaceInformers struct {
+	informers informerCollection
+	stop      chan struct{}
+
+	// done is closed once the informers are stopped, either because the namespace is no longer monitored
+	// or because the controller is stopped
+	done chan struct{}
+}
+
+// namespacedInformers manages the informers of namespaced resources. Rather than watching resources cluster-wide,
+// informers are started for a namespace once it is monitored, and stopped once it is no longer monitored, so that
+// resources in namespaces that are not part of the mesh are never cached.
+type namespacedInformers struct {
+	initFuncs map[InformerKey]namespacedInformerInitFunc
+	stop      <-chan struct{}
+
+	mutex      sync.RWMutex
+	namespaces map[string]*namespaceInformers
+}
+
+// newNamespacedInformers creates a new namespacedInformers creating informers with the given init functions,
+// whose informers are all stopped when the given stop channel is closed
+func newNamespacedInformers(initFuncs map[InformerKey]namespacedInformerInitFunc, stop <-chan struct{}) *namespacedInformers {
+	return &namespacedInformers{
+		initFuncs:  initFuncs,
+		stop:       stop,
+		namespaces: make(map[string]*namespaceInformers),
+	}
+}
+
+// start starts the informers of the given namespace if they are not already running, and returns the functions
+// reporting whether their caches have synced along with the channel closed once the informers are stopped
+func (ni *namespacedInformers) start(namespace string) ([]cache.InformerSynced, <-chan struct{}) {
+	if len(ni.initFuncs) == 0 {
+		return nil, ni.stop
+	}
+
+	ni.mutex.Lock()
+	defer ni.mutex.Unlock()
+
+	if nsInformers, ok := ni.namespaces[namespace]; ok {
+		return nsInformers.hasSynced(), nsInformers.done
+	}
+
+	nsInformers := &namespaceInformers{
+		informers: informerCollection{},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	for key, initFunc := range ni.initFuncs {
+		nsInformers.informers[key] = initFunc(namespace)
+	}
+
+	// Informers stop when the namespace is no longer monitored, or when the controller is stopped
+	go func() {
+		defer close(nsInformers.done)
+		select {
+		case <-ni.stop:
+		case <-nsInformers.stop:
+		}
+	}()
+
+	for _, informer := range nsInformers.informers {
+		go informer.Run(nsInformers.done)
+	}
+	ni.namespaces[namespace] = nsInformers
+	log.Info().Msgf("Started informers for namespace %s", namespace)
+
+	return nsInformers.hasSynced(), nsInformers.done
+}
+
+// startAndWaitForCacheSync starts the informers of the given namespace, and waits for their caches to sync.
+// It returns false if the informers are stopped before their caches have synced.
+func (ni *namespacedInformers) startAndWaitForCacheSync(namespace string) bool {
+	hasSynced, done := ni.start(namespace)
+	if !cache.WaitForCacheSync(done, hasSynced...) {
+		log.Error().Err(errSyncingCaches).Msgf("Error syncing the caches of the informers for namespace %s", namespace)
+		return false
+	}
+	return true
+}
+
+// stopNamespace stops the informers of the given namespace, dropping their caches. It returns false if the informers
+// of the namespace were not running.
+func (ni *namespacedInformers) stopNamespace(namespace string) bool {
+	ni.mutex.Lock()
+	defer ni.mutex.Unlock()
+
+	nsInformers, ok := ni.namespaces[namespace]
+	if !ok {
+		return false
+	}
+	close(nsInformers.stop)
+	delete(ni.namespaces, namespace)
+	log.Info().Msgf("Stopped informers for namespace %s", namespace)
+	return true
+}
+
+// getIndexer returns the indexer of the given informer for the given namespace, or nil if the namespace is not monitored
+func (ni *namespacedInformers) getIndexer(key InformerKey, namespace string) cache.Indexer {
+	ni.mutex.RLock()
+	defer ni.mutex.RUnlock()
+
+	nsInformers, ok := ni.namespaces[namespace]
+	if !ok {
+		return nil
+	}
+	informer, ok := nsInformers.informers[key]
+	if !ok {
+		return nil
+	}
+	return informer.GetIndexer()
+}
+
+// getByKey returns the object with the given <namespace>/<name> key from the cache of the given informer for the given namespace
+func (ni *namespacedInformers) getByKey(key InformerKey, namespace, objKey string) (interface{}, bool, error) {
+	indexer := ni.getIndexer(key, namespace)
+	if indexer == nil {
+		return nil, false, nil
+	}
+	return indexer.GetByKey(objKey)
+}
+
+// list returns the objects in the caches of the given informer for all monitored namespaces
+func (ni *namespacedInformers) list(key InformerKey) []interface{} {
+	ni.mutex.RLock()
+	defer ni.mutex.RUnlock()
+
+	var objs []interface{}
+	for _, nsInformers := range ni.namespaces {
+		if informer, ok := nsInformers.informers[key]; ok {
+			objs = append(objs, informer.GetStore().List()...)
+		}
+	}
+	return objs
+}
+
+// hasSynced returns the functions reporting whether the caches of the informers have synced
+func (nsInformers *namespaceInformers) hasSynced() []cache.InformerSynced {
+	var hasSynced []cache.InformerSynced
+	for _, informer := range nsInformers.informers {
+		hasSynced = append(hasSynced, informer.HasSynced)
+	}
+	return hasSynced
+}
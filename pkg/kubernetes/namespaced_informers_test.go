@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestNamespacedInformers(t *testing.T) {
+	assert := tassert.New(t)
+
+	kubeClient := testclient.NewSimpleClientset()
+	for _, ns := range []string{"ns-1", "ns-2", "ns-3"} {
+		_, err := kubeClient.CoreV1().Services(ns).Create(context.TODO(), tests.NewServiceFixture("svc", ns, nil), metav1.CreateOptions{})
+		assert.Nil(err)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	initCount := 0
+	ni := newNamespacedInformers(map[InformerKey]namespacedInformerInitFunc{
+		Services: func(namespace string) cache.SharedIndexInformer {
+			initCount++
+			return coreinformers.NewServiceInformer(kubeClient, namespace, DefaultKubeEventResyncInterval, cache.Indexers{})
+		},
+	}, stop)
+
+	assert.True(ni.startAndWaitForCacheSync("ns-1"))
+	assert.True(ni.startAndWaitForCacheSync("ns-2"))
+	assert.Len(ni.list(Services), 2)
+
+	// Starting the informers of a namespace is idempotent
+	assert.True(ni.startAndWaitForCacheSync("ns-1"))
+	assert.Equal(2, initCount)
+
+	_, exists, err := ni.getByKey(Services, "ns-1", "ns-1/svc")
+	assert.Nil(err)
+	assert.True(exists)
+
+	_, exists, err = ni.getByKey(Services, "ns-3", "ns-3/svc")
+	assert.Nil(err)
+	assert.False(exists)
+
+	// Informers not initialized are not found
+	assert.Nil(ni.getIndexer(Pods, "ns-1"))
+
+	assert.True(ni.stopNamespace("ns-1"))
+	assert.Len(ni.list(Services), 1)
+	assert.Nil(ni.getIndexer(Services, "ns-1"))
+
+	// Stopping the informers of a namespace not monitored is a no-op
+	assert.False(ni.stopNamespace("ns-3"))
+	assert.Len(ni.list(Services), 1)
+}
@@ -80,6 +80,12 @@ func (pf *PortForwarder) Stop() {
 	}
 }
 
+// GetForwardedPorts returns the ports being forwarded, which is useful to retrieve the local port
+// chosen when port forwarding is set up without a local port. It must be called once port forwarding is ready.
+func (pf *PortForwarder) GetForwardedPorts() ([]portforward.ForwardedPort, error) {
+	return pf.forwarder.GetPorts()
+}
+
 // Done returns a channel that is closed after Stop has been called.
 func (pf *PortForwarder) Done() <-chan struct{} {
 	return pf.done
@@ -0,0 +1,191 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// staticController is a Controller serving a fixed set of Kubernetes resources, without a Kubernetes client
+type staticController struct {
+	namespaces      cache.Store
+	services        cache.Store
+	serviceAccounts cache.Store
+	pods            cache.Indexer
+	endpointSlices  cache.Indexer
+	secrets         cache.Store
+}
+
+// NewStaticController returns a Controller serving the given Namespaces, Services, ServiceAccounts, Pods, EndpointSlices
+// and Secrets, without a Kubernetes client. As with a live Controller, only the namespaces labeled as monitored by the
+// mesh with the given name are monitored. The given pods are all considered part of the mesh. Objects of other types
+// are ignored.
+func NewStaticController(meshName string, objects []runtime.Object) (Controller, error) {
+	c := &staticController{
+		namespaces:      cache.NewStore(cache.MetaNamespaceKeyFunc),
+		services:        cache.NewStore(cache.MetaNamespaceKeyFunc),
+		serviceAccounts: cache.NewStore(cache.MetaNamespaceKeyFunc),
+		pods:            cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{podServiceAccountIndex: podServiceAccountIndexFunc}),
+		endpointSlices:  cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{endpointSliceServiceIndex: endpointSliceServiceIndexFunc}),
+		secrets:         cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *corev1.Namespace:
+			if o.Labels[constants.OSMKubeResourceMonitorAnnotation] == meshName {
+				err = c.namespaces.Add(o)
+			}
+		case *corev1.Service:
+			err = c.services.Add(o)
+		case *corev1.ServiceAccount:
+			err = c.serviceAccounts.Add(o)
+		case *corev1.Pod:
+			err = c.pods.Add(o)
+		case *discoveryv1beta1.EndpointSlice:
+			err = c.endpointSlices.Add(o)
+		case *corev1.Secret:
+			// Only TLS Secrets are served, as with a live Controller
+			if o.Type == corev1.SecretTypeTLS {
+				err = c.secrets.Add(o)
+			}
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error adding %T to static Kubernetes controller", obj)
+		}
+	}
+
+	return c, nil
+}
+
+// ListServices returns the services in monitored namespaces
+func (c *staticController) ListServices() []*corev1.Service {
+	var services []*corev1.Service
+	for _, obj := range c.services.List() {
+		svc := obj.(*corev1.Service)
+		if c.IsMonitoredNamespace(svc.Namespace) {
+			services = append(services, svc)
+		}
+	}
+	return services
+}
+
+// ListServiceAccounts returns the service accounts in monitored namespaces
+func (c *staticController) ListServiceAccounts() []*corev1.ServiceAccount {
+	var serviceAccounts []*corev1.ServiceAccount
+	for _, obj := range c.serviceAccounts.List() {
+		sa := obj.(*corev1.ServiceAccount)
+		if c.IsMonitoredNamespace(sa.Namespace) {
+			serviceAccounts = append(serviceAccounts, sa)
+		}
+	}
+	return serviceAccounts
+}
+
+// GetService returns the Kubernetes Service for the given MeshService in a monitored namespace, or nil if there is none
+func (c *staticController) GetService(svc service.MeshService) *corev1.Service {
+	if !c.IsMonitoredNamespace(svc.Namespace) {
+		return nil
+	}
+	obj, exists, err := c.services.GetByKey(svc.String())
+	if !exists || err != nil {
+		return nil
+	}
+	return obj.(*corev1.Service)
+}
+
+// IsMonitoredNamespace returns whether the namespace with the given name is monitored by the mesh
+func (c *staticController) IsMonitoredNamespace(namespace string) bool {
+	_, exists, _ := c.namespaces.GetByKey(namespace)
+	return exists
+}
+
+// ListMonitoredNamespaces returns the namespaces monitored by the mesh
+func (c *staticController) ListMonitoredNamespaces() ([]string, error) {
+	return c.namespaces.ListKeys(), nil
+}
+
+// GetNamespace returns the monitored namespace with the given name, or nil if there is none
+func (c *staticController) GetNamespace(ns string) *corev1.Namespace {
+	obj, exists, err := c.namespaces.GetByKey(ns)
+	if !exists || err != nil {
+		return nil
+	}
+	return obj.(*corev1.Namespace)
+}
+
+// ListPods returns the pods in monitored namespaces
+func (c *staticController) ListPods() []*corev1.Pod {
+	var pods []*corev1.Pod
+	for _, obj := range c.pods.List() {
+		pod := obj.(*corev1.Pod)
+		if c.IsMonitoredNamespace(pod.Namespace) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// ListPodsForServiceAccount returns the pods in monitored namespaces running as the given service account
+func (c *staticController) ListPodsForServiceAccount(sa identity.K8sServiceAccount) []*corev1.Pod {
+	if !c.IsMonitoredNamespace(sa.Namespace) {
+		return nil
+	}
+	objs, err := c.pods.ByIndex(podServiceAccountIndex, sa.String())
+	if err != nil {
+		return nil
+	}
+
+	pods := make([]*corev1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		pods = append(pods, obj.(*corev1.Pod))
+	}
+	return pods
+}
+
+// ListServiceIdentitiesForService lists the service accounts of the pods selected by the given service
+func (c *staticController) ListServiceIdentitiesForService(svc service.MeshService) ([]identity.K8sServiceAccount, error) {
+	k8sSvc := c.GetService(svc)
+	if k8sSvc == nil {
+		return nil, errors.Errorf("Error fetching service %q: %s", svc, errServiceNotFound)
+	}
+	return getServiceAccountsForService(k8sSvc, c.ListPods()), nil
+}
+
+// ListEndpointSlicesForService returns the EndpointSlices of the given service in a monitored namespace
+func (c *staticController) ListEndpointSlicesForService(svc service.MeshService) ([]*discoveryv1beta1.EndpointSlice, error) {
+	if !c.IsMonitoredNamespace(svc.Namespace) {
+		return nil, nil
+	}
+	objs, err := c.endpointSlices.ByIndex(endpointSliceServiceIndex, svc.String())
+	if err != nil {
+		return nil, err
+	}
+
+	endpointSlices := make([]*discoveryv1beta1.EndpointSlice, 0, len(objs))
+	for _, obj := range objs {
+		endpointSlices = append(endpointSlices, obj.(*discoveryv1beta1.EndpointSlice))
+	}
+	return endpointSlices, nil
+}
+
+// GetSecret returns the TLS Secret with the given name in the given monitored namespace, or nil if there is none
+func (c *staticController) GetSecret(namespace string, name string) *corev1.Secret {
+	if !c.IsMonitoredNamespace(namespace) {
+		return nil
+	}
+	obj, exists, err := c.secrets.GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if !exists || err != nil {
+		return nil
+	}
+	return obj.(*corev1.Secret)
+}
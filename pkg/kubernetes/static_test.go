@@ -0,0 +1,57 @@
+package kubernetes
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestStaticController(t *testing.T) {
+	assert := tassert.New(t)
+
+	monitoredLabels := map[string]string{constants.OSMKubeResourceMonitorAnnotation: testMeshName}
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitored", Labels: monitoredLabels}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unmonitored"}},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "monitored"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "bookstore"}},
+		},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "unmonitored"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "bookstore-1", Namespace: "monitored", Labels: map[string]string{"app": "bookstore"}},
+			Spec:       corev1.PodSpec{ServiceAccountName: "bookstore"},
+		},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opaque", Namespace: "monitored"}, Type: corev1.SecretTypeOpaque},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "monitored"}, Type: corev1.SecretTypeTLS},
+	}
+
+	c, err := NewStaticController(testMeshName, objects)
+	assert.Nil(err)
+
+	namespaces, err := c.ListMonitoredNamespaces()
+	assert.Nil(err)
+	assert.Equal([]string{"monitored"}, namespaces)
+	assert.Nil(c.GetNamespace("unmonitored"))
+
+	assert.Len(c.ListServices(), 1)
+	assert.NotNil(c.GetService(service.MeshService{Name: "bookstore", Namespace: "monitored"}))
+	assert.Nil(c.GetService(service.MeshService{Name: "bookstore", Namespace: "unmonitored"}))
+
+	svcAccounts, err := c.ListServiceIdentitiesForService(service.MeshService{Name: "bookstore", Namespace: "monitored"})
+	assert.Nil(err)
+	assert.Equal([]identity.K8sServiceAccount{{Name: "bookstore", Namespace: "monitored"}}, svcAccounts)
+
+	assert.Len(c.ListPodsForServiceAccount(identity.K8sServiceAccount{Name: "bookstore", Namespace: "monitored"}), 1)
+	assert.Empty(c.ListPodsForServiceAccount(identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "monitored"}))
+
+	assert.Nil(c.GetSecret("monitored", "opaque"))
+	assert.NotNil(c.GetSecret("monitored", "tls"))
+}
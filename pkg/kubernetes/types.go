@@ -1,13 +1,15 @@
 // Package kubernetes implements the Kubernetes Controller interface to monitor and retrieve information regarding
-// Kubernetes resources such as Namespaces, Services, Pods, Endpoints, and ServiceAccounts.
+// Kubernetes resources such as Namespaces, Services, Pods, EndpointSlices, and ServiceAccounts.
 package kubernetes
 
 import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -42,6 +44,9 @@ const (
 
 	// providerName is the name of the Kubernetes event provider
 	providerName = "Kubernetes"
+
+	// namespaceWorkers is the number of workers starting and stopping the informers of monitored namespaces
+	namespaceWorkers = 4
 )
 
 // InformerKey stores the different Informers we keep for K8s resources
@@ -54,12 +59,20 @@ const (
 	Services InformerKey = "Services"
 	// Pods lookup identifier
 	Pods InformerKey = "Pods"
-	// Endpoints lookup identifier
-	Endpoints InformerKey = "Endpoints"
+	// EndpointSlices lookup identifier
+	EndpointSlices InformerKey = "EndpointSlices"
 	// ServiceAccounts lookup identifier
 	ServiceAccounts InformerKey = "ServiceAccounts"
+	// Secrets lookup identifier
+	Secrets InformerKey = "Secrets"
 )
 
+// endpointSliceServiceIndex is the name of the index of EndpointSlices by the <namespace>/<name> key of their service
+const endpointSliceServiceIndex = "service"
+
+// podServiceAccountIndex is the name of the index of Pods by the <namespace>/<name> key of their service account
+const podServiceAccountIndex = "serviceAccount"
+
 // informerCollection is the type holding the collection of informers we keep
 type informerCollection map[InformerKey]cache.SharedIndexInformer
 
@@ -69,6 +82,15 @@ type Client struct {
 	kubeClient  kubernetes.Interface
 	informers   informerCollection
 	cacheSynced chan interface{}
+
+	// namespacedInformers holds the informers of namespaced resources in monitored namespaces
+	namespacedInformers *namespacedInformers
+
+	// namespaceQueue holds the names of the namespaces whose informers must be started or stopped
+	namespaceQueue workqueue.Interface
+
+	// namespaceEventHandlers announces the events of monitored namespaces
+	namespaceEventHandlers cache.ResourceEventHandlerFuncs
 }
 
 // Controller is the controller interface for K8s services
@@ -95,9 +117,15 @@ type Controller interface {
 	// ListPods returns a list of pods part of the mesh
 	ListPods() []*corev1.Pod
 
+	// ListPodsForServiceAccount returns the pods part of the mesh running as the given service account
+	ListPodsForServiceAccount(identity.K8sServiceAccount) []*corev1.Pod
+
 	// ListServiceIdentitiesForService lists ServiceAccounts associated with the given service
 	ListServiceIdentitiesForService(svc service.MeshService) ([]identity.K8sServiceAccount, error)
 
-	// GetEndpoints returns the endpoints for a given service, if found
-	GetEndpoints(svc service.MeshService) (*corev1.Endpoints, error)
+	// ListEndpointSlicesForService returns the EndpointSlices of the given service
+	ListEndpointSlicesForService(svc service.MeshService) ([]*discoveryv1beta1.EndpointSlice, error)
+
+	// GetSecret returns the TLS Secret with the given name in the given namespace if it exists in cache, otherwise nil
+	GetSecret(namespace string, name string) *corev1.Secret
 }
@@ -100,3 +100,32 @@ func GetKubernetesServerVersionNumber(kubeClient kubernetes.Interface) ([]int, e
 
 	return ver.Segments(), nil
 }
+
+// IsJobPod returns true if the given pod is controlled by a Job, including pods of Jobs created by a CronJob
+func IsJobPod(pod *corev1.Pod) bool {
+	for _, ref := range pod.GetOwnerReferences() {
+		if ref.Controller != nil && *ref.Controller {
+			return ref.Kind == "Job"
+		}
+	}
+	return false
+}
+
+// IsEnvoyAdminPortExposed returns true if the Envoy sidecar of the given pod exposes its admin port. The admin interface
+// of sidecars injected with the admin interface bound to a Unix domain socket is reached with osm-healthcheck instead.
+func IsEnvoyAdminPortExposed(pod *corev1.Pod) bool {
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			if container.Name != constants.EnvoyContainerName {
+				continue
+			}
+			for _, port := range container.Ports {
+				if port.Name == constants.EnvoyAdminPortName {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return false
+}
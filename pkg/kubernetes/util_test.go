@@ -6,11 +6,13 @@ import (
 
 	tassert "github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -190,3 +192,114 @@ func TestGetKubernetesServerVersionNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestIsJobPod(t *testing.T) {
+	isController := true
+
+	testCases := []struct {
+		name      string
+		ownerRefs []metav1.OwnerReference
+		expected  bool
+	}{
+		{
+			name:      "pod without owner",
+			ownerRefs: nil,
+			expected:  false,
+		},
+		{
+			name: "pod controlled by a Job",
+			ownerRefs: []metav1.OwnerReference{
+				{Kind: "Job", Name: "pi", Controller: &isController},
+			},
+			expected: true,
+		},
+		{
+			name: "pod controlled by a ReplicaSet",
+			ownerRefs: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "bookstore-v1-5f8b9c", Controller: &isController},
+			},
+			expected: false,
+		},
+		{
+			name: "pod owned by a Job that is not its controller",
+			ownerRefs: []metav1.OwnerReference{
+				{Kind: "Job", Name: "pi"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: tc.ownerRefs,
+				},
+			}
+			assert.Equal(tc.expected, IsJobPod(pod))
+		})
+	}
+}
+
+func TestIsEnvoyAdminPortExposed(t *testing.T) {
+	adminPort := corev1.ContainerPort{Name: constants.EnvoyAdminPortName, ContainerPort: constants.EnvoyAdminPort}
+	inboundPort := corev1.ContainerPort{Name: constants.EnvoyInboundListenerPortName, ContainerPort: constants.EnvoyInboundListenerPort}
+
+	testCases := []struct {
+		name     string
+		podSpec  corev1.PodSpec
+		expected bool
+	}{
+		{
+			name: "Envoy sidecar exposing its admin port",
+			podSpec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app"},
+					{Name: constants.EnvoyContainerName, Ports: []corev1.ContainerPort{adminPort, inboundPort}},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "Envoy sidecar container without an admin port",
+			podSpec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app"},
+					{Name: constants.EnvoyContainerName, Ports: []corev1.ContainerPort{inboundPort}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "Envoy sidecar init container exposing its admin port",
+			podSpec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: constants.InitContainerName},
+					{Name: constants.EnvoyContainerName, Ports: []corev1.ContainerPort{adminPort}},
+				},
+				Containers: []corev1.Container{
+					{Name: "app"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "pod without an Envoy sidecar",
+			podSpec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "app", Ports: []corev1.ContainerPort{adminPort}},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, IsEnvoyAdminPortExposed(&corev1.Pod{Spec: tc.podSpec}))
+		})
+	}
+}
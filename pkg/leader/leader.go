@@ -0,0 +1,105 @@
+// Package leader implements the election of a leader among the replicas of osm-controller, for the components
+// that must act on a single replica at a time, such as the components updating Kubernetes resources on a schedule.
+package leader
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// LeaseName is the name of the Lease held by the leader among the replicas of osm-controller
+	LeaseName = "osm-controller-leader"
+
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+var (
+	log = logger.New("leader-election")
+)
+
+// Checker reports whether this replica of osm-controller is the leader
+type Checker interface {
+	// IsLeader returns true if this replica is currently the leader
+	IsLeader() bool
+}
+
+// Elector elects a leader among the replicas of osm-controller through a Lease in the namespace of the control plane
+type Elector struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+	identity   string
+
+	// leading is 1 while this replica is the leader, 0 otherwise
+	leading int32
+}
+
+// NewElector returns an Elector for the replica of osm-controller with the given identity, ex. the name of its pod
+func NewElector(kubeClient kubernetes.Interface, namespace, identity string) *Elector {
+	return &Elector{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		identity:   identity,
+	}
+}
+
+// Start campaigns for the leadership until the stop channel is closed. A replica losing the leadership campaigns again,
+// and the Lease is released when the stop channel is closed so that another replica takes over without delay.
+func (e *Elector) Start(stop <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      LeaseName,
+			Namespace: e.namespace,
+		},
+		Client: e.kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: e.identity,
+		},
+	}
+	config := leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		ReleaseOnCancel: true,
+		Name:            LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				log.Info().Msgf("Replica %s started leading", e.identity)
+				atomic.StoreInt32(&e.leading, 1)
+			},
+			OnStoppedLeading: func() {
+				log.Info().Msgf("Replica %s stopped leading", e.identity)
+				atomic.StoreInt32(&e.leading, 0)
+			},
+		},
+	}
+
+	go func() {
+		for ctx.Err() == nil {
+			// RunOrDie returns when the leadership is lost or the context is canceled
+			leaderelection.RunOrDie(ctx, config)
+		}
+	}()
+}
+
+// IsLeader returns true if this replica is currently the leader
+func (e *Elector) IsLeader() bool {
+	return atomic.LoadInt32(&e.leading) == 1
+}
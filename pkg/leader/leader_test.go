@@ -0,0 +1,33 @@
+package leader
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestElector(t *testing.T) {
+	assert := tassert.New(t)
+
+	kubeClient := fake.NewSimpleClientset()
+	stop1 := make(chan struct{})
+	stop2 := make(chan struct{})
+	defer close(stop2)
+
+	elector1 := NewElector(kubeClient, "osm-system", "osm-controller-1")
+	elector1.Start(stop1)
+	assert.Eventually(elector1.IsLeader, 5*time.Second, 10*time.Millisecond)
+
+	// The Lease is held by the first replica
+	elector2 := NewElector(kubeClient, "osm-system", "osm-controller-2")
+	elector2.Start(stop2)
+	assert.Never(elector2.IsLeader, 500*time.Millisecond, 10*time.Millisecond)
+
+	// The Lease is released when the first replica stops, and acquired by the second replica
+	close(stop1)
+	assert.Eventually(func() bool {
+		return !elector1.IsLeader() && elector2.IsLeader()
+	}, 10*time.Second, 10*time.Millisecond)
+}
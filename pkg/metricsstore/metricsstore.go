@@ -37,6 +37,25 @@ type MetricsStore struct {
 	// ProxyConfigUpdateTime is the histogram to track time spent for proxy configuration and its occurrences
 	ProxyConfigUpdateTime *prometheus.HistogramVec
 
+	// ProxyPendingPushCount is the metric for the number of proxy configuration updates waiting to be computed and sent
+	ProxyPendingPushCount prometheus.Gauge
+
+	// ProxyResponseNACKCount is the metric for the number of discovery responses rejected by proxies
+	ProxyResponseNACKCount *prometheus.CounterVec
+
+	// ProxyConfigRollbackCount is the metric for the number of times proxies were rolled back to their last ACKed configuration
+	ProxyConfigRollbackCount *prometheus.CounterVec
+
+	// ProxyVersionCount is the metric for the number of proxies connected to the controller per Envoy version
+	ProxyVersionCount *prometheus.GaugeVec
+
+	// ProxyConfigClassCount is the metric for the number of sidecars connected to the controller per proxy configuration class
+	ProxyConfigClassCount *prometheus.GaugeVec
+
+	// ProxyUnsupportedVersionWithheldFeatureCount is the metric for the number of connected proxies a newer configuration
+	// feature is withheld from, because they run an Envoy version below the minimum supported version
+	ProxyUnsupportedVersionWithheldFeatureCount *prometheus.GaugeVec
+
 	/*
 	 * Injector metrics
 	 */
@@ -55,6 +74,18 @@ type MetricsStore struct {
 	// CertXdsIssuedCounter the histogram to track the time to issue a certificates
 	CertIssuedTime *prometheus.HistogramVec
 
+	/*
+	 * Message broker metrics
+	 */
+	// BrokerQueueLength is the metric for the number of announcements queued for subscribers, per subscription
+	BrokerQueueLength *prometheus.GaugeVec
+
+	// BrokerRelayedCount is the metric for the number of announcements relayed through the external message broker
+	BrokerRelayedCount *prometheus.CounterVec
+
+	// BrokerDroppedCount is the metric for the number of announcements dropped by the external message broker
+	BrokerDroppedCount *prometheus.CounterVec
+
 	/*
 	 * MetricsStore internals should be defined below --------------
 	 */
@@ -115,6 +146,69 @@ func init() {
 			"success",       // further labels if the operation succeeded or not
 		})
 
+	defaultMetricsStore.ProxyPendingPushCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "pending_push_count",
+		Help:      "represents the number of proxy configuration updates waiting to be computed and sent by OSM controller",
+	})
+
+	defaultMetricsStore.ProxyResponseNACKCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "response_nack_count",
+			Help:      "represents the number of discovery responses rejected by proxies",
+		},
+		[]string{
+			"resource_type", // identifies a typeURI resource
+		})
+
+	defaultMetricsStore.ProxyConfigRollbackCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "config_rollback_count",
+			Help:      "represents the number of times proxies were rolled back to the last configuration they accepted",
+		},
+		[]string{
+			"resource_type", // identifies a typeURI resource
+		})
+
+	defaultMetricsStore.ProxyVersionCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "version_count",
+			Help:      "represents the number of proxies connected to OSM controller per Envoy version",
+		},
+		[]string{
+			"version", // the Envoy version of the proxies
+		})
+
+	defaultMetricsStore.ProxyConfigClassCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "config_class_count",
+			Help:      "represents the number of sidecars connected to OSM controller per proxy configuration class",
+		},
+		[]string{
+			"class", // the proxy configuration class of the sidecars
+		})
+
+	defaultMetricsStore.ProxyUnsupportedVersionWithheldFeatureCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "proxy",
+			Name:      "unsupported_version_withheld_feature_count",
+			Help:      "represents the number of proxies connected to OSM controller a newer configuration feature is withheld from, because they run an Envoy version below the minimum supported version",
+		},
+		[]string{
+			"version", // the Envoy version of the proxies
+			"feature", // the configuration feature withheld from the proxies
+		})
+
 	/*
 	 * Injector metrics
 	 */
@@ -156,6 +250,43 @@ func init() {
 			Help:      "Histogram to track time spent to issue xds certificate",
 		},
 		[]string{})
+
+	/*
+	 * Message broker metrics
+	 */
+	defaultMetricsStore.BrokerQueueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "broker",
+			Name:      "queue_length",
+			Help:      "represents the number of announcements queued for the subscribers of OSM controller's message broker",
+		},
+		[]string{
+			"subscription", // the announcement types subscribed to
+		})
+
+	defaultMetricsStore.BrokerRelayedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "broker",
+			Name:      "relayed_count",
+			Help:      "represents the number of announcements relayed between the replicas of OSM controller through the external message broker",
+		},
+		[]string{
+			"direction", // sent or received
+		})
+
+	defaultMetricsStore.BrokerDroppedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsRootNamespace,
+			Subsystem: "broker",
+			Name:      "dropped_count",
+			Help:      "represents the number of announcements dropped by the external message broker",
+		},
+		[]string{
+			"reason", // the reason the announcements were dropped
+		})
+
 	defaultMetricsStore.registry = prometheus.NewRegistry()
 }
 
@@ -0,0 +1,198 @@
+package policy
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+)
+
+const (
+	// egressSourceServiceAccountIndex is the name of the index of Egress policies by the <namespace>/<name> key of
+	// the service accounts of their ServiceAccount sources
+	egressSourceServiceAccountIndex = "sourceServiceAccount"
+
+	// egressSourcePodNamespaceIndex is the name of the index of Egress policies by the namespaces of their Pod sources
+	egressSourcePodNamespaceIndex = "sourcePodNamespace"
+)
+
+// egressIndexers are the indexers of the Egress policies, used to retrieve the policies of a source identity without
+// iterating over all the policies
+var egressIndexers = cache.Indexers{
+	egressSourceServiceAccountIndex: egressSourceServiceAccountIndexFunc,
+	egressSourcePodNamespaceIndex:   egressSourcePodNamespaceIndexFunc,
+}
+
+// egressSourceServiceAccountIndexFunc indexes an Egress policy by the service accounts of its ServiceAccount sources
+func egressSourceServiceAccountIndexFunc(obj interface{}) ([]string, error) {
+	egressPolicy, ok := obj.(*policyV1alpha1.Egress)
+	if !ok {
+		return nil, errors.Errorf("Expected an Egress, got %T", obj)
+	}
+
+	var keys []string
+	for _, sourceSpec := range egressPolicy.Spec.Sources {
+		if sourceSpec.Kind == egressSourceKindSvcAccount {
+			keys = append(keys, identity.K8sServiceAccount{Namespace: sourceSpec.Namespace, Name: sourceSpec.Name}.String())
+		}
+	}
+	return keys, nil
+}
+
+// egressSourcePodNamespaceIndexFunc indexes an Egress policy by the namespaces of its Pod sources
+func egressSourcePodNamespaceIndexFunc(obj interface{}) ([]string, error) {
+	egressPolicy, ok := obj.(*policyV1alpha1.Egress)
+	if !ok {
+		return nil, errors.Errorf("Expected an Egress, got %T", obj)
+	}
+
+	var keys []string
+	for _, sourceSpec := range egressPolicy.Spec.Sources {
+		if sourceSpec.Kind == egressSourceKindPod {
+			keys = append(keys, sourceSpec.Namespace)
+		}
+	}
+	return keys, nil
+}
+
+// egressSourceCache caches the Egress policies of source identities. Entries are invalidated when an Egress policy
+// changes, when the labels or the service account of a pod change, and when a namespace joins or leaves the mesh.
+type egressSourceCache struct {
+	mutex    sync.RWMutex
+	policies map[identity.K8sServiceAccount][]*policyV1alpha1.Egress
+
+	// generation is incremented on every invalidation, so that policies computed concurrently with an invalidation
+	// are not cached
+	generation uint64
+}
+
+func newEgressSourceCache() *egressSourceCache {
+	return &egressSourceCache{
+		policies: make(map[identity.K8sServiceAccount][]*policyV1alpha1.Egress),
+	}
+}
+
+// get returns the cached Egress policies of the given source identity if any, and the current generation of the cache
+func (ec *egressSourceCache) get(source identity.K8sServiceAccount) ([]*policyV1alpha1.Egress, bool, uint64) {
+	ec.mutex.RLock()
+	defer ec.mutex.RUnlock()
+
+	policies, ok := ec.policies[source]
+	return policies, ok, ec.generation
+}
+
+// set caches the given Egress policies of the given source identity, unless the cache was invalidated since the
+// given generation
+func (ec *egressSourceCache) set(source identity.K8sServiceAccount, policies []*policyV1alpha1.Egress, generation uint64) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	if ec.generation == generation {
+		ec.policies[source] = policies
+	}
+}
+
+// invalidate removes the cached Egress policies of the given source identities
+func (ec *egressSourceCache) invalidate(sources ...identity.K8sServiceAccount) {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	for _, source := range sources {
+		delete(ec.policies, source)
+	}
+	ec.generation++
+}
+
+// invalidateAll removes the cached Egress policies of all the source identities
+func (ec *egressSourceCache) invalidateAll() {
+	ec.mutex.Lock()
+	defer ec.mutex.Unlock()
+
+	ec.policies = make(map[identity.K8sServiceAccount][]*policyV1alpha1.Egress)
+	ec.generation++
+}
+
+// runInvalidation invalidates the entries of the cache on the announcements of the changes of Egress policies, pods
+// and namespaces, until the given stop channel is closed
+func (ec *egressSourceCache) runInvalidation(stop <-chan struct{}) {
+	subscription := events.GetPubSubInstance().Subscribe(
+		announcements.EgressAdded, announcements.EgressDeleted, announcements.EgressUpdated,
+		announcements.PodAdded, announcements.PodDeleted, announcements.PodUpdated,
+		announcements.NamespaceAdded, announcements.NamespaceDeleted, announcements.NamespaceUpdated,
+	)
+	defer events.GetPubSubInstance().Unsub(subscription)
+
+	for {
+		select {
+		case <-stop:
+			return
+		case msg := <-subscription:
+			psubMessage, ok := msg.(events.PubSubMessage)
+			if !ok {
+				log.Error().Msgf("Error casting PubSubMessage: %v", msg)
+				continue
+			}
+			ec.handleAnnouncement(psubMessage)
+		}
+	}
+}
+
+// handleAnnouncement invalidates the entries of the cache affected by the given announcement
+func (ec *egressSourceCache) handleAnnouncement(msg events.PubSubMessage) {
+	switch msg.AnnouncementType {
+	case announcements.PodAdded:
+		if pod, ok := msg.NewObj.(*corev1.Pod); ok {
+			ec.invalidate(podServiceAccount(pod))
+		}
+
+	case announcements.PodDeleted:
+		if pod, ok := msg.OldObj.(*corev1.Pod); ok {
+			ec.invalidate(podServiceAccount(pod))
+		}
+
+	case announcements.PodUpdated:
+		oldPod, oldOk := msg.OldObj.(*corev1.Pod)
+		newPod, newOk := msg.NewObj.(*corev1.Pod)
+		if !oldOk || !newOk {
+			ec.invalidateAll()
+			return
+		}
+		// Only the labels and the service account of a pod determine the Egress policies of its service account
+		if oldPod.Spec.ServiceAccountName != newPod.Spec.ServiceAccountName || !reflect.DeepEqual(oldPod.Labels, newPod.Labels) {
+			ec.invalidate(podServiceAccount(oldPod), podServiceAccount(newPod))
+		}
+
+	case announcements.EgressUpdated:
+		oldEgress, oldOk := msg.OldObj.(*policyV1alpha1.Egress)
+		newEgress, newOk := msg.NewObj.(*policyV1alpha1.Egress)
+		// Updates of the status of a policy, and resyncs, do not change the policies of source identities
+		if oldOk && newOk && reflect.DeepEqual(oldEgress.Spec, newEgress.Spec) {
+			return
+		}
+		ec.invalidateAll()
+
+	case announcements.NamespaceUpdated:
+		oldNs, oldOk := msg.OldObj.(*corev1.Namespace)
+		newNs, newOk := msg.NewObj.(*corev1.Namespace)
+		// Only a change of labels can change whether a namespace is monitored
+		if oldOk && newOk && reflect.DeepEqual(oldNs.Labels, newNs.Labels) {
+			return
+		}
+		ec.invalidateAll()
+
+	default:
+		ec.invalidateAll()
+	}
+}
+
+// podServiceAccount returns the service account of the given pod
+func podServiceAccount(pod *corev1.Pod) identity.K8sServiceAccount {
+	return identity.K8sServiceAccount{Namespace: pod.Namespace, Name: pod.Spec.ServiceAccountName}
+}
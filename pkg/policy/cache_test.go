@@ -0,0 +1,175 @@
+package policy
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/announcements"
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/kubernetes/events"
+)
+
+func TestEgressIndexFuncs(t *testing.T) {
+	assert := tassert.New(t)
+
+	egressPolicy := &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "test"},
+		Spec: policyV1alpha1.EgressSpec{
+			Sources: []policyV1alpha1.SourceSpec{
+				{Kind: "ServiceAccount", Namespace: "test", Name: "sa-1"},
+				{Kind: "ServiceAccount", Namespace: "other", Name: "sa-2"},
+				{Kind: "Pod", Namespace: "pods"},
+			},
+		},
+	}
+
+	keys, err := egressSourceServiceAccountIndexFunc(egressPolicy)
+	assert.Nil(err)
+	assert.Equal([]string{"test/sa-1", "other/sa-2"}, keys)
+
+	keys, err = egressSourcePodNamespaceIndexFunc(egressPolicy)
+	assert.Nil(err)
+	assert.Equal([]string{"pods"}, keys)
+
+	_, err = egressSourceServiceAccountIndexFunc(&corev1.Pod{})
+	assert.NotNil(err)
+	_, err = egressSourcePodNamespaceIndexFunc(&corev1.Pod{})
+	assert.NotNil(err)
+}
+
+func TestEgressSourceCacheGeneration(t *testing.T) {
+	assert := tassert.New(t)
+
+	sa := identity.K8sServiceAccount{Namespace: "test", Name: "sa-1"}
+	policies := []*policyV1alpha1.Egress{{ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "test"}}}
+	ec := newEgressSourceCache()
+
+	_, ok, generation := ec.get(sa)
+	assert.False(ok)
+
+	// Policies computed before an invalidation are not cached
+	ec.invalidateAll()
+	ec.set(sa, policies, generation)
+	_, ok, generation = ec.get(sa)
+	assert.False(ok)
+
+	ec.set(sa, policies, generation)
+	actual, ok, _ := ec.get(sa)
+	assert.True(ok)
+	assert.Equal(policies, actual)
+}
+
+func TestEgressSourceCacheHandleAnnouncement(t *testing.T) {
+	sa1 := identity.K8sServiceAccount{Namespace: "test", Name: "sa-1"}
+	sa2 := identity.K8sServiceAccount{Namespace: "test", Name: "sa-2"}
+
+	newPod := func(sa string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "test", Labels: labels},
+			Spec:       corev1.PodSpec{ServiceAccountName: sa},
+		}
+	}
+	newEgress := func(hosts ...string) *policyV1alpha1.Egress {
+		return &policyV1alpha1.Egress{
+			ObjectMeta: metav1.ObjectMeta{Name: "egress", Namespace: "test"},
+			Spec:       policyV1alpha1.EgressSpec{Hosts: hosts},
+		}
+	}
+	newNamespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: labels}}
+	}
+
+	testCases := []struct {
+		name         string
+		msg          events.PubSubMessage
+		expectCached []identity.K8sServiceAccount
+	}{
+		{
+			name:         "pod added",
+			msg:          events.PubSubMessage{AnnouncementType: announcements.PodAdded, NewObj: newPod("sa-1", nil)},
+			expectCached: []identity.K8sServiceAccount{sa2},
+		},
+		{
+			name:         "pod deleted",
+			msg:          events.PubSubMessage{AnnouncementType: announcements.PodDeleted, OldObj: newPod("sa-2", nil)},
+			expectCached: []identity.K8sServiceAccount{sa1},
+		},
+		{
+			name: "pod updated without changes of labels or service account",
+			msg: events.PubSubMessage{
+				AnnouncementType: announcements.PodUpdated,
+				OldObj:           newPod("sa-1", map[string]string{"app": "foo"}),
+				NewObj:           newPod("sa-1", map[string]string{"app": "foo"}),
+			},
+			expectCached: []identity.K8sServiceAccount{sa1, sa2},
+		},
+		{
+			name: "pod labels updated",
+			msg: events.PubSubMessage{
+				AnnouncementType: announcements.PodUpdated,
+				OldObj:           newPod("sa-1", map[string]string{"app": "foo"}),
+				NewObj:           newPod("sa-1", map[string]string{"app": "bar"}),
+			},
+			expectCached: []identity.K8sServiceAccount{sa2},
+		},
+		{
+			name:         "egress added",
+			msg:          events.PubSubMessage{AnnouncementType: announcements.EgressAdded, NewObj: newEgress("foo.com")},
+			expectCached: nil,
+		},
+		{
+			name:         "egress status updated",
+			msg:          events.PubSubMessage{AnnouncementType: announcements.EgressUpdated, OldObj: newEgress("foo.com"), NewObj: newEgress("foo.com")},
+			expectCached: []identity.K8sServiceAccount{sa1, sa2},
+		},
+		{
+			name:         "egress spec updated",
+			msg:          events.PubSubMessage{AnnouncementType: announcements.EgressUpdated, OldObj: newEgress("foo.com"), NewObj: newEgress("bar.com")},
+			expectCached: nil,
+		},
+		{
+			name: "namespace updated without changes of labels",
+			msg: events.PubSubMessage{
+				AnnouncementType: announcements.NamespaceUpdated,
+				OldObj:           newNamespace(map[string]string{"foo": "bar"}),
+				NewObj:           newNamespace(map[string]string{"foo": "bar"}),
+			},
+			expectCached: []identity.K8sServiceAccount{sa1, sa2},
+		},
+		{
+			name: "namespace labels updated",
+			msg: events.PubSubMessage{
+				AnnouncementType: announcements.NamespaceUpdated,
+				OldObj:           newNamespace(map[string]string{"foo": "bar"}),
+				NewObj:           newNamespace(nil),
+			},
+			expectCached: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			ec := newEgressSourceCache()
+			for _, sa := range []identity.K8sServiceAccount{sa1, sa2} {
+				_, _, generation := ec.get(sa)
+				ec.set(sa, nil, generation)
+			}
+
+			ec.handleAnnouncement(tc.msg)
+
+			var cached []identity.K8sServiceAccount
+			for _, sa := range []identity.K8sServiceAccount{sa1, sa2} {
+				if _, ok, _ := ec.get(sa); ok {
+					cached = append(cached, sa)
+				}
+			}
+			assert.Equal(tc.expectCached, cached)
+		})
+	}
+}
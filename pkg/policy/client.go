@@ -2,8 +2,12 @@ package policy
 
 import (
 	"reflect"
+	"sort"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 
@@ -14,6 +18,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/announcements"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 const (
@@ -22,6 +27,9 @@ const (
 
 	// egressSourceKindSvcAccount is the ServiceAccount kind for a source defined in Egress policy
 	egressSourceKindSvcAccount = "ServiceAccount"
+
+	// egressSourceKindPod is the Pod kind for a source defined in Egress policy
+	egressSourceKindPod = "Pod"
 )
 
 // NewPolicyController returns a policy.Controller interface related to functionality provided by the resources in the policy.openservicemesh.io API group
@@ -42,11 +50,16 @@ func newPolicyClient(policyClient policyV1alpha1Client.Interface, kubeController
 	informerFactory := policyV1alpha1Informers.NewSharedInformerFactory(policyClient, kubernetes.DefaultKubeEventResyncInterval)
 
 	informerCollection := informerCollection{
-		egress: informerFactory.Policy().V1alpha1().Egresses().Informer(),
+		egress:                 informerFactory.Policy().V1alpha1().Egresses().Informer(),
+		upstreamTrafficSetting: informerFactory.Policy().V1alpha1().UpstreamTrafficSettings().Informer(),
+	}
+	if err := informerCollection.egress.AddIndexers(egressIndexers); err != nil {
+		return client{}, errors.Errorf("Could not add Egress indexers: %s", err)
 	}
 
 	cacheCollection := cacheCollection{
-		egress: informerCollection.egress.GetStore(),
+		egress:                 informerCollection.egress.GetIndexer(),
+		upstreamTrafficSetting: informerCollection.upstreamTrafficSetting.GetStore(),
 	}
 
 	client := client{
@@ -54,6 +67,7 @@ func newPolicyClient(policyClient policyV1alpha1Client.Interface, kubeController
 		caches:         &cacheCollection,
 		cacheSynced:    make(chan interface{}),
 		kubeController: kubeController,
+		egressSources:  newEgressSourceCache(),
 	}
 
 	shouldObserve := func(obj interface{}) bool {
@@ -68,6 +82,13 @@ func newPolicyClient(policyClient policyV1alpha1Client.Interface, kubeController
 	}
 	informerCollection.egress.AddEventHandler(kubernetes.GetKubernetesEventHandlers("Egress", "Policy", shouldObserve, egressEventTypes))
 
+	upstreamTrafficSettingEventTypes := kubernetes.EventTypes{
+		Add:    announcements.UpstreamTrafficSettingAdded,
+		Update: announcements.UpstreamTrafficSettingUpdated,
+		Delete: announcements.UpstreamTrafficSettingDeleted,
+	}
+	informerCollection.upstreamTrafficSetting.AddEventHandler(kubernetes.GetKubernetesEventHandlers("UpstreamTrafficSetting", "Policy", shouldObserve, upstreamTrafficSettingEventTypes))
+
 	err := client.run(stop)
 	if err != nil {
 		return client, errors.Errorf("Could not start %s client: %s", apiGroup, err)
@@ -83,37 +104,196 @@ func (c client) run(stop <-chan struct{}) error {
 		return errInitInformers
 	}
 
+	go c.egressSources.runInvalidation(stop)
 	go c.informers.egress.Run(stop)
+	go c.informers.upstreamTrafficSetting.Run(stop)
 
-	log.Info().Msgf("Waiting for %s Egress informers' cache to sync", apiGroup)
-	if !cache.WaitForCacheSync(stop, c.informers.egress.HasSynced) {
+	log.Info().Msgf("Waiting for %s Egress and UpstreamTrafficSetting informers' cache to sync", apiGroup)
+	if !cache.WaitForCacheSync(stop, c.informers.egress.HasSynced, c.informers.upstreamTrafficSetting.HasSynced) {
 		return errSyncingCaches
 	}
 
 	// Closing the cacheSynced channel signals to the rest of the system that... caches have been synced.
 	close(c.cacheSynced)
 
-	log.Info().Msgf("Cache sync finished for %s Egress informers", apiGroup)
+	log.Info().Msgf("Cache sync finished for %s Egress and UpstreamTrafficSetting informers", apiGroup)
 	return nil
 }
 
-// ListEgressPoliciesForSourceIdentity lists the Egress policies for the given source identity based on service accounts
-func (c client) ListEgressPoliciesForSourceIdentity(source identity.K8sServiceAccount) []*policyV1alpha1.Egress {
+// NewStaticController returns a Controller serving the given Egress and UpstreamTrafficSetting resources, without a
+// Kubernetes client. Resources in namespaces not monitored by the given Kubernetes controller are ignored, as with a
+// live Controller. Objects of other types are ignored.
+func NewStaticController(kubeController kubernetes.Controller, objects []runtime.Object) (Controller, error) {
+	c := client{
+		caches: &cacheCollection{
+			egress:                 cache.NewIndexer(cache.MetaNamespaceKeyFunc, egressIndexers),
+			upstreamTrafficSetting: cache.NewStore(cache.MetaNamespaceKeyFunc),
+		},
+		kubeController: kubeController,
+	}
+
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *policyV1alpha1.Egress:
+			err = c.caches.egress.Add(o)
+		case *policyV1alpha1.UpstreamTrafficSetting:
+			err = c.caches.upstreamTrafficSetting.Add(o)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error adding %T to static policy controller", obj)
+		}
+	}
+
+	return c, nil
+}
+
+// ListEgressPolicies lists the Egress policies in the monitored namespaces
+func (c client) ListEgressPolicies() []*policyV1alpha1.Egress {
 	var policies []*policyV1alpha1.Egress
 
 	for _, egressIface := range c.caches.egress.List() {
 		egressPolicy := egressIface.(*policyV1alpha1.Egress)
 
+		if c.kubeController.IsMonitoredNamespace(egressPolicy.Namespace) {
+			policies = append(policies, egressPolicy)
+		}
+	}
+
+	return policies
+}
+
+// ListEgressPoliciesForSourceIdentity lists the Egress policies for the given source identity based on service accounts,
+// either referenced directly or resolved from the pods selected by the policy
+func (c client) ListEgressPoliciesForSourceIdentity(source identity.K8sServiceAccount) []*policyV1alpha1.Egress {
+	if c.egressSources == nil {
+		return c.listEgressPoliciesForSourceIdentity(source)
+	}
+
+	policies, ok, generation := c.egressSources.get(source)
+	if ok {
+		return policies
+	}
+	policies = c.listEgressPoliciesForSourceIdentity(source)
+	c.egressSources.set(source, policies, generation)
+	return policies
+}
+
+// listEgressPoliciesForSourceIdentity lists the Egress policies for the given source identity from the indexes of the
+// Egress policies, sorted by namespace and name
+func (c client) listEgressPoliciesForSourceIdentity(source identity.K8sServiceAccount) []*policyV1alpha1.Egress {
+	// Candidate policies reference the service account, or select pods in the namespace of the service account
+	candidates := make(map[string]*policyV1alpha1.Egress)
+	for _, index := range []struct{ name, key string }{
+		{name: egressSourceServiceAccountIndex, key: source.String()},
+		{name: egressSourcePodNamespaceIndex, key: source.Namespace},
+	} {
+		objs, err := c.caches.egress.ByIndex(index.name, index.key)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing Egress policies by index %s for source identity %s", index.name, source)
+			continue
+		}
+		for _, obj := range objs {
+			egressPolicy := obj.(*policyV1alpha1.Egress)
+			candidates[egressPolicy.Namespace+"/"+egressPolicy.Name] = egressPolicy
+		}
+	}
+
+	var policies []*policyV1alpha1.Egress
+	for _, egressPolicy := range candidates {
 		if !c.kubeController.IsMonitoredNamespace(egressPolicy.Namespace) {
 			continue
 		}
 
 		for _, sourceSpec := range egressPolicy.Spec.Sources {
-			if sourceSpec.Kind == egressSourceKindSvcAccount && sourceSpec.Name == source.Name && sourceSpec.Namespace == source.Namespace {
+			if c.isEgressSource(sourceSpec, source) {
 				policies = append(policies, egressPolicy)
+				break
 			}
 		}
 	}
 
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Namespace != policies[j].Namespace {
+			return policies[i].Namespace < policies[j].Namespace
+		}
+		return policies[i].Name < policies[j].Name
+	})
 	return policies
 }
+
+// isEgressSource returns a boolean indicating if the given source spec of an Egress policy matches the given source identity.
+// Egress policies are programmed per service identity, so a source of the Pod kind only matches a service account if all
+// the pods running as the service account are selected by its label selector. A service account shared with pods that are
+// not selected does not match, since the policy would otherwise apply to these pods as well.
+func (c client) isEgressSource(sourceSpec policyV1alpha1.SourceSpec, source identity.K8sServiceAccount) bool {
+	if sourceSpec.Namespace != source.Namespace {
+		return false
+	}
+
+	switch sourceSpec.Kind {
+	case egressSourceKindSvcAccount:
+		return sourceSpec.Name == source.Name
+
+	case egressSourceKindPod:
+		// An empty or unspecified selector matches all the pods in the namespace
+		selector := labels.Everything()
+		if sourceSpec.Selector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(sourceSpec.Selector)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error parsing pod selector %v for Egress source in namespace %s", sourceSpec.Selector, sourceSpec.Namespace)
+				return false
+			}
+		}
+
+		pods := c.kubeController.ListPodsForServiceAccount(source)
+		for _, pod := range pods {
+			if !selector.Matches(labels.Set(pod.Labels)) {
+				log.Warn().Msgf("Pod %s/%s is not selected by the pod selector %v of an Egress source but shares its service account %s with selected pods, the Egress source is ignored for the service account",
+					pod.Namespace, pod.Name, sourceSpec.Selector, source)
+				return false
+			}
+		}
+		return len(pods) > 0
+
+	default:
+		return false
+	}
+}
+
+// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting whose host matches the given upstream service.
+// An UpstreamTrafficSetting only applies to a service in its own namespace.
+func (c client) GetUpstreamTrafficSetting(upstream service.MeshService) *policyV1alpha1.UpstreamTrafficSetting {
+	if !c.kubeController.IsMonitoredNamespace(upstream.Namespace) {
+		return nil
+	}
+
+	for _, settingIface := range c.caches.upstreamTrafficSetting.List() {
+		setting := settingIface.(*policyV1alpha1.UpstreamTrafficSetting)
+
+		if setting.Namespace == upstream.Namespace && setting.Spec.Host == upstream.ServerName() {
+			return setting
+		}
+	}
+
+	return nil
+}
+
+// GetUpstreamTrafficSettingForEgressHost returns the UpstreamTrafficSetting whose host matches the given external host.
+// An UpstreamTrafficSetting only applies to the hosts specified in Egress policies in its own namespace.
+func (c client) GetUpstreamTrafficSettingForEgressHost(namespace string, host string) *policyV1alpha1.UpstreamTrafficSetting {
+	if !c.kubeController.IsMonitoredNamespace(namespace) {
+		return nil
+	}
+
+	for _, settingIface := range c.caches.upstreamTrafficSetting.List() {
+		setting := settingIface.(*policyV1alpha1.UpstreamTrafficSetting)
+
+		if setting.Namespace == namespace && setting.Spec.Host == host {
+			return setting
+		}
+	}
+
+	return nil
+}
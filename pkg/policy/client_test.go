@@ -7,12 +7,15 @@ import (
 
 	"github.com/golang/mock/gomock"
 	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	fakePolicyClient "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 func TestNewPolicyClient(t *testing.T) {
@@ -23,6 +26,8 @@ func TestNewPolicyClient(t *testing.T) {
 	assert.NotNil(client)
 	assert.NotNil(client.informers.egress)
 	assert.NotNil(client.caches.egress)
+	assert.NotNil(client.informers.upstreamTrafficSetting)
+	assert.NotNil(client.caches.upstreamTrafficSetting)
 }
 
 func TestListEgressPoliciesForSourceIdentity(t *testing.T) {
@@ -159,3 +164,272 @@ func TestListEgressPoliciesForSourceIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestListEgressPoliciesForPodSelectorSource(t *testing.T) {
+	assert := tassert.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+
+	pod1 := tests.NewPodFixture("test", "pod-1", "sa-1", map[string]string{"app": "foo"})
+	pod2 := tests.NewPodFixture("test", "pod-2", "sa-2", map[string]string{"app": "bar"})
+	pod3 := tests.NewPodFixture("test", "pod-3", "sa-4", map[string]string{"app": "foo"})
+	pod4 := tests.NewPodFixture("test", "pod-4", "sa-4", map[string]string{"app": "other"})
+	mockKubeController.EXPECT().ListPodsForServiceAccount(identity.K8sServiceAccount{Name: "sa-1", Namespace: "test"}).Return([]*corev1.Pod{&pod1}).AnyTimes()
+	mockKubeController.EXPECT().ListPodsForServiceAccount(identity.K8sServiceAccount{Name: "sa-2", Namespace: "test"}).Return([]*corev1.Pod{&pod2}).AnyTimes()
+	mockKubeController.EXPECT().ListPodsForServiceAccount(identity.K8sServiceAccount{Name: "sa-3", Namespace: "test"}).Return(nil).AnyTimes()
+	mockKubeController.EXPECT().ListPodsForServiceAccount(identity.K8sServiceAccount{Name: "sa-4", Namespace: "test"}).Return([]*corev1.Pod{&pod3, &pod4}).AnyTimes()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	newEgress := func(name string, selector *metav1.LabelSelector) *policyV1alpha1.Egress {
+		return &policyV1alpha1.Egress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+			},
+			Spec: policyV1alpha1.EgressSpec{
+				Sources: []policyV1alpha1.SourceSpec{
+					{
+						Kind:      "Pod",
+						Namespace: "test",
+						Selector:  selector,
+					},
+				},
+				Hosts: []string{"foo.com"},
+				Ports: []policyV1alpha1.PortSpec{
+					{
+						Number:   80,
+						Protocol: "http",
+					},
+				},
+			},
+		}
+	}
+
+	fooEgress := newEgress("egress-foo", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}})
+	allEgress := newEgress("egress-all", nil)
+	expressionEgress := newEgress("egress-expression", &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"bar", "baz"}},
+		},
+	})
+	invalidEgress := newEgress("egress-invalid", &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: "invalid"},
+		},
+	})
+
+	fakePolicyClientSet := fakePolicyClient.NewSimpleClientset()
+	for _, egressPolicy := range []*policyV1alpha1.Egress{fooEgress, allEgress, expressionEgress, invalidEgress} {
+		_, err := fakePolicyClientSet.PolicyV1alpha1().Egresses(egressPolicy.Namespace).Create(context.TODO(), egressPolicy, metav1.CreateOptions{})
+		assert.Nil(err)
+	}
+
+	policyClient, err := newPolicyClient(fakePolicyClientSet, mockKubeController, stop)
+	assert.Nil(err)
+
+	testCases := []struct {
+		name             string
+		source           identity.K8sServiceAccount
+		expectedEgresses []*policyV1alpha1.Egress
+	}{
+		{
+			name:             "source identity of pods matching the selector labels",
+			source:           identity.K8sServiceAccount{Name: "sa-1", Namespace: "test"},
+			expectedEgresses: []*policyV1alpha1.Egress{fooEgress, allEgress},
+		},
+		{
+			name:             "source identity of pods matching the selector expressions",
+			source:           identity.K8sServiceAccount{Name: "sa-2", Namespace: "test"},
+			expectedEgresses: []*policyV1alpha1.Egress{allEgress, expressionEgress},
+		},
+		{
+			name:             "source identity without pods",
+			source:           identity.K8sServiceAccount{Name: "sa-3", Namespace: "test"},
+			expectedEgresses: nil,
+		},
+		{
+			// Only pod-3 matches the selector of egress-foo, applying it to sa-4 would grant access to pod-4 as well
+			name:             "source identity shared by pods matching and not matching the selector",
+			source:           identity.K8sServiceAccount{Name: "sa-4", Namespace: "test"},
+			expectedEgresses: []*policyV1alpha1.Egress{allEgress},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.ElementsMatch(tc.expectedEgresses, policyClient.ListEgressPoliciesForSourceIdentity(tc.source))
+		})
+	}
+}
+
+func TestGetUpstreamTrafficSetting(t *testing.T) {
+	assert := tassert.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("other").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	allSettings := []*policyV1alpha1.UpstreamTrafficSetting{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore",
+				Namespace: "test",
+			},
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host:             "bookstore.test.svc.cluster.local",
+				AdmissionControl: &policyV1alpha1.AdmissionControlSpec{},
+			},
+		},
+		{
+			// Settings do not apply to services in other namespaces
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookbuyer",
+				Namespace: "other",
+			},
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host: "bookbuyer.test.svc.cluster.local",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bookstore",
+				Namespace: "unmonitored",
+			},
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host: "bookstore.unmonitored.svc.cluster.local",
+			},
+		},
+	}
+
+	fakePolicyClientSet := fakePolicyClient.NewSimpleClientset()
+	for _, setting := range allSettings {
+		_, err := fakePolicyClientSet.PolicyV1alpha1().UpstreamTrafficSettings(setting.Namespace).Create(context.TODO(), setting, metav1.CreateOptions{})
+		assert.Nil(err)
+	}
+
+	policyClient, err := newPolicyClient(fakePolicyClientSet, mockKubeController, stop)
+	assert.Nil(err)
+
+	testCases := []struct {
+		name            string
+		upstream        service.MeshService
+		expectedSetting *policyV1alpha1.UpstreamTrafficSetting
+	}{
+		{
+			name:            "setting found for service",
+			upstream:        service.MeshService{Name: "bookstore", Namespace: "test"},
+			expectedSetting: allSettings[0],
+		},
+		{
+			name:            "setting in another namespace is ignored",
+			upstream:        service.MeshService{Name: "bookbuyer", Namespace: "test"},
+			expectedSetting: nil,
+		},
+		{
+			name:            "setting in unmonitored namespace is ignored",
+			upstream:        service.MeshService{Name: "bookstore", Namespace: "unmonitored"},
+			expectedSetting: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expectedSetting, policyClient.GetUpstreamTrafficSetting(tc.upstream))
+		})
+	}
+}
+
+func TestGetUpstreamTrafficSettingForEgressHost(t *testing.T) {
+	assert := tassert.New(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().IsMonitoredNamespace("test").Return(true).AnyTimes()
+	mockKubeController.EXPECT().IsMonitoredNamespace("unmonitored").Return(false).AnyTimes()
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	allSettings := []*policyV1alpha1.UpstreamTrafficSetting{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "httpbin",
+				Namespace: "test",
+			},
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host: "httpbin.org",
+				ConnectionSettings: &policyV1alpha1.ConnectionSettingsSpec{
+					TCP: &policyV1alpha1.TCPConnectionSettings{},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "httpbin",
+				Namespace: "unmonitored",
+			},
+			Spec: policyV1alpha1.UpstreamTrafficSettingSpec{
+				Host: "httpbin.org",
+			},
+		},
+	}
+
+	fakePolicyClientSet := fakePolicyClient.NewSimpleClientset()
+	for _, setting := range allSettings {
+		_, err := fakePolicyClientSet.PolicyV1alpha1().UpstreamTrafficSettings(setting.Namespace).Create(context.TODO(), setting, metav1.CreateOptions{})
+		assert.Nil(err)
+	}
+
+	policyClient, err := newPolicyClient(fakePolicyClientSet, mockKubeController, stop)
+	assert.Nil(err)
+
+	testCases := []struct {
+		name            string
+		namespace       string
+		host            string
+		expectedSetting *policyV1alpha1.UpstreamTrafficSetting
+	}{
+		{
+			name:            "setting found for host",
+			namespace:       "test",
+			host:            "httpbin.org",
+			expectedSetting: allSettings[0],
+		},
+		{
+			name:            "no setting for host",
+			namespace:       "test",
+			host:            "foo.com",
+			expectedSetting: nil,
+		},
+		{
+			name:            "setting in unmonitored namespace is ignored",
+			namespace:       "unmonitored",
+			host:            "httpbin.org",
+			expectedSetting: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expectedSetting, policyClient.GetUpstreamTrafficSettingForEgressHost(tc.namespace, tc.host))
+		})
+	}
+}
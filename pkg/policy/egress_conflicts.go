@@ -0,0 +1,169 @@
+package policy
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	policyV1alpha1Client "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/leader"
+)
+
+// maxEgressConflicts is the maximum number of conflicts reported in the status of an Egress policy
+const maxEgressConflicts = 100
+
+// EgressConflictReporter reports the destinations of Egress policies for which another Egress policy takes precedence
+// in the status of the Egress policies, so that conflicting policies owned by different teams are visible to them.
+// Only the leader among the replicas of osm-controller updates the status of the Egress policies.
+type EgressConflictReporter struct {
+	policyController Controller
+	kubeController   kubernetes.Controller
+	policyClient     policyV1alpha1Client.Interface
+	leader           leader.Checker
+}
+
+// NewEgressConflictReporter creates a reporter of the conflicts between Egress policies.
+func NewEgressConflictReporter(policyController Controller, kubeController kubernetes.Controller, policyClient policyV1alpha1Client.Interface, leader leader.Checker) *EgressConflictReporter {
+	return &EgressConflictReporter{
+		policyController: policyController,
+		kubeController:   kubeController,
+		policyClient:     policyClient,
+		leader:           leader,
+	}
+}
+
+// Start starts reporting the conflicts between Egress policies at the given interval.
+func (r *EgressConflictReporter) Start(reportInterval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(reportInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			r.reconcile()
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (r *EgressConflictReporter) reconcile() {
+	if !r.leader.IsLeader() {
+		return
+	}
+
+	egresses := r.policyController.ListEgressPolicies()
+	conflicts := r.getConflicts(egresses)
+
+	for _, egress := range egresses {
+		key := egressKey(egress)
+		if conflictsEqual(egress.Status.Conflicts, conflicts[key]) {
+			continue
+		}
+
+		updated := egress.DeepCopy()
+		updated.Status.Conflicts = conflicts[key]
+		if _, err := r.policyClient.PolicyV1alpha1().Egresses(egress.Namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{}); err != nil {
+			log.Error().Err(err).Msgf("Error updating the conflicts in the status of Egress policy %s", key)
+			continue
+		}
+		log.Info().Msgf("Updated the status of Egress policy %s with %d conflicts", key, len(conflicts[key]))
+	}
+}
+
+// getConflicts returns the conflicts of the given Egress policies keyed by policy, computed for each source identity
+// the policies apply to
+func (r *EgressConflictReporter) getConflicts(egresses []*policyV1alpha1.Egress) map[string][]policyV1alpha1.EgressConflict {
+	conflicts := make(map[string][]policyV1alpha1.EgressConflict)
+	reported := make(map[string]map[policyV1alpha1.EgressConflict]bool)
+
+	for _, source := range r.getSourceIdentities(egresses) {
+		precedence := ResolveEgressPrecedence(source.Namespace, r.policyController.ListEgressPoliciesForSourceIdentity(source))
+		for _, override := range precedence.Overrides {
+			key := egressKey(override.Policy)
+			conflict := policyV1alpha1.EgressConflict{
+				Host:         override.Destination.Host,
+				Port:         override.Destination.Port,
+				OverriddenBy: egressKey(override.OverriddenBy),
+				Reason:       override.Reason,
+			}
+			if reported[key] == nil {
+				reported[key] = make(map[policyV1alpha1.EgressConflict]bool)
+			}
+			if reported[key][conflict] || len(conflicts[key]) >= maxEgressConflicts {
+				continue
+			}
+			reported[key][conflict] = true
+			conflicts[key] = append(conflicts[key], conflict)
+		}
+	}
+
+	for _, policyConflicts := range conflicts {
+		sort.Slice(policyConflicts, func(i, j int) bool {
+			a, b := policyConflicts[i], policyConflicts[j]
+			if a.Host != b.Host {
+				return a.Host < b.Host
+			}
+			if a.Port != b.Port {
+				return a.Port < b.Port
+			}
+			return a.OverriddenBy < b.OverriddenBy
+		})
+	}
+
+	return conflicts
+}
+
+// getSourceIdentities returns the service accounts that may be sources of the given Egress policies. The service accounts
+// of all the pods in the namespace of a source of the Pod kind are returned, since the selector of the source is
+// applied when listing the Egress policies of a service account.
+func (r *EgressConflictReporter) getSourceIdentities(egresses []*policyV1alpha1.Egress) []identity.K8sServiceAccount {
+	identities := make(map[identity.K8sServiceAccount]bool)
+	podNamespaces := make(map[string]bool)
+
+	for _, egress := range egresses {
+		for _, sourceSpec := range egress.Spec.Sources {
+			switch sourceSpec.Kind {
+			case egressSourceKindSvcAccount:
+				identities[identity.K8sServiceAccount{Namespace: sourceSpec.Namespace, Name: sourceSpec.Name}] = true
+			case egressSourceKindPod:
+				podNamespaces[sourceSpec.Namespace] = true
+			}
+		}
+	}
+
+	if len(podNamespaces) > 0 {
+		for _, pod := range r.kubeController.ListPods() {
+			if podNamespaces[pod.Namespace] {
+				identities[identity.K8sServiceAccount{Namespace: pod.Namespace, Name: pod.Spec.ServiceAccountName}] = true
+			}
+		}
+	}
+
+	var sources []identity.K8sServiceAccount
+	for source := range identities {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].String() < sources[j].String()
+	})
+	return sources
+}
+
+func conflictsEqual(a, b []policyV1alpha1.EgressConflict) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
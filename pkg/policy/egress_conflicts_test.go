@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	fakePolicyClient "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+type fakeLeader bool
+
+func (f fakeLeader) IsLeader() bool {
+	return bool(f)
+}
+
+func TestEgressConflictReporter(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	created := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	sharedAllow := newTestEgress("shared", "allow-foo", created, "", "foo.com")
+	sharedAllow.Spec.Sources = []policyV1alpha1.SourceSpec{{Kind: "Pod", Namespace: "curl"}}
+	localDeny := newTestEgress("curl", "deny-foo", created, policyV1alpha1.EgressActionDeny, "foo.com")
+	localDeny.Spec.Sources = []policyV1alpha1.SourceSpec{{Kind: "ServiceAccount", Namespace: "curl", Name: "curl"}}
+	// A stale conflict is cleared from the status of a policy that is no longer overridden
+	localDeny.Status.Conflicts = []policyV1alpha1.EgressConflict{
+		{Host: "bar.com", Port: 443, OverriddenBy: "curl/other", Reason: OverrideReasonOlderPolicy},
+	}
+
+	curl := identity.K8sServiceAccount{Namespace: "curl", Name: "curl"}
+	other := identity.K8sServiceAccount{Namespace: "curl", Name: "other"}
+
+	mockPolicyController := NewMockController(mockCtrl)
+	mockKubeController := kubernetes.NewMockController(mockCtrl)
+	mockPolicyController.EXPECT().ListEgressPolicies().Return([]*policyV1alpha1.Egress{sharedAllow, localDeny}).AnyTimes()
+	mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(curl).Return([]*policyV1alpha1.Egress{sharedAllow, localDeny}).AnyTimes()
+	mockPolicyController.EXPECT().ListEgressPoliciesForSourceIdentity(other).Return([]*policyV1alpha1.Egress{sharedAllow}).AnyTimes()
+	mockKubeController.EXPECT().ListPods().Return([]*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "curl", Name: "curl-1"},
+			Spec:       corev1.PodSpec{ServiceAccountName: "curl"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "curl", Name: "other-1"},
+			Spec:       corev1.PodSpec{ServiceAccountName: "other"},
+		},
+	}).AnyTimes()
+
+	policyClient := fakePolicyClient.NewSimpleClientset(sharedAllow, localDeny)
+	reporter := NewEgressConflictReporter(mockPolicyController, mockKubeController, policyClient, fakeLeader(true))
+	reporter.reconcile()
+
+	// Only the status of the policies whose conflicts changed is updated
+	var updatedStatuses []string
+	for _, action := range policyClient.Actions() {
+		assert.Equal("update", action.GetVerb())
+		assert.Equal("status", action.GetSubresource())
+		updatedStatuses = append(updatedStatuses, action.GetNamespace())
+	}
+	assert.ElementsMatch([]string{"shared", "curl"}, updatedStatuses)
+
+	updated, err := policyClient.PolicyV1alpha1().Egresses("shared").Get(context.Background(), "allow-foo", metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Equal([]policyV1alpha1.EgressConflict{
+		{Host: "foo.com", Port: 443, OverriddenBy: "curl/deny-foo", Reason: OverrideReasonDenyOverride},
+	}, updated.Status.Conflicts)
+
+	updated, err = policyClient.PolicyV1alpha1().Egresses("curl").Get(context.Background(), "deny-foo", metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Empty(updated.Status.Conflicts)
+
+	// The policies in the cache are not modified
+	assert.Empty(sharedAllow.Status.Conflicts)
+
+	// The status of a policy is not updated when its conflicts are unchanged
+	policyClient.ClearActions()
+	sharedAllow.Status.Conflicts = []policyV1alpha1.EgressConflict{
+		{Host: "foo.com", Port: 443, OverriddenBy: "curl/deny-foo", Reason: OverrideReasonDenyOverride},
+	}
+	localDeny.Status.Conflicts = nil
+	reporter.reconcile()
+	assert.Empty(policyClient.Actions())
+}
+
+func TestEgressConflictReporterNotLeader(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sharedAllow := newTestEgress("shared", "allow-foo", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), "", "foo.com")
+	sharedAllow.Status.Conflicts = []policyV1alpha1.EgressConflict{
+		{Host: "foo.com", Port: 443, OverriddenBy: "curl/deny-foo", Reason: OverrideReasonDenyOverride},
+	}
+	policyClient := fakePolicyClient.NewSimpleClientset(sharedAllow)
+
+	// The replicas that are not the leader do not list the policies nor update their status
+	reporter := NewEgressConflictReporter(NewMockController(mockCtrl), kubernetes.NewMockController(mockCtrl), policyClient, fakeLeader(false))
+	reporter.reconcile()
+	assert.Empty(policyClient.Actions())
+}
@@ -0,0 +1,224 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	// OverrideReasonMoreSpecificHost is the reason an Egress policy is overridden for a host by a policy specifying
+	// the host more specifically, ex. api.example.com over *.example.com
+	OverrideReasonMoreSpecificHost = "MoreSpecificHost"
+
+	// OverrideReasonDenyOverride is the reason an Egress policy allowing a destination is overridden by a policy denying it
+	OverrideReasonDenyOverride = "DenyOverride"
+
+	// OverrideReasonNamespacePriority is the reason an Egress policy is overridden for a destination by a policy
+	// with the same action in the namespace of the source
+	OverrideReasonNamespacePriority = "NamespacePriority"
+
+	// OverrideReasonOlderPolicy is the reason an Egress policy is overridden for a destination by an older policy
+	OverrideReasonOlderPolicy = "OlderPolicy"
+)
+
+// EgressDestination is the type used to represent a host and port the traffic of a source is directed to
+type EgressDestination struct {
+	Host string
+	Port int
+}
+
+// EgressOverride is the type used to represent an Egress policy that is not applied to a destination because
+// another Egress policy takes precedence
+type EgressOverride struct {
+	// Destination is the destination the Egress policy is overridden for
+	Destination EgressDestination
+
+	// Policy is the Egress policy overridden
+	Policy *policyV1alpha1.Egress
+
+	// OverriddenBy is the Egress policy applied to the destination
+	OverriddenBy *policyV1alpha1.Egress
+
+	// Reason is the precedence rule that applied
+	Reason string
+}
+
+// EgressPrecedence is the type used to represent the Egress policy applied to each destination of a source
+// covered by multiple Egress policies
+type EgressPrecedence struct {
+	applied map[EgressDestination]*policyV1alpha1.Egress
+
+	// Overrides lists the Egress policies not applied to a destination they specify
+	Overrides []EgressOverride
+}
+
+// IsApplied returns a boolean indicating if the given Egress policy is the one applied to the given host and port.
+// All the policies are applied when the precedence is nil.
+func (p *EgressPrecedence) IsApplied(egress *policyV1alpha1.Egress, host string, port int) bool {
+	if p == nil {
+		return true
+	}
+	applied, ok := p.applied[EgressDestination{Host: host, Port: port}]
+	return !ok || applied == egress
+}
+
+// ResolveEgressPrecedence returns the Egress policy applied to each host and port specified by the given Egress policies,
+// which apply to a source in the given namespace. When multiple policies specify the same host and port, the policy
+// applied is the first one in the following order:
+// 1. Policies denying the traffic, before policies allowing it, regardless of their namespaces
+// 2. Policies in the namespace of the source, before policies in other namespaces
+// 3. Older policies, before newer policies, with ties broken by namespace and name
+// Policies specifying different hosts do not conflict since the most specific host matching the traffic is used,
+// but a policy is reported as overridden for a more specific host specified by a policy with a different action.
+func ResolveEgressPrecedence(sourceNamespace string, egresses []*policyV1alpha1.Egress) *EgressPrecedence {
+	candidates := make(map[EgressDestination][]*policyV1alpha1.Egress)
+	for _, egress := range egresses {
+		for _, port := range getEgressPorts(egress) {
+			for _, host := range egress.Spec.Hosts {
+				dest := EgressDestination{Host: host, Port: port}
+				if !containsEgress(candidates[dest], egress) {
+					candidates[dest] = append(candidates[dest], egress)
+				}
+			}
+		}
+	}
+
+	precedence := &EgressPrecedence{
+		applied: make(map[EgressDestination]*policyV1alpha1.Egress, len(candidates)),
+	}
+	for dest, policies := range candidates {
+		sort.SliceStable(policies, func(i, j int) bool {
+			return egressPrecedes(sourceNamespace, policies[i], policies[j])
+		})
+		winner := policies[0]
+		precedence.applied[dest] = winner
+
+		for _, overridden := range policies[1:] {
+			precedence.Overrides = append(precedence.Overrides, EgressOverride{
+				Destination:  dest,
+				Policy:       overridden,
+				OverriddenBy: winner,
+				Reason:       overrideReason(sourceNamespace, winner, overridden),
+			})
+		}
+	}
+
+	// Traffic to a host is handled according to the most specific host matching it, which may differ from the
+	// action of a policy specifying a wildcard host matching it
+	for dest, winner := range precedence.applied {
+		for wildcardDest, wildcardWinner := range precedence.applied {
+			if wildcardDest.Port != dest.Port || !isMoreSpecificHost(dest.Host, wildcardDest.Host) {
+				continue
+			}
+			if getEgressAction(winner) == getEgressAction(wildcardWinner) {
+				continue
+			}
+			precedence.Overrides = append(precedence.Overrides, EgressOverride{
+				Destination:  dest,
+				Policy:       wildcardWinner,
+				OverriddenBy: winner,
+				Reason:       OverrideReasonMoreSpecificHost,
+			})
+		}
+	}
+
+	sort.Slice(precedence.Overrides, func(i, j int) bool {
+		a, b := precedence.Overrides[i], precedence.Overrides[j]
+		if a.Destination.Host != b.Destination.Host {
+			return a.Destination.Host < b.Destination.Host
+		}
+		if a.Destination.Port != b.Destination.Port {
+			return a.Destination.Port < b.Destination.Port
+		}
+		return egressKey(a.Policy) < egressKey(b.Policy)
+	})
+
+	return precedence
+}
+
+// IsEgressDenied returns a boolean indicating if the given Egress policy denies the traffic it matches
+func IsEgressDenied(egress *policyV1alpha1.Egress) bool {
+	return getEgressAction(egress) == policyV1alpha1.EgressActionDeny
+}
+
+// getEgressAction returns the action of the given Egress policy, which defaults to Allow
+func getEgressAction(egress *policyV1alpha1.Egress) policyV1alpha1.EgressAction {
+	if egress.Spec.Action == policyV1alpha1.EgressActionDeny {
+		return policyV1alpha1.EgressActionDeny
+	}
+	return policyV1alpha1.EgressActionAllow
+}
+
+// egressPrecedes returns a boolean indicating if Egress policy a takes precedence over Egress policy b for a source
+// in the given namespace
+func egressPrecedes(sourceNamespace string, a, b *policyV1alpha1.Egress) bool {
+	// A policy denying a destination cannot be overridden by a policy allowing it, regardless of their namespaces
+	if aDeny, bDeny := IsEgressDenied(a), IsEgressDenied(b); aDeny != bDeny {
+		return aDeny
+	}
+	if aLocal, bLocal := a.Namespace == sourceNamespace, b.Namespace == sourceNamespace; aLocal != bLocal {
+		return aLocal
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return egressKey(a) < egressKey(b)
+}
+
+// overrideReason returns the precedence rule that applied for the given winning Egress policy to take precedence over
+// the given overridden Egress policy
+func overrideReason(sourceNamespace string, winner, overridden *policyV1alpha1.Egress) string {
+	switch {
+	case IsEgressDenied(winner) != IsEgressDenied(overridden):
+		return OverrideReasonDenyOverride
+	case (winner.Namespace == sourceNamespace) != (overridden.Namespace == sourceNamespace):
+		return OverrideReasonNamespacePriority
+	default:
+		return OverrideReasonOlderPolicy
+	}
+}
+
+// isMoreSpecificHost returns a boolean indicating if the given host is matched by the given wildcard host, ex.
+// api.example.com and *.api.example.com are more specific than *.example.com
+func isMoreSpecificHost(host, wildcardHost string) bool {
+	if !strings.HasPrefix(wildcardHost, "*.") || host == wildcardHost {
+		return false
+	}
+	return strings.HasSuffix(host, wildcardHost[1:])
+}
+
+// getEgressPorts returns the port numbers specified by the given Egress policy, where port ranges are expanded.
+// Invalid port ranges, including the ranges exceeding MaxPortRangeSize ports, are skipped as they are when the
+// policy is applied.
+func getEgressPorts(egress *policyV1alpha1.Egress) []int {
+	var ports []int
+	for _, portSpec := range egress.Spec.Ports {
+		if portSpec.EndNumber == 0 {
+			ports = append(ports, portSpec.Number)
+			continue
+		}
+		rangePorts, err := ExpandPortRange(portSpec.Number, portSpec.EndNumber)
+		if err != nil {
+			continue
+		}
+		ports = append(ports, rangePorts...)
+	}
+	return ports
+}
+
+func containsEgress(egresses []*policyV1alpha1.Egress, egress *policyV1alpha1.Egress) bool {
+	for _, e := range egresses {
+		if e == egress {
+			return true
+		}
+	}
+	return false
+}
+
+// egressKey returns the key of the given Egress policy, of the form <namespace>/<name>
+func egressKey(egress *policyV1alpha1.Egress) string {
+	return fmt.Sprintf("%s/%s", egress.Namespace, egress.Name)
+}
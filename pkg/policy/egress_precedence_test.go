@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+func newTestEgress(namespace, name string, created time.Time, action policyV1alpha1.EgressAction, hosts ...string) *policyV1alpha1.Egress {
+	return &policyV1alpha1.Egress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: policyV1alpha1.EgressSpec{
+			Action: action,
+			Hosts:  hosts,
+			Ports: []policyV1alpha1.PortSpec{
+				{
+					Number:   443,
+					Protocol: "https",
+				},
+			},
+		},
+	}
+}
+
+func TestResolveEgressPrecedence(t *testing.T) {
+	older := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	localAllow := newTestEgress("curl", "local-allow", newer, "", "foo.com")
+	localDeny := newTestEgress("curl", "local-deny", newer, policyV1alpha1.EgressActionDeny, "foo.com")
+	sharedAllow := newTestEgress("shared", "shared-allow", older, policyV1alpha1.EgressActionAllow, "foo.com")
+	sharedDeny := newTestEgress("shared", "shared-deny", newer, policyV1alpha1.EgressActionDeny, "foo.com")
+	sharedAllowNewer := newTestEgress("shared", "shared-allow-newer", newer, policyV1alpha1.EgressActionAllow, "foo.com")
+	sharedDenyWildcard := newTestEgress("shared", "shared-deny-wildcard", older, policyV1alpha1.EgressActionDeny, "*.foo.com")
+	localAllowSubdomain := newTestEgress("curl", "local-allow-subdomain", newer, "", "api.foo.com")
+
+	testCases := []struct {
+		name              string
+		egresses          []*policyV1alpha1.Egress
+		expectedApplied   *policyV1alpha1.Egress
+		expectedOverrides []EgressOverride
+	}{
+		{
+			name:            "single policy",
+			egresses:        []*policyV1alpha1.Egress{sharedAllow},
+			expectedApplied: sharedAllow,
+		},
+		{
+			name:            "policy in the namespace of the source takes precedence",
+			egresses:        []*policyV1alpha1.Egress{sharedAllow, localAllow},
+			expectedApplied: localAllow,
+			expectedOverrides: []EgressOverride{
+				{
+					Destination:  EgressDestination{Host: "foo.com", Port: 443},
+					Policy:       sharedAllow,
+					OverriddenBy: localAllow,
+					Reason:       OverrideReasonNamespacePriority,
+				},
+			},
+		},
+		{
+			name:            "deny overrides allow in the same namespace",
+			egresses:        []*policyV1alpha1.Egress{localAllow, localDeny},
+			expectedApplied: localDeny,
+			expectedOverrides: []EgressOverride{
+				{
+					Destination:  EgressDestination{Host: "foo.com", Port: 443},
+					Policy:       localAllow,
+					OverriddenBy: localDeny,
+					Reason:       OverrideReasonDenyOverride,
+				},
+			},
+		},
+		{
+			name:            "deny in another namespace overrides allow in the namespace of the source",
+			egresses:        []*policyV1alpha1.Egress{localAllow, sharedDeny},
+			expectedApplied: sharedDeny,
+			expectedOverrides: []EgressOverride{
+				{
+					Destination:  EgressDestination{Host: "foo.com", Port: 443},
+					Policy:       localAllow,
+					OverriddenBy: sharedDeny,
+					Reason:       OverrideReasonDenyOverride,
+				},
+			},
+		},
+		{
+			name:            "older policy takes precedence",
+			egresses:        []*policyV1alpha1.Egress{sharedAllowNewer, sharedAllow},
+			expectedApplied: sharedAllow,
+			expectedOverrides: []EgressOverride{
+				{
+					Destination:  EgressDestination{Host: "foo.com", Port: 443},
+					Policy:       sharedAllowNewer,
+					OverriddenBy: sharedAllow,
+					Reason:       OverrideReasonOlderPolicy,
+				},
+			},
+		},
+		{
+			name:            "more specific host takes precedence over a wildcard host",
+			egresses:        []*policyV1alpha1.Egress{sharedDenyWildcard, localAllowSubdomain, sharedAllow},
+			expectedApplied: sharedAllow,
+			expectedOverrides: []EgressOverride{
+				{
+					Destination:  EgressDestination{Host: "api.foo.com", Port: 443},
+					Policy:       sharedDenyWildcard,
+					OverriddenBy: localAllowSubdomain,
+					Reason:       OverrideReasonMoreSpecificHost,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			precedence := ResolveEgressPrecedence("curl", tc.egresses)
+			for _, egress := range tc.egresses {
+				for _, host := range egress.Spec.Hosts {
+					if host == "foo.com" {
+						assert.Equal(egress == tc.expectedApplied, precedence.IsApplied(egress, host, 443))
+					}
+				}
+			}
+			assert.Equal(tc.expectedOverrides, precedence.Overrides)
+		})
+	}
+}
+
+func TestIsMoreSpecificHost(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.True(isMoreSpecificHost("api.foo.com", "*.foo.com"))
+	assert.True(isMoreSpecificHost("*.api.foo.com", "*.foo.com"))
+	assert.False(isMoreSpecificHost("foo.com", "*.foo.com"))
+	assert.False(isMoreSpecificHost("*.foo.com", "*.foo.com"))
+	assert.False(isMoreSpecificHost("api.foo.com", "foo.com"))
+	assert.False(isMoreSpecificHost("api.barfoo.com", "*.foo.com"))
+}
+
+func TestGetEgressPorts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		ports         []policyV1alpha1.PortSpec
+		expectedPorts []int
+	}{
+		{
+			name:          "single port",
+			ports:         []policyV1alpha1.PortSpec{{Number: 443}},
+			expectedPorts: []int{443},
+		},
+		{
+			name:          "port range is expanded",
+			ports:         []policyV1alpha1.PortSpec{{Number: 8000, EndNumber: 8002}},
+			expectedPorts: []int{8000, 8001, 8002},
+		},
+		{
+			name:          "port range exceeding the maximum size is skipped",
+			ports:         []policyV1alpha1.PortSpec{{Number: 1, EndNumber: 65535}, {Number: 443}},
+			expectedPorts: []int{443},
+		},
+		{
+			name:          "inverted port range is skipped",
+			ports:         []policyV1alpha1.PortSpec{{Number: 8002, EndNumber: 8000}},
+			expectedPorts: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			egress := &policyV1alpha1.Egress{Spec: policyV1alpha1.EgressSpec{Ports: tc.ports}}
+			assert.Equal(tc.expectedPorts, getEgressPorts(egress))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package policy
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxPortNumber is the largest valid port number
+	maxPortNumber = 65535
+
+	// MaxPortRangeSize is the maximum number of ports a single port range can expand to.
+	// Every port in a range results in a filter chain match or RBAC permission, so the size of a range is bounded.
+	MaxPortRangeSize = 1000
+)
+
+// ExpandPortRange returns the ports in the inclusive range [start, end]
+func ExpandPortRange(start, end int) ([]int, error) {
+	if start <= 0 || start > maxPortNumber || end <= 0 || end > maxPortNumber {
+		return nil, errors.Errorf("Invalid port range %d-%d, ports must be between 1 and %d", start, end, maxPortNumber)
+	}
+	if end < start {
+		return nil, errors.Errorf("Invalid port range %d-%d, end port must not be smaller than start port", start, end)
+	}
+	if end-start+1 > MaxPortRangeSize {
+		return nil, errors.Errorf("Invalid port range %d-%d, a range cannot exceed %d ports", start, end, MaxPortRangeSize)
+	}
+
+	ports := make([]int, 0, end-start+1)
+	for port := start; port <= end; port++ {
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
 )
 
 var (
@@ -16,12 +17,14 @@ var (
 
 // informerCollection is the type used to represent the collection of informers for the policy.openservicemesh.io API group
 type informerCollection struct {
-	egress cache.SharedIndexInformer
+	egress                 cache.SharedIndexInformer
+	upstreamTrafficSetting cache.SharedIndexInformer
 }
 
 // cacheCollection is the type used to represent the collection of caches for the policy.openservicemesh.io API group
 type cacheCollection struct {
-	egress cache.Store
+	egress                 cache.Indexer
+	upstreamTrafficSetting cache.Store
 }
 
 // client is the type used to represent the Kubernetes client for the policy.openservicemesh.io API group
@@ -30,10 +33,23 @@ type client struct {
 	caches         *cacheCollection
 	cacheSynced    chan interface{}
 	kubeController kubernetes.Controller
+
+	// egressSources caches the Egress policies of source identities, nil if they are not cached
+	egressSources *egressSourceCache
 }
 
 // Controller is the interface for the functionality provided by the resources part of the policy.openservicemesh.io API group
 type Controller interface {
+	// ListEgressPolicies lists the Egress policies in the monitored namespaces
+	ListEgressPolicies() []*policyV1alpha1.Egress
+
 	// ListEgressPoliciesForSourceIdentity lists the Egress policies for the given source identity
 	ListEgressPoliciesForSourceIdentity(identity.K8sServiceAccount) []*policyV1alpha1.Egress
+
+	// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting for the given upstream service, or nil if there is none
+	GetUpstreamTrafficSetting(service.MeshService) *policyV1alpha1.UpstreamTrafficSetting
+
+	// GetUpstreamTrafficSettingForEgressHost returns the UpstreamTrafficSetting in the given namespace for the given
+	// external host, or nil if there is none
+	GetUpstreamTrafficSettingForEgressHost(namespace string, host string) *policyV1alpha1.UpstreamTrafficSetting
 }
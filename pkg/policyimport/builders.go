@@ -0,0 +1,118 @@
+package policyimport
+
+import (
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	httpRouteGroupKind  = "HTTPRouteGroup"
+	tcpRouteKind        = "TCPRoute"
+	egressSourceKindPod = "Pod"
+)
+
+// newEgress returns an Egress policy from the pods of the given namespace matching the given selector
+func newEgress(name, namespace string, selector *metav1.LabelSelector, hosts, ipAddresses []string, ports []policyV1alpha1.PortSpec) *policyV1alpha1.Egress {
+	return &policyV1alpha1.Egress{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyV1alpha1.SchemeGroupVersion.String(),
+			Kind:       "Egress",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: policyV1alpha1.EgressSpec{
+			Sources: []policyV1alpha1.SourceSpec{
+				{
+					Kind:      egressSourceKindPod,
+					Namespace: namespace,
+					Selector:  selector,
+				},
+			},
+			Hosts:       hosts,
+			IPAddresses: ipAddresses,
+			Ports:       ports,
+		},
+	}
+}
+
+// newTrafficTarget returns a TrafficTarget from the given sources to the given service account, for the given route
+func newTrafficTarget(name, namespace, destination string, sources []smiAccess.IdentityBindingSubject, routeKind, routeName string) *smiAccess.TrafficTarget {
+	return &smiAccess.TrafficTarget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: smiAccess.SchemeGroupVersion.String(),
+			Kind:       "TrafficTarget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: smiAccess.TrafficTargetSpec{
+			Destination: smiAccess.IdentityBindingSubject{
+				Kind:      identity.KubernetesServiceAccountKind,
+				Namespace: namespace,
+				Name:      destination,
+			},
+			Sources: sources,
+			Rules: []smiAccess.TrafficTargetRule{
+				{
+					Kind: routeKind,
+					Name: routeName,
+				},
+			},
+		},
+	}
+}
+
+// newHTTPRouteGroup returns an HTTPRouteGroup with the given matches
+func newHTTPRouteGroup(name, namespace string, matches []smiSpecs.HTTPMatch) *smiSpecs.HTTPRouteGroup {
+	return &smiSpecs.HTTPRouteGroup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: smiSpecs.SchemeGroupVersion.String(),
+			Kind:       httpRouteGroupKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: smiSpecs.HTTPRouteGroupSpec{
+			Matches: matches,
+		},
+	}
+}
+
+// newHTTPMatch returns an HTTP route match for the given path regex and methods, all the methods if none are given
+func newHTTPMatch(name, pathRegex string, methods []string) smiSpecs.HTTPMatch {
+	if len(methods) == 0 {
+		methods = []string{string(smiSpecs.HTTPRouteMethodAll)}
+	}
+	return smiSpecs.HTTPMatch{
+		Name:      name,
+		PathRegex: pathRegex,
+		Methods:   methods,
+	}
+}
+
+// newTCPRoute returns a TCPRoute matching the given ports, all the ports if none are given
+func newTCPRoute(name, namespace string, ports []int) *smiSpecs.TCPRoute {
+	return &smiSpecs.TCPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: smiSpecs.SchemeGroupVersion.String(),
+			Kind:       tcpRouteKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: smiSpecs.TCPRouteSpec{
+			Matches: smiSpecs.TCPMatch{
+				Ports: ports,
+			},
+		},
+	}
+}
@@ -0,0 +1,223 @@
+package policyimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	istioNetworkingGroup = "networking.istio.io"
+	istioSecurityGroup   = "security.istio.io"
+
+	defaultServiceAccount = "default"
+)
+
+var deserializer = serializer.NewCodecFactory(clientgoscheme.Scheme).UniversalDeserializer()
+
+// importer accumulates the resources of the imported manifests and the result of their conversion
+type importer struct {
+	serviceEntries        []serviceEntry
+	virtualServices       []virtualService
+	authorizationPolicies []authorizationPolicy
+	networkPolicies       []*networkingv1.NetworkPolicy
+	workloads             []workload
+
+	result Result
+}
+
+// Import converts the Istio ServiceEntry, VirtualService and AuthorizationPolicy resources, and the Kubernetes
+// NetworkPolicy resources of the given YAML or JSON manifests into SMI and OSM policies. The manifests can be separated
+// by '---' and include lists of resources. The Pods and the Deployments, StatefulSets, DaemonSets, ReplicaSets and Jobs
+// of the manifests are used to resolve the service accounts of the pods selected by the converted resources.
+// Resources of other kinds are ignored.
+func Import(r io.Reader) (*Result, error) {
+	imp := &importer{}
+
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading manifest")
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		if err := imp.add(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, se := range imp.serviceEntries {
+		imp.importServiceEntry(se)
+	}
+	for _, vs := range imp.virtualServices {
+		imp.importVirtualService(vs)
+	}
+	for _, ap := range imp.authorizationPolicies {
+		imp.importAuthorizationPolicy(ap)
+	}
+	for _, np := range imp.networkPolicies {
+		imp.importNetworkPolicy(np)
+	}
+
+	return &imp.result, nil
+}
+
+// add adds the resource in the given manifest, or the resources of the list in the manifest, to the imported resources
+func (imp *importer) add(doc []byte) error {
+	jsonDoc, err := yamlutil.ToJSON(doc)
+	if err != nil {
+		return errors.Wrap(err, "Error converting manifest to JSON")
+	}
+	if string(bytes.TrimSpace(jsonDoc)) == "null" {
+		// A document made of comments only
+		return nil
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(jsonDoc, &typeMeta); err != nil {
+		return errors.Wrap(err, "Error decoding manifest")
+	}
+	gvk := typeMeta.GroupVersionKind()
+
+	switch gvk.Group {
+	case istioNetworkingGroup, istioSecurityGroup:
+		return imp.addIstioResource(gvk, jsonDoc)
+	}
+
+	obj, _, err := deserializer.Decode(jsonDoc, nil, nil)
+	if runtime.IsNotRegisteredError(err) {
+		log.Debug().Msgf("Ignoring resource of unknown kind %s", gvk)
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "Error decoding manifest")
+	}
+
+	switch o := obj.(type) {
+	case *corev1.List:
+		for _, item := range o.Items {
+			if err := imp.add(item.Raw); err != nil {
+				return err
+			}
+		}
+	case *networkingv1.NetworkPolicy:
+		imp.networkPolicies = append(imp.networkPolicies, o)
+	case *corev1.Pod:
+		imp.addWorkload(o.Namespace, o.Labels, o.Spec.ServiceAccountName)
+	case *appsv1.Deployment:
+		imp.addWorkload(o.Namespace, o.Spec.Template.Labels, o.Spec.Template.Spec.ServiceAccountName)
+	case *appsv1.StatefulSet:
+		imp.addWorkload(o.Namespace, o.Spec.Template.Labels, o.Spec.Template.Spec.ServiceAccountName)
+	case *appsv1.DaemonSet:
+		imp.addWorkload(o.Namespace, o.Spec.Template.Labels, o.Spec.Template.Spec.ServiceAccountName)
+	case *appsv1.ReplicaSet:
+		imp.addWorkload(o.Namespace, o.Spec.Template.Labels, o.Spec.Template.Spec.ServiceAccountName)
+	case *batchv1.Job:
+		imp.addWorkload(o.Namespace, o.Spec.Template.Labels, o.Spec.Template.Spec.ServiceAccountName)
+	}
+
+	return nil
+}
+
+// addIstioResource adds the Istio resource of the given kind in the given JSON manifest to the imported resources
+func (imp *importer) addIstioResource(gvk schema.GroupVersionKind, jsonDoc []byte) error {
+	var err error
+	switch gvk.Kind {
+	case "ServiceEntry":
+		var se serviceEntry
+		if err = json.Unmarshal(jsonDoc, &se); err == nil {
+			imp.serviceEntries = append(imp.serviceEntries, se)
+		}
+	case "VirtualService":
+		var vs virtualService
+		if err = json.Unmarshal(jsonDoc, &vs); err == nil {
+			imp.virtualServices = append(imp.virtualServices, vs)
+		}
+	case "AuthorizationPolicy":
+		var ap authorizationPolicy
+		if err = json.Unmarshal(jsonDoc, &ap); err == nil {
+			imp.authorizationPolicies = append(imp.authorizationPolicies, ap)
+		}
+	default:
+		var meta struct {
+			Metadata istioObjectMeta `json:"metadata"`
+		}
+		if err = json.Unmarshal(jsonDoc, &meta); err == nil {
+			imp.report(resourceName(gvk.Kind, meta.Metadata.Namespace, meta.Metadata.Name), "Istio resources of this kind are not supported")
+		}
+	}
+
+	return errors.Wrapf(err, "Error decoding Istio %s", gvk.Kind)
+}
+
+// addWorkload adds the pods of the given namespace with the given labels and service account to the imported workloads
+func (imp *importer) addWorkload(namespace string, podLabels map[string]string, serviceAccount string) {
+	if serviceAccount == "" {
+		serviceAccount = defaultServiceAccount
+	}
+	imp.workloads = append(imp.workloads, workload{
+		namespace:      namespaceOrDefault(namespace),
+		labels:         podLabels,
+		serviceAccount: serviceAccount,
+	})
+}
+
+// serviceAccountsForSelector returns the sorted service accounts of the imported workloads in the given namespace
+// matching the given selector
+func (imp *importer) serviceAccountsForSelector(namespace string, selector labels.Selector) []string {
+	found := make(map[string]bool)
+	for _, w := range imp.workloads {
+		if w.namespace == namespace && selector.Matches(w.labels) {
+			found[w.serviceAccount] = true
+		}
+	}
+
+	var serviceAccounts []string
+	for sa := range found {
+		serviceAccounts = append(serviceAccounts, sa)
+	}
+	sort.Strings(serviceAccounts)
+	return serviceAccounts
+}
+
+// report records an issue with the given resource
+func (imp *importer) report(resource string, format string, args ...interface{}) {
+	imp.result.Issues = append(imp.result.Issues, Issue{
+		Resource: resource,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// resourceName returns the name of the given resource used in issues
+func resourceName(kind, namespace, name string) string {
+	return fmt.Sprintf("%s %s/%s", kind, namespaceOrDefault(namespace), name)
+}
+
+// namespaceOrDefault returns the given namespace, or the default namespace if empty
+func namespaceOrDefault(namespace string) string {
+	if namespace == "" {
+		return metav1.NamespaceDefault
+	}
+	return namespace
+}
@@ -0,0 +1,369 @@
+package policyimport
+
+import (
+	"strings"
+	"testing"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const workloadsManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  template:
+    metadata:
+      labels:
+        app: bookstore
+    spec:
+      serviceAccountName: bookstore
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: bookbuyer
+  namespace: bookstore
+  labels:
+    app: bookbuyer
+spec:
+  serviceAccountName: bookbuyer
+`
+
+func TestImport(t *testing.T) {
+	testCases := []struct {
+		name            string
+		manifest        string
+		expectedObjects []interface{}
+		expectedIssues  []string
+	}{
+		{
+			name: "ServiceEntry",
+			manifest: `
+apiVersion: networking.istio.io/v1beta1
+kind: ServiceEntry
+metadata:
+  name: httpbin
+  namespace: curl
+spec:
+  hosts:
+  - httpbin.org
+  exportTo:
+  - "."
+  ports:
+  - number: 443
+    name: https
+    protocol: TLS
+  - number: 27017
+    name: mongo
+    protocol: MONGO
+  - number: 53
+    name: dns
+    protocol: UDP
+`,
+			expectedObjects: []interface{}{
+				newEgress("httpbin", "curl", &metav1.LabelSelector{}, []string{"httpbin.org"}, nil, []policyV1alpha1.PortSpec{
+					{Number: 443, Protocol: constants.ProtocolHTTPS},
+					{Number: 27017, Protocol: constants.ProtocolTCP},
+				}),
+			},
+			expectedIssues: []string{
+				`ServiceEntry curl/httpbin: port 53 with protocol "UDP" has no equivalent and is not converted`,
+			},
+		},
+		{
+			name: "ServiceEntry exported to all namespaces and internal ServiceEntry",
+			manifest: `
+apiVersion: networking.istio.io/v1beta1
+kind: ServiceEntry
+metadata:
+  name: httpbin
+  namespace: curl
+spec:
+  hosts:
+  - httpbin.org
+  ports:
+  - number: 80
+    protocol: HTTP
+---
+apiVersion: networking.istio.io/v1beta1
+kind: ServiceEntry
+metadata:
+  name: vm
+  namespace: curl
+spec:
+  hosts:
+  - vm.internal
+  location: MESH_INTERNAL
+  ports:
+  - number: 80
+    protocol: HTTP
+`,
+			expectedObjects: []interface{}{
+				newEgress("httpbin", "curl", &metav1.LabelSelector{}, []string{"httpbin.org"}, nil, []policyV1alpha1.PortSpec{
+					{Number: 80, Protocol: constants.ProtocolHTTP},
+				}),
+			},
+			expectedIssues: []string{
+				"ServiceEntry curl/httpbin: the ServiceEntry is exported to other namespaces, the Egress policy only applies to the pods in namespace curl: add the sources of other namespaces to the policy",
+				"ServiceEntry curl/vm: location MESH_INTERNAL has no equivalent, services in the mesh are discovered from Kubernetes services",
+			},
+		},
+		{
+			name: "VirtualService with weighted destinations",
+			manifest: `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  hosts:
+  - bookstore.bookstore.svc.cluster.local
+  http:
+  - route:
+    - destination:
+        host: bookstore-v1
+      weight: 90
+    - destination:
+        host: bookstore-v2.bookstore
+      weight: 10
+`,
+			expectedObjects: []interface{}{
+				&smiSplit.TrafficSplit{
+					TypeMeta:   metav1.TypeMeta{APIVersion: "split.smi-spec.io/v1alpha2", Kind: "TrafficSplit"},
+					ObjectMeta: metav1.ObjectMeta{Name: "bookstore", Namespace: "bookstore"},
+					Spec: smiSplit.TrafficSplitSpec{
+						Service: "bookstore",
+						Backends: []smiSplit.TrafficSplitBackend{
+							{Service: "bookstore-v1", Weight: 90},
+							{Service: "bookstore-v2", Weight: 10},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "VirtualService with unsupported routes",
+			manifest: `
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  hosts:
+  - bookstore
+  http:
+  - match:
+    - uri:
+        prefix: /books
+    retries:
+      attempts: 3
+    route:
+    - destination:
+        host: bookstore-v1
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: VirtualService
+metadata:
+  name: external
+  namespace: bookstore
+spec:
+  hosts:
+  - httpbin.org
+  http:
+  - route:
+    - destination:
+        host: httpbin.org
+`,
+			expectedIssues: []string{
+				"VirtualService bookstore/bookstore: match, retries of HTTP routes have no equivalent",
+				"VirtualService bookstore/external: host httpbin.org is not a service in namespace bookstore",
+			},
+		},
+		{
+			name: "AuthorizationPolicy",
+			manifest: workloadsManifest + `
+---
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  selector:
+    matchLabels:
+      app: bookstore
+  rules:
+  - from:
+    - source:
+        principals:
+        - cluster.local/ns/bookstore/sa/bookbuyer
+    to:
+    - operation:
+        methods:
+        - GET
+        paths:
+        - /books/*
+        - /health
+  - from:
+    - source:
+        namespaces:
+        - bookthief
+`,
+			expectedObjects: []interface{}{
+				newHTTPRouteGroup("bookstore-0", "bookstore", []smiSpecs.HTTPMatch{
+					{Name: "operation-0-0", PathRegex: "/books/.*", Methods: []string{"GET"}},
+					{Name: "operation-0-1", PathRegex: "/health", Methods: []string{"GET"}},
+				}),
+				newTrafficTarget("bookstore-0-bookstore", "bookstore", "bookstore", []smiAccess.IdentityBindingSubject{
+					{Kind: "ServiceAccount", Namespace: "bookstore", Name: "bookbuyer"},
+				}, httpRouteGroupKind, "bookstore-0"),
+			},
+			expectedIssues: []string{
+				"AuthorizationPolicy bookstore/bookstore: rule 1: sources other than principals have no equivalent, the rule is not converted",
+			},
+		},
+		{
+			name: "AuthorizationPolicy without selected workloads or with a DENY action",
+			manifest: `
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  rules:
+  - from:
+    - source:
+        principals:
+        - cluster.local/ns/bookstore/sa/bookbuyer
+---
+apiVersion: security.istio.io/v1beta1
+kind: AuthorizationPolicy
+metadata:
+  name: deny
+  namespace: bookstore
+spec:
+  action: DENY
+`,
+			expectedIssues: []string{
+				"AuthorizationPolicy bookstore/bookstore: no workload selected by the policy found in the manifests, include the Deployments or Pods the policy applies to",
+				"AuthorizationPolicy bookstore/deny: action DENY has no equivalent, only ALLOW policies can be converted",
+			},
+		},
+		{
+			name: "NetworkPolicy",
+			manifest: workloadsManifest + `
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  podSelector:
+    matchLabels:
+      app: bookstore
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels:
+          app: bookbuyer
+    ports:
+    - port: 14001
+    - port: metrics
+  - from:
+    - ipBlock:
+        cidr: 10.0.0.0/8
+  egress:
+  - to:
+    - ipBlock:
+        cidr: 1.2.3.0/24
+    ports:
+    - port: 5432
+`,
+			expectedObjects: []interface{}{
+				func() interface{} {
+					route := newTCPRoute("bookstore-ingress-0", "bookstore", []int{14001})
+					route.Annotations = map[string]string{constants.TCPRouteNamedPortsAnnotation: "metrics"}
+					return route
+				}(),
+				newTrafficTarget("bookstore-ingress-0-bookstore", "bookstore", "bookstore", []smiAccess.IdentityBindingSubject{
+					{Kind: "ServiceAccount", Namespace: "bookstore", Name: "bookbuyer"},
+				}, tcpRouteKind, "bookstore-ingress-0"),
+				newEgress("bookstore-egress-0", "bookstore", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bookstore"}}, nil, []string{"1.2.3.0/24"}, []policyV1alpha1.PortSpec{
+					{Number: 5432, Protocol: constants.ProtocolTCP},
+				}),
+			},
+			expectedIssues: []string{
+				"NetworkPolicy bookstore/bookstore: ingress rule 1: sources other than pods of namespace bookstore have no equivalent, the rule is not converted",
+			},
+		},
+		{
+			name: "unknown kinds",
+			manifest: `
+apiVersion: networking.istio.io/v1beta1
+kind: Gateway
+metadata:
+  name: ingress
+  namespace: istio-system
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+`,
+			expectedIssues: []string{
+				"Gateway istio-system/ingress: Istio resources of this kind are not supported",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			result, err := Import(strings.NewReader(tc.manifest))
+			assert.NoError(err)
+
+			var objects []interface{}
+			for _, obj := range result.Objects {
+				objects = append(objects, obj)
+			}
+			assert.Equal(tc.expectedObjects, objects)
+
+			var issues []string
+			for _, issue := range result.Issues {
+				issues = append(issues, issue.String())
+			}
+			assert.Equal(tc.expectedIssues, issues)
+		})
+	}
+}
+
+func TestImportInvalidManifest(t *testing.T) {
+	assert := tassert.New(t)
+
+	_, err := Import(strings.NewReader("apiVersion: networking.istio.io/v1beta1\nkind: ServiceEntry\nspec: [\n"))
+	assert.Error(err)
+}
+
+func TestIstioPathToRegex(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal(".*", istioPathToRegex("*"))
+	assert.Equal("/books/.*", istioPathToRegex("/books/*"))
+	assert.Equal(".*\\.js", istioPathToRegex("*.js"))
+	assert.Equal("/books\\?id=1", istioPathToRegex("/books?id=1"))
+}
@@ -0,0 +1,322 @@
+package policyimport
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	// istioRootNamespace is the default root namespace of Istio, whose AuthorizationPolicies apply to the whole mesh
+	istioRootNamespace = "istio-system"
+
+	istioLocationMeshInternal = "MESH_INTERNAL"
+	istioActionAllow          = "ALLOW"
+	istioMeshGateway          = "mesh"
+	istioExportToNamespace    = "."
+
+	// allowAllPathRegex is the path regex of the HTTP route matching all the requests
+	allowAllPathRegex = ".*"
+)
+
+// istioPrincipalRegex matches the principals of Istio workloads, of the form <trust domain>/ns/<namespace>/sa/<service account>
+var istioPrincipalRegex = regexp.MustCompile(`^[^/*]+/ns/([^/*]+)/sa/([^/*]+)$`)
+
+// istioPortProtocols maps the protocols of the ports of Istio ServiceEntries to the protocols of Egress policies
+var istioPortProtocols = map[string]string{
+	"HTTP":  constants.ProtocolHTTP,
+	"HTTP2": constants.ProtocolHTTP,
+	"GRPC":  constants.ProtocolHTTP,
+	"HTTPS": constants.ProtocolHTTPS,
+	"TLS":   constants.ProtocolHTTPS,
+	"TCP":   constants.ProtocolTCP,
+	"MONGO": constants.ProtocolTCP,
+	"MYSQL": constants.ProtocolTCP,
+	"REDIS": constants.ProtocolTCP,
+}
+
+// importServiceEntry converts the given ServiceEntry describing external hosts into an Egress policy
+func (imp *importer) importServiceEntry(se serviceEntry) {
+	namespace := namespaceOrDefault(se.Metadata.Namespace)
+	resource := resourceName("ServiceEntry", namespace, se.Metadata.Name)
+
+	if strings.EqualFold(se.Spec.Location, istioLocationMeshInternal) {
+		imp.report(resource, "location MESH_INTERNAL has no equivalent, services in the mesh are discovered from Kubernetes services")
+		return
+	}
+	if len(se.Spec.Endpoints) > 0 || se.Spec.WorkloadSelector != nil {
+		imp.report(resource, "endpoints and workloadSelector have no equivalent, the hosts are resolved with DNS")
+		return
+	}
+
+	var ports []policyV1alpha1.PortSpec
+	for _, port := range se.Spec.Ports {
+		protocol, ok := istioPortProtocols[strings.ToUpper(port.Protocol)]
+		if !ok {
+			imp.report(resource, "port %d with protocol %q has no equivalent and is not converted", port.Number, port.Protocol)
+			continue
+		}
+		ports = append(ports, policyV1alpha1.PortSpec{Number: port.Number, Protocol: protocol})
+	}
+	if len(ports) == 0 {
+		imp.report(resource, "no port can be converted")
+		return
+	}
+
+	if len(se.Spec.ExportTo) != 1 || se.Spec.ExportTo[0] != istioExportToNamespace {
+		imp.report(resource, "the ServiceEntry is exported to other namespaces, the Egress policy only applies to the pods in namespace %s: add the sources of other namespaces to the policy", namespace)
+	}
+
+	imp.result.Objects = append(imp.result.Objects, newEgress(se.Metadata.Name, namespace, &metav1.LabelSelector{}, se.Spec.Hosts, se.Spec.Addresses, ports))
+}
+
+// importVirtualService converts the weighted routes of the given VirtualService into a TrafficSplit. Only a
+// VirtualService routing all the HTTP requests to a service in the mesh to several services of the same namespace
+// can be converted.
+func (imp *importer) importVirtualService(vs virtualService) {
+	namespace := namespaceOrDefault(vs.Metadata.Namespace)
+	resource := resourceName("VirtualService", namespace, vs.Metadata.Name)
+
+	for _, gateway := range vs.Spec.Gateways {
+		if gateway != istioMeshGateway {
+			imp.report(resource, "routes of gateway %s have no equivalent, ingress traffic is routed by Kubernetes Ingress resources", gateway)
+			return
+		}
+	}
+	if len(vs.Spec.TCP) > 0 || len(vs.Spec.TLS) > 0 {
+		imp.report(resource, "TCP and TLS routes have no equivalent")
+		return
+	}
+	if len(vs.Spec.Hosts) != 1 {
+		imp.report(resource, "only VirtualServices with a single host can be converted")
+		return
+	}
+	rootService, ok := parseServiceHost(vs.Spec.Hosts[0], namespace)
+	if !ok {
+		imp.report(resource, "host %s is not a service in namespace %s", vs.Spec.Hosts[0], namespace)
+		return
+	}
+	if len(vs.Spec.HTTP) != 1 {
+		imp.report(resource, "only VirtualServices with a single HTTP route can be converted")
+		return
+	}
+
+	route := vs.Spec.HTTP[0]
+	if unsupported := route.unsupportedFields(); len(unsupported) > 0 {
+		imp.report(resource, "%s of HTTP routes have no equivalent", strings.Join(unsupported, ", "))
+		return
+	}
+	if len(route.Route) < 2 {
+		// Requests are routed to a single service without any policy, which is the default behavior of OSM
+		return
+	}
+
+	var backends []smiSplit.TrafficSplitBackend
+	for _, dst := range route.Route {
+		if dst.Destination.Subset != "" || dst.Destination.Port != nil || dst.Headers != nil {
+			imp.report(resource, "subset, port and headers of route destinations have no equivalent")
+			return
+		}
+		backend, ok := parseServiceHost(dst.Destination.Host, namespace)
+		if !ok {
+			imp.report(resource, "destination %s is not a service in namespace %s", dst.Destination.Host, namespace)
+			return
+		}
+		backends = append(backends, smiSplit.TrafficSplitBackend{Service: backend, Weight: dst.Weight})
+	}
+
+	imp.result.Objects = append(imp.result.Objects, &smiSplit.TrafficSplit{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: smiSplit.SchemeGroupVersion.String(),
+			Kind:       "TrafficSplit",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vs.Metadata.Name,
+			Namespace: namespace,
+		},
+		Spec: smiSplit.TrafficSplitSpec{
+			Service:  rootService,
+			Backends: backends,
+		},
+	})
+}
+
+// unsupportedFields returns the fields of the HTTP route that have no equivalent in a TrafficSplit
+func (r httpRoute) unsupportedFields() []string {
+	var unsupported []string
+	for field, set := range map[string]bool{
+		"match":      len(r.Match) > 0,
+		"redirect":   r.Redirect != nil,
+		"rewrite":    r.Rewrite != nil,
+		"timeout":    r.Timeout != "",
+		"retries":    r.Retries != nil,
+		"fault":      r.Fault != nil,
+		"mirror":     r.Mirror != nil || r.MirrorPct != nil,
+		"corsPolicy": r.CorsPolicy != nil,
+		"headers":    r.Headers != nil,
+		"delegate":   r.Delegate != nil,
+	} {
+		if set {
+			unsupported = append(unsupported, field)
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported
+}
+
+// parseServiceHost returns the name of the service of the given namespace designated by the given host, of the form
+// <service>, <service>.<namespace>, <service>.<namespace>.svc or <service>.<namespace>.svc.cluster.local
+func parseServiceHost(host, namespace string) (string, bool) {
+	chunks := strings.Split(strings.TrimSuffix(host, ".svc.cluster.local"), ".")
+	switch {
+	case len(chunks) == 1:
+		return chunks[0], chunks[0] != "*"
+	case len(chunks) == 2 && chunks[1] == namespace:
+		return chunks[0], true
+	case len(chunks) == 3 && chunks[1] == namespace && chunks[2] == "svc":
+		return chunks[0], true
+	default:
+		return "", false
+	}
+}
+
+// importAuthorizationPolicy converts each rule of the given ALLOW AuthorizationPolicy into TrafficTargets from the
+// service accounts of the rule to the service accounts of the workloads selected by the policy, with an HTTPRouteGroup
+// matching the operations of the rule
+func (imp *importer) importAuthorizationPolicy(ap authorizationPolicy) {
+	namespace := namespaceOrDefault(ap.Metadata.Namespace)
+	resource := resourceName("AuthorizationPolicy", namespace, ap.Metadata.Name)
+
+	if ap.Spec.Action != "" && !strings.EqualFold(ap.Spec.Action, istioActionAllow) {
+		imp.report(resource, "action %s has no equivalent, only ALLOW policies can be converted", ap.Spec.Action)
+		return
+	}
+	if namespace == istioRootNamespace {
+		imp.report(resource, "mesh-wide policies of the Istio root namespace have no equivalent")
+		return
+	}
+
+	var selector labels.Selector = labels.Everything()
+	if ap.Spec.Selector != nil {
+		selector = labels.SelectorFromSet(ap.Spec.Selector.MatchLabels)
+	}
+	destinations := imp.serviceAccountsForSelector(namespace, selector)
+	if len(destinations) == 0 {
+		imp.report(resource, "no workload selected by the policy found in the manifests, include the Deployments or Pods the policy applies to")
+		return
+	}
+
+	if len(ap.Spec.Rules) == 0 {
+		// An ALLOW policy without rules denies all the requests, which is the default behavior of OSM without TrafficTargets
+		return
+	}
+
+	for i, rule := range ap.Spec.Rules {
+		sources, ok := imp.authorizationRuleSources(resource, i, rule)
+		if !ok {
+			continue
+		}
+		matches, ok := imp.authorizationRuleMatches(resource, i, rule)
+		if !ok {
+			continue
+		}
+
+		name := fmt.Sprintf("%s-%d", ap.Metadata.Name, i)
+		imp.result.Objects = append(imp.result.Objects, newHTTPRouteGroup(name, namespace, matches))
+		for _, dst := range destinations {
+			imp.result.Objects = append(imp.result.Objects, newTrafficTarget(fmt.Sprintf("%s-%s", name, dst), namespace, dst, sources, httpRouteGroupKind, name))
+		}
+	}
+}
+
+// authorizationRuleSources returns the service accounts the given rule allows requests from
+func (imp *importer) authorizationRuleSources(resource string, index int, rule authorizationRule) ([]smiAccess.IdentityBindingSubject, bool) {
+	if len(rule.When) > 0 {
+		imp.report(resource, "rule %d: conditions have no equivalent, the rule is not converted", index)
+		return nil, false
+	}
+	if len(rule.From) == 0 {
+		imp.report(resource, "rule %d: requests from any source have no equivalent, the rule is not converted", index)
+		return nil, false
+	}
+
+	var sources []smiAccess.IdentityBindingSubject
+	for _, from := range rule.From {
+		src := from.Source
+		if len(src.NotPrincipals)+len(src.RequestPrincipals)+len(src.NotRequestPrincipals)+len(src.Namespaces)+len(src.NotNamespaces)+
+			len(src.IPBlocks)+len(src.NotIPBlocks)+len(src.RemoteIPBlocks)+len(src.NotRemoteIPBlocks) > 0 {
+			imp.report(resource, "rule %d: sources other than principals have no equivalent, the rule is not converted", index)
+			return nil, false
+		}
+		for _, principal := range src.Principals {
+			match := istioPrincipalRegex.FindStringSubmatch(principal)
+			if match == nil {
+				imp.report(resource, "rule %d: principal %q is not a service account, the rule is not converted", index, principal)
+				return nil, false
+			}
+			sources = append(sources, smiAccess.IdentityBindingSubject{
+				Kind:      identity.KubernetesServiceAccountKind,
+				Namespace: match[1],
+				Name:      match[2],
+			})
+		}
+	}
+	if len(sources) == 0 {
+		imp.report(resource, "rule %d: requests from any principal have no equivalent, the rule is not converted", index)
+		return nil, false
+	}
+
+	return sources, true
+}
+
+// authorizationRuleMatches returns the HTTP route matches of the operations the given rule allows
+func (imp *importer) authorizationRuleMatches(resource string, index int, rule authorizationRule) ([]smiSpecs.HTTPMatch, bool) {
+	if len(rule.To) == 0 {
+		return []smiSpecs.HTTPMatch{newHTTPMatch("all", allowAllPathRegex, nil)}, true
+	}
+
+	var matches []smiSpecs.HTTPMatch
+	for i, to := range rule.To {
+		op := to.Operation
+		if len(op.Hosts)+len(op.NotHosts)+len(op.Ports)+len(op.NotPorts)+len(op.NotMethods)+len(op.NotPaths) > 0 {
+			imp.report(resource, "rule %d: operations other than paths and methods have no equivalent, the rule is not converted", index)
+			return nil, false
+		}
+
+		if len(op.Paths) == 0 {
+			matches = append(matches, newHTTPMatch(fmt.Sprintf("operation-%d", i), allowAllPathRegex, op.Methods))
+			continue
+		}
+		for j, path := range op.Paths {
+			matches = append(matches, newHTTPMatch(fmt.Sprintf("operation-%d-%d", i, j), istioPathToRegex(path), op.Methods))
+		}
+	}
+
+	return matches, true
+}
+
+// istioPathToRegex returns the regex matching the given Istio path, which is an exact path, a prefix of the form
+// <prefix>*, a suffix of the form *<suffix>, or '*'
+func istioPathToRegex(path string) string {
+	switch {
+	case path == "*":
+		return allowAllPathRegex
+	case strings.HasSuffix(path, "*"):
+		return regexp.QuoteMeta(strings.TrimSuffix(path, "*")) + ".*"
+	case strings.HasPrefix(path, "*"):
+		return ".*" + regexp.QuoteMeta(strings.TrimPrefix(path, "*"))
+	default:
+		return regexp.QuoteMeta(path)
+	}
+}
@@ -0,0 +1,159 @@
+package policyimport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// importNetworkPolicy converts the ingress rules of the given NetworkPolicy into TrafficTargets from the service
+// accounts of the pods of the rules to the service accounts of the pods selected by the policy, and its egress rules
+// to IP blocks into Egress policies
+func (imp *importer) importNetworkPolicy(np *networkingv1.NetworkPolicy) {
+	namespace := namespaceOrDefault(np.Namespace)
+	resource := resourceName("NetworkPolicy", namespace, np.Name)
+
+	selector, err := metav1.LabelSelectorAsSelector(&np.Spec.PodSelector)
+	if err != nil {
+		imp.report(resource, "invalid pod selector: %s", err)
+		return
+	}
+
+	if len(np.Spec.Ingress) > 0 {
+		destinations := imp.serviceAccountsForSelector(namespace, selector)
+		if len(destinations) == 0 {
+			imp.report(resource, "no pod selected by the policy found in the manifests, include the Deployments or Pods the policy applies to")
+		} else {
+			for i, rule := range np.Spec.Ingress {
+				imp.importNetworkPolicyIngressRule(np, resource, i, rule, destinations)
+			}
+		}
+	}
+
+	for i, rule := range np.Spec.Egress {
+		imp.importNetworkPolicyEgressRule(np, resource, i, rule)
+	}
+}
+
+// importNetworkPolicyIngressRule converts the given ingress rule into TrafficTargets to the given service accounts
+func (imp *importer) importNetworkPolicyIngressRule(np *networkingv1.NetworkPolicy, resource string, index int, rule networkingv1.NetworkPolicyIngressRule, destinations []string) {
+	namespace := namespaceOrDefault(np.Namespace)
+
+	if len(rule.From) == 0 {
+		imp.report(resource, "ingress rule %d: traffic from any source has no equivalent, the rule is not converted", index)
+		return
+	}
+
+	sourceAccounts := make(map[string]bool)
+	for _, peer := range rule.From {
+		if peer.IPBlock != nil || peer.NamespaceSelector != nil || peer.PodSelector == nil {
+			imp.report(resource, "ingress rule %d: sources other than pods of namespace %s have no equivalent, the rule is not converted", index, namespace)
+			return
+		}
+		selector, err := metav1.LabelSelectorAsSelector(peer.PodSelector)
+		if err != nil {
+			imp.report(resource, "ingress rule %d: invalid pod selector: %s", index, err)
+			return
+		}
+		for _, sa := range imp.serviceAccountsForSelector(namespace, selector) {
+			sourceAccounts[sa] = true
+		}
+	}
+	if len(sourceAccounts) == 0 {
+		imp.report(resource, "ingress rule %d: no source pod found in the manifests, include the Deployments or Pods of the sources", index)
+		return
+	}
+
+	var sources []smiAccess.IdentityBindingSubject
+	for sa := range sourceAccounts {
+		sources = append(sources, smiAccess.IdentityBindingSubject{Kind: identity.KubernetesServiceAccountKind, Namespace: namespace, Name: sa})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+
+	// A rule without ports, or with a TCP port without a number, matches all the ports
+	allPorts := len(rule.Ports) == 0
+	var ports []int
+	var namedPorts []string
+	for _, port := range rule.Ports {
+		if port.Protocol != nil && *port.Protocol != corev1.ProtocolTCP {
+			imp.report(resource, "ingress rule %d: %s ports have no equivalent and are not converted", index, *port.Protocol)
+			continue
+		}
+		switch {
+		case port.Port == nil:
+			allPorts = true
+		case port.Port.Type == intstr.String:
+			namedPorts = append(namedPorts, port.Port.StrVal)
+		default:
+			ports = append(ports, port.Port.IntValue())
+		}
+	}
+	if allPorts {
+		ports, namedPorts = nil, nil
+	} else if len(ports) == 0 && len(namedPorts) == 0 {
+		imp.report(resource, "ingress rule %d: no port can be converted, the rule is not converted", index)
+		return
+	}
+
+	name := fmt.Sprintf("%s-ingress-%d", np.Name, index)
+	tcpRoute := newTCPRoute(name, namespace, ports)
+	if len(namedPorts) > 0 {
+		tcpRoute.Annotations = map[string]string{constants.TCPRouteNamedPortsAnnotation: strings.Join(namedPorts, ",")}
+	}
+	imp.result.Objects = append(imp.result.Objects, tcpRoute)
+	for _, dst := range destinations {
+		imp.result.Objects = append(imp.result.Objects, newTrafficTarget(fmt.Sprintf("%s-%s", name, dst), namespace, dst, sources, tcpRouteKind, name))
+	}
+}
+
+// importNetworkPolicyEgressRule converts the given egress rule to IP blocks into an Egress policy from the pods
+// selected by the NetworkPolicy
+func (imp *importer) importNetworkPolicyEgressRule(np *networkingv1.NetworkPolicy, resource string, index int, rule networkingv1.NetworkPolicyEgressRule) {
+	namespace := namespaceOrDefault(np.Namespace)
+
+	if len(rule.To) == 0 {
+		imp.report(resource, "egress rule %d: traffic to any destination has no equivalent, the rule is not converted", index)
+		return
+	}
+
+	var ipAddresses []string
+	for _, peer := range rule.To {
+		if peer.IPBlock == nil {
+			imp.report(resource, "egress rule %d: traffic to pods is allowed by the TrafficTargets of the destinations, the rule is not converted", index)
+			return
+		}
+		if len(peer.IPBlock.Except) > 0 {
+			imp.report(resource, "egress rule %d: IP block exceptions have no equivalent, the rule is not converted", index)
+			return
+		}
+		ipAddresses = append(ipAddresses, peer.IPBlock.CIDR)
+	}
+
+	var ports []policyV1alpha1.PortSpec
+	for _, port := range rule.Ports {
+		switch {
+		case port.Protocol != nil && *port.Protocol != corev1.ProtocolTCP:
+			imp.report(resource, "egress rule %d: %s ports have no equivalent and are not converted", index, *port.Protocol)
+		case port.Port == nil || port.Port.Type == intstr.String:
+			imp.report(resource, "egress rule %d: ports without a number have no equivalent and are not converted", index)
+		default:
+			ports = append(ports, policyV1alpha1.PortSpec{Number: port.Port.IntValue(), Protocol: constants.ProtocolTCP})
+		}
+	}
+	if len(ports) == 0 {
+		imp.report(resource, "egress rule %d: Egress policies require ports, the rule is not converted", index)
+		return
+	}
+
+	imp.result.Objects = append(imp.result.Objects, newEgress(fmt.Sprintf("%s-egress-%d", np.Name, index), namespace, np.Spec.PodSelector.DeepCopy(), nil, ipAddresses, ports))
+}
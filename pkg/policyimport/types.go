@@ -0,0 +1,171 @@
+// Package policyimport converts Istio and Kubernetes NetworkPolicy resources into equivalent SMI and OSM policies,
+// to ease the migration of a mesh onto OSM. Constructs without an equivalent are reported instead of converted.
+package policyimport
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("policyimport")
+
+// Result is the result of the conversion of a set of manifests
+type Result struct {
+	// Objects are the SMI and OSM resources equivalent to the converted resources
+	Objects []runtime.Object
+
+	// Issues are the constructs of the converted resources that could not be converted
+	Issues []Issue
+}
+
+// Issue is a construct of a converted resource that could not be converted
+type Issue struct {
+	// Resource is the converted resource, of the form <kind> <namespace>/<name>
+	Resource string
+
+	// Message describes the construct and why it could not be converted
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Resource, i.Message)
+}
+
+// workload is a set of pods declared in the imported manifests, used to resolve the service accounts of the pods
+// selected by label selectors
+type workload struct {
+	namespace      string
+	labels         labels.Set
+	serviceAccount string
+}
+
+// istioObjectMeta is the metadata of an Istio resource
+type istioObjectMeta struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// serviceEntry is the subset of the Istio ServiceEntry resource (networking.istio.io) known to the importer
+type serviceEntry struct {
+	Metadata istioObjectMeta  `json:"metadata"`
+	Spec     serviceEntrySpec `json:"spec"`
+}
+
+type serviceEntrySpec struct {
+	Hosts            []string             `json:"hosts"`
+	Addresses        []string             `json:"addresses"`
+	Ports            []serviceEntryPort   `json:"ports"`
+	Location         string               `json:"location"`
+	Endpoints        []interface{}        `json:"endpoints"`
+	WorkloadSelector *istioWorkloadSelect `json:"workloadSelector"`
+	ExportTo         []string             `json:"exportTo"`
+}
+
+type serviceEntryPort struct {
+	Number   int    `json:"number"`
+	Protocol string `json:"protocol"`
+	Name     string `json:"name"`
+}
+
+type istioWorkloadSelect struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// virtualService is the subset of the Istio VirtualService resource (networking.istio.io) known to the importer
+type virtualService struct {
+	Metadata istioObjectMeta    `json:"metadata"`
+	Spec     virtualServiceSpec `json:"spec"`
+}
+
+type virtualServiceSpec struct {
+	Hosts    []string      `json:"hosts"`
+	Gateways []string      `json:"gateways"`
+	HTTP     []httpRoute   `json:"http"`
+	TCP      []interface{} `json:"tcp"`
+	TLS      []interface{} `json:"tls"`
+}
+
+type httpRoute struct {
+	Match      []interface{}          `json:"match"`
+	Route      []httpRouteDestination `json:"route"`
+	Redirect   interface{}            `json:"redirect"`
+	Rewrite    interface{}            `json:"rewrite"`
+	Timeout    string                 `json:"timeout"`
+	Retries    interface{}            `json:"retries"`
+	Fault      interface{}            `json:"fault"`
+	Mirror     interface{}            `json:"mirror"`
+	CorsPolicy interface{}            `json:"corsPolicy"`
+	Headers    interface{}            `json:"headers"`
+	Delegate   interface{}            `json:"delegate"`
+	MirrorPct  interface{}            `json:"mirrorPercentage"`
+}
+
+type httpRouteDestination struct {
+	Destination destination `json:"destination"`
+	Weight      int         `json:"weight"`
+	Headers     interface{} `json:"headers"`
+}
+
+type destination struct {
+	Host   string      `json:"host"`
+	Subset string      `json:"subset"`
+	Port   interface{} `json:"port"`
+}
+
+// authorizationPolicy is the subset of the Istio AuthorizationPolicy resource (security.istio.io) known to the importer
+type authorizationPolicy struct {
+	Metadata istioObjectMeta         `json:"metadata"`
+	Spec     authorizationPolicySpec `json:"spec"`
+}
+
+type authorizationPolicySpec struct {
+	Selector *istioWorkloadSelectorMatch `json:"selector"`
+	Action   string                      `json:"action"`
+	Rules    []authorizationRule         `json:"rules"`
+}
+
+type istioWorkloadSelectorMatch struct {
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
+type authorizationRule struct {
+	From []authorizationFrom `json:"from"`
+	To   []authorizationTo   `json:"to"`
+	When []interface{}       `json:"when"`
+}
+
+type authorizationFrom struct {
+	Source authorizationSource `json:"source"`
+}
+
+type authorizationSource struct {
+	Principals           []string `json:"principals"`
+	NotPrincipals        []string `json:"notPrincipals"`
+	RequestPrincipals    []string `json:"requestPrincipals"`
+	NotRequestPrincipals []string `json:"notRequestPrincipals"`
+	Namespaces           []string `json:"namespaces"`
+	NotNamespaces        []string `json:"notNamespaces"`
+	IPBlocks             []string `json:"ipBlocks"`
+	NotIPBlocks          []string `json:"notIpBlocks"`
+	RemoteIPBlocks       []string `json:"remoteIpBlocks"`
+	NotRemoteIPBlocks    []string `json:"notRemoteIpBlocks"`
+}
+
+type authorizationTo struct {
+	Operation authorizationOperation `json:"operation"`
+}
+
+type authorizationOperation struct {
+	Hosts      []string `json:"hosts"`
+	NotHosts   []string `json:"notHosts"`
+	Ports      []string `json:"ports"`
+	NotPorts   []string `json:"notPorts"`
+	Methods    []string `json:"methods"`
+	NotMethods []string `json:"notMethods"`
+	Paths      []string `json:"paths"`
+	NotPaths   []string `json:"notPaths"`
+}
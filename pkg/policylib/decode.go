@@ -0,0 +1,93 @@
+package policylib
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+var (
+	scheme       = runtime.NewScheme()
+	deserializer = serializer.NewCodecFactory(scheme).UniversalDeserializer()
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(smiAccess.AddToScheme(scheme))
+	utilruntime.Must(smiSpecs.AddToScheme(scheme))
+	utilruntime.Must(smiSplit.AddToScheme(scheme))
+	utilruntime.Must(policyV1alpha1.AddToScheme(scheme))
+}
+
+// DecodeObjects decodes the Kubernetes resources of the given YAML or JSON manifests, which can be separated by '---'
+// and include lists of resources, such as the output of 'kubectl get -o yaml'
+func DecodeObjects(r io.Reader) ([]runtime.Object, error) {
+	var objects []runtime.Object
+
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(r))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "Error reading manifest")
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		decoded, err := decodeObject(doc)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+
+	return objects, nil
+}
+
+// decodeObject decodes the Kubernetes resource in the given manifest, or the resources of the list in the manifest
+func decodeObject(doc []byte) ([]runtime.Object, error) {
+	jsonDoc, err := yamlutil.ToJSON(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error converting manifest to JSON")
+	}
+	if string(bytes.TrimSpace(jsonDoc)) == "null" {
+		// A document made of comments only
+		return nil, nil
+	}
+
+	obj, _, err := deserializer.Decode(jsonDoc, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decoding manifest")
+	}
+
+	list, ok := obj.(*corev1.List)
+	if !ok {
+		return []runtime.Object{obj}, nil
+	}
+
+	var objects []runtime.Object
+	for _, item := range list.Items {
+		decoded, err := decodeObject(item.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
@@ -0,0 +1,158 @@
+package policylib
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/endpoint/providers/kube"
+	"github.com/openservicemesh/osm/pkg/identity"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/policy"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+// Compute returns the traffic policies of the service identities of the mesh described by the given inputs, which are
+// the identities of the service accounts and pods in the monitored namespaces, sorted by identity
+func Compute(inputs Inputs) ([]IdentityPolicies, error) {
+	m, err := newMesh(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []IdentityPolicies
+	for _, si := range listServiceIdentities(m.kubeController) {
+		identityPolicies, err := m.computeForIdentity(si)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, *identityPolicies)
+	}
+	return policies, nil
+}
+
+// ComputeForIdentity returns the traffic policies of the given service identity in the mesh described by the given inputs
+func ComputeForIdentity(inputs Inputs, si identity.ServiceIdentity) (*IdentityPolicies, error) {
+	m, err := newMesh(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return m.computeForIdentity(si)
+}
+
+// mesh is the static mesh described by a set of inputs
+type mesh struct {
+	kubeController k8s.Controller
+	kubeProvider   endpoint.Provider
+	meshCatalog    *catalog.MeshCatalog
+}
+
+func (m *mesh) computeForIdentity(si identity.ServiceIdentity) (*IdentityPolicies, error) {
+	meshCatalog := m.meshCatalog
+
+	// The proxies of an identity without services only originate traffic
+	services, err := m.kubeProvider.GetServicesForServiceAccount(si.ToK8sServiceAccount())
+	if err != nil && !errors.Is(err, kube.ErrServiceNotFound) {
+		return nil, errors.Wrapf(err, "Error listing services for identity %s", si)
+	}
+	sortServices(services)
+
+	egress, err := meshCatalog.GetEgressTrafficPolicy(si)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error computing egress traffic policy for identity %s", si)
+	}
+
+	allowedOutboundServices := meshCatalog.ListAllowedOutboundServicesForIdentity(si)
+	sortServices(allowedOutboundServices)
+
+	return &IdentityPolicies{
+		Identity:                si,
+		Services:                services,
+		Inbound:                 meshCatalog.ListInboundTrafficPolicies(si, services),
+		Outbound:                meshCatalog.ListOutboundTrafficPolicies(si),
+		AllowedOutboundServices: allowedOutboundServices,
+		Egress:                  egress,
+	}, nil
+}
+
+// newMesh returns the static mesh described by the given inputs
+func newMesh(inputs Inputs) (*mesh, error) {
+	kubeController, err := k8s.NewStaticController(inputs.MeshName, inputs.Objects)
+	if err != nil {
+		return nil, err
+	}
+	meshSpec, err := smi.NewStaticMeshSpec(kubeController, inputs.Objects)
+	if err != nil {
+		return nil, err
+	}
+	policyController, err := policy.NewStaticController(kubeController, inputs.Objects)
+	if err != nil {
+		return nil, err
+	}
+
+	var configMap *corev1.ConfigMap
+	for _, obj := range inputs.Objects {
+		if cm, ok := obj.(*corev1.ConfigMap); ok && cm.Name == constants.OSMConfigMap {
+			configMap = cm
+			break
+		}
+	}
+	cfg, err := configurator.NewStaticConfigurator(configMap)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeProvider, err := kube.NewProvider(nil, kubeController, constants.KubeProviderName, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	now := inputs.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	log.Debug().Msgf("Computing traffic policies of mesh %s from %d objects", inputs.MeshName, len(inputs.Objects))
+	return &mesh{
+		kubeController: kubeController,
+		kubeProvider:   kubeProvider,
+		meshCatalog:    catalog.NewStaticMeshCatalog(kubeController, meshSpec, policyController, inputs.IdentityProviders, inputs.Features, now, cfg, kubeProvider),
+	}, nil
+}
+
+// listServiceIdentities returns the sorted identities of the service accounts and pods in the monitored namespaces
+func listServiceIdentities(kubeController k8s.Controller) []identity.ServiceIdentity {
+	identities := make(map[identity.ServiceIdentity]bool)
+	for _, sa := range kubeController.ListServiceAccounts() {
+		identities[identity.K8sServiceAccount{Namespace: sa.Namespace, Name: sa.Name}.ToServiceIdentity()] = true
+	}
+	for _, pod := range kubeController.ListPods() {
+		svcAccount := pod.Spec.ServiceAccountName
+		if svcAccount == "" {
+			svcAccount = "default"
+		}
+		identities[identity.K8sServiceAccount{Namespace: pod.Namespace, Name: svcAccount}.ToServiceIdentity()] = true
+	}
+
+	var sorted []identity.ServiceIdentity
+	for si := range identities {
+		sorted = append(sorted, si)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+func sortServices(services []service.MeshService) {
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].String() < services[j].String()
+	})
+}
@@ -0,0 +1,253 @@
+package policylib
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const testManifest = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: bookstore
+  labels:
+    openservicemesh.io/monitored-by: osm
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: unmonitored
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: osm-config
+  namespace: osm-system
+data:
+  permissive_traffic_policy_mode: "false"
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ServiceAccount
+  metadata:
+    name: bookbuyer
+    namespace: bookstore
+- apiVersion: v1
+  kind: ServiceAccount
+  metadata:
+    name: bookstore
+    namespace: bookstore
+- apiVersion: v1
+  kind: ServiceAccount
+  metadata:
+    name: other
+    namespace: unmonitored
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  selector:
+    app: bookstore
+  ports:
+  - name: http
+    port: 14001
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: bookstore-1
+  namespace: bookstore
+  labels:
+    app: bookstore
+spec:
+  serviceAccountName: bookstore
+  containers:
+  - name: bookstore
+    image: bookstore
+---
+apiVersion: specs.smi-spec.io/v1alpha4
+kind: HTTPRouteGroup
+metadata:
+  name: bookstore-routes
+  namespace: bookstore
+spec:
+  matches:
+  - name: buy-a-book
+    pathRegex: /buy
+    methods:
+    - GET
+---
+apiVersion: access.smi-spec.io/v1alpha3
+kind: TrafficTarget
+metadata:
+  name: bookstore
+  namespace: bookstore
+spec:
+  destination:
+    kind: ServiceAccount
+    name: bookstore
+    namespace: bookstore
+  rules:
+  - kind: HTTPRouteGroup
+    name: bookstore-routes
+    matches:
+    - buy-a-book
+  sources:
+  - kind: ServiceAccount
+    name: bookbuyer
+    namespace: bookstore
+`
+
+func TestDecodeObjects(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, err := DecodeObjects(strings.NewReader(testManifest))
+	assert.Nil(err)
+	assert.Len(objects, 10)
+
+	_, err = DecodeObjects(strings.NewReader("apiVersion: v1\nkind: Unknown\n"))
+	assert.NotNil(err)
+}
+
+func TestCompute(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, err := DecodeObjects(strings.NewReader(testManifest))
+	assert.Nil(err)
+
+	policies, err := Compute(Inputs{MeshName: "osm", Objects: objects})
+	assert.Nil(err)
+
+	// The service account of the unmonitored namespace is not part of the mesh
+	assert.Len(policies, 2)
+	bookbuyer, bookstore := policies[0], policies[1]
+	assert.Equal(identity.ServiceIdentity("bookbuyer.bookstore.cluster.local"), bookbuyer.Identity)
+	assert.Equal(identity.ServiceIdentity("bookstore.bookstore.cluster.local"), bookstore.Identity)
+
+	// The bookbuyer is allowed to send requests to the bookstore on the routes of the traffic target
+	assert.Empty(bookbuyer.Services)
+	assert.Len(bookbuyer.AllowedOutboundServices, 1)
+	assert.Equal("bookstore/bookstore", bookbuyer.AllowedOutboundServices[0].String())
+	assert.Len(bookbuyer.Outbound, 1)
+
+	assert.Len(bookstore.Services, 1)
+	assert.Empty(bookstore.AllowedOutboundServices)
+	assert.Len(bookstore.Inbound, 1)
+	assert.Len(bookstore.Inbound[0].Rules, 1)
+	assert.Equal("/buy", bookstore.Inbound[0].Rules[0].Route.HTTPRouteMatch.Path)
+	assert.Nil(bookstore.Egress)
+}
+
+func TestComputeForIdentity(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, err := DecodeObjects(strings.NewReader(testManifest))
+	assert.Nil(err)
+
+	policies, err := ComputeForIdentity(Inputs{MeshName: "osm", Objects: objects}, "bookstore.bookstore.cluster.local")
+	assert.Nil(err)
+	assert.Equal("bookstore/bookstore", policies.Services[0].String())
+
+	// Services are unknown to identities outside the mesh
+	policies, err = ComputeForIdentity(Inputs{MeshName: "other", Objects: objects}, "bookbuyer.bookstore.cluster.local")
+	assert.Nil(err)
+	assert.Empty(policies.AllowedOutboundServices)
+}
+
+const testEgressManifest = `
+apiVersion: policy.openservicemesh.io/v1alpha1
+kind: Egress
+metadata:
+  name: backup-window
+  namespace: bookstore
+spec:
+  sources:
+  - kind: ServiceAccount
+    name: bookbuyer
+    namespace: bookstore
+  hosts:
+  - backup.vendor.com
+  ports:
+  - number: 443
+    protocol: https
+  validity:
+    notBefore: "2021-06-01T00:00:00Z"
+    notAfter: "2021-07-01T00:00:00Z"
+`
+
+func TestComputeFeaturesAndTime(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, err := DecodeObjects(strings.NewReader(testManifest + "---" + testEgressManifest))
+	assert.Nil(err)
+
+	testCases := []struct {
+		name                string
+		features            featureflags.OptionalFeatures
+		now                 time.Time
+		expectEgressPolicy  bool
+		expectEgressMatches int
+	}{
+		{
+			name:               "egress policy feature disabled",
+			now:                time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC),
+			expectEgressPolicy: false,
+		},
+		{
+			name:                "egress policy within its validity window",
+			features:            featureflags.OptionalFeatures{EgressPolicy: true},
+			now:                 time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC),
+			expectEgressPolicy:  true,
+			expectEgressMatches: 1,
+		},
+		{
+			name:                "egress policy after its validity window",
+			features:            featureflags.OptionalFeatures{EgressPolicy: true},
+			now:                 time.Date(2021, 7, 15, 0, 0, 0, 0, time.UTC),
+			expectEgressPolicy:  true,
+			expectEgressMatches: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			inputs := Inputs{MeshName: "osm", Objects: objects, Features: tc.features, Now: tc.now}
+			policies, err := ComputeForIdentity(inputs, "bookbuyer.bookstore.cluster.local")
+			assert.Nil(err)
+			if !tc.expectEgressPolicy {
+				assert.Nil(policies.Egress)
+				return
+			}
+			assert.NotNil(policies.Egress)
+			assert.Len(policies.Egress.TrafficMatches, tc.expectEgressMatches)
+		})
+	}
+}
+
+func TestComputeDefaultsToCurrentTime(t *testing.T) {
+	assert := tassert.New(t)
+
+	// The Egress policy is valid from a date in the past, with no end
+	egressManifest := strings.Replace(testEgressManifest, "    notAfter: \"2021-07-01T00:00:00Z\"\n", "", 1)
+	objects, err := DecodeObjects(strings.NewReader(testManifest + "---" + egressManifest))
+	assert.Nil(err)
+
+	inputs := Inputs{MeshName: "osm", Objects: objects, Features: featureflags.OptionalFeatures{EgressPolicy: true}}
+	policies, err := ComputeForIdentity(inputs, "bookbuyer.bookstore.cluster.local")
+	assert.Nil(err)
+	assert.NotNil(policies.Egress)
+	assert.Len(policies.Egress.TrafficMatches, 1)
+}
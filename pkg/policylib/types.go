@@ -0,0 +1,60 @@
+// Package policylib computes the traffic policies of a mesh from declarative inputs, without live Kubernetes clients.
+// It is meant to be consumed as a library by offline validation tools, and to unit test sets of user policies.
+package policylib
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/openservicemesh/osm/pkg/featureflags"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+var log = logger.New("policylib")
+
+// Inputs are the declarative inputs the traffic policies of a mesh are computed from
+type Inputs struct {
+	// MeshName is the name of the mesh. Only the namespaces labeled as monitored by the mesh are part of the mesh.
+	MeshName string
+
+	// Objects are the Kubernetes resources of the mesh: Namespaces, Services, ServiceAccounts, Pods, EndpointSlices,
+	// TLS Secrets, SMI resources, and Egress and UpstreamTrafficSetting policies. The ConfigMap named osm-config, if any,
+	// configures the mesh. All the given Pods are considered part of the mesh. Objects of other types are ignored.
+	Objects []runtime.Object
+
+	// IdentityProviders resolve the sources of SMI TrafficTarget policies that are not Kubernetes service accounts
+	IdentityProviders []identity.Provider
+
+	// Features are the optional features enabled for the mesh, the feature flags of the process are not used
+	Features featureflags.OptionalFeatures
+
+	// Now is the time at which the validity windows of TrafficTargets and Egress policies are evaluated, the current
+	// time if not set
+	Now time.Time
+}
+
+// IdentityPolicies are the traffic policies of the proxies of a service identity
+type IdentityPolicies struct {
+	// Identity is the service identity of the proxies
+	Identity identity.ServiceIdentity
+
+	// Services are the services of the proxies
+	Services []service.MeshService
+
+	// Inbound are the policies of the traffic directed to the services of the proxies
+	Inbound []*trafficpolicy.InboundTrafficPolicy
+
+	// Outbound are the policies of the traffic originating from the proxies
+	Outbound []*trafficpolicy.OutboundTrafficPolicy
+
+	// AllowedOutboundServices are the services the proxies are allowed to connect to
+	AllowedOutboundServices []service.MeshService
+
+	// Egress is the policy of the traffic originating from the proxies directed outside the mesh, nil when the
+	// Egress policy feature is disabled in the inputs
+	Egress *trafficpolicy.EgressTrafficPolicy
+}
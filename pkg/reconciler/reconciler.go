@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/diagnostics"
 	"github.com/openservicemesh/osm/pkg/injector"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
@@ -38,6 +40,9 @@ func (r *MutatingWebhookConfigurationReconciler) Reconcile(req ctrl.Request) (ct
 
 		if err := r.Get(ctx, req.NamespacedName, instance); err != nil {
 			log.Error().Err(err).Msgf("Error reading object %s ", req.NamespacedName)
+			if !apierrors.IsNotFound(err) {
+				diagnostics.Record(diagnostics.ErrResourceReconcile, errors.Errorf("Error reading MutatingWebhookConfiguration %s: %s", req.Name, err))
+			}
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 
@@ -51,7 +56,9 @@ func (r *MutatingWebhookConfigurationReconciler) Reconcile(req ctrl.Request) (ct
 					shouldUpdate = true
 					webhookHandlerCert, err := providers.GetCertFromKubernetes(r.OsmNamespace, constants.WebhookCertificateSecretName, r.KubeClient)
 					if err != nil {
-						return ctrl.Result{}, errors.Errorf("Error fetching injector webhook certificate from k8s secret: %s", err)
+						err = errors.Errorf("Error fetching injector webhook certificate from k8s secret: %s", err)
+						diagnostics.Record(diagnostics.ErrResourceReconcile, err)
+						return ctrl.Result{}, err
 					}
 					instance.Webhooks[idx].ClientConfig.CABundle = webhookHandlerCert.GetCertificateChain()
 				}
@@ -65,6 +72,7 @@ func (r *MutatingWebhookConfigurationReconciler) Reconcile(req ctrl.Request) (ct
 
 		if err := r.Update(ctx, instance); err != nil {
 			log.Error().Err(err).Msgf("Error updating MutatingWebhookConfiguration %s", req.Name)
+			diagnostics.Record(diagnostics.ErrResourceReconcile, errors.Errorf("Error updating MutatingWebhookConfiguration %s: %s", req.Name, err))
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 
@@ -0,0 +1,73 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	prometheusQueryTimeout = 10 * time.Second
+
+	// errorRateQuery computes the percentage of requests to the given cluster that failed with a 5xx response code.
+	// The requests are reported by the Envoy proxies of the downstream clients, whose cluster for the upstream service
+	// is named after the service.
+	errorRateQuery = `100 * sum(rate(envoy_cluster_upstream_rq_xx{envoy_response_code_class="5",envoy_cluster_name="%[1]s"}[%[2]s])) / sum(rate(envoy_cluster_upstream_rq_xx{envoy_cluster_name="%[1]s"}[%[2]s]))`
+)
+
+// prometheusMetricsProvider retrieves the metrics used to analyze a rollout from Prometheus
+type prometheusMetricsProvider struct {
+	api promv1.API
+}
+
+// NewPrometheusMetricsProvider creates a MetricsProvider that queries the Prometheus server at the given address.
+func NewPrometheusMetricsProvider(address string) (MetricsProvider, error) {
+	client, err := api.NewClient(api.Config{Address: address})
+	if err != nil {
+		log.Error().Err(err).Msgf("Error creating Prometheus client for address %s", address)
+		return nil, err
+	}
+
+	return &prometheusMetricsProvider{
+		api: promv1.NewAPI(client),
+	}, nil
+}
+
+// GetErrorRate returns the percentage of requests to the given service that failed over the given window.
+func (p *prometheusMetricsProvider) GetErrorRate(svc service.MeshService, window time.Duration) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), prometheusQueryTimeout)
+	defer cancel()
+
+	query := getErrorRateQuery(svc, window)
+	result, warnings, err := p.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	for _, warning := range warnings {
+		log.Warn().Msgf("Warning for Prometheus query %s: %s", query, warning)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return 0, errors.Errorf("Unexpected result type %s for Prometheus query %s", result.Type(), query)
+	}
+
+	// No requests were sent to the service over the window
+	if len(vector) == 0 || math.IsNaN(float64(vector[0].Value)) {
+		return 0, nil
+	}
+
+	return float64(vector[0].Value), nil
+}
+
+func getErrorRateQuery(svc service.MeshService, window time.Duration) string {
+	return fmt.Sprintf(errorRateQuery, svc.String(), model.Duration(window).String())
+}
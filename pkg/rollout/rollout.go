@@ -0,0 +1,252 @@
+package rollout
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	smiSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/leader"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+const (
+	// maxWeight is the total weight of the backends of a TrafficSplit managed by the controller,
+	// so that the weight of a backend is the percentage of traffic it receives.
+	maxWeight = 100
+
+	defaultStepWeight   = 10
+	defaultStepInterval = 1 * time.Minute
+	defaultMaxErrorRate = 5.0
+)
+
+// NewController creates a new progressive delivery controller.
+// Rollouts are only progressed by the replica of osm-controller that is the leader, so that steps are applied once.
+func NewController(meshSpec smi.MeshSpec, splitClient smiSplitClient.Interface, metrics MetricsProvider, leader leader.Checker) *Controller {
+	return &Controller{
+		meshSpec:    meshSpec,
+		splitClient: splitClient,
+		metrics:     metrics,
+		leader:      leader,
+	}
+}
+
+// Start starts analyzing the rollouts of TrafficSplits annotated for progressive delivery at the given interval.
+func (c *Controller) Start(checkInterval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			c.reconcile(time.Now())
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (c *Controller) reconcile(now time.Time) {
+	if !c.leader.IsLeader() {
+		return
+	}
+
+	for _, trafficSplit := range c.meshSpec.ListTrafficSplits() {
+		if !isProgressiveDeliveryEnabled(trafficSplit) {
+			continue
+		}
+
+		if err := c.progress(trafficSplit, now); err != nil {
+			log.Error().Err(err).Msgf("Error progressing rollout for TrafficSplit %s/%s", trafficSplit.Namespace, trafficSplit.Name)
+		}
+	}
+}
+
+// progress analyzes the rollout of the given TrafficSplit, and shifts the weights of its backends if the next step is due.
+// The state of the rollout is recorded in the annotations of the TrafficSplit, so that it is preserved across controller restarts.
+func (c *Controller) progress(trafficSplit *split.TrafficSplit, now time.Time) error {
+	currentStatus := Status(trafficSplit.Annotations[constants.CanaryStatusAnnotation])
+	switch currentStatus {
+	case StatusSucceeded, StatusRolledBack:
+		// The rollout is complete, it is restarted by removing the status annotation
+		return nil
+	}
+	// A rollout without status is starting, the weight and time of the last step recorded by a previous rollout are stale
+	starting := currentStatus == ""
+
+	cfg, err := getConfig(trafficSplit)
+	if err != nil {
+		return err
+	}
+
+	canaryIdx, stableIdx, err := getBackendIndices(trafficSplit, cfg.canaryBackend)
+	if err != nil {
+		return err
+	}
+
+	if lastStep, ok := trafficSplit.Annotations[constants.CanaryLastStepAnnotation]; ok && !starting {
+		lastStepTime, err := time.Parse(time.RFC3339, lastStep)
+		if err != nil {
+			return errors.Errorf("Invalid value %q for annotation %s: %s", lastStep, constants.CanaryLastStepAnnotation, err)
+		}
+		if now.Sub(lastStepTime) < cfg.stepInterval {
+			return nil
+		}
+	}
+
+	canaryWeight := getCanaryWeight(trafficSplit, canaryIdx, cfg.dryRun && !starting)
+	canarySvc := service.MeshService{
+		Name:      cfg.canaryBackend,
+		Namespace: trafficSplit.Namespace,
+	}
+
+	errorRate, err := c.metrics.GetErrorRate(canarySvc, cfg.stepInterval)
+	if err != nil {
+		return errors.Errorf("Error retrieving error rate for canary backend %s: %s", canarySvc, err)
+	}
+
+	var status Status
+	switch {
+	case errorRate > cfg.maxErrorRate:
+		log.Warn().Msgf("Rolling back TrafficSplit %s/%s: error rate %.2f%% of canary backend %s exceeds %.2f%%",
+			trafficSplit.Namespace, trafficSplit.Name, errorRate, canarySvc, cfg.maxErrorRate)
+		canaryWeight = 0
+		status = StatusRolledBack
+
+	case canaryWeight+cfg.stepWeight >= maxWeight:
+		log.Info().Msgf("Completing rollout of TrafficSplit %s/%s to canary backend %s", trafficSplit.Namespace, trafficSplit.Name, canarySvc)
+		canaryWeight = maxWeight
+		status = StatusSucceeded
+
+	default:
+		canaryWeight += cfg.stepWeight
+		log.Info().Msgf("Shifting %d%% of traffic for TrafficSplit %s/%s to canary backend %s", canaryWeight, trafficSplit.Namespace, trafficSplit.Name, canarySvc)
+		status = StatusProgressing
+	}
+
+	updated := trafficSplit.DeepCopy()
+	if cfg.dryRun {
+		log.Info().Msgf("Dry-run for TrafficSplit %s/%s: canary backend %s would receive %d%% of traffic, rollout status %s",
+			trafficSplit.Namespace, trafficSplit.Name, canarySvc, canaryWeight, status)
+	} else {
+		updated.Spec.Backends[canaryIdx].Weight = canaryWeight
+		updated.Spec.Backends[stableIdx].Weight = maxWeight - canaryWeight
+	}
+	updated.Annotations[constants.CanaryStatusAnnotation] = string(status)
+	updated.Annotations[constants.CanaryWeightAnnotation] = strconv.Itoa(canaryWeight)
+	updated.Annotations[constants.CanaryLastStepAnnotation] = now.UTC().Format(time.RFC3339)
+	if status != StatusProgressing {
+		// The state of the steps of a completed rollout is not carried over to the next rollout
+		delete(updated.Annotations, constants.CanaryWeightAnnotation)
+		delete(updated.Annotations, constants.CanaryLastStepAnnotation)
+	}
+
+	if _, err := c.splitClient.SplitV1alpha2().TrafficSplits(updated.Namespace).Update(context.Background(), updated, metav1.UpdateOptions{}); err != nil {
+		return errors.Errorf("Error updating TrafficSplit %s/%s: %s", updated.Namespace, updated.Name, err)
+	}
+
+	return nil
+}
+
+// isProgressiveDeliveryEnabled returns true if the TrafficSplit is annotated for progressive delivery
+func isProgressiveDeliveryEnabled(trafficSplit *split.TrafficSplit) bool {
+	switch strings.ToLower(trafficSplit.Annotations[constants.ProgressiveDeliveryAnnotation]) {
+	case "enabled", "yes", "true":
+		return true
+	default:
+		return false
+	}
+}
+
+// getConfig returns the configuration of the rollout from the annotations of the TrafficSplit
+func getConfig(trafficSplit *split.TrafficSplit) (config, error) {
+	annotations := trafficSplit.Annotations
+	cfg := config{
+		canaryBackend: annotations[constants.CanaryBackendAnnotation],
+		stepWeight:    defaultStepWeight,
+		stepInterval:  defaultStepInterval,
+		maxErrorRate:  defaultMaxErrorRate,
+	}
+
+	if cfg.canaryBackend == "" {
+		return cfg, errors.Errorf("Missing annotation %s", constants.CanaryBackendAnnotation)
+	}
+
+	if val, ok := annotations[constants.CanaryStepWeightAnnotation]; ok {
+		stepWeight, err := strconv.Atoi(val)
+		if err != nil || stepWeight <= 0 || stepWeight > maxWeight {
+			return cfg, errors.Errorf("Invalid value %q for annotation %s, must be an integer between 1 and %d", val, constants.CanaryStepWeightAnnotation, maxWeight)
+		}
+		cfg.stepWeight = stepWeight
+	}
+
+	if val, ok := annotations[constants.CanaryStepIntervalAnnotation]; ok {
+		stepInterval, err := time.ParseDuration(val)
+		if err != nil || stepInterval <= 0 {
+			return cfg, errors.Errorf("Invalid value %q for annotation %s, must be a positive duration", val, constants.CanaryStepIntervalAnnotation)
+		}
+		cfg.stepInterval = stepInterval
+	}
+
+	if val, ok := annotations[constants.CanaryMaxErrorRateAnnotation]; ok {
+		maxErrorRate, err := strconv.ParseFloat(val, 64)
+		if err != nil || maxErrorRate < 0 || maxErrorRate > 100 {
+			return cfg, errors.Errorf("Invalid value %q for annotation %s, must be a percentage between 0 and 100", val, constants.CanaryMaxErrorRateAnnotation)
+		}
+		cfg.maxErrorRate = maxErrorRate
+	}
+
+	if val, ok := annotations[constants.CanaryDryRunAnnotation]; ok {
+		dryRun, err := strconv.ParseBool(val)
+		if err != nil {
+			return cfg, errors.Errorf("Invalid value %q for annotation %s, must be a boolean", val, constants.CanaryDryRunAnnotation)
+		}
+		cfg.dryRun = dryRun
+	}
+
+	return cfg, nil
+}
+
+// getBackendIndices returns the indices of the canary and stable backends of the TrafficSplit.
+// Progressive delivery requires exactly 2 backends: the canary backend, and the stable backend.
+func getBackendIndices(trafficSplit *split.TrafficSplit, canaryBackend string) (int, int, error) {
+	if len(trafficSplit.Spec.Backends) != 2 {
+		return 0, 0, errors.Errorf("Progressive delivery requires 2 backends, found %d", len(trafficSplit.Spec.Backends))
+	}
+
+	for idx, backend := range trafficSplit.Spec.Backends {
+		if backend.Service == canaryBackend {
+			return idx, 1 - idx, nil
+		}
+	}
+
+	return 0, 0, errors.Errorf("Canary backend %s is not a backend of the TrafficSplit", canaryBackend)
+}
+
+// getCanaryWeight returns the current weight of the canary backend as a percentage of the total weight.
+// During a dry-run the weights of the backends are not changed, so the weight recorded by the controller is used instead.
+func getCanaryWeight(trafficSplit *split.TrafficSplit, canaryIdx int, dryRun bool) int {
+	if dryRun {
+		if weight, err := strconv.Atoi(trafficSplit.Annotations[constants.CanaryWeightAnnotation]); err == nil {
+			return weight
+		}
+	}
+
+	var totalWeight int
+	for _, backend := range trafficSplit.Spec.Backends {
+		totalWeight += backend.Weight
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return trafficSplit.Spec.Backends[canaryIdx].Weight * maxWeight / totalWeight
+}
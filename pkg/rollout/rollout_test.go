@@ -0,0 +1,307 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	split "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	testTrafficSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+type fakeMetricsProvider struct {
+	errorRate float64
+	err       error
+}
+
+func (f fakeMetricsProvider) GetErrorRate(svc service.MeshService, window time.Duration) (float64, error) {
+	return f.errorRate, f.err
+}
+
+type fakeLeader bool
+
+func (f fakeLeader) IsLeader() bool {
+	return bool(f)
+}
+
+func newTrafficSplit(annotations map[string]string, stableWeight, canaryWeight int) *split.TrafficSplit {
+	return &split.TrafficSplit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bookstore-split",
+			Namespace:   tests.Namespace,
+			Annotations: annotations,
+		},
+		Spec: split.TrafficSplitSpec{
+			Service: tests.BookstoreApexServiceName,
+			Backends: []split.TrafficSplitBackend{
+				{
+					Service: tests.BookstoreV1ServiceName,
+					Weight:  stableWeight,
+				},
+				{
+					Service: tests.BookstoreV2ServiceName,
+					Weight:  canaryWeight,
+				},
+			},
+		},
+	}
+}
+
+func TestProgress(t *testing.T) {
+	now := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                 string
+		annotations          map[string]string
+		stableWeight         int
+		canaryWeight         int
+		metrics              fakeMetricsProvider
+		expectError          bool
+		expectUpdate         bool
+		expectedStableWeight int
+		expectedCanaryWeight int
+		expectedStatus       Status
+		// expectedWeightAnnotation is the weight of the canary backend recorded for a rollout in progress
+		expectedWeightAnnotation string
+	}{
+		{
+			name: "first step shifts traffic to the canary backend",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryStepWeightAnnotation:    "20",
+			},
+			stableWeight:             100,
+			canaryWeight:             0,
+			expectUpdate:             true,
+			expectedStableWeight:     80,
+			expectedCanaryWeight:     20,
+			expectedStatus:           StatusProgressing,
+			expectedWeightAnnotation: "20",
+		},
+		{
+			name: "step is not due yet",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryStepIntervalAnnotation:  "5m",
+				constants.CanaryStatusAnnotation:        string(StatusProgressing),
+				constants.CanaryLastStepAnnotation:      now.Add(-1 * time.Minute).Format(time.RFC3339),
+			},
+			stableWeight: 90,
+			canaryWeight: 10,
+			expectUpdate: false,
+		},
+		{
+			name: "step of a previous rollout is ignored when a rollout starts",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryStepIntervalAnnotation:  "5m",
+				constants.CanaryLastStepAnnotation:      now.Add(-1 * time.Minute).Format(time.RFC3339),
+			},
+			stableWeight:             100,
+			canaryWeight:             0,
+			expectUpdate:             true,
+			expectedStableWeight:     90,
+			expectedCanaryWeight:     10,
+			expectedStatus:           StatusProgressing,
+			expectedWeightAnnotation: "10",
+		},
+		{
+			name: "last step completes the rollout",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryStatusAnnotation:        string(StatusProgressing),
+				constants.CanaryWeightAnnotation:        "95",
+				constants.CanaryLastStepAnnotation:      now.Add(-2 * time.Minute).Format(time.RFC3339),
+			},
+			stableWeight:         5,
+			canaryWeight:         95,
+			expectUpdate:         true,
+			expectedStableWeight: 0,
+			expectedCanaryWeight: 100,
+			expectedStatus:       StatusSucceeded,
+		},
+		{
+			name: "error rate above the threshold rolls back",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryMaxErrorRateAnnotation:  "1.5",
+			},
+			stableWeight:         50,
+			canaryWeight:         50,
+			metrics:              fakeMetricsProvider{errorRate: 2},
+			expectUpdate:         true,
+			expectedStableWeight: 100,
+			expectedCanaryWeight: 0,
+			expectedStatus:       StatusRolledBack,
+		},
+		{
+			name: "dry-run does not change weights",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryDryRunAnnotation:        "true",
+				constants.CanaryStatusAnnotation:        string(StatusProgressing),
+				constants.CanaryWeightAnnotation:        "30",
+			},
+			stableWeight:             100,
+			canaryWeight:             0,
+			expectUpdate:             true,
+			expectedStableWeight:     100,
+			expectedCanaryWeight:     0,
+			expectedStatus:           StatusProgressing,
+			expectedWeightAnnotation: "40",
+		},
+		{
+			name: "dry-run ignores the weight recorded by a previous rollout when a rollout starts",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryDryRunAnnotation:        "true",
+				constants.CanaryWeightAnnotation:        "100",
+			},
+			stableWeight:             100,
+			canaryWeight:             0,
+			expectUpdate:             true,
+			expectedStableWeight:     100,
+			expectedCanaryWeight:     0,
+			expectedStatus:           StatusProgressing,
+			expectedWeightAnnotation: "10",
+		},
+		{
+			name: "completed rollout is ignored",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryStatusAnnotation:        string(StatusRolledBack),
+			},
+			stableWeight: 100,
+			canaryWeight: 0,
+			expectUpdate: false,
+		},
+		{
+			name: "canary backend is not a backend of the TrafficSplit",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       "unknown",
+			},
+			stableWeight: 100,
+			canaryWeight: 0,
+			expectError:  true,
+		},
+		{
+			name: "invalid step weight",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+				constants.CanaryStepWeightAnnotation:    "0",
+			},
+			stableWeight: 100,
+			canaryWeight: 0,
+			expectError:  true,
+		},
+		{
+			name: "error retrieving metrics",
+			annotations: map[string]string{
+				constants.ProgressiveDeliveryAnnotation: "enabled",
+				constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+			},
+			stableWeight: 100,
+			canaryWeight: 0,
+			metrics:      fakeMetricsProvider{err: errors.New("unavailable")},
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			require := trequire.New(t)
+
+			trafficSplit := newTrafficSplit(tc.annotations, tc.stableWeight, tc.canaryWeight)
+			splitClient := testTrafficSplitClient.NewSimpleClientset(trafficSplit)
+			c := NewController(nil, splitClient, tc.metrics, fakeLeader(true))
+
+			err := c.progress(trafficSplit, now)
+			assert.Equal(tc.expectError, err != nil)
+
+			actual, err := splitClient.SplitV1alpha2().TrafficSplits(trafficSplit.Namespace).Get(context.TODO(), trafficSplit.Name, metav1.GetOptions{})
+			require.Nil(err)
+
+			if !tc.expectUpdate {
+				assert.Equal(trafficSplit, actual)
+				return
+			}
+
+			assert.Equal(tc.expectedStableWeight, actual.Spec.Backends[0].Weight)
+			assert.Equal(tc.expectedCanaryWeight, actual.Spec.Backends[1].Weight)
+			assert.Equal(string(tc.expectedStatus), actual.Annotations[constants.CanaryStatusAnnotation])
+			if tc.expectedStatus != StatusProgressing {
+				// The state of the steps of a completed rollout is reset
+				assert.NotContains(actual.Annotations, constants.CanaryWeightAnnotation)
+				assert.NotContains(actual.Annotations, constants.CanaryLastStepAnnotation)
+				return
+			}
+			assert.Equal(tc.expectedWeightAnnotation, actual.Annotations[constants.CanaryWeightAnnotation])
+			assert.Equal(now.Format(time.RFC3339), actual.Annotations[constants.CanaryLastStepAnnotation])
+		})
+	}
+}
+
+func TestReconcileSkipsTrafficSplitsWithoutAnnotation(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+
+	trafficSplit := newTrafficSplit(nil, 100, 0)
+	splitClient := testTrafficSplitClient.NewSimpleClientset(trafficSplit)
+	mockMeshSpec.EXPECT().ListTrafficSplits().Return([]*split.TrafficSplit{trafficSplit}).Times(1)
+
+	c := NewController(mockMeshSpec, splitClient, fakeMetricsProvider{}, fakeLeader(true))
+	c.reconcile(time.Now())
+
+	actual, err := splitClient.SplitV1alpha2().TrafficSplits(trafficSplit.Namespace).Get(context.TODO(), trafficSplit.Name, metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Equal(trafficSplit, actual)
+}
+
+func TestReconcileSkipsRolloutsWhenNotLeader(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+
+	trafficSplit := newTrafficSplit(map[string]string{
+		constants.ProgressiveDeliveryAnnotation: "enabled",
+		constants.CanaryBackendAnnotation:       tests.BookstoreV2ServiceName,
+	}, 100, 0)
+	splitClient := testTrafficSplitClient.NewSimpleClientset(trafficSplit)
+	mockMeshSpec.EXPECT().ListTrafficSplits().Times(0)
+
+	c := NewController(mockMeshSpec, splitClient, fakeMetricsProvider{}, fakeLeader(false))
+	c.reconcile(time.Now())
+
+	actual, err := splitClient.SplitV1alpha2().TrafficSplits(trafficSplit.Namespace).Get(context.TODO(), trafficSplit.Name, metav1.GetOptions{})
+	assert.Nil(err)
+	assert.Equal(trafficSplit, actual)
+}
+
+func TestGetErrorRateQuery(t *testing.T) {
+	assert := tassert.New(t)
+
+	query := getErrorRateQuery(tests.BookstoreV2Service, time.Minute)
+	assert.Equal(`100 * sum(rate(envoy_cluster_upstream_rq_xx{envoy_response_code_class="5",envoy_cluster_name="default/bookstore-v2"}[1m])) / sum(rate(envoy_cluster_upstream_rq_xx{envoy_cluster_name="default/bookstore-v2"}[1m]))`, query)
+}
@@ -0,0 +1,57 @@
+// Package rollout implements a controller for the progressive delivery of TrafficSplit backends.
+// The controller shifts traffic to a canary backend on a schedule, and rolls back to the stable backend
+// when the error rate of requests to the canary backend exceeds a threshold.
+package rollout
+
+import (
+	"time"
+
+	smiSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/leader"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+var (
+	log = logger.New("rollout")
+)
+
+// Status is the status of a rollout
+type Status string
+
+const (
+	// StatusProgressing is the status of a rollout shifting traffic to the canary backend
+	StatusProgressing Status = "Progressing"
+
+	// StatusSucceeded is the status of a rollout that shifted all traffic to the canary backend
+	StatusSucceeded Status = "Succeeded"
+
+	// StatusRolledBack is the status of a rollout that shifted all traffic back to the stable backend
+	StatusRolledBack Status = "RolledBack"
+)
+
+// Controller progressively shifts the weights of TrafficSplit backends annotated for progressive delivery.
+type Controller struct {
+	meshSpec    smi.MeshSpec
+	splitClient smiSplitClient.Interface
+	metrics     MetricsProvider
+	leader      leader.Checker
+}
+
+// MetricsProvider is an interface to retrieve the metrics used to analyze a rollout.
+type MetricsProvider interface {
+	// GetErrorRate returns the percentage of requests to the given service that failed over the given window.
+	// The error rate of a service that did not receive requests is 0.
+	GetErrorRate(svc service.MeshService, window time.Duration) (float64, error)
+}
+
+// config is the configuration of a rollout, as specified by the annotations of a TrafficSplit
+type config struct {
+	canaryBackend string
+	stepWeight    int
+	stepInterval  time.Duration
+	maxErrorRate  float64
+	dryRun        bool
+}
@@ -0,0 +1,83 @@
+package sizing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	metricsAPIQueryTimeout = 10 * time.Second
+
+	// podMetricsPath is the path of the metrics of the pods in a namespace served by the Kubernetes metrics API
+	podMetricsPath = "/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods"
+)
+
+// podMetricsList is the subset of the PodMetricsList resource of the metrics.k8s.io API used by the controller
+type podMetricsList struct {
+	Items []struct {
+		Containers []struct {
+			Name  string `json:"name"`
+			Usage struct {
+				CPU    resource.Quantity `json:"cpu"`
+				Memory resource.Quantity `json:"memory"`
+			} `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// metricsAPIProvider retrieves the resource usage of Envoy sidecars from the Kubernetes metrics API
+type metricsAPIProvider struct {
+	restClient rest.Interface
+}
+
+// NewMetricsAPIProvider creates a MetricsProvider that queries the Kubernetes metrics API using the given REST client.
+// The metrics API is served by the metrics-server deployed in the cluster.
+func NewMetricsAPIProvider(restClient rest.Interface) MetricsProvider {
+	return &metricsAPIProvider{
+		restClient: restClient,
+	}
+}
+
+// GetSidecarUsage returns the current resource usage of each Envoy sidecar in the given namespace.
+func (p *metricsAPIProvider) GetSidecarUsage(namespace string) ([]Usage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsAPIQueryTimeout)
+	defer cancel()
+
+	data, err := p.restClient.Get().AbsPath(fmt.Sprintf(podMetricsPath, namespace)).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSidecarUsage(data)
+}
+
+// parseSidecarUsage returns the resource usage of the Envoy sidecars in the given PodMetricsList
+func parseSidecarUsage(data []byte) ([]Usage, error) {
+	var podMetrics podMetricsList
+	if err := json.Unmarshal(data, &podMetrics); err != nil {
+		return nil, errors.Errorf("Error unmarshaling pod metrics: %s", err)
+	}
+
+	var usage []Usage
+	for _, pod := range podMetrics.Items {
+		for _, container := range pod.Containers {
+			if container.Name != constants.EnvoyContainerName {
+				continue
+			}
+			usage = append(usage, Usage{
+				CPU:    container.Usage.CPU,
+				Memory: container.Usage.Memory,
+			})
+		}
+	}
+
+	return usage, nil
+}
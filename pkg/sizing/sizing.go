@@ -0,0 +1,229 @@
+package sizing
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+const (
+	// maxChecks is the number of checks whose samples are used to recommend the resource requests of the sidecars
+	maxChecks = 60
+
+	// minChecks is the number of checks whose samples are required before recommending the resource requests of the sidecars
+	minChecks = 10
+
+	// usagePercentile is the percentile of the observed usage of the sidecars the requests are based on
+	usagePercentile = 0.9
+
+	// headroom is the ratio by which the requests exceed the observed usage, to absorb bursts
+	headroom = 1.2
+
+	mebibyte = 1024 * 1024
+)
+
+// NewController creates a new sidecar sizing controller.
+// The recommended requests are automatically applied to the sidecars of new pods if autoApply is true.
+func NewController(kubeClient kubernetes.Interface, kubeController k8s.Controller, metrics MetricsProvider, bounds Bounds, autoApply bool) *Controller {
+	return &Controller{
+		kubeClient:     kubeClient,
+		kubeController: kubeController,
+		metrics:        metrics,
+		bounds:         bounds,
+		autoApply:      autoApply,
+		samples:        make(map[string][][]Usage),
+	}
+}
+
+// ParseBounds parses the bounds within which the resource requests of the sidecars are recommended.
+func ParseBounds(minCPU, maxCPU, minMemory, maxMemory string) (Bounds, error) {
+	var bounds Bounds
+	for _, b := range []struct {
+		val      string
+		quantity *resource.Quantity
+	}{
+		{minCPU, &bounds.MinCPU},
+		{maxCPU, &bounds.MaxCPU},
+		{minMemory, &bounds.MinMemory},
+		{maxMemory, &bounds.MaxMemory},
+	} {
+		quantity, err := resource.ParseQuantity(b.val)
+		if err != nil || quantity.Sign() <= 0 {
+			return bounds, errors.Errorf("Invalid sidecar resource bound %q, must be a positive quantity", b.val)
+		}
+		*b.quantity = quantity
+	}
+
+	if bounds.MinCPU.Cmp(bounds.MaxCPU) > 0 {
+		return bounds, errors.Errorf("Minimum sidecar CPU request %s exceeds maximum %s", bounds.MinCPU.String(), bounds.MaxCPU.String())
+	}
+	if bounds.MinMemory.Cmp(bounds.MaxMemory) > 0 {
+		return bounds, errors.Errorf("Minimum sidecar memory request %s exceeds maximum %s", bounds.MinMemory.String(), bounds.MaxMemory.String())
+	}
+
+	return bounds, nil
+}
+
+// Start starts observing the resource usage of the sidecars at the given interval.
+func (c *Controller) Start(checkInterval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(checkInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			c.reconcile()
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (c *Controller) reconcile() {
+	namespaces, err := c.kubeController.ListMonitoredNamespaces()
+	if err != nil {
+		log.Error().Err(err).Msg("Error listing monitored namespaces")
+		return
+	}
+
+	monitored := make(map[string]bool)
+	for _, namespace := range namespaces {
+		monitored[namespace] = true
+		if err := c.observe(namespace); err != nil {
+			log.Error().Err(err).Msgf("Error recommending sidecar resources for namespace %s", namespace)
+		}
+	}
+
+	// Forget the samples of the namespaces that are no longer monitored
+	for namespace := range c.samples {
+		if !monitored[namespace] {
+			delete(c.samples, namespace)
+		}
+	}
+}
+
+// observe records the current resource usage of the sidecars of the given namespace, and updates the recommended
+// requests of the namespace once enough samples are observed.
+func (c *Controller) observe(namespace string) error {
+	usage, err := c.metrics.GetSidecarUsage(namespace)
+	if err != nil {
+		return errors.Errorf("Error retrieving resource usage of sidecars: %s", err)
+	}
+	if len(usage) == 0 {
+		return nil
+	}
+
+	checks := append(c.samples[namespace], usage)
+	if len(checks) > maxChecks {
+		checks = checks[len(checks)-maxChecks:]
+	}
+	c.samples[namespace] = checks
+	if len(checks) < minChecks {
+		return nil
+	}
+
+	cpu, memory := c.recommend(checks)
+	return c.record(namespace, cpu, memory)
+}
+
+// recommend returns the CPU and memory requests recommended for sidecars with the given usage, within the bounds
+// of the controller
+func (c *Controller) recommend(checks [][]Usage) (resource.Quantity, resource.Quantity) {
+	var cpuSamples, memorySamples []int64
+	for _, check := range checks {
+		for _, usage := range check {
+			cpuSamples = append(cpuSamples, usage.CPU.MilliValue())
+			memorySamples = append(memorySamples, usage.Memory.Value())
+		}
+	}
+
+	milliCPU := int64(math.Ceil(float64(percentile(cpuSamples, usagePercentile)) * headroom))
+	memoryMi := int64(math.Ceil(float64(percentile(memorySamples, usagePercentile)) * headroom / mebibyte))
+
+	cpu := clamp(*resource.NewMilliQuantity(milliCPU, resource.DecimalSI), c.bounds.MinCPU, c.bounds.MaxCPU)
+	memory := clamp(*resource.NewQuantity(memoryMi*mebibyte, resource.BinarySI), c.bounds.MinMemory, c.bounds.MaxMemory)
+	return cpu, memory
+}
+
+// record records the given recommended requests on the annotations of the namespace, and applies them to the sidecars
+// injected into the new pods of the namespace if the controller automatically applies its recommendations
+func (c *Controller) record(namespace string, cpu, memory resource.Quantity) error {
+	annotations := map[string]string{
+		constants.RecommendedSidecarCPURequestAnnotation:    cpu.String(),
+		constants.RecommendedSidecarMemoryRequestAnnotation: memory.String(),
+	}
+	if c.autoApply {
+		annotations[constants.SidecarCPURequestAnnotation] = cpu.String()
+		annotations[constants.SidecarMemoryRequestAnnotation] = memory.String()
+	}
+
+	if ns := c.kubeController.GetNamespace(namespace); ns != nil && isAnnotated(ns.Annotations, annotations) {
+		return nil
+	}
+
+	log.Info().Msgf("Recommending sidecar CPU request %s and memory request %s for namespace %s, applied: %t", cpu.String(), memory.String(), namespace, c.autoApply)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.kubeClient.CoreV1().Namespaces().Patch(context.Background(), namespace, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return errors.Errorf("Error annotating namespace %s: %s", namespace, err)
+	}
+
+	return nil
+}
+
+// isAnnotated returns true if the given annotations include the expected annotations
+func isAnnotated(annotations, expected map[string]string) bool {
+	for key, val := range expected {
+		if annotations[key] != val {
+			return false
+		}
+	}
+	return true
+}
+
+// percentile returns the given percentile of the samples using the nearest-rank method
+func percentile(samples []int64, p float64) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sorted := append([]int64(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// clamp returns the given quantity bounded by min and max
+func clamp(quantity, min, max resource.Quantity) resource.Quantity {
+	if quantity.Cmp(min) < 0 {
+		return min
+	}
+	if quantity.Cmp(max) > 0 {
+		return max
+	}
+	return quantity
+}
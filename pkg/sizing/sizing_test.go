@@ -0,0 +1,215 @@
+package sizing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+type fakeMetricsProvider struct {
+	usage []Usage
+	err   error
+}
+
+func (f fakeMetricsProvider) GetSidecarUsage(namespace string) ([]Usage, error) {
+	return f.usage, f.err
+}
+
+func newUsage(cpu, memory string) Usage {
+	return Usage{
+		CPU:    resource.MustParse(cpu),
+		Memory: resource.MustParse(memory),
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	bounds, err := ParseBounds("10m", "500m", "32Mi", "512Mi")
+	trequire.Nil(t, err)
+
+	testCases := []struct {
+		name                string
+		checks              int
+		usage               []Usage
+		autoApply           bool
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:                "not enough samples",
+			checks:              minChecks - 1,
+			usage:               []Usage{newUsage("50m", "100Mi")},
+			expectedAnnotations: nil,
+		},
+		{
+			name:   "recommendation is recorded",
+			checks: minChecks,
+			usage:  []Usage{newUsage("50m", "100Mi"), newUsage("100m", "50Mi")},
+			expectedAnnotations: map[string]string{
+				constants.RecommendedSidecarCPURequestAnnotation:    "120m",
+				constants.RecommendedSidecarMemoryRequestAnnotation: "120Mi",
+			},
+		},
+		{
+			name:      "recommendation is applied",
+			checks:    minChecks,
+			usage:     []Usage{newUsage("50m", "100Mi")},
+			autoApply: true,
+			expectedAnnotations: map[string]string{
+				constants.RecommendedSidecarCPURequestAnnotation:    "60m",
+				constants.RecommendedSidecarMemoryRequestAnnotation: "120Mi",
+				constants.SidecarCPURequestAnnotation:               "60m",
+				constants.SidecarMemoryRequestAnnotation:            "120Mi",
+			},
+		},
+		{
+			name:   "recommendation is bounded",
+			checks: minChecks,
+			usage:  []Usage{newUsage("1", "1Mi")},
+			expectedAnnotations: map[string]string{
+				constants.RecommendedSidecarCPURequestAnnotation:    "500m",
+				constants.RecommendedSidecarMemoryRequestAnnotation: "32Mi",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: tests.Namespace,
+				},
+			}
+			kubeClient := fake.NewSimpleClientset(ns)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().ListMonitoredNamespaces().Return([]string{tests.Namespace}, nil).Times(tc.checks)
+			mockKubeController.EXPECT().GetNamespace(tests.Namespace).Return(ns).AnyTimes()
+
+			c := NewController(kubeClient, mockKubeController, fakeMetricsProvider{usage: tc.usage}, bounds, tc.autoApply)
+			for i := 0; i < tc.checks; i++ {
+				c.reconcile()
+			}
+
+			updated, err := kubeClient.CoreV1().Namespaces().Get(context.Background(), tests.Namespace, metav1.GetOptions{})
+			assert.Nil(err)
+			assert.Equal(tc.expectedAnnotations, updated.Annotations)
+		})
+	}
+}
+
+func TestReconcileForgetsUnmonitoredNamespaces(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().ListMonitoredNamespaces().Return([]string{tests.Namespace}, nil)
+	mockKubeController.EXPECT().ListMonitoredNamespaces().Return(nil, nil)
+
+	c := NewController(fake.NewSimpleClientset(), mockKubeController, fakeMetricsProvider{usage: []Usage{newUsage("50m", "100Mi")}}, Bounds{}, false)
+	c.reconcile()
+	assert.Len(c.samples[tests.Namespace], 1)
+
+	c.reconcile()
+	assert.Empty(c.samples)
+}
+
+func TestParseBounds(t *testing.T) {
+	testCases := []struct {
+		name      string
+		minCPU    string
+		maxCPU    string
+		minMemory string
+		maxMemory string
+		expectErr bool
+	}{
+		{
+			name:      "valid bounds",
+			minCPU:    "10m",
+			maxCPU:    "1",
+			minMemory: "32Mi",
+			maxMemory: "1Gi",
+		},
+		{
+			name:      "invalid quantity",
+			minCPU:    "ten",
+			maxCPU:    "1",
+			minMemory: "32Mi",
+			maxMemory: "1Gi",
+			expectErr: true,
+		},
+		{
+			name:      "minimum exceeds maximum",
+			minCPU:    "10m",
+			maxCPU:    "1",
+			minMemory: "2Gi",
+			maxMemory: "1Gi",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			_, err := ParseBounds(tc.minCPU, tc.maxCPU, tc.minMemory, tc.maxMemory)
+			assert.Equal(tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal(int64(0), percentile(nil, 0.9))
+	assert.Equal(int64(5), percentile([]int64{5}, 0.9))
+	assert.Equal(int64(9), percentile([]int64{10, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 0.9))
+}
+
+func TestParseSidecarUsage(t *testing.T) {
+	assert := tassert.New(t)
+
+	data := []byte(`{
+		"kind": "PodMetricsList",
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"items": [
+			{
+				"metadata": {"name": "bookstore-1", "namespace": "bookstore"},
+				"containers": [
+					{"name": "bookstore", "usage": {"cpu": "200m", "memory": "300Mi"}},
+					{"name": "envoy", "usage": {"cpu": "15m", "memory": "40Mi"}}
+				]
+			},
+			{
+				"metadata": {"name": "bookstore-2", "namespace": "bookstore"},
+				"containers": [
+					{"name": "envoy", "usage": {"cpu": "1234567n", "memory": "41000Ki"}}
+				]
+			}
+		]
+	}`)
+
+	usage, err := parseSidecarUsage(data)
+	assert.Nil(err)
+	assert.Len(usage, 2)
+	assert.Equal(int64(15), usage[0].CPU.MilliValue())
+	assert.Equal(int64(40*mebibyte), usage[0].Memory.Value())
+	assert.Equal(int64(2), usage[1].CPU.MilliValue())
+	assert.Equal(int64(41000*1024), usage[1].Memory.Value())
+
+	_, err = parseSidecarUsage([]byte("not json"))
+	assert.NotNil(err)
+}
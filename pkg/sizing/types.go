@@ -0,0 +1,49 @@
+// Package sizing implements a controller recommending the resource requests of the Envoy sidecars of each namespace.
+// The controller observes the resource usage of the sidecars reported by the Kubernetes metrics API, records the
+// recommended requests on the namespaces, and optionally applies them to the sidecars injected into new pods.
+package sizing
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("sizing")
+)
+
+// Controller recommends the resource requests of the Envoy sidecars of the namespaces monitored by the mesh.
+type Controller struct {
+	kubeClient     kubernetes.Interface
+	kubeController k8s.Controller
+	metrics        MetricsProvider
+	bounds         Bounds
+	autoApply      bool
+
+	// samples holds the resource usage of the sidecars of each namespace observed during the recent checks,
+	// the oldest check first
+	samples map[string][][]Usage
+}
+
+// MetricsProvider is an interface to retrieve the resource usage of Envoy sidecars.
+type MetricsProvider interface {
+	// GetSidecarUsage returns the current resource usage of each Envoy sidecar in the given namespace.
+	GetSidecarUsage(namespace string) ([]Usage, error)
+}
+
+// Usage is the resource usage of an Envoy sidecar
+type Usage struct {
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// Bounds are the bounds within which the resource requests of the sidecars are recommended
+type Bounds struct {
+	MinCPU    resource.Quantity
+	MaxCPU    resource.Quantity
+	MinMemory resource.Quantity
+	MaxMemory resource.Quantity
+}
@@ -16,6 +16,7 @@ import (
 	smiTrafficSpecInformers "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/informers/externalversions"
 	smiTrafficSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 	smiTrafficSplitInformers "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/informers/externalversions"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -163,6 +164,41 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 	return &client, err
 }
 
+// NewStaticMeshSpec returns a MeshSpec serving the given SMI TrafficSplit, HTTPRouteGroup, TCPRoute and TrafficTarget
+// resources, without a Kubernetes client. Resources in namespaces not monitored by the given Kubernetes controller
+// are ignored, as with a live MeshSpec. Objects of other types are ignored.
+func NewStaticMeshSpec(kubeController k8s.Controller, objects []runtime.Object) (MeshSpec, error) {
+	c := &client{
+		caches: &cacheCollection{
+			TrafficSplit:   cache.NewStore(cache.MetaNamespaceKeyFunc),
+			HTTPRouteGroup: cache.NewStore(cache.MetaNamespaceKeyFunc),
+			TCPRoute:       cache.NewStore(cache.MetaNamespaceKeyFunc),
+			TrafficTarget:  cache.NewStore(cache.MetaNamespaceKeyFunc),
+		},
+		providerIdent:  kubernetesClientName,
+		kubeController: kubeController,
+	}
+
+	for _, obj := range objects {
+		var err error
+		switch o := obj.(type) {
+		case *smiSplit.TrafficSplit:
+			err = c.caches.TrafficSplit.Add(o)
+		case *smiSpecs.HTTPRouteGroup:
+			err = c.caches.HTTPRouteGroup.Add(o)
+		case *smiSpecs.TCPRoute:
+			err = c.caches.TCPRoute.Add(o)
+		case *smiAccess.TrafficTarget:
+			err = c.caches.TrafficTarget.Add(o)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error adding %T to static MeshSpec", obj)
+		}
+	}
+
+	return c, nil
+}
+
 // ListTrafficSplits implements mesh.MeshSpec by returning the list of traffic splits.
 func (c *client) ListTrafficSplits() []*smiSplit.TrafficSplit {
 	var trafficSplits []*smiSplit.TrafficSplit
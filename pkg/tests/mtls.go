@@ -1,10 +1,18 @@
 package tests
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
 
 	"google.golang.org/grpc/credentials"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	tresorPem "github.com/openservicemesh/osm/pkg/certificate/pem"
 )
 
 // NewMockAuthInfo creates a new credentials.AuthInfo
@@ -15,3 +23,36 @@ func NewMockAuthInfo(cert *x509.Certificate) credentials.AuthInfo {
 			VerifiedChains: [][]*x509.Certificate{{cert}}},
 	}
 }
+
+// NewSelfSignedPEMCertificate returns a TEST self-signed certificate valid between the given times, and its private key,
+// used ONLY for testing
+func NewSelfSignedPEMCertificate(cn string, notBefore, notAfter time.Time) (tresorPem.Certificate, tresorPem.PrivateKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	derBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM, err := certificate.EncodeCertDERtoPEM(derBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := certificate.EncodeKeyDERtoPEM(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certPEM, keyPEM, nil
+}
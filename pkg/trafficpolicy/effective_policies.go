@@ -0,0 +1,107 @@
+package trafficpolicy
+
+// EffectivePolicies is the type used to represent the policies applied to the traffic of a service identity,
+// merged from all the policies referencing the identity. It is a stable schema meant to be consumed by
+// tooling external to the control plane, so its lists are sorted and its fields are not tied to the internal
+// representation of policies.
+type EffectivePolicies struct {
+	// Identity is the service identity the policies apply to
+	Identity string `json:"identity"`
+
+	// Inbound defines the policies applied to the traffic received by the identity from other identities in the mesh
+	Inbound []EffectiveTrafficPolicy `json:"inbound"`
+
+	// InboundTCP defines the TCP traffic targets allowing the traffic received by the identity from other identities in the mesh
+	InboundTCP []EffectiveTCPTrafficTarget `json:"inbound_tcp"`
+
+	// Outbound defines the policies applied to the traffic sent by the identity to other services in the mesh
+	Outbound []EffectiveTrafficPolicy `json:"outbound"`
+
+	// Egress defines the policies applied to the traffic sent by the identity to destinations external to the mesh
+	Egress []EffectiveEgressPolicy `json:"egress"`
+
+	// Ingress defines the policies applied to the traffic received by the identity from ingress controllers
+	Ingress []EffectiveTrafficPolicy `json:"ingress"`
+}
+
+// EffectiveTrafficPolicy is the type used to represent the routes applied to the traffic for a set of hostnames
+type EffectiveTrafficPolicy struct {
+	// Name is the name of the policy
+	Name string `json:"name"`
+
+	// Hostnames is the list of hostnames the policy applies to
+	Hostnames []string `json:"hostnames"`
+
+	// Routes is the list of routes of the policy
+	Routes []EffectiveRoute `json:"routes"`
+}
+
+// EffectiveRoute is the type used to represent an HTTP route, its backends and the clients allowed to use it
+type EffectiveRoute struct {
+	// Path is the path matched by the route
+	Path string `json:"path,omitempty"`
+
+	// PathMatchType is the type of match applied to the path, one of regex, exact or prefix
+	PathMatchType string `json:"path_match_type,omitempty"`
+
+	// Methods is the list of HTTP methods matched by the route
+	Methods []string `json:"methods,omitempty"`
+
+	// Headers is the map of HTTP headers matched by the route
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// WeightedClusters is the list of clusters traffic matching the route is routed to
+	WeightedClusters []EffectiveWeightedCluster `json:"weighted_clusters,omitempty"`
+
+	// AllowedServiceAccounts is the list of service accounts allowed to use the route, applicable to inbound
+	// and ingress routes
+	AllowedServiceAccounts []string `json:"allowed_service_accounts,omitempty"`
+
+	// AllowedDestinationIPRanges is the list of destination IP ranges allowed for the route, applicable to egress routes
+	AllowedDestinationIPRanges []string `json:"allowed_destination_ip_ranges,omitempty"`
+}
+
+// EffectiveTCPTrafficTarget is the type used to represent the TCP traffic allowed by a traffic target
+type EffectiveTCPTrafficTarget struct {
+	// Name is the name of the traffic target
+	Name string `json:"name"`
+
+	// AllowedIdentities is the list of identities allowed to connect to the destination ports
+	AllowedIdentities []string `json:"allowed_identities"`
+
+	// Ports is the list of destination ports the connections are allowed to, all ports are allowed if empty
+	Ports []int `json:"ports,omitempty"`
+}
+
+// EffectiveWeightedCluster is the type used to represent a cluster and its weight
+type EffectiveWeightedCluster struct {
+	// Cluster is the name of the cluster
+	Cluster string `json:"cluster"`
+
+	// Weight is the weight of the cluster
+	Weight int `json:"weight"`
+}
+
+// EffectiveEgressPolicy is the type used to represent the egress policies applied to the traffic sent to a port
+type EffectiveEgressPolicy struct {
+	// Port is the destination port matched by the policy
+	Port int `json:"port"`
+
+	// Protocol is the protocol of the traffic sent to the port
+	Protocol string `json:"protocol"`
+
+	// ServerNames is the list of server names matched against the SNI of the traffic sent to the port, applicable to
+	// TLS traffic
+	ServerNames []string `json:"server_names,omitempty"`
+
+	// IPRanges is the list of destination IP ranges specified for the traffic sent to the port, applicable to
+	// TCP traffic
+	IPRanges []string `json:"ip_ranges,omitempty"`
+
+	// Hosts is the list of destination hosts specified for the traffic sent to the port, applicable to TCP traffic
+	Hosts []string `json:"hosts,omitempty"`
+
+	// HTTPRoutes is the list of HTTP route configurations applied to the traffic sent to the port, applicable to
+	// HTTP traffic
+	HTTPRoutes []EffectiveTrafficPolicy `json:"http_routes,omitempty"`
+}
@@ -28,6 +28,36 @@ type EgressTrafficPolicy struct {
 type TrafficMatch struct {
 	// DestinationPort defines the destination port's specification - port's number and protocol
 	DestinationPort policyV1alpha1.PortSpec
+
+	// ServerNames defines the list of server names matched against the SNI of TLS traffic, applicable to
+	// the HTTPS protocol. TLS traffic matching a server name is forwarded to Cluster without being terminated.
+	// +optional
+	ServerNames []string
+
+	// Cluster defines the name of the external cluster matching TLS traffic is forwarded to, applicable to
+	// the HTTPS protocol
+	// +optional
+	Cluster string
+
+	// RateLimit defines the rate limiting applied to the traffic matched, as specified by the
+	// UpstreamTrafficSetting for the host matched by ServerNames
+	// +optional
+	RateLimit *policyV1alpha1.RateLimitSpec
+
+	// Deny defines whether the TLS traffic matched by ServerNames is denied instead of being forwarded to Cluster,
+	// applicable to the HTTPS protocol
+	// +optional
+	Deny bool
+
+	// DestinationIPRanges defines the list of IP ranges specified by the Egress policies matching the traffic,
+	// applicable to protocols other than HTTP and HTTPS
+	// +optional
+	DestinationIPRanges []string
+
+	// DestinationHosts defines the list of hosts specified by the Egress policies matching the traffic,
+	// applicable to protocols other than HTTP and HTTPS
+	// +optional
+	DestinationHosts []string
 }
 
 // EgressClusterConfig is the type used to represent an external cluster corresponding to a
@@ -47,6 +77,16 @@ type EgressClusterConfig struct {
 
 	// Port defines the port number of the external cluster's endpoint
 	Port int
+
+	// PolicyName defines the namespaced name of the Egress policy the external cluster is built for,
+	// as <namespace>/<name>, used to tag the statistics of the cluster
+	// +optional
+	PolicyName string
+
+	// ConnectionSettings defines the connection settings for the external cluster, as specified by the
+	// UpstreamTrafficSetting for Host
+	// +optional
+	ConnectionSettings *policyV1alpha1.ConnectionSettingsSpec
 }
 
 // EgressHTTPRouteConfig is the type used to represent an HTTP route configuration along with associated routing rules
@@ -62,6 +102,13 @@ type EgressHTTPRouteConfig struct {
 	// RoutingRules defines the list of routes for the Egress HTTP route configuration, and corresponding
 	// rules to be applied to those routes.
 	RoutingRules []*EgressHTTPRoutingRule
+
+	// HostRewrite defines the host the Host/authority header of HTTP requests matching the Egress HTTP
+	// route configuration is rewritten to. The header is not rewritten when empty.
+	HostRewrite string
+
+	// Deny defines whether the HTTP requests matching Hostnames are denied, in which case RoutingRules is empty
+	Deny bool
 }
 
 // EgressHTTPRoutingRule is the type used to represent an Egress HTTP routing rule with its route and associated permissions
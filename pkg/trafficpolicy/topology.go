@@ -0,0 +1,31 @@
+package trafficpolicy
+
+// MeshTopology is the type used to represent the communication graph of the mesh, where the edges are the
+// connections allowed by the traffic policies between the service identities and services in the mesh.
+// It is a stable schema meant to be consumed by visualization tooling external to the control plane,
+// so its lists are sorted.
+type MeshTopology struct {
+	// Services is the list of services in the mesh
+	Services []TopologyService `json:"services"`
+
+	// Edges is the list of connections allowed from a service identity to a service
+	Edges []TopologyEdge `json:"edges"`
+}
+
+// TopologyService is the type used to represent a service in the mesh topology
+type TopologyService struct {
+	// Name is the namespaced name of the service, ex. bookstore/bookstore-v1
+	Name string `json:"name"`
+
+	// Identities is the list of service identities of the workloads backing the service
+	Identities []string `json:"identities"`
+}
+
+// TopologyEdge is the type used to represent a connection allowed from a service identity to a service
+type TopologyEdge struct {
+	// Source is the service identity of the downstream client
+	Source string `json:"source"`
+
+	// Destination is the namespaced name of the upstream service
+	Destination string `json:"destination"`
+}
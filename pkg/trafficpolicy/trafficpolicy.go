@@ -58,22 +58,22 @@ func (rwc *RouteWeightedClusters) TotalClustersWeight() int {
 	return totalWeight
 }
 
-// AddRule adds a Rule to an InboundTrafficPolicy based on the given HTTP route match, weighted cluster, and allowed service account
-//	parameters. If a Rule for the given HTTP route match exists, it will add the given service account to the Rule. If the the given route
-//	match is not already associated with a Rule, it will create a Rule for the given route and service account.
-func (in *InboundTrafficPolicy) AddRule(route RouteWeightedClusters, allowedServiceAccount identity.K8sServiceAccount) {
+// AddRule adds a Rule to an InboundTrafficPolicy based on the given HTTP route match, weighted cluster, and allowed service identity
+//	parameters. If a Rule for the given HTTP route match exists, it will add the given service identity to the Rule. If the the given route
+//	match is not already associated with a Rule, it will create a Rule for the given route and service identity.
+func (in *InboundTrafficPolicy) AddRule(route RouteWeightedClusters, allowedServiceIdentity identity.ServiceIdentity) {
 	routeExists := false
 	for _, rule := range in.Rules {
 		if reflect.DeepEqual(rule.Route, route) {
 			routeExists = true
-			rule.AllowedServiceAccounts.Add(allowedServiceAccount)
+			rule.AllowedServiceAccounts.Add(allowedServiceIdentity)
 			break
 		}
 	}
 	if !routeExists {
 		in.Rules = append(in.Rules, &Rule{
 			Route:                  route,
-			AllowedServiceAccounts: mapset.NewSet(allowedServiceAccount),
+			AllowedServiceAccounts: mapset.NewSet(allowedServiceIdentity),
 		})
 	}
 }
@@ -38,15 +38,15 @@ var (
 		Weight:      100,
 	}
 
-	testServiceAccount1 = identity.K8sServiceAccount{
+	testServiceIdentity1 = identity.K8sServiceAccount{
 		Name:      "testServiceAccount1",
 		Namespace: "testNamespace1",
-	}
+	}.ToServiceIdentity()
 
-	testServiceAccount2 = identity.K8sServiceAccount{
+	testServiceIdentity2 = identity.K8sServiceAccount{
 		Name:      "testServiceAccount2",
 		Namespace: "testNamespace2",
-	}
+	}.ToServiceIdentity()
 
 	testRoute = RouteWeightedClusters{
 		HTTPRouteMatch:   testHTTPRouteMatch,
@@ -63,21 +63,21 @@ func TestAddRule(t *testing.T) {
 	assert := tassert.New(t)
 
 	testCases := []struct {
-		name                  string
-		existingRules         []*Rule
-		allowedServiceAccount identity.K8sServiceAccount
-		route                 RouteWeightedClusters
-		expectedRules         []*Rule
+		name                   string
+		existingRules          []*Rule
+		allowedServiceIdentity identity.ServiceIdentity
+		route                  RouteWeightedClusters
+		expectedRules          []*Rule
 	}{
 		{
-			name:                  "rule for route does not exist",
-			existingRules:         []*Rule{},
-			allowedServiceAccount: testServiceAccount1,
-			route:                 testRoute,
+			name:                   "rule for route does not exist",
+			existingRules:          []*Rule{},
+			allowedServiceIdentity: testServiceIdentity1,
+			route:                  testRoute,
 			expectedRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 		},
@@ -86,15 +86,32 @@ func TestAddRule(t *testing.T) {
 			existingRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
+				},
+			},
+			allowedServiceIdentity: testServiceIdentity2,
+			route:                  testRoute,
+			expectedRules: []*Rule{
+				{
+					Route:                  testRoute,
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1, testServiceIdentity2),
+				},
+			},
+		},
+		{
+			name: "rule exists for route but not for given identity resolved by another identity provider",
+			existingRules: []*Rule{
+				{
+					Route:                  testRoute,
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
-			allowedServiceAccount: testServiceAccount2,
-			route:                 testRoute,
+			allowedServiceIdentity: identity.ServiceIdentity("spiffe://example.org/vm/billing"),
+			route:                  testRoute,
 			expectedRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1, testServiceAccount2),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1, identity.ServiceIdentity("spiffe://example.org/vm/billing")),
 				},
 			},
 		},
@@ -103,15 +120,15 @@ func TestAddRule(t *testing.T) {
 			existingRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
-			allowedServiceAccount: testServiceAccount1,
-			route:                 testRoute,
+			allowedServiceIdentity: testServiceIdentity1,
+			route:                  testRoute,
 			expectedRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 		},
@@ -120,7 +137,7 @@ func TestAddRule(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			inboundPolicy := newTestInboundPolicy(tc.name, tc.existingRules)
-			inboundPolicy.AddRule(tc.route, tc.allowedServiceAccount)
+			inboundPolicy.AddRule(tc.route, tc.allowedServiceIdentity)
 			assert.Equal(tc.expectedRules, inboundPolicy.Rules)
 		})
 	}
@@ -251,11 +268,11 @@ func TestMergeInboundPolicies(t *testing.T) {
 
 	testRule1 := Rule{
 		Route:                  testRoute,
-		AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+		AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 	}
 	testRule2 := Rule{
 		Route:                  testRoute2,
-		AllowedServiceAccounts: mapset.NewSet(testServiceAccount2),
+		AllowedServiceAccounts: mapset.NewSet(testServiceIdentity2),
 	}
 	testRule1Modified := Rule{
 		Route: RouteWeightedClusters{
@@ -355,11 +372,11 @@ func TestMergeInboundPoliciesWithPartialHostnames(t *testing.T) {
 
 	testRule1 := Rule{
 		Route:                  testRoute,
-		AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+		AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 	}
 	testRule2 := Rule{
 		Route:                  testRoute2,
-		AllowedServiceAccounts: mapset.NewSet(testServiceAccount2),
+		AllowedServiceAccounts: mapset.NewSet(testServiceIdentity2),
 	}
 	testRule1Modified := Rule{
 		Route: RouteWeightedClusters{
@@ -443,19 +460,19 @@ func TestMergeRules(t *testing.T) {
 			originalRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 			newRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount2),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity2),
 				},
 			},
 			expectedRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSetWith(testServiceAccount1, testServiceAccount2),
+					AllowedServiceAccounts: mapset.NewSetWith(testServiceIdentity1, testServiceIdentity2),
 				},
 			},
 		},
@@ -464,19 +481,19 @@ func TestMergeRules(t *testing.T) {
 			originalRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 			newRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 			expectedRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSetWith(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSetWith(testServiceIdentity1),
 				},
 			},
 		},
@@ -485,23 +502,23 @@ func TestMergeRules(t *testing.T) {
 			originalRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 			newRules: []*Rule{
 				{
 					Route:                  testRoute2,
-					AllowedServiceAccounts: mapset.NewSet(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSet(testServiceIdentity1),
 				},
 			},
 			expectedRules: []*Rule{
 				{
 					Route:                  testRoute,
-					AllowedServiceAccounts: mapset.NewSetWith(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSetWith(testServiceIdentity1),
 				},
 				{
 					Route:                  testRoute2,
-					AllowedServiceAccounts: mapset.NewSetWith(testServiceAccount1),
+					AllowedServiceAccounts: mapset.NewSetWith(testServiceIdentity1),
 				},
 			},
 		},
@@ -5,6 +5,7 @@ package trafficpolicy
 import (
 	mapset "github.com/deckarep/golang-set"
 
+	policyV1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 	"github.com/openservicemesh/osm/pkg/identity"
 )
 
@@ -34,6 +35,13 @@ type HTTPRouteMatch struct {
 	PathMatchType PathMatchType     `json:"path_match_type:omitempty"`
 	Methods       []string          `json:"methods:omitempty"`
 	Headers       map[string]string `json:"headers:omitempty"`
+
+	// GRPC restricts the route match to gRPC requests, whose path is of the form /<service>/<method>
+	GRPC bool `json:"grpc,omitempty"`
+
+	// Ports restricts the route match to the requests received on the given target ports of the upstream service.
+	// The route matches the requests received on all the ports of the service when empty.
+	Ports []int `json:"ports,omitempty"`
 }
 
 // TCPRouteMatch is a struct to represent a TCP route matching based on ports
@@ -45,6 +53,20 @@ type TCPRouteMatch struct {
 type RouteWeightedClusters struct {
 	HTTPRouteMatch   HTTPRouteMatch `json:"http_route_match:omitempty"`
 	WeightedClusters mapset.Set     `json:"weighted_clusters:omitempty"`
+
+	// GRPCRetryPolicy is the retry policy applied to the requests matching a gRPC route
+	GRPCRetryPolicy *policyV1alpha1.GRPCRetryPolicySpec `json:"grpc_retry_policy,omitempty"`
+
+	// Maintenance is the response returned to the requests matching an inbound route, instead of forwarding them,
+	// while the upstream service is in maintenance mode
+	Maintenance *policyV1alpha1.MaintenanceSpec `json:"maintenance,omitempty"`
+
+	// RateLimit is the rate limit applied to the requests matching an inbound route, depending on their source identity
+	RateLimit *policyV1alpha1.HTTPLocalRateLimitSpec `json:"rate_limit,omitempty"`
+
+	// XFFOverwrite replaces the x-forwarded-for header of the requests matching an inbound route with the address of
+	// the client, as determined by the upstream sidecar
+	XFFOverwrite bool `json:"xff_overwrite,omitempty"`
 }
 
 // InboundTrafficPolicy is a struct that associates incoming traffic on a set of Hostnames with a list of Rules
@@ -54,7 +76,8 @@ type InboundTrafficPolicy struct {
 	Rules     []*Rule  `json:"rules:omitempty"`
 }
 
-// Rule is a struct that represents which Service Accounts can access a Route
+// Rule is a struct that represents which Service Accounts can access a Route. AllowedServiceAccounts holds the
+// identity.ServiceIdentity of each allowed downstream, the empty identity allowing any downstream.
 type Rule struct {
 	Route                  RouteWeightedClusters `json:"route:omitempty"`
 	AllowedServiceAccounts mapset.Set            `json:"allowed_service_accounts:omitempty"`
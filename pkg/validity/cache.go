@@ -0,0 +1,51 @@
+package validity
+
+// NewCache returns an empty Cache
+func NewCache() *Cache {
+	return &Cache{
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// Get returns the validity window of the policy with the given key and resource version, which is parsed with the given
+// function unless the window of this version of the policy is cached. Policies without a resource version are not cached.
+func (c *Cache) Get(key, resourceVersion string, parse func() (*Window, error)) (*Window, error) {
+	if c == nil || resourceVersion == "" {
+		return parse()
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || entry.resourceVersion != resourceVersion {
+		window, err := parse()
+		entry = &cacheEntry{
+			resourceVersion: resourceVersion,
+			window:          window,
+			err:             err,
+		}
+		c.entries[key] = entry
+	}
+	entry.used = true
+	return entry.window, entry.err
+}
+
+// Sweep removes the windows of the policies that were not requested since the previous call to Sweep, ex. the windows
+// of deleted policies
+func (c *Cache) Sweep() {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, entry := range c.entries {
+		if !entry.used {
+			delete(c.entries, key)
+			continue
+		}
+		entry.used = false
+	}
+}
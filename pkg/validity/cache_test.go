@@ -0,0 +1,61 @@
+package validity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	assert := tassert.New(t)
+
+	parsed := 0
+	window := &Window{NotAfter: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)}
+	parse := func() (*Window, error) {
+		parsed++
+		return window, nil
+	}
+	errInvalid := errors.New("invalid")
+	parseInvalid := func() (*Window, error) {
+		parsed++
+		return nil, errInvalid
+	}
+
+	cache := NewCache()
+
+	// The window of a version of a policy is parsed once
+	actual, err := cache.Get("Egress/test/foo", "1", parse)
+	assert.Nil(err)
+	assert.Equal(window, actual)
+	_, _ = cache.Get("Egress/test/foo", "1", parse)
+	assert.Equal(1, parsed)
+
+	// The window is parsed again when the policy is updated, and errors are cached as well
+	_, err = cache.Get("Egress/test/foo", "2", parseInvalid)
+	assert.Equal(errInvalid, err)
+	_, err = cache.Get("Egress/test/foo", "2", parseInvalid)
+	assert.Equal(errInvalid, err)
+	assert.Equal(2, parsed)
+
+	// Policies without a resource version are not cached
+	_, _ = cache.Get("Egress/test/bar", "", parse)
+	_, _ = cache.Get("Egress/test/bar", "", parse)
+	assert.Equal(4, parsed)
+
+	// The windows that were not requested since the previous sweep are removed
+	_, _ = cache.Get("TrafficTarget/test/baz", "1", parse)
+	cache.Sweep()
+	_, _ = cache.Get("Egress/test/foo", "2", parseInvalid)
+	cache.Sweep()
+	assert.Len(cache.entries, 1)
+	assert.Contains(cache.entries, "Egress/test/foo")
+	assert.Equal(5, parsed)
+
+	// A nil cache parses the window on every call
+	var nilCache *Cache
+	_, _ = nilCache.Get("Egress/test/foo", "2", parse)
+	nilCache.Sweep()
+	assert.Equal(6, parsed)
+}
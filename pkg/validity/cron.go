@@ -0,0 +1,165 @@
+package validity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// cronField describes a field of a cron expression
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ParseSchedule parses a cron expression of the form '<minute> <hour> <day of month> <month> <day of week>'.
+// Each field is '*', a value, a range '<start>-<end>', or a comma separated list of those, optionally followed by
+// a step '/<step>'. Days of week range from 0 to 7, where both 0 and 7 are Sunday.
+func ParseSchedule(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("Invalid schedule %q, expected %d fields but got %d", expr, len(cronFields), len(fields))
+	}
+
+	values := make([][]bool, len(fields))
+	for i, field := range fields {
+		var err error
+		if values[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, errors.Wrapf(err, "Invalid schedule %q", expr)
+		}
+	}
+
+	// Sunday is both 0 and 7
+	daysOfWeek := values[4]
+	daysOfWeek[0] = daysOfWeek[0] || daysOfWeek[7]
+
+	return &Schedule{
+		minutes:               values[0],
+		hours:                 values[1],
+		latestMinutes:         latestValues(values[0]),
+		latestHours:           latestValues(values[1]),
+		daysOfMonth:           values[2],
+		months:                values[3],
+		daysOfWeek:            daysOfWeek[:7],
+		daysOfMonthRestricted: !strings.HasPrefix(fields[2], "*"),
+		daysOfWeekRestricted:  !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField returns the values of the given field of a cron expression, indexed by value
+func parseCronField(field string, desc cronField) ([]bool, error) {
+	values := make([]bool, desc.max+1)
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+				return nil, errors.Errorf("invalid step in %s field %q", desc.name, field)
+			}
+			part = part[:i]
+		}
+
+		start, end := desc.min, desc.max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return nil, errors.Errorf("invalid value in %s field %q", desc.name, field)
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return nil, errors.Errorf("invalid value in %s field %q", desc.name, field)
+				}
+			}
+		}
+		if start < desc.min || end > desc.max || start > end {
+			return nil, errors.Errorf("%s field %q is out of range [%d, %d]", desc.name, field, desc.min, desc.max)
+		}
+
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+// latestValues returns, for each value of a field, the greatest value lower than or equal to it in the given values of
+// the field indexed by value, or -1 if there is none
+func latestValues(values []bool) []int {
+	latest := make([]int, len(values))
+	prev := -1
+	for value, ok := range values {
+		if ok {
+			prev = value
+		}
+		latest[value] = prev
+	}
+	return latest
+}
+
+// Matches returns a boolean indicating if the minute of the given time matches the schedule
+func (s *Schedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	return s.minutes[t.Minute()] && s.hours[t.Hour()] && s.matchesDay(t)
+}
+
+// matchesDay returns a boolean indicating if the day of the given time, in UTC, matches the schedule
+func (s *Schedule) matchesDay(t time.Time) bool {
+	if !s.months[t.Month()] {
+		return false
+	}
+	dayOfMonth, dayOfWeek := s.daysOfMonth[t.Day()], s.daysOfWeek[t.Weekday()]
+	if s.daysOfMonthRestricted && s.daysOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// latestMatch returns the latest minute matching the schedule at or before the given time, on the day of the given time
+// or the day before in UTC. It returns false if no minute of this range matches the schedule.
+func (s *Schedule) latestMatch(t time.Time) (time.Time, bool) {
+	t = t.UTC()
+	year, month, day := t.Date()
+	for daysBefore := 0; daysBefore <= 1; daysBefore++ {
+		date := time.Date(year, month, day-daysBefore, 0, 0, 0, 0, time.UTC)
+		if !s.matchesDay(date) {
+			continue
+		}
+
+		// The latest minute of the day, or of the part of the day up to the given time
+		hour, minute := 23, 59
+		if daysBefore == 0 {
+			hour, minute = t.Hour(), t.Minute()
+		}
+
+		matchedHour := s.latestHours[hour]
+		if matchedHour == hour {
+			if matchedMinute := s.latestMinutes[minute]; matchedMinute >= 0 {
+				return date.Add(time.Duration(matchedHour)*time.Hour + time.Duration(matchedMinute)*time.Minute), true
+			}
+			// No matching minute in the current hour, the latest match is in an earlier hour
+			matchedHour = -1
+			if hour > 0 {
+				matchedHour = s.latestHours[hour-1]
+			}
+		}
+		if matchedHour >= 0 {
+			return date.Add(time.Duration(matchedHour)*time.Hour + time.Duration(s.latestMinutes[59])*time.Minute), true
+		}
+	}
+	return time.Time{}, false
+}
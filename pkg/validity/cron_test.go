@@ -0,0 +1,164 @@
+package validity
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expr        string
+		matches     []time.Time
+		noMatches   []time.Time
+		expectError bool
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			matches: []time.Time{
+				time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 6, 1, 13, 37, 30, 0, time.UTC),
+			},
+		},
+		{
+			name: "Sunday at 2AM, with Sunday as 7",
+			expr: "0 2 * * 7",
+			matches: []time.Time{
+				time.Date(2021, 6, 6, 2, 0, 59, 0, time.UTC), // Sunday
+			},
+			noMatches: []time.Time{
+				time.Date(2021, 6, 6, 2, 1, 0, 0, time.UTC),
+				time.Date(2021, 6, 7, 2, 0, 0, 0, time.UTC), // Monday
+			},
+		},
+		{
+			name: "ranges, lists and steps",
+			expr: "*/15 9-17 * 1,6 1-5",
+			matches: []time.Time{
+				time.Date(2021, 6, 1, 9, 45, 0, 0, time.UTC),  // Tuesday
+				time.Date(2021, 1, 29, 17, 0, 0, 0, time.UTC), // Friday
+			},
+			noMatches: []time.Time{
+				time.Date(2021, 6, 1, 9, 50, 0, 0, time.UTC),
+				time.Date(2021, 6, 1, 18, 0, 0, 0, time.UTC),
+				time.Date(2021, 7, 1, 9, 45, 0, 0, time.UTC),
+				time.Date(2021, 6, 5, 9, 45, 0, 0, time.UTC), // Saturday
+			},
+		},
+		{
+			name: "day of month or day of week when both are restricted",
+			expr: "0 0 1 * 1",
+			matches: []time.Time{
+				time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), // first of the month, Tuesday
+				time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC), // Monday
+			},
+			noMatches: []time.Time{
+				time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "times are evaluated in UTC",
+			expr: "0 2 * * *",
+			matches: []time.Time{
+				time.Date(2021, 6, 1, 4, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			},
+		},
+		{
+			name:        "missing fields",
+			expr:        "0 2 * *",
+			expectError: true,
+		},
+		{
+			name:        "value out of range",
+			expr:        "60 * * * *",
+			expectError: true,
+		},
+		{
+			name:        "invalid step",
+			expr:        "*/0 * * * *",
+			expectError: true,
+		},
+		{
+			name:        "invalid range",
+			expr:        "* 10-2 * * *",
+			expectError: true,
+		},
+		{
+			name:        "named day of week",
+			expr:        "* * * * SUN",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			schedule, err := ParseSchedule(tc.expr)
+			assert.Equal(tc.expectError, err != nil)
+			if tc.expectError {
+				return
+			}
+			for _, match := range tc.matches {
+				assert.True(schedule.Matches(match), "expected %s to match", match)
+			}
+			for _, noMatch := range tc.noMatches {
+				assert.False(schedule.Matches(noMatch), "expected %s not to match", noMatch)
+			}
+		})
+	}
+}
+
+func TestScheduleLatestMatch(t *testing.T) {
+	// The latest match is compared with the latest minute matching the schedule found by scanning the minutes of
+	// the day of the given time and the day before
+	bruteForce := func(schedule *Schedule, now time.Time) (time.Time, bool) {
+		year, month, day := now.UTC().Date()
+		dayBefore := time.Date(year, month, day-1, 0, 0, 0, 0, time.UTC)
+		for start := now.UTC().Truncate(time.Minute); !start.Before(dayBefore); start = start.Add(-time.Minute) {
+			if schedule.Matches(start) {
+				return start, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	exprs := []string{
+		"* * * * *",
+		"0 2 * * 0",
+		"*/15 9-17 * 1,6 1-5",
+		"0 0 1 * 1",
+		"30 22 * * *",
+		"45 0,12 * * *",
+		"59 23 31 12 *",
+	}
+	times := []time.Time{
+		time.Date(2021, 6, 6, 2, 0, 0, 0, time.UTC),
+		time.Date(2021, 6, 6, 1, 59, 59, 0, time.UTC),
+		time.Date(2021, 6, 7, 0, 10, 0, 0, time.UTC),
+		time.Date(2021, 6, 1, 9, 44, 0, 0, time.UTC),
+		time.Date(2021, 6, 1, 12, 44, 0, 0, time.UTC),
+		time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 1, 0, 30, 0, 0, time.UTC),
+		time.Date(2021, 3, 1, 0, 0, 30, 0, time.UTC),
+		time.Date(2021, 6, 6, 3, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+	}
+
+	for _, expr := range exprs {
+		t.Run(expr, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			schedule, err := ParseSchedule(expr)
+			assert.Nil(err)
+			for _, now := range times {
+				expected, expectedOk := bruteForce(schedule, now)
+				actual, ok := schedule.latestMatch(now)
+				assert.Equal(expectedOk, ok, "at %s", now)
+				assert.True(expected.Equal(actual), "at %s: expected %s, got %s", now, expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Package validity implements the validity windows of policies, which restrict the time during which a policy is
+// enforced. A window is bounded by optional start and expiry times, and can be further restricted to recurring
+// periods starting on a cron schedule, ex. a maintenance window every Sunday at 2AM for 2 hours.
+package validity
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// MaxScheduleDuration is the maximum duration of the periods started by a schedule
+	MaxScheduleDuration = 24 * time.Hour
+)
+
+// Window is the validity window of a policy. A nil window is always active.
+type Window struct {
+	// NotBefore is the time from which the window is active, or the zero time if the window has no start
+	NotBefore time.Time
+
+	// NotAfter is the time from which the window is no longer active, or the zero time if the window does not expire
+	NotAfter time.Time
+
+	// Schedule is the schedule starting the recurring periods during which the window is active, or nil if
+	// the window is active for its whole lifetime
+	Schedule *Schedule
+
+	// Duration is the duration of the periods started by Schedule
+	Duration time.Duration
+}
+
+// Schedule is a cron schedule, matching the times whose minute, hour, day of month, month and day of week
+// are in the sets of values of the corresponding fields of the schedule. Times are evaluated in UTC.
+type Schedule struct {
+	minutes     []bool
+	hours       []bool
+	daysOfMonth []bool
+	months      []bool
+	daysOfWeek  []bool
+
+	// latestMinutes and latestHours are the greatest minute and hour of the schedule lower than or equal to each minute
+	// and hour, or -1 if there is none
+	latestMinutes []int
+	latestHours   []int
+
+	// daysOfMonthRestricted and daysOfWeekRestricted record whether the day of month and day of week fields do not
+	// start with '*', since a time matches either restricted field when both are
+	daysOfMonthRestricted bool
+	daysOfWeekRestricted  bool
+}
+
+// Cache caches the validity windows of policies, so that the window of a policy is only parsed again when the policy
+// is updated. A nil Cache does not cache the windows.
+type Cache struct {
+	mutex   sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry is the validity window of a given version of a policy
+type cacheEntry struct {
+	resourceVersion string
+	window          *Window
+	err             error
+
+	// used is set when the entry is returned, and reset when the unused entries are removed by Sweep
+	used bool
+}
@@ -0,0 +1,98 @@
+package validity
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// NewWindow returns the validity window bounded by the given optional start and expiry times, and restricted to the
+// periods of the given duration started by the given cron schedule when the schedule is not empty.
+// It returns nil if the window is unbounded and unrestricted.
+func NewWindow(notBefore, notAfter *time.Time, schedule string, duration time.Duration) (*Window, error) {
+	if notBefore == nil && notAfter == nil && schedule == "" {
+		return nil, nil
+	}
+
+	window := &Window{}
+	if notBefore != nil {
+		window.NotBefore = *notBefore
+	}
+	if notAfter != nil {
+		window.NotAfter = *notAfter
+	}
+	if notBefore != nil && notAfter != nil && !notAfter.After(*notBefore) {
+		return nil, errors.Errorf("Invalid validity window, expiry time %s must be after start time %s", notAfter.Format(time.RFC3339), notBefore.Format(time.RFC3339))
+	}
+
+	if schedule == "" {
+		return window, nil
+	}
+	if duration <= 0 || duration > MaxScheduleDuration {
+		return nil, errors.Errorf("Invalid validity window, the duration of a schedule must be greater than 0 and at most %s", MaxScheduleDuration)
+	}
+	var err error
+	if window.Schedule, err = ParseSchedule(schedule); err != nil {
+		return nil, err
+	}
+	window.Duration = duration
+
+	return window, nil
+}
+
+// FromAnnotations returns the validity window specified by the given annotations of a policy, or nil if they do not
+// specify one
+func FromAnnotations(annotations map[string]string) (*Window, error) {
+	var notBefore, notAfter *time.Time
+	for _, bound := range []struct {
+		annotation string
+		value      **time.Time
+	}{
+		{annotation: constants.PolicyNotBeforeAnnotation, value: &notBefore},
+		{annotation: constants.PolicyNotAfterAnnotation, value: &notAfter},
+	} {
+		value, ok := annotations[bound.annotation]
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.Errorf("Invalid value %q for annotation %s, expected an RFC3339 time", value, bound.annotation)
+		}
+		*bound.value = &t
+	}
+
+	schedule := annotations[constants.PolicyScheduleAnnotation]
+	var duration time.Duration
+	if value, ok := annotations[constants.PolicyScheduleDurationAnnotation]; ok {
+		var err error
+		if duration, err = time.ParseDuration(value); err != nil {
+			return nil, errors.Errorf("Invalid value %q for annotation %s, expected a duration", value, constants.PolicyScheduleDurationAnnotation)
+		}
+	}
+
+	return NewWindow(notBefore, notAfter, schedule, duration)
+}
+
+// IsActive returns a boolean indicating if the window is active at the given time. A nil window is always active.
+func (w *Window) IsActive(now time.Time) bool {
+	if w == nil {
+		return true
+	}
+	if !w.NotBefore.IsZero() && now.Before(w.NotBefore) {
+		return false
+	}
+	if !w.NotAfter.IsZero() && !now.Before(w.NotAfter) {
+		return false
+	}
+	if w.Schedule == nil {
+		return true
+	}
+
+	// The window is active if the schedule started a period within the duration preceding the given time. Since the
+	// duration is at most MaxScheduleDuration, the period started on the day of the given time or the day before.
+	start, ok := w.Schedule.latestMatch(now)
+	return ok && now.Sub(start) < w.Duration
+}
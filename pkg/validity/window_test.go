@@ -0,0 +1,103 @@
+package validity
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestWindowIsActive(t *testing.T) {
+	notBefore := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		active      []time.Time
+		inactive    []time.Time
+		expectNil   bool
+		expectError bool
+	}{
+		{
+			name:        "no validity window",
+			annotations: map[string]string{"foo": "bar"},
+			expectNil:   true,
+		},
+		{
+			name: "start and expiry times",
+			annotations: map[string]string{
+				constants.PolicyNotBeforeAnnotation: notBefore.Format(time.RFC3339),
+				constants.PolicyNotAfterAnnotation:  notAfter.Format(time.RFC3339),
+			},
+			active:   []time.Time{notBefore, notAfter.Add(-time.Second)},
+			inactive: []time.Time{notBefore.Add(-time.Second), notAfter},
+		},
+		{
+			name: "schedule within start and expiry times",
+			annotations: map[string]string{
+				constants.PolicyNotBeforeAnnotation:        notBefore.Format(time.RFC3339),
+				constants.PolicyNotAfterAnnotation:         notAfter.Format(time.RFC3339),
+				constants.PolicyScheduleAnnotation:         "0 22 * * *",
+				constants.PolicyScheduleDurationAnnotation: "3h",
+			},
+			active: []time.Time{
+				time.Date(2021, 6, 10, 22, 0, 0, 0, time.UTC),
+				time.Date(2021, 6, 11, 0, 59, 59, 0, time.UTC), // period spanning midnight
+			},
+			inactive: []time.Time{
+				time.Date(2021, 6, 10, 21, 59, 0, 0, time.UTC),
+				time.Date(2021, 6, 11, 1, 0, 0, 0, time.UTC),
+				time.Date(2021, 5, 31, 22, 30, 0, 0, time.UTC), // before the start time
+			},
+		},
+		{
+			name: "invalid time",
+			annotations: map[string]string{
+				constants.PolicyNotAfterAnnotation: "tomorrow",
+			},
+			expectError: true,
+		},
+		{
+			name: "expiry time before start time",
+			annotations: map[string]string{
+				constants.PolicyNotBeforeAnnotation: notAfter.Format(time.RFC3339),
+				constants.PolicyNotAfterAnnotation:  notBefore.Format(time.RFC3339),
+			},
+			expectError: true,
+		},
+		{
+			name: "schedule without duration",
+			annotations: map[string]string{
+				constants.PolicyScheduleAnnotation: "0 22 * * *",
+			},
+			expectError: true,
+		},
+		{
+			name: "schedule duration too long",
+			annotations: map[string]string{
+				constants.PolicyScheduleAnnotation:         "0 22 * * *",
+				constants.PolicyScheduleDurationAnnotation: "25h",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			window, err := FromAnnotations(tc.annotations)
+			assert.Equal(tc.expectError, err != nil)
+			assert.Equal(tc.expectNil || tc.expectError, window == nil)
+			for _, active := range tc.active {
+				assert.True(window.IsActive(active), "expected window to be active at %s", active)
+			}
+			for _, inactive := range tc.inactive {
+				assert.False(window.IsActive(inactive), "expected window to be inactive at %s", inactive)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+const (
+	// certRotationCheckInterval is the interval at which the expiration of the certificate served by a webhook server is checked
+	certRotationCheckInterval = 1 * time.Minute
+
+	// caBundlePropagationDelay is the time given to the API server to observe the CA bundle trusting a renewed
+	// certificate, before the webhook server starts serving it
+	caBundlePropagationDelay = 1 * time.Minute
+)
+
+// CertRotator rotates the certificate served by a webhook server before it expires. The CA bundle of the webhook
+// configuration is patched to trust both the current and the renewed certificates before the renewed certificate is
+// served, so that the API server can reach the webhook server throughout the rotation.
+type CertRotator struct {
+	certManager    certificate.Manager
+	commonName     certificate.CommonName
+	validityPeriod time.Duration
+
+	// patchCABundle patches the CA bundle of the webhook configuration with the given PEM encoded certificates
+	patchCABundle func(caBundle []byte) error
+
+	// syncRenewedCertificate, when set, synchronizes the renewed certificate with the other replicas of the webhook
+	// server and returns the certificate they must all serve
+	syncRenewedCertificate func(renewed, current certificate.Certificater) (certificate.Certificater, error)
+
+	cert           certificate.Certificater
+	renewed        certificate.Certificater
+	serveRenewedAt time.Time
+	tlsCertMutex   sync.RWMutex
+	tlsCert        *tls.Certificate
+}
+
+// NewCertRotator returns a CertRotator serving the given certificate, which is renewed with the given certificate
+// manager for the given common name and validity period. The optional syncRenewedCertificate function synchronizes
+// a renewed certificate with the other replicas of the webhook server.
+func NewCertRotator(certManager certificate.Manager, cn certificate.CommonName, cert certificate.Certificater, validityPeriod time.Duration,
+	patchCABundle func(caBundle []byte) error,
+	syncRenewedCertificate func(renewed, current certificate.Certificater) (certificate.Certificater, error)) (*CertRotator, error) {
+	tlsCert, err := tls.X509KeyPair(cert.GetCertificateChain(), cert.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Errorf("Error parsing webhook certificate: %s", err)
+	}
+
+	return &CertRotator{
+		certManager:            certManager,
+		commonName:             cn,
+		validityPeriod:         validityPeriod,
+		patchCABundle:          patchCABundle,
+		syncRenewedCertificate: syncRenewedCertificate,
+		cert:                   cert,
+		tlsCert:                &tlsCert,
+	}, nil
+}
+
+// GetCertificate returns the certificate currently served by the webhook server, and is meant to be used as the
+// GetCertificate callback of the server's TLS config
+func (r *CertRotator) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.tlsCertMutex.RLock()
+	defer r.tlsCertMutex.RUnlock()
+	return r.tlsCert, nil
+}
+
+// Run periodically rotates the certificate served by the webhook server until the given stop channel is closed
+func (r *CertRotator) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(certRotationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			r.rotate(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// rotate renews the served certificate when it nears its expiration, and serves the renewed certificate once the
+// CA bundle trusting it had the time to propagate
+func (r *CertRotator) rotate(now time.Time) {
+	if r.renewed != nil {
+		if now.Before(r.serveRenewedAt) {
+			return
+		}
+		if err := r.serve(r.renewed); err != nil {
+			log.Error().Err(err).Msgf("Error serving renewed webhook certificate for CN=%s", r.commonName)
+			r.renewed = nil
+			return
+		}
+		log.Info().Msgf("Serving renewed webhook certificate for CN=%s expiring on %s", r.commonName, r.cert.GetExpiration())
+		r.renewed = nil
+		return
+	}
+
+	if !shouldRenew(r.cert, now) {
+		return
+	}
+
+	cn := r.commonName
+	// The certificate manager returns its cached certificate for the CN until it is released
+	r.certManager.ReleaseCertificate(cn)
+	renewed, err := r.certManager.IssueCertificate(cn, r.validityPeriod)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error renewing webhook certificate for CN=%s, will retry", cn)
+		return
+	}
+	if r.syncRenewedCertificate != nil {
+		if renewed, err = r.syncRenewedCertificate(renewed, r.cert); err != nil {
+			log.Error().Err(err).Msgf("Error synchronizing renewed webhook certificate for CN=%s, will retry", cn)
+			return
+		}
+	}
+
+	// The current certificate remains trusted until the renewed certificate is served
+	caBundle := append(append([]byte{}, renewed.GetCertificateChain()...), r.cert.GetCertificateChain()...)
+	if err := r.patchCABundle(caBundle); err != nil {
+		log.Error().Err(err).Msgf("Error patching the CA bundle of the webhook with the renewed certificate for CN=%s, will retry", cn)
+		return
+	}
+
+	log.Info().Msgf("Renewed webhook certificate for CN=%s expiring on %s, serving it in %s", cn, renewed.GetExpiration(), caBundlePropagationDelay)
+	r.renewed = renewed
+	r.serveRenewedAt = now.Add(caBundlePropagationDelay)
+}
+
+func (r *CertRotator) serve(cert certificate.Certificater) error {
+	tlsCert, err := tls.X509KeyPair(cert.GetCertificateChain(), cert.GetPrivateKey())
+	if err != nil {
+		return err
+	}
+
+	r.tlsCertMutex.Lock()
+	defer r.tlsCertMutex.Unlock()
+	r.cert = cert
+	r.tlsCert = &tlsCert
+	return nil
+}
+
+// shouldRenew returns whether less than a third of the validity period of the given certificate remains at the given time
+func shouldRenew(cert certificate.Certificater, now time.Time) bool {
+	x509Cert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error decoding webhook certificate with SerialNumber=%s", cert.GetSerialNumber())
+		return false
+	}
+
+	validityPeriod := x509Cert.NotAfter.Sub(x509Cert.NotBefore)
+	return x509Cert.NotAfter.Sub(now) <= validityPeriod/3
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+const testWebhookCN = certificate.CommonName("osm-injector.osm-system.svc")
+
+func newTestCertificate(t *testing.T, mockCtrl *gomock.Controller, notBefore, notAfter time.Time) *certificate.MockCertificater {
+	certPEM, keyPEM, err := tests.NewSelfSignedPEMCertificate(testWebhookCN.String(), notBefore, notAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := certificate.NewMockCertificater(mockCtrl)
+	cert.EXPECT().GetCertificateChain().Return([]byte(certPEM)).AnyTimes()
+	cert.EXPECT().GetPrivateKey().Return([]byte(keyPEM)).AnyTimes()
+	cert.EXPECT().GetExpiration().Return(notAfter).AnyTimes()
+	cert.EXPECT().GetSerialNumber().Return(certificate.SerialNumber("1")).AnyTimes()
+	return cert
+}
+
+// isServing returns whether the given rotator serves the given certificate
+func isServing(t *testing.T, rotator *CertRotator, cert certificate.Certificater) bool {
+	tlsCert, err := rotator.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x509Cert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Equal(x509Cert.Raw, tlsCert.Certificate[0])
+}
+
+func TestCertRotator(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	certManager := certificate.NewMockManager(mockCtrl)
+
+	issuedAt := time.Now()
+	current := newTestCertificate(t, mockCtrl, issuedAt, issuedAt.Add(3*time.Hour))
+	renewed := newTestCertificate(t, mockCtrl, issuedAt.Add(2*time.Hour), issuedAt.Add(5*time.Hour))
+
+	var caBundles [][]byte
+	patchErr := errors.New("error patching CA bundle")
+	rotator, err := NewCertRotator(certManager, testWebhookCN, current, 3*time.Hour, func(caBundle []byte) error {
+		caBundles = append(caBundles, caBundle)
+		return patchErr
+	}, nil)
+	assert.Nil(err)
+	assert.True(isServing(t, rotator, current))
+
+	// The certificate is not renewed while more than a third of its validity period remains
+	rotator.rotate(issuedAt.Add(time.Hour))
+	assert.True(isServing(t, rotator, current))
+	assert.Empty(caBundles)
+
+	// The renewed certificate is not served when the CA bundle cannot be patched to trust it, and is renewed again later
+	certManager.EXPECT().ReleaseCertificate(testWebhookCN).Times(3)
+	certManager.EXPECT().IssueCertificate(testWebhookCN, 3*time.Hour).Return(renewed, nil).Times(3)
+	renewAt := issuedAt.Add(2 * time.Hour)
+	rotator.rotate(renewAt)
+	assert.Len(caBundles, 1)
+	rotator.rotate(renewAt.Add(caBundlePropagationDelay))
+	assert.Len(caBundles, 2)
+	assert.True(isServing(t, rotator, current))
+
+	// The CA bundle trusts both certificates before the renewed certificate is served
+	patchErr = nil
+	rotator.rotate(renewAt)
+	assert.Len(caBundles, 3)
+	assert.Equal(append(append([]byte{}, renewed.GetCertificateChain()...), current.GetCertificateChain()...), caBundles[2])
+	assert.True(isServing(t, rotator, current))
+
+	rotator.rotate(renewAt.Add(caBundlePropagationDelay / 2))
+	assert.True(isServing(t, rotator, current))
+
+	rotator.rotate(renewAt.Add(caBundlePropagationDelay))
+	assert.True(isServing(t, rotator, renewed))
+}
+
+func TestCertRotatorSyncRenewedCertificate(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	certManager := certificate.NewMockManager(mockCtrl)
+
+	issuedAt := time.Now()
+	current := newTestCertificate(t, mockCtrl, issuedAt, issuedAt.Add(3*time.Hour))
+	renewed := newTestCertificate(t, mockCtrl, issuedAt.Add(2*time.Hour), issuedAt.Add(5*time.Hour))
+	renewedByOtherReplica := newTestCertificate(t, mockCtrl, issuedAt.Add(2*time.Hour), issuedAt.Add(5*time.Hour))
+
+	certManager.EXPECT().ReleaseCertificate(testWebhookCN).Times(1)
+	certManager.EXPECT().IssueCertificate(testWebhookCN, 3*time.Hour).Return(renewed, nil).Times(1)
+
+	rotator, err := NewCertRotator(certManager, testWebhookCN, current, 3*time.Hour, func(caBundle []byte) error {
+		return nil
+	}, func(r, c certificate.Certificater) (certificate.Certificater, error) {
+		assert.Equal(renewed, r)
+		assert.Equal(current, c)
+		return renewedByOtherReplica, nil
+	})
+	assert.Nil(err)
+
+	// The certificate returned by the synchronization is served
+	renewAt := issuedAt.Add(2 * time.Hour)
+	rotator.rotate(renewAt)
+	rotator.rotate(renewAt.Add(caBundlePropagationDelay))
+	assert.True(isServing(t, rotator, renewedByOtherReplica))
+}